@@ -0,0 +1,55 @@
+package audit
+
+import (
+	"time"
+)
+
+const (
+	logFieldSink = "sink"
+
+	sinkFile    = "file"
+	sinkSyslog  = "syslog"
+	sinkWebhook = "webhook"
+)
+
+const (
+	webhookRetryBackoff = time.Second
+)
+
+const (
+	megabyte = 1024 * 1024
+
+	fileMode = 0600
+)
+
+const (
+	syslogVersion       = 1
+	syslogSeverityInfo  = 6
+	syslogMessageID     = "authz"
+	syslogNilValue      = "-"
+	syslogNetworkStream = "unix"
+	syslogNetworkPacket = "unixgram"
+)
+
+var syslogFacilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
@@ -0,0 +1,129 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewFileSink returns a new *FileSink which writes one record per line to a file and rotates it when it exceeds the
+// configured size.
+func NewFileSink(config *schema.AuditLogFile) *FileSink {
+	return &FileSink{
+		path:       config.Path,
+		maxSize:    int64(config.MaxSize) * megabyte,
+		maxBackups: config.MaxBackups,
+	}
+}
+
+// FileSink is a Sink which writes to a file with size based rotation. Rotated files have the suffix '.1' through to the
+// maximum number of backups with '.1' being the most recent.
+type FileSink struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// Open the file for appending.
+func (s *FileSink) Open() (err error) {
+	s.mu.Lock()
+
+	defer s.mu.Unlock()
+
+	return s.open()
+}
+
+// Write a record to the file, rotating it first if the record would cause it to exceed the maximum size.
+func (s *FileSink) Write(record []byte) (err error) {
+	s.mu.Lock()
+
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		if err = s.open(); err != nil {
+			return err
+		}
+	}
+
+	n := int64(len(record) + 1)
+
+	if s.size > 0 && s.size+n > s.maxSize {
+		if err = s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	written, err := s.file.Write(append(record, '\n'))
+
+	s.size += int64(written)
+
+	return err
+}
+
+// Close the file.
+func (s *FileSink) Close() (err error) {
+	s.mu.Lock()
+
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+
+	err = s.file.Close()
+
+	s.file = nil
+
+	return err
+}
+
+func (s *FileSink) open() (err error) {
+	if s.file, err = os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, fileMode); err != nil {
+		return fmt.Errorf("error occurred opening the audit log file '%s': %w", s.path, err)
+	}
+
+	var info os.FileInfo
+
+	if info, err = s.file.Stat(); err != nil {
+		return fmt.Errorf("error occurred inspecting the audit log file '%s': %w", s.path, err)
+	}
+
+	s.size = info.Size()
+
+	return nil
+}
+
+func (s *FileSink) rotate() (err error) {
+	if err = s.file.Close(); err != nil {
+		return fmt.Errorf("error occurred closing the audit log file '%s' for rotation: %w", s.path, err)
+	}
+
+	s.file = nil
+
+	if err = os.Remove(s.backup(s.maxBackups)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error occurred removing the oldest audit log file: %w", err)
+	}
+
+	for i := s.maxBackups - 1; i > 0; i-- {
+		if err = os.Rename(s.backup(i), s.backup(i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("error occurred rotating the audit log file '%s': %w", s.backup(i), err)
+		}
+	}
+
+	if err = os.Rename(s.path, s.backup(1)); err != nil {
+		return fmt.Errorf("error occurred rotating the audit log file '%s': %w", s.path, err)
+	}
+
+	return s.open()
+}
+
+func (s *FileSink) backup(i int) string {
+	return fmt.Sprintf("%s.%d", s.path, i)
+}
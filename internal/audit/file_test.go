@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSinkShouldRotate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	sink := &FileSink{path: path, maxSize: 9, maxBackups: 2}
+
+	require.NoError(t, sink.Open())
+
+	for _, record := range []string{"one", "two", "three", "four", "five"} {
+		require.NoError(t, sink.Write([]byte(record)))
+	}
+
+	require.NoError(t, sink.Close())
+
+	for name, expected := range map[string]string{
+		path:        "five\n",
+		path + ".1": "four\n",
+		path + ".2": "three\n",
+	} {
+		data, err := os.ReadFile(name)
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(data))
+	}
+
+	_, err := os.Stat(path + ".3")
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestFileSinkShouldAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	require.NoError(t, os.WriteFile(path, []byte("existing\n"), 0600))
+
+	sink := &FileSink{path: path, maxSize: megabyte, maxBackups: 1}
+
+	require.NoError(t, sink.Write([]byte("record")))
+	require.NoError(t, sink.Close())
+	require.NoError(t, sink.Close())
+
+	data, err := os.ReadFile(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, "existing\nrecord\n", string(data))
+}
@@ -0,0 +1,106 @@
+package audit
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+)
+
+// NewProvider instantiates an audit Provider given a configuration. It returns nil if no sinks are configured.
+func NewProvider(config *schema.AuditLog, trusted *x509.CertPool) *Provider {
+	provider := &Provider{
+		log: logging.Logger(),
+	}
+
+	if config.File != nil {
+		provider.sinks = append(provider.sinks, NewFileSink(config.File))
+	}
+
+	if config.Syslog != nil {
+		provider.sinks = append(provider.sinks, NewSyslogSink(config.Syslog))
+	}
+
+	if config.Webhook != nil {
+		provider.sinks = append(provider.sinks, NewWebhookSink(config.Webhook, trusted))
+	}
+
+	if len(provider.sinks) == 0 {
+		return nil
+	}
+
+	return provider
+}
+
+// Provider writes audit records to each of the configured sinks.
+type Provider struct {
+	sinks []Sink
+
+	log *logrus.Logger
+}
+
+// StartupCheck implements the startup check provider interface.
+func (p *Provider) StartupCheck() (err error) {
+	for _, sink := range p.sinks {
+		if err = sink.Open(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RecordAuthz writes an AuthzRecord to each of the sinks. Errors are logged rather than returned so that auditing can't
+// affect the authorization decision.
+func (p *Provider) RecordAuthz(record *AuthzRecord) {
+	if p == nil {
+		return
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		p.log.WithError(err).Error("Error occurred encoding the authorization audit record")
+
+		return
+	}
+
+	for _, sink := range p.sinks {
+		if err = sink.Write(data); err != nil {
+			p.log.WithError(err).WithField(logFieldSink, sinkName(sink)).Error("Error occurred writing the authorization audit record")
+		}
+	}
+}
+
+// Close each of the sinks.
+func (p *Provider) Close() (err error) {
+	if p == nil {
+		return nil
+	}
+
+	var errs []error
+
+	for _, sink := range p.sinks {
+		if err = sink.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func sinkName(sink Sink) string {
+	switch sink.(type) {
+	case *FileSink:
+		return sinkFile
+	case *SyslogSink:
+		return sinkSyslog
+	case *WebhookSink:
+		return sinkWebhook
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,81 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestNewProviderShouldReturnNilWithoutSinks(t *testing.T) {
+	provider := NewProvider(&schema.AuditLog{}, nil)
+
+	assert.Nil(t, provider)
+
+	provider.RecordAuthz(&AuthzRecord{})
+
+	assert.NoError(t, provider.Close())
+}
+
+func TestProviderRecordAuthz(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	provider := NewProvider(&schema.AuditLog{File: &schema.AuditLogFile{Path: path, MaxSize: 1, MaxBackups: 1}}, nil)
+
+	require.NotNil(t, provider)
+	require.NoError(t, provider.StartupCheck())
+
+	provider.RecordAuthz(&AuthzRecord{
+		Time: time.Unix(1700000000, 0).UTC(),
+		Subject: AuthzSubject{
+			Username: "john",
+			Groups:   []string{"admins", "dev"},
+			IP:       "192.168.1.10",
+		},
+		Object: AuthzObject{
+			URL:    "https://admin.example.com/",
+			Method: "GET",
+		},
+		Rule:           2,
+		Policy:         "two_factor",
+		Result:         "unauthorized",
+		AuthnType:      "Cookie",
+		Implementation: "ForwardAuth",
+		Latency:        1.5,
+	})
+
+	provider.RecordAuthz(&AuthzRecord{
+		Time:           time.Unix(1700000001, 0).UTC(),
+		Subject:        AuthzSubject{IP: "192.168.1.11"},
+		Object:         AuthzObject{URL: "https://public.example.com/", Method: "GET"},
+		Policy:         "bypass",
+		Result:         "authorized",
+		AuthnType:      "None",
+		Implementation: "AuthRequest",
+	})
+
+	require.NoError(t, provider.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"time":"2023-11-14T22:13:20Z","subject":{"username":"john","groups":["admins","dev"],"ip":"192.168.1.10"},"object":{"url":"https://admin.example.com/","method":"GET"},"rule":2,"policy":"two_factor","result":"unauthorized","authn_type":"Cookie","implementation":"ForwardAuth","latency_ms":1.5}
+{"time":"2023-11-14T22:13:21Z","subject":{"ip":"192.168.1.11"},"object":{"url":"https://public.example.com/","method":"GET"},"rule":0,"policy":"bypass","result":"authorized","authn_type":"None","implementation":"AuthRequest","latency_ms":0}
+`, string(data))
+}
+
+func TestProviderShouldErrorStartupCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "audit.log")
+
+	provider := NewProvider(&schema.AuditLog{File: &schema.AuditLogFile{Path: path, MaxSize: 1, MaxBackups: 1}}, nil)
+
+	err := provider.StartupCheck()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error occurred opening the audit log file '"+path+"': ")
+}
@@ -0,0 +1,260 @@
+package audit
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+)
+
+// NewSyslogSink returns a new *SyslogSink which sends each record as a RFC5424 syslog message.
+func NewSyslogSink(config *schema.AuditLogSyslog) *SyslogSink {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = syslogNilValue
+	}
+
+	return &SyslogSink{
+		address:  config.Address,
+		priority: syslogFacilities[config.Facility]*8 + syslogSeverityInfo,
+		hostname: hostname,
+		tag:      config.Tag,
+		pid:      strconv.Itoa(os.Getpid()),
+		size:     config.BufferSize,
+		notify:   make(chan struct{}, 1),
+		log:      logging.Logger().WithField(logFieldSink, sinkSyslog),
+	}
+}
+
+// SyslogSink is a Sink which sends records to a syslog server or socket. Records are buffered and sent by a background
+// routine so that an unreachable server doesn't block the caller, and records written while the buffer is full are
+// dropped.
+type SyslogSink struct {
+	address  *schema.AddressUDP
+	priority int
+	hostname string
+	tag      string
+	pid      string
+	size     int
+
+	mu      sync.Mutex
+	pending [][]byte
+
+	dropped atomic.Uint64
+
+	// The connection is only used by the background routine, or by Close once the background routine has stopped.
+	conn   net.Conn
+	stream bool
+
+	notify chan struct{}
+	done   chan struct{}
+	wg     sync.WaitGroup
+
+	log *logrus.Entry
+}
+
+// Open the connection to the syslog server and start the background routine which sends the messages.
+func (s *SyslogSink) Open() (err error) {
+	s.mu.Lock()
+
+	defer s.mu.Unlock()
+
+	if s.done != nil {
+		return nil
+	}
+
+	if s.conn == nil {
+		if err = s.dial(); err != nil {
+			return err
+		}
+	}
+
+	s.done = make(chan struct{})
+
+	s.wg.Add(1)
+
+	go s.run(s.done)
+
+	return nil
+}
+
+// Write adds a record to the buffer and signals the background routine. The record is dropped if the buffer is full.
+func (s *SyslogSink) Write(record []byte) (err error) {
+	message := s.format(time.Now(), record)
+
+	s.mu.Lock()
+
+	if len(s.pending) >= s.size {
+		s.mu.Unlock()
+
+		s.dropped.Add(1)
+
+		return nil
+	}
+
+	s.pending = append(s.pending, message)
+
+	s.mu.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// Close stops the background routine after sending any remaining messages and closes the connection to the syslog
+// server.
+func (s *SyslogSink) Close() (err error) {
+	s.mu.Lock()
+
+	done := s.done
+
+	s.done = nil
+
+	s.mu.Unlock()
+
+	if done != nil {
+		close(done)
+
+		s.wg.Wait()
+	} else {
+		s.logDropped()
+
+		err = s.send()
+	}
+
+	if s.conn == nil {
+		return err
+	}
+
+	if e := s.conn.Close(); err == nil {
+		err = e
+	}
+
+	s.conn = nil
+
+	return err
+}
+
+func (s *SyslogSink) run(done chan struct{}) {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case <-done:
+			s.logDropped()
+
+			if err := s.send(); err != nil {
+				s.log.WithError(err).Error("Error occurred sending the audit records")
+			}
+
+			return
+		case <-s.notify:
+		}
+
+		s.logDropped()
+
+		if err := s.send(); err != nil {
+			s.log.WithError(err).Error("Error occurred sending the audit records")
+		}
+	}
+}
+
+// logDropped logs the number of records dropped because the buffer was full since it was last logged.
+func (s *SyslogSink) logDropped() {
+	if n := s.dropped.Swap(0); n != 0 {
+		s.log.WithField("dropped", n).Error("Audit records were dropped because the buffer is full")
+	}
+}
+
+// send the pending messages. The connection is reestablished once if a write fails, and the remaining messages are
+// dropped if it can't be.
+func (s *SyslogSink) send() (err error) {
+	s.mu.Lock()
+
+	pending := s.pending
+
+	s.pending = nil
+
+	s.mu.Unlock()
+
+	for i, message := range pending {
+		if err = s.write(message); err != nil {
+			return fmt.Errorf("%d audit records were dropped: %w", len(pending)-i, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *SyslogSink) write(message []byte) (err error) {
+	if s.conn != nil {
+		if _, err = s.conn.Write(s.terminate(message)); err == nil {
+			return nil
+		}
+
+		_ = s.conn.Close()
+
+		s.conn = nil
+	}
+
+	if err = s.dial(); err != nil {
+		return err
+	}
+
+	_, err = s.conn.Write(s.terminate(message))
+
+	return err
+}
+
+func (s *SyslogSink) dial() (err error) {
+	if !s.address.IsUnixDomainSocket() {
+		s.stream = false
+
+		if s.conn, err = s.address.Dial(); err != nil {
+			return fmt.Errorf("error occurred connecting to the syslog server '%s': %w", s.address.String(), err)
+		}
+
+		return nil
+	}
+
+	path := s.address.NetworkAddress()
+
+	if s.conn, err = net.Dial(syslogNetworkPacket, path); err == nil {
+		s.stream = false
+
+		return nil
+	}
+
+	if s.conn, err = net.Dial(syslogNetworkStream, path); err != nil {
+		return fmt.Errorf("error occurred connecting to the syslog socket '%s': %w", path, err)
+	}
+
+	s.stream = true
+
+	return nil
+}
+
+// format the record as a RFC5424 message.
+func (s *SyslogSink) format(now time.Time, record []byte) (message []byte) {
+	return fmt.Appendf(nil, "<%d>%d %s %s %s %s %s %s %s", s.priority, syslogVersion, now.UTC().Format(time.RFC3339Nano), s.hostname, s.tag, s.pid, syslogMessageID, syslogNilValue, record)
+}
+
+// terminate the message with a line feed if it's being sent over a stream socket.
+func (s *SyslogSink) terminate(message []byte) []byte {
+	if s.stream {
+		return append(message, '\n')
+	}
+
+	return message
+}
@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"net"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestSyslogSinkUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	address, err := schema.NewAddressDefault("udp://"+conn.LocalAddr().String(), schema.AddressSchemeUDP, schema.AddressSchemeUnix)
+	require.NoError(t, err)
+
+	sink := NewSyslogSink(&schema.AuditLogSyslog{Address: &schema.AddressUDP{Address: *address}, Facility: "authpriv", Tag: "authelia", BufferSize: 10})
+
+	require.NoError(t, sink.Open())
+	require.NoError(t, sink.Write([]byte(`{"result":"authorized"}`)))
+	require.NoError(t, sink.Close())
+	require.NoError(t, sink.Close())
+
+	assert.Regexp(t, regexp.MustCompile(`^<86>1 \S+ \S+ authelia \d+ authz - \{"result":"authorized"}$`), readPacket(t, conn))
+}
+
+func TestSyslogSinkUnixDatagram(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.sock")
+
+	conn, err := net.ListenPacket("unixgram", path)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	address := schema.NewAddressUnix(path)
+
+	sink := NewSyslogSink(&schema.AuditLogSyslog{Address: &schema.AddressUDP{Address: address}, Facility: "local0", Tag: "audit", BufferSize: 10})
+
+	require.NoError(t, sink.Write([]byte(`{"result":"forbidden"}`)))
+	require.NoError(t, sink.Close())
+
+	assert.Regexp(t, regexp.MustCompile(`^<134>1 \S+ \S+ audit \d+ authz - \{"result":"forbidden"}$`), readPacket(t, conn))
+}
+
+func TestSyslogSinkShouldDropWhenBufferFull(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.sock")
+
+	conn, err := net.ListenPacket("unixgram", path)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	sink := NewSyslogSink(&schema.AuditLogSyslog{Address: &schema.AddressUDP{Address: schema.NewAddressUnix(path)}, Facility: "authpriv", Tag: "authelia", BufferSize: 2})
+
+	require.NoError(t, sink.Write([]byte(`{"rule":1}`)))
+	require.NoError(t, sink.Write([]byte(`{"rule":2}`)))
+	require.NoError(t, sink.Write([]byte(`{"rule":3}`)))
+
+	assert.Equal(t, uint64(1), sink.dropped.Load())
+
+	require.NoError(t, sink.Close())
+
+	assert.Equal(t, uint64(0), sink.dropped.Load())
+	assert.Contains(t, readPacket(t, conn), `{"rule":1}`)
+	assert.Contains(t, readPacket(t, conn), `{"rule":2}`)
+}
+
+func TestSyslogSinkShouldNotBlockWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.sock")
+
+	conn, err := net.ListenPacket("unixgram", path)
+	require.NoError(t, err)
+
+	sink := NewSyslogSink(&schema.AuditLogSyslog{Address: &schema.AddressUDP{Address: schema.NewAddressUnix(path)}, Facility: "authpriv", Tag: "authelia", BufferSize: 10})
+
+	require.NoError(t, sink.Open())
+	require.NoError(t, conn.Close())
+
+	for i := 0; i < 20; i++ {
+		assert.NoError(t, sink.Write([]byte(`{}`)))
+	}
+
+	assert.NoError(t, sink.Close())
+}
+
+func TestSyslogSinkShouldErrorOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.sock")
+
+	sink := NewSyslogSink(&schema.AuditLogSyslog{Address: &schema.AddressUDP{Address: schema.NewAddressUnix(path)}, Facility: "authpriv", Tag: "authelia", BufferSize: 10})
+
+	err := sink.Open()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error occurred connecting to the syslog socket '"+path+"': ")
+}
+
+func TestSyslogSinkFormat(t *testing.T) {
+	sink := &SyslogSink{priority: 86, hostname: "host", tag: "authelia", pid: "1"}
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	assert.Equal(t, `<86>1 2024-01-02T03:04:05Z host authelia 1 authz - {}`, string(sink.terminate(sink.format(now, []byte("{}")))))
+
+	sink.stream = true
+
+	assert.Equal(t, "<86>1 2024-01-02T03:04:05Z host authelia 1 authz - {}\n", string(sink.terminate(sink.format(now, []byte("{}")))))
+}
+
+func readPacket(t *testing.T, conn net.PacketConn) string {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second*5)))
+
+	buf := make([]byte, 2048)
+
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+
+	return string(buf[:n])
+}
@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"time"
+)
+
+// Sink is a destination for audit records.
+type Sink interface {
+	// Open prepares the Sink for writing.
+	Open() (err error)
+
+	// Write a single encoded audit record to the Sink.
+	Write(record []byte) (err error)
+
+	// Close the Sink, flushing any pending records.
+	Close() (err error)
+}
+
+// AuthzRecord is the audit record of a single authorization decision.
+type AuthzRecord struct {
+	Time    time.Time    `json:"time"`
+	Subject AuthzSubject `json:"subject"`
+	Object  AuthzObject  `json:"object"`
+
+	// Rule is the position of the matched access control rule starting at 1, or 0 if the default policy was applied or
+	// no decision was made.
+	Rule   int    `json:"rule"`
+	Policy string `json:"policy"`
+	Result string `json:"result"`
+
+	AuthnType      string `json:"authn_type"`
+	Implementation string `json:"implementation"`
+
+	// Latency is the time taken to make the decision in milliseconds.
+	Latency float64 `json:"latency_ms"`
+
+	// Error is the reason the request was rejected before a decision could be made or failed to authenticate.
+	Error string `json:"error,omitempty"`
+}
+
+// AuthzSubject is the subject of an AuthzRecord.
+type AuthzSubject struct {
+	Username string   `json:"username,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+	ClientID string   `json:"client_id,omitempty"`
+	IP       string   `json:"ip"`
+}
+
+// AuthzObject is the object of an AuthzRecord.
+type AuthzObject struct {
+	URL    string `json:"url"`
+	Method string `json:"method"`
+}
@@ -0,0 +1,256 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// NewWebhookSink returns a new *WebhookSink which sends batches of records to a HTTP endpoint.
+func NewWebhookSink(config *schema.AuditLogWebhook, trusted *x509.CertPool) *WebhookSink {
+	transport := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: utils.NewTLSConfig(config.TLS, trusted),
+	}
+
+	return &WebhookSink{
+		config:  config,
+		client:  &http.Client{Transport: transport, Timeout: config.Timeout},
+		backoff: webhookRetryBackoff,
+		flush:   make(chan struct{}, 1),
+		log:     logging.Logger().WithField(logFieldSink, sinkWebhook),
+	}
+}
+
+// WebhookSink is a Sink which sends records to a HTTP endpoint as a JSON array with a POST request. Records are sent
+// when the batch size is reached or when the flush interval elapses, whichever comes first. At most the buffer size of
+// records are held at any one time including those which are being sent, and records written while the buffer is full
+// are dropped.
+type WebhookSink struct {
+	config  *schema.AuditLogWebhook
+	client  *http.Client
+	backoff time.Duration
+
+	mu      sync.Mutex
+	batch   []json.RawMessage
+	sending int
+
+	dropped atomic.Uint64
+
+	flush chan struct{}
+	done  chan struct{}
+	wg    sync.WaitGroup
+
+	log *logrus.Entry
+}
+
+// Open starts the background routine which sends the batches.
+func (s *WebhookSink) Open() (err error) {
+	s.mu.Lock()
+
+	defer s.mu.Unlock()
+
+	if s.done != nil {
+		return nil
+	}
+
+	s.done = make(chan struct{})
+
+	s.wg.Add(1)
+
+	go s.run(s.done)
+
+	return nil
+}
+
+// Write adds a record to the current batch and signals the background routine if the batch is full. The record is
+// dropped if the buffer is full.
+func (s *WebhookSink) Write(record []byte) (err error) {
+	s.mu.Lock()
+
+	if len(s.batch)+s.sending >= s.config.BufferSize {
+		s.mu.Unlock()
+
+		s.dropped.Add(1)
+
+		return nil
+	}
+
+	s.batch = append(s.batch, json.RawMessage(bytes.Clone(record)))
+
+	full := len(s.batch) >= s.config.BatchSize
+
+	s.mu.Unlock()
+
+	if full {
+		select {
+		case s.flush <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// Close stops the background routine after sending any remaining records. The remaining records are not retried.
+func (s *WebhookSink) Close() (err error) {
+	s.mu.Lock()
+
+	done := s.done
+
+	s.done = nil
+
+	s.mu.Unlock()
+
+	if done == nil {
+		s.logDropped()
+
+		return s.send(nil, 0)
+	}
+
+	close(done)
+
+	s.wg.Wait()
+
+	return nil
+}
+
+func (s *WebhookSink) run(done chan struct{}) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.FlushInterval)
+
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			s.logDropped()
+
+			if err := s.send(nil, 0); err != nil {
+				s.log.WithError(err).Error("Error occurred sending the audit records")
+			}
+
+			return
+		case <-ticker.C:
+		case <-s.flush:
+		}
+
+		s.logDropped()
+
+		if err := s.send(done, s.config.MaxRetries); err != nil {
+			s.log.WithError(err).Error("Error occurred sending the audit records")
+		}
+	}
+}
+
+// logDropped logs the number of records dropped because the buffer was full since it was last logged.
+func (s *WebhookSink) logDropped() {
+	if n := s.dropped.Swap(0); n != 0 {
+		s.log.WithField("dropped", n).Error("Audit records were dropped because the buffer is full")
+	}
+}
+
+// send the pending records in batches, retrying each batch which fails up to the provided number of retries. Batches
+// which fail to send are dropped, and the error for the last batch which was dropped is returned.
+func (s *WebhookSink) send(done chan struct{}, retries int) (err error) {
+	s.mu.Lock()
+
+	pending := s.batch
+
+	s.batch, s.sending = nil, len(pending)
+
+	s.mu.Unlock()
+
+	var dropped int
+
+	for len(pending) != 0 {
+		n := min(len(pending), s.config.BatchSize)
+
+		if e := s.postWithRetry(done, pending[:n], retries); e != nil {
+			dropped, err = dropped+n, e
+		}
+
+		pending = pending[n:]
+
+		s.mu.Lock()
+
+		s.sending -= n
+
+		s.mu.Unlock()
+	}
+
+	if err != nil {
+		return fmt.Errorf("%d audit records were dropped: %w", dropped, err)
+	}
+
+	return nil
+}
+
+// postWithRetry posts the records, waiting for an exponentially increasing backoff between each attempt. Retrying is
+// abandoned if the done channel is closed.
+func (s *WebhookSink) postWithRetry(done chan struct{}, records []json.RawMessage, retries int) (err error) {
+	for attempt := 0; ; attempt++ {
+		if err = s.post(records); err == nil || attempt >= retries {
+			return err
+		}
+
+		s.log.WithError(err).WithField("attempt", attempt+1).Warn("Error occurred sending the audit records, retrying")
+
+		timer := time.NewTimer(s.backoff << attempt)
+
+		select {
+		case <-done:
+			timer.Stop()
+
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func (s *WebhookSink) post(records []json.RawMessage) (err error) {
+	var body []byte
+
+	if body, err = json.Marshal(records); err != nil {
+		return fmt.Errorf("error occurred encoding the audit records: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.Timeout)
+
+	defer cancel()
+
+	var req *http.Request
+
+	if req, err = http.NewRequestWithContext(ctx, http.MethodPost, s.config.Address.String(), bytes.NewReader(body)); err != nil {
+		return fmt.Errorf("error occurred creating the audit webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	var resp *http.Response
+
+	if resp, err = s.client.Do(req); err != nil {
+		return fmt.Errorf("error occurred sending the audit webhook request: %w", err)
+	}
+
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("error occurred sending the audit webhook request: the server responded with status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+type webhookRecorder struct {
+	mu       sync.Mutex
+	batches  [][]map[string]any
+	status   int
+	failures int
+	attempts int
+}
+
+func (r *webhookRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	var batch []map[string]any
+
+	_ = json.Unmarshal(body, &batch)
+
+	r.mu.Lock()
+
+	defer r.mu.Unlock()
+
+	r.attempts++
+
+	if r.failures > 0 {
+		r.failures--
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+
+		return
+	}
+
+	if req.Method == http.MethodPost && req.Header.Get("Content-Type") == "application/json" {
+		r.batches = append(r.batches, batch)
+	}
+
+	if r.status != 0 {
+		w.WriteHeader(r.status)
+	}
+}
+
+func (r *webhookRecorder) Batches() [][]map[string]any {
+	r.mu.Lock()
+
+	defer r.mu.Unlock()
+
+	return r.batches
+}
+
+func (r *webhookRecorder) Attempts() int {
+	r.mu.Lock()
+
+	defer r.mu.Unlock()
+
+	return r.attempts
+}
+
+func newTestWebhookSink(t *testing.T, address string, batch int, interval time.Duration) *WebhookSink {
+	uri, err := url.Parse(address)
+	require.NoError(t, err)
+
+	sink := NewWebhookSink(&schema.AuditLogWebhook{
+		Address:       uri,
+		BatchSize:     batch,
+		FlushInterval: interval,
+		Timeout:       time.Second * 5,
+		BufferSize:    batch * 2,
+		MaxRetries:    2,
+		TLS:           &schema.TLS{},
+	}, nil)
+
+	sink.backoff = time.Millisecond
+
+	return sink
+}
+
+func TestWebhookSinkShouldSendFullBatches(t *testing.T) {
+	recorder := &webhookRecorder{}
+
+	server := httptest.NewServer(recorder)
+
+	defer server.Close()
+
+	sink := newTestWebhookSink(t, server.URL, 2, time.Hour)
+
+	require.NoError(t, sink.Open())
+
+	require.NoError(t, sink.Write([]byte(`{"rule":1}`)))
+	require.NoError(t, sink.Write([]byte(`{"rule":2}`)))
+
+	assert.Eventually(t, func() bool { return len(recorder.Batches()) == 1 }, time.Second*5, time.Millisecond*10)
+
+	require.NoError(t, sink.Write([]byte(`{"rule":3}`)))
+	require.NoError(t, sink.Close())
+
+	assert.Equal(t, [][]map[string]any{
+		{{"rule": float64(1)}, {"rule": float64(2)}},
+		{{"rule": float64(3)}},
+	}, recorder.Batches())
+}
+
+func TestWebhookSinkShouldSendOnInterval(t *testing.T) {
+	recorder := &webhookRecorder{}
+
+	server := httptest.NewServer(recorder)
+
+	defer server.Close()
+
+	sink := newTestWebhookSink(t, server.URL, 100, time.Millisecond*50)
+
+	require.NoError(t, sink.Open())
+	require.NoError(t, sink.Open())
+
+	require.NoError(t, sink.Write([]byte(`{"rule":1}`)))
+
+	assert.Eventually(t, func() bool { return len(recorder.Batches()) == 1 }, time.Second*5, time.Millisecond*10)
+
+	require.NoError(t, sink.Close())
+
+	assert.Equal(t, [][]map[string]any{{{"rule": float64(1)}}}, recorder.Batches())
+}
+
+func TestWebhookSinkShouldErrorStatusCode(t *testing.T) {
+	recorder := &webhookRecorder{status: http.StatusInternalServerError}
+
+	server := httptest.NewServer(recorder)
+
+	defer server.Close()
+
+	sink := newTestWebhookSink(t, server.URL, 100, time.Hour)
+
+	require.NoError(t, sink.Write([]byte(`{"rule":1}`)))
+
+	assert.EqualError(t, sink.Close(), "1 audit records were dropped: error occurred sending the audit webhook request: the server responded with status code 500")
+
+	assert.NoError(t, sink.Close())
+}
+
+func TestWebhookSinkShouldRetryFailedBatches(t *testing.T) {
+	recorder := &webhookRecorder{failures: 2}
+
+	server := httptest.NewServer(recorder)
+
+	defer server.Close()
+
+	sink := newTestWebhookSink(t, server.URL, 1, time.Hour)
+
+	require.NoError(t, sink.Open())
+
+	require.NoError(t, sink.Write([]byte(`{"rule":1}`)))
+
+	assert.Eventually(t, func() bool { return len(recorder.Batches()) == 1 }, time.Second*5, time.Millisecond*10)
+
+	require.NoError(t, sink.Close())
+
+	assert.Equal(t, 3, recorder.Attempts())
+	assert.Equal(t, [][]map[string]any{{{"rule": float64(1)}}}, recorder.Batches())
+}
+
+func TestWebhookSinkShouldDropBatchAfterRetries(t *testing.T) {
+	recorder := &webhookRecorder{failures: 3}
+
+	server := httptest.NewServer(recorder)
+
+	defer server.Close()
+
+	sink := newTestWebhookSink(t, server.URL, 1, time.Hour)
+
+	require.NoError(t, sink.Write([]byte(`{"rule":1}`)))
+
+	assert.EqualError(t, sink.send(make(chan struct{}), sink.config.MaxRetries), "1 audit records were dropped: error occurred sending the audit webhook request: the server responded with status code 503")
+	assert.Equal(t, 3, recorder.Attempts())
+
+	require.NoError(t, sink.Write([]byte(`{"rule":2}`)))
+	require.NoError(t, sink.Close())
+
+	assert.Equal(t, [][]map[string]any{{{"rule": float64(2)}}}, recorder.Batches())
+}
+
+func TestWebhookSinkShouldDropWhenBufferFull(t *testing.T) {
+	recorder := &webhookRecorder{}
+
+	server := httptest.NewServer(recorder)
+
+	defer server.Close()
+
+	sink := newTestWebhookSink(t, server.URL, 2, time.Hour)
+
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, sink.Write([]byte(fmt.Sprintf(`{"rule":%d}`, i))))
+	}
+
+	assert.Equal(t, uint64(1), sink.dropped.Load())
+
+	require.NoError(t, sink.Close())
+
+	assert.Equal(t, uint64(0), sink.dropped.Load())
+	assert.Equal(t, [][]map[string]any{
+		{{"rule": float64(1)}, {"rule": float64(2)}},
+		{{"rule": float64(3)}, {"rule": float64(4)}},
+	}, recorder.Batches())
+}
@@ -0,0 +1,356 @@
+package authentication
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/storage"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// ChainedUserProvider is a UserProvider which consults multiple backends in order of precedence.
+type ChainedUserProvider struct {
+	conflictMode string
+	backends     []*ChainedUserProviderBackend
+
+	// recheckBackoffMin and recheckBackoffMax are the bounds of the backoff between the startup checks of a backend
+	// which failed its startup check.
+	recheckBackoffMin time.Duration
+	recheckBackoffMax time.Duration
+
+	log *logrus.Logger
+}
+
+// ChainedUserProviderBackend is an individual backend of a ChainedUserProvider.
+type ChainedUserProviderBackend struct {
+	Name     string
+	Optional bool
+
+	Domains         []string
+	UsernamePattern *regexp.Regexp
+
+	Provider UserProvider
+
+	// failed is true when the last startup check of an optional backend failed, such backends are not consulted.
+	failed atomic.Bool
+
+	mu       sync.Mutex
+	checking bool
+	backoff  time.Duration
+	retryAt  time.Time
+}
+
+// NewChainedUserProvider creates a new instance of ChainedUserProvider from the chained authentication backend
+// configuration. The metrics recorder is optional.
+func NewChainedUserProvider(config schema.AuthenticationBackend, certPool *x509.CertPool, storage storage.UserDatabaseProvider, metrics LDAPPoolMetricsRecorder) (provider *ChainedUserProvider) {
+	backends := make([]*ChainedUserProviderBackend, len(config.Chain.Backends))
+
+	for i, backend := range config.Chain.Backends {
+		backends[i] = &ChainedUserProviderBackend{
+			Name:            backend.Name,
+			Optional:        backend.Optional,
+			Domains:         backend.Domains,
+			UsernamePattern: backend.UsernamePattern,
+		}
+
+		switch {
+		case backend.File != nil:
+			backends[i].Provider = NewFileUserProvider(backend.File)
+		case backend.LDAP != nil:
+			backends[i].Provider = newLDAPUserProvider(*backend.LDAP, config.PasswordReset.Disable, certPool, backend.Name, metrics)
+		case backend.SQL != nil:
+			backends[i].Provider = NewSQLUserProvider(backend.SQL, storage)
+		}
+	}
+
+	return NewChainedUserProviderWithBackends(config.Chain.ConflictMode, backends...)
+}
+
+// NewChainedUserProviderWithBackends creates a new instance of ChainedUserProvider with the specified backends.
+func NewChainedUserProviderWithBackends(conflictMode string, backends ...*ChainedUserProviderBackend) (provider *ChainedUserProvider) {
+	if conflictMode == "" {
+		conflictMode = schema.AuthenticationBackendChainConflictModePrecedence
+	}
+
+	return &ChainedUserProvider{
+		conflictMode:      conflictMode,
+		backends:          backends,
+		recheckBackoffMin: chainedBackendRecheckBackoffMin,
+		recheckBackoffMax: chainedBackendRecheckBackoffMax,
+		log:               logging.Logger(),
+	}
+}
+
+// CheckUserPassword checks if provided password matches for the given user using the backend which the user belongs
+// to.
+func (p *ChainedUserProvider) CheckUserPassword(username string, password string) (valid bool, err error) {
+	var backend *ChainedUserProviderBackend
+
+	if p.conflictMode == schema.AuthenticationBackendChainConflictModePrecedence {
+		// The password is only checked against the first backend which contains the user, an incorrect password never
+		// results in the lower precedence backends being checked.
+		for _, backend = range p.candidates(username) {
+			if valid, err = backend.Provider.CheckUserPassword(username, password); errors.Is(err, ErrUserNotFound) {
+				continue
+			}
+
+			p.log.WithFields(map[string]any{"backend": backend.Name, "username": username}).Trace("Checked user password using chained authentication backend")
+
+			return valid, err
+		}
+
+		return false, ErrUserNotFound
+	}
+
+	if backend, _, err = p.resolve(username); err != nil {
+		return false, err
+	}
+
+	return backend.Provider.CheckUserPassword(username, password)
+}
+
+// GetDetails retrieves the details of the given user from the backend which the user belongs to.
+func (p *ChainedUserProvider) GetDetails(username string) (details *UserDetails, err error) {
+	var backend *ChainedUserProviderBackend
+
+	if backend, details, err = p.resolve(username); err != nil {
+		return nil, err
+	}
+
+	if backend.routed() && !backend.matches(details.Username) {
+		p.log.WithFields(map[string]any{"backend": backend.Name, "username": details.Username}).
+			Warn("The username returned by the chained authentication backend does not match the routing rules of the backend which may prevent the user details from being refreshed, the username attribute of the backend should return a value which includes the domain")
+	}
+
+	return details, nil
+}
+
+// UpdatePassword updates the password of the given user using the backend which the user belongs to.
+func (p *ChainedUserProvider) UpdatePassword(username string, newPassword string) (err error) {
+	var backend *ChainedUserProviderBackend
+
+	if backend, _, err = p.resolve(username); err != nil {
+		return err
+	}
+
+	return backend.Provider.UpdatePassword(username, newPassword)
+}
+
+// StartupCheck implements the startup check provider interface by performing the startup check of every backend. The
+// check only fails if a backend which isn't optional fails.
+func (p *ChainedUserProvider) StartupCheck() (err error) {
+	var failures []string
+
+	for _, backend := range p.backends {
+		log := p.log.WithField("backend", backend.Name)
+
+		err = backend.Provider.StartupCheck()
+
+		p.setCheckResult(backend, err)
+
+		switch {
+		case err == nil:
+			log.Debug("Startup check for chained authentication backend completed successfully")
+		case backend.Optional:
+			log.WithError(err).Warn("Startup check for optional chained authentication backend failed, the backend will not be consulted until the startup check which is retried in the background succeeds")
+		default:
+			log.WithError(err).Error("Startup check for chained authentication backend failed")
+
+			failures = append(failures, backend.Name)
+		}
+	}
+
+	if len(failures) != 0 {
+		return fmt.Errorf("the startup check failed for the chained authentication backends '%s'", strings.Join(failures, "', '"))
+	}
+
+	return nil
+}
+
+// Close closes every backend which holds resources such as pooled LDAP connections. The first error is returned after
+// attempting to close every backend.
+func (p *ChainedUserProvider) Close() (err error) {
+	for _, backend := range p.backends {
+		closer, ok := backend.Provider.(io.Closer)
+		if !ok {
+			continue
+		}
+
+		if e := closer.Close(); e != nil {
+			p.log.WithError(e).WithField("backend", backend.Name).Error("Error occurred closing the chained authentication backend")
+
+			if err == nil {
+				err = e
+			}
+		}
+	}
+
+	return err
+}
+
+// candidates returns the backends which should be consulted for the given username in order of precedence. If the
+// username matches the routing rules of any backend only those backends are returned, otherwise the backends without
+// routing rules are returned. Backends which failed their startup check are never returned, and a user routed to such a
+// backend does not fall back to the backends without routing rules. The startup check of such backends is retried in
+// the background.
+func (p *ChainedUserProvider) candidates(username string) (backends []*ChainedUserProviderBackend) {
+	var matched, fallback []*ChainedUserProviderBackend
+
+	for _, backend := range p.backends {
+		switch {
+		case !backend.routed():
+			fallback = append(fallback, backend)
+		case backend.matches(username):
+			matched = append(matched, backend)
+		}
+	}
+
+	if len(matched) == 0 {
+		matched = fallback
+	}
+
+	for _, backend := range matched {
+		if backend.failed.Load() {
+			p.log.WithFields(map[string]any{"backend": backend.Name, "username": username}).Trace("Skipping chained authentication backend which failed the startup check")
+
+			p.recheck(backend)
+
+			continue
+		}
+
+		backends = append(backends, backend)
+	}
+
+	return backends
+}
+
+// recheck performs the startup check of a backend which failed its startup check in the background if the backoff has
+// elapsed and a check isn't already in progress. The backend is consulted again once the check succeeds.
+func (p *ChainedUserProvider) recheck(backend *ChainedUserProviderBackend) {
+	backend.mu.Lock()
+
+	if backend.checking || time.Now().Before(backend.retryAt) {
+		backend.mu.Unlock()
+
+		return
+	}
+
+	backend.checking = true
+
+	backend.mu.Unlock()
+
+	go func() {
+		log := p.log.WithField("backend", backend.Name)
+
+		err := backend.Provider.StartupCheck()
+
+		p.setCheckResult(backend, err)
+
+		backend.mu.Lock()
+
+		backend.checking = false
+
+		backend.mu.Unlock()
+
+		if err != nil {
+			log.WithError(err).Debug("Startup check for chained authentication backend which previously failed has failed again")
+
+			return
+		}
+
+		log.Info("Startup check for chained authentication backend which previously failed completed successfully, the backend will now be consulted")
+	}()
+}
+
+// setCheckResult records the result of a startup check of a backend. The backoff before the next check doubles after
+// each consecutive failure and is reset by a success.
+func (p *ChainedUserProvider) setCheckResult(backend *ChainedUserProviderBackend, err error) {
+	backend.mu.Lock()
+
+	defer backend.mu.Unlock()
+
+	if err == nil {
+		backend.backoff = 0
+		backend.failed.Store(false)
+
+		return
+	}
+
+	backend.backoff = min(max(backend.backoff*2, p.recheckBackoffMin), p.recheckBackoffMax)
+	backend.retryAt = time.Now().Add(backend.backoff)
+	backend.failed.Store(true)
+}
+
+// resolve returns the backend which the given user belongs to along with the user details.
+func (p *ChainedUserProvider) resolve(username string) (backend *ChainedUserProviderBackend, details *UserDetails, err error) {
+	for _, candidate := range p.candidates(username) {
+		var d *UserDetails
+
+		switch d, err = candidate.Provider.GetDetails(username); {
+		case err == nil:
+			if p.conflictMode == schema.AuthenticationBackendChainConflictModePrecedence {
+				return candidate, d, nil
+			}
+
+			if backend != nil {
+				return nil, nil, fmt.Errorf("%w: the user '%s' exists in both the '%s' and '%s' backends", ErrUserConflict, username, backend.Name, candidate.Name)
+			}
+
+			backend, details = candidate, d
+		case errors.Is(err, ErrUserNotFound):
+			continue
+		default:
+			return nil, nil, fmt.Errorf("error occurred retrieving the details of user '%s' from the '%s' backend: %w", username, candidate.Name, err)
+		}
+	}
+
+	if backend == nil {
+		return nil, nil, ErrUserNotFound
+	}
+
+	return backend, details, nil
+}
+
+func (b *ChainedUserProviderBackend) routed() bool {
+	return len(b.Domains) != 0 || b.UsernamePattern != nil
+}
+
+func (b *ChainedUserProviderBackend) matches(username string) bool {
+	if b.UsernamePattern != nil && b.UsernamePattern.MatchString(username) {
+		return true
+	}
+
+	if domain := chainedUsernameDomain(username); domain != "" {
+		return utils.IsStringInSliceFold(domain, b.Domains)
+	}
+
+	return false
+}
+
+// chainedUsernameDomain returns the domain of a username in either the user@domain or DOMAIN\user format.
+func chainedUsernameDomain(username string) (domain string) {
+	if i := strings.LastIndex(username, "@"); i != -1 {
+		return username[i+1:]
+	}
+
+	if i := strings.Index(username, `\`); i != -1 {
+		return username[:i]
+	}
+
+	return ""
+}
+
+var (
+	_ UserProvider = (*ChainedUserProvider)(nil)
+)
@@ -0,0 +1,400 @@
+package authentication
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestChainedUserProvider_CheckUserPassword(t *testing.T) {
+	testCases := []struct {
+		name     string
+		username string
+		setup    func(corp, partner, local *MockUserProvider)
+		valid    bool
+		err      string
+	}{
+		{
+			"ShouldRouteDomainUPN",
+			"john@corp.example.com",
+			func(corp, partner, local *MockUserProvider) {
+				corp.EXPECT().CheckUserPassword("john@corp.example.com", "password").Return(true, nil)
+			},
+			true,
+			"",
+		},
+		{
+			"ShouldRouteDomainNetBIOS",
+			`PARTNER\john`,
+			func(corp, partner, local *MockUserProvider) {
+				partner.EXPECT().CheckUserPassword(`PARTNER\john`, "password").Return(true, nil)
+			},
+			true,
+			"",
+		},
+		{
+			"ShouldRoutePattern",
+			"svc-backup",
+			func(corp, partner, local *MockUserProvider) {
+				partner.EXPECT().CheckUserPassword("svc-backup", "password").Return(true, nil)
+			},
+			true,
+			"",
+		},
+		{
+			"ShouldFallbackToBackendsWithoutRoutingRules",
+			"breakglass",
+			func(corp, partner, local *MockUserProvider) {
+				local.EXPECT().CheckUserPassword("breakglass", "password").Return(true, nil)
+			},
+			true,
+			"",
+		},
+		{
+			"ShouldNotFallbackOnInvalidPassword",
+			"john@corp.example.com",
+			func(corp, partner, local *MockUserProvider) {
+				corp.EXPECT().CheckUserPassword("john@corp.example.com", "password").Return(false, errors.New("authentication failed"))
+			},
+			false,
+			"authentication failed",
+		},
+		{
+			"ShouldReturnUserNotFound",
+			"john@example.org",
+			func(corp, partner, local *MockUserProvider) {
+				local.EXPECT().CheckUserPassword("john@example.org", "password").Return(false, ErrUserNotFound)
+			},
+			false,
+			"user not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			corp, partner, local := NewMockUserProvider(ctrl), NewMockUserProvider(ctrl), NewMockUserProvider(ctrl)
+
+			provider := NewChainedUserProviderWithBackends("",
+				&ChainedUserProviderBackend{Name: "corp", Domains: []string{"corp.example.com", "corp"}, Provider: corp},
+				&ChainedUserProviderBackend{Name: "partner", Domains: []string{"partner"}, UsernamePattern: regexp.MustCompile(`^svc-`), Provider: partner},
+				&ChainedUserProviderBackend{Name: "local", Provider: local},
+			)
+
+			tc.setup(corp, partner, local)
+
+			valid, err := provider.CheckUserPassword(tc.username, "password")
+
+			assert.Equal(t, tc.valid, valid)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestChainedUserProvider_CheckUserPasswordShouldTryBackendsInOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	first, second := NewMockUserProvider(ctrl), NewMockUserProvider(ctrl)
+
+	provider := NewChainedUserProviderWithBackends(schema.AuthenticationBackendChainConflictModePrecedence,
+		&ChainedUserProviderBackend{Name: "first", Provider: first},
+		&ChainedUserProviderBackend{Name: "second", Provider: second},
+	)
+
+	gomock.InOrder(
+		first.EXPECT().CheckUserPassword("john", "password").Return(false, ErrUserNotFound),
+		second.EXPECT().CheckUserPassword("john", "password").Return(true, nil),
+	)
+
+	valid, err := provider.CheckUserPassword("john", "password")
+
+	assert.True(t, valid)
+	assert.NoError(t, err)
+}
+
+func TestChainedUserProvider_ConflictModeDeny(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	first, second := NewMockUserProvider(ctrl), NewMockUserProvider(ctrl)
+
+	provider := NewChainedUserProviderWithBackends(schema.AuthenticationBackendChainConflictModeDeny,
+		&ChainedUserProviderBackend{Name: "first", Provider: first},
+		&ChainedUserProviderBackend{Name: "second", Provider: second},
+	)
+
+	first.EXPECT().GetDetails("john").Return(&UserDetails{Username: "john"}, nil).Times(2)
+	second.EXPECT().GetDetails("john").Return(&UserDetails{Username: "john"}, nil).Times(2)
+
+	valid, err := provider.CheckUserPassword("john", "password")
+
+	assert.False(t, valid)
+	assert.EqualError(t, err, "user exists in more than one backend: the user 'john' exists in both the 'first' and 'second' backends")
+	assert.True(t, errors.Is(err, ErrUserConflict))
+
+	details, err := provider.GetDetails("john")
+
+	assert.Nil(t, details)
+	assert.True(t, errors.Is(err, ErrUserConflict))
+
+	first.EXPECT().GetDetails("fred").Return(nil, ErrUserNotFound)
+	second.EXPECT().GetDetails("fred").Return(&UserDetails{Username: "fred"}, nil)
+	second.EXPECT().CheckUserPassword("fred", "password").Return(true, nil)
+
+	valid, err = provider.CheckUserPassword("fred", "password")
+
+	assert.True(t, valid)
+	assert.NoError(t, err)
+}
+
+func TestChainedUserProvider_GetDetails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	first, second := NewMockUserProvider(ctrl), NewMockUserProvider(ctrl)
+
+	provider := NewChainedUserProviderWithBackends(schema.AuthenticationBackendChainConflictModePrecedence,
+		&ChainedUserProviderBackend{Name: "first", Provider: first},
+		&ChainedUserProviderBackend{Name: "second", Provider: second},
+	)
+
+	gomock.InOrder(
+		first.EXPECT().GetDetails("john").Return(nil, ErrUserNotFound),
+		second.EXPECT().GetDetails("john").Return(&UserDetails{Username: "john", Groups: []string{"admins"}}, nil),
+	)
+
+	details, err := provider.GetDetails("john")
+
+	require.NoError(t, err)
+	assert.Equal(t, "john", details.Username)
+	assert.Equal(t, []string{"admins"}, details.Groups)
+
+	first.EXPECT().GetDetails("fred").Return(nil, errors.New("connection refused"))
+
+	details, err = provider.GetDetails("fred")
+
+	assert.Nil(t, details)
+	assert.EqualError(t, err, "error occurred retrieving the details of user 'fred' from the 'first' backend: connection refused")
+}
+
+func TestChainedUserProvider_UpdatePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	first, second := NewMockUserProvider(ctrl), NewMockUserProvider(ctrl)
+
+	provider := NewChainedUserProviderWithBackends(schema.AuthenticationBackendChainConflictModePrecedence,
+		&ChainedUserProviderBackend{Name: "first", Provider: first},
+		&ChainedUserProviderBackend{Name: "second", Provider: second},
+	)
+
+	gomock.InOrder(
+		first.EXPECT().GetDetails("john").Return(nil, ErrUserNotFound),
+		second.EXPECT().GetDetails("john").Return(&UserDetails{Username: "john"}, nil),
+		second.EXPECT().UpdatePassword("john", "new").Return(nil),
+	)
+
+	assert.NoError(t, provider.UpdatePassword("john", "new"))
+
+	gomock.InOrder(
+		first.EXPECT().GetDetails("fred").Return(nil, ErrUserNotFound),
+		second.EXPECT().GetDetails("fred").Return(nil, ErrUserNotFound),
+	)
+
+	assert.Equal(t, ErrUserNotFound, provider.UpdatePassword("fred", "new"))
+}
+
+func TestChainedUserProvider_StartupCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	first, second, third := NewMockUserProvider(ctrl), NewMockUserProvider(ctrl), NewMockUserProvider(ctrl)
+
+	provider := NewChainedUserProviderWithBackends(schema.AuthenticationBackendChainConflictModePrecedence,
+		&ChainedUserProviderBackend{Name: "first", Provider: first},
+		&ChainedUserProviderBackend{Name: "second", Optional: true, Provider: second},
+		&ChainedUserProviderBackend{Name: "third", Provider: third},
+	)
+
+	first.EXPECT().StartupCheck().Return(nil)
+	second.EXPECT().StartupCheck().Return(errors.New("connection refused"))
+	third.EXPECT().StartupCheck().Return(nil)
+
+	assert.NoError(t, provider.StartupCheck())
+
+	first.EXPECT().StartupCheck().Return(errors.New("connection refused"))
+	second.EXPECT().StartupCheck().Return(nil)
+	third.EXPECT().StartupCheck().Return(errors.New("file not found"))
+
+	assert.EqualError(t, provider.StartupCheck(), "the startup check failed for the chained authentication backends 'first', 'third'")
+}
+
+func TestChainedUserProvider_ShouldSkipFailedOptionalBackends(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	corp, first, second := NewMockUserProvider(ctrl), NewMockUserProvider(ctrl), NewMockUserProvider(ctrl)
+
+	provider := NewChainedUserProviderWithBackends(schema.AuthenticationBackendChainConflictModePrecedence,
+		&ChainedUserProviderBackend{Name: "corp", Optional: true, Domains: []string{"corp.example.com"}, Provider: corp},
+		&ChainedUserProviderBackend{Name: "first", Optional: true, Provider: first},
+		&ChainedUserProviderBackend{Name: "second", Provider: second},
+	)
+
+	corp.EXPECT().StartupCheck().Return(errors.New("connection refused"))
+	first.EXPECT().StartupCheck().Return(errors.New("file not found"))
+	second.EXPECT().StartupCheck().Return(nil)
+
+	require.NoError(t, provider.StartupCheck())
+
+	second.EXPECT().CheckUserPassword("john", "password").Return(true, nil)
+
+	valid, err := provider.CheckUserPassword("john", "password")
+
+	assert.True(t, valid)
+	assert.NoError(t, err)
+
+	second.EXPECT().GetDetails("john").Return(&UserDetails{Username: "john"}, nil).Times(2)
+	second.EXPECT().UpdatePassword("john", "new").Return(nil)
+
+	details, err := provider.GetDetails("john")
+
+	require.NoError(t, err)
+	assert.Equal(t, "john", details.Username)
+
+	assert.NoError(t, provider.UpdatePassword("john", "new"))
+
+	valid, err = provider.CheckUserPassword("john@corp.example.com", "password")
+
+	assert.False(t, valid)
+	assert.Equal(t, ErrUserNotFound, err)
+
+	details, err = provider.GetDetails("john@corp.example.com")
+
+	assert.Nil(t, details)
+	assert.Equal(t, ErrUserNotFound, err)
+
+	corp.EXPECT().StartupCheck().Return(nil)
+	first.EXPECT().StartupCheck().Return(nil)
+	second.EXPECT().StartupCheck().Return(nil)
+
+	require.NoError(t, provider.StartupCheck())
+
+	corp.EXPECT().CheckUserPassword("john@corp.example.com", "password").Return(true, nil)
+
+	valid, err = provider.CheckUserPassword("john@corp.example.com", "password")
+
+	assert.True(t, valid)
+	assert.NoError(t, err)
+}
+
+func TestChainedUserProvider_ShouldRecheckFailedOptionalBackends(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	corp, local := NewMockUserProvider(ctrl), NewMockUserProvider(ctrl)
+
+	provider := NewChainedUserProviderWithBackends(schema.AuthenticationBackendChainConflictModePrecedence,
+		&ChainedUserProviderBackend{Name: "corp", Optional: true, Domains: []string{"corp.example.com"}, Provider: corp},
+		&ChainedUserProviderBackend{Name: "local", Provider: local},
+	)
+
+	provider.recheckBackoffMin, provider.recheckBackoffMax = 0, 0
+
+	corp.EXPECT().StartupCheck().Return(errors.New("connection refused"))
+	local.EXPECT().StartupCheck().Return(nil)
+
+	require.NoError(t, provider.StartupCheck())
+
+	checked := make(chan struct{})
+
+	corp.EXPECT().StartupCheck().DoAndReturn(func() error {
+		close(checked)
+
+		return nil
+	})
+
+	valid, err := provider.CheckUserPassword("john@corp.example.com", "password")
+
+	assert.False(t, valid)
+	assert.Equal(t, ErrUserNotFound, err)
+
+	select {
+	case <-checked:
+	case <-time.After(time.Second * 5):
+		t.Fatal("the startup check of the failed backend was not retried")
+	}
+
+	assert.Eventually(t, func() bool { return !provider.backends[0].failed.Load() }, time.Second*5, time.Millisecond*10)
+
+	corp.EXPECT().CheckUserPassword("john@corp.example.com", "password").Return(true, nil)
+
+	valid, err = provider.CheckUserPassword("john@corp.example.com", "password")
+
+	assert.True(t, valid)
+	assert.NoError(t, err)
+}
+
+func TestChainedUserProvider_ShouldBackoffRecheck(t *testing.T) {
+	provider := NewChainedUserProviderWithBackends("")
+
+	provider.recheckBackoffMin, provider.recheckBackoffMax = time.Second, time.Second*3
+
+	backend := &ChainedUserProviderBackend{Name: "corp"}
+
+	expected := []time.Duration{time.Second, time.Second * 2, time.Second * 3, time.Second * 3}
+
+	for _, backoff := range expected {
+		provider.setCheckResult(backend, errors.New("connection refused"))
+
+		assert.True(t, backend.failed.Load())
+		assert.Equal(t, backoff, backend.backoff)
+		assert.WithinDuration(t, time.Now().Add(backoff), backend.retryAt, time.Second)
+	}
+
+	provider.setCheckResult(backend, nil)
+
+	assert.False(t, backend.failed.Load())
+	assert.Equal(t, time.Duration(0), backend.backoff)
+
+	backend.retryAt = time.Now().Add(time.Hour)
+	backend.failed.Store(true)
+
+	provider.recheck(backend)
+
+	assert.False(t, backend.checking)
+}
+
+func TestChainedUsernameDomain(t *testing.T) {
+	testCases := []struct {
+		name     string
+		username string
+		expected string
+	}{
+		{"ShouldReturnEmpty", "john", ""},
+		{"ShouldReturnUPNDomain", "john@corp.example.com", "corp.example.com"},
+		{"ShouldReturnUPNDomainLastAt", "john@home@corp.example.com", "corp.example.com"},
+		{"ShouldReturnNetBIOSDomain", `CORP\john`, "CORP"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, chainedUsernameDomain(tc.username))
+		})
+	}
+}
@@ -2,10 +2,19 @@ package authentication
 
 import (
 	"errors"
+	"regexp"
+	"time"
 
 	"golang.org/x/text/encoding/unicode"
 )
 
+const (
+	// The bounds of the backoff between the startup checks of an optional chained backend which failed its startup
+	// check.
+	chainedBackendRecheckBackoffMin = 10 * time.Second
+	chainedBackendRecheckBackoffMax = 5 * time.Minute
+)
+
 const (
 	ldapSupportedExtensionAttribute = "supportedExtension"
 
@@ -50,13 +59,68 @@ const (
 	ldapOIDControlMsftServerPolicyHintsDeprecated = "1.2.840.113556.1.4.2066"
 )
 
+const (
+	ldapSupportedCapabilitiesAttribute = "supportedCapabilities"
+
+	// LDAP Capability OID: Microsoft Active Directory.
+	//
+	// MS ADTS: https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-adts/3ed61e6c-cfdc-487b-b5c5-be3c4a8b7b1d
+	//
+	// OID Reference: https://oidref.com/1.2.840.113556.1.4.800
+	//
+	// See the linked documents for more information.
+	ldapOIDCapabilityActiveDirectory = "1.2.840.113556.1.4.800"
+
+	// LDAP Matching Rule OID: Microsoft LDAP_MATCHING_RULE_IN_CHAIN.
+	//
+	// MS ADTS: https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-adts/4e638665-f466-4597-93c4-12f2ebfabab5
+	//
+	// OID Reference: https://oidref.com/1.2.840.113556.1.4.1941
+	//
+	// See the linked documents for more information.
+	ldapOIDMatchingRuleInChain = "1.2.840.113556.1.4.1941"
+)
+
 const (
 	ldapAttributeUnicodePwd   = "unicodePwd"
 	ldapAttributeUserPassword = "userPassword"
+
+	ldapAttributeObjectClass          = "objectClass"
+	ldapAttributeCommonName           = "cn"
+	ldapAttributeSurname              = "sn"
+	ldapAttributeMemberUID            = "memberUid"
+	ldapAttributeUserAccountControl   = "userAccountControl"
+	ldapAttributeNSAccountLock        = "nsAccountLock"
+	ldapAttributePwdAccountLockedTime = "pwdAccountLockedTime"
+)
+
+const (
+	ldapObjectClassPerson = "person"
+
+	// LDAP userAccountControl flags for Active Directory.
+	//
+	// MS ADTS: https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-adts/dd302fd1-0aa7-406b-ad91-2a6b35738557
+	ldapUserAccountControlAccountDisable = 0x0002
+	ldapUserAccountControlNormalAccount  = 0x0200
+
+	// The special pwdAccountLockedTime value which indicates the account is locked until an administrator unlocks it.
+	//
+	// Password Policy for LDAP Directories: https://datatracker.ietf.org/doc/html/draft-behera-ldap-password-policy-11
+	ldapPwdAccountLockedTimePermanent = "000001010000Z"
 )
 
 const (
 	ldapBaseObjectFilter = "(objectClass=*)"
+
+	// ldapNoAttributes is the special attribute selector which requests no attributes be returned.
+	ldapNoAttributes = "1.1"
+)
+
+const (
+	ldapPoolAcquireReused  = "reused"
+	ldapPoolAcquireDialed  = "dialed"
+	ldapPoolAcquireTimeout = "timeout"
+	ldapPoolAcquireFailure = "failure"
 )
 
 const (
@@ -95,12 +159,57 @@ var (
 	// ErrUserNotFound indicates the user wasn't found in the authentication backend.
 	ErrUserNotFound = errors.New("user not found")
 
+	// ErrUserConflict indicates the user was found in more than one authentication backend.
+	ErrUserConflict = errors.New("user exists in more than one backend")
+
+	// ErrNotInitialized indicates the authentication backend was used before it was successfully initialized by the
+	// startup check.
+	ErrNotInitialized = errors.New("the authentication backend has not been initialized")
+
+	// ErrAdministrationDisabled indicates the administration of users is not enabled for the authentication backend.
+	ErrAdministrationDisabled = errors.New("user administration is not enabled")
+
 	// ErrNoContent is returned when the file is empty.
 	ErrNoContent = errors.New("no file content")
+
+	// ErrLDAPPoolTimeout is returned when a pooled LDAP connection does not become available in time.
+	ErrLDAPPoolTimeout = errors.New("timeout waiting for a pooled connection")
+
+	// ErrLDAPPoolClosed is returned when a pooled LDAP connection is requested after the pool is closed.
+	ErrLDAPPoolClosed = errors.New("the connection pool is closed")
 )
 
 const fileAuthenticationMode = 0600
 
+const (
+	fileDatabaseKeyUsers       = "users"
+	fileDatabaseKeyPassword    = "password"
+	fileDatabaseKeyDisplayName = "displayname"
+	fileDatabaseKeyEmail       = "email"
+	fileDatabaseKeyGroups      = "groups"
+	fileDatabaseKeyDisabled    = "disabled"
+	fileDatabaseKeyAttributes  = "attributes"
+)
+
+var (
+	reFileDatabaseAttributeName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+	fileDatabaseReservedAttributeNames = []string{"user", "username", "name", "display_name", "email", "emails", "groups"}
+)
+
+// reLDAPFilterDistinguishedNameAssertion matches the equality assertions in a filter which assert the value is the
+// {dn} placeholder, which are replaced with the LDAP_MATCHING_RULE_IN_CHAIN extensible match when nested groups are
+// resolved by the directory server.
+var reLDAPFilterDistinguishedNameAssertion = regexp.MustCompile(`\(([a-zA-Z][a-zA-Z0-9-]*)=\{dn\}\)`)
+
+const (
+	yamlTagStr  = "!!str"
+	yamlTagBool = "!!bool"
+	yamlTagNull = "!!null"
+	yamlTagMap  = "!!map"
+	yamlTagSeq  = "!!seq"
+)
+
 // OWASP recommends to escape some special characters.
 // https://github.com/OWASP/CheatSheetSeries/blob/master/cheatsheets/LDAP_Injection_Prevention_Cheat_Sheet.md
 const specialLDAPRunes = ",#+<>;\"="
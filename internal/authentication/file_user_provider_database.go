@@ -1,6 +1,7 @@
 package authentication
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 type FileUserProviderDatabase interface {
@@ -80,6 +82,19 @@ func (m *FileUserDatabase) Load() (err error) {
 	return m.LoadAliases()
 }
 
+// Validate the database using the alias rules applied when it's loaded such as the uniqueness of emails when email
+// search is enabled.
+func (m *FileUserDatabase) Validate() (err error) {
+	m.Lock()
+
+	defer m.Unlock()
+
+	m.Emails = map[string]string{}
+	m.Aliases = map[string]string{}
+
+	return m.LoadAliases()
+}
+
 // LoadAliases performs the loading of alias information from the database.
 func (m *FileUserDatabase) LoadAliases() (err error) {
 	if m.SearchEmail || m.SearchCI {
@@ -194,6 +209,15 @@ func (m *FileUserDatabase) SetUserDetails(username string, details *FileUserData
 	m.Unlock()
 }
 
+// DeleteUserDetails deletes the FileUserDatabaseUserDetails for a given user.
+func (m *FileUserDatabase) DeleteUserDetails(username string) {
+	m.Lock()
+
+	delete(m.Users, username)
+
+	m.Unlock()
+}
+
 // ToDatabaseModel converts the FileUserDatabase into the FileDatabaseModel for saving.
 func (m *FileUserDatabase) ToDatabaseModel() (model *FileDatabaseModel) {
 	model = &FileDatabaseModel{
@@ -219,6 +243,7 @@ type FileUserDatabaseUserDetails struct {
 	Email       string                 `json:"email" jsonschema:"title=Email" jsonschema_description:"The email for the user."`
 	Groups      []string               `json:"groups" jsonschema:"title=Groups" jsonschema_description:"The groups list for the user."`
 	Disabled    bool                   `json:"disabled" jsonschema:"default=false,title=Disabled" jsonschema_description:"The disabled status for the user."`
+	Attributes  map[string]string      `json:"attributes" jsonschema:"title=Attributes" jsonschema_description:"The extended attributes for the user."`
 }
 
 // ToUserDetails converts FileUserDatabaseUserDetails into a *UserDetails given a username.
@@ -228,6 +253,7 @@ func (m FileUserDatabaseUserDetails) ToUserDetails() (details *UserDetails) {
 		DisplayName: m.DisplayName,
 		Emails:      []string{m.Email},
 		Groups:      m.Groups,
+		Attributes:  m.Attributes,
 	}
 }
 
@@ -238,6 +264,8 @@ func (m FileUserDatabaseUserDetails) ToUserDetailsModel() (model FileDatabaseUse
 		DisplayName: m.DisplayName,
 		Email:       m.Email,
 		Groups:      m.Groups,
+		Disabled:    m.Disabled,
+		Attributes:  m.Attributes,
 	}
 }
 
@@ -267,10 +295,7 @@ func (m *FileDatabaseModel) ReadToFileUserDatabase(db *FileUserDatabase) (err er
 
 // Read a FileDatabaseModel from disk.
 func (m *FileDatabaseModel) Read(filePath string) (err error) {
-	var (
-		content []byte
-		ok      bool
-	)
+	var content []byte
 
 	if content, err = os.ReadFile(filePath); err != nil {
 		return fmt.Errorf("failed to read the '%s' file: %w", filePath, err)
@@ -284,6 +309,28 @@ func (m *FileDatabaseModel) Read(filePath string) (err error) {
 		return fmt.Errorf("could not parse the YAML database: %w", err)
 	}
 
+	return m.validateSchema()
+}
+
+// Validate the FileDatabaseModel using the same rules applied when it's read from disk including the decoding of the
+// password digests.
+func (m *FileDatabaseModel) Validate() (err error) {
+	if err = m.validateSchema(); err != nil {
+		return err
+	}
+
+	for user, details := range m.Users {
+		if _, err = details.ToDatabaseUserDetailsModel(user); err != nil {
+			return fmt.Errorf("failed to parse hash for user '%s': %w", user, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *FileDatabaseModel) validateSchema() (err error) {
+	var ok bool
+
 	if ok, err = govalidator.ValidateStruct(m); err != nil {
 		return fmt.Errorf("could not validate the schema: %w", err)
 	}
@@ -292,29 +339,68 @@ func (m *FileDatabaseModel) Read(filePath string) (err error) {
 		return fmt.Errorf("the schema is invalid")
 	}
 
+	for user, details := range m.Users {
+		for name := range details.Attributes {
+			if !reFileDatabaseAttributeName.MatchString(name) || utils.IsStringInSlice(name, fileDatabaseReservedAttributeNames) {
+				return fmt.Errorf("could not validate the schema: Users.%s.attributes: the attribute name '%s' is invalid as it's either reserved or doesn't start with a lowercase letter and only contain lowercase letters, numbers, and underscores", user, name)
+			}
+		}
+	}
+
 	return nil
 }
 
-// Write a FileDatabaseModel to disk.
+// Write a FileDatabaseModel to disk. If the file already exists the model is merged into the existing content so the
+// comments and ordering are retained.
 func (m *FileDatabaseModel) Write(fileName string) (err error) {
-	var (
-		data []byte
-	)
+	var content, data []byte
 
-	if data, err = yaml.Marshal(m); err != nil {
+	if content, err = os.ReadFile(fileName); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read the '%s' file: %w", fileName, err)
+	}
+
+	if data, err = m.Marshal(content); err != nil {
 		return err
 	}
 
 	return os.WriteFile(fileName, data, fileAuthenticationMode)
 }
 
+// Marshal the FileDatabaseModel into YAML. If the existing content is not empty the model is merged into it. The
+// resulting YAML is validated before it's returned.
+func (m *FileDatabaseModel) Marshal(existing []byte) (data []byte, err error) {
+	if len(bytes.TrimSpace(existing)) == 0 {
+		data, err = yaml.Marshal(m)
+	} else {
+		data, err = fileDatabaseMerge(existing, m)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &FileDatabaseModel{}
+
+	if err = yaml.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("could not parse the resulting YAML database: %w", err)
+	}
+
+	if err = result.Validate(); err != nil {
+		return nil, fmt.Errorf("the resulting YAML database is invalid: %w", err)
+	}
+
+	return data, nil
+}
+
 // FileDatabaseUserDetailsModel is the model of user details in the file database.
 type FileDatabaseUserDetailsModel struct {
 	Password    string   `yaml:"password" valid:"required"`
 	DisplayName string   `yaml:"displayname" valid:"required"`
-	Email       string   `yaml:"email"`
-	Groups      []string `yaml:"groups"`
-	Disabled    bool     `yaml:"disabled"`
+	Email       string   `yaml:"email,omitempty"`
+	Groups      []string `yaml:"groups,omitempty"`
+	Disabled    bool     `yaml:"disabled,omitempty"`
+
+	Attributes map[string]string `yaml:"attributes,omitempty"`
 }
 
 // ToDatabaseUserDetailsModel converts a FileDatabaseUserDetailsModel into a *FileUserDatabaseUserDetails.
@@ -332,5 +418,6 @@ func (m FileDatabaseUserDetailsModel) ToDatabaseUserDetailsModel(username string
 		DisplayName: m.DisplayName,
 		Email:       m.Email,
 		Groups:      m.Groups,
+		Attributes:  m.Attributes,
 	}, nil
 }
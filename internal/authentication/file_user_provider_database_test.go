@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDatabaseModel_Read(t *testing.T) {
@@ -37,3 +39,221 @@ func TestDatabaseModel_Read(t *testing.T) {
 
 	assert.EqualError(t, model.Read(f), "could not parse the YAML database: yaml: line 2: found character that cannot start any token")
 }
+
+func TestDatabaseModel_WriteShouldRetainCommentsAndOrdering(t *testing.T) {
+	dir := t.TempDir()
+
+	f := filepath.Join(dir, "users_database.yml")
+
+	require.NoError(t, os.WriteFile(f, []byte(testDatabaseModelWriteContent), 0600))
+
+	model := &FileDatabaseModel{}
+
+	require.NoError(t, model.Read(f))
+
+	john := model.Users["john"]
+	john.Disabled = true
+	john.Groups = []string{"dev", "ops"}
+
+	model.Users["john"] = john
+	model.Users["fred"] = FileDatabaseUserDetailsModel{Password: testDatabaseModelDigest, DisplayName: "Fred", Groups: []string{"users"}}
+	model.Users["abe"] = FileDatabaseUserDetailsModel{Password: testDatabaseModelDigest, DisplayName: "Abe"}
+
+	delete(model.Users, "harry")
+
+	require.NoError(t, model.Write(f))
+
+	data, err := os.ReadFile(f)
+	require.NoError(t, err)
+
+	assert.Equal(t, testDatabaseModelWriteExpected, string(data))
+
+	model = &FileDatabaseModel{}
+
+	require.NoError(t, model.Read(f))
+
+	assert.Len(t, model.Users, 3)
+	assert.True(t, model.Users["john"].Disabled)
+}
+
+func TestDatabaseModel_WriteShouldCreateFile(t *testing.T) {
+	dir := t.TempDir()
+
+	f := filepath.Join(dir, "users_database.yml")
+
+	model := &FileDatabaseModel{Users: map[string]FileDatabaseUserDetailsModel{
+		"john": {Password: testDatabaseModelDigest, DisplayName: "John Doe"},
+	}}
+
+	require.NoError(t, model.Write(f))
+
+	data, err := os.ReadFile(f)
+	require.NoError(t, err)
+
+	assert.Equal(t, "users:\n    john:\n        password: "+testDatabaseModelDigest+"\n        displayname: John Doe\n", string(data))
+}
+
+func TestDatabaseModel_WriteShouldMergeAttributes(t *testing.T) {
+	dir := t.TempDir()
+
+	f := filepath.Join(dir, "users_database.yml")
+
+	require.NoError(t, os.WriteFile(f, []byte("users:\n  john:\n    password: "+testDatabaseModelDigest+"\n    displayname: John Doe\n    attributes:\n      # The family name.\n      family_name: Doe\n      given_name: John\n      locale: en-US\n"), 0600))
+
+	model := &FileDatabaseModel{}
+
+	require.NoError(t, model.Read(f))
+
+	assert.Equal(t, map[string]string{"family_name": "Doe", "given_name": "John", "locale": "en-US"}, model.Users["john"].Attributes)
+
+	john := model.Users["john"]
+	john.Attributes = map[string]string{"family_name": "Smith", "given_name": "John", "phone_number": "+1 555 0100", "department": "Engineering"}
+
+	model.Users["john"] = john
+
+	require.NoError(t, model.Write(f))
+
+	data, err := os.ReadFile(f)
+	require.NoError(t, err)
+
+	assert.Equal(t, "users:\n  john:\n    password: "+testDatabaseModelDigest+"\n    displayname: John Doe\n    attributes:\n      # The family name.\n      family_name: Smith\n      given_name: John\n      department: Engineering\n      phone_number: +1 555 0100\n", string(data))
+
+	details, err := john.ToDatabaseUserDetailsModel("john")
+	require.NoError(t, err)
+
+	assert.Equal(t, john.Attributes, details.ToUserDetails().GetAttributes())
+}
+
+func TestDatabaseModel_MarshalShouldValidate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		existing string
+		model    *FileDatabaseModel
+		err      string
+	}{
+		{
+			"ShouldErrorMissingDisplayName",
+			"",
+			&FileDatabaseModel{Users: map[string]FileDatabaseUserDetailsModel{"john": {Password: testDatabaseModelDigest}}},
+			"the resulting YAML database is invalid: could not validate the schema: Users.john.users: non zero value required",
+		},
+		{
+			"ShouldErrorBadDigest",
+			"users: {}\n",
+			&FileDatabaseModel{Users: map[string]FileDatabaseUserDetailsModel{"john": {Password: "abc", DisplayName: "John"}}},
+			"the resulting YAML database is invalid: failed to parse hash for user 'john': provided encoded hash has an invalid format: the digest doesn't begin with the delimiter '$' and is not one of the other understood formats",
+		},
+		{
+			"ShouldErrorInvalidAttributeName",
+			"",
+			&FileDatabaseModel{Users: map[string]FileDatabaseUserDetailsModel{"john": {Password: testDatabaseModelDigest, DisplayName: "John", Attributes: map[string]string{"Phone": "123"}}}},
+			"the resulting YAML database is invalid: could not validate the schema: Users.john.attributes: the attribute name 'Phone' is invalid as it's either reserved or doesn't start with a lowercase letter and only contain lowercase letters, numbers, and underscores",
+		},
+		{
+			"ShouldErrorReservedAttributeName",
+			"",
+			&FileDatabaseModel{Users: map[string]FileDatabaseUserDetailsModel{"john": {Password: testDatabaseModelDigest, DisplayName: "John", Attributes: map[string]string{"groups": "admins"}}}},
+			"the resulting YAML database is invalid: could not validate the schema: Users.john.attributes: the attribute name 'groups' is invalid as it's either reserved or doesn't start with a lowercase letter and only contain lowercase letters, numbers, and underscores",
+		},
+		{
+			"ShouldErrorNotMapping",
+			"- abc\n",
+			&FileDatabaseModel{Users: map[string]FileDatabaseUserDetailsModel{"john": {Password: testDatabaseModelDigest, DisplayName: "John"}}},
+			"could not parse the YAML database: the document root is not a mapping",
+		},
+		{
+			"ShouldErrorUsersNotMapping",
+			"users: abc\n",
+			&FileDatabaseModel{Users: map[string]FileDatabaseUserDetailsModel{"john": {Password: testDatabaseModelDigest, DisplayName: "John"}}},
+			"could not parse the YAML database: the 'users' key is not a mapping",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := tc.model.Marshal([]byte(tc.existing))
+
+			assert.EqualError(t, err, tc.err)
+			assert.Nil(t, data)
+		})
+	}
+}
+
+func TestFileUserDatabase_DeleteUserDetails(t *testing.T) {
+	database := NewFileUserDatabase("", false, false)
+
+	database.SetUserDetails("john", &FileUserDatabaseUserDetails{Username: "john"})
+
+	_, err := database.GetUserDetails("john")
+	assert.NoError(t, err)
+
+	database.DeleteUserDetails("john")
+
+	_, err = database.GetUserDetails("john")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+const testDatabaseModelDigest = "$argon2id$v=19$m=65536,t=3,p=2$BpLnfgDsc2WD8F2q$o/vzA4myCqZZ36bUGsDY//8mKUYNZZaR0t4MFFSs+iM"
+
+const testDatabaseModelWriteContent = `# yamllint disable rule:line-length
+---
+# The users database.
+
+users:
+  # John is an administrator.
+  john:
+    displayname: "John Doe"
+    password: "$argon2id$v=19$m=65536,t=3,p=2$BpLnfgDsc2WD8F2q$o/vzA4myCqZZ36bUGsDY//8mKUYNZZaR0t4MFFSs+iM"  # Password is 'password'
+    email: john.doe@authelia.com
+    groups:
+      - admins  # Remove when John leaves.
+      - dev
+  harry:
+    displayname: "Harry Potter"
+    password: "$argon2id$v=19$m=65536,t=3,p=2$BpLnfgDsc2WD8F2q$o/vzA4myCqZZ36bUGsDY//8mKUYNZZaR0t4MFFSs+iM"
+...
+# yamllint enable rule:line-length
+`
+
+const testDatabaseModelWriteExpected = `# yamllint disable rule:line-length
+---
+# The users database.
+
+users:
+  # John is an administrator.
+  john:
+    displayname: "John Doe"
+    password: "$argon2id$v=19$m=65536,t=3,p=2$BpLnfgDsc2WD8F2q$o/vzA4myCqZZ36bUGsDY//8mKUYNZZaR0t4MFFSs+iM" # Password is 'password'
+    email: john.doe@authelia.com
+    groups:
+      - dev
+      - ops
+    disabled: true
+  abe:
+    password: $argon2id$v=19$m=65536,t=3,p=2$BpLnfgDsc2WD8F2q$o/vzA4myCqZZ36bUGsDY//8mKUYNZZaR0t4MFFSs+iM
+    displayname: Abe
+  fred:
+    password: $argon2id$v=19$m=65536,t=3,p=2$BpLnfgDsc2WD8F2q$o/vzA4myCqZZ36bUGsDY//8mKUYNZZaR0t4MFFSs+iM
+    displayname: Fred
+    groups:
+      - users
+...
+# yamllint enable rule:line-length
+`
+
+func TestFileUserDatabase_Validate(t *testing.T) {
+	database := NewFileUserDatabase("", true, false)
+
+	database.SetUserDetails("john", &FileUserDatabaseUserDetails{Username: "john", Email: "john@example.com"})
+
+	assert.NoError(t, database.Validate())
+
+	database.SetUserDetails("fred", &FileUserDatabaseUserDetails{Username: "fred", Email: "john@example.com"})
+
+	assert.Regexp(t, regexp.MustCompile(`^error loading authentication database: email 'john@example.com' is configured for for more than one user \(users are '(john|fred)', '(john|fred)'\) which isn't allowed when email search is enabled$`), database.Validate().Error())
+
+	database.DeleteUserDetails("fred")
+
+	assert.NoError(t, database.Validate())
+	assert.Equal(t, map[string]string{"john@example.com": "john"}, database.Emails)
+}
@@ -0,0 +1,283 @@
+package authentication
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fileDatabaseMerge merges the FileDatabaseModel into the existing YAML content retaining the comments and the
+// ordering of the existing content. Users which exist in the content but not in the model are removed, users which
+// exist in both are updated in place, and users which only exist in the model are appended in lexical order.
+func fileDatabaseMerge(content []byte, model *FileDatabaseModel) (data []byte, err error) {
+	head, body, foot := fileDatabaseSplitDocumentMarkers(content)
+
+	doc := &yaml.Node{}
+
+	if err = yaml.Unmarshal(body, doc); err != nil {
+		return nil, fmt.Errorf("could not parse the YAML database: %w", err)
+	}
+
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("could not parse the YAML database: the document root is not a mapping")
+	}
+
+	root := doc.Content[0]
+
+	users := yamlMappingValue(root, fileDatabaseKeyUsers)
+
+	switch {
+	case users == nil:
+		users = &yaml.Node{Kind: yaml.MappingNode, Tag: yamlTagMap}
+
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: yamlTagStr, Value: fileDatabaseKeyUsers}, users)
+	case users.Kind == yaml.ScalarNode && users.Tag == yamlTagNull:
+		users.Kind, users.Tag, users.Value, users.Style = yaml.MappingNode, yamlTagMap, "", 0
+	case users.Kind != yaml.MappingNode:
+		return nil, fmt.Errorf("could not parse the YAML database: the '%s' key is not a mapping", fileDatabaseKeyUsers)
+	}
+
+	seen := make(map[string]bool, len(model.Users))
+	nodes := make([]*yaml.Node, 0, len(model.Users)*2)
+
+	for i := 0; i+1 < len(users.Content); i += 2 {
+		key, value := users.Content[i], users.Content[i+1]
+
+		details, ok := model.Users[key.Value]
+		if !ok {
+			continue
+		}
+
+		seen[key.Value] = true
+
+		if value.Kind == yaml.MappingNode {
+			fileDatabaseMergeUser(value, details)
+		} else if err = value.Encode(details); err != nil {
+			return nil, fmt.Errorf("failed to encode user '%s': %w", key.Value, err)
+		}
+
+		nodes = append(nodes, key, value)
+	}
+
+	names := make([]string, 0, len(model.Users)-len(seen))
+
+	for name := range model.Users {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := &yaml.Node{}
+
+		if err = value.Encode(model.Users[name]); err != nil {
+			return nil, fmt.Errorf("failed to encode user '%s': %w", name, err)
+		}
+
+		nodes = append(nodes, &yaml.Node{Kind: yaml.ScalarNode, Tag: yamlTagStr, Value: name}, value)
+	}
+
+	users.Content = nodes
+
+	buf := &bytes.Buffer{}
+
+	encoder := yaml.NewEncoder(buf)
+
+	encoder.SetIndent(2)
+
+	if err = encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+
+	if err = encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	data = make([]byte, 0, len(head)+buf.Len()+len(foot))
+
+	data = append(data, head...)
+	data = append(data, buf.Bytes()...)
+	data = append(data, foot...)
+
+	return data, nil
+}
+
+func fileDatabaseMergeUser(node *yaml.Node, details FileDatabaseUserDetailsModel) {
+	yamlMappingSetScalar(node, fileDatabaseKeyPassword, details.Password, yamlTagStr, true)
+	yamlMappingSetScalar(node, fileDatabaseKeyDisplayName, details.DisplayName, yamlTagStr, true)
+	yamlMappingSetScalar(node, fileDatabaseKeyEmail, details.Email, yamlTagStr, details.Email != "")
+	yamlMappingSetSequence(node, fileDatabaseKeyGroups, details.Groups, len(details.Groups) != 0)
+	yamlMappingSetScalar(node, fileDatabaseKeyDisabled, strconv.FormatBool(details.Disabled), yamlTagBool, details.Disabled)
+	yamlMappingSetMapping(node, fileDatabaseKeyAttributes, details.Attributes, len(details.Attributes) != 0)
+}
+
+// fileDatabaseSplitDocumentMarkers splits the explicit document start and end markers and any comments outside of them
+// from the content, as these are not reliably retained when the content is decoded to and encoded from a yaml.Node.
+func fileDatabaseSplitDocumentMarkers(content []byte) (head, body, foot []byte) {
+	lines := bytes.SplitAfter(content, []byte("\n"))
+
+	start, end := 0, len(lines)
+
+	for i, line := range lines {
+		trimmed := bytes.TrimSpace(line)
+
+		if bytes.Equal(trimmed, []byte("---")) {
+			start = i + 1
+
+			break
+		}
+
+		if len(trimmed) != 0 && trimmed[0] != '#' {
+			break
+		}
+	}
+
+	for i := len(lines) - 1; i >= start; i-- {
+		trimmed := bytes.TrimSpace(lines[i])
+
+		if bytes.Equal(trimmed, []byte("...")) {
+			end = i
+
+			break
+		}
+
+		if len(trimmed) != 0 && trimmed[0] != '#' {
+			break
+		}
+	}
+
+	return bytes.Join(lines[:start], nil), bytes.Join(lines[start:end], nil), bytes.Join(lines[end:], nil)
+}
+
+func yamlMappingValue(node *yaml.Node, key string) (value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+func yamlMappingSetScalar(node *yaml.Node, key, value, tag string, add bool) {
+	if current := yamlMappingValue(node, key); current != nil {
+		if current.Kind == yaml.ScalarNode && current.Value == value {
+			return
+		}
+
+		current.Kind, current.Tag, current.Value, current.Content = yaml.ScalarNode, tag, value, nil
+
+		if tag != yamlTagStr {
+			current.Style = 0
+		}
+
+		return
+	}
+
+	if !add {
+		return
+	}
+
+	node.Content = append(node.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: yamlTagStr, Value: key},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value},
+	)
+}
+
+func yamlMappingSetSequence(node *yaml.Node, key string, values []string, add bool) {
+	current := yamlMappingValue(node, key)
+
+	if current == nil {
+		if !add {
+			return
+		}
+
+		current = &yaml.Node{Kind: yaml.SequenceNode, Tag: yamlTagSeq}
+
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: yamlTagStr, Value: key}, current)
+	}
+
+	existing := map[string]*yaml.Node{}
+
+	if current.Kind == yaml.SequenceNode {
+		for _, item := range current.Content {
+			if item.Kind == yaml.ScalarNode {
+				existing[item.Value] = item
+			}
+		}
+	} else {
+		current.Kind, current.Tag, current.Value, current.Style = yaml.SequenceNode, yamlTagSeq, "", 0
+	}
+
+	content := make([]*yaml.Node, len(values))
+
+	for i, value := range values {
+		if item, ok := existing[value]; ok {
+			content[i] = item
+		} else {
+			content[i] = &yaml.Node{Kind: yaml.ScalarNode, Tag: yamlTagStr, Value: value}
+		}
+	}
+
+	current.Content = content
+}
+
+func yamlMappingSetMapping(node *yaml.Node, key string, values map[string]string, add bool) {
+	current := yamlMappingValue(node, key)
+
+	if current == nil {
+		if !add {
+			return
+		}
+
+		current = &yaml.Node{Kind: yaml.MappingNode, Tag: yamlTagMap}
+
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: yamlTagStr, Value: key}, current)
+	} else if current.Kind != yaml.MappingNode {
+		current.Kind, current.Tag, current.Value, current.Style, current.Content = yaml.MappingNode, yamlTagMap, "", 0, nil
+	}
+
+	seen := make(map[string]bool, len(values))
+	content := make([]*yaml.Node, 0, len(values)*2)
+
+	for i := 0; i+1 < len(current.Content); i += 2 {
+		k, v := current.Content[i], current.Content[i+1]
+
+		value, ok := values[k.Value]
+		if !ok {
+			continue
+		}
+
+		seen[k.Value] = true
+
+		if v.Kind != yaml.ScalarNode || v.Value != value {
+			v.Kind, v.Tag, v.Value, v.Content = yaml.ScalarNode, yamlTagStr, value, nil
+		}
+
+		content = append(content, k, v)
+	}
+
+	names := make([]string, 0, len(values)-len(seen))
+
+	for name := range values {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		content = append(content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: yamlTagStr, Value: name},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: yamlTagStr, Value: values[name]},
+		)
+	}
+
+	current.Content = content
+}
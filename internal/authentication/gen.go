@@ -5,5 +5,8 @@ package authentication
 
 //go:generate mockgen -package authentication -destination ldap_client_mock_test.go -mock_names LDAPClient=MockLDAPClient github.com/authelia/authelia/v4/internal/authentication LDAPClient
 //go:generate mockgen -package authentication -destination ldap_client_factory_mock_test.go -mock_names LDAPClientFactory=MockLDAPClientFactory github.com/authelia/authelia/v4/internal/authentication LDAPClientFactory
+//go:generate mockgen -package authentication -destination ldap_pool_metrics_recorder_mock_test.go -mock_names LDAPPoolMetricsRecorder=MockLDAPPoolMetricsRecorder github.com/authelia/authelia/v4/internal/authentication LDAPPoolMetricsRecorder
 //go:generate mockgen -package authentication -destination file_user_provider_database_mock_test.go -mock_names FileUserDatabase=MockFileUserDatabase github.com/authelia/authelia/v4/internal/authentication FileUserDatabase
 //go:generate mockgen -package authentication -destination file_user_provider_hash_mock_test.go -mock_names Hash=MockHash github.com/go-crypt/crypt/algorithm Hash
+//go:generate mockgen -package authentication -destination sql_user_provider_storage_mock_test.go -mock_names UserDatabaseProvider=MockUserDatabaseProvider github.com/authelia/authelia/v4/internal/storage UserDatabaseProvider
+//go:generate mockgen -package authentication -destination user_provider_mock_test.go -mock_names UserProvider=MockUserProvider github.com/authelia/authelia/v4/internal/authentication UserProvider
@@ -1,7 +1,14 @@
 package authentication
 
 import (
+	"sync"
+	"time"
+
 	"github.com/go-ldap/ldap/v3"
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
 )
 
 // ProductionLDAPClientFactory the production implementation of an ldap connection factory.
@@ -16,3 +23,383 @@ func NewProductionLDAPClientFactory() *ProductionLDAPClientFactory {
 func (f *ProductionLDAPClientFactory) DialURL(addr string, opts ...ldap.DialOpt) (client LDAPClient, err error) {
 	return ldap.DialURL(addr, opts...)
 }
+
+// PooledLDAPClientFactory is a LDAPClientFactory which maintains a pool of clients which are bound as the service
+// account. Clients created via DialURL are never pooled and are dialed using the underlying LDAPClientFactory, which
+// ensures user binds and referrals never share a connection with the service account.
+type PooledLDAPClientFactory struct {
+	factory LDAPClientFactory
+	dial    func() (client LDAPClient, err error)
+	config  schema.AuthenticationBackendLDAPPooling
+	name    string
+	metrics LDAPPoolMetricsRecorder
+	log     *logrus.Entry
+
+	slots chan struct{}
+	done  chan struct{}
+	wg    sync.WaitGroup
+
+	mu     sync.Mutex
+	idle   []LDAPClient
+	open   int
+	closed bool
+}
+
+// NewPooledLDAPClientFactory creates a new PooledLDAPClientFactory which dials clients using the underlying
+// LDAPClientFactory, and dials the pooled clients using the dial func which must return a client bound as the service
+// account. The metrics recorder is optional.
+func NewPooledLDAPClientFactory(factory LDAPClientFactory, config schema.AuthenticationBackendLDAPPooling, name string, metrics LDAPPoolMetricsRecorder, dial func() (client LDAPClient, err error)) (pool *PooledLDAPClientFactory) {
+	if config.MaxOpen <= 0 {
+		config.MaxOpen = schema.DefaultLDAPAuthenticationBackendConfigurationPooling.MaxOpen
+	}
+
+	pool = &PooledLDAPClientFactory{
+		factory: factory,
+		dial:    dial,
+		config:  config,
+		name:    name,
+		metrics: metrics,
+		log:     logging.Logger().WithField("backend", name),
+		slots:   make(chan struct{}, config.MaxOpen),
+		done:    make(chan struct{}),
+	}
+
+	if config.HealthCheckInterval > 0 {
+		pool.wg.Add(1)
+
+		go pool.run()
+	}
+
+	return pool
+}
+
+// DialURL creates a client from an LDAP URL using the underlying LDAPClientFactory. The client is not pooled.
+func (f *PooledLDAPClientFactory) DialURL(addr string, opts ...ldap.DialOpt) (client LDAPClient, err error) {
+	return f.factory.DialURL(addr, opts...)
+}
+
+// GetClient returns a client bound as the service account from the pool, dialing a new client if there are no idle
+// clients. Closing the returned client releases it back to the pool.
+func (f *PooledLDAPClientFactory) GetClient() (client LDAPClient, err error) {
+	started := time.Now()
+
+	if err = f.acquire(); err != nil {
+		f.recordAcquire(ldapPoolAcquireTimeout, started)
+
+		return nil, err
+	}
+
+	f.mu.Lock()
+
+	if f.closed {
+		f.mu.Unlock()
+
+		<-f.slots
+
+		return nil, ErrLDAPPoolClosed
+	}
+
+	for len(f.idle) != 0 {
+		client, f.idle = f.idle[len(f.idle)-1], f.idle[:len(f.idle)-1]
+
+		if client.IsClosing() {
+			f.open--
+
+			f.log.Trace("Discarding closed pooled LDAP connection")
+
+			continue
+		}
+
+		f.mu.Unlock()
+
+		f.recordAcquire(ldapPoolAcquireReused, started)
+
+		return &pooledLDAPClient{LDAPClient: client, pool: f}, nil
+	}
+
+	f.open++
+
+	f.mu.Unlock()
+
+	if client, err = f.dial(); err != nil {
+		f.mu.Lock()
+
+		f.open--
+
+		f.mu.Unlock()
+
+		<-f.slots
+
+		f.recordAcquire(ldapPoolAcquireFailure, started)
+
+		return nil, err
+	}
+
+	f.recordAcquire(ldapPoolAcquireDialed, started)
+
+	return &pooledLDAPClient{LDAPClient: client, pool: f}, nil
+}
+
+// Close stops the health checks, waiting for any health check in progress to complete, and closes all of the idle
+// clients. Clients which are in use are closed when they're released.
+func (f *PooledLDAPClientFactory) Close() (err error) {
+	f.mu.Lock()
+
+	if f.closed {
+		f.mu.Unlock()
+
+		return nil
+	}
+
+	f.closed = true
+
+	idle := f.idle
+
+	f.idle = nil
+	f.open -= len(idle)
+
+	f.mu.Unlock()
+
+	close(f.done)
+
+	f.wg.Wait()
+
+	for _, client := range idle {
+		if e := client.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	f.recordConnections()
+
+	return err
+}
+
+func (f *PooledLDAPClientFactory) acquire() (err error) {
+	if f.config.Timeout <= 0 {
+		f.slots <- struct{}{}
+
+		return nil
+	}
+
+	timer := time.NewTimer(f.config.Timeout)
+
+	defer timer.Stop()
+
+	select {
+	case f.slots <- struct{}{}:
+		return nil
+	case <-timer.C:
+		return ErrLDAPPoolTimeout
+	}
+}
+
+func (f *PooledLDAPClientFactory) release(client LDAPClient, discard bool) (err error) {
+	defer func() {
+		<-f.slots
+	}()
+
+	f.mu.Lock()
+
+	if discard || f.closed || client.IsClosing() || len(f.idle) >= f.config.MaxIdle {
+		f.open--
+
+		f.mu.Unlock()
+
+		f.recordConnections()
+
+		return client.Close()
+	}
+
+	f.idle = append(f.idle, client)
+
+	f.mu.Unlock()
+
+	f.recordConnections()
+
+	return nil
+}
+
+func (f *PooledLDAPClientFactory) run() {
+	defer f.wg.Done()
+
+	ticker := time.NewTicker(f.config.HealthCheckInterval)
+
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-f.done:
+			return
+		case <-ticker.C:
+			f.check()
+		}
+	}
+}
+
+// check performs a health check of every idle client, closing the clients which fail the check so that they're
+// replaced with a newly dialed client when next required.
+func (f *PooledLDAPClientFactory) check() {
+	f.mu.Lock()
+
+	idle := f.idle
+
+	f.idle = nil
+
+	f.mu.Unlock()
+
+	healthy := make([]LDAPClient, 0, len(idle))
+
+	for _, client := range idle {
+		if err := f.ping(client); err != nil {
+			f.log.WithError(err).Debug("Discarding pooled LDAP connection which failed the health check")
+
+			_ = client.Close()
+
+			f.recordHealthCheck(false)
+
+			continue
+		}
+
+		f.recordHealthCheck(true)
+
+		healthy = append(healthy, client)
+	}
+
+	var excess []LDAPClient
+
+	f.mu.Lock()
+
+	f.open -= len(idle) - len(healthy)
+
+	for _, client := range healthy {
+		if f.closed || len(f.idle) >= f.config.MaxIdle {
+			excess = append(excess, client)
+
+			continue
+		}
+
+		f.idle = append(f.idle, client)
+	}
+
+	f.open -= len(excess)
+
+	f.mu.Unlock()
+
+	for _, client := range excess {
+		_ = client.Close()
+	}
+
+	f.recordConnections()
+}
+
+func (f *PooledLDAPClientFactory) ping(client LDAPClient) (err error) {
+	if client.IsClosing() {
+		return ldap.ErrConnUnbound
+	}
+
+	request := ldap.NewSearchRequest("", ldap.ScopeBaseObject, ldap.NeverDerefAliases,
+		1, 0, false, ldapBaseObjectFilter, []string{ldapNoAttributes}, nil)
+
+	_, err = client.Search(request)
+
+	return err
+}
+
+func (f *PooledLDAPClientFactory) recordAcquire(result string, started time.Time) {
+	if f.metrics == nil {
+		return
+	}
+
+	f.metrics.RecordLDAPPoolAcquire(f.name, result, time.Since(started))
+
+	f.recordConnections()
+}
+
+func (f *PooledLDAPClientFactory) recordConnections() {
+	if f.metrics == nil {
+		return
+	}
+
+	f.mu.Lock()
+
+	open, idle := f.open, len(f.idle)
+
+	f.mu.Unlock()
+
+	f.metrics.RecordLDAPPoolConnections(f.name, open, idle)
+}
+
+func (f *PooledLDAPClientFactory) recordHealthCheck(healthy bool) {
+	if f.metrics == nil {
+		return
+	}
+
+	f.metrics.RecordLDAPPoolHealthCheck(f.name, healthy)
+}
+
+// pooledLDAPClient is a LDAPClient which is released back to the PooledLDAPClientFactory when it's closed instead of
+// being closed. Clients which encounter a network error or which are bound as another user are closed instead.
+type pooledLDAPClient struct {
+	LDAPClient
+
+	pool    *PooledLDAPClientFactory
+	once    sync.Once
+	discard bool
+}
+
+// Close releases the client back to the pool.
+func (c *pooledLDAPClient) Close() (err error) {
+	c.once.Do(func() {
+		err = c.pool.release(c.LDAPClient, c.discard)
+	})
+
+	return err
+}
+
+// Bind performs a bind on the client, the client is discarded when it's released as it's no longer bound as the
+// service account.
+func (c *pooledLDAPClient) Bind(username, password string) (err error) {
+	c.discard = true
+
+	return c.LDAPClient.Bind(username, password)
+}
+
+// UnauthenticatedBind performs an unauthenticated bind on the client, the client is discarded when it's released as
+// it's no longer bound as the service account.
+func (c *pooledLDAPClient) UnauthenticatedBind(username string) (err error) {
+	c.discard = true
+
+	return c.LDAPClient.UnauthenticatedBind(username)
+}
+
+// Search performs a search on the client, the client is discarded when it's released if a network error occurs.
+func (c *pooledLDAPClient) Search(request *ldap.SearchRequest) (result *ldap.SearchResult, err error) {
+	result, err = c.LDAPClient.Search(request)
+
+	c.check(err)
+
+	return result, err
+}
+
+// SearchWithPaging performs a paged search on the client, the client is discarded when it's released if a network
+// error occurs.
+func (c *pooledLDAPClient) SearchWithPaging(request *ldap.SearchRequest, pagingSize uint32) (result *ldap.SearchResult, err error) {
+	result, err = c.LDAPClient.SearchWithPaging(request, pagingSize)
+
+	c.check(err)
+
+	return result, err
+}
+
+func (c *pooledLDAPClient) check(err error) {
+	if err != nil && ldap.IsErrorAnyOf(err, ldap.ErrorNetwork, ldap.ErrorUnexpectedResponse) {
+		c.discard = true
+	}
+}
+
+var (
+	_ LDAPClientFactory = (*ProductionLDAPClientFactory)(nil)
+	_ LDAPClientFactory = (*PooledLDAPClientFactory)(nil)
+	_ LDAPClient        = (*pooledLDAPClient)(nil)
+)
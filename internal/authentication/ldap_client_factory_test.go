@@ -0,0 +1,368 @@
+package authentication
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestPooledLDAPClientFactory_ShouldReuseClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := NewMockLDAPClient(ctrl)
+	mockMetrics := NewMockLDAPPoolMetricsRecorder(ctrl)
+
+	dials := 0
+
+	pool := NewPooledLDAPClientFactory(nil, schema.AuthenticationBackendLDAPPooling{MaxOpen: 2, MaxIdle: 2}, "ldap", mockMetrics, func() (LDAPClient, error) {
+		dials++
+
+		return mockClient, nil
+	})
+
+	gomock.InOrder(
+		mockMetrics.EXPECT().RecordLDAPPoolAcquire("ldap", ldapPoolAcquireDialed, gomock.Any()),
+		mockMetrics.EXPECT().RecordLDAPPoolConnections("ldap", 1, 0),
+		mockClient.EXPECT().IsClosing().Return(false),
+		mockMetrics.EXPECT().RecordLDAPPoolConnections("ldap", 1, 1),
+		mockClient.EXPECT().IsClosing().Return(false),
+		mockMetrics.EXPECT().RecordLDAPPoolAcquire("ldap", ldapPoolAcquireReused, gomock.Any()),
+		mockMetrics.EXPECT().RecordLDAPPoolConnections("ldap", 1, 0),
+		mockClient.EXPECT().IsClosing().Return(false),
+		mockMetrics.EXPECT().RecordLDAPPoolConnections("ldap", 1, 1),
+	)
+
+	client, err := pool.GetClient()
+	require.NoError(t, err)
+	assert.NoError(t, client.Close())
+
+	// Closing the client twice must not release it twice.
+	assert.NoError(t, client.Close())
+
+	client, err = pool.GetClient()
+	require.NoError(t, err)
+	assert.NoError(t, client.Close())
+
+	assert.Equal(t, 1, dials)
+}
+
+func TestPooledLDAPClientFactory_ShouldRedialClosedClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient, mockClientNew := NewMockLDAPClient(ctrl), NewMockLDAPClient(ctrl)
+
+	clients := []LDAPClient{mockClient, mockClientNew}
+
+	pool := NewPooledLDAPClientFactory(nil, schema.AuthenticationBackendLDAPPooling{MaxOpen: 1, MaxIdle: 1}, "ldap", nil, func() (client LDAPClient, err error) {
+		client, clients = clients[0], clients[1:]
+
+		return client, nil
+	})
+
+	gomock.InOrder(
+		mockClient.EXPECT().IsClosing().Return(false),
+		mockClient.EXPECT().IsClosing().Return(true),
+	)
+
+	client, err := pool.GetClient()
+	require.NoError(t, err)
+	assert.NoError(t, client.Close())
+
+	client, err = pool.GetClient()
+	require.NoError(t, err)
+
+	assert.Equal(t, mockClientNew, client.(*pooledLDAPClient).LDAPClient)
+	assert.Equal(t, 1, pool.open)
+}
+
+func TestPooledLDAPClientFactory_ShouldDiscardClientOnNetworkError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := NewMockLDAPClient(ctrl)
+
+	pool := NewPooledLDAPClientFactory(nil, schema.AuthenticationBackendLDAPPooling{MaxOpen: 1, MaxIdle: 1}, "ldap", nil, func() (LDAPClient, error) {
+		return mockClient, nil
+	})
+
+	gomock.InOrder(
+		mockClient.EXPECT().Search(gomock.Any()).Return(nil, ldap.NewError(ldap.ErrorNetwork, errors.New("connection reset"))),
+		mockClient.EXPECT().Close().Return(nil),
+	)
+
+	client, err := pool.GetClient()
+	require.NoError(t, err)
+
+	_, err = client.Search(&ldap.SearchRequest{})
+	assert.EqualError(t, err, "LDAP Result Code 200 \"Network Error\": connection reset")
+
+	assert.NoError(t, client.Close())
+	assert.Equal(t, 0, pool.open)
+	assert.Len(t, pool.idle, 0)
+}
+
+func TestPooledLDAPClientFactory_ShouldDiscardClientAfterBind(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := NewMockLDAPClient(ctrl)
+
+	pool := NewPooledLDAPClientFactory(nil, schema.AuthenticationBackendLDAPPooling{MaxOpen: 1, MaxIdle: 1}, "ldap", nil, func() (LDAPClient, error) {
+		return mockClient, nil
+	})
+
+	gomock.InOrder(
+		mockClient.EXPECT().Bind("uid=john,dc=example,dc=com", "password").Return(nil),
+		mockClient.EXPECT().Close().Return(nil),
+	)
+
+	client, err := pool.GetClient()
+	require.NoError(t, err)
+
+	assert.NoError(t, client.Bind("uid=john,dc=example,dc=com", "password"))
+	assert.NoError(t, client.Close())
+	assert.Len(t, pool.idle, 0)
+}
+
+func TestPooledLDAPClientFactory_ShouldTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := NewMockLDAPClient(ctrl)
+	mockMetrics := NewMockLDAPPoolMetricsRecorder(ctrl)
+
+	pool := NewPooledLDAPClientFactory(nil, schema.AuthenticationBackendLDAPPooling{MaxOpen: 1, MaxIdle: 1, Timeout: time.Millisecond * 10}, "ldap", mockMetrics, func() (LDAPClient, error) {
+		return mockClient, nil
+	})
+
+	gomock.InOrder(
+		mockMetrics.EXPECT().RecordLDAPPoolAcquire("ldap", ldapPoolAcquireDialed, gomock.Any()),
+		mockMetrics.EXPECT().RecordLDAPPoolConnections("ldap", 1, 0),
+		mockMetrics.EXPECT().RecordLDAPPoolAcquire("ldap", ldapPoolAcquireTimeout, gomock.Any()),
+		mockMetrics.EXPECT().RecordLDAPPoolConnections("ldap", 1, 0),
+	)
+
+	_, err := pool.GetClient()
+	require.NoError(t, err)
+
+	client, err := pool.GetClient()
+
+	assert.Nil(t, client)
+	assert.Equal(t, ErrLDAPPoolTimeout, err)
+}
+
+func TestPooledLDAPClientFactory_ShouldReleaseSlotOnDialFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMetrics := NewMockLDAPPoolMetricsRecorder(ctrl)
+
+	pool := NewPooledLDAPClientFactory(nil, schema.AuthenticationBackendLDAPPooling{MaxOpen: 1, MaxIdle: 1, Timeout: time.Millisecond * 10}, "ldap", mockMetrics, func() (LDAPClient, error) {
+		return nil, errors.New("dial failed with error: connection refused")
+	})
+
+	mockMetrics.EXPECT().RecordLDAPPoolAcquire("ldap", ldapPoolAcquireFailure, gomock.Any()).Times(2)
+	mockMetrics.EXPECT().RecordLDAPPoolConnections("ldap", 0, 0).Times(2)
+
+	for i := 0; i < 2; i++ {
+		client, err := pool.GetClient()
+
+		assert.Nil(t, client)
+		assert.EqualError(t, err, "dial failed with error: connection refused")
+	}
+}
+
+func TestPooledLDAPClientFactory_ShouldNotRetainMoreThanMaxIdle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient, mockClientOther := NewMockLDAPClient(ctrl), NewMockLDAPClient(ctrl)
+
+	clients := []LDAPClient{mockClient, mockClientOther}
+
+	pool := NewPooledLDAPClientFactory(nil, schema.AuthenticationBackendLDAPPooling{MaxOpen: 2, MaxIdle: 1}, "ldap", nil, func() (client LDAPClient, err error) {
+		client, clients = clients[0], clients[1:]
+
+		return client, nil
+	})
+
+	mockClient.EXPECT().IsClosing().Return(false)
+	mockClientOther.EXPECT().IsClosing().Return(false)
+	mockClientOther.EXPECT().Close().Return(nil)
+
+	first, err := pool.GetClient()
+	require.NoError(t, err)
+
+	second, err := pool.GetClient()
+	require.NoError(t, err)
+
+	assert.NoError(t, first.Close())
+	assert.NoError(t, second.Close())
+
+	assert.Equal(t, 1, pool.open)
+	assert.Equal(t, []LDAPClient{mockClient}, pool.idle)
+}
+
+func TestPooledLDAPClientFactory_ShouldHealthCheckIdleClients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClientHealthy, mockClientUnhealthy, mockClientClosed := NewMockLDAPClient(ctrl), NewMockLDAPClient(ctrl), NewMockLDAPClient(ctrl)
+	mockMetrics := NewMockLDAPPoolMetricsRecorder(ctrl)
+
+	pool := NewPooledLDAPClientFactory(nil, schema.AuthenticationBackendLDAPPooling{MaxOpen: 3, MaxIdle: 3}, "ldap", mockMetrics, nil)
+
+	pool.idle = []LDAPClient{mockClientHealthy, mockClientUnhealthy, mockClientClosed}
+	pool.open = 3
+
+	mockClientHealthy.EXPECT().IsClosing().Return(false)
+	mockClientHealthy.EXPECT().Search(gomock.Any()).DoAndReturn(func(request *ldap.SearchRequest) (*ldap.SearchResult, error) {
+		assert.Equal(t, "", request.BaseDN)
+		assert.Equal(t, ldap.ScopeBaseObject, request.Scope)
+		assert.Equal(t, []string{"1.1"}, request.Attributes)
+
+		return &ldap.SearchResult{}, nil
+	})
+
+	mockClientUnhealthy.EXPECT().IsClosing().Return(false)
+	mockClientUnhealthy.EXPECT().Search(gomock.Any()).Return(nil, ldap.NewError(ldap.ErrorNetwork, errors.New("connection reset")))
+	mockClientUnhealthy.EXPECT().Close().Return(nil)
+
+	mockClientClosed.EXPECT().IsClosing().Return(true)
+	mockClientClosed.EXPECT().Close().Return(nil)
+
+	mockMetrics.EXPECT().RecordLDAPPoolHealthCheck("ldap", true)
+	mockMetrics.EXPECT().RecordLDAPPoolHealthCheck("ldap", false).Times(2)
+	mockMetrics.EXPECT().RecordLDAPPoolConnections("ldap", 1, 1)
+
+	pool.check()
+
+	assert.Equal(t, 1, pool.open)
+	assert.Equal(t, []LDAPClient{mockClientHealthy}, pool.idle)
+}
+
+func TestPooledLDAPClientFactory_Close(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient, mockClientInUse := NewMockLDAPClient(ctrl), NewMockLDAPClient(ctrl)
+
+	pool := NewPooledLDAPClientFactory(nil, schema.AuthenticationBackendLDAPPooling{MaxOpen: 2, MaxIdle: 2, HealthCheckInterval: time.Hour}, "ldap", nil, func() (LDAPClient, error) {
+		return mockClientInUse, nil
+	})
+
+	client, err := pool.GetClient()
+	require.NoError(t, err)
+
+	pool.idle = []LDAPClient{mockClient}
+	pool.open = 2
+
+	mockClient.EXPECT().Close().Return(nil)
+	mockClientInUse.EXPECT().Close().Return(nil)
+
+	assert.NoError(t, pool.Close())
+	assert.NoError(t, pool.Close())
+	assert.Equal(t, 1, pool.open)
+
+	assert.NoError(t, client.Close())
+	assert.Equal(t, 0, pool.open)
+
+	client, err = pool.GetClient()
+
+	assert.Nil(t, client)
+	assert.Equal(t, ErrLDAPPoolClosed, err)
+}
+
+func TestLDAPUserProvider_CloseShouldStopPoolHealthCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := NewMockLDAPClient(ctrl)
+
+	pool := NewPooledLDAPClientFactory(nil, schema.AuthenticationBackendLDAPPooling{MaxOpen: 1, MaxIdle: 1, HealthCheckInterval: time.Millisecond}, "ldap", nil, func() (LDAPClient, error) {
+		return mockClient, nil
+	})
+
+	provider := &LDAPUserProvider{factory: pool}
+
+	exited := make(chan struct{})
+
+	go func() {
+		pool.wg.Wait()
+
+		close(exited)
+	}()
+
+	select {
+	case <-exited:
+		t.Fatal("the health check exited before the provider was closed")
+	case <-time.After(time.Millisecond * 10):
+	}
+
+	assert.NoError(t, provider.Close())
+
+	select {
+	case <-exited:
+	case <-time.After(time.Second):
+		t.Fatal("the health check did not exit after the provider was closed")
+	}
+
+	client, err := pool.GetClient()
+
+	assert.Nil(t, client)
+	assert.Equal(t, ErrLDAPPoolClosed, err)
+}
+
+func TestChainedUserProvider_CloseShouldCloseBackends(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := NewMockLDAPClient(ctrl)
+
+	pool := NewPooledLDAPClientFactory(nil, schema.AuthenticationBackendLDAPPooling{MaxOpen: 1, MaxIdle: 1, HealthCheckInterval: time.Hour}, "ldap", nil, func() (LDAPClient, error) {
+		return mockClient, nil
+	})
+
+	pool.idle = []LDAPClient{mockClient}
+	pool.open = 1
+
+	provider := &ChainedUserProvider{
+		backends: []*ChainedUserProviderBackend{
+			{Name: "file", Provider: &FileUserProvider{}},
+			{Name: "ldap", Provider: &LDAPUserProvider{factory: pool}},
+		},
+	}
+
+	mockClient.EXPECT().Close().Return(nil)
+
+	assert.NoError(t, provider.Close())
+	assert.True(t, pool.closed)
+	assert.Equal(t, 0, pool.open)
+}
+
+func TestPooledLDAPClientFactory_DialURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFactory := NewMockLDAPClientFactory(ctrl)
+	mockClient := NewMockLDAPClient(ctrl)
+
+	pool := NewPooledLDAPClientFactory(mockFactory, schema.AuthenticationBackendLDAPPooling{}, "ldap", nil, nil)
+
+	mockFactory.EXPECT().DialURL("ldap://127.0.0.1:389", gomock.Any()).Return(mockClient, nil)
+
+	client, err := pool.DialURL("ldap://127.0.0.1:389")
+
+	assert.NoError(t, err)
+	assert.Equal(t, mockClient, client)
+	assert.Equal(t, schema.DefaultLDAPAuthenticationBackendConfigurationPooling.MaxOpen, cap(pool.slots))
+}
@@ -0,0 +1,76 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/authelia/authelia/v4/internal/authentication (interfaces: LDAPPoolMetricsRecorder)
+//
+// Generated by this command:
+//
+//	mockgen -package authentication -destination ldap_pool_metrics_recorder_mock_test.go -mock_names LDAPPoolMetricsRecorder=MockLDAPPoolMetricsRecorder github.com/authelia/authelia/v4/internal/authentication LDAPPoolMetricsRecorder
+//
+
+// Package authentication is a generated GoMock package.
+package authentication
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLDAPPoolMetricsRecorder is a mock of LDAPPoolMetricsRecorder interface.
+type MockLDAPPoolMetricsRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockLDAPPoolMetricsRecorderMockRecorder
+}
+
+// MockLDAPPoolMetricsRecorderMockRecorder is the mock recorder for MockLDAPPoolMetricsRecorder.
+type MockLDAPPoolMetricsRecorderMockRecorder struct {
+	mock *MockLDAPPoolMetricsRecorder
+}
+
+// NewMockLDAPPoolMetricsRecorder creates a new mock instance.
+func NewMockLDAPPoolMetricsRecorder(ctrl *gomock.Controller) *MockLDAPPoolMetricsRecorder {
+	mock := &MockLDAPPoolMetricsRecorder{ctrl: ctrl}
+	mock.recorder = &MockLDAPPoolMetricsRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLDAPPoolMetricsRecorder) EXPECT() *MockLDAPPoolMetricsRecorderMockRecorder {
+	return m.recorder
+}
+
+// RecordLDAPPoolAcquire mocks base method.
+func (m *MockLDAPPoolMetricsRecorder) RecordLDAPPoolAcquire(arg0, arg1 string, arg2 time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordLDAPPoolAcquire", arg0, arg1, arg2)
+}
+
+// RecordLDAPPoolAcquire indicates an expected call of RecordLDAPPoolAcquire.
+func (mr *MockLDAPPoolMetricsRecorderMockRecorder) RecordLDAPPoolAcquire(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLDAPPoolAcquire", reflect.TypeOf((*MockLDAPPoolMetricsRecorder)(nil).RecordLDAPPoolAcquire), arg0, arg1, arg2)
+}
+
+// RecordLDAPPoolConnections mocks base method.
+func (m *MockLDAPPoolMetricsRecorder) RecordLDAPPoolConnections(arg0 string, arg1, arg2 int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordLDAPPoolConnections", arg0, arg1, arg2)
+}
+
+// RecordLDAPPoolConnections indicates an expected call of RecordLDAPPoolConnections.
+func (mr *MockLDAPPoolMetricsRecorderMockRecorder) RecordLDAPPoolConnections(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLDAPPoolConnections", reflect.TypeOf((*MockLDAPPoolMetricsRecorder)(nil).RecordLDAPPoolConnections), arg0, arg1, arg2)
+}
+
+// RecordLDAPPoolHealthCheck mocks base method.
+func (m *MockLDAPPoolMetricsRecorder) RecordLDAPPoolHealthCheck(arg0 string, arg1 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordLDAPPoolHealthCheck", arg0, arg1)
+}
+
+// RecordLDAPPoolHealthCheck indicates an expected call of RecordLDAPPoolHealthCheck.
+func (mr *MockLDAPPoolMetricsRecorderMockRecorder) RecordLDAPPoolHealthCheck(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLDAPPoolHealthCheck", reflect.TypeOf((*MockLDAPPoolMetricsRecorder)(nil).RecordLDAPPoolHealthCheck), arg0, arg1)
+}
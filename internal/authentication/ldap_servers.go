@@ -0,0 +1,162 @@
+package authentication
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/random"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// ldapServer represents a single LDAP directory server which the LDAPUserProvider is able to connect to.
+type ldapServer struct {
+	address   *schema.AddressLDAP
+	tlsConfig *tls.Config
+	dialOpts  []ldap.DialOpt
+
+	failures int
+	until    time.Time
+}
+
+// ldapServers manages the LDAP directory servers which the LDAPUserProvider fails over between. Servers which fail to
+// dial or bind are skipped for a backoff period which doubles with each consecutive failure.
+type ldapServers struct {
+	servers    []*ldapServer
+	strategy   string
+	backoff    time.Duration
+	maxBackoff time.Duration
+	random     random.Provider
+
+	mu sync.Mutex
+}
+
+// newLDAPServers creates the ldapServers from the address and failover addresses in the configuration. The TLS server
+// name of each server is the hostname of its address unless the server name is explicitly configured to a value other
+// than the hostname of the primary address.
+func newLDAPServers(config schema.AuthenticationBackendLDAP, certPool *x509.CertPool) *ldapServers {
+	servers := &ldapServers{
+		servers:    make([]*ldapServer, 0, len(config.Failover.Addresses)+1),
+		strategy:   config.Failover.Strategy,
+		backoff:    config.Failover.Backoff,
+		maxBackoff: config.Failover.MaxBackoff,
+		random:     random.NewMathematical(),
+	}
+
+	if servers.backoff <= 0 {
+		servers.backoff = schema.DefaultLDAPAuthenticationBackendConfigurationFailover.Backoff
+	}
+
+	if servers.maxBackoff < servers.backoff {
+		servers.maxBackoff = max(servers.backoff, schema.DefaultLDAPAuthenticationBackendConfigurationFailover.MaxBackoff)
+	}
+
+	for _, address := range append([]*schema.AddressLDAP{config.Address}, config.Failover.Addresses...) {
+		if address == nil {
+			continue
+		}
+
+		servers.servers = append(servers.servers, newLDAPServer(config, address, certPool))
+	}
+
+	return servers
+}
+
+func newLDAPServer(config schema.AuthenticationBackendLDAP, address *schema.AddressLDAP, certPool *x509.CertPool) *ldapServer {
+	var tlsConfig *tls.Config
+
+	if config.TLS != nil {
+		c := *config.TLS
+
+		if c.ServerName == "" || (config.Address != nil && c.ServerName == config.Address.Hostname()) {
+			c.ServerName = address.Hostname()
+		}
+
+		tlsConfig = utils.NewTLSConfig(&c, certPool)
+	}
+
+	server := &ldapServer{
+		address:   address,
+		tlsConfig: tlsConfig,
+		dialOpts: []ldap.DialOpt{
+			ldap.DialWithDialer(&net.Dialer{Timeout: config.Timeout}),
+		},
+	}
+
+	if tlsConfig != nil {
+		server.dialOpts = append(server.dialOpts, ldap.DialWithTLSConfig(tlsConfig))
+	}
+
+	return server
+}
+
+// candidates returns the servers in the order they should be attempted. Servers which are not backing off are ordered
+// by the strategy, and are followed by the servers which are backing off ordered by when their backoff ends. This
+// ensures a connection is always attempted even when every server is backing off.
+func (s *ldapServers) candidates(now time.Time) (candidates []*ldapServer) {
+	s.mu.Lock()
+
+	defer s.mu.Unlock()
+
+	var backoff []*ldapServer
+
+	candidates = make([]*ldapServer, 0, len(s.servers))
+
+	for _, server := range s.servers {
+		if server.until.After(now) {
+			backoff = append(backoff, server)
+		} else {
+			candidates = append(candidates, server)
+		}
+	}
+
+	if s.strategy == schema.LDAPFailoverStrategyRandom {
+		for i := len(candidates) - 1; i > 0; i-- {
+			j := s.random.Intn(i + 1)
+
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		}
+	}
+
+	sort.SliceStable(backoff, func(i, j int) bool {
+		return backoff[i].until.Before(backoff[j].until)
+	})
+
+	return append(candidates, backoff...)
+}
+
+// success resets the backoff of the server.
+func (s *ldapServers) success(server *ldapServer) {
+	s.mu.Lock()
+
+	defer s.mu.Unlock()
+
+	server.failures, server.until = 0, time.Time{}
+}
+
+// failure records a failure of the server and returns the duration it's skipped for.
+func (s *ldapServers) failure(server *ldapServer, now time.Time) (backoff time.Duration) {
+	s.mu.Lock()
+
+	defer s.mu.Unlock()
+
+	server.failures++
+
+	backoff = s.backoff
+
+	for i := 1; i < server.failures && backoff < s.maxBackoff; i++ {
+		backoff *= 2
+	}
+
+	backoff = min(backoff, s.maxBackoff)
+
+	server.until = now.Add(backoff)
+
+	return backoff
+}
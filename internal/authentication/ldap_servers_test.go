@@ -0,0 +1,137 @@
+package authentication
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestNewLDAPServers(t *testing.T) {
+	testCases := []struct {
+		name       string
+		serverName string
+		expected   []string
+	}{
+		{"ShouldUseHostnameOfEachAddress", "", []string{"ldap1.example.com", "ldap2.example.com", "ldap3.example.com"}},
+		{"ShouldUseHostnameOfEachAddressWhenDefaultedFromAddress", "ldap1.example.com", []string{"ldap1.example.com", "ldap2.example.com", "ldap3.example.com"}},
+		{"ShouldUseExplicitServerName", "ldap.example.com", []string{"ldap.example.com", "ldap.example.com", "ldap.example.com"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			servers := newLDAPServers(schema.AuthenticationBackendLDAP{
+				Address: MustParseAddress("ldaps://ldap1.example.com"),
+				TLS:     &schema.TLS{ServerName: tc.serverName},
+				Failover: schema.AuthenticationBackendLDAPFailover{
+					Addresses: []*schema.AddressLDAP{
+						MustParseAddress("ldaps://ldap2.example.com"),
+						MustParseAddress("ldaps://ldap3.example.com"),
+					},
+				},
+			}, nil)
+
+			require.Len(t, servers.servers, len(tc.expected))
+
+			assert.Equal(t, schema.DefaultLDAPAuthenticationBackendConfigurationFailover.Backoff, servers.backoff)
+			assert.Equal(t, schema.DefaultLDAPAuthenticationBackendConfigurationFailover.MaxBackoff, servers.maxBackoff)
+
+			for i, server := range servers.servers {
+				require.NotNil(t, server.tlsConfig)
+				assert.Equal(t, tc.expected[i], server.tlsConfig.ServerName)
+				assert.Len(t, server.dialOpts, 2)
+			}
+		})
+	}
+}
+
+func TestLDAPServersCandidatesOrdered(t *testing.T) {
+	servers := newTestLDAPServers(schema.LDAPFailoverStrategyOrdered, "ldap://ldap1", "ldap://ldap2", "ldap://ldap3")
+
+	now := time.Unix(1000000, 0)
+
+	assert.Equal(t, []string{"ldap://ldap1:389", "ldap://ldap2:389", "ldap://ldap3:389"}, ldapServerAddresses(servers.candidates(now)))
+
+	assert.Equal(t, time.Second*5, servers.failure(servers.servers[1], now))
+	assert.Equal(t, time.Second*5, servers.failure(servers.servers[0], now.Add(time.Second)))
+
+	assert.Equal(t, []string{"ldap://ldap3:389", "ldap://ldap2:389", "ldap://ldap1:389"}, ldapServerAddresses(servers.candidates(now.Add(time.Second))))
+	assert.Equal(t, []string{"ldap://ldap2:389", "ldap://ldap3:389", "ldap://ldap1:389"}, ldapServerAddresses(servers.candidates(now.Add(time.Second*5))))
+	assert.Equal(t, []string{"ldap://ldap1:389", "ldap://ldap2:389", "ldap://ldap3:389"}, ldapServerAddresses(servers.candidates(now.Add(time.Second*6))))
+
+	servers.success(servers.servers[0])
+
+	assert.Equal(t, 0, servers.servers[0].failures)
+	assert.True(t, servers.servers[0].until.IsZero())
+}
+
+func TestLDAPServersCandidatesRandom(t *testing.T) {
+	servers := newTestLDAPServers(schema.LDAPFailoverStrategyRandom, "ldap://ldap1", "ldap://ldap2", "ldap://ldap3", "ldap://ldap4")
+
+	now := time.Unix(1000000, 0)
+
+	servers.failure(servers.servers[0], now)
+
+	for i := 0; i < 10; i++ {
+		candidates := ldapServerAddresses(servers.candidates(now))
+
+		require.Len(t, candidates, 4)
+
+		assert.ElementsMatch(t, []string{"ldap://ldap2:389", "ldap://ldap3:389", "ldap://ldap4:389"}, candidates[:3])
+		assert.Equal(t, "ldap://ldap1:389", candidates[3])
+	}
+
+	assert.Equal(t, []string{"ldap://ldap1:389", "ldap://ldap2:389", "ldap://ldap3:389", "ldap://ldap4:389"}, ldapServerAddresses(servers.servers))
+}
+
+func TestLDAPServersFailureBackoff(t *testing.T) {
+	servers := newTestLDAPServers(schema.LDAPFailoverStrategyOrdered, "ldap://ldap1")
+
+	now := time.Unix(1000000, 0)
+
+	expected := []time.Duration{
+		time.Second * 5,
+		time.Second * 10,
+		time.Second * 20,
+		time.Second * 40,
+		time.Second * 80,
+		time.Second * 160,
+		time.Minute * 5,
+		time.Minute * 5,
+	}
+
+	for i, backoff := range expected {
+		assert.Equal(t, backoff, servers.failure(servers.servers[0], now), "failure %d", i+1)
+		assert.Equal(t, now.Add(backoff), servers.servers[0].until)
+	}
+
+	assert.Equal(t, len(expected), servers.servers[0].failures)
+}
+
+func newTestLDAPServers(strategy string, addresses ...string) *ldapServers {
+	config := schema.AuthenticationBackendLDAP{
+		Address: MustParseAddress(addresses[0]),
+		Failover: schema.AuthenticationBackendLDAPFailover{
+			Strategy:   strategy,
+			Backoff:    time.Second * 5,
+			MaxBackoff: time.Minute * 5,
+		},
+	}
+
+	for _, address := range addresses[1:] {
+		config.Failover.Addresses = append(config.Failover.Addresses, MustParseAddress(address))
+	}
+
+	return newLDAPServers(config, nil)
+}
+
+func ldapServerAddresses(servers []*ldapServer) (addresses []string) {
+	for _, server := range servers {
+		addresses = append(addresses, server.address.String())
+	}
+
+	return addresses
+}
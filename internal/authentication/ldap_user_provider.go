@@ -25,6 +25,9 @@ type LDAPUserProvider struct {
 	log       *logrus.Logger
 	factory   LDAPClientFactory
 
+	// The servers which are failed over between, only set when failover addresses are configured.
+	servers *ldapServers
+
 	clock clock.Provider
 
 	disableResetPassword bool
@@ -48,11 +51,23 @@ type LDAPUserProvider struct {
 	groupsFilterReplacementDN           bool
 	groupsFilterReplacementsMemberOfDN  bool
 	groupsFilterReplacementsMemberOfRDN bool
+	groupsFilterInChain                 string
+}
+
+// NewLDAPUserProvider creates a new instance of LDAPUserProvider with the ProductionLDAPClientFactory, which is wrapped
+// by a PooledLDAPClientFactory when pooling is enabled. The metrics recorder is optional.
+func NewLDAPUserProvider(config schema.AuthenticationBackend, certPool *x509.CertPool, metrics LDAPPoolMetricsRecorder) (provider *LDAPUserProvider) {
+	return newLDAPUserProvider(*config.LDAP, config.PasswordReset.Disable, certPool, "ldap", metrics)
 }
 
-// NewLDAPUserProvider creates a new instance of LDAPUserProvider with the ProductionLDAPClientFactory.
-func NewLDAPUserProvider(config schema.AuthenticationBackend, certPool *x509.CertPool) (provider *LDAPUserProvider) {
-	provider = NewLDAPUserProviderWithFactory(*config.LDAP, config.PasswordReset.Disable, certPool, NewProductionLDAPClientFactory())
+func newLDAPUserProvider(config schema.AuthenticationBackendLDAP, disableResetPassword bool, certPool *x509.CertPool, name string, metrics LDAPPoolMetricsRecorder) (provider *LDAPUserProvider) {
+	factory := NewProductionLDAPClientFactory()
+
+	provider = NewLDAPUserProviderWithFactory(config, disableResetPassword, certPool, factory)
+
+	if config.Pooling.Enable {
+		provider.factory = NewPooledLDAPClientFactory(factory, config.Pooling, name, metrics, provider.dial)
+	}
 
 	return provider
 }
@@ -87,6 +102,10 @@ func NewLDAPUserProviderWithFactory(config schema.AuthenticationBackendLDAP, dis
 		clock:                clock.New(),
 	}
 
+	if len(config.Failover.Addresses) != 0 {
+		provider.servers = newLDAPServers(config, certPool)
+	}
+
 	provider.parseDynamicUsersConfiguration()
 	provider.parseDynamicGroupsConfiguration()
 
@@ -110,7 +129,7 @@ func (p *LDAPUserProvider) CheckUserPassword(username string, password string) (
 		return false, err
 	}
 
-	if clientUser, err = p.connectCustom(p.config.Address.String(), profile.DN, password, p.config.StartTLS, p.dialOpts...); err != nil {
+	if clientUser, err = p.dialUser(profile.DN, password); err != nil {
 		return false, fmt.Errorf("authentication failed. Cause: %w", err)
 	}
 
@@ -149,6 +168,7 @@ func (p *LDAPUserProvider) GetDetails(username string) (details *UserDetails, er
 		DisplayName: profile.DisplayName,
 		Emails:      profile.Emails,
 		Groups:      groups,
+		Attributes:  profile.Attributes,
 	}, nil
 }
 
@@ -169,6 +189,14 @@ func (p *LDAPUserProvider) UpdatePassword(username, password string) (err error)
 		return fmt.Errorf("unable to update password. Cause: %w", err)
 	}
 
+	if err = p.setPassword(client, profile.DN, password); err != nil {
+		return fmt.Errorf("unable to update password. Cause: %w", err)
+	}
+
+	return nil
+}
+
+func (p *LDAPUserProvider) setPassword(client LDAPClient, dn, password string) (err error) {
 	var controls []ldap.Control
 
 	switch {
@@ -181,45 +209,103 @@ func (p *LDAPUserProvider) UpdatePassword(username, password string) (err error)
 	switch {
 	case p.features.Extensions.PwdModifyExOp:
 		pwdModifyRequest := ldap.NewPasswordModifyRequest(
-			profile.DN,
+			dn,
 			"",
 			password,
 		)
 
 		err = p.pwdModify(client, pwdModifyRequest)
 	case p.config.Implementation == schema.LDAPImplementationActiveDirectory:
-		modifyRequest := ldap.NewModifyRequest(profile.DN, controls)
-		// The password needs to be enclosed in quotes
-		// https://docs.microsoft.com/en-us/openspecs/windows_protocols/ms-adts/6e803168-f140-4d23-b2d3-c3a8ab5917d2
-		pwdEncoded, _ := encodingUTF16LittleEndian.NewEncoder().String(fmt.Sprintf("\"%s\"", password))
-		modifyRequest.Replace(ldapAttributeUnicodePwd, []string{pwdEncoded})
+		modifyRequest := ldap.NewModifyRequest(dn, controls)
+		modifyRequest.Replace(ldapAttributeUnicodePwd, []string{ldapEncodeUnicodePwd(password)})
 
 		err = p.modify(client, modifyRequest)
 	default:
-		modifyRequest := ldap.NewModifyRequest(profile.DN, controls)
+		modifyRequest := ldap.NewModifyRequest(dn, controls)
 		modifyRequest.Replace(ldapAttributeUserPassword, []string{password})
 
 		err = p.modify(client, modifyRequest)
 	}
 
-	if err != nil {
-		return fmt.Errorf("unable to update password. Cause: %w", err)
+	return err
+}
+
+// Close closes the pooled clients and stops the pool health checks if pooling is enabled.
+func (p *LDAPUserProvider) Close() (err error) {
+	if pool, ok := p.factory.(*PooledLDAPClientFactory); ok {
+		return pool.Close()
 	}
 
 	return nil
 }
 
+// connect returns a client bound as the service account, which is taken from the pool if pooling is enabled.
 func (p *LDAPUserProvider) connect() (client LDAPClient, err error) {
+	if pool, ok := p.factory.(*PooledLDAPClientFactory); ok {
+		return pool.GetClient()
+	}
+
+	return p.dial()
+}
+
+func (p *LDAPUserProvider) dial() (client LDAPClient, err error) {
+	if p.servers != nil {
+		return p.connectFailover(p.config.User, p.config.Password, true)
+	}
+
 	return p.connectCustom(p.config.Address.String(), p.config.User, p.config.Password, p.config.StartTLS, p.dialOpts...)
 }
 
+func (p *LDAPUserProvider) dialUser(dn, password string) (client LDAPClient, err error) {
+	if p.servers != nil {
+		return p.connectFailover(dn, password, false)
+	}
+
+	return p.connectCustom(p.config.Address.String(), dn, password, p.config.StartTLS, p.dialOpts...)
+}
+
+// connectFailover connects to the first server which succeeds in the order determined by the failover strategy. Every
+// failure backs off the server when binding as the service account, otherwise only failures which indicate the server
+// is unavailable back off the server as the other failures are likely to be the result of invalid user credentials.
+func (p *LDAPUserProvider) connectFailover(username, password string, service bool) (client LDAPClient, err error) {
+	for _, server := range p.servers.candidates(p.clock.Now()) {
+		if client, err = p.connectServer(server, username, password); err == nil {
+			p.servers.success(server)
+
+			return client, nil
+		}
+
+		if !service && !ldapIsErrorUnavailable(err) {
+			return nil, err
+		}
+
+		backoff := p.servers.failure(server, p.clock.Now())
+
+		p.log.
+			WithError(err).
+			WithField("address", server.address.String()).
+			WithField("backoff", backoff).
+			Warn("Failed to connect to the LDAP server, the next LDAP server will be attempted if available")
+	}
+
+	return nil, err
+}
+
+func (p *LDAPUserProvider) connectServer(server *ldapServer, username, password string) (client LDAPClient, err error) {
+	return p.connectCustomTLS(server.address.String(), username, password, p.config.StartTLS, server.tlsConfig, server.dialOpts...)
+}
+
 func (p *LDAPUserProvider) connectCustom(url, username, password string, startTLS bool, opts ...ldap.DialOpt) (client LDAPClient, err error) {
+	return p.connectCustomTLS(url, username, password, startTLS, p.tlsConfig, opts...)
+}
+
+func (p *LDAPUserProvider) connectCustomTLS(url, username, password string, startTLS bool, tlsConfig *tls.Config, opts ...ldap.DialOpt) (client LDAPClient, err error) {
 	if client, err = p.factory.DialURL(url, opts...); err != nil {
 		return nil, fmt.Errorf("dial failed with error: %w", err)
 	}
 
 	if startTLS {
-		if err = client.StartTLS(p.tlsConfig); err != nil {
+		if err = client.StartTLS(tlsConfig); err != nil {
 			client.Close()
 
 			return nil, fmt.Errorf("starttls failed with error: %w", err)
@@ -382,6 +468,10 @@ func (p *LDAPUserProvider) getUserProfileResultToProfile(username string, result
 
 			userProfile.MemberOf = attr.Values
 		}
+
+		if attrs != 0 {
+			p.getUserProfileExtraAttributes(&userProfile, attr)
+		}
 	}
 
 	if userProfile.Username == "" {
@@ -396,6 +486,20 @@ func (p *LDAPUserProvider) getUserProfileResultToProfile(username string, result
 	return &userProfile, nil
 }
 
+func (p *LDAPUserProvider) getUserProfileExtraAttributes(profile *ldapUserProfile, attr *ldap.EntryAttribute) {
+	for name, attribute := range p.config.Attributes.Extra {
+		if attribute != attr.Name || len(attr.Values[0]) == 0 {
+			continue
+		}
+
+		if profile.Attributes == nil {
+			profile.Attributes = map[string]string{}
+		}
+
+		profile.Attributes[name] = attr.Values[0]
+	}
+}
+
 func (p *LDAPUserProvider) getUserGroups(client LDAPClient, username string, profile *ldapUserProfile) (groups []string, err error) {
 	request := ldap.NewSearchRequest(
 		p.groupsBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
@@ -416,6 +520,8 @@ func (p *LDAPUserProvider) getUserGroups(client LDAPClient, username string, pro
 		return p.getUserGroupsRequestFilter(client, username, profile, request)
 	case "memberof":
 		return p.getUserGroupsRequestMemberOf(client, username, profile, request)
+	case "nested":
+		return p.getUserGroupsRequestNested(client, username, profile, request)
 	default:
 		return nil, fmt.Errorf("could not perform group search with mode '%s' as it's unknown", p.config.GroupSearchMode)
 	}
@@ -472,6 +578,101 @@ func (p *LDAPUserProvider) getUserGroupsRequestMemberOf(client LDAPClient, usern
 	return groups, nil
 }
 
+// getUserGroupsRequestNested resolves the groups the user is a member of along with the groups those groups are members
+// of. The directory server performs the resolution using the LDAP_MATCHING_RULE_IN_CHAIN extensible match when it's
+// known to support it, otherwise the groups are resolved one level at a time up to the maximum depth.
+func (p *LDAPUserProvider) getUserGroupsRequestNested(client LDAPClient, username string, profile *ldapUserProfile, request *ldap.SearchRequest) (groups []string, err error) {
+	if p.isInChainSupported() {
+		request.Filter = p.resolveGroupsFilterWith(p.groupsFilterInChain, username, profile)
+
+		p.log.
+			WithField("filter", request.Filter).
+			WithField("mode", "nested").
+			Trace("Performing group search using the in chain matching rule")
+
+		return p.getUserGroupsRequestFilter(client, username, profile, request)
+	}
+
+	var result *ldap.SearchResult
+
+	if result, err = p.search(client, request); err != nil {
+		return nil, fmt.Errorf("unable to retrieve groups of user '%s'. Cause: %w", username, err)
+	}
+
+	seen := map[string]bool{}
+
+	var pending []string
+
+	groups, pending = p.getUserGroupsNestedFromEntries(result.Entries, seen, groups)
+
+	for depth := 1; len(pending) != 0; depth++ {
+		if depth >= p.config.NestedGroupsMaxDepth {
+			p.log.
+				WithField("username", username).
+				WithField("depth", depth).
+				WithField("unresolved", pending).
+				Debug("Skipping the resolution of nested groups as the maximum depth has been reached")
+
+			break
+		}
+
+		var next []string
+
+		for _, dn := range pending {
+			requestNested := ldap.NewSearchRequest(
+				p.groupsBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+				0, 0, false, p.resolveGroupsFilterWith(p.config.GroupsFilter, username, &ldapUserProfile{DN: dn, Username: profile.Username}), p.groupsAttributes, nil,
+			)
+
+			if result, err = p.search(client, requestNested); err != nil {
+				return nil, fmt.Errorf("unable to retrieve nested groups of user '%s' from group '%s'. Cause: %w", username, dn, err)
+			}
+
+			var found []string
+
+			groups, found = p.getUserGroupsNestedFromEntries(result.Entries, seen, groups)
+
+			next = append(next, found...)
+		}
+
+		pending = next
+	}
+
+	return groups, nil
+}
+
+// getUserGroupsNestedFromEntries appends the names of the groups which have not already been seen to the groups and
+// returns the DNs of those groups so their parent groups can be resolved. Groups which have already been seen are
+// skipped which prevents cyclic group memberships from being resolved indefinitely.
+func (p *LDAPUserProvider) getUserGroupsNestedFromEntries(entries []*ldap.Entry, seen map[string]bool, groups []string) (result []string, dns []string) {
+	for _, entry := range entries {
+		key := strings.ToLower(entry.DN)
+
+		if seen[key] {
+			p.log.
+				WithField("dn", entry.DN).
+				WithField("mode", "nested").
+				Trace("Skipping group as it has already been resolved")
+
+			continue
+		}
+
+		seen[key] = true
+
+		dns = append(dns, entry.DN)
+
+		if group := p.getUserGroupFromEntry(entry); len(group) != 0 && !utils.IsStringInSlice(group, groups) {
+			groups = append(groups, group)
+		}
+	}
+
+	return groups, dns
+}
+
+func (p *LDAPUserProvider) isInChainSupported() bool {
+	return p.config.Implementation == schema.LDAPImplementationActiveDirectory || p.features.Capabilities.ActiveDirectory
+}
+
 func (p *LDAPUserProvider) getUserGroupFromEntry(entry *ldap.Entry) string {
 attributes:
 	for _, attr := range entry.Attributes {
@@ -534,11 +735,13 @@ func (p *LDAPUserProvider) resolveUsersFilter(input string) (filter string) {
 }
 
 func (p *LDAPUserProvider) resolveGroupsFilter(input string, profile *ldapUserProfile) (filter string) {
-	filter = p.config.GroupsFilter
+	return p.resolveGroupsFilterWith(p.config.GroupsFilter, input, profile)
+}
 
+func (p *LDAPUserProvider) resolveGroupsFilterWith(filter, input string, profile *ldapUserProfile) string {
 	if p.groupsFilterReplacementInput {
 		// The {input} placeholder is replaced by the users username input.
-		filter = strings.ReplaceAll(p.config.GroupsFilter, ldapPlaceholderInput, ldapEscape(input))
+		filter = strings.ReplaceAll(filter, ldapPlaceholderInput, ldapEscape(input))
 	}
 
 	if profile != nil {
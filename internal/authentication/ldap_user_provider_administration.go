@@ -0,0 +1,332 @@
+package authentication
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// CreateUser creates a user in the directory server with the given details and password, and adds the user to the
+// groups in the details.
+func (p *LDAPUserProvider) CreateUser(details *UserDetails, password string) (err error) {
+	if !p.config.Administration.Enable {
+		return ErrAdministrationDisabled
+	}
+
+	if details == nil || details.Username == "" {
+		return fmt.Errorf("unable to create user: the username is required")
+	}
+
+	var client LDAPClient
+
+	if client, err = p.connect(); err != nil {
+		return fmt.Errorf("unable to create user '%s'. Cause: %w", details.Username, err)
+	}
+
+	defer client.Close()
+
+	if _, err = p.getAdministrationUserEntry(client, details.Username); err == nil {
+		return fmt.Errorf("unable to create user '%s': the user already exists", details.Username)
+	} else if !errors.Is(err, ErrUserNotFound) {
+		return fmt.Errorf("unable to create user '%s'. Cause: %w", details.Username, err)
+	}
+
+	dn := fmt.Sprintf("%s=%s,%s", p.config.Administration.UsersRDNAttribute, ldap.EscapeDN(details.Username), p.usersBaseDN)
+
+	if err = client.Add(p.getAdministrationAddRequest(dn, details, password)); err != nil {
+		return fmt.Errorf("unable to create user '%s'. Cause: %w", details.Username, err)
+	}
+
+	// Active Directory requires the password to be set when the user is added, all other implementations set the
+	// password after the user is added so the password is hashed using the most appropriate method.
+	if p.config.Implementation != schema.LDAPImplementationActiveDirectory && password != "" {
+		if err = p.setPassword(client, dn, password); err != nil {
+			return fmt.Errorf("unable to set the password for created user '%s'. Cause: %w", details.Username, err)
+		}
+	}
+
+	if err = p.modifyUserGroups(client, details.Username, dn, details.Groups, true); err != nil {
+		return fmt.Errorf("unable to add created user '%s' to groups. Cause: %w", details.Username, err)
+	}
+
+	return nil
+}
+
+// DisableUser disables the given user using the method appropriate for the implementation.
+func (p *LDAPUserProvider) DisableUser(username string) (err error) {
+	return p.setUserDisabled(username, true)
+}
+
+// EnableUser enables the given user using the method appropriate for the implementation.
+func (p *LDAPUserProvider) EnableUser(username string) (err error) {
+	return p.setUserDisabled(username, false)
+}
+
+// AddUserGroups adds the given user as a member of the given groups.
+func (p *LDAPUserProvider) AddUserGroups(username string, groups ...string) (err error) {
+	return p.updateUserGroups(username, groups, true)
+}
+
+// RemoveUserGroups removes the given user as a member of the given groups.
+func (p *LDAPUserProvider) RemoveUserGroups(username string, groups ...string) (err error) {
+	return p.updateUserGroups(username, groups, false)
+}
+
+func (p *LDAPUserProvider) setUserDisabled(username string, disabled bool) (err error) {
+	action := "enable"
+
+	if disabled {
+		action = "disable"
+	}
+
+	if !p.config.Administration.Enable {
+		return ErrAdministrationDisabled
+	}
+
+	var (
+		client LDAPClient
+		entry  *ldap.Entry
+	)
+
+	if client, err = p.connect(); err != nil {
+		return fmt.Errorf("unable to %s user '%s'. Cause: %w", action, username, err)
+	}
+
+	defer client.Close()
+
+	var attributes []string
+
+	if p.config.Implementation == schema.LDAPImplementationActiveDirectory {
+		attributes = []string{ldapAttributeUserAccountControl}
+	}
+
+	if entry, err = p.getAdministrationUserEntry(client, username, attributes...); err != nil {
+		return fmt.Errorf("unable to %s user '%s'. Cause: %w", action, username, err)
+	}
+
+	request := ldap.NewModifyRequest(entry.DN, nil)
+
+	switch p.config.Implementation {
+	case schema.LDAPImplementationActiveDirectory:
+		var uac int
+
+		if uac, err = strconv.Atoi(entry.GetAttributeValue(ldapAttributeUserAccountControl)); err != nil {
+			return fmt.Errorf("unable to %s user '%s': the '%s' attribute could not be parsed: %w", action, username, ldapAttributeUserAccountControl, err)
+		}
+
+		if disabled {
+			uac |= ldapUserAccountControlAccountDisable
+		} else {
+			uac &^= ldapUserAccountControlAccountDisable
+		}
+
+		request.Replace(ldapAttributeUserAccountControl, []string{strconv.Itoa(uac)})
+	case schema.LDAPImplementationFreeIPA:
+		request.Replace(ldapAttributeNSAccountLock, []string{strings.ToUpper(strconv.FormatBool(disabled))})
+	case schema.LDAPImplementationLLDAP:
+		return fmt.Errorf("unable to %s user '%s': the '%s' implementation does not support disabling users", action, username, p.config.Implementation)
+	default:
+		if disabled {
+			request.Replace(ldapAttributePwdAccountLockedTime, []string{ldapPwdAccountLockedTimePermanent})
+		} else {
+			request.Delete(ldapAttributePwdAccountLockedTime, nil)
+		}
+	}
+
+	if err = p.modify(client, request); err != nil {
+		if !disabled && ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchAttribute) {
+			return nil
+		}
+
+		return fmt.Errorf("unable to %s user '%s'. Cause: %w", action, username, err)
+	}
+
+	return nil
+}
+
+func (p *LDAPUserProvider) updateUserGroups(username string, groups []string, add bool) (err error) {
+	action := "remove user '%s' from groups"
+
+	if add {
+		action = "add user '%s' to groups"
+	}
+
+	action = fmt.Sprintf(action, username)
+
+	if !p.config.Administration.Enable {
+		return ErrAdministrationDisabled
+	}
+
+	var (
+		client LDAPClient
+		entry  *ldap.Entry
+	)
+
+	if client, err = p.connect(); err != nil {
+		return fmt.Errorf("unable to %s. Cause: %w", action, err)
+	}
+
+	defer client.Close()
+
+	if entry, err = p.getAdministrationUserEntry(client, username); err != nil {
+		return fmt.Errorf("unable to %s. Cause: %w", action, err)
+	}
+
+	if err = p.modifyUserGroups(client, username, entry.DN, groups, add); err != nil {
+		return fmt.Errorf("unable to %s. Cause: %w", action, err)
+	}
+
+	return nil
+}
+
+func (p *LDAPUserProvider) modifyUserGroups(client LDAPClient, username, dn string, groups []string, add bool) (err error) {
+	attribute, value := p.config.Administration.GroupsMemberAttribute, dn
+
+	// The memberUid attribute of the posixGroup object class contains usernames instead of distinguished names.
+	if strings.EqualFold(attribute, ldapAttributeMemberUID) {
+		value = username
+	}
+
+	for _, group := range groups {
+		var groupDN string
+
+		if groupDN, err = p.getAdministrationGroupDN(client, group); err != nil {
+			return err
+		}
+
+		request := ldap.NewModifyRequest(groupDN, nil)
+
+		if add {
+			request.Add(attribute, []string{value})
+		} else {
+			request.Delete(attribute, []string{value})
+		}
+
+		if err = p.modify(client, request); err != nil {
+			switch {
+			case add && ldap.IsErrorWithCode(err, ldap.LDAPResultAttributeOrValueExists):
+				continue
+			case !add && ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchAttribute):
+				continue
+			default:
+				return fmt.Errorf("error occurred modifying the members of group '%s': %w", group, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *LDAPUserProvider) getAdministrationAddRequest(dn string, details *UserDetails, password string) (request *ldap.AddRequest) {
+	request = ldap.NewAddRequest(dn, nil)
+
+	var added []string
+
+	attribute := func(name string, values ...string) {
+		if name == "" || len(values) == 0 || values[0] == "" || utils.IsStringInSliceFold(name, added) {
+			return
+		}
+
+		added = append(added, name)
+
+		request.Attribute(name, values)
+	}
+
+	name := details.DisplayName
+
+	if name == "" {
+		name = details.Username
+	}
+
+	attribute(ldapAttributeObjectClass, p.config.Administration.UsersObjectClasses...)
+	attribute(p.config.Administration.UsersRDNAttribute, details.Username)
+	attribute(p.config.Attributes.Username, details.Username)
+	attribute(p.config.Attributes.DisplayName, name)
+	attribute(p.config.Attributes.Mail, details.Emails...)
+
+	switch {
+	case p.config.Implementation == schema.LDAPImplementationActiveDirectory:
+		uac := ldapUserAccountControlNormalAccount
+
+		if password == "" {
+			uac |= ldapUserAccountControlAccountDisable
+		} else {
+			attribute(ldapAttributeUnicodePwd, ldapEncodeUnicodePwd(password))
+		}
+
+		attribute(ldapAttributeUserAccountControl, strconv.Itoa(uac))
+	case utils.IsStringInSliceFold(ldapObjectClassPerson, p.config.Administration.UsersObjectClasses):
+		// The person object class requires both the cn and sn attributes.
+		attribute(ldapAttributeCommonName, name)
+		attribute(ldapAttributeSurname, name)
+	}
+
+	return request
+}
+
+func (p *LDAPUserProvider) getAdministrationUserEntry(client LDAPClient, username string, attributes ...string) (entry *ldap.Entry, err error) {
+	classes := p.config.Administration.UsersObjectClasses
+
+	filter := fmt.Sprintf("(%s=%s)", p.config.Attributes.Username, ldap.EscapeFilter(username))
+
+	// The users filter is not used as it usually excludes disabled users.
+	if len(classes) != 0 {
+		filter = fmt.Sprintf("(&%s(%s=%s))", filter, ldapAttributeObjectClass, ldap.EscapeFilter(classes[len(classes)-1]))
+	}
+
+	request := ldap.NewSearchRequest(
+		p.usersBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		1, 0, false, filter, append([]string{p.config.Attributes.Username}, attributes...), nil,
+	)
+
+	var result *ldap.SearchResult
+
+	if result, err = p.search(client, request); err != nil {
+		return nil, fmt.Errorf("cannot find user DN of user '%s'. Cause: %w", username, err)
+	}
+
+	switch len(result.Entries) {
+	case 0:
+		return nil, ErrUserNotFound
+	case 1:
+		return result.Entries[0], nil
+	default:
+		return nil, fmt.Errorf("there were %d users found when searching for '%s' but there should only be 1", len(result.Entries), username)
+	}
+}
+
+func (p *LDAPUserProvider) getAdministrationGroupDN(client LDAPClient, group string) (dn string, err error) {
+	filter := fmt.Sprintf("(&(%s=%s)(%s=%s))",
+		p.config.Attributes.GroupName, ldap.EscapeFilter(group),
+		ldapAttributeObjectClass, ldap.EscapeFilter(p.config.Administration.GroupsObjectClass))
+
+	request := ldap.NewSearchRequest(
+		p.groupsBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		1, 0, false, filter, []string{p.config.Attributes.GroupName}, nil,
+	)
+
+	var result *ldap.SearchResult
+
+	if result, err = p.search(client, request); err != nil {
+		return "", fmt.Errorf("cannot find group DN of group '%s'. Cause: %w", group, err)
+	}
+
+	switch len(result.Entries) {
+	case 0:
+		return "", fmt.Errorf("the group '%s' does not exist", group)
+	case 1:
+		return result.Entries[0].DN, nil
+	default:
+		return "", fmt.Errorf("there were %d groups found when searching for '%s' but there should only be 1", len(result.Entries), group)
+	}
+}
+
+var (
+	_ UserAdministrationProvider = (*LDAPUserProvider)(nil)
+)
@@ -0,0 +1,473 @@
+package authentication
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestLDAPUserProviderAdministrationDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	provider := NewLDAPUserProviderWithFactory(newLDAPAdministrationTestConfig(schema.LDAPImplementationCustom, false), false, nil, NewMockLDAPClientFactory(ctrl))
+
+	assert.ErrorIs(t, provider.CreateUser(&UserDetails{Username: "john"}, "password"), ErrAdministrationDisabled)
+	assert.ErrorIs(t, provider.DisableUser("john"), ErrAdministrationDisabled)
+	assert.ErrorIs(t, provider.EnableUser("john"), ErrAdministrationDisabled)
+	assert.ErrorIs(t, provider.AddUserGroups("john", "admins"), ErrAdministrationDisabled)
+	assert.ErrorIs(t, provider.RemoveUserGroups("john", "admins"), ErrAdministrationDisabled)
+}
+
+func TestLDAPUserProviderCreateUser(t *testing.T) {
+	testCases := []struct {
+		name           string
+		implementation string
+		details        *UserDetails
+		password       string
+		setup          func(client *MockLDAPClient)
+		err            string
+	}{
+		{
+			"ShouldCreateUser",
+			schema.LDAPImplementationCustom,
+			&UserDetails{Username: "john", DisplayName: "John Doe", Emails: []string{"john.doe@example.com"}, Groups: []string{"admins"}},
+			"password",
+			func(client *MockLDAPClient) {
+				add := ldap.NewAddRequest("uid=john,ou=users,dc=example,dc=com", nil)
+				add.Attribute("objectClass", []string{"top", "person", "organizationalPerson", "inetOrgPerson"})
+				add.Attribute("uid", []string{"john"})
+				add.Attribute("displayName", []string{"John Doe"})
+				add.Attribute("mail", []string{"john.doe@example.com"})
+				add.Attribute("cn", []string{"John Doe"})
+				add.Attribute("sn", []string{"John Doe"})
+
+				password := ldap.NewModifyRequest("uid=john,ou=users,dc=example,dc=com", nil)
+				password.Replace("userPassword", []string{"password"})
+
+				member := ldap.NewModifyRequest("cn=admins,ou=groups,dc=example,dc=com", nil)
+				member.Add("member", []string{"uid=john,ou=users,dc=example,dc=com"})
+
+				gomock.InOrder(
+					client.EXPECT().Search(NewSearchRequestMatcher("(&(uid=john)(objectClass=inetOrgPerson))")).Return(&ldap.SearchResult{}, nil),
+					client.EXPECT().Add(gomock.Eq(add)).Return(nil),
+					client.EXPECT().Modify(gomock.Eq(password)).Return(nil),
+					client.EXPECT().Search(NewSearchRequestMatcher("(&(cn=admins)(objectClass=groupOfNames))")).Return(&ldap.SearchResult{Entries: []*ldap.Entry{{DN: "cn=admins,ou=groups,dc=example,dc=com"}}}, nil),
+					client.EXPECT().Modify(gomock.Eq(member)).Return(nil),
+				)
+			},
+			"",
+		},
+		{
+			"ShouldCreateUserActiveDirectory",
+			schema.LDAPImplementationActiveDirectory,
+			&UserDetails{Username: "john"},
+			"password",
+			func(client *MockLDAPClient) {
+				add := ldap.NewAddRequest("cn=john,ou=users,dc=example,dc=com", nil)
+				add.Attribute("objectClass", []string{"top", "person", "organizationalPerson", "user"})
+				add.Attribute("cn", []string{"john"})
+				add.Attribute("sAMAccountName", []string{"john"})
+				add.Attribute("displayName", []string{"john"})
+				add.Attribute("unicodePwd", []string{ldapEncodeUnicodePwd("password")})
+				add.Attribute("userAccountControl", []string{"512"})
+
+				gomock.InOrder(
+					client.EXPECT().Search(NewSearchRequestMatcher("(&(sAMAccountName=john)(objectClass=user))")).Return(&ldap.SearchResult{}, nil),
+					client.EXPECT().Add(gomock.Eq(add)).Return(nil),
+				)
+			},
+			"",
+		},
+		{
+			"ShouldCreateDisabledUserActiveDirectoryWithoutPassword",
+			schema.LDAPImplementationActiveDirectory,
+			&UserDetails{Username: "john"},
+			"",
+			func(client *MockLDAPClient) {
+				add := ldap.NewAddRequest("cn=john,ou=users,dc=example,dc=com", nil)
+				add.Attribute("objectClass", []string{"top", "person", "organizationalPerson", "user"})
+				add.Attribute("cn", []string{"john"})
+				add.Attribute("sAMAccountName", []string{"john"})
+				add.Attribute("displayName", []string{"john"})
+				add.Attribute("userAccountControl", []string{"514"})
+
+				gomock.InOrder(
+					client.EXPECT().Search(NewSearchRequestMatcher("(&(sAMAccountName=john)(objectClass=user))")).Return(&ldap.SearchResult{}, nil),
+					client.EXPECT().Add(gomock.Eq(add)).Return(nil),
+				)
+			},
+			"",
+		},
+		{
+			"ShouldNotCreateExistingUser",
+			schema.LDAPImplementationCustom,
+			&UserDetails{Username: "john"},
+			"password",
+			func(client *MockLDAPClient) {
+				client.EXPECT().Search(NewSearchRequestMatcher("(&(uid=john)(objectClass=inetOrgPerson))")).Return(&ldap.SearchResult{Entries: []*ldap.Entry{{DN: "uid=john,ou=users,dc=example,dc=com"}}}, nil)
+			},
+			"unable to create user 'john': the user already exists",
+		},
+		{
+			"ShouldReturnAddError",
+			schema.LDAPImplementationCustom,
+			&UserDetails{Username: "john"},
+			"password",
+			func(client *MockLDAPClient) {
+				gomock.InOrder(
+					client.EXPECT().Search(gomock.Any()).Return(&ldap.SearchResult{}, nil),
+					client.EXPECT().Add(gomock.Any()).Return(errors.New("insufficient access")),
+				)
+			},
+			"unable to create user 'john'. Cause: insufficient access",
+		},
+		{
+			"ShouldReturnGroupNotFoundError",
+			schema.LDAPImplementationCustom,
+			&UserDetails{Username: "john", Groups: []string{"nope"}},
+			"",
+			func(client *MockLDAPClient) {
+				gomock.InOrder(
+					client.EXPECT().Search(gomock.Any()).Return(&ldap.SearchResult{}, nil),
+					client.EXPECT().Add(gomock.Any()).Return(nil),
+					client.EXPECT().Search(NewSearchRequestMatcher("(&(cn=nope)(objectClass=groupOfNames))")).Return(&ldap.SearchResult{}, nil),
+				)
+			},
+			"unable to add created user 'john' to groups. Cause: the group 'nope' does not exist",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			factory := NewMockLDAPClientFactory(ctrl)
+			client := NewMockLDAPClient(ctrl)
+
+			provider := NewLDAPUserProviderWithFactory(newLDAPAdministrationTestConfig(tc.implementation, true), false, nil, factory)
+
+			setupLDAPAdministrationTestConnection(factory, client)
+
+			tc.setup(client)
+
+			err := provider.CreateUser(tc.details, tc.password)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestLDAPUserProviderSetUserDisabled(t *testing.T) {
+	testCases := []struct {
+		name           string
+		implementation string
+		disabled       bool
+		setup          func(client *MockLDAPClient)
+		err            string
+	}{
+		{
+			"ShouldDisableUserActiveDirectory",
+			schema.LDAPImplementationActiveDirectory,
+			true,
+			func(client *MockLDAPClient) {
+				modify := ldap.NewModifyRequest("cn=john,ou=users,dc=example,dc=com", nil)
+				modify.Replace("userAccountControl", []string{"514"})
+
+				gomock.InOrder(
+					client.EXPECT().Search(NewSearchRequestMatcher("(&(sAMAccountName=john)(objectClass=user))")).Return(newLDAPAdministrationTestUserResult("cn=john,ou=users,dc=example,dc=com", "userAccountControl", "512"), nil),
+					client.EXPECT().Modify(gomock.Eq(modify)).Return(nil),
+				)
+			},
+			"",
+		},
+		{
+			"ShouldEnableUserActiveDirectory",
+			schema.LDAPImplementationActiveDirectory,
+			false,
+			func(client *MockLDAPClient) {
+				modify := ldap.NewModifyRequest("cn=john,ou=users,dc=example,dc=com", nil)
+				modify.Replace("userAccountControl", []string{"66048"})
+
+				gomock.InOrder(
+					client.EXPECT().Search(gomock.Any()).Return(newLDAPAdministrationTestUserResult("cn=john,ou=users,dc=example,dc=com", "userAccountControl", "66050"), nil),
+					client.EXPECT().Modify(gomock.Eq(modify)).Return(nil),
+				)
+			},
+			"",
+		},
+		{
+			"ShouldNotDisableUserActiveDirectoryInvalidUserAccountControl",
+			schema.LDAPImplementationActiveDirectory,
+			true,
+			func(client *MockLDAPClient) {
+				client.EXPECT().Search(gomock.Any()).Return(newLDAPAdministrationTestUserResult("cn=john,ou=users,dc=example,dc=com", "userAccountControl", ""), nil)
+			},
+			"unable to disable user 'john': the 'userAccountControl' attribute could not be parsed: strconv.Atoi: parsing \"\": invalid syntax",
+		},
+		{
+			"ShouldDisableUserFreeIPA",
+			schema.LDAPImplementationFreeIPA,
+			true,
+			func(client *MockLDAPClient) {
+				modify := ldap.NewModifyRequest("uid=john,ou=users,dc=example,dc=com", nil)
+				modify.Replace("nsAccountLock", []string{"TRUE"})
+
+				gomock.InOrder(
+					client.EXPECT().Search(NewSearchRequestMatcher("(&(uid=john)(objectClass=inetUser))")).Return(newLDAPAdministrationTestUserResult("uid=john,ou=users,dc=example,dc=com", "uid", "john"), nil),
+					client.EXPECT().Modify(gomock.Eq(modify)).Return(nil),
+				)
+			},
+			"",
+		},
+		{
+			"ShouldDisableUserRFC2307bis",
+			schema.LDAPImplementationRFC2307bis,
+			true,
+			func(client *MockLDAPClient) {
+				modify := ldap.NewModifyRequest("uid=john,ou=users,dc=example,dc=com", nil)
+				modify.Replace("pwdAccountLockedTime", []string{"000001010000Z"})
+
+				gomock.InOrder(
+					client.EXPECT().Search(gomock.Any()).Return(newLDAPAdministrationTestUserResult("uid=john,ou=users,dc=example,dc=com", "uid", "john"), nil),
+					client.EXPECT().Modify(gomock.Eq(modify)).Return(nil),
+				)
+			},
+			"",
+		},
+		{
+			"ShouldEnableUserNotLocked",
+			schema.LDAPImplementationCustom,
+			false,
+			func(client *MockLDAPClient) {
+				modify := ldap.NewModifyRequest("uid=john,ou=users,dc=example,dc=com", nil)
+				modify.Delete("pwdAccountLockedTime", nil)
+
+				gomock.InOrder(
+					client.EXPECT().Search(gomock.Any()).Return(newLDAPAdministrationTestUserResult("uid=john,ou=users,dc=example,dc=com", "uid", "john"), nil),
+					client.EXPECT().Modify(gomock.Eq(modify)).Return(ldap.NewError(ldap.LDAPResultNoSuchAttribute, errors.New("no such attribute"))),
+				)
+			},
+			"",
+		},
+		{
+			"ShouldNotDisableUserLLDAP",
+			schema.LDAPImplementationLLDAP,
+			true,
+			func(client *MockLDAPClient) {
+				client.EXPECT().Search(gomock.Any()).Return(newLDAPAdministrationTestUserResult("uid=john,ou=people,dc=example,dc=com", "uid", "john"), nil)
+			},
+			"unable to disable user 'john': the 'lldap' implementation does not support disabling users",
+		},
+		{
+			"ShouldNotDisableUserNotFound",
+			schema.LDAPImplementationCustom,
+			true,
+			func(client *MockLDAPClient) {
+				client.EXPECT().Search(gomock.Any()).Return(&ldap.SearchResult{}, nil)
+			},
+			"unable to disable user 'john'. Cause: user not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			factory := NewMockLDAPClientFactory(ctrl)
+			client := NewMockLDAPClient(ctrl)
+
+			config := newLDAPAdministrationTestConfig(tc.implementation, true)
+
+			if tc.implementation == schema.LDAPImplementationLLDAP {
+				config.AdditionalUsersDN = "ou=people"
+			}
+
+			provider := NewLDAPUserProviderWithFactory(config, false, nil, factory)
+
+			setupLDAPAdministrationTestConnection(factory, client)
+
+			tc.setup(client)
+
+			var err error
+
+			if tc.disabled {
+				err = provider.DisableUser("john")
+			} else {
+				err = provider.EnableUser("john")
+			}
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestLDAPUserProviderUpdateUserGroups(t *testing.T) {
+	testCases := []struct {
+		name   string
+		add    bool
+		member string
+		setup  func(client *MockLDAPClient)
+		err    string
+	}{
+		{
+			"ShouldAddUserGroups",
+			true,
+			"",
+			func(client *MockLDAPClient) {
+				admins := ldap.NewModifyRequest("cn=admins,ou=groups,dc=example,dc=com", nil)
+				admins.Add("member", []string{"uid=john,ou=users,dc=example,dc=com"})
+
+				dev := ldap.NewModifyRequest("cn=dev,ou=groups,dc=example,dc=com", nil)
+				dev.Add("member", []string{"uid=john,ou=users,dc=example,dc=com"})
+
+				gomock.InOrder(
+					client.EXPECT().Search(gomock.Any()).Return(newLDAPAdministrationTestUserResult("uid=john,ou=users,dc=example,dc=com", "uid", "john"), nil),
+					client.EXPECT().Search(NewSearchRequestMatcher("(&(cn=admins)(objectClass=groupOfNames))")).Return(&ldap.SearchResult{Entries: []*ldap.Entry{{DN: "cn=admins,ou=groups,dc=example,dc=com"}}}, nil),
+					client.EXPECT().Modify(gomock.Eq(admins)).Return(ldap.NewError(ldap.LDAPResultAttributeOrValueExists, errors.New("exists"))),
+					client.EXPECT().Search(NewSearchRequestMatcher("(&(cn=dev)(objectClass=groupOfNames))")).Return(&ldap.SearchResult{Entries: []*ldap.Entry{{DN: "cn=dev,ou=groups,dc=example,dc=com"}}}, nil),
+					client.EXPECT().Modify(gomock.Eq(dev)).Return(nil),
+				)
+			},
+			"",
+		},
+		{
+			"ShouldRemoveUserGroupsMemberUID",
+			false,
+			"memberUid",
+			func(client *MockLDAPClient) {
+				admins := ldap.NewModifyRequest("cn=admins,ou=groups,dc=example,dc=com", nil)
+				admins.Delete("memberUid", []string{"john"})
+
+				dev := ldap.NewModifyRequest("cn=dev,ou=groups,dc=example,dc=com", nil)
+				dev.Delete("memberUid", []string{"john"})
+
+				gomock.InOrder(
+					client.EXPECT().Search(gomock.Any()).Return(newLDAPAdministrationTestUserResult("uid=john,ou=users,dc=example,dc=com", "uid", "john"), nil),
+					client.EXPECT().Search(gomock.Any()).Return(&ldap.SearchResult{Entries: []*ldap.Entry{{DN: "cn=admins,ou=groups,dc=example,dc=com"}}}, nil),
+					client.EXPECT().Modify(gomock.Eq(admins)).Return(nil),
+					client.EXPECT().Search(gomock.Any()).Return(&ldap.SearchResult{Entries: []*ldap.Entry{{DN: "cn=dev,ou=groups,dc=example,dc=com"}}}, nil),
+					client.EXPECT().Modify(gomock.Eq(dev)).Return(ldap.NewError(ldap.LDAPResultNoSuchAttribute, errors.New("no such attribute"))),
+				)
+			},
+			"",
+		},
+		{
+			"ShouldReturnModifyError",
+			false,
+			"",
+			func(client *MockLDAPClient) {
+				gomock.InOrder(
+					client.EXPECT().Search(gomock.Any()).Return(newLDAPAdministrationTestUserResult("uid=john,ou=users,dc=example,dc=com", "uid", "john"), nil),
+					client.EXPECT().Search(gomock.Any()).Return(&ldap.SearchResult{Entries: []*ldap.Entry{{DN: "cn=admins,ou=groups,dc=example,dc=com"}}}, nil),
+					client.EXPECT().Modify(gomock.Any()).Return(ldap.NewError(ldap.LDAPResultObjectClassViolation, errors.New("object class violation"))),
+				)
+			},
+			"unable to remove user 'john' from groups. Cause: error occurred modifying the members of group 'admins': LDAP Result Code 65 \"Object Class Violation\": object class violation",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			factory := NewMockLDAPClientFactory(ctrl)
+			client := NewMockLDAPClient(ctrl)
+
+			config := newLDAPAdministrationTestConfig(schema.LDAPImplementationCustom, true)
+
+			if tc.member != "" {
+				config.Administration.GroupsMemberAttribute = tc.member
+			}
+
+			provider := NewLDAPUserProviderWithFactory(config, false, nil, factory)
+
+			setupLDAPAdministrationTestConnection(factory, client)
+
+			tc.setup(client)
+
+			var err error
+
+			if tc.add {
+				err = provider.AddUserGroups("john", "admins", "dev")
+			} else {
+				err = provider.RemoveUserGroups("john", "admins", "dev")
+			}
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func newLDAPAdministrationTestConfig(implementation string, enable bool) (config schema.AuthenticationBackendLDAP) {
+	var defaults schema.AuthenticationBackendLDAP
+
+	switch implementation {
+	case schema.LDAPImplementationActiveDirectory:
+		defaults = schema.DefaultLDAPAuthenticationBackendConfigurationImplementationActiveDirectory
+	case schema.LDAPImplementationRFC2307bis:
+		defaults = schema.DefaultLDAPAuthenticationBackendConfigurationImplementationRFC2307bis
+	case schema.LDAPImplementationFreeIPA:
+		defaults = schema.DefaultLDAPAuthenticationBackendConfigurationImplementationFreeIPA
+	case schema.LDAPImplementationLLDAP:
+		defaults = schema.DefaultLDAPAuthenticationBackendConfigurationImplementationLLDAP
+	default:
+		defaults = schema.DefaultLDAPAuthenticationBackendConfigurationImplementationCustom
+	}
+
+	config = schema.AuthenticationBackendLDAP{
+		Implementation:     implementation,
+		Address:            testLDAPAddress,
+		User:               "uid=admin,dc=example,dc=com",
+		Password:           "password",
+		BaseDN:             "dc=example,dc=com",
+		AdditionalUsersDN:  "ou=users",
+		AdditionalGroupsDN: "ou=groups",
+		UsersFilter:        defaults.UsersFilter,
+		GroupsFilter:       defaults.GroupsFilter,
+		Attributes:         defaults.Attributes,
+		Administration:     defaults.Administration,
+	}
+
+	config.Administration.Enable = enable
+
+	return config
+}
+
+func setupLDAPAdministrationTestConnection(factory *MockLDAPClientFactory, client *MockLDAPClient) {
+	gomock.InOrder(
+		factory.EXPECT().DialURL(gomock.Eq("ldap://127.0.0.1:389"), gomock.Any()).Return(client, nil),
+		client.EXPECT().Bind(gomock.Eq("uid=admin,dc=example,dc=com"), gomock.Eq("password")).Return(nil),
+	)
+
+	client.EXPECT().Close().Return(nil)
+}
+
+func newLDAPAdministrationTestUserResult(dn, attribute, value string) *ldap.SearchResult {
+	return &ldap.SearchResult{
+		Entries: []*ldap.Entry{
+			{
+				DN: dn,
+				Attributes: []*ldap.EntryAttribute{
+					{Name: attribute, Values: []string{value}},
+				},
+			},
+		},
+	}
+}
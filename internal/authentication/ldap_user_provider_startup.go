@@ -2,9 +2,11 @@ package authentication
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/go-ldap/ldap/v3"
+	"github.com/sirupsen/logrus"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/utils"
@@ -12,16 +14,22 @@ import (
 
 // StartupCheck implements the startup check provider interface.
 func (p *LDAPUserProvider) StartupCheck() (err error) {
-	var client LDAPClient
+	if p.servers != nil {
+		if p.features, err = p.getServersSupportedFeatures(); err != nil {
+			return err
+		}
+	} else {
+		var client LDAPClient
 
-	if client, err = p.connect(); err != nil {
-		return err
-	}
+		if client, err = p.connect(); err != nil {
+			return err
+		}
 
-	defer client.Close()
+		defer client.Close()
 
-	if p.features, err = p.getServerSupportedFeatures(client); err != nil {
-		return err
+		if p.features, err = p.getServerSupportedFeatures(client); err != nil {
+			return err
+		}
 	}
 
 	if !p.features.Extensions.PwdModifyExOp && !p.disableResetPassword &&
@@ -31,7 +39,7 @@ func (p *LDAPUserProvider) StartupCheck() (err error) {
 			"attribute when users reset their password via Authelia.")
 	}
 
-	if p.features.Extensions.TLS && !p.config.StartTLS && !p.config.Address.IsExplicitlySecure() {
+	if p.features.Extensions.TLS && !p.config.StartTLS && !p.isExplicitlySecure() {
 		p.log.Error("Your LDAP Server supports TLS but you don't appear to be utilizing it. We strongly " +
 			"recommend using the scheme 'ldaps://' or enabling the StartTLS option to secure connections with your " +
 			"LDAP Server.")
@@ -40,6 +48,77 @@ func (p *LDAPUserProvider) StartupCheck() (err error) {
 	return nil
 }
 
+// getServersSupportedFeatures connects to every server to report which servers are reachable and which features they
+// advertise. The features of the first reachable server in the configured order are returned, and an error is only
+// returned if none of the servers are reachable.
+func (p *LDAPUserProvider) getServersSupportedFeatures() (features LDAPSupportedFeatures, err error) {
+	var (
+		client    LDAPClient
+		reachable []string
+	)
+
+	for _, server := range p.servers.servers {
+		var serverFeatures LDAPSupportedFeatures
+
+		log := p.log.WithField("address", server.address.String())
+
+		if client, err = p.connectServer(server, p.config.User, p.config.Password); err == nil {
+			serverFeatures, err = p.getServerSupportedFeatures(client)
+
+			_ = client.Close()
+		}
+
+		if err != nil {
+			log.WithError(err).Error("The LDAP server is unreachable")
+
+			p.servers.failure(server, p.clock.Now())
+
+			continue
+		}
+
+		p.servers.success(server)
+
+		log.WithFields(logrus.Fields{
+			"tls":                serverFeatures.Extensions.TLS,
+			"pwd_modify":         serverFeatures.Extensions.PwdModifyExOp,
+			"msft_pwd_pol_hints": serverFeatures.ControlTypes.MsftPwdPolHints || serverFeatures.ControlTypes.MsftPwdPolHintsDeprecated,
+			"active_directory":   serverFeatures.Capabilities.ActiveDirectory,
+		}).Info("The LDAP server is reachable")
+
+		switch {
+		case len(reachable) == 0:
+			features = serverFeatures
+		case serverFeatures != features:
+			log.WithField("primary", reachable[0]).Warn("The LDAP server advertises different supported features to the first reachable LDAP server which may result in inconsistent functionality")
+		}
+
+		reachable = append(reachable, server.address.String())
+	}
+
+	if len(reachable) == 0 {
+		return features, fmt.Errorf("none of the %d configured LDAP servers are reachable: %w", len(p.servers.servers), err)
+	}
+
+	p.log.Infof("Reachable LDAP servers: %d of %d", len(reachable), len(p.servers.servers))
+
+	return features, nil
+}
+
+// isExplicitlySecure returns true if every server address is explicitly secure.
+func (p *LDAPUserProvider) isExplicitlySecure() bool {
+	if p.servers == nil {
+		return p.config.Address.IsExplicitlySecure()
+	}
+
+	for _, server := range p.servers.servers {
+		if !server.address.IsExplicitlySecure() {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (p *LDAPUserProvider) getServerSupportedFeatures(client LDAPClient) (features LDAPSupportedFeatures, err error) {
 	var (
 		request *ldap.SearchRequest
@@ -47,7 +126,7 @@ func (p *LDAPUserProvider) getServerSupportedFeatures(client LDAPClient) (featur
 	)
 
 	request = ldap.NewSearchRequest("", ldap.ScopeBaseObject, ldap.NeverDerefAliases,
-		1, 0, false, ldapBaseObjectFilter, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute}, nil)
+		1, 0, false, ldapBaseObjectFilter, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute}, nil)
 
 	if result, err = client.Search(request); err != nil {
 		if p.config.PermitFeatureDetectionFailure {
@@ -111,6 +190,20 @@ func (p *LDAPUserProvider) parseDynamicUsersConfiguration() {
 		}
 	}
 
+	extra := make([]string, 0, len(p.config.Attributes.Extra))
+
+	for name := range p.config.Attributes.Extra {
+		extra = append(extra, name)
+	}
+
+	sort.Strings(extra)
+
+	for _, name := range extra {
+		if attribute := p.config.Attributes.Extra[name]; len(attribute) != 0 && !utils.IsStringInSlice(attribute, p.usersAttributes) {
+			p.usersAttributes = append(p.usersAttributes, attribute)
+		}
+	}
+
 	if p.config.AdditionalUsersDN != "" {
 		p.usersBaseDN = p.config.AdditionalUsersDN + "," + p.config.BaseDN
 	} else {
@@ -179,4 +272,10 @@ func (p *LDAPUserProvider) parseDynamicGroupsConfiguration() {
 	}
 
 	p.log.Tracef("Detected group filter replacements that need to be resolved per lookup are: input=%v, username=%v, dn=%v", p.groupsFilterReplacementInput, p.groupsFilterReplacementUsername, p.groupsFilterReplacementDN)
+
+	if p.config.GroupSearchMode == schema.LDAPGroupSearchModeNested {
+		p.groupsFilterInChain = reLDAPFilterDistinguishedNameAssertion.ReplaceAllString(p.config.GroupsFilter, fmt.Sprintf("($1:%s:=%s)", ldapOIDMatchingRuleInChain, ldapPlaceholderDistinguishedName))
+
+		p.log.Tracef("Dynamically generated groups filter using the in chain matching rule is %s", p.groupsFilterInChain)
+	}
 }
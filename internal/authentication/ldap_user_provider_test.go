@@ -18,7 +18,7 @@ import (
 )
 
 func TestNewLDAPUserProvider(t *testing.T) {
-	provider := NewLDAPUserProvider(schema.AuthenticationBackend{LDAP: &schema.AuthenticationBackendLDAP{}}, nil)
+	provider := NewLDAPUserProvider(schema.AuthenticationBackend{LDAP: &schema.AuthenticationBackendLDAP{}}, nil, nil)
 
 	assert.NotNil(t, provider)
 }
@@ -354,7 +354,7 @@ func TestShouldCheckLDAPServerExtensions(t *testing.T) {
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(&ldap.SearchResult{
 			Entries: []*ldap.Entry{
 				{
@@ -422,7 +422,7 @@ func TestShouldNotCheckLDAPServerExtensionsWhenRootDSEReturnsMoreThanOneEntry(t
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(&ldap.SearchResult{
 			Entries: []*ldap.Entry{
 				{
@@ -491,7 +491,7 @@ func TestShouldCheckLDAPServerControlTypes(t *testing.T) {
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(&ldap.SearchResult{
 			Entries: []*ldap.Entry{
 				{
@@ -559,7 +559,7 @@ func TestShouldNotEnablePasswdModifyExtensionOrControlTypes(t *testing.T) {
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(&ldap.SearchResult{
 			Entries: []*ldap.Entry{
 				{
@@ -663,7 +663,7 @@ func TestShouldReturnCheckServerSearchError(t *testing.T) {
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(nil, errors.New("could not perform the search"))
 
 	connClose := mockClient.EXPECT().Close()
@@ -712,7 +712,7 @@ func TestShouldPermitRootDSEFailure(t *testing.T) {
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(nil, errors.New("could not perform the search"))
 
 	connClose := mockClient.EXPECT().Close()
@@ -1491,6 +1491,395 @@ func TestShouldReturnUsernameFromLDAP(t *testing.T) {
 	assert.Equal(t, details.Username, "John")
 }
 
+func TestShouldReturnExtraAttributesFromLDAP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFactory := NewMockLDAPClientFactory(ctrl)
+	mockClient := NewMockLDAPClient(ctrl)
+
+	provider := NewLDAPUserProviderWithFactory(
+		schema.AuthenticationBackendLDAP{
+			Address:  testLDAPAddress,
+			User:     "cn=admin,dc=example,dc=com",
+			Password: "password",
+			Attributes: schema.AuthenticationBackendLDAPAttributes{
+				Username:    "uid",
+				Mail:        "mail",
+				DisplayName: "displayName",
+				MemberOf:    "memberOf",
+				GroupName:   "cn",
+				Extra: map[string]string{
+					"given_name":   "givenName",
+					"family_name":  "sn",
+					"phone_number": "telephoneNumber",
+					"department":   "departmentNumber",
+				},
+			},
+			UsersFilter:       "uid={input}",
+			AdditionalUsersDN: "ou=users",
+			BaseDN:            "dc=example,dc=com",
+		},
+		false,
+		nil,
+		mockFactory)
+
+	assert.Equal(t, []string{"uid", "mail", "displayName", "memberOf", "departmentNumber", "sn", "givenName", "telephoneNumber"}, provider.usersAttributes)
+
+	dialURL := mockFactory.EXPECT().
+		DialURL(gomock.Eq("ldap://127.0.0.1:389"), gomock.Any()).
+		Return(mockClient, nil)
+
+	connBind := mockClient.EXPECT().
+		Bind(gomock.Eq("cn=admin,dc=example,dc=com"), gomock.Eq("password")).
+		Return(nil)
+
+	connClose := mockClient.EXPECT().Close()
+
+	searchGroups := mockClient.EXPECT().
+		Search(gomock.Any()).
+		Return(createGroupSearchResultModeFilter(provider.config.Attributes.GroupName, "group1", "group2"), nil)
+
+	searchProfile := mockClient.EXPECT().
+		Search(gomock.Any()).
+		Return(&ldap.SearchResult{
+			Entries: []*ldap.Entry{
+				{
+					DN: "uid=test,dc=example,dc=com",
+					Attributes: []*ldap.EntryAttribute{
+						{
+							Name:   "displayName",
+							Values: []string{"John Doe"},
+						},
+						{
+							Name:   "mail",
+							Values: []string{"test@example.com"},
+						},
+						{
+							Name:   "uid",
+							Values: []string{"John"},
+						},
+						{
+							Name:   "givenName",
+							Values: []string{"John"},
+						},
+						{
+							Name:   "sn",
+							Values: []string{"Doe"},
+						},
+						{
+							Name:   "telephoneNumber",
+							Values: []string{"+1 555 0100", "+1 555 0101"},
+						},
+						{
+							Name:   "departmentNumber",
+							Values: []string{},
+						},
+					},
+				},
+			},
+		}, nil)
+
+	gomock.InOrder(dialURL, connBind, searchProfile, searchGroups, connClose)
+
+	details, err := provider.GetDetails("john")
+	require.NoError(t, err)
+
+	assert.Equal(t, "John", details.Username)
+	assert.Equal(t, map[string]string{"given_name": "John", "family_name": "Doe", "phone_number": "+1 555 0100"}, details.Attributes)
+	assert.Equal(t, details.Attributes, details.GetAttributes())
+}
+
+func TestShouldReuseServiceAccountConnectionWhenPooled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFactory := NewMockLDAPClientFactory(ctrl)
+	mockClient := NewMockLDAPClient(ctrl)
+	mockClientUser := NewMockLDAPClient(ctrl)
+
+	config := schema.AuthenticationBackendLDAP{
+		Address:  testLDAPAddress,
+		User:     "cn=admin,dc=example,dc=com",
+		Password: "password",
+		Attributes: schema.AuthenticationBackendLDAPAttributes{
+			Username:    "uid",
+			Mail:        "mail",
+			DisplayName: "displayName",
+			MemberOf:    "memberOf",
+			GroupName:   "cn",
+		},
+		UsersFilter:       "uid={input}",
+		AdditionalUsersDN: "ou=users",
+		BaseDN:            "dc=example,dc=com",
+		Pooling: schema.AuthenticationBackendLDAPPooling{
+			Enable:  true,
+			MaxOpen: 1,
+			MaxIdle: 1,
+		},
+	}
+
+	provider := NewLDAPUserProviderWithFactory(config, false, nil, mockFactory)
+
+	provider.factory = NewPooledLDAPClientFactory(mockFactory, config.Pooling, "ldap", nil, provider.dial)
+
+	searchProfileResult := &ldap.SearchResult{
+		Entries: []*ldap.Entry{
+			{
+				DN: "uid=test,dc=example,dc=com",
+				Attributes: []*ldap.EntryAttribute{
+					{
+						Name:   "displayName",
+						Values: []string{"John Doe"},
+					},
+					{
+						Name:   "mail",
+						Values: []string{"test@example.com"},
+					},
+					{
+						Name:   "uid",
+						Values: []string{"john"},
+					},
+				},
+			},
+		},
+	}
+
+	gomock.InOrder(
+		mockFactory.EXPECT().
+			DialURL(gomock.Eq("ldap://127.0.0.1:389"), gomock.Any()).
+			Return(mockClient, nil),
+		mockClient.EXPECT().
+			Bind(gomock.Eq("cn=admin,dc=example,dc=com"), gomock.Eq("password")).
+			Return(nil),
+		mockClient.EXPECT().
+			Search(gomock.Any()).
+			Return(searchProfileResult, nil),
+		mockClient.EXPECT().
+			Search(gomock.Any()).
+			Return(createGroupSearchResultModeFilter(provider.config.Attributes.GroupName, "group1"), nil),
+		mockClient.EXPECT().IsClosing().Return(false),
+		mockClient.EXPECT().IsClosing().Return(false),
+		mockClient.EXPECT().
+			Search(gomock.Any()).
+			Return(searchProfileResult, nil),
+		mockFactory.EXPECT().
+			DialURL(gomock.Eq("ldap://127.0.0.1:389"), gomock.Any()).
+			Return(mockClientUser, nil),
+		mockClientUser.EXPECT().
+			Bind(gomock.Eq("uid=test,dc=example,dc=com"), gomock.Eq("password")).
+			Return(nil),
+		mockClientUser.EXPECT().Close(),
+		mockClient.EXPECT().IsClosing().Return(false),
+	)
+
+	details, err := provider.GetDetails("john")
+	require.NoError(t, err)
+
+	assert.Equal(t, "john", details.Username)
+	assert.Equal(t, []string{"group1"}, details.Groups)
+
+	valid, err := provider.CheckUserPassword("john", "password")
+
+	assert.True(t, valid)
+	assert.NoError(t, err)
+}
+
+func newTestLDAPUserProviderNested(mockFactory LDAPClientFactory, implementation string, depth int) *LDAPUserProvider {
+	return NewLDAPUserProviderWithFactory(
+		schema.AuthenticationBackendLDAP{
+			Address:        testLDAPAddress,
+			Implementation: implementation,
+			User:           "cn=admin,dc=example,dc=com",
+			Password:       "password",
+			Attributes: schema.AuthenticationBackendLDAPAttributes{
+				Username:    "uid",
+				Mail:        "mail",
+				DisplayName: "displayName",
+				GroupName:   "cn",
+			},
+			GroupSearchMode:      "nested",
+			NestedGroupsMaxDepth: depth,
+			UsersFilter:          "uid={input}",
+			GroupsFilter:         "(&(member={dn})(objectClass=groupOfNames))",
+			AdditionalUsersDN:    "ou=users",
+			AdditionalGroupsDN:   "ou=groups",
+			BaseDN:               "dc=example,dc=com",
+		},
+		false,
+		nil,
+		mockFactory)
+}
+
+func expectTestLDAPUserProviderNestedProfile(mockFactory *MockLDAPClientFactory, mockClient *MockLDAPClient) []any {
+	return []any{
+		mockFactory.EXPECT().
+			DialURL(gomock.Eq("ldap://127.0.0.1:389"), gomock.Any()).
+			Return(mockClient, nil),
+		mockClient.EXPECT().
+			Bind(gomock.Eq("cn=admin,dc=example,dc=com"), gomock.Eq("password")).
+			Return(nil),
+		mockClient.EXPECT().
+			Search(gomock.Any()).
+			Return(&ldap.SearchResult{
+				Entries: []*ldap.Entry{
+					{
+						DN: "uid=john,ou=users,dc=example,dc=com",
+						Attributes: []*ldap.EntryAttribute{
+							{
+								Name:   "uid",
+								Values: []string{"john"},
+							},
+						},
+					},
+				},
+			}, nil),
+	}
+}
+
+func TestShouldResolveNestedGroups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFactory := NewMockLDAPClientFactory(ctrl)
+	mockClient := NewMockLDAPClient(ctrl)
+
+	provider := newTestLDAPUserProviderNested(mockFactory, schema.LDAPImplementationCustom, 10)
+
+	assert.Equal(t, "(&(member:1.2.840.113556.1.4.1941:={dn})(objectClass=groupOfNames))", provider.groupsFilterInChain)
+
+	matcher := func(dn string) gomock.Matcher {
+		return NewExtendedSearchRequestMatcher(fmt.Sprintf("(&(member=%s)(objectClass=groupOfNames))", dn), "ou=groups,dc=example,dc=com", ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, false, []string{"cn"})
+	}
+
+	calls := expectTestLDAPUserProviderNestedProfile(mockFactory, mockClient)
+
+	calls = append(calls,
+		mockClient.EXPECT().
+			Search(matcher("uid=john,ou=users,dc=example,dc=com")).
+			Return(createGroupSearchResultModeFilterWithDN("cn", []string{"staff", "users"}, []string{"cn=staff,ou=groups,dc=example,dc=com", "cn=users,ou=groups,dc=example,dc=com"}), nil),
+		mockClient.EXPECT().
+			Search(matcher("cn=staff,ou=groups,dc=example,dc=com")).
+			Return(createGroupSearchResultModeFilterWithDN("cn", []string{"engineering"}, []string{"cn=engineering,ou=groups,dc=example,dc=com"}), nil),
+		mockClient.EXPECT().
+			Search(matcher("cn=users,ou=groups,dc=example,dc=com")).
+			Return(&ldap.SearchResult{}, nil),
+		mockClient.EXPECT().
+			Search(matcher("cn=engineering,ou=groups,dc=example,dc=com")).
+			Return(createGroupSearchResultModeFilterWithDN("cn", []string{"staff", "all"}, []string{"CN=staff,OU=groups,DC=example,DC=com", "cn=all,ou=groups,dc=example,dc=com"}), nil),
+		mockClient.EXPECT().
+			Search(matcher("cn=all,ou=groups,dc=example,dc=com")).
+			Return(&ldap.SearchResult{}, nil),
+		mockClient.EXPECT().Close(),
+	)
+
+	gomock.InOrder(calls...)
+
+	details, err := provider.GetDetails("john")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"staff", "users", "engineering", "all"}, details.Groups)
+}
+
+func TestShouldResolveNestedGroupsToMaximumDepth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFactory := NewMockLDAPClientFactory(ctrl)
+	mockClient := NewMockLDAPClient(ctrl)
+
+	provider := newTestLDAPUserProviderNested(mockFactory, schema.LDAPImplementationCustom, 2)
+
+	calls := expectTestLDAPUserProviderNestedProfile(mockFactory, mockClient)
+
+	calls = append(calls,
+		mockClient.EXPECT().
+			Search(gomock.Any()).
+			Return(createGroupSearchResultModeFilterWithDN("cn", []string{"staff"}, []string{"cn=staff,ou=groups,dc=example,dc=com"}), nil),
+		mockClient.EXPECT().
+			Search(gomock.Any()).
+			Return(createGroupSearchResultModeFilterWithDN("cn", []string{"engineering"}, []string{"cn=engineering,ou=groups,dc=example,dc=com"}), nil),
+		mockClient.EXPECT().Close(),
+	)
+
+	gomock.InOrder(calls...)
+
+	details, err := provider.GetDetails("john")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"staff", "engineering"}, details.Groups)
+}
+
+func TestShouldReturnErrorWhenNestedGroupSearchFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFactory := NewMockLDAPClientFactory(ctrl)
+	mockClient := NewMockLDAPClient(ctrl)
+
+	provider := newTestLDAPUserProviderNested(mockFactory, schema.LDAPImplementationCustom, 10)
+
+	calls := expectTestLDAPUserProviderNestedProfile(mockFactory, mockClient)
+
+	calls = append(calls,
+		mockClient.EXPECT().
+			Search(gomock.Any()).
+			Return(createGroupSearchResultModeFilterWithDN("cn", []string{"staff"}, []string{"cn=staff,ou=groups,dc=example,dc=com"}), nil),
+		mockClient.EXPECT().
+			Search(gomock.Any()).
+			Return(nil, errors.New("timeout")),
+		mockClient.EXPECT().Close(),
+	)
+
+	gomock.InOrder(calls...)
+
+	details, err := provider.GetDetails("john")
+
+	assert.Nil(t, details)
+	assert.EqualError(t, err, "unable to retrieve nested groups of user 'john' from group 'cn=staff,ou=groups,dc=example,dc=com'. Cause: timeout")
+}
+
+func TestShouldResolveNestedGroupsUsingInChainMatchingRule(t *testing.T) {
+	testCases := []struct {
+		name           string
+		implementation string
+		features       LDAPSupportedFeatures
+	}{
+		{"ShouldUseActiveDirectoryImplementation", schema.LDAPImplementationActiveDirectory, LDAPSupportedFeatures{}},
+		{"ShouldUseDetectedActiveDirectoryCapability", schema.LDAPImplementationCustom, LDAPSupportedFeatures{Capabilities: LDAPSupportedCapabilities{ActiveDirectory: true}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFactory := NewMockLDAPClientFactory(ctrl)
+			mockClient := NewMockLDAPClient(ctrl)
+
+			provider := newTestLDAPUserProviderNested(mockFactory, tc.implementation, 10)
+
+			provider.features = tc.features
+
+			calls := expectTestLDAPUserProviderNestedProfile(mockFactory, mockClient)
+
+			calls = append(calls,
+				mockClient.EXPECT().
+					Search(NewExtendedSearchRequestMatcher("(&(member:1.2.840.113556.1.4.1941:=uid=john,ou=users,dc=example,dc=com)(objectClass=groupOfNames))", "ou=groups,dc=example,dc=com", ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, false, []string{"cn"})).
+					Return(createGroupSearchResultModeFilter("cn", "staff", "engineering"), nil),
+				mockClient.EXPECT().Close(),
+			)
+
+			gomock.InOrder(calls...)
+
+			details, err := provider.GetDetails("john")
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{"staff", "engineering"}, details.Groups)
+		})
+	}
+}
+
 func TestShouldReturnUsernameFromLDAPSearchModeMemberOfRDN(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -2655,7 +3044,7 @@ func TestShouldNotUpdateUserPasswordConnect(t *testing.T) {
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(&ldap.SearchResult{
 			Entries: []*ldap.Entry{
 				{
@@ -2725,7 +3114,7 @@ func TestShouldNotUpdateUserPasswordGetDetails(t *testing.T) {
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(&ldap.SearchResult{
 			Entries: []*ldap.Entry{
 				{
@@ -2811,7 +3200,7 @@ func TestShouldUpdateUserPassword(t *testing.T) {
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(&ldap.SearchResult{
 			Entries: []*ldap.Entry{
 				{
@@ -2923,7 +3312,7 @@ func TestShouldUpdateUserPasswordMSAD(t *testing.T) {
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(&ldap.SearchResult{
 			Entries: []*ldap.Entry{
 				{
@@ -3037,7 +3426,7 @@ func TestShouldUpdateUserPasswordMSADWithReferrals(t *testing.T) {
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(&ldap.SearchResult{
 			Entries: []*ldap.Entry{
 				{
@@ -3168,7 +3557,7 @@ func TestShouldUpdateUserPasswordMSADWithReferralsWithReferralConnectErr(t *test
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(&ldap.SearchResult{
 			Entries: []*ldap.Entry{
 				{
@@ -3290,7 +3679,7 @@ func TestShouldUpdateUserPasswordMSADWithReferralsWithReferralModifyErr(t *testi
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(&ldap.SearchResult{
 			Entries: []*ldap.Entry{
 				{
@@ -3425,7 +3814,7 @@ func TestShouldUpdateUserPasswordMSADWithoutReferrals(t *testing.T) {
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(&ldap.SearchResult{
 			Entries: []*ldap.Entry{
 				{
@@ -3538,7 +3927,7 @@ func TestShouldUpdateUserPasswordPasswdModifyExtension(t *testing.T) {
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(&ldap.SearchResult{
 			Entries: []*ldap.Entry{
 				{
@@ -3649,7 +4038,7 @@ func TestShouldUpdateUserPasswordPasswdModifyExtensionWithReferrals(t *testing.T
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(&ldap.SearchResult{
 			Entries: []*ldap.Entry{
 				{
@@ -3779,7 +4168,7 @@ func TestShouldUpdateUserPasswordPasswdModifyExtensionWithoutReferrals(t *testin
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(&ldap.SearchResult{
 			Entries: []*ldap.Entry{
 				{
@@ -3895,7 +4284,7 @@ func TestShouldUpdateUserPasswordPasswdModifyExtensionWithReferralsReferralConne
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(&ldap.SearchResult{
 			Entries: []*ldap.Entry{
 				{
@@ -4016,7 +4405,7 @@ func TestShouldUpdateUserPasswordPasswdModifyExtensionWithReferralsReferralPassw
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(&ldap.SearchResult{
 			Entries: []*ldap.Entry{
 				{
@@ -4155,7 +4544,7 @@ func TestShouldUpdateUserPasswordActiveDirectoryWithServerPolicyHints(t *testing
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(&ldap.SearchResult{
 			Entries: []*ldap.Entry{
 				{
@@ -4270,7 +4659,7 @@ func TestShouldUpdateUserPasswordActiveDirectoryWithServerPolicyHintsDeprecated(
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(&ldap.SearchResult{
 			Entries: []*ldap.Entry{
 				{
@@ -4385,7 +4774,7 @@ func TestShouldUpdateUserPasswordActiveDirectory(t *testing.T) {
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(&ldap.SearchResult{
 			Entries: []*ldap.Entry{
 				{
@@ -4496,7 +4885,7 @@ func TestShouldUpdateUserPasswordBasic(t *testing.T) {
 		Return(nil)
 
 	searchOIDs := mockClient.EXPECT().
-		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute})).
+		Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
 		Return(&ldap.SearchResult{
 			Entries: []*ldap.Entry{
 				{
@@ -5412,3 +5801,238 @@ func TestShouldReturnLDAPSAlreadySecuredWhenStartTLSAttempted(t *testing.T) {
 	_, err := provider.GetDetails("john")
 	assert.EqualError(t, err, "starttls failed with error: LDAP Result Code 200 \"Network Error\": ldap: already encrypted")
 }
+
+func newTestLDAPUserProviderFailover(mockFactory LDAPClientFactory, addresses ...string) *LDAPUserProvider {
+	config := schema.AuthenticationBackendLDAP{
+		Address:  testLDAPAddress,
+		User:     "cn=admin,dc=example,dc=com",
+		Password: "password",
+		Attributes: schema.AuthenticationBackendLDAPAttributes{
+			Username:    "uid",
+			Mail:        "mail",
+			DisplayName: "displayName",
+		},
+		UsersFilter:       "uid={input}",
+		AdditionalUsersDN: "ou=users",
+		BaseDN:            "dc=example,dc=com",
+		Failover: schema.AuthenticationBackendLDAPFailover{
+			Strategy:   schema.LDAPFailoverStrategyOrdered,
+			Backoff:    time.Second * 5,
+			MaxBackoff: time.Minute * 5,
+		},
+	}
+
+	for _, address := range addresses {
+		config.Failover.Addresses = append(config.Failover.Addresses, MustParseAddress(address))
+	}
+
+	return NewLDAPUserProviderWithFactory(config, false, nil, mockFactory)
+}
+
+func TestShouldCheckFailoverServersOnStartup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFactory := NewMockLDAPClientFactory(ctrl)
+	mockClientSecondary := NewMockLDAPClient(ctrl)
+	mockClientTertiary := NewMockLDAPClient(ctrl)
+
+	provider := newTestLDAPUserProviderFailover(mockFactory, "ldap://127.0.0.2:389", "ldap://127.0.0.3:389")
+
+	request := NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})
+
+	gomock.InOrder(
+		mockFactory.EXPECT().
+			DialURL(gomock.Eq("ldap://127.0.0.1:389"), gomock.Any()).
+			Return(nil, ldap.NewError(ldap.ErrorNetwork, errors.New("connection refused"))),
+		mockFactory.EXPECT().
+			DialURL(gomock.Eq("ldap://127.0.0.2:389"), gomock.Any()).
+			Return(mockClientSecondary, nil),
+		mockClientSecondary.EXPECT().
+			Bind(gomock.Eq("cn=admin,dc=example,dc=com"), gomock.Eq("password")).
+			Return(nil),
+		mockClientSecondary.EXPECT().
+			Search(request).
+			Return(&ldap.SearchResult{
+				Entries: []*ldap.Entry{
+					{
+						DN: "",
+						Attributes: []*ldap.EntryAttribute{
+							{
+								Name:   ldapSupportedExtensionAttribute,
+								Values: []string{ldapOIDExtensionPwdModifyExOp, ldapOIDExtensionTLS},
+							},
+						},
+					},
+				},
+			}, nil),
+		mockClientSecondary.EXPECT().Close(),
+		mockFactory.EXPECT().
+			DialURL(gomock.Eq("ldap://127.0.0.3:389"), gomock.Any()).
+			Return(mockClientTertiary, nil),
+		mockClientTertiary.EXPECT().
+			Bind(gomock.Eq("cn=admin,dc=example,dc=com"), gomock.Eq("password")).
+			Return(nil),
+		mockClientTertiary.EXPECT().
+			Search(request).
+			Return(&ldap.SearchResult{
+				Entries: []*ldap.Entry{
+					{
+						DN: "",
+						Attributes: []*ldap.EntryAttribute{
+							{
+								Name:   ldapSupportedExtensionAttribute,
+								Values: []string{ldapOIDExtensionTLS},
+							},
+						},
+					},
+				},
+			}, nil),
+		mockClientTertiary.EXPECT().Close(),
+	)
+
+	require.NoError(t, provider.StartupCheck())
+
+	assert.True(t, provider.features.Extensions.PwdModifyExOp)
+	assert.True(t, provider.features.Extensions.TLS)
+
+	assert.Equal(t, 1, provider.servers.servers[0].failures)
+	assert.Equal(t, 0, provider.servers.servers[1].failures)
+	assert.Equal(t, 0, provider.servers.servers[2].failures)
+}
+
+func TestShouldReturnErrorOnStartupWhenNoFailoverServersAreReachable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFactory := NewMockLDAPClientFactory(ctrl)
+	mockClient := NewMockLDAPClient(ctrl)
+
+	provider := newTestLDAPUserProviderFailover(mockFactory, "ldap://127.0.0.2:389")
+
+	gomock.InOrder(
+		mockFactory.EXPECT().
+			DialURL(gomock.Eq("ldap://127.0.0.1:389"), gomock.Any()).
+			Return(nil, ldap.NewError(ldap.ErrorNetwork, errors.New("connection refused"))),
+		mockFactory.EXPECT().
+			DialURL(gomock.Eq("ldap://127.0.0.2:389"), gomock.Any()).
+			Return(mockClient, nil),
+		mockClient.EXPECT().
+			Bind(gomock.Eq("cn=admin,dc=example,dc=com"), gomock.Eq("password")).
+			Return(ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("invalid credentials"))),
+		mockClient.EXPECT().Close(),
+	)
+
+	assert.EqualError(t, provider.StartupCheck(), "none of the 2 configured LDAP servers are reachable: bind failed with error: LDAP Result Code 49 \"Invalid Credentials\": invalid credentials")
+
+	assert.Equal(t, 1, provider.servers.servers[0].failures)
+	assert.Equal(t, 1, provider.servers.servers[1].failures)
+}
+
+func TestShouldFailoverWhenCheckingUserPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFactory := NewMockLDAPClientFactory(ctrl)
+	mockClient := NewMockLDAPClient(ctrl)
+	mockClientUser := NewMockLDAPClient(ctrl)
+
+	provider := newTestLDAPUserProviderFailover(mockFactory, "ldap://127.0.0.2:389")
+
+	gomock.InOrder(
+		mockFactory.EXPECT().
+			DialURL(gomock.Eq("ldap://127.0.0.1:389"), gomock.Any()).
+			Return(mockClient, nil),
+		mockClient.EXPECT().
+			Bind(gomock.Eq("cn=admin,dc=example,dc=com"), gomock.Eq("password")).
+			Return(nil),
+		mockClient.EXPECT().
+			Search(gomock.Any()).
+			Return(&ldap.SearchResult{
+				Entries: []*ldap.Entry{
+					{
+						DN: "uid=test,dc=example,dc=com",
+						Attributes: []*ldap.EntryAttribute{
+							{
+								Name:   "uid",
+								Values: []string{"john"},
+							},
+						},
+					},
+				},
+			}, nil),
+		mockFactory.EXPECT().
+			DialURL(gomock.Eq("ldap://127.0.0.1:389"), gomock.Any()).
+			Return(nil, ldap.NewError(ldap.ErrorNetwork, errors.New("connection reset"))),
+		mockFactory.EXPECT().
+			DialURL(gomock.Eq("ldap://127.0.0.2:389"), gomock.Any()).
+			Return(mockClientUser, nil),
+		mockClientUser.EXPECT().
+			Bind(gomock.Eq("uid=test,dc=example,dc=com"), gomock.Eq("password")).
+			Return(nil),
+		mockClientUser.EXPECT().Close(),
+		mockClient.EXPECT().Close(),
+	)
+
+	valid, err := provider.CheckUserPassword("john", "password")
+
+	assert.True(t, valid)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, provider.servers.servers[0].failures)
+	assert.Equal(t, 0, provider.servers.servers[1].failures)
+}
+
+func TestShouldNotFailoverWhenUserCredentialsAreInvalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFactory := NewMockLDAPClientFactory(ctrl)
+	mockClient := NewMockLDAPClient(ctrl)
+	mockClientUser := NewMockLDAPClient(ctrl)
+
+	provider := newTestLDAPUserProviderFailover(mockFactory, "ldap://127.0.0.2:389")
+
+	gomock.InOrder(
+		mockFactory.EXPECT().
+			DialURL(gomock.Eq("ldap://127.0.0.1:389"), gomock.Any()).
+			Return(nil, ldap.NewError(ldap.ErrorNetwork, errors.New("connection refused"))),
+		mockFactory.EXPECT().
+			DialURL(gomock.Eq("ldap://127.0.0.2:389"), gomock.Any()).
+			Return(mockClient, nil),
+		mockClient.EXPECT().
+			Bind(gomock.Eq("cn=admin,dc=example,dc=com"), gomock.Eq("password")).
+			Return(nil),
+		mockClient.EXPECT().
+			Search(gomock.Any()).
+			Return(&ldap.SearchResult{
+				Entries: []*ldap.Entry{
+					{
+						DN: "uid=test,dc=example,dc=com",
+						Attributes: []*ldap.EntryAttribute{
+							{
+								Name:   "uid",
+								Values: []string{"john"},
+							},
+						},
+					},
+				},
+			}, nil),
+		mockFactory.EXPECT().
+			DialURL(gomock.Eq("ldap://127.0.0.2:389"), gomock.Any()).
+			Return(mockClientUser, nil),
+		mockClientUser.EXPECT().
+			Bind(gomock.Eq("uid=test,dc=example,dc=com"), gomock.Eq("password")).
+			Return(ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("invalid credentials"))),
+		mockClientUser.EXPECT().Close(),
+		mockClient.EXPECT().Close(),
+	)
+
+	valid, err := provider.CheckUserPassword("john", "password")
+
+	assert.False(t, valid)
+	assert.EqualError(t, err, "authentication failed. Cause: bind failed with error: LDAP Result Code 49 \"Invalid Credentials\": invalid credentials")
+
+	assert.Equal(t, 1, provider.servers.servers[0].failures)
+	assert.Equal(t, 0, provider.servers.servers[1].failures)
+}
@@ -51,12 +51,27 @@ func ldapGetFeatureSupportFromEntry(entry *ldap.Entry) (controlTypeOIDs, extensi
 					features.Extensions.TLS = true
 				}
 			}
+		case ldapSupportedCapabilitiesAttribute:
+			for _, oid := range attr.Values {
+				if oid == ldapOIDCapabilityActiveDirectory {
+					features.Capabilities.ActiveDirectory = true
+				}
+			}
 		}
 	}
 
 	return controlTypeOIDs, extensionOIDs, features
 }
 
+// ldapEncodeUnicodePwd encodes a password for the Active Directory unicodePwd attribute. The password needs to be
+// enclosed in quotes and encoded as UTF-16 little endian.
+// https://docs.microsoft.com/en-us/openspecs/windows_protocols/ms-adts/6e803168-f140-4d23-b2d3-c3a8ab5917d2
+func ldapEncodeUnicodePwd(password string) string {
+	encoded, _ := encodingUTF16LittleEndian.NewEncoder().String(fmt.Sprintf("\"%s\"", password))
+
+	return encoded
+}
+
 func ldapEscape(inputUsername string) string {
 	inputUsername = ldap.EscapeFilter(inputUsername)
 	for _, c := range specialLDAPRunes {
@@ -104,3 +119,9 @@ func ldapGetReferral(err error) (referral string, ok bool) {
 		return "", false
 	}
 }
+
+// ldapIsErrorUnavailable returns true if the error indicates the server is unavailable, i.e. a network error or a
+// result code indicating the server is busy or unavailable.
+func ldapIsErrorUnavailable(err error) bool {
+	return ldap.IsErrorAnyOf(err, ldap.ErrorNetwork, ldap.LDAPResultBusy, ldap.LDAPResultUnavailable)
+}
@@ -88,6 +88,25 @@ func TestLDAPGetFeatureSupportFromEntry(t *testing.T) {
 	}
 }
 
+func TestLDAPGetFeatureSupportFromEntryCapabilities(t *testing.T) {
+	entry := &ldap.Entry{
+		DN: "",
+		Attributes: []*ldap.EntryAttribute{
+			{Name: ldapSupportedCapabilitiesAttribute, Values: []string{"1.2.840.113556.1.4.1670", ldapOIDCapabilityActiveDirectory}},
+		},
+	}
+
+	_, _, actual := ldapGetFeatureSupportFromEntry(entry)
+
+	assert.Equal(t, LDAPSupportedFeatures{Capabilities: LDAPSupportedCapabilities{ActiveDirectory: true}}, actual)
+
+	entry.Attributes[0].Values = []string{"1.2.840.113556.1.4.1670"}
+
+	_, _, actual = ldapGetFeatureSupportFromEntry(entry)
+
+	assert.Equal(t, LDAPSupportedFeatures{}, actual)
+}
+
 func TestLDAPEntriesContainsEntry(t *testing.T) {
 	testCases := []struct {
 		description string
@@ -0,0 +1,139 @@
+package authentication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-crypt/crypt/algorithm"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+// SQLUserProvider is a provider reading details from the user tables of the storage provider.
+type SQLUserProvider struct {
+	config  *schema.AuthenticationBackendSQL
+	hash    algorithm.Hash
+	storage storage.UserDatabaseProvider
+}
+
+// NewSQLUserProvider creates a new instance of SQLUserProvider.
+func NewSQLUserProvider(config *schema.AuthenticationBackendSQL, storage storage.UserDatabaseProvider) (provider *SQLUserProvider) {
+	return &SQLUserProvider{
+		config:  config,
+		storage: storage,
+	}
+}
+
+// CheckUserPassword checks if provided password matches for the given user.
+func (p *SQLUserProvider) CheckUserPassword(username string, password string) (match bool, err error) {
+	var user *model.User
+
+	if p.storage == nil {
+		return false, ErrNotInitialized
+	}
+
+	if user, err = p.getUser(username); err != nil {
+		return false, err
+	}
+
+	var digest *schema.PasswordDigest
+
+	if digest, err = schema.DecodePasswordDigest(user.Password); err != nil {
+		return false, fmt.Errorf("error decoding the password digest for user '%s': %w", user.Username, err)
+	}
+
+	return digest.MatchAdvanced(password)
+}
+
+// GetDetails retrieve the groups a user belongs to.
+func (p *SQLUserProvider) GetDetails(username string) (details *UserDetails, err error) {
+	var user *model.User
+
+	if p.storage == nil {
+		return nil, ErrNotInitialized
+	}
+
+	if user, err = p.getUser(username); err != nil {
+		return nil, err
+	}
+
+	return &UserDetails{
+		Username:    user.Username,
+		DisplayName: user.DisplayName,
+		Emails:      user.GetEmails(),
+		Groups:      user.Groups,
+	}, nil
+}
+
+// UpdatePassword update the password of the given user.
+func (p *SQLUserProvider) UpdatePassword(username string, newPassword string) (err error) {
+	var user *model.User
+
+	if p.storage == nil || p.hash == nil {
+		return ErrNotInitialized
+	}
+
+	if user, err = p.getUser(username); err != nil {
+		return err
+	}
+
+	var digest algorithm.Digest
+
+	if digest, err = p.hash.Hash(newPassword); err != nil {
+		return err
+	}
+
+	if err = p.storage.UpdateUserPassword(context.Background(), user.Username, digest.Encode()); err != nil {
+		if errors.Is(err, storage.ErrNoUser) {
+			return ErrUserNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// StartupCheck implements the startup check provider interface.
+func (p *SQLUserProvider) StartupCheck() (err error) {
+	if p.storage == nil {
+		return fmt.Errorf("the sql authentication backend requires a storage provider")
+	}
+
+	if p.hash, err = NewFileCryptoHashFromConfig(p.config.Password); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *SQLUserProvider) getUser(username string) (user *model.User, err error) {
+	ctx := context.Background()
+
+	switch user, err = p.storage.LoadUser(ctx, username); {
+	case err == nil:
+		break
+	case errors.Is(err, storage.ErrNoUser) && p.config.Search.Email && strings.Contains(username, "@"):
+		if user, err = p.storage.LoadUserByEmail(ctx, username); err != nil {
+			if errors.Is(err, storage.ErrNoUser) {
+				return nil, ErrUserNotFound
+			}
+
+			return nil, err
+		}
+	case errors.Is(err, storage.ErrNoUser):
+		return nil, ErrUserNotFound
+	default:
+		return nil, err
+	}
+
+	if user.Disabled {
+		return nil, ErrUserNotFound
+	}
+
+	return user, nil
+}
@@ -0,0 +1,212 @@
+package authentication
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestSQLUserProviderCheckUserPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mock := NewMockUserDatabaseProvider(ctrl)
+
+	provider := NewSQLUserProvider(&schema.AuthenticationBackendSQL{Password: schema.DefaultPasswordConfig}, mock)
+
+	require.NoError(t, provider.StartupCheck())
+
+	gomock.InOrder(
+		mock.EXPECT().LoadUser(gomock.Any(), "john").Return(&model.User{Username: "john", Password: sqlUserProviderTestDigest}, nil),
+		mock.EXPECT().LoadUser(gomock.Any(), "john").Return(&model.User{Username: "john", Password: sqlUserProviderTestDigest}, nil),
+		mock.EXPECT().LoadUser(gomock.Any(), "john").Return(&model.User{Username: "john", Password: sqlUserProviderTestDigest, Disabled: true}, nil),
+		mock.EXPECT().LoadUser(gomock.Any(), "fred").Return(nil, storage.ErrNoUser),
+		mock.EXPECT().LoadUser(gomock.Any(), "john").Return(nil, errors.New("bad conn")),
+	)
+
+	ok, err := provider.CheckUserPassword("john", "password")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = provider.CheckUserPassword("john", "wrong")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = provider.CheckUserPassword("john", "password")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	assert.False(t, ok)
+
+	ok, err = provider.CheckUserPassword("fred", "password")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	assert.False(t, ok)
+
+	ok, err = provider.CheckUserPassword("john", "password")
+	assert.EqualError(t, err, "bad conn")
+	assert.False(t, ok)
+}
+
+func TestSQLUserProviderGetDetails(t *testing.T) {
+	testCases := []struct {
+		name     string
+		email    bool
+		username string
+		setup    func(mock *MockUserDatabaseProvider)
+		expected *UserDetails
+		err      string
+	}{
+		{
+			"ShouldReturnDetails",
+			false,
+			"john",
+			func(mock *MockUserDatabaseProvider) {
+				mock.EXPECT().LoadUser(gomock.Any(), "john").Return(&model.User{Username: "john", DisplayName: "John Doe", Email: "john.doe@authelia.com", Groups: []string{"admins", "dev"}}, nil)
+			},
+			&UserDetails{Username: "john", DisplayName: "John Doe", Emails: []string{"john.doe@authelia.com"}, Groups: []string{"admins", "dev"}},
+			"",
+		},
+		{
+			"ShouldReturnDetailsWithoutEmail",
+			false,
+			"john",
+			func(mock *MockUserDatabaseProvider) {
+				mock.EXPECT().LoadUser(gomock.Any(), "john").Return(&model.User{Username: "john", DisplayName: "John Doe"}, nil)
+			},
+			&UserDetails{Username: "john", DisplayName: "John Doe"},
+			"",
+		},
+		{
+			"ShouldReturnDetailsByEmail",
+			true,
+			"john.doe@authelia.com",
+			func(mock *MockUserDatabaseProvider) {
+				gomock.InOrder(
+					mock.EXPECT().LoadUser(gomock.Any(), "john.doe@authelia.com").Return(nil, storage.ErrNoUser),
+					mock.EXPECT().LoadUserByEmail(gomock.Any(), "john.doe@authelia.com").Return(&model.User{Username: "john", DisplayName: "John Doe", Email: "john.doe@authelia.com"}, nil),
+				)
+			},
+			&UserDetails{Username: "john", DisplayName: "John Doe", Emails: []string{"john.doe@authelia.com"}},
+			"",
+		},
+		{
+			"ShouldNotSearchEmailWhenDisabled",
+			false,
+			"john.doe@authelia.com",
+			func(mock *MockUserDatabaseProvider) {
+				mock.EXPECT().LoadUser(gomock.Any(), "john.doe@authelia.com").Return(nil, storage.ErrNoUser)
+			},
+			nil,
+			"user not found",
+		},
+		{
+			"ShouldReturnNotFoundByEmail",
+			true,
+			"fred@authelia.com",
+			func(mock *MockUserDatabaseProvider) {
+				gomock.InOrder(
+					mock.EXPECT().LoadUser(gomock.Any(), "fred@authelia.com").Return(nil, storage.ErrNoUser),
+					mock.EXPECT().LoadUserByEmail(gomock.Any(), "fred@authelia.com").Return(nil, storage.ErrNoUser),
+				)
+			},
+			nil,
+			"user not found",
+		},
+		{
+			"ShouldReturnNotFoundWhenDisabled",
+			false,
+			"john",
+			func(mock *MockUserDatabaseProvider) {
+				mock.EXPECT().LoadUser(gomock.Any(), "john").Return(&model.User{Username: "john", Disabled: true}, nil)
+			},
+			nil,
+			"user not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			mock := NewMockUserDatabaseProvider(ctrl)
+
+			provider := NewSQLUserProvider(&schema.AuthenticationBackendSQL{Password: schema.DefaultPasswordConfig, Search: schema.AuthenticationBackendSQLSearch{Email: tc.email}}, mock)
+
+			tc.setup(mock)
+
+			details, err := provider.GetDetails(tc.username)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, details)
+			} else {
+				assert.EqualError(t, err, tc.err)
+				assert.Nil(t, details)
+			}
+		})
+	}
+}
+
+func TestSQLUserProviderUpdatePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mock := NewMockUserDatabaseProvider(ctrl)
+
+	provider := NewSQLUserProvider(&schema.AuthenticationBackendSQL{Password: schema.DefaultPasswordConfig}, mock)
+
+	require.NoError(t, provider.StartupCheck())
+
+	gomock.InOrder(
+		mock.EXPECT().LoadUser(gomock.Any(), "john").Return(&model.User{Username: "john", Password: sqlUserProviderTestDigest}, nil),
+		mock.EXPECT().UpdateUserPassword(gomock.Any(), "john", gomock.Any()).DoAndReturn(func(_ any, _ string, password string) error {
+			digest, err := schema.DecodePasswordDigest(password)
+			require.NoError(t, err)
+
+			match, err := digest.MatchAdvanced("newpassword")
+			assert.NoError(t, err)
+			assert.True(t, match)
+
+			return nil
+		}),
+		mock.EXPECT().LoadUser(gomock.Any(), "john").Return(&model.User{Username: "john", Password: sqlUserProviderTestDigest}, nil),
+		mock.EXPECT().UpdateUserPassword(gomock.Any(), "john", gomock.Any()).Return(storage.ErrNoUser),
+	)
+
+	assert.NoError(t, provider.UpdatePassword("john", "newpassword"))
+	assert.ErrorIs(t, provider.UpdatePassword("john", "newpassword"), ErrUserNotFound)
+}
+
+func TestSQLUserProviderStartupCheck(t *testing.T) {
+	provider := NewSQLUserProvider(&schema.AuthenticationBackendSQL{}, nil)
+
+	assert.EqualError(t, provider.StartupCheck(), "the sql authentication backend requires a storage provider")
+
+	provider = NewSQLUserProvider(&schema.AuthenticationBackendSQL{}, NewMockUserDatabaseProvider(gomock.NewController(t)))
+
+	assert.EqualError(t, provider.StartupCheck(), "failed to initialize hash settings: argon2 validation error: parameter is invalid: parameter 't' must be between 1 and 2147483647 but is set to '0'")
+}
+
+func TestSQLUserProviderShouldErrNotInitialized(t *testing.T) {
+	provider := NewSQLUserProvider(&schema.AuthenticationBackendSQL{}, nil)
+
+	valid, err := provider.CheckUserPassword("john", "password")
+	assert.False(t, valid)
+	assert.Equal(t, ErrNotInitialized, err)
+
+	details, err := provider.GetDetails("john")
+	assert.Nil(t, details)
+	assert.Equal(t, ErrNotInitialized, err)
+
+	assert.Equal(t, ErrNotInitialized, provider.UpdatePassword("john", "newpassword"))
+
+	provider = NewSQLUserProvider(&schema.AuthenticationBackendSQL{}, NewMockUserDatabaseProvider(gomock.NewController(t)))
+
+	assert.Error(t, provider.StartupCheck())
+	assert.Equal(t, ErrNotInitialized, provider.UpdatePassword("john", "newpassword"))
+}
+
+const sqlUserProviderTestDigest = "$argon2id$v=19$m=65536,t=3,p=2$BpLnfgDsc2WD8F2q$o/vzA4myCqZZ36bUGsDY//8mKUYNZZaR0t4MFFSs+iM"
@@ -13,6 +13,13 @@ type LDAPClientFactory interface {
 	DialURL(addr string, opts ...ldap.DialOpt) (client LDAPClient, err error)
 }
 
+// LDAPPoolMetricsRecorder represents the methods used to record LDAP connection pool metrics.
+type LDAPPoolMetricsRecorder interface {
+	RecordLDAPPoolConnections(backend string, open, idle int)
+	RecordLDAPPoolAcquire(backend, result string, elapsed time.Duration)
+	RecordLDAPPoolHealthCheck(backend string, healthy bool)
+}
+
 // LDAPClient is a cut down version of the ldap.Client interface with just the methods we use.
 //
 // Methods added to this interface that have a direct correlation with one from ldap.Client should have the same signature.
@@ -57,6 +64,9 @@ type UserDetails struct {
 	DisplayName string
 	Emails      []string
 	Groups      []string
+
+	// Attributes are the extended user attributes such as given_name, family_name, phone_number, or locale.
+	Attributes map[string]string
 }
 
 // Addresses returns the Emails []string as []mail.Address formatted with DisplayName as the Name attribute.
@@ -93,18 +103,24 @@ func (d UserDetails) GetEmails() (emails []string) {
 	return d.Emails
 }
 
+func (d UserDetails) GetAttributes() (attributes map[string]string) {
+	return d.Attributes
+}
+
 type ldapUserProfile struct {
 	DN          string
 	Emails      []string
 	DisplayName string
 	Username    string
 	MemberOf    []string
+	Attributes  map[string]string
 }
 
 // LDAPSupportedFeatures represents features which a server may support which are implemented in code.
 type LDAPSupportedFeatures struct {
 	Extensions   LDAPSupportedExtensions
 	ControlTypes LDAPSupportedControlTypes
+	Capabilities LDAPSupportedCapabilities
 }
 
 // LDAPSupportedExtensions represents extensions which a server may support which are implemented in code.
@@ -119,6 +135,11 @@ type LDAPSupportedControlTypes struct {
 	MsftPwdPolHintsDeprecated bool
 }
 
+// LDAPSupportedCapabilities represents capabilities which a server may support which are implemented in code.
+type LDAPSupportedCapabilities struct {
+	ActiveDirectory bool
+}
+
 // Level is the type representing a level of authentication.
 type Level int
 
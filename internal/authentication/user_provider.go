@@ -13,3 +13,15 @@ type UserProvider interface {
 	GetDetails(username string) (details *UserDetails, err error)
 	UpdatePassword(username string, newPassword string) (err error)
 }
+
+// UserAdministrationProvider is an optional extension of the UserProvider interface for providers which support
+// creating users, disabling and enabling users, and managing the group memberships of users.
+type UserAdministrationProvider interface {
+	UserProvider
+
+	CreateUser(details *UserDetails, password string) (err error)
+	DisableUser(username string) (err error)
+	EnableUser(username string) (err error)
+	AddUserGroups(username string, groups ...string) (err error)
+	RemoveUserGroups(username string, groups ...string) (err error)
+}
@@ -0,0 +1,139 @@
+package authorization
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// IsGeoIPNetwork returns true if the network is a country, continent, or autonomous system network.
+func IsGeoIPNetwork(network string) (geoip bool) {
+	return strings.HasPrefix(network, prefixGeoIPCountry) || strings.HasPrefix(network, prefixGeoIPContinent) || strings.HasPrefix(network, prefixGeoIPASN)
+}
+
+// NewAccessControlGeoIPNetwork parses a country, continent, or autonomous system network into a SubjectMatcher.
+func NewAccessControlGeoIPNetwork(network string) (matcher SubjectMatcher, err error) {
+	switch {
+	case strings.HasPrefix(network, prefixGeoIPCountry):
+		value := strings.TrimPrefix(network, prefixGeoIPCountry)
+
+		if !isAlphaCode(value) {
+			return nil, fmt.Errorf("the country '%s' is not a two letter ISO 3166-1 code", value)
+		}
+
+		return &AccessControlGeoIPCountry{Country: strings.ToUpper(value)}, nil
+	case strings.HasPrefix(network, prefixGeoIPContinent):
+		value := strings.TrimPrefix(network, prefixGeoIPContinent)
+
+		if !isAlphaCode(value) {
+			return nil, fmt.Errorf("the continent '%s' is not a two letter continent code", value)
+		}
+
+		return &AccessControlGeoIPContinent{Continent: strings.ToUpper(value)}, nil
+	case strings.HasPrefix(network, prefixGeoIPASN):
+		value := strings.TrimPrefix(strings.TrimPrefix(network, prefixGeoIPASN), "AS")
+
+		asn, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || asn == 0 {
+			return nil, fmt.Errorf("the autonomous system number '%s' is not a valid number", strings.TrimPrefix(network, prefixGeoIPASN))
+		}
+
+		return &AccessControlGeoIPASN{ASN: asn}, nil
+	default:
+		return nil, fmt.Errorf("the network '%s' is not a country, continent, or autonomous system network", network)
+	}
+}
+
+// AccessControlGeoIPCountry matches the country of a Subject.
+type AccessControlGeoIPCountry struct {
+	Country string
+}
+
+// IsMatch returns true if the Subject IP is located in the country.
+func (acg AccessControlGeoIPCountry) IsMatch(subject Subject) (match bool) {
+	return subject.GeoIP.Country == acg.Country
+}
+
+// AccessControlGeoIPContinent matches the continent of a Subject.
+type AccessControlGeoIPContinent struct {
+	Continent string
+}
+
+// IsMatch returns true if the Subject IP is located in the continent.
+func (acg AccessControlGeoIPContinent) IsMatch(subject Subject) (match bool) {
+	return subject.GeoIP.Continent == acg.Continent
+}
+
+// AccessControlGeoIPASN matches the autonomous system number of a Subject.
+type AccessControlGeoIPASN struct {
+	ASN uint64
+}
+
+// IsMatch returns true if the Subject IP belongs to the autonomous system.
+func (acg AccessControlGeoIPASN) IsMatch(subject Subject) (match bool) {
+	return subject.GeoIP.ASN == acg.ASN
+}
+
+func schemaNetworksToGeoIP(networkRules []string, geoipMap map[string][]SubjectMatcher) (matchers []SubjectMatcher) {
+	for _, network := range networkRules {
+		if named, ok := geoipMap[network]; ok {
+			matchers = append(matchers, named...)
+
+			continue
+		}
+
+		if !IsGeoIPNetwork(network) {
+			continue
+		}
+
+		if matcher, err := NewAccessControlGeoIPNetwork(network); err == nil {
+			matchers = append(matchers, matcher)
+		}
+	}
+
+	return matchers
+}
+
+func parseSchemaNetworksGeoIP(schemaNetworks []schema.AccessControlNetwork) (geoipMap map[string][]SubjectMatcher) {
+	geoipMap = map[string][]SubjectMatcher{}
+
+	for _, aclNetwork := range schemaNetworks {
+		if _, ok := geoipMap[aclNetwork.Name]; ok {
+			continue
+		}
+
+		var matchers []SubjectMatcher
+
+		for _, network := range aclNetwork.Networks {
+			if !IsGeoIPNetwork(network) {
+				continue
+			}
+
+			if matcher, err := NewAccessControlGeoIPNetwork(network); err == nil {
+				matchers = append(matchers, matcher)
+			}
+		}
+
+		if len(matchers) != 0 {
+			geoipMap[aclNetwork.Name] = matchers
+		}
+	}
+
+	return geoipMap
+}
+
+func isAlphaCode(value string) bool {
+	if len(value) != 2 {
+		return false
+	}
+
+	for _, r := range value {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,87 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/geoip"
+)
+
+func TestNewAccessControlGeoIPNetwork(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected SubjectMatcher
+		err      string
+	}{
+		{"ShouldParseCountry", "country:AU", &AccessControlGeoIPCountry{Country: "AU"}, ""},
+		{"ShouldParseCountryLowerCase", "country:au", &AccessControlGeoIPCountry{Country: "AU"}, ""},
+		{"ShouldParseContinent", "continent:oc", &AccessControlGeoIPContinent{Continent: "OC"}, ""},
+		{"ShouldParseASN", "asn:13335", &AccessControlGeoIPASN{ASN: 13335}, ""},
+		{"ShouldParseASNWithPrefix", "asn:AS13335", &AccessControlGeoIPASN{ASN: 13335}, ""},
+		{"ShouldErrorCountryLength", "country:AUS", nil, "the country 'AUS' is not a two letter ISO 3166-1 code"},
+		{"ShouldErrorCountryCharacters", "country:A1", nil, "the country 'A1' is not a two letter ISO 3166-1 code"},
+		{"ShouldErrorContinent", "continent:", nil, "the continent '' is not a two letter continent code"},
+		{"ShouldErrorASNZero", "asn:0", nil, "the autonomous system number '0' is not a valid number"},
+		{"ShouldErrorASNInvalid", "asn:ASabc", nil, "the autonomous system number 'ASabc' is not a valid number"},
+		{"ShouldErrorASNTooLarge", "asn:4294967296", nil, "the autonomous system number '4294967296' is not a valid number"},
+		{"ShouldErrorNotGeoIP", "10.0.0.0/8", nil, "the network '10.0.0.0/8' is not a country, continent, or autonomous system network"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := NewAccessControlGeoIPNetwork(tc.have)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, actual)
+			} else {
+				assert.EqualError(t, err, tc.err)
+				assert.Nil(t, actual)
+			}
+		})
+	}
+}
+
+func TestIsGeoIPNetwork(t *testing.T) {
+	assert.True(t, IsGeoIPNetwork("country:AU"))
+	assert.True(t, IsGeoIPNetwork("continent:OC"))
+	assert.True(t, IsGeoIPNetwork("asn:AS13335"))
+	assert.False(t, IsGeoIPNetwork("10.0.0.0/8"))
+	assert.False(t, IsGeoIPNetwork("internal"))
+}
+
+func TestAccessControlGeoIPMatchers(t *testing.T) {
+	subject := Subject{GeoIP: geoip.Record{Country: "AU", Continent: "OC", ASN: 13335}}
+
+	assert.True(t, AccessControlGeoIPCountry{Country: "AU"}.IsMatch(subject))
+	assert.False(t, AccessControlGeoIPCountry{Country: "NZ"}.IsMatch(subject))
+	assert.True(t, AccessControlGeoIPContinent{Continent: "OC"}.IsMatch(subject))
+	assert.False(t, AccessControlGeoIPContinent{Continent: "EU"}.IsMatch(subject))
+	assert.True(t, AccessControlGeoIPASN{ASN: 13335}.IsMatch(subject))
+	assert.False(t, AccessControlGeoIPASN{ASN: 15169}.IsMatch(subject))
+
+	assert.False(t, AccessControlGeoIPCountry{Country: "AU"}.IsMatch(Subject{}))
+	assert.False(t, AccessControlGeoIPASN{ASN: 13335}.IsMatch(Subject{}))
+}
+
+func TestSchemaNetworksToGeoIP(t *testing.T) {
+	geoipMap := parseSchemaNetworksGeoIP([]schema.AccessControlNetwork{
+		{Name: "oceania", Networks: []string{"10.0.0.0/8", "continent:OC", "country:XYZ"}},
+		{Name: "oceania", Networks: []string{"continent:EU"}},
+		{Name: "internal", Networks: []string{"10.0.0.0/8"}},
+	})
+
+	assert.Equal(t, map[string][]SubjectMatcher{
+		"oceania": {&AccessControlGeoIPContinent{Continent: "OC"}},
+	}, geoipMap)
+
+	assert.Equal(t, []SubjectMatcher{
+		&AccessControlGeoIPContinent{Continent: "OC"},
+		&AccessControlGeoIPASN{ASN: 15169},
+	}, schemaNetworksToGeoIP([]string{"oceania", "internal", "192.168.0.0/16", "asn:AS15169", "asn:abc"}, geoipMap))
+
+	assert.Nil(t, schemaNetworksToGeoIP([]string{"internal", "192.168.0.0/16"}, geoipMap))
+}
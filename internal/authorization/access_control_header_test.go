@@ -0,0 +1,111 @@
+package authorization
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestNewAccessControlHeaders(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     [][]schema.AccessControlRuleHeader
+		expected []AccessControlHeaders
+		matches  [][]Object
+	}{
+		{
+			"ShouldSkipInvalidTypeEqual",
+			[][]schema.AccessControlRuleHeader{
+				{
+					{Operator: operatorEqual, Key: "X-Example", Value: 1},
+				},
+			},
+			[]AccessControlHeaders{{Rules: []ObjectMatcher(nil)}},
+			[][]Object{{{}}},
+		},
+		{
+			"ShouldSkipInvalidTypePattern",
+			[][]schema.AccessControlRuleHeader{
+				{
+					{Operator: operatorPattern, Key: "X-Example", Value: 1},
+				},
+			},
+			[]AccessControlHeaders{{Rules: []ObjectMatcher(nil)}},
+			[][]Object{{{}}},
+		},
+		{
+			"ShouldSkipInvalidOperator",
+			[][]schema.AccessControlRuleHeader{
+				{
+					{Operator: "nop", Key: "X-Example", Value: 1},
+				},
+			},
+			[]AccessControlHeaders{{Rules: []ObjectMatcher(nil)}},
+			[][]Object{{{}}},
+		},
+		{
+			"ShouldCanonicalizeKey",
+			[][]schema.AccessControlRuleHeader{
+				{
+					{Operator: operatorPresent, Key: "x-api-version"},
+				},
+			},
+			[]AccessControlHeaders{{Rules: []ObjectMatcher{&AccessControlHeaderMatcherPresent{key: "X-Api-Version", present: true}}}},
+			[][]Object{{{Header: http.Header{"X-Api-Version": []string{"1"}}}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := NewAccessControlHeaders(tc.have)
+			assert.Equal(t, tc.expected, actual)
+
+			for i, rule := range actual {
+				for _, object := range tc.matches[i] {
+					assert.True(t, rule.IsMatch(object))
+				}
+			}
+		})
+	}
+}
+
+func TestAccessControlHeaderObjectMatchers(t *testing.T) {
+	header := http.Header{
+		"X-Api-Version": []string{"2"},
+		"User-Agent":    []string{"curl/8.5.0"},
+	}
+
+	testCases := []struct {
+		name     string
+		have     schema.AccessControlRuleHeader
+		header   http.Header
+		expected bool
+	}{
+		{"ShouldMatchEqual", schema.AccessControlRuleHeader{Operator: operatorEqual, Key: "X-Api-Version", Value: "2"}, header, true},
+		{"ShouldNotMatchEqual", schema.AccessControlRuleHeader{Operator: operatorEqual, Key: "X-Api-Version", Value: "1"}, header, false},
+		{"ShouldMatchNotEqual", schema.AccessControlRuleHeader{Operator: operatorNotEqual, Key: "X-Api-Version", Value: "1"}, header, true},
+		{"ShouldNotMatchNotEqual", schema.AccessControlRuleHeader{Operator: operatorNotEqual, Key: "X-Api-Version", Value: "2"}, header, false},
+		{"ShouldMatchPresent", schema.AccessControlRuleHeader{Operator: operatorPresent, Key: "User-Agent"}, header, true},
+		{"ShouldNotMatchPresent", schema.AccessControlRuleHeader{Operator: operatorPresent, Key: "X-Tenant"}, header, false},
+		{"ShouldNotMatchPresentNilHeader", schema.AccessControlRuleHeader{Operator: operatorPresent, Key: "X-Tenant"}, nil, false},
+		{"ShouldMatchAbsent", schema.AccessControlRuleHeader{Operator: operatorAbsent, Key: "X-Tenant"}, header, true},
+		{"ShouldNotMatchAbsent", schema.AccessControlRuleHeader{Operator: operatorAbsent, Key: "User-Agent"}, header, false},
+		{"ShouldMatchPattern", schema.AccessControlRuleHeader{Operator: operatorPattern, Key: "user-agent", Value: regexp.MustCompile(`^curl/`)}, header, true},
+		{"ShouldNotMatchPattern", schema.AccessControlRuleHeader{Operator: operatorPattern, Key: "User-Agent", Value: regexp.MustCompile(`^Mozilla/`)}, header, false},
+		{"ShouldMatchNotPattern", schema.AccessControlRuleHeader{Operator: operatorNotPattern, Key: "User-Agent", Value: regexp.MustCompile(`^Mozilla/`)}, header, true},
+		{"ShouldNotMatchNotPattern", schema.AccessControlRuleHeader{Operator: operatorNotPattern, Key: "User-Agent", Value: regexp.MustCompile(`^curl/`)}, header, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			matcher, err := NewAccessControlHeaderObjectMatcher(tc.have)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, matcher.IsMatch(Object{Header: tc.header}))
+		})
+	}
+}
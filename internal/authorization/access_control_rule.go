@@ -2,6 +2,7 @@ package authorization
 
 import (
 	"net"
+	"time"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/utils"
@@ -10,23 +11,30 @@ import (
 // NewAccessControlRules converts a schema.AccessControl into an AccessControlRule slice.
 func NewAccessControlRules(config schema.AccessControl) (rules []*AccessControlRule) {
 	networksMap, networksCacheMap := parseSchemaNetworks(config.Networks)
+	geoipMap := parseSchemaNetworksGeoIP(config.Networks)
 
 	for i, schemaRule := range config.Rules {
-		rules = append(rules, NewAccessControlRule(i+1, schemaRule, networksMap, networksCacheMap))
+		rules = append(rules, NewAccessControlRule(i+1, schemaRule, networksMap, networksCacheMap, geoipMap))
 	}
 
 	return rules
 }
 
 // NewAccessControlRule parses a schema ACL and generates an internal ACL.
-func NewAccessControlRule(pos int, rule schema.AccessControlRule, networksMap map[string][]*net.IPNet, networksCacheMap map[string]*net.IPNet) *AccessControlRule {
+func NewAccessControlRule(pos int, rule schema.AccessControlRule, networksMap map[string][]*net.IPNet, networksCacheMap map[string]*net.IPNet, geoipMap map[string][]SubjectMatcher) *AccessControlRule {
 	r := &AccessControlRule{
-		Position: pos,
-		Query:    NewAccessControlQuery(rule.Query),
-		Methods:  schemaMethodsToACL(rule.Methods),
-		Networks: schemaNetworksToACL(rule.Networks, networksMap, networksCacheMap),
-		Subjects: schemaSubjectsToACL(rule.Subjects),
-		Policy:   NewLevel(rule.Policy),
+		Position:  pos,
+		Query:     NewAccessControlQuery(rule.Query),
+		Headers:   NewAccessControlHeaders(rule.Headers),
+		Methods:   schemaMethodsToACL(rule.Methods),
+		Networks:  schemaNetworksToACL(rule.Networks, networksMap, networksCacheMap),
+		GeoIP:     schemaNetworksToGeoIP(rule.Networks, geoipMap),
+		Subjects:  schemaSubjectsToACL(rule.Subjects),
+		Schedules: NewAccessControlSchedules(rule.Schedule),
+		Policy:    NewLevel(rule.Policy),
+
+		SecondFactorMethods: rule.SecondFactorMethods,
+		MaxAge:              rule.MaxAge,
 	}
 
 	if len(r.Subjects) != 0 {
@@ -48,14 +56,24 @@ type AccessControlRule struct {
 	Domains   []AccessControlDomain
 	Resources []AccessControlResource
 	Query     []AccessControlQuery
+	Headers   []AccessControlHeaders
 	Methods   []string
 	Networks  []*net.IPNet
+	GeoIP     []SubjectMatcher
 	Subjects  []AccessControlSubjects
+	Schedules []AccessControlSchedule
 	Policy    Level
+
+	// SecondFactorMethods is the list of second factor methods of which at least one must have been used to satisfy the
+	// TwoFactor policy.
+	SecondFactorMethods []string
+
+	// MaxAge is the maximum duration since the factor required by the Policy was last performed.
+	MaxAge time.Duration
 }
 
-// IsMatch returns true if all elements of an AccessControlRule match the object and subject.
-func (acr *AccessControlRule) IsMatch(subject Subject, object Object) (match bool) {
+// IsMatch returns true if all elements of an AccessControlRule match the object and subject at the given time.
+func (acr *AccessControlRule) IsMatch(subject Subject, object Object, now time.Time) (match bool) {
 	if !acr.MatchesDomains(subject, object) {
 		return false
 	}
@@ -68,6 +86,10 @@ func (acr *AccessControlRule) IsMatch(subject Subject, object Object) (match boo
 		return false
 	}
 
+	if !acr.MatchesHeaders(object) {
+		return false
+	}
+
 	if !acr.MatchesMethods(object) {
 		return false
 	}
@@ -76,6 +98,10 @@ func (acr *AccessControlRule) IsMatch(subject Subject, object Object) (match boo
 		return false
 	}
 
+	if !acr.MatchesSchedule(now) {
+		return false
+	}
+
 	if !acr.MatchesSubjects(subject) {
 		return false
 	}
@@ -134,6 +160,23 @@ func (acr *AccessControlRule) MatchesQuery(object Object) (match bool) {
 	return false
 }
 
+// MatchesHeaders returns true if the rule matches the request headers.
+func (acr *AccessControlRule) MatchesHeaders(object Object) (match bool) {
+	// If there are no header rules in this rule then the header condition is a match.
+	if len(acr.Headers) == 0 {
+		return true
+	}
+
+	// Iterate over the headers until we find a match (return true) or until we exit the loop (return false).
+	for _, header := range acr.Headers {
+		if header.IsMatch(object) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // MatchesMethods returns true if the rule matches the method.
 func (acr *AccessControlRule) MatchesMethods(object Object) (match bool) {
 	// If there are no methods in this rule then the method condition is a match.
@@ -147,7 +190,7 @@ func (acr *AccessControlRule) MatchesMethods(object Object) (match bool) {
 // MatchesNetworks returns true if the rule matches the networks.
 func (acr *AccessControlRule) MatchesNetworks(subject Subject) (match bool) {
 	// If there are no networks in this rule then the network condition is a match.
-	if len(acr.Networks) == 0 {
+	if len(acr.Networks) == 0 && len(acr.GeoIP) == 0 {
 		return true
 	}
 
@@ -158,6 +201,30 @@ func (acr *AccessControlRule) MatchesNetworks(subject Subject) (match bool) {
 		}
 	}
 
+	// Iterate over the country, continent, and autonomous system networks until we find a match.
+	for _, network := range acr.GeoIP {
+		if network.IsMatch(subject) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchesSchedule returns true if the rule matches the schedules.
+func (acr *AccessControlRule) MatchesSchedule(now time.Time) (match bool) {
+	// If there are no schedules in this rule then the schedule condition is a match.
+	if len(acr.Schedules) == 0 {
+		return true
+	}
+
+	// Iterate over the schedules until we find a match (return true) or until we exit the loop (return false).
+	for _, schedule := range acr.Schedules {
+		if schedule.IsMatch(now) {
+			return true
+		}
+	}
+
 	return false
 }
 
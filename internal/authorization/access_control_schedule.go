@@ -0,0 +1,184 @@
+package authorization
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewAccessControlSchedules creates a new AccessControlSchedule slice from a schema.AccessControlRuleSchedule slice.
+// Invalid schedules are skipped as they are reported by the configuration validation.
+func NewAccessControlSchedules(config []schema.AccessControlRuleSchedule) (schedules []AccessControlSchedule) {
+	if len(config) == 0 {
+		return nil
+	}
+
+	for _, s := range config {
+		schedule, err := NewAccessControlSchedule(s)
+		if err != nil {
+			continue
+		}
+
+		schedules = append(schedules, *schedule)
+	}
+
+	return schedules
+}
+
+// NewAccessControlSchedule creates a new AccessControlSchedule from a schema.AccessControlRuleSchedule.
+func NewAccessControlSchedule(config schema.AccessControlRuleSchedule) (schedule *AccessControlSchedule, err error) {
+	schedule = &AccessControlSchedule{
+		Location: time.UTC,
+		Start:    -1,
+		End:      -1,
+	}
+
+	if config.Timezone != "" {
+		if schedule.Location, err = time.LoadLocation(config.Timezone); err != nil {
+			return nil, fmt.Errorf("option 'timezone' with value '%s' is invalid: %w", config.Timezone, err)
+		}
+	}
+
+	for _, day := range config.Days {
+		weekday, ok := weekdays[strings.ToLower(day)]
+		if !ok {
+			return nil, fmt.Errorf("option 'days' has an invalid value '%s'", day)
+		}
+
+		schedule.Days = append(schedule.Days, weekday)
+	}
+
+	if schedule.Start, err = parseScheduleTimeOfDay(config.StartTime); err != nil {
+		return nil, fmt.Errorf("option 'start_time' %w", err)
+	}
+
+	if schedule.End, err = parseScheduleTimeOfDay(config.EndTime); err != nil {
+		return nil, fmt.Errorf("option 'end_time' %w", err)
+	}
+
+	if schedule.Start != -1 && schedule.Start == schedule.End {
+		return nil, fmt.Errorf("options 'start_time' and 'end_time' must not be equal")
+	}
+
+	if schedule.NotBefore, err = parseScheduleDate(config.StartDate, schedule.Location, false); err != nil {
+		return nil, fmt.Errorf("option 'start_date' %w", err)
+	}
+
+	if schedule.NotAfter, err = parseScheduleDate(config.EndDate, schedule.Location, true); err != nil {
+		return nil, fmt.Errorf("option 'end_date' %w", err)
+	}
+
+	if !schedule.NotBefore.IsZero() && !schedule.NotAfter.IsZero() && !schedule.NotAfter.After(schedule.NotBefore) {
+		return nil, fmt.Errorf("option 'end_date' must be after option 'start_date'")
+	}
+
+	return schedule, nil
+}
+
+// AccessControlSchedule represents an ACL time window. The Start and End are the minutes since midnight in the
+// Location, or -1 if not configured.
+type AccessControlSchedule struct {
+	Location *time.Location
+	Days     []time.Weekday
+
+	Start int
+	End   int
+
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// IsMatch returns true if the time is within this schedule.
+func (acs AccessControlSchedule) IsMatch(now time.Time) (match bool) {
+	now = now.In(acs.Location)
+
+	if !acs.NotBefore.IsZero() && now.Before(acs.NotBefore) {
+		return false
+	}
+
+	if !acs.NotAfter.IsZero() && !now.Before(acs.NotAfter) {
+		return false
+	}
+
+	minute, day := now.Hour()*60+now.Minute(), now.Weekday()
+
+	switch {
+	case acs.Start == -1 && acs.End == -1:
+		break
+	case acs.Start == -1:
+		if minute >= acs.End {
+			return false
+		}
+	case acs.End == -1:
+		if minute < acs.Start {
+			return false
+		}
+	case acs.Start < acs.End:
+		if minute < acs.Start || minute >= acs.End {
+			return false
+		}
+	default:
+		// The window spans midnight so the time after midnight belongs to the window which started the previous day.
+		switch {
+		case minute >= acs.Start:
+			break
+		case minute < acs.End:
+			day = (day + 6) % 7
+		default:
+			return false
+		}
+	}
+
+	return acs.matchesDay(day)
+}
+
+func (acs AccessControlSchedule) matchesDay(day time.Weekday) bool {
+	if len(acs.Days) == 0 {
+		return true
+	}
+
+	for _, d := range acs.Days {
+		if d == day {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseScheduleTimeOfDay(value string) (minutes int, err error) {
+	if value == "" {
+		return -1, nil
+	}
+
+	var t time.Time
+
+	if t, err = time.Parse(layoutScheduleTime, value); err != nil {
+		return -1, fmt.Errorf("with value '%s' must be in the HH:MM format", value)
+	}
+
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func parseScheduleDate(value string, location *time.Location, end bool) (t time.Time, err error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err = time.ParseInLocation(layoutScheduleDateTime, value, location); err == nil {
+		return t, nil
+	}
+
+	if t, err = time.ParseInLocation(layoutScheduleDate, value, location); err != nil {
+		return time.Time{}, fmt.Errorf("with value '%s' must be in the YYYY-MM-DD or YYYY-MM-DD HH:MM format", value)
+	}
+
+	// A date without a time is inclusive of the entire day.
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+
+	return t, nil
+}
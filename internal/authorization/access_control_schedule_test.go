@@ -0,0 +1,235 @@
+package authorization
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestNewAccessControlSchedule(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     schema.AccessControlRuleSchedule
+		expected string
+	}{
+		{
+			"ShouldParseFull",
+			schema.AccessControlRuleSchedule{Timezone: "Australia/Melbourne", Days: []string{"monday", "Friday"}, StartTime: "08:00", EndTime: "18:00", StartDate: "2026-01-01", EndDate: "2026-12-31 18:00"},
+			"",
+		},
+		{
+			"ShouldParseEmpty",
+			schema.AccessControlRuleSchedule{},
+			"",
+		},
+		{
+			"ShouldErrInvalidTimezone",
+			schema.AccessControlRuleSchedule{Timezone: "Not/AZone"},
+			"option 'timezone' with value 'Not/AZone' is invalid: unknown time zone Not/AZone",
+		},
+		{
+			"ShouldErrInvalidDay",
+			schema.AccessControlRuleSchedule{Days: []string{"funday"}},
+			"option 'days' has an invalid value 'funday'",
+		},
+		{
+			"ShouldErrInvalidStartTime",
+			schema.AccessControlRuleSchedule{StartTime: "8am"},
+			"option 'start_time' with value '8am' must be in the HH:MM format",
+		},
+		{
+			"ShouldErrInvalidEndTime",
+			schema.AccessControlRuleSchedule{EndTime: "25:00"},
+			"option 'end_time' with value '25:00' must be in the HH:MM format",
+		},
+		{
+			"ShouldErrEqualTimes",
+			schema.AccessControlRuleSchedule{StartTime: "08:00", EndTime: "08:00"},
+			"options 'start_time' and 'end_time' must not be equal",
+		},
+		{
+			"ShouldErrInvalidStartDate",
+			schema.AccessControlRuleSchedule{StartDate: "01/01/2026"},
+			"option 'start_date' with value '01/01/2026' must be in the YYYY-MM-DD or YYYY-MM-DD HH:MM format",
+		},
+		{
+			"ShouldErrInvalidEndDate",
+			schema.AccessControlRuleSchedule{EndDate: "tomorrow"},
+			"option 'end_date' with value 'tomorrow' must be in the YYYY-MM-DD or YYYY-MM-DD HH:MM format",
+		},
+		{
+			"ShouldErrEndDateBeforeStartDate",
+			schema.AccessControlRuleSchedule{StartDate: "2026-01-02", EndDate: "2026-01-01"},
+			"option 'end_date' must be after option 'start_date'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := NewAccessControlSchedule(tc.have)
+
+			if tc.expected == "" {
+				assert.NoError(t, err)
+				assert.NotNil(t, schedule)
+			} else {
+				assert.EqualError(t, err, tc.expected)
+				assert.Nil(t, schedule)
+			}
+		})
+	}
+}
+
+func TestAccessControlScheduleIsMatch(t *testing.T) {
+	melbourne, err := time.LoadLocation("Australia/Melbourne")
+	require.NoError(t, err)
+
+	weekdays := []string{"monday", "tuesday", "wednesday", "thursday", "friday"}
+
+	testCases := []struct {
+		name     string
+		have     schema.AccessControlRuleSchedule
+		time     time.Time
+		expected bool
+	}{
+		{
+			"ShouldMatchEmpty",
+			schema.AccessControlRuleSchedule{},
+			time.Date(2026, 10, 18, 3, 0, 0, 0, time.UTC),
+			true,
+		},
+		{
+			"ShouldMatchBusinessHours",
+			schema.AccessControlRuleSchedule{Timezone: "Australia/Melbourne", Days: weekdays, StartTime: "08:00", EndTime: "18:00"},
+			time.Date(2026, 10, 19, 8, 0, 0, 0, melbourne),
+			true,
+		},
+		{
+			"ShouldMatchBusinessHoursOtherTimezone",
+			schema.AccessControlRuleSchedule{Timezone: "Australia/Melbourne", Days: weekdays, StartTime: "08:00", EndTime: "18:00"},
+			time.Date(2026, 10, 19, 6, 59, 0, 0, time.UTC),
+			true,
+		},
+		{
+			"ShouldNotMatchBusinessHoursEnd",
+			schema.AccessControlRuleSchedule{Timezone: "Australia/Melbourne", Days: weekdays, StartTime: "08:00", EndTime: "18:00"},
+			time.Date(2026, 10, 19, 18, 0, 0, 0, melbourne),
+			false,
+		},
+		{
+			"ShouldNotMatchBusinessHoursBeforeStart",
+			schema.AccessControlRuleSchedule{Timezone: "Australia/Melbourne", Days: weekdays, StartTime: "08:00", EndTime: "18:00"},
+			time.Date(2026, 10, 19, 7, 59, 0, 0, melbourne),
+			false,
+		},
+		{
+			"ShouldNotMatchBusinessHoursWeekend",
+			schema.AccessControlRuleSchedule{Timezone: "Australia/Melbourne", Days: weekdays, StartTime: "08:00", EndTime: "18:00"},
+			time.Date(2026, 10, 18, 12, 0, 0, 0, melbourne),
+			false,
+		},
+		{
+			"ShouldMatchOnlyStartTime",
+			schema.AccessControlRuleSchedule{StartTime: "12:00"},
+			time.Date(2026, 10, 18, 23, 59, 0, 0, time.UTC),
+			true,
+		},
+		{
+			"ShouldNotMatchOnlyStartTime",
+			schema.AccessControlRuleSchedule{StartTime: "12:00"},
+			time.Date(2026, 10, 18, 11, 59, 0, 0, time.UTC),
+			false,
+		},
+		{
+			"ShouldMatchOnlyEndTime",
+			schema.AccessControlRuleSchedule{EndTime: "12:00"},
+			time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+			true,
+		},
+		{
+			"ShouldNotMatchOnlyEndTime",
+			schema.AccessControlRuleSchedule{EndTime: "12:00"},
+			time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+			false,
+		},
+		{
+			"ShouldMatchOvernightSameDay",
+			schema.AccessControlRuleSchedule{Days: []string{"friday"}, StartTime: "22:00", EndTime: "06:00"},
+			time.Date(2026, 10, 16, 23, 0, 0, 0, time.UTC),
+			true,
+		},
+		{
+			"ShouldMatchOvernightNextDay",
+			schema.AccessControlRuleSchedule{Days: []string{"friday"}, StartTime: "22:00", EndTime: "06:00"},
+			time.Date(2026, 10, 17, 5, 59, 0, 0, time.UTC),
+			true,
+		},
+		{
+			"ShouldNotMatchOvernightPreviousDay",
+			schema.AccessControlRuleSchedule{Days: []string{"friday"}, StartTime: "22:00", EndTime: "06:00"},
+			time.Date(2026, 10, 16, 5, 0, 0, 0, time.UTC),
+			false,
+		},
+		{
+			"ShouldNotMatchOvernightMiddle",
+			schema.AccessControlRuleSchedule{Days: []string{"friday"}, StartTime: "22:00", EndTime: "06:00"},
+			time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+			false,
+		},
+		{
+			"ShouldMatchDateRangeInclusiveEnd",
+			schema.AccessControlRuleSchedule{StartDate: "2026-11-07", EndDate: "2026-11-07"},
+			time.Date(2026, 11, 7, 23, 59, 0, 0, time.UTC),
+			true,
+		},
+		{
+			"ShouldNotMatchDateRangeAfterEnd",
+			schema.AccessControlRuleSchedule{StartDate: "2026-11-07", EndDate: "2026-11-07"},
+			time.Date(2026, 11, 8, 0, 0, 0, 0, time.UTC),
+			false,
+		},
+		{
+			"ShouldNotMatchDateRangeBeforeStart",
+			schema.AccessControlRuleSchedule{StartDate: "2026-11-07", EndDate: "2026-11-07"},
+			time.Date(2026, 11, 6, 23, 59, 0, 0, time.UTC),
+			false,
+		},
+		{
+			"ShouldMatchDateTimeRange",
+			schema.AccessControlRuleSchedule{Timezone: "Australia/Melbourne", StartDate: "2026-11-07 20:00", EndDate: "2026-11-08 02:00"},
+			time.Date(2026, 11, 8, 1, 0, 0, 0, melbourne),
+			true,
+		},
+		{
+			"ShouldNotMatchDateTimeRangeExclusiveEnd",
+			schema.AccessControlRuleSchedule{Timezone: "Australia/Melbourne", StartDate: "2026-11-07 20:00", EndDate: "2026-11-08 02:00"},
+			time.Date(2026, 11, 8, 2, 0, 0, 0, melbourne),
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := NewAccessControlSchedule(tc.have)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, schedule.IsMatch(tc.time))
+		})
+	}
+}
+
+func TestNewAccessControlSchedulesShouldSkipInvalid(t *testing.T) {
+	assert.Nil(t, NewAccessControlSchedules(nil))
+
+	schedules := NewAccessControlSchedules([]schema.AccessControlRuleSchedule{
+		{StartTime: "08:00", EndTime: "18:00"},
+		{StartTime: "8am"},
+	})
+
+	require.Len(t, schedules, 1)
+	assert.Equal(t, 480, schedules[0].Start)
+	assert.Equal(t, 1080, schedules[0].End)
+}
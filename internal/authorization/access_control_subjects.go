@@ -1,9 +1,83 @@
 package authorization
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
+
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
+// NewAccessControlSubject parses a subject rule into a SubjectMatcher. The rule is either a user, group, email address,
+// or OAuth 2.0 client prefixed with 'user:', 'group:', 'email:', or 'oauth2:client:', a regular expression matching the
+// username, any of the groups, or any of the email addresses prefixed with 'user~', 'group~', or 'email~', or a user
+// attribute in the 'attr:<name>=<value>' or 'attr:<name>~<regex>' format.
+func NewAccessControlSubject(subjectRule string) (matcher SubjectMatcher, err error) {
+	switch {
+	case strings.HasPrefix(subjectRule, prefixUser):
+		return AccessControlUser{Name: strings.Trim(subjectRule[lenPrefixUser:], " ")}, nil
+	case strings.HasPrefix(subjectRule, prefixGroup):
+		return AccessControlGroup{Name: strings.Trim(subjectRule[lenPrefixGroup:], " ")}, nil
+	case strings.HasPrefix(subjectRule, prefixEmail):
+		return AccessControlEmail{Address: strings.Trim(strings.TrimPrefix(subjectRule, prefixEmail), " ")}, nil
+	case strings.HasPrefix(subjectRule, prefixOAuth2Client):
+		return AccessControlClient{Provider: "OAuth2", ID: strings.Trim(subjectRule[lenPrefixOAuth2Client:], " ")}, nil
+	case strings.HasPrefix(subjectRule, prefixAttribute):
+		return newAccessControlAttribute(strings.TrimPrefix(subjectRule, prefixAttribute))
+	}
+
+	var (
+		pattern *regexp.Regexp
+		prefix  string
+	)
+
+	switch {
+	case strings.HasPrefix(subjectRule, prefixUserRegex):
+		prefix = prefixUserRegex
+	case strings.HasPrefix(subjectRule, prefixGroupRegex):
+		prefix = prefixGroupRegex
+	case strings.HasPrefix(subjectRule, prefixEmailRegex):
+		prefix = prefixEmailRegex
+	default:
+		return nil, fmt.Errorf("must start with %s", utils.StringJoinOr([]string{prefixUser, prefixGroup, prefixEmail, prefixAttribute, prefixOAuth2Client, prefixUserRegex, prefixGroupRegex, prefixEmailRegex}))
+	}
+
+	if pattern, err = regexp.Compile(strings.TrimPrefix(subjectRule, prefix)); err != nil {
+		return nil, fmt.Errorf("the regular expression is not valid: %w", err)
+	}
+
+	switch prefix {
+	case prefixUserRegex:
+		return AccessControlUserRegex{Pattern: pattern}, nil
+	case prefixGroupRegex:
+		return AccessControlGroupRegex{Pattern: pattern}, nil
+	default:
+		return AccessControlEmailRegex{Pattern: pattern}, nil
+	}
+}
+
+func newAccessControlAttribute(value string) (matcher SubjectMatcher, err error) {
+	i := strings.IndexAny(value, "=~")
+
+	if i < 0 || strings.TrimSpace(value[:i]) == "" {
+		return nil, fmt.Errorf("must be in the '%s<name>=<value>' or '%s<name>~<regex>' format", prefixAttribute, prefixAttribute)
+	}
+
+	attribute := AccessControlAttribute{Name: strings.TrimSpace(value[:i])}
+
+	if value[i] == '=' {
+		attribute.Value = value[i+1:]
+
+		return attribute, nil
+	}
+
+	if attribute.Pattern, err = regexp.Compile(value[i+1:]); err != nil {
+		return nil, fmt.Errorf("the regular expression is not valid: %w", err)
+	}
+
+	return attribute, nil
+}
+
 // AccessControlSubjects represents an ACL subject.
 type AccessControlSubjects struct {
 	Subjects []SubjectMatcher
@@ -59,3 +133,81 @@ type AccessControlClient struct {
 func (acg AccessControlClient) IsMatch(subject Subject) (match bool) {
 	return acg.ID == subject.ClientID
 }
+
+// AccessControlEmail represents an ACL subject of type `email:`.
+type AccessControlEmail struct {
+	Address string
+}
+
+// IsMatch returns true if the AccessControlEmail address case-insensitively matches one of the emails of the Subject.
+func (ace AccessControlEmail) IsMatch(subject Subject) (match bool) {
+	for _, email := range subject.Emails {
+		if strings.EqualFold(email, ace.Address) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AccessControlUserRegex represents an ACL subject of type `user~`.
+type AccessControlUserRegex struct {
+	Pattern *regexp.Regexp
+}
+
+// IsMatch returns true if the AccessControlUserRegex pattern matches the Subject username.
+func (acu AccessControlUserRegex) IsMatch(subject Subject) (match bool) {
+	return subject.Username != "" && acu.Pattern.MatchString(subject.Username)
+}
+
+// AccessControlGroupRegex represents an ACL subject of type `group~`.
+type AccessControlGroupRegex struct {
+	Pattern *regexp.Regexp
+}
+
+// IsMatch returns true if the AccessControlGroupRegex pattern matches one of the groups of the Subject.
+func (acg AccessControlGroupRegex) IsMatch(subject Subject) (match bool) {
+	return isAnyStringMatch(acg.Pattern, subject.Groups)
+}
+
+// AccessControlEmailRegex represents an ACL subject of type `email~`.
+type AccessControlEmailRegex struct {
+	Pattern *regexp.Regexp
+}
+
+// IsMatch returns true if the AccessControlEmailRegex pattern matches one of the emails of the Subject.
+func (ace AccessControlEmailRegex) IsMatch(subject Subject) (match bool) {
+	return isAnyStringMatch(ace.Pattern, subject.Emails)
+}
+
+// AccessControlAttribute represents an ACL subject of type `attr:`. The attribute matches when it's equal to the
+// Value, or when the Pattern is not nil and it matches the attribute.
+type AccessControlAttribute struct {
+	Name    string
+	Value   string
+	Pattern *regexp.Regexp
+}
+
+// IsMatch returns true if the Subject has the attribute and the value matches.
+func (aca AccessControlAttribute) IsMatch(subject Subject) (match bool) {
+	value, ok := subject.Attributes[aca.Name]
+	if !ok {
+		return false
+	}
+
+	if aca.Pattern != nil {
+		return aca.Pattern.MatchString(value)
+	}
+
+	return value == aca.Value
+}
+
+func isAnyStringMatch(pattern *regexp.Regexp, values []string) (match bool) {
+	for _, value := range values {
+		if pattern.MatchString(value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,78 @@
+package authorization
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAccessControlSubject(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected SubjectMatcher
+		err      string
+	}{
+		{"ShouldParseUser", "user:john", AccessControlUser{Name: "john"}, ""},
+		{"ShouldParseGroup", "group: admins ", AccessControlGroup{Name: "admins"}, ""},
+		{"ShouldParseEmail", "email:john@example.com", AccessControlEmail{Address: "john@example.com"}, ""},
+		{"ShouldParseClient", "oauth2:client:app", AccessControlClient{Provider: "OAuth2", ID: "app"}, ""},
+		{"ShouldParseUserRegex", "user~^svc-", AccessControlUserRegex{Pattern: regexp.MustCompile("^svc-")}, ""},
+		{"ShouldParseGroupRegex", "group~^team-.*-admins$", AccessControlGroupRegex{Pattern: regexp.MustCompile("^team-.*-admins$")}, ""},
+		{"ShouldParseEmailRegex", `email~@example\.com$`, AccessControlEmailRegex{Pattern: regexp.MustCompile(`@example\.com$`)}, ""},
+		{"ShouldParseAttribute", "attr:department=finance", AccessControlAttribute{Name: "department", Value: "finance"}, ""},
+		{"ShouldParseAttributeEmptyValue", "attr:department=", AccessControlAttribute{Name: "department"}, ""},
+		{"ShouldParseAttributeRegex", "attr:department~^(finance|legal)$", AccessControlAttribute{Name: "department", Pattern: regexp.MustCompile("^(finance|legal)$")}, ""},
+		{"ShouldParseAttributeValueWithSeparators", "attr:title=a~b=c", AccessControlAttribute{Name: "title", Value: "a~b=c"}, ""},
+		{"ShouldErrorPrefix", "admins", nil, "must start with 'user:', 'group:', 'email:', 'attr:', 'oauth2:client:', 'user~', 'group~', or 'email~'"},
+		{"ShouldErrorRegex", "group~^team-(", nil, "the regular expression is not valid: error parsing regexp: missing closing ): `^team-(`"},
+		{"ShouldErrorAttributeFormat", "attr:department", nil, "must be in the 'attr:<name>=<value>' or 'attr:<name>~<regex>' format"},
+		{"ShouldErrorAttributeName", "attr:=finance", nil, "must be in the 'attr:<name>=<value>' or 'attr:<name>~<regex>' format"},
+		{"ShouldErrorAttributeRegex", "attr:department~(", nil, "the regular expression is not valid: error parsing regexp: missing closing ): `(`"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := NewAccessControlSubject(tc.have)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, actual)
+			} else {
+				assert.EqualError(t, err, tc.err)
+				assert.Nil(t, actual)
+			}
+		})
+	}
+}
+
+func TestAccessControlSubjectMatchers(t *testing.T) {
+	subject := Subject{
+		Username:   "svc-backup",
+		Groups:     []string{"dev", "team-payments-admins"},
+		Emails:     []string{"Backup@Example.com"},
+		Attributes: map[string]string{"department": "finance", "title": ""},
+	}
+
+	assert.True(t, AccessControlEmail{Address: "backup@example.com"}.IsMatch(subject))
+	assert.False(t, AccessControlEmail{Address: "john@example.com"}.IsMatch(subject))
+
+	assert.True(t, AccessControlUserRegex{Pattern: regexp.MustCompile("^svc-")}.IsMatch(subject))
+	assert.False(t, AccessControlUserRegex{Pattern: regexp.MustCompile("^usr-")}.IsMatch(subject))
+	assert.False(t, AccessControlUserRegex{Pattern: regexp.MustCompile(".*")}.IsMatch(Subject{}))
+
+	assert.True(t, AccessControlGroupRegex{Pattern: regexp.MustCompile("^team-.*-admins$")}.IsMatch(subject))
+	assert.False(t, AccessControlGroupRegex{Pattern: regexp.MustCompile("^team-.*-users$")}.IsMatch(subject))
+
+	assert.True(t, AccessControlEmailRegex{Pattern: regexp.MustCompile(`(?i)@example\.com$`)}.IsMatch(subject))
+	assert.False(t, AccessControlEmailRegex{Pattern: regexp.MustCompile(`@example\.org$`)}.IsMatch(subject))
+
+	assert.True(t, AccessControlAttribute{Name: "department", Value: "finance"}.IsMatch(subject))
+	assert.False(t, AccessControlAttribute{Name: "department", Value: "Finance"}.IsMatch(subject))
+	assert.True(t, AccessControlAttribute{Name: "title", Value: ""}.IsMatch(subject))
+	assert.False(t, AccessControlAttribute{Name: "location", Value: ""}.IsMatch(subject))
+	assert.True(t, AccessControlAttribute{Name: "department", Pattern: regexp.MustCompile("^fin")}.IsMatch(subject))
+	assert.False(t, AccessControlAttribute{Name: "department", Pattern: regexp.MustCompile("^legal$")}.IsMatch(subject))
+	assert.False(t, AccessControlAttribute{Name: "location", Pattern: regexp.MustCompile(".*")}.IsMatch(subject))
+}
@@ -1,62 +1,122 @@
 package authorization
 
 import (
+	"sync/atomic"
+
 	"github.com/sirupsen/logrus"
 
+	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/geoip"
 	"github.com/authelia/authelia/v4/internal/logging"
 )
 
 // Authorizer the component in charge of checking whether a user can access a given resource.
 type Authorizer struct {
+	state atomic.Pointer[authorizerState]
+	clock clock.Provider
+	geoip *geoip.Provider
+	log   *logrus.Logger
+}
+
+// authorizerState is the state of the Authorizer derived from the configuration which is replaced as a whole when the
+// Authorizer is updated.
+type authorizerState struct {
 	defaultPolicy Level
 	rules         []*AccessControlRule
+	headers       []string
 	mfa           bool
-	log           *logrus.Logger
+	geoip         *geoip.Provider
 }
 
-// NewAuthorizer create an instance of authorizer with a given access control config.
-func NewAuthorizer(config *schema.Configuration) (authorizer *Authorizer) {
+// NewAuthorizer create an instance of authorizer with a given access control config. The GeoIP provider may be nil if
+// no rules use the country, continent, or autonomous system networks.
+func NewAuthorizer(config *schema.Configuration, clock clock.Provider, geoip *geoip.Provider) (authorizer *Authorizer) {
 	authorizer = &Authorizer{
+		clock: clock,
+		geoip: geoip,
+		log:   logging.Logger(),
+	}
+
+	authorizer.Update(config)
+
+	return authorizer
+}
+
+// Update atomically replaces the rules and default policy of the Authorizer with the ones from the given config. Any
+// request being checked while the Authorizer is updated is checked entirely against either the old or the new rules.
+func (p *Authorizer) Update(config *schema.Configuration) {
+	p.state.Store(newAuthorizerState(config, p.geoip))
+}
+
+func newAuthorizerState(config *schema.Configuration, geoip *geoip.Provider) (state *authorizerState) {
+	state = &authorizerState{
 		defaultPolicy: NewLevel(config.AccessControl.DefaultPolicy),
 		rules:         NewAccessControlRules(config.AccessControl),
-		log:           logging.Logger(),
+		headers:       schemaHeadersToHeaderNames(config.AccessControl.Rules),
+	}
+
+	for _, rule := range state.rules {
+		if len(rule.GeoIP) != 0 {
+			state.geoip = geoip
+
+			break
+		}
 	}
 
-	if authorizer.defaultPolicy == TwoFactor {
-		authorizer.mfa = true
+	if state.defaultPolicy == TwoFactor {
+		state.mfa = true
 
-		return authorizer
+		return state
 	}
 
-	for _, rule := range authorizer.rules {
+	for _, rule := range state.rules {
 		if rule.Policy == TwoFactor {
-			authorizer.mfa = true
+			state.mfa = true
 
-			return authorizer
+			return state
 		}
 	}
 
-	authorizer.mfa = isOpenIDConnectMFA(config)
+	state.mfa = isOpenIDConnectMFA(config)
 
-	return authorizer
+	return state
 }
 
 // IsSecondFactorEnabled return true if at least one policy is set to second factor.
 func (p *Authorizer) IsSecondFactorEnabled() bool {
-	return p.mfa
+	return p.state.Load().mfa
+}
+
+// HeaderNames returns the canonical names of the request headers the rules match against.
+func (p *Authorizer) HeaderNames() []string {
+	return p.state.Load().headers
 }
 
 // GetRequiredLevel retrieve the required level of authorization to access the object.
 func (p *Authorizer) GetRequiredLevel(subject Subject, object Object) (hasSubjects bool, level Level) {
+	hasSubjects, policy := p.GetRequiredPolicy(subject, object)
+
+	return hasSubjects, policy.Level
+}
+
+// GetRequiredPolicy retrieve the required level of authorization to access the object alongside the additional
+// requirements of the matching rule such as the second factor methods and the maximum authentication age.
+func (p *Authorizer) GetRequiredPolicy(subject Subject, object Object) (hasSubjects bool, policy RequiredPolicy) {
 	p.log.Debugf("Check authorization of subject %s and object %s (method %s).",
 		subject.String(), object.String(), object.Method)
 
-	for _, rule := range p.rules {
-		if rule.IsMatch(subject, object) {
+	state := p.state.Load()
+
+	subject = state.resolveSubject(subject)
+
+	now := p.clock.Now()
+
+	for _, rule := range state.rules {
+		if rule.IsMatch(subject, object, now) {
 			p.log.Tracef(traceFmtACLHitMiss, "HIT", rule.Position, subject, object, object.Method, rule.Policy)
 
-			return rule.HasSubjects, rule.Policy
+			return rule.HasSubjects, RequiredPolicy{Position: rule.Position, Level: rule.Policy, SecondFactorMethods: rule.SecondFactorMethods, MaxAge: rule.MaxAge}
 		}
 
 		p.log.Tracef(traceFmtACLHitMiss, "MISS", rule.Position, subject, object, object.Method, rule.Policy)
@@ -64,16 +124,22 @@ func (p *Authorizer) GetRequiredLevel(subject Subject, object Object) (hasSubjec
 
 	p.log.Debugf("No matching rule for subject %s and url %s (method %s) applying default policy", subject, object, object.Method)
 
-	return false, p.defaultPolicy
+	return false, RequiredPolicy{Level: state.defaultPolicy}
 }
 
 // GetRuleMatchResults iterates through the rules and produces a list of RuleMatchResult provided a subject and object.
 func (p *Authorizer) GetRuleMatchResults(subject Subject, object Object) (results []RuleMatchResult) {
 	skipped := false
 
-	results = make([]RuleMatchResult, len(p.rules))
+	state := p.state.Load()
 
-	for i, rule := range p.rules {
+	subject = state.resolveSubject(subject)
+
+	now := p.clock.Now()
+
+	results = make([]RuleMatchResult, len(state.rules))
+
+	for i, rule := range state.rules {
 		results[i] = RuleMatchResult{
 			Rule:    rule,
 			Skipped: skipped,
@@ -81,10 +147,12 @@ func (p *Authorizer) GetRuleMatchResults(subject Subject, object Object) (result
 			MatchDomain:        rule.MatchesDomains(subject, object),
 			MatchResources:     rule.MatchesResources(subject, object),
 			MatchQuery:         rule.MatchesQuery(object),
+			MatchHeaders:       rule.MatchesHeaders(object),
 			MatchMethods:       rule.MatchesMethods(object),
 			MatchNetworks:      rule.MatchesNetworks(subject),
 			MatchSubjects:      rule.MatchesSubjects(subject),
 			MatchSubjectsExact: rule.MatchesSubjectExact(subject),
+			MatchSchedule:      rule.MatchesSchedule(now),
 		}
 
 		skipped = skipped || results[i].IsMatch()
@@ -92,3 +160,14 @@ func (p *Authorizer) GetRuleMatchResults(subject Subject, object Object) (result
 
 	return results
 }
+
+// resolveSubject resolves the GeoIP information of the subject when rules require it and it's not already resolved.
+func (s *authorizerState) resolveSubject(subject Subject) Subject {
+	if s.geoip == nil || subject.IP == nil || !subject.GeoIP.IsEmpty() {
+		return subject
+	}
+
+	subject.GeoIP = s.geoip.Lookup(subject.IP)
+
+	return subject
+}
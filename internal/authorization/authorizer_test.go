@@ -2,16 +2,20 @@ package authorization
 
 import (
 	"net"
+	"net/http"
 	"net/url"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/valyala/fasthttp"
 
+	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/geoip"
 )
 
 type AuthorizerSuite struct {
@@ -22,13 +26,13 @@ type AuthorizerTester struct {
 	*Authorizer
 }
 
-func NewAuthorizerTester(config schema.AccessControl) *AuthorizerTester {
+func NewAuthorizerTester(config schema.AccessControl, clock clock.Provider) *AuthorizerTester {
 	fullConfig := &schema.Configuration{
 		AccessControl: config,
 	}
 
 	return &AuthorizerTester{
-		NewAuthorizer(fullConfig),
+		NewAuthorizer(fullConfig, clock, nil),
 	}
 }
 
@@ -52,10 +56,18 @@ func (s *AuthorizerTester) GetRuleMatchResults(subject Subject, requestURI, meth
 
 type AuthorizerTesterBuilder struct {
 	config schema.AccessControl
+	clock  clock.Provider
 }
 
 func NewAuthorizerBuilder() *AuthorizerTesterBuilder {
-	return &AuthorizerTesterBuilder{}
+	return &AuthorizerTesterBuilder{
+		clock: clock.New(),
+	}
+}
+
+func (b *AuthorizerTesterBuilder) WithClock(clock clock.Provider) *AuthorizerTesterBuilder {
+	b.clock = clock
+	return b
 }
 
 func (b *AuthorizerTesterBuilder) WithDefaultPolicy(policy string) *AuthorizerTesterBuilder {
@@ -69,7 +81,7 @@ func (b *AuthorizerTesterBuilder) WithRule(rule schema.AccessControlRule) *Autho
 }
 
 func (b *AuthorizerTesterBuilder) Build() *AuthorizerTester {
-	return NewAuthorizerTester(b.config)
+	return NewAuthorizerTester(b.config, b.clock)
 }
 
 var AnonymousUser = Subject{
@@ -412,47 +424,47 @@ func (s *AuthorizerSuite) TestShouldCheckDomainMatching() {
 	tester.CheckAuthorizations(s.T(), Bob, "https://x.example.com", fasthttp.MethodGet, TwoFactor)
 	tester.CheckAuthorizations(s.T(), AnonymousUser, "https://x.example.com", fasthttp.MethodGet, OneFactor)
 
-	s.Require().Len(tester.rules, 5)
+	s.Require().Len(tester.state.Load().rules, 5)
 
-	s.Require().Len(tester.rules[0].Domains, 1)
+	s.Require().Len(tester.state.Load().rules[0].Domains, 1)
 
-	ruleMatcher0, ok := tester.rules[0].Domains[0].Matcher.(*AccessControlDomainMatcher)
+	ruleMatcher0, ok := tester.state.Load().rules[0].Domains[0].Matcher.(*AccessControlDomainMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("public.example.com", ruleMatcher0.Name)
 	s.Assert().False(ruleMatcher0.Wildcard)
 	s.Assert().False(ruleMatcher0.UserWildcard)
 	s.Assert().False(ruleMatcher0.GroupWildcard)
 
-	s.Require().Len(tester.rules[1].Domains, 1)
+	s.Require().Len(tester.state.Load().rules[1].Domains, 1)
 
-	ruleMatcher1, ok := tester.rules[1].Domains[0].Matcher.(*AccessControlDomainMatcher)
+	ruleMatcher1, ok := tester.state.Load().rules[1].Domains[0].Matcher.(*AccessControlDomainMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("one-factor.example.com", ruleMatcher1.Name)
 	s.Assert().False(ruleMatcher1.Wildcard)
 	s.Assert().False(ruleMatcher1.UserWildcard)
 	s.Assert().False(ruleMatcher1.GroupWildcard)
 
-	s.Require().Len(tester.rules[2].Domains, 1)
+	s.Require().Len(tester.state.Load().rules[2].Domains, 1)
 
-	ruleMatcher2, ok := tester.rules[2].Domains[0].Matcher.(*AccessControlDomainMatcher)
+	ruleMatcher2, ok := tester.state.Load().rules[2].Domains[0].Matcher.(*AccessControlDomainMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("two-factor.example.com", ruleMatcher2.Name)
 	s.Assert().False(ruleMatcher2.Wildcard)
 	s.Assert().False(ruleMatcher2.UserWildcard)
 	s.Assert().False(ruleMatcher2.GroupWildcard)
 
-	s.Require().Len(tester.rules[3].Domains, 1)
+	s.Require().Len(tester.state.Load().rules[3].Domains, 1)
 
-	ruleMatcher3, ok := tester.rules[3].Domains[0].Matcher.(*AccessControlDomainMatcher)
+	ruleMatcher3, ok := tester.state.Load().rules[3].Domains[0].Matcher.(*AccessControlDomainMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal(".example.com", ruleMatcher3.Name)
 	s.Assert().True(ruleMatcher3.Wildcard)
 	s.Assert().False(ruleMatcher3.UserWildcard)
 	s.Assert().False(ruleMatcher3.GroupWildcard)
 
-	s.Require().Len(tester.rules[4].Domains, 1)
+	s.Require().Len(tester.state.Load().rules[4].Domains, 1)
 
-	ruleMatcher4, ok := tester.rules[4].Domains[0].Matcher.(*AccessControlDomainMatcher)
+	ruleMatcher4, ok := tester.state.Load().rules[4].Domains[0].Matcher.(*AccessControlDomainMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal(".example.com", ruleMatcher4.Name)
 	s.Assert().True(ruleMatcher4.Wildcard)
@@ -499,35 +511,35 @@ func (s *AuthorizerSuite) TestShouldCheckDomainRegexMatching() {
 	tester.CheckAuthorizations(s.T(), John, "https://group-dev.regex.com", fasthttp.MethodGet, TwoFactor)
 	tester.CheckAuthorizations(s.T(), Bob, "https://group-dev.regex.com", fasthttp.MethodGet, Denied)
 
-	s.Require().Len(tester.rules, 5)
+	s.Require().Len(tester.state.Load().rules, 5)
 
-	s.Require().Len(tester.rules[0].Domains, 1)
+	s.Require().Len(tester.state.Load().rules[0].Domains, 1)
 
-	ruleMatcher0, ok := tester.rules[0].Domains[0].Matcher.(RegexpStringSubjectMatcher)
+	ruleMatcher0, ok := tester.state.Load().rules[0].Domains[0].Matcher.(RegexpStringSubjectMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("^.*\\.example.com$", ruleMatcher0.String())
 
-	s.Require().Len(tester.rules[1].Domains, 1)
+	s.Require().Len(tester.state.Load().rules[1].Domains, 1)
 
-	ruleMatcher1, ok := tester.rules[1].Domains[0].Matcher.(RegexpStringSubjectMatcher)
+	ruleMatcher1, ok := tester.state.Load().rules[1].Domains[0].Matcher.(RegexpStringSubjectMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("^.*\\.example2.com$", ruleMatcher1.String())
 
-	s.Require().Len(tester.rules[2].Domains, 1)
+	s.Require().Len(tester.state.Load().rules[2].Domains, 1)
 
-	ruleMatcher2, ok := tester.rules[2].Domains[0].Matcher.(RegexpGroupStringSubjectMatcher)
+	ruleMatcher2, ok := tester.state.Load().rules[2].Domains[0].Matcher.(RegexpGroupStringSubjectMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("^(?P<User>[a-zA-Z0-9]+)\\.regex.com$", ruleMatcher2.String())
 
-	s.Require().Len(tester.rules[3].Domains, 1)
+	s.Require().Len(tester.state.Load().rules[3].Domains, 1)
 
-	ruleMatcher3, ok := tester.rules[3].Domains[0].Matcher.(RegexpGroupStringSubjectMatcher)
+	ruleMatcher3, ok := tester.state.Load().rules[3].Domains[0].Matcher.(RegexpGroupStringSubjectMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("^group-(?P<Group>[a-zA-Z0-9]+)\\.regex.com$", ruleMatcher3.String())
 
-	s.Require().Len(tester.rules[4].Domains, 1)
+	s.Require().Len(tester.state.Load().rules[4].Domains, 1)
 
-	ruleMatcher4, ok := tester.rules[4].Domains[0].Matcher.(RegexpStringSubjectMatcher)
+	ruleMatcher4, ok := tester.state.Load().rules[4].Domains[0].Matcher.(RegexpStringSubjectMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("^.*\\.(one|two).com$", ruleMatcher4.String())
 }
@@ -597,25 +609,25 @@ func (s *AuthorizerSuite) TestShouldCheckResourceSubjectMatching() {
 	tester.CheckAuthorizations(s.T(), Bob, "https://id.example.com/invalidgroup/group", fasthttp.MethodGet, Denied)
 	tester.CheckAuthorizations(s.T(), AnonymousUser, "https://id.example.com/invalidgroup/group", fasthttp.MethodGet, OneFactor)
 
-	s.Require().Len(tester.rules, 3)
+	s.Require().Len(tester.state.Load().rules, 3)
 
-	s.Require().Len(tester.rules[0].Resources, 2)
+	s.Require().Len(tester.state.Load().rules[0].Resources, 2)
 
-	ruleMatcher00, ok := tester.rules[0].Resources[0].Matcher.(RegexpGroupStringSubjectMatcher)
+	ruleMatcher00, ok := tester.state.Load().rules[0].Resources[0].Matcher.(RegexpGroupStringSubjectMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("^/(?P<User>[a-zA-Z0-9]+)/personal(/|/.*)?$", ruleMatcher00.String())
 
-	ruleMatcher01, ok := tester.rules[0].Resources[1].Matcher.(RegexpGroupStringSubjectMatcher)
+	ruleMatcher01, ok := tester.state.Load().rules[0].Resources[1].Matcher.(RegexpGroupStringSubjectMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("^/(?P<Group>[a-zA-Z0-9]+)/group(/|/.*)?$", ruleMatcher01.String())
 
-	s.Require().Len(tester.rules[1].Resources, 2)
+	s.Require().Len(tester.state.Load().rules[1].Resources, 2)
 
-	ruleMatcher10, ok := tester.rules[1].Resources[0].Matcher.(RegexpStringSubjectMatcher)
+	ruleMatcher10, ok := tester.state.Load().rules[1].Resources[0].Matcher.(RegexpStringSubjectMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("^/([a-zA-Z0-9]+)/personal(/|/.*)?$", ruleMatcher10.String())
 
-	ruleMatcher11, ok := tester.rules[1].Resources[1].Matcher.(RegexpStringSubjectMatcher)
+	ruleMatcher11, ok := tester.state.Load().rules[1].Resources[1].Matcher.(RegexpStringSubjectMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("^/([a-zA-Z0-9]+)/group(/|/.*)?$", ruleMatcher11.String())
 }
@@ -679,6 +691,36 @@ func (s *AuthorizerSuite) TestShouldCheckMultipleSubjectsMatching() {
 	tester.CheckAuthorizations(s.T(), AnonymousUser, "https://protected.example.com/", fasthttp.MethodGet, OneFactor)
 }
 
+func (s *AuthorizerSuite) TestShouldCheckAttributeAndExpressionSubjectsMatching() {
+	tester := NewAuthorizerBuilder().
+		WithDefaultPolicy(deny).
+		WithRule(schema.AccessControlRule{
+			Domains:  []string{"finance.example.com"},
+			Policy:   oneFactor,
+			Subjects: [][]string{{"attr:department=finance"}, {`email~@finance\.example\.com$`}},
+		}).
+		WithRule(schema.AccessControlRule{
+			Domains:  []string{"admin.example.com"},
+			Policy:   twoFactor,
+			Subjects: [][]string{{"group~^team-.*-admins$", "attr:location~^(AU|NZ)$"}},
+		}).
+		Build()
+
+	finance := Subject{Username: "alice", Attributes: map[string]string{"department": "finance"}}
+	email := Subject{Username: "carol", Emails: []string{"carol@finance.example.com"}}
+	admin := Subject{Username: "dave", Groups: []string{"team-payments-admins"}, Attributes: map[string]string{"location": "AU"}}
+	overseas := Subject{Username: "erin", Groups: []string{"team-payments-admins"}, Attributes: map[string]string{"location": "US"}}
+
+	tester.CheckAuthorizations(s.T(), finance, "https://finance.example.com/", fasthttp.MethodGet, OneFactor)
+	tester.CheckAuthorizations(s.T(), email, "https://finance.example.com/", fasthttp.MethodGet, OneFactor)
+	tester.CheckAuthorizations(s.T(), John, "https://finance.example.com/", fasthttp.MethodGet, Denied)
+	tester.CheckAuthorizations(s.T(), AnonymousUser, "https://finance.example.com/", fasthttp.MethodGet, OneFactor)
+
+	tester.CheckAuthorizations(s.T(), admin, "https://admin.example.com/", fasthttp.MethodGet, TwoFactor)
+	tester.CheckAuthorizations(s.T(), overseas, "https://admin.example.com/", fasthttp.MethodGet, Denied)
+	tester.CheckAuthorizations(s.T(), finance, "https://admin.example.com/", fasthttp.MethodGet, Denied)
+}
+
 func (s *AuthorizerSuite) TestShouldCheckIPMatching() {
 	tester := NewAuthorizerBuilder().
 		WithDefaultPolicy(deny).
@@ -723,6 +765,119 @@ func (s *AuthorizerSuite) TestShouldCheckIPMatching() {
 	tester.CheckAuthorizations(s.T(), Sam, "https://ipv6.example.com/", fasthttp.MethodGet, TwoFactor)
 }
 
+func (s *AuthorizerSuite) TestShouldCheckGeoIPMatching() {
+	tester := NewAuthorizerTester(schema.AccessControl{
+		DefaultPolicy: deny,
+		Networks: []schema.AccessControlNetwork{
+			{
+				Name:     "oceania",
+				Networks: []string{"continent:OC", "192.168.0.0/16"},
+			},
+		},
+		Rules: []schema.AccessControlRule{
+			{
+				Domains:  []string{"protected.example.com"},
+				Policy:   bypass,
+				Networks: []string{"country:AU"},
+			},
+			{
+				Domains:  []string{"protected.example.com"},
+				Policy:   oneFactor,
+				Networks: []string{"oceania"},
+			},
+			{
+				Domains:  []string{"protected.example.com"},
+				Policy:   twoFactor,
+				Networks: []string{"asn:AS13335", "10.0.0.0/8"},
+			},
+		},
+	}, clock.New())
+
+	australia := Subject{IP: net.ParseIP("1.1.1.1"), GeoIP: geoip.Record{Country: "AU", Continent: "OC", ASN: 13335}}
+	zealand := Subject{IP: net.ParseIP("1.1.1.2"), GeoIP: geoip.Record{Country: "NZ", Continent: "OC"}}
+	cloudflare := Subject{IP: net.ParseIP("1.1.1.3"), GeoIP: geoip.Record{Country: "US", Continent: "NA", ASN: 13335}}
+	unknown := Subject{IP: net.ParseIP("1.1.1.4"), GeoIP: geoip.Record{Country: "US", Continent: "NA", ASN: 15169}}
+
+	tester.CheckAuthorizations(s.T(), australia, "https://protected.example.com/", fasthttp.MethodGet, Bypass)
+	tester.CheckAuthorizations(s.T(), zealand, "https://protected.example.com/", fasthttp.MethodGet, OneFactor)
+	tester.CheckAuthorizations(s.T(), cloudflare, "https://protected.example.com/", fasthttp.MethodGet, TwoFactor)
+	tester.CheckAuthorizations(s.T(), unknown, "https://protected.example.com/", fasthttp.MethodGet, Denied)
+	tester.CheckAuthorizations(s.T(), Subject{IP: net.ParseIP("192.168.1.1")}, "https://protected.example.com/", fasthttp.MethodGet, OneFactor)
+	tester.CheckAuthorizations(s.T(), John, "https://protected.example.com/", fasthttp.MethodGet, TwoFactor)
+	tester.CheckAuthorizations(s.T(), AnonymousUser, "https://protected.example.com/", fasthttp.MethodGet, Denied)
+}
+
+func (s *AuthorizerSuite) TestShouldReturnSecondFactorMethods() {
+	tester := NewAuthorizerBuilder().
+		WithDefaultPolicy(twoFactor).
+		WithRule(schema.AccessControlRule{
+			Domains:             []string{"admin.example.com"},
+			Policy:              twoFactor,
+			SecondFactorMethods: []string{SecondFactorMethodWebAuthnHardware, SecondFactorMethodPhishingResistant},
+		}).
+		WithRule(schema.AccessControlRule{
+			Domains: []string{"public.example.com"},
+			Policy:  oneFactor,
+		}).
+		Build()
+
+	targetURL, _ := url.ParseRequestURI("https://admin.example.com/")
+
+	hasSubjects, policy := tester.GetRequiredPolicy(John, NewObject(targetURL, fasthttp.MethodGet))
+
+	s.False(hasSubjects)
+	s.Equal(TwoFactor, policy.Level)
+	s.Equal([]string{SecondFactorMethodWebAuthnHardware, SecondFactorMethodPhishingResistant}, policy.SecondFactorMethods)
+
+	targetURL, _ = url.ParseRequestURI("https://public.example.com/")
+
+	_, policy = tester.GetRequiredPolicy(John, NewObject(targetURL, fasthttp.MethodGet))
+
+	s.Equal(OneFactor, policy.Level)
+	s.Nil(policy.SecondFactorMethods)
+
+	targetURL, _ = url.ParseRequestURI("https://other.example.com/")
+
+	_, policy = tester.GetRequiredPolicy(John, NewObject(targetURL, fasthttp.MethodGet))
+
+	s.Equal(TwoFactor, policy.Level)
+	s.Nil(policy.SecondFactorMethods)
+}
+
+func (s *AuthorizerSuite) TestShouldReturnMaxAge() {
+	tester := NewAuthorizerBuilder().
+		WithDefaultPolicy(twoFactor).
+		WithRule(schema.AccessControlRule{
+			Domains: []string{"admin.example.com"},
+			Policy:  twoFactor,
+			MaxAge:  time.Minute * 15,
+		}).
+		WithRule(schema.AccessControlRule{
+			Domains: []string{"public.example.com"},
+			Policy:  oneFactor,
+			MaxAge:  time.Hour,
+		}).
+		Build()
+
+	targetURL, _ := url.ParseRequestURI("https://admin.example.com/")
+
+	_, policy := tester.GetRequiredPolicy(John, NewObject(targetURL, fasthttp.MethodGet))
+
+	s.Equal(RequiredPolicy{Position: 1, Level: TwoFactor, MaxAge: time.Minute * 15}, policy)
+
+	targetURL, _ = url.ParseRequestURI("https://public.example.com/")
+
+	_, policy = tester.GetRequiredPolicy(John, NewObject(targetURL, fasthttp.MethodGet))
+
+	s.Equal(RequiredPolicy{Position: 2, Level: OneFactor, MaxAge: time.Hour}, policy)
+
+	targetURL, _ = url.ParseRequestURI("https://other.example.com/")
+
+	_, policy = tester.GetRequiredPolicy(John, NewObject(targetURL, fasthttp.MethodGet))
+
+	s.Equal(RequiredPolicy{Level: TwoFactor}, policy)
+}
+
 func (s *AuthorizerSuite) TestShouldCheckMethodMatching() {
 	tester := NewAuthorizerBuilder().
 		WithDefaultPolicy(deny).
@@ -1017,20 +1172,63 @@ func TestNewAuthorizer(t *testing.T) {
 		},
 	}
 
-	authorizer := NewAuthorizer(config)
+	authorizer := NewAuthorizer(config, clock.New(), nil)
 
-	assert.Equal(t, Denied, authorizer.defaultPolicy)
-	assert.Equal(t, TwoFactor, authorizer.rules[0].Policy)
+	assert.Equal(t, Denied, authorizer.state.Load().defaultPolicy)
+	assert.Equal(t, TwoFactor, authorizer.state.Load().rules[0].Policy)
 
-	user, ok := authorizer.rules[0].Subjects[0].Subjects[0].(AccessControlUser)
+	user, ok := authorizer.state.Load().rules[0].Subjects[0].Subjects[0].(AccessControlUser)
 	require.True(t, ok)
 	assert.Equal(t, "admin", user.Name)
 
-	group, ok := authorizer.rules[0].Subjects[1].Subjects[0].(AccessControlGroup)
+	group, ok := authorizer.state.Load().rules[0].Subjects[1].Subjects[0].(AccessControlGroup)
 	require.True(t, ok)
 	assert.Equal(t, "admins", group.Name)
 }
 
+func TestAuthorizerUpdate(t *testing.T) {
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: deny,
+			Rules: []schema.AccessControlRule{
+				{
+					Domains: []string{"example.com"},
+					Policy:  oneFactor,
+				},
+			},
+		},
+	}
+
+	authorizer := NewAuthorizer(config, clock.New(), nil)
+
+	object := NewObject(&url.URL{Scheme: "https", Host: "example.com", Path: "/"}, fasthttp.MethodGet)
+
+	_, level := authorizer.GetRequiredLevel(Subject{}, object)
+
+	assert.Equal(t, OneFactor, level)
+	assert.False(t, authorizer.IsSecondFactorEnabled())
+	assert.Nil(t, authorizer.HeaderNames())
+
+	authorizer.Update(&schema.Configuration{
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: twoFactor,
+			Rules: []schema.AccessControlRule{
+				{
+					Domains: []string{"other.example.com"},
+					Policy:  oneFactor,
+					Headers: [][]schema.AccessControlRuleHeader{{{Operator: "present", Key: "x-api-key"}}},
+				},
+			},
+		},
+	})
+
+	_, level = authorizer.GetRequiredLevel(Subject{}, object)
+
+	assert.Equal(t, TwoFactor, level)
+	assert.True(t, authorizer.IsSecondFactorEnabled())
+	assert.Equal(t, []string{"X-Api-Key"}, authorizer.HeaderNames())
+}
+
 func TestAuthorizerIsSecondFactorEnabledRuleWithNoOIDC(t *testing.T) {
 	config := &schema.Configuration{
 		AccessControl: schema.AccessControl{
@@ -1044,11 +1242,11 @@ func TestAuthorizerIsSecondFactorEnabledRuleWithNoOIDC(t *testing.T) {
 		},
 	}
 
-	authorizer := NewAuthorizer(config)
+	authorizer := NewAuthorizer(config, clock.New(), nil)
 	assert.False(t, authorizer.IsSecondFactorEnabled())
 
 	config.AccessControl.Rules[0].Policy = twoFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, clock.New(), nil)
 	assert.True(t, authorizer.IsSecondFactorEnabled())
 }
 
@@ -1074,27 +1272,123 @@ func TestAuthorizerIsSecondFactorEnabledRuleWithOIDC(t *testing.T) {
 		},
 	}
 
-	authorizer := NewAuthorizer(config)
+	authorizer := NewAuthorizer(config, clock.New(), nil)
 	assert.False(t, authorizer.IsSecondFactorEnabled())
 
 	config.AccessControl.Rules[0].Policy = twoFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, clock.New(), nil)
 	assert.True(t, authorizer.IsSecondFactorEnabled())
 
 	config.AccessControl.Rules[0].Policy = oneFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, clock.New(), nil)
 	assert.False(t, authorizer.IsSecondFactorEnabled())
 
 	config.IdentityProviders.OIDC.Clients[0].AuthorizationPolicy = twoFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, clock.New(), nil)
 	assert.True(t, authorizer.IsSecondFactorEnabled())
 
 	config.AccessControl.Rules[0].Policy = oneFactor
 	config.IdentityProviders.OIDC.Clients[0].AuthorizationPolicy = oneFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, clock.New(), nil)
 	assert.False(t, authorizer.IsSecondFactorEnabled())
 
 	config.AccessControl.DefaultPolicy = twoFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, clock.New(), nil)
 	assert.True(t, authorizer.IsSecondFactorEnabled())
 }
+
+func (s *AuthorizerSuite) TestShouldCheckScheduleMatching() {
+	now := clock.NewFixed(time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC))
+
+	tester := NewAuthorizerBuilder().
+		WithDefaultPolicy(deny).
+		WithClock(now).
+		WithRule(schema.AccessControlRule{
+			Domains: []string{"office.example.com"},
+			Policy:  twoFactor,
+			Schedule: []schema.AccessControlRuleSchedule{
+				{
+					Days:      []string{"monday", "tuesday", "wednesday", "thursday", "friday"},
+					StartTime: "08:00",
+					EndTime:   "18:00",
+				},
+			},
+		}).
+		Build()
+
+	tester.CheckAuthorizations(s.T(), John, "https://office.example.com", fasthttp.MethodGet, TwoFactor)
+
+	results := tester.GetRuleMatchResults(John, "https://office.example.com", fasthttp.MethodGet)
+
+	s.Require().Len(results, 1)
+	s.Assert().True(results[0].IsMatch())
+	s.Assert().True(results[0].MatchSchedule)
+
+	now.Set(time.Date(2026, 10, 19, 18, 0, 0, 0, time.UTC))
+
+	tester.CheckAuthorizations(s.T(), John, "https://office.example.com", fasthttp.MethodGet, Denied)
+
+	results = tester.GetRuleMatchResults(John, "https://office.example.com", fasthttp.MethodGet)
+
+	s.Require().Len(results, 1)
+	s.Assert().False(results[0].IsMatch())
+	s.Assert().False(results[0].MatchSchedule)
+}
+
+func (s *AuthorizerSuite) TestShouldCheckHeadersPolicy() {
+	tester := NewAuthorizerBuilder().
+		WithDefaultPolicy(deny).
+		WithRule(schema.AccessControlRule{
+			Domains: []string{"api.example.com"},
+			Headers: [][]schema.AccessControlRuleHeader{
+				{
+					{
+						Operator: operatorEqual,
+						Key:      "X-Api-Version",
+						Value:    "2",
+					},
+					{
+						Operator: operatorPattern,
+						Key:      "user-agent",
+						Value:    regexp.MustCompile(`^curl/`),
+					},
+				},
+				{
+					{
+						Operator: operatorPresent,
+						Key:      "X-Tenant",
+					},
+				},
+			},
+			Policy: bypass,
+		}).
+		WithRule(schema.AccessControlRule{
+			Domains: []string{"api.example.com"},
+			Policy:  twoFactor,
+		}).
+		Build()
+
+	s.Assert().Equal([]string{"X-Api-Version", "User-Agent", "X-Tenant"}, tester.HeaderNames())
+
+	targetURL, err := url.ParseRequestURI("https://api.example.com/v2")
+	s.Require().NoError(err)
+
+	testCases := []struct {
+		name     string
+		header   http.Header
+		expected Level
+	}{
+		{"ShouldMatchAllHeaders", http.Header{"X-Api-Version": []string{"2"}, "User-Agent": []string{"curl/8.5.0"}}, Bypass},
+		{"ShouldMatchAlternativeHeaders", http.Header{"X-Tenant": []string{"example"}}, Bypass},
+		{"ShouldNotMatchPartialHeaders", http.Header{"X-Api-Version": []string{"2"}}, TwoFactor},
+		{"ShouldNotMatchNoHeaders", nil, TwoFactor},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			_, level := tester.GetRequiredLevel(John, NewObjectRaw(targetURL, []byte(fasthttp.MethodGet), tc.header))
+
+			assert.Equal(t, tc.expected, level)
+		})
+	}
+}
@@ -1,5 +1,9 @@
 package authorization
 
+import (
+	"time"
+)
+
 // Level is the type representing an authorization level.
 type Level int
 
@@ -20,7 +24,13 @@ const (
 const (
 	prefixUser         = "user:"
 	prefixGroup        = "group:"
+	prefixEmail        = "email:"
+	prefixAttribute    = "attr:"
 	prefixOAuth2Client = "oauth2:client:"
+
+	prefixUserRegex  = "user~"
+	prefixGroupRegex = "group~"
+	prefixEmailRegex = "email~"
 )
 
 const (
@@ -29,6 +39,31 @@ const (
 	lenPrefixOAuth2Client = len(prefixOAuth2Client)
 )
 
+// Second factor method constants which a rule may require the user to have authenticated with.
+const (
+	// SecondFactorMethodTOTP requires the user authenticated with a Time-based One-Time Password.
+	SecondFactorMethodTOTP = "totp"
+
+	// SecondFactorMethodDuo requires the user authenticated with a Duo push notification.
+	SecondFactorMethodDuo = "mobile_push"
+
+	// SecondFactorMethodWebAuthn requires the user authenticated with any WebAuthn credential.
+	SecondFactorMethodWebAuthn = "webauthn"
+
+	// SecondFactorMethodWebAuthnHardware requires the user authenticated with a hardware WebAuthn credential.
+	SecondFactorMethodWebAuthnHardware = "webauthn_hardware"
+
+	// SecondFactorMethodWebAuthnSoftware requires the user authenticated with a software WebAuthn credential.
+	SecondFactorMethodWebAuthnSoftware = "webauthn_software"
+
+	// SecondFactorMethodWebAuthnUserVerified requires the user authenticated with a WebAuthn credential which verified
+	// the user.
+	SecondFactorMethodWebAuthnUserVerified = "webauthn_user_verified"
+
+	// SecondFactorMethodPhishingResistant requires the user authenticated with a phishing resistant method.
+	SecondFactorMethodPhishingResistant = "phishing_resistant"
+)
+
 const (
 	bypass    = "bypass"
 	oneFactor = "one_factor"
@@ -56,3 +91,25 @@ var (
 )
 
 const traceFmtACLHitMiss = "ACL %s Position %d for subject %s and object %s (method %s, policy %s)"
+
+const (
+	prefixGeoIPCountry   = "country:"
+	prefixGeoIPContinent = "continent:"
+	prefixGeoIPASN       = "asn:"
+)
+
+const (
+	layoutScheduleTime     = "15:04"
+	layoutScheduleDate     = "2006-01-02"
+	layoutScheduleDateTime = "2006-01-02 15:04"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
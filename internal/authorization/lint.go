@@ -0,0 +1,391 @@
+package authorization
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// LintKind is the kind of a LintIssue.
+type LintKind string
+
+const (
+	// LintKindUnreachable is a rule which can never match as an earlier rule matches every request it would.
+	LintKindUnreachable LintKind = "unreachable"
+
+	// LintKindDuplicate is a rule which has the same criteria as an earlier rule.
+	LintKindDuplicate LintKind = "duplicate"
+
+	// LintKindBroadRegex is a domain or resource regex which matches more than it likely should.
+	LintKindBroadRegex LintKind = "broad-regex"
+
+	// LintKindBypassBeforeTwoFactor is a two_factor rule without subjects which follows a bypass rule that matches all of
+	// its domains.
+	LintKindBypassBeforeTwoFactor LintKind = "bypass-before-two-factor"
+
+	// LintKindUndefinedNetwork is a rule network which is not defined and is not an IP address, CIDR, or GeoIP network.
+	LintKindUndefinedNetwork LintKind = "undefined-network"
+)
+
+// LintIssue is a potential problem with the access control rules.
+type LintIssue struct {
+	// Position is the position of the rule the issue relates to starting at 1.
+	Position int
+
+	// Related is the position of the earlier rule involved in the issue, or 0 if there is none.
+	Related int
+
+	Kind       LintKind
+	Message    string
+	Suggestion string
+}
+
+// String returns the text representation of this LintIssue.
+func (i LintIssue) String() string {
+	return fmt.Sprintf("rule #%d: %s", i.Position, i.Message)
+}
+
+// LintAccessControl analyses the compiled access control rules and returns any potential problems with them in rule
+// order. The analysis is conservative, i.e. a rule is only reported as unreachable when it's certain.
+func LintAccessControl(config schema.AccessControl) (issues []LintIssue) {
+	rules := NewAccessControlRules(config)
+
+	names := make(map[string]struct{}, len(config.Networks))
+
+	for _, network := range config.Networks {
+		names[network.Name] = struct{}{}
+	}
+
+	for i, rule := range rules {
+		issues = append(issues, lintNetworks(rule, config.Rules[i], names)...)
+		issues = append(issues, lintRegexes(rule, config.Rules[i])...)
+
+		if issue, ok := lintShadowed(rule, rules[:i]); ok {
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues
+}
+
+func lintNetworks(rule *AccessControlRule, config schema.AccessControlRule, names map[string]struct{}) (issues []LintIssue) {
+	for _, network := range config.Networks {
+		if _, ok := names[network]; ok || IsGeoIPNetwork(network) {
+			continue
+		}
+
+		if _, err := parseNetwork(network); err == nil {
+			continue
+		}
+
+		message := fmt.Sprintf("the network '%s' is not defined in the 'networks' option and is not an IP address or CIDR so it's ignored", network)
+
+		if len(rule.Networks) == 0 && len(rule.GeoIP) == 0 {
+			message += " which means the rule matches requests from any network"
+		}
+
+		issues = append(issues, LintIssue{
+			Position:   rule.Position,
+			Kind:       LintKindUndefinedNetwork,
+			Message:    message,
+			Suggestion: fmt.Sprintf("define a network named '%s' in the 'networks' option or correct the value", network),
+		})
+	}
+
+	return issues
+}
+
+func lintRegexes(rule *AccessControlRule, config schema.AccessControlRule) (issues []LintIssue) {
+	for _, pattern := range config.DomainsRegex {
+		switch exp := pattern.String(); {
+		case lintMatchesAll(pattern.MatchString, lintProbesDomain):
+			issues = append(issues, LintIssue{
+				Position:   rule.Position,
+				Kind:       LintKindBroadRegex,
+				Message:    fmt.Sprintf("the domain regex '%s' matches any domain", exp),
+				Suggestion: "use the 'domain' option with a wildcard or restrict the pattern to the intended domains",
+			})
+		case !strings.HasPrefix(exp, "^") || !strings.HasSuffix(exp, "$"):
+			issues = append(issues, LintIssue{
+				Position:   rule.Position,
+				Kind:       LintKindBroadRegex,
+				Message:    fmt.Sprintf("the domain regex '%s' is not anchored so it matches any domain containing a match", exp),
+				Suggestion: fmt.Sprintf("anchor the pattern with '^' and '$' i.e. '^%s$'", strings.TrimSuffix(strings.TrimPrefix(exp, "^"), "$")),
+			})
+		}
+	}
+
+	for _, pattern := range config.Resources {
+		switch exp := pattern.String(); {
+		case lintMatchesAll(pattern.MatchString, lintProbesResource):
+			issues = append(issues, LintIssue{
+				Position:   rule.Position,
+				Kind:       LintKindBroadRegex,
+				Message:    fmt.Sprintf("the resource regex '%s' matches any path", exp),
+				Suggestion: "remove the 'resources' option as it has no effect or restrict the pattern to the intended paths",
+			})
+		case !strings.HasPrefix(exp, "^"):
+			issues = append(issues, LintIssue{
+				Position:   rule.Position,
+				Kind:       LintKindBroadRegex,
+				Message:    fmt.Sprintf("the resource regex '%s' is not anchored so it matches any path containing a match", exp),
+				Suggestion: fmt.Sprintf("anchor the pattern with '^' i.e. '^%s'", exp),
+			})
+		}
+	}
+
+	return issues
+}
+
+func lintShadowed(rule *AccessControlRule, previous []*AccessControlRule) (issue LintIssue, ok bool) {
+	for _, earlier := range previous {
+		if !lintRuleCovers(earlier, rule) {
+			continue
+		}
+
+		if lintRuleCovers(rule, earlier) {
+			return LintIssue{
+				Position:   rule.Position,
+				Related:    earlier.Position,
+				Kind:       LintKindDuplicate,
+				Message:    fmt.Sprintf("the rule is a duplicate of rule #%d and can never match", earlier.Position),
+				Suggestion: fmt.Sprintf("remove the rule or merge it with rule #%d", earlier.Position),
+			}, true
+		}
+
+		return LintIssue{
+			Position:   rule.Position,
+			Related:    earlier.Position,
+			Kind:       LintKindUnreachable,
+			Message:    fmt.Sprintf("the rule can never match as rule #%d with the policy '%s' matches every request it would match", earlier.Position, earlier.Policy),
+			Suggestion: fmt.Sprintf("move the rule before rule #%d or remove it", earlier.Position),
+		}, true
+	}
+
+	if rule.Policy != TwoFactor || len(rule.Subjects) != 0 {
+		return issue, false
+	}
+
+	for _, earlier := range previous {
+		if earlier.Policy == Bypass && lintCoversDomains(earlier.Domains, rule.Domains) {
+			return LintIssue{
+				Position:   rule.Position,
+				Related:    earlier.Position,
+				Kind:       LintKindBypassBeforeTwoFactor,
+				Message:    fmt.Sprintf("the rule has the policy 'two_factor' and no subjects but follows the 'bypass' rule #%d which matches all of its domains so some requests may bypass authentication", earlier.Position),
+				Suggestion: fmt.Sprintf("move the rule before rule #%d if requests matching both rules must require two factor authentication", earlier.Position),
+			}, true
+		}
+	}
+
+	return issue, false
+}
+
+// lintRuleCovers returns true if every request which matches rule b is certain to match rule a.
+func lintRuleCovers(a, b *AccessControlRule) bool {
+	return lintCoversDomains(a.Domains, b.Domains) &&
+		lintCoversStringers(lintResourcesStringers(a.Resources), lintResourcesStringers(b.Resources)) &&
+		lintCoversEqual(a.Query, b.Query) &&
+		lintCoversEqual(a.Headers, b.Headers) &&
+		lintCoversMethods(a.Methods, b.Methods) &&
+		lintCoversNetworks(a, b) &&
+		lintCoversEqual(a.Schedules, b.Schedules) &&
+		lintCoversSubjects(a.Subjects, b.Subjects)
+}
+
+func lintCoversDomains(a, b []AccessControlDomain) bool {
+	if len(a) == 0 {
+		return true
+	}
+
+	if len(b) == 0 {
+		return false
+	}
+
+outer:
+	for _, db := range b {
+		for _, da := range a {
+			if lintDomainCovers(da, db) {
+				continue outer
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
+func lintDomainCovers(a, b AccessControlDomain) bool {
+	switch ma := a.Matcher.(type) {
+	case *AccessControlDomainMatcher:
+		mb, ok := b.Matcher.(*AccessControlDomainMatcher)
+		if !ok {
+			return false
+		}
+
+		if ma.Wildcard {
+			return !mb.UserWildcard && !mb.GroupWildcard && strings.HasSuffix(mb.Name, ma.Name)
+		}
+
+		return *ma == *mb
+	case RegexpStringSubjectMatcher:
+		switch mb := b.Matcher.(type) {
+		case *AccessControlDomainMatcher:
+			return !mb.Wildcard && !mb.UserWildcard && !mb.GroupWildcard && ma.Pattern.MatchString(mb.Name)
+		case RegexpStringSubjectMatcher:
+			return ma.String() == mb.String()
+		}
+	case RegexpGroupStringSubjectMatcher:
+		if mb, ok := b.Matcher.(RegexpGroupStringSubjectMatcher); ok {
+			return ma.String() == mb.String()
+		}
+	}
+
+	return false
+}
+
+func lintResourcesStringers(resources []AccessControlResource) (stringers []fmt.Stringer) {
+	for _, resource := range resources {
+		if stringer, ok := resource.Matcher.(fmt.Stringer); ok {
+			stringers = append(stringers, stringer)
+		}
+	}
+
+	return stringers
+}
+
+func lintCoversStringers(a, b []fmt.Stringer) bool {
+	if len(a) == 0 {
+		return true
+	}
+
+	if len(b) == 0 {
+		return false
+	}
+
+outer:
+	for _, sb := range b {
+		for _, sa := range a {
+			if sa.String() == sb.String() {
+				continue outer
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
+func lintCoversEqual[T any](a, b []T) bool {
+	return len(a) == 0 || reflect.DeepEqual(a, b)
+}
+
+func lintCoversMethods(a, b []string) bool {
+	if len(a) == 0 {
+		return true
+	}
+
+	return len(b) != 0 && utils.IsStringSliceContainsAll(b, a)
+}
+
+func lintCoversNetworks(a, b *AccessControlRule) bool {
+	if len(a.Networks) == 0 && len(a.GeoIP) == 0 {
+		return true
+	}
+
+	if len(b.Networks) == 0 && len(b.GeoIP) == 0 {
+		return false
+	}
+
+outer:
+	for _, nb := range b.Networks {
+		for _, na := range a.Networks {
+			if lintNetworkContains(na, nb) {
+				continue outer
+			}
+		}
+
+		return false
+	}
+
+outerGeoIP:
+	for _, gb := range b.GeoIP {
+		for _, ga := range a.GeoIP {
+			if reflect.DeepEqual(ga, gb) {
+				continue outerGeoIP
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
+func lintNetworkContains(a, b *net.IPNet) bool {
+	onesA, bitsA := a.Mask.Size()
+	onesB, bitsB := b.Mask.Size()
+
+	return bitsA == bitsB && onesA <= onesB && a.Contains(b.IP)
+}
+
+// lintCoversSubjects returns true if every subject which matches b is certain to match a. Each of the subjects in b
+// must require all the criteria of at least one of the subjects in a.
+func lintCoversSubjects(a, b []AccessControlSubjects) bool {
+	if len(a) == 0 {
+		return true
+	}
+
+	if len(b) == 0 {
+		return false
+	}
+
+outer:
+	for _, sb := range b {
+		for _, sa := range a {
+			if lintSubjectsContainsAll(sb.Subjects, sa.Subjects) {
+				continue outer
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
+func lintSubjectsContainsAll(haystack, needles []SubjectMatcher) bool {
+outer:
+	for _, needle := range needles {
+		for _, item := range haystack {
+			if reflect.DeepEqual(item, needle) {
+				continue outer
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
+func lintMatchesAll(match func(string) bool, probes []string) bool {
+	for _, probe := range probes {
+		if !match(probe) {
+			return false
+		}
+	}
+
+	return true
+}
+
+var (
+	lintProbesDomain   = []string{"example.com", "app.example.org", "a.b.c.invalid", "localhost"}
+	lintProbesResource = []string{"/", "/api/v1/users?id=1", "/admin", "/a/b/c"}
+)
@@ -0,0 +1,135 @@
+package authorization
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestLintAccessControl(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     schema.AccessControl
+		expected []LintIssue
+	}{
+		{
+			"ShouldNotReportValidRules",
+			schema.AccessControl{
+				DefaultPolicy: deny,
+				Networks:      []schema.AccessControlNetwork{{Name: "internal", Networks: []string{"10.0.0.0/8"}}},
+				Rules: []schema.AccessControlRule{
+					{Domains: []string{"public.example.com"}, Policy: bypass},
+					{Domains: []string{"admin.example.com"}, Subjects: [][]string{{"group:admins"}}, Policy: twoFactor},
+					{Domains: []string{"admin.example.com"}, Policy: deny},
+					{Domains: []string{"*.example.com"}, Networks: []string{"internal"}, Policy: oneFactor},
+					{Domains: []string{"*.example.com"}, Resources: []regexp.Regexp{*regexp.MustCompile(`^/api([/?].*)?$`)}, Policy: oneFactor},
+					{DomainsRegex: []regexp.Regexp{*regexp.MustCompile(`^(?P<User>\w+)\.home\.example\.com$`)}, Policy: oneFactor},
+				},
+			},
+			nil,
+		},
+		{
+			"ShouldReportDuplicate",
+			schema.AccessControl{
+				DefaultPolicy: deny,
+				Rules: []schema.AccessControlRule{
+					{Domains: []string{"app.example.com"}, Methods: []string{"GET", "POST"}, Policy: oneFactor},
+					{Domains: []string{"app.example.com"}, Methods: []string{"POST", "GET"}, Policy: twoFactor},
+				},
+			},
+			[]LintIssue{
+				{Position: 2, Related: 1, Kind: LintKindDuplicate, Message: "the rule is a duplicate of rule #1 and can never match", Suggestion: "remove the rule or merge it with rule #1"},
+			},
+		},
+		{
+			"ShouldReportUnreachable",
+			schema.AccessControl{
+				DefaultPolicy: deny,
+				Networks:      []schema.AccessControlNetwork{{Name: "internal", Networks: []string{"10.0.0.0/8"}}},
+				Rules: []schema.AccessControlRule{
+					{Domains: []string{"*.example.com"}, Networks: []string{"internal"}, Policy: bypass},
+					{Domains: []string{"app.example.com"}, Networks: []string{"10.1.0.0/16"}, Subjects: [][]string{{"user:john"}}, Policy: twoFactor},
+					{Domains: []string{"example.com"}, Policy: oneFactor},
+					{Domains: []string{"example.com"}, Subjects: [][]string{{"group:admins", "user:john"}}, Methods: []string{"GET"}, Policy: twoFactor},
+				},
+			},
+			[]LintIssue{
+				{Position: 2, Related: 1, Kind: LintKindUnreachable, Message: "the rule can never match as rule #1 with the policy 'bypass' matches every request it would match", Suggestion: "move the rule before rule #1 or remove it"},
+				{Position: 4, Related: 3, Kind: LintKindUnreachable, Message: "the rule can never match as rule #3 with the policy 'one_factor' matches every request it would match", Suggestion: "move the rule before rule #3 or remove it"},
+			},
+		},
+		{
+			"ShouldReportUnreachableRegex",
+			schema.AccessControl{
+				DefaultPolicy: deny,
+				Rules: []schema.AccessControlRule{
+					{DomainsRegex: []regexp.Regexp{*regexp.MustCompile(`^(app|api)\.example\.com$`)}, Policy: oneFactor},
+					{Domains: []string{"api.example.com"}, Policy: twoFactor},
+				},
+			},
+			[]LintIssue{
+				{Position: 2, Related: 1, Kind: LintKindUnreachable, Message: "the rule can never match as rule #1 with the policy 'one_factor' matches every request it would match", Suggestion: "move the rule before rule #1 or remove it"},
+			},
+		},
+		{
+			"ShouldReportBroadRegexes",
+			schema.AccessControl{
+				DefaultPolicy: deny,
+				Rules: []schema.AccessControlRule{
+					{DomainsRegex: []regexp.Regexp{*regexp.MustCompile(`.*`)}, Resources: []regexp.Regexp{*regexp.MustCompile(`^/.*$`)}, Policy: twoFactor},
+					{DomainsRegex: []regexp.Regexp{*regexp.MustCompile(`app\.example\.com`)}, Resources: []regexp.Regexp{*regexp.MustCompile(`/admin`)}, Policy: deny},
+				},
+			},
+			[]LintIssue{
+				{Position: 1, Kind: LintKindBroadRegex, Message: "the domain regex '.*' matches any domain", Suggestion: "use the 'domain' option with a wildcard or restrict the pattern to the intended domains"},
+				{Position: 1, Kind: LintKindBroadRegex, Message: "the resource regex '^/.*$' matches any path", Suggestion: "remove the 'resources' option as it has no effect or restrict the pattern to the intended paths"},
+				{Position: 2, Kind: LintKindBroadRegex, Message: "the domain regex 'app\\.example\\.com' is not anchored so it matches any domain containing a match", Suggestion: "anchor the pattern with '^' and '$' i.e. '^app\\.example\\.com$'"},
+				{Position: 2, Kind: LintKindBroadRegex, Message: "the resource regex '/admin' is not anchored so it matches any path containing a match", Suggestion: "anchor the pattern with '^' i.e. '^/admin'"},
+			},
+		},
+		{
+			"ShouldReportBypassBeforeTwoFactor",
+			schema.AccessControl{
+				DefaultPolicy: deny,
+				Rules: []schema.AccessControlRule{
+					{Domains: []string{"*.example.com"}, Resources: []regexp.Regexp{*regexp.MustCompile(`^/public/`)}, Policy: bypass},
+					{Domains: []string{"app.example.com"}, Policy: twoFactor},
+					{Domains: []string{"admin.example.com"}, Subjects: [][]string{{"group:admins"}}, Policy: twoFactor},
+				},
+			},
+			[]LintIssue{
+				{Position: 2, Related: 1, Kind: LintKindBypassBeforeTwoFactor, Message: "the rule has the policy 'two_factor' and no subjects but follows the 'bypass' rule #1 which matches all of its domains so some requests may bypass authentication", Suggestion: "move the rule before rule #1 if requests matching both rules must require two factor authentication"},
+			},
+		},
+		{
+			"ShouldReportUndefinedNetworks",
+			schema.AccessControl{
+				DefaultPolicy: deny,
+				Networks:      []schema.AccessControlNetwork{{Name: "internal", Networks: []string{"10.0.0.0/8"}}},
+				Rules: []schema.AccessControlRule{
+					{Domains: []string{"a.example.com"}, Networks: []string{"internl"}, Policy: bypass},
+					{Domains: []string{"b.example.com"}, Networks: []string{"internal", "vpn", "192.168.1.1", "country:AU"}, Policy: bypass},
+				},
+			},
+			[]LintIssue{
+				{Position: 1, Kind: LintKindUndefinedNetwork, Message: "the network 'internl' is not defined in the 'networks' option and is not an IP address or CIDR so it's ignored which means the rule matches requests from any network", Suggestion: "define a network named 'internl' in the 'networks' option or correct the value"},
+				{Position: 2, Kind: LintKindUndefinedNetwork, Message: "the network 'vpn' is not defined in the 'networks' option and is not an IP address or CIDR so it's ignored", Suggestion: "define a network named 'vpn' in the 'networks' option or correct the value"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, LintAccessControl(tc.have))
+		})
+	}
+}
+
+func TestLintIssueString(t *testing.T) {
+	issue := LintIssue{Position: 4, Message: "the rule is a duplicate of rule #1 and can never match"}
+
+	assert.Equal(t, "rule #4: the rule is a duplicate of rule #1 and can never match", issue.String())
+}
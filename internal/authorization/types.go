@@ -3,9 +3,12 @@ package authorization
 import (
 	"fmt"
 	"net"
+	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
+	"github.com/authelia/authelia/v4/internal/geoip"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
@@ -31,10 +34,13 @@ type ObjectMatcher interface {
 
 // Subject represents the identity of a user for the purposes of ACL matching.
 type Subject struct {
-	Username string
-	Groups   []string
-	ClientID string
-	IP       net.IP
+	Username   string
+	Groups     []string
+	Emails     []string
+	Attributes map[string]string
+	ClientID   string
+	IP         net.IP
+	GeoIP      geoip.Record
 }
 
 // String returns a string representation of the Subject.
@@ -54,6 +60,7 @@ type Object struct {
 	Domain string
 	Path   string
 	Method string
+	Header http.Header
 }
 
 // String is a string representation of the Object.
@@ -61,9 +68,12 @@ func (o Object) String() string {
 	return o.URL.String()
 }
 
-// NewObjectRaw creates a new Object type from a URL and a method header.
-func NewObjectRaw(targetURL *url.URL, method []byte) (object Object) {
-	return NewObject(targetURL, string(method))
+// NewObjectRaw creates a new Object type from a URL, a method header, and the request headers relevant to the rules.
+func NewObjectRaw(targetURL *url.URL, method []byte, header http.Header) (object Object) {
+	object = NewObject(targetURL, string(method))
+	object.Header = header
+
+	return object
 }
 
 // NewObject creates a new Object type from a URL and a method header.
@@ -76,6 +86,23 @@ func NewObject(targetURL *url.URL, method string) (object Object) {
 	}
 }
 
+// RequiredPolicy describes the requirements a subject must satisfy to access an object.
+type RequiredPolicy struct {
+	// Position is the position of the matched rule, starting at 1. It is zero when the default policy was applied.
+	Position int
+
+	// Level is the required level of authorization.
+	Level Level
+
+	// SecondFactorMethods is the list of second factor methods of which at least one must have been used when the Level
+	// is TwoFactor. It is empty when any second factor method is sufficient.
+	SecondFactorMethods []string
+
+	// MaxAge is the maximum duration since the factor required by the Level was last performed. It is zero when there is
+	// no maximum.
+	MaxAge time.Duration
+}
+
 // RuleMatchResult describes how well a rule matched a subject/object combo.
 type RuleMatchResult struct {
 	Rule *AccessControlRule
@@ -85,18 +112,20 @@ type RuleMatchResult struct {
 	MatchDomain        bool
 	MatchResources     bool
 	MatchQuery         bool
+	MatchHeaders       bool
 	MatchMethods       bool
 	MatchNetworks      bool
 	MatchSubjects      bool
 	MatchSubjectsExact bool
+	MatchSchedule      bool
 }
 
 // IsMatch returns true if all the criteria matched.
 func (r RuleMatchResult) IsMatch() (match bool) {
-	return r.MatchDomain && r.MatchResources && r.MatchMethods && r.MatchNetworks && r.MatchSubjectsExact
+	return r.MatchDomain && r.MatchResources && r.MatchHeaders && r.MatchMethods && r.MatchNetworks && r.MatchSchedule && r.MatchSubjectsExact
 }
 
 // IsPotentialMatch returns true if the rule is potentially a match.
 func (r RuleMatchResult) IsPotentialMatch() (match bool) {
-	return r.MatchDomain && r.MatchResources && r.MatchMethods && r.MatchNetworks && r.MatchSubjects && !r.MatchSubjectsExact
+	return r.MatchDomain && r.MatchResources && r.MatchHeaders && r.MatchMethods && r.MatchNetworks && r.MatchSchedule && r.MatchSubjects && !r.MatchSubjectsExact
 }
@@ -1,6 +1,7 @@
 package authorization
 
 import (
+	"net/http"
 	"net/url"
 	"testing"
 
@@ -27,13 +28,14 @@ func TestShouldCreateNewObjectFromRaw(t *testing.T) {
 
 	require.NoError(t, err)
 
-	object := NewObjectRaw(targetURL, []byte(fasthttp.MethodGet))
+	object := NewObjectRaw(targetURL, []byte(fasthttp.MethodGet), http.Header{"X-Api-Version": []string{"2"}})
 
 	assert.Equal(t, "https", object.URL.Scheme)
 	assert.Equal(t, "domain.example.com", object.Domain)
 	assert.Equal(t, "/api", object.URL.Path)
 	assert.Equal(t, "/api", object.Path)
 	assert.Equal(t, fasthttp.MethodGet, object.Method)
+	assert.Equal(t, "2", object.Header.Get("X-Api-Version"))
 }
 
 func TestShouldCleanURL(t *testing.T) {
@@ -99,12 +101,22 @@ func TestRuleMatchResult_IsPotentialMatch(t *testing.T) {
 		},
 		{
 			"ShouldMatch",
-			RuleMatchResult{nil, true, true, true, true, true, true, true, false},
+			RuleMatchResult{nil, true, true, true, true, true, true, true, true, false, true},
 			true,
 		},
 		{
 			"ShouldMatchExact",
-			RuleMatchResult{nil, true, true, true, true, true, true, true, true},
+			RuleMatchResult{nil, true, true, true, true, true, true, true, true, true, true},
+			false,
+		},
+		{
+			"ShouldNotMatchHeaders",
+			RuleMatchResult{nil, true, true, true, true, false, true, true, true, false, true},
+			false,
+		},
+		{
+			"ShouldNotMatchSchedule",
+			RuleMatchResult{nil, true, true, true, true, true, true, true, true, false, false},
 			false,
 		},
 	}
@@ -2,11 +2,13 @@ package authorization
 
 import (
 	"net"
+	"net/http"
 	"regexp"
 	"strings"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // NewLevel converts a string policy to int authorization level.
@@ -56,25 +58,9 @@ func stringSliceToRegexpSlice(strings []string) (regexps []regexp.Regexp, err er
 }
 
 func schemaSubjectToACLSubject(subjectRule string) (subject SubjectMatcher) {
-	if strings.HasPrefix(subjectRule, prefixUser) {
-		user := strings.Trim(subjectRule[lenPrefixUser:], " ")
+	subject, _ = NewAccessControlSubject(subjectRule)
 
-		return AccessControlUser{Name: user}
-	}
-
-	if strings.HasPrefix(subjectRule, prefixGroup) {
-		group := strings.Trim(subjectRule[lenPrefixGroup:], " ")
-
-		return AccessControlGroup{Name: group}
-	}
-
-	if strings.HasPrefix(subjectRule, prefixOAuth2Client) {
-		clientID := strings.Trim(subjectRule[lenPrefixOAuth2Client:], " ")
-
-		return AccessControlClient{Provider: "OAuth2", ID: clientID}
-	}
-
-	return nil
+	return subject
 }
 
 func ruleAddDomain(domainRules []string, rule *AccessControlRule) {
@@ -189,6 +175,26 @@ func parseNetwork(networkRule string) (cidr *net.IPNet, err error) {
 	return cidr, err
 }
 
+func schemaHeadersToHeaderNames(rules []schema.AccessControlRule) (names []string) {
+	for _, rule := range rules {
+		for _, headers := range rule.Headers {
+			for _, header := range headers {
+				if header.Key == "" {
+					continue
+				}
+
+				name := http.CanonicalHeaderKey(header.Key)
+
+				if !utils.IsStringInSlice(name, names) {
+					names = append(names, name)
+				}
+			}
+		}
+	}
+
+	return names
+}
+
 func schemaSubjectsToACL(subjectRules [][]string) (subjects []AccessControlSubjects) {
 	for _, subjectRule := range subjectRules {
 		subject := AccessControlSubjects{}
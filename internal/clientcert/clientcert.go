@@ -0,0 +1,192 @@
+package clientcert
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+// NewProvider instantiates a client certificate provider given a configuration.
+func NewProvider(config *schema.AuthenticationBackendClientCertificate) *Provider {
+	return &Provider{
+		config: config,
+		log:    logging.Logger(),
+	}
+}
+
+// StartupCheck implements the startup check provider interface.
+func (p *Provider) StartupCheck() (err error) {
+	var (
+		tmpl    *template.Template
+		roots   *x509.CertPool
+		proxies []*net.IPNet
+	)
+
+	if p.config.UsernameTemplate != "" {
+		if tmpl, err = template.New("username").Funcs(templates.FuncMap()).Option("missingkey=error").Parse(p.config.UsernameTemplate); err != nil {
+			return fmt.Errorf("error occurred parsing the username template: %w", err)
+		}
+	}
+
+	if len(p.config.Forwarded.CertificateAuthorities) != 0 {
+		roots = x509.NewCertPool()
+
+		var data []byte
+
+		for _, path := range p.config.Forwarded.CertificateAuthorities {
+			if data, err = os.ReadFile(path); err != nil {
+				return fmt.Errorf("error occurred loading the certificate authority '%s': %w", path, err)
+			}
+
+			if !roots.AppendCertsFromPEM(data) {
+				return fmt.Errorf("the certificate authority '%s' does not contain any PEM encoded certificates", path)
+			}
+		}
+	}
+
+	var network *net.IPNet
+
+	for _, proxy := range p.config.Forwarded.TrustedProxies {
+		if network, err = ParseNetwork(proxy); err != nil {
+			return fmt.Errorf("error occurred parsing the trusted proxy '%s': %w", proxy, err)
+		}
+
+		proxies = append(proxies, network)
+	}
+
+	p.mu.Lock()
+
+	p.template, p.roots, p.proxies = tmpl, roots, proxies
+
+	p.mu.Unlock()
+
+	return nil
+}
+
+// Header returns the name of the header trusted proxies forward the client certificate with.
+func (p *Provider) Header() string {
+	return p.config.Forwarded.Header
+}
+
+// Identify returns the Identity of the verified client certificate of a request. When the request originates from a
+// trusted proxy only the certificate forwarded via the configured header is considered, otherwise only the certificate
+// presented via mutual TLS is considered. The Identity is nil when neither is present.
+func (p *Provider) Identify(state *tls.ConnectionState, remoteIP net.IP, header []byte) (identity *Identity, err error) {
+	p.mu.RLock()
+
+	roots, proxies := p.roots, p.proxies
+
+	p.mu.RUnlock()
+
+	if isTrustedProxy(proxies, remoteIP) {
+		if len(header) == 0 {
+			return nil, nil
+		}
+
+		if roots == nil {
+			return nil, errors.New("the forwarded client certificate can't be verified as no certificate authorities are configured")
+		}
+
+		var (
+			certificate   *x509.Certificate
+			intermediates []*x509.Certificate
+		)
+
+		if certificate, intermediates, err = ParseForwardedCertificate(header); err != nil {
+			return nil, fmt.Errorf("error occurred parsing the forwarded client certificate: %w", err)
+		}
+
+		opts := x509.VerifyOptions{Roots: roots, Intermediates: x509.NewCertPool(), KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}}
+
+		for _, intermediate := range intermediates {
+			opts.Intermediates.AddCert(intermediate)
+		}
+
+		if _, err = certificate.Verify(opts); err != nil {
+			return nil, fmt.Errorf("error occurred verifying the forwarded client certificate with subject '%s': %w", certificate.Subject, err)
+		}
+
+		identity = NewIdentity(certificate)
+		identity.Forwarded = true
+
+		return identity, nil
+	}
+
+	if len(header) != 0 {
+		p.log.WithField("remote_ip", remoteIP.String()).Debug("Ignoring the forwarded client certificate as the request did not originate from a trusted proxy")
+	}
+
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil, nil
+	}
+
+	return NewIdentity(state.VerifiedChains[0][0]), nil
+}
+
+// Username returns the username the Identity is mapped to using the username template or the value of the configured
+// mapping.
+func (p *Provider) Username(identity *Identity) (username string, err error) {
+	p.mu.RLock()
+
+	tmpl := p.template
+
+	p.mu.RUnlock()
+
+	if tmpl == nil {
+		username = identity.Value(p.config.Mapping)
+	} else {
+		buf := &bytes.Buffer{}
+
+		if err = tmpl.Execute(buf, identity); err != nil {
+			return "", fmt.Errorf("error occurred executing the username template for the client certificate with subject '%s': %w", identity.SubjectDN, err)
+		}
+
+		username = buf.String()
+	}
+
+	if username = strings.TrimSpace(username); username == "" {
+		return "", fmt.Errorf("the client certificate with subject '%s' could not be mapped to a username as the '%s' mapping has no value", identity.SubjectDN, p.config.Mapping)
+	}
+
+	return username, nil
+}
+
+// NewIdentity returns the Identity of a *x509.Certificate.
+func NewIdentity(certificate *x509.Certificate) *Identity {
+	identity := &Identity{
+		SubjectDN:    certificate.Subject.String(),
+		CommonName:   certificate.Subject.CommonName,
+		SerialNumber: certificate.SerialNumber.String(),
+		UPN:          certificateUPN(certificate),
+	}
+
+	if len(certificate.EmailAddresses) != 0 {
+		identity.Email = certificate.EmailAddresses[0]
+	}
+
+	return identity
+}
+
+func isTrustedProxy(proxies []*net.IPNet, ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, proxy := range proxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,358 @@
+package clientcert
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestShouldMapDirectCertificate(t *testing.T) {
+	ca, key := newTestCA(t)
+	leaf := newTestLeaf(t, ca, key, "john", "john@example.com", "john@corp.example.com")
+
+	testCases := []struct {
+		name     string
+		mapping  string
+		template string
+		expected string
+	}{
+		{"ShouldMapEmail", schema.ClientCertificateMappingEmail, "", "john@example.com"},
+		{"ShouldMapUPN", schema.ClientCertificateMappingUPN, "", "john@corp.example.com"},
+		{"ShouldMapSubjectDN", schema.ClientCertificateMappingSubjectDN, "", "CN=john,O=Example"},
+		{"ShouldMapTemplate", schema.ClientCertificateMappingSubjectDN, "{{ .CommonName | upper }}", "JOHN"},
+		{"ShouldMapTemplateUPN", schema.ClientCertificateMappingUPN, `{{ trimSuffix "@corp.example.com" .UPN }}`, "john"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := NewProvider(&schema.AuthenticationBackendClientCertificate{Mapping: tc.mapping, UsernameTemplate: tc.template})
+
+			require.NoError(t, provider.StartupCheck())
+
+			identity, err := provider.Identify(&tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{leaf, ca}}}, net.ParseIP("192.168.1.10"), nil)
+
+			require.NoError(t, err)
+			require.NotNil(t, identity)
+			assert.False(t, identity.Forwarded)
+
+			username, err := provider.Username(identity)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, username)
+		})
+	}
+}
+
+func TestShouldNotIdentifyWithoutVerifiedCertificate(t *testing.T) {
+	ca, key := newTestCA(t)
+	leaf := newTestLeaf(t, ca, key, "john", "john@example.com", "")
+
+	provider := NewProvider(&schema.AuthenticationBackendClientCertificate{
+		Mapping: schema.ClientCertificateMappingEmail,
+		Forwarded: schema.AuthenticationBackendClientCertificateForwarded{
+			TrustedProxies: []string{"10.0.0.0/8"},
+		},
+	})
+
+	require.NoError(t, provider.StartupCheck())
+
+	identity, err := provider.Identify(nil, net.ParseIP("192.168.1.10"), nil)
+
+	assert.NoError(t, err)
+	assert.Nil(t, identity)
+
+	identity, err = provider.Identify(&tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf}}, net.ParseIP("192.168.1.10"), nil)
+
+	assert.NoError(t, err)
+	assert.Nil(t, identity)
+
+	identity, err = provider.Identify(nil, net.ParseIP("192.168.1.10"), []byte(base64.StdEncoding.EncodeToString(leaf.Raw)))
+
+	assert.NoError(t, err)
+	assert.Nil(t, identity)
+
+	identity, err = provider.Identify(&tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{leaf, ca}}}, net.ParseIP("10.0.0.1"), nil)
+
+	assert.NoError(t, err)
+	assert.Nil(t, identity)
+
+	identity, err = provider.Identify(nil, net.ParseIP("10.0.0.1"), []byte(base64.StdEncoding.EncodeToString(leaf.Raw)))
+
+	assert.EqualError(t, err, "the forwarded client certificate can't be verified as no certificate authorities are configured")
+	assert.Nil(t, identity)
+}
+
+func TestShouldIdentifyForwardedCertificate(t *testing.T) {
+	ca, key := newTestCA(t)
+	leaf := newTestLeaf(t, ca, key, "john", "john@example.com", "")
+
+	other, otherKey := newTestCA(t)
+	untrusted := newTestLeaf(t, other, otherKey, "harry", "harry@example.com", "")
+
+	path := filepath.Join(t.TempDir(), "ca.pem")
+
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw}), 0600))
+
+	provider := NewProvider(&schema.AuthenticationBackendClientCertificate{
+		Mapping: schema.ClientCertificateMappingEmail,
+		Forwarded: schema.AuthenticationBackendClientCertificateForwarded{
+			Header:                 "X-Forwarded-Client-Cert",
+			TrustedProxies:         []string{"10.0.0.0/8", "192.168.1.1"},
+			CertificateAuthorities: []string{path},
+		},
+	})
+
+	require.NoError(t, provider.StartupCheck())
+
+	escaped := url.PathEscape(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf.Raw})))
+
+	testCases := []struct {
+		name   string
+		header string
+	}{
+		{"ShouldParseBase64", base64.StdEncoding.EncodeToString(leaf.Raw)},
+		{"ShouldParseBase64Chain", base64.StdEncoding.EncodeToString(leaf.Raw) + "," + base64.StdEncoding.EncodeToString(ca.Raw)},
+		{"ShouldParseEscapedPEM", escaped},
+		{"ShouldParseXFCC", `By=spiffe://example.com/authelia;Hash=abc;Cert="` + escaped + `";Subject="CN=john,O=Example"`},
+		{"ShouldParseXFCCLastElement", `By=spiffe://example.com/proxy;Cert="abc",By=spiffe://example.com/authelia;Cert="` + escaped + `"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			identity, err := provider.Identify(nil, net.ParseIP("192.168.1.1"), []byte(tc.header))
+
+			require.NoError(t, err)
+			require.NotNil(t, identity)
+
+			assert.True(t, identity.Forwarded)
+			assert.Equal(t, "john@example.com", identity.Email)
+			assert.Equal(t, "john", identity.CommonName)
+		})
+	}
+
+	identity, err := provider.Identify(nil, net.ParseIP("10.1.1.1"), []byte(base64.StdEncoding.EncodeToString(untrusted.Raw)))
+
+	assert.Nil(t, identity)
+	assert.ErrorContains(t, err, "error occurred verifying the forwarded client certificate with subject 'CN=harry,O=Example'")
+
+	identity, err = provider.Identify(nil, net.ParseIP("10.1.1.1"), []byte("By=abc"))
+
+	assert.Nil(t, identity)
+	assert.EqualError(t, err, "error occurred parsing the forwarded client certificate: the value is not a PEM or base64 encoded certificate: illegal base64 data at input byte 2")
+}
+
+func TestShouldIdentifyForwardedCertificateWithIntermediate(t *testing.T) {
+	root, rootKey := newTestCA(t)
+	intermediate, intermediateKey := newTestIntermediate(t, root, rootKey)
+	leaf := newTestLeaf(t, intermediate, intermediateKey, "john", "john@example.com", "")
+
+	path := filepath.Join(t.TempDir(), "ca.pem")
+
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: root.Raw}), 0600))
+
+	provider := NewProvider(&schema.AuthenticationBackendClientCertificate{
+		Mapping: schema.ClientCertificateMappingEmail,
+		Forwarded: schema.AuthenticationBackendClientCertificateForwarded{
+			Header:                 "X-Forwarded-Client-Cert",
+			TrustedProxies:         []string{"10.0.0.0/8"},
+			CertificateAuthorities: []string{path},
+		},
+	})
+
+	require.NoError(t, provider.StartupCheck())
+
+	escapedLeaf := url.PathEscape(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf.Raw})))
+	escapedChain := url.PathEscape(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf.Raw})) + string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: intermediate.Raw})))
+
+	testCases := []struct {
+		name   string
+		header string
+	}{
+		{"ShouldParseBase64Chain", base64.StdEncoding.EncodeToString(leaf.Raw) + "," + base64.StdEncoding.EncodeToString(intermediate.Raw)},
+		{"ShouldParseEscapedPEMBundle", escapedChain},
+		{"ShouldParseXFCCCertAndChain", `By=spiffe://example.com/authelia;Cert="` + escapedLeaf + `";Chain="` + escapedChain + `";Subject="CN=john,O=Example"`},
+		{"ShouldParseXFCCChain", `By=spiffe://example.com/authelia;Chain="` + escapedChain + `"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			identity, err := provider.Identify(nil, net.ParseIP("10.1.1.1"), []byte(tc.header))
+
+			require.NoError(t, err)
+			require.NotNil(t, identity)
+
+			assert.True(t, identity.Forwarded)
+			assert.Equal(t, "john@example.com", identity.Email)
+		})
+	}
+
+	identity, err := provider.Identify(nil, net.ParseIP("10.1.1.1"), []byte(`By=spiffe://example.com/authelia;Cert="`+escapedLeaf+`"`))
+
+	assert.Nil(t, identity)
+	assert.ErrorContains(t, err, "error occurred verifying the forwarded client certificate with subject 'CN=john,O=Example': x509: certificate signed by unknown authority")
+
+	identity, err = provider.Identify(nil, net.ParseIP("10.1.1.1"), []byte(`By=spiffe://example.com/proxy;Cert="abc",By=spiffe://example.com/authelia;Hash=abc`))
+
+	assert.Nil(t, identity)
+	assert.EqualError(t, err, "error occurred parsing the forwarded client certificate: the last element of the X-Forwarded-Client-Cert value does not contain the Cert or Chain key")
+}
+
+func TestShouldFailToMapWithoutValue(t *testing.T) {
+	provider := NewProvider(&schema.AuthenticationBackendClientCertificate{Mapping: schema.ClientCertificateMappingUPN})
+
+	require.NoError(t, provider.StartupCheck())
+
+	username, err := provider.Username(&Identity{SubjectDN: "CN=john", Email: "john@example.com"})
+
+	assert.Equal(t, "", username)
+	assert.EqualError(t, err, "the client certificate with subject 'CN=john' could not be mapped to a username as the 'upn' mapping has no value")
+}
+
+func TestShouldFailStartupCheck(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *schema.AuthenticationBackendClientCertificate
+		err    string
+	}{
+		{
+			"ShouldFailInvalidTemplate",
+			&schema.AuthenticationBackendClientCertificate{UsernameTemplate: "{{ .CommonName"},
+			"error occurred parsing the username template: template: username:1: unclosed action",
+		},
+		{
+			"ShouldFailInvalidTrustedProxy",
+			&schema.AuthenticationBackendClientCertificate{Forwarded: schema.AuthenticationBackendClientCertificateForwarded{TrustedProxies: []string{"abc"}}},
+			"error occurred parsing the trusted proxy 'abc': invalid IP address: abc",
+		},
+		{
+			"ShouldFailMissingCertificateAuthority",
+			&schema.AuthenticationBackendClientCertificate{Forwarded: schema.AuthenticationBackendClientCertificateForwarded{CertificateAuthorities: []string{"/path/does/not/exist/ca.pem"}}},
+			"error occurred loading the certificate authority '/path/does/not/exist/ca.pem': open /path/does/not/exist/ca.pem: no such file or directory",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.EqualError(t, NewProvider(tc.config).StartupCheck(), tc.err)
+		})
+	}
+}
+
+func newTestCA(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Example CA", Organization: []string{"Example"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+
+	require.NoError(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+
+	require.NoError(t, err)
+
+	return certificate, key
+}
+
+func newTestIntermediate(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(3),
+		Subject:               pkix.Name{CommonName: "Example Intermediate CA", Organization: []string{"Example"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+
+	require.NoError(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+
+	require.NoError(t, err)
+
+	return certificate, key
+}
+
+func newTestLeaf(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey, cn, email, upn string) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: cn, Organization: []string{"Example"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	names := []asn1.RawValue{{Class: asn1.ClassContextSpecific, Tag: 1, Bytes: []byte(email)}}
+
+	if upn != "" {
+		value, err := asn1.MarshalWithParams(upn, "utf8")
+
+		require.NoError(t, err)
+
+		oid, err := asn1.Marshal(oidUserPrincipalName)
+
+		require.NoError(t, err)
+
+		explicit, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: value})
+
+		require.NoError(t, err)
+
+		names = append(names, asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: append(oid, explicit...)})
+	}
+
+	san, err := asn1.Marshal(names)
+
+	require.NoError(t, err)
+
+	template.ExtraExtensions = []pkix.Extension{{Id: oidExtensionSubjectAltName, Value: san}}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+
+	require.NoError(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+
+	require.NoError(t, err)
+
+	return certificate
+}
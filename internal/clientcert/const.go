@@ -0,0 +1,20 @@
+package clientcert
+
+import (
+	"encoding/asn1"
+)
+
+var (
+	// oidExtensionSubjectAltName is the object identifier of the Subject Alternative Name extension.
+	oidExtensionSubjectAltName = asn1.ObjectIdentifier{2, 5, 29, 17}
+
+	// oidUserPrincipalName is the object identifier of the Microsoft User Principal Name otherName SAN.
+	oidUserPrincipalName = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 20, 2, 3}
+)
+
+const (
+	xfccKeyCert  = "Cert"
+	xfccKeyChain = "Chain"
+
+	pemBlockPrefix = "-----BEGIN"
+)
@@ -0,0 +1,49 @@
+package clientcert
+
+import (
+	"crypto/x509"
+	"net"
+	"sync"
+	"text/template"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// Provider maps verified client certificates presented via mutual TLS or forwarded by a trusted reverse proxy to
+// usernames.
+type Provider struct {
+	config *schema.AuthenticationBackendClientCertificate
+	log    *logrus.Logger
+
+	template *template.Template
+	roots    *x509.CertPool
+	proxies  []*net.IPNet
+
+	mu sync.RWMutex
+}
+
+// Identity represents the identity values of a verified client certificate which are available to the username
+// template.
+type Identity struct {
+	SubjectDN    string
+	CommonName   string
+	Email        string
+	UPN          string
+	SerialNumber string
+
+	Forwarded bool
+}
+
+// Value returns the value of the Identity for the given mapping.
+func (i Identity) Value(mapping string) string {
+	switch mapping {
+	case schema.ClientCertificateMappingUPN:
+		return i.UPN
+	case schema.ClientCertificateMappingSubjectDN:
+		return i.SubjectDN
+	default:
+		return i.Email
+	}
+}
@@ -0,0 +1,247 @@
+package clientcert
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// ParseNetwork parses an IP address or a network in CIDR notation as a *net.IPNet.
+func ParseNetwork(value string) (network *net.IPNet, err error) {
+	if strings.Contains(value, "/") {
+		_, network, err = net.ParseCIDR(value)
+
+		return network, err
+	}
+
+	ip := net.ParseIP(value)
+
+	switch {
+	case ip == nil:
+		return nil, fmt.Errorf("invalid IP address: %s", value)
+	case ip.To4() != nil:
+		return &net.IPNet{IP: ip.To4(), Mask: net.CIDRMask(32, 32)}, nil
+	default:
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+	}
+}
+
+// ParseForwardedCertificate parses a client certificate forwarded by a reverse proxy along with the other certificates
+// of its chain. The supported formats are the Envoy X-Forwarded-Client-Cert format, a URL encoded PEM certificate or
+// bundle, and a comma separated list of base64 encoded DER certificates. The leaf certificate is expected to be the
+// first, and the other certificates are returned as the intermediates used to verify it.
+func ParseForwardedCertificate(value []byte) (certificate *x509.Certificate, intermediates []*x509.Certificate, err error) {
+	raw := strings.TrimSpace(string(value))
+
+	if raw == "" {
+		return nil, nil, errors.New("the value is empty")
+	}
+
+	if strings.Contains(raw, xfccKeyCert+"=") || strings.Contains(raw, xfccKeyChain+"=") {
+		return xfccCertificates(raw)
+	}
+
+	var certificates []*x509.Certificate
+
+	if certificates, err = parseCertificates(raw); err != nil {
+		return nil, nil, err
+	}
+
+	return certificates[0], certificates[1:], nil
+}
+
+// parseCertificates parses a URL encoded PEM bundle, or a comma separated list of base64 encoded DER certificates.
+func parseCertificates(raw string) (certificates []*x509.Certificate, err error) {
+	if decoded, err := url.PathUnescape(raw); err == nil && strings.Contains(decoded, pemBlockPrefix) {
+		return parsePEMCertificates([]byte(decoded))
+	}
+
+	var (
+		der         []byte
+		certificate *x509.Certificate
+	)
+
+	for _, value := range strings.Split(raw, ",") {
+		if der, err = base64.StdEncoding.DecodeString(strings.Join(strings.Fields(value), "")); err != nil {
+			return nil, fmt.Errorf("the value is not a PEM or base64 encoded certificate: %w", err)
+		}
+
+		if certificate, err = x509.ParseCertificate(der); err != nil {
+			return nil, err
+		}
+
+		certificates = append(certificates, certificate)
+	}
+
+	return certificates, nil
+}
+
+// parsePEMCertificates parses every certificate of a PEM bundle.
+func parsePEMCertificates(data []byte) (certificates []*x509.Certificate, err error) {
+	var (
+		block       *pem.Block
+		certificate *x509.Certificate
+	)
+
+	for {
+		if block, data = pem.Decode(data); block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			return nil, errors.New("the value does not contain a PEM encoded certificate")
+		}
+
+		if certificate, err = x509.ParseCertificate(block.Bytes); err != nil {
+			return nil, err
+		}
+
+		certificates = append(certificates, certificate)
+	}
+
+	if len(certificates) == 0 {
+		return nil, errors.New("the value does not contain a PEM encoded certificate")
+	}
+
+	return certificates, nil
+}
+
+// xfccCertificates returns the certificate and the intermediates of the last element of an Envoy
+// X-Forwarded-Client-Cert header value. The last element is the element added by the proxy nearest to Authelia. The
+// certificate is taken from the Cert key, and the intermediates are taken from the Chain key which includes the leaf
+// certificate. If the Cert key is absent the leaf certificate is the first certificate of the Chain key.
+func xfccCertificates(value string) (certificate *x509.Certificate, intermediates []*x509.Certificate, err error) {
+	elements := xfccSplit(value, ',')
+
+	var cert, chain string
+
+	for _, pair := range xfccSplit(elements[len(elements)-1], ';') {
+		k, v, found := strings.Cut(pair, "=")
+
+		if !found {
+			continue
+		}
+
+		switch k = strings.TrimSpace(k); {
+		case strings.EqualFold(k, xfccKeyCert):
+			cert = strings.Trim(strings.TrimSpace(v), `"`)
+		case strings.EqualFold(k, xfccKeyChain):
+			chain = strings.Trim(strings.TrimSpace(v), `"`)
+		}
+	}
+
+	if cert == "" && chain == "" {
+		return nil, nil, fmt.Errorf("the last element of the %s value does not contain the %s or %s key", "X-Forwarded-Client-Cert", xfccKeyCert, xfccKeyChain)
+	}
+
+	var certificates []*x509.Certificate
+
+	if chain != "" {
+		if certificates, err = parseCertificates(chain); err != nil {
+			return nil, nil, fmt.Errorf("error occurred parsing the %s key: %w", xfccKeyChain, err)
+		}
+	}
+
+	if cert == "" {
+		return certificates[0], certificates[1:], nil
+	}
+
+	var leaf []*x509.Certificate
+
+	if leaf, err = parseCertificates(cert); err != nil {
+		return nil, nil, err
+	}
+
+	for _, c := range certificates {
+		if !c.Equal(leaf[0]) {
+			intermediates = append(intermediates, c)
+		}
+	}
+
+	return leaf[0], intermediates, nil
+}
+
+// xfccSplit splits a value by the separator ignoring separators within quoted strings.
+func xfccSplit(value string, sep rune) (parts []string) {
+	var (
+		quoted bool
+		start  int
+	)
+
+	for i, r := range value {
+		switch r {
+		case '"':
+			quoted = !quoted
+		case sep:
+			if !quoted {
+				parts = append(parts, value[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, value[start:])
+}
+
+// certificateUPN returns the first Microsoft User Principal Name otherName of the Subject Alternative Name extension of
+// a certificate.
+func certificateUPN(certificate *x509.Certificate) string {
+	for _, ext := range certificate.Extensions {
+		if !ext.Id.Equal(oidExtensionSubjectAltName) {
+			continue
+		}
+
+		var seq asn1.RawValue
+
+		if rest, err := asn1.Unmarshal(ext.Value, &seq); err != nil || len(rest) != 0 || !seq.IsCompound || seq.Tag != asn1.TagSequence {
+			return ""
+		}
+
+		rest := seq.Bytes
+
+		for len(rest) != 0 {
+			var (
+				name asn1.RawValue
+				err  error
+			)
+
+			if rest, err = asn1.Unmarshal(rest, &name); err != nil {
+				return ""
+			}
+
+			// The otherName GeneralName is tagged with the context-specific tag 0.
+			if name.Class != asn1.ClassContextSpecific || name.Tag != 0 {
+				continue
+			}
+
+			var (
+				oid   asn1.ObjectIdentifier
+				value asn1.RawValue
+				upn   string
+			)
+
+			remaining, err := asn1.Unmarshal(name.Bytes, &oid)
+			if err != nil || !oid.Equal(oidUserPrincipalName) {
+				continue
+			}
+
+			if _, err = asn1.Unmarshal(remaining, &value); err != nil || value.Class != asn1.ClassContextSpecific || value.Tag != 0 {
+				continue
+			}
+
+			if _, err = asn1.UnmarshalWithParams(value.Bytes, &upn, "utf8"); err != nil {
+				continue
+			}
+
+			return upn
+		}
+	}
+
+	return ""
+}
@@ -3,17 +3,19 @@ package commands
 import (
 	"errors"
 	"fmt"
-	"net"
-	"net/url"
 	"os"
+	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/valyala/fasthttp"
 
 	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/validator"
+	"github.com/authelia/authelia/v4/internal/geoip"
 )
 
 func newAccessControlCommand(ctx *CmdCtx) (cmd *cobra.Command) {
@@ -28,6 +30,7 @@ func newAccessControlCommand(ctx *CmdCtx) (cmd *cobra.Command) {
 
 	cmd.AddCommand(
 		newAccessControlCheckCommand(ctx),
+		newAccessControlLintCommand(ctx),
 	)
 
 	return cmd
@@ -51,12 +54,76 @@ func newAccessControlCheckCommand(ctx *CmdCtx) (cmd *cobra.Command) {
 	cmd.Flags().String("method", fasthttp.MethodGet, "the HTTP method of the object")
 	cmd.Flags().String("username", "", "the username of the subject")
 	cmd.Flags().StringSlice("groups", nil, "the groups of the subject")
+	cmd.Flags().StringSlice("emails", nil, "the emails of the subject")
+	cmd.Flags().StringArray("attribute", nil, "an attribute of the subject in the 'name=value' format, can be specified multiple times")
 	cmd.Flags().String("ip", "", "the ip of the subject")
+	cmd.Flags().StringArray("header", nil, "a header of the object in the 'Name: value' format, can be specified multiple times")
+	cmd.Flags().String("time", "", "the time of the request in the RFC3339 or 'YYYY-MM-DD HH:MM' format, defaults to the current time")
 	cmd.Flags().Bool("verbose", false, "enables verbose output")
+	cmd.Flags().String(cmdFlagNameFile, "", "a CSV or JSON Lines file of test cases with the expected policy to check instead of a single request")
+	cmd.Flags().Bool(cmdFlagNameTrace, false, "prints every field of the match result of every rule for each request")
 
 	return cmd
 }
 
+func newAccessControlLintCommand(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "lint",
+		Short:   cmdAutheliaAccessControlLintShort,
+		Long:    cmdAutheliaAccessControlLintLong,
+		Example: cmdAutheliaAccessControlLintExample,
+		Args:    cobra.NoArgs,
+		PreRunE: ctx.ChainRunE(
+			ctx.HelperConfigLoadRunE,
+		),
+		RunE: ctx.AccessControlLintRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+// AccessControlLintRunE is the RunE for the authelia access-control lint command.
+func (ctx *CmdCtx) AccessControlLintRunE(_ *cobra.Command, _ []string) (err error) {
+	validator.ValidateAccessControl(ctx.config, ctx.cconfig.validator)
+
+	if ctx.cconfig.validator.HasErrors() {
+		return errors.New("failed to execute command due to errors in the configuration")
+	}
+
+	issues := authorization.LintAccessControl(ctx.config.AccessControl)
+
+	if len(issues) == 0 {
+		fmt.Printf("\nNo issues were found with the %d access control rules.\n\n", len(ctx.config.AccessControl.Rules))
+
+		return nil
+	}
+
+	accessControlLintWriteOutput(issues)
+
+	os.Exit(1)
+
+	return nil
+}
+
+func accessControlLintWriteOutput(issues []authorization.LintIssue) {
+	fmt.Printf("\nFound %d potential issues with the access control rules:\n\n", len(issues))
+
+	w := tabwriter.NewWriter(os.Stdout, 1, 1, 4, ' ', 0)
+
+	_, _ = fmt.Fprintln(w, "  #\tKind\tIssue")
+
+	for _, issue := range issues {
+		_, _ = fmt.Fprintf(w, "  %d\t%s\t%s\n", issue.Position, issue.Kind, issue.Message)
+		_, _ = fmt.Fprintf(w, "   \t\tSuggestion: %s\n", issue.Suggestion)
+	}
+
+	_ = w.Flush()
+
+	fmt.Println()
+}
+
 func (ctx *CmdCtx) AccessControlCheckRunE(cmd *cobra.Command, _ []string) (err error) {
 	validator.ValidateAccessControl(ctx.config, ctx.cconfig.validator)
 
@@ -64,13 +131,66 @@ func (ctx *CmdCtx) AccessControlCheckRunE(cmd *cobra.Command, _ []string) (err e
 		return errors.New("failed to execute command due to errors in the configuration")
 	}
 
-	authorizer := authorization.NewAuthorizer(ctx.config)
+	provider, err := getClockFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	var (
+		file  string
+		trace bool
+	)
+
+	if file, err = cmd.Flags().GetString(cmdFlagNameFile); err != nil {
+		return err
+	}
+
+	if trace, err = cmd.Flags().GetBool(cmdFlagNameTrace); err != nil {
+		return err
+	}
+
+	var geo *geoip.Provider
+
+	if ctx.config.AccessControl.GeoIP.CountryDatabase != "" || ctx.config.AccessControl.GeoIP.ASNDatabase != "" {
+		geo = geoip.NewProvider(&ctx.config.AccessControl.GeoIP)
+
+		if err = geo.StartupCheck(); err != nil {
+			return err
+		}
+	}
+
+	authorizer := authorization.NewAuthorizer(ctx.config, provider, geo)
+
+	if file != "" {
+		var (
+			cases   []accessControlCheckCase
+			results []accessControlCheckCaseResult
+		)
+
+		if cases, err = loadAccessControlCheckCases(file); err != nil {
+			return err
+		}
+
+		if results, err = evaluateAccessControlCheckCases(authorizer, provider, geo, provider.Now(), cases); err != nil {
+			return err
+		}
+
+		if accessControlCheckCasesWriteOutput(results, trace) != 0 {
+			os.Exit(1)
+		}
+
+		return nil
+	}
 
 	subject, object, err := getSubjectAndObjectFromFlags(cmd)
 	if err != nil {
 		return err
 	}
 
+	if geo != nil && subject.IP != nil {
+		subject.GeoIP = geo.Lookup(subject.IP)
+	}
+
 	results := authorizer.GetRuleMatchResults(subject, object)
 
 	if len(results) == 0 {
@@ -84,12 +204,18 @@ func (ctx *CmdCtx) AccessControlCheckRunE(cmd *cobra.Command, _ []string) (err e
 		return err
 	}
 
-	accessControlCheckWriteOutput(object, subject, results, ctx.config.AccessControl.DefaultPolicy, verbose)
+	accessControlCheckWriteOutput(object, subject, provider.Now(), results, ctx.config.AccessControl.DefaultPolicy, verbose)
+
+	if trace {
+		accessControlCheckWriteTrace(results)
+
+		fmt.Println()
+	}
 
 	return nil
 }
 
-func accessControlCheckWriteObjectSubject(object authorization.Object, subject authorization.Subject) {
+func accessControlCheckWriteObjectSubject(object authorization.Object, subject authorization.Subject, now time.Time) {
 	output := strings.Builder{}
 
 	output.WriteString(fmt.Sprintf("Performing policy check for request to '%s'", object.String()))
@@ -106,21 +232,43 @@ func accessControlCheckWriteObjectSubject(object authorization.Object, subject a
 		output.WriteString(fmt.Sprintf(" groups '%s'", strings.Join(subject.Groups, ",")))
 	}
 
+	if len(subject.Emails) != 0 {
+		output.WriteString(fmt.Sprintf(" emails '%s'", strings.Join(subject.Emails, ",")))
+	}
+
+	if len(subject.Attributes) != 0 {
+		attributes := make([]string, 0, len(subject.Attributes))
+
+		for name, value := range subject.Attributes {
+			attributes = append(attributes, name+"="+value)
+		}
+
+		sort.Strings(attributes)
+
+		output.WriteString(fmt.Sprintf(" attributes '%s'", strings.Join(attributes, ",")))
+	}
+
 	if subject.IP != nil {
 		output.WriteString(fmt.Sprintf(" from IP '%s'", subject.IP.String()))
+
+		if !subject.GeoIP.IsEmpty() {
+			output.WriteString(fmt.Sprintf(" (country '%s' continent '%s' asn '%d' organization '%s')", subject.GeoIP.Country, subject.GeoIP.Continent, subject.GeoIP.ASN, subject.GeoIP.Organization))
+		}
 	}
 
+	output.WriteString(fmt.Sprintf(" at '%s'", now.Format(time.RFC3339)))
+
 	output.WriteString(".\n")
 
 	fmt.Println(output.String())
 }
 
-func accessControlCheckWriteOutput(object authorization.Object, subject authorization.Subject, results []authorization.RuleMatchResult, defaultPolicy string, verbose bool) {
-	accessControlCheckWriteObjectSubject(object, subject)
+func accessControlCheckWriteOutput(object authorization.Object, subject authorization.Subject, now time.Time, results []authorization.RuleMatchResult, defaultPolicy string, verbose bool) {
+	accessControlCheckWriteObjectSubject(object, subject, now)
 
 	w := tabwriter.NewWriter(os.Stdout, 1, 1, 4, ' ', 0)
 
-	_, _ = fmt.Fprintln(w, "  #\tDomain\tResource\tMethod\tNetwork\tSubject")
+	_, _ = fmt.Fprintln(w, "  #\tDomain\tResource\tHeaders\tMethod\tNetwork\tSchedule\tSubject")
 
 	var (
 		appliedPos int
@@ -139,15 +287,15 @@ func accessControlCheckWriteOutput(object authorization.Object, subject authoriz
 		case result.IsMatch() && !result.Skipped:
 			appliedPos, applied = i+1, result
 
-			_, _ = fmt.Fprintf(w, "* %d\t%s\t%s\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact))
+			_, _ = fmt.Fprintf(w, "* %d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchHeaders), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSchedule), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact))
 		case result.IsPotentialMatch() && !result.Skipped:
 			if potentialPos == 0 {
 				potentialPos, potential = i+1, result
 			}
 
-			_, _ = fmt.Fprintf(w, "~ %d\t%s\t%s\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact))
+			_, _ = fmt.Fprintf(w, "~ %d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchHeaders), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSchedule), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact))
 		default:
-			_, _ = fmt.Fprintf(w, "  %d\t%s\t%s\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact))
+			_, _ = fmt.Fprintf(w, "  %d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchHeaders), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSchedule), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact))
 		}
 	}
 
@@ -187,45 +335,80 @@ func hitMissMay(in ...bool) (out string) {
 }
 
 func getSubjectAndObjectFromFlags(cmd *cobra.Command) (subject authorization.Subject, object authorization.Object, err error) {
-	requestURL, err := cmd.Flags().GetString("url")
-	if err != nil {
+	c := accessControlCheckCase{}
+
+	if c.URL, err = cmd.Flags().GetString("url"); err != nil {
 		return subject, object, err
 	}
 
-	parsedURL, err := url.ParseRequestURI(requestURL)
-	if err != nil {
+	if c.Method, err = cmd.Flags().GetString("method"); err != nil {
 		return subject, object, err
 	}
 
-	method, err := cmd.Flags().GetString("method")
-	if err != nil {
+	if c.Username, err = cmd.Flags().GetString("username"); err != nil {
 		return subject, object, err
 	}
 
-	username, err := cmd.Flags().GetString("username")
-	if err != nil {
+	if c.Groups, err = cmd.Flags().GetStringSlice("groups"); err != nil {
 		return subject, object, err
 	}
 
-	groups, err := cmd.Flags().GetStringSlice("groups")
-	if err != nil {
+	if c.Emails, err = cmd.Flags().GetStringSlice("emails"); err != nil {
 		return subject, object, err
 	}
 
-	remoteIP, err := cmd.Flags().GetString("ip")
-	if err != nil {
+	if c.IP, err = cmd.Flags().GetString("ip"); err != nil {
+		return subject, object, err
+	}
+
+	if c.Headers, err = cmd.Flags().GetStringArray("header"); err != nil {
 		return subject, object, err
 	}
 
-	parsedIP := net.ParseIP(remoteIP)
+	var rawAttributes []string
 
-	subject = authorization.Subject{
-		Username: username,
-		Groups:   groups,
-		IP:       parsedIP,
+	if rawAttributes, err = cmd.Flags().GetStringArray("attribute"); err != nil {
+		return subject, object, err
 	}
 
-	object = authorization.NewObject(parsedURL, method)
+	for _, rawAttribute := range rawAttributes {
+		if err = c.addAttribute(rawAttribute); err != nil {
+			return subject, object, err
+		}
+	}
+
+	return c.subjectObject()
+}
+
+// getClockFromFlags returns a fixed clock set to the time from the flags or the current time. A fixed clock is always
+// returned so the time can be adjusted for each test case when checking a file.
+func getClockFromFlags(cmd *cobra.Command) (provider *clock.Fixed, err error) {
+	value, err := cmd.Flags().GetString("time")
+	if err != nil {
+		return nil, err
+	}
+
+	if value == "" {
+		return clock.NewFixed(time.Now()), nil
+	}
+
+	var now time.Time
+
+	if now, err = parseAccessControlCheckTime(value); err != nil {
+		return nil, err
+	}
+
+	return clock.NewFixed(now), nil
+}
+
+func parseAccessControlCheckTime(value string) (now time.Time, err error) {
+	if now, err = time.Parse(time.RFC3339, value); err == nil {
+		return now, nil
+	}
+
+	if now, err = time.ParseInLocation("2006-01-02 15:04", value, time.Local); err != nil {
+		return now, fmt.Errorf("failed to parse the time '%s': must be in the RFC3339 or 'YYYY-MM-DD HH:MM' format", value)
+	}
 
-	return subject, object, nil
+	return now, nil
 }
@@ -0,0 +1,407 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/geoip"
+)
+
+// accessControlCheckCase is a single test case read from an access control check file.
+type accessControlCheckCase struct {
+	Line int `json:"-"`
+
+	URL      string   `json:"url"`
+	Method   string   `json:"method"`
+	Username string   `json:"username"`
+	Groups   []string `json:"groups"`
+	Emails   []string `json:"emails"`
+	IP       string   `json:"ip"`
+	Headers  []string `json:"headers"`
+	Time     string   `json:"time"`
+	Expected string   `json:"expected"`
+
+	Attributes map[string]string `json:"attributes"`
+}
+
+// accessControlCheckCaseResult is the outcome of evaluating an accessControlCheckCase.
+type accessControlCheckCaseResult struct {
+	Case    accessControlCheckCase
+	Subject authorization.Subject
+	Object  authorization.Object
+	Now     time.Time
+	Policy  authorization.RequiredPolicy
+	Results []authorization.RuleMatchResult
+}
+
+// Passed returns true if the applied policy is the expected policy.
+func (r accessControlCheckCaseResult) Passed() bool {
+	return r.Policy.Level.String() == r.Case.Expected
+}
+
+func loadAccessControlCheckCases(path string) (cases []accessControlCheckCase, err error) {
+	var data []byte
+
+	if data, err = os.ReadFile(path); err != nil {
+		return nil, fmt.Errorf("failed to read the test cases file '%s': %w", path, err)
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case extCSV:
+		cases, err = parseAccessControlCheckCasesCSV(bytes.NewReader(data))
+	case extJSONL, extNDJSON, extJSON:
+		cases, err = parseAccessControlCheckCasesJSONL(bytes.NewReader(data))
+	default:
+		return nil, fmt.Errorf("failed to read the test cases file '%s': the extension '%s' is not supported, must be one of '%s', '%s', '%s', or '%s'", path, ext, extCSV, extJSONL, extNDJSON, extJSON)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the test cases file '%s': %w", path, err)
+	}
+
+	if len(cases) == 0 {
+		return nil, fmt.Errorf("failed to parse the test cases file '%s': the file does not contain any test cases", path)
+	}
+
+	return cases, nil
+}
+
+// parseAccessControlCheckCasesCSV parses test cases from CSV with a header row. Every column named 'header' adds a
+// header to the case in the 'Name: value' format, every column named 'attribute' adds an attribute to the case in the
+// 'name=value' format, and the 'groups' and 'emails' columns are comma separated lists.
+func parseAccessControlCheckCasesCSV(r io.Reader) (cases []accessControlCheckCase, err error) {
+	reader := csv.NewReader(r)
+
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var columns []string
+
+	if columns, err = reader.Read(); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("line 1: %w", err)
+	}
+
+	hasURL, hasExpected := false, false
+
+	for i, column := range columns {
+		columns[i] = strings.ToLower(strings.TrimSpace(column))
+
+		switch columns[i] {
+		case "url":
+			hasURL = true
+		case "expected":
+			hasExpected = true
+		case "method", "username", "groups", "emails", "ip", "header", "attribute", "time":
+			continue
+		default:
+			return nil, fmt.Errorf("line 1: the column '%s' is not known", column)
+		}
+	}
+
+	if !hasURL || !hasExpected {
+		return nil, fmt.Errorf("line 1: the columns 'url' and 'expected' are required")
+	}
+
+	var record []string
+
+	for {
+		if record, err = reader.Read(); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		if len(record) > len(columns) {
+			return nil, fmt.Errorf("line %d: the record has %d fields but the header only has %d", line, len(record), len(columns))
+		}
+
+		c := accessControlCheckCase{Line: line}
+
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+
+			switch columns[i] {
+			case "url":
+				c.URL = value
+			case "method":
+				c.Method = value
+			case "username":
+				c.Username = value
+			case "groups":
+				c.Groups = splitAccessControlCheckList(value)
+			case "emails":
+				c.Emails = splitAccessControlCheckList(value)
+			case "ip":
+				c.IP = value
+			case "header":
+				if value != "" {
+					c.Headers = append(c.Headers, value)
+				}
+			case "attribute":
+				if value == "" {
+					continue
+				}
+
+				if err = c.addAttribute(value); err != nil {
+					return nil, fmt.Errorf("line %d: %w", line, err)
+				}
+			case "time":
+				c.Time = value
+			case "expected":
+				c.Expected = value
+			}
+		}
+
+		if err = c.validate(); err != nil {
+			return nil, err
+		}
+
+		cases = append(cases, c)
+	}
+
+	return cases, nil
+}
+
+// parseAccessControlCheckCasesJSONL parses test cases from JSON Lines i.e. one JSON object per line. Blank lines and
+// lines starting with '#' are ignored.
+func parseAccessControlCheckCasesJSONL(r io.Reader) (cases []accessControlCheckCase, err error) {
+	scanner := bufio.NewScanner(r)
+
+	line := 0
+
+	for scanner.Scan() {
+		line++
+
+		raw := bytes.TrimSpace(scanner.Bytes())
+
+		if len(raw) == 0 || raw[0] == '#' {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+
+		decoder.DisallowUnknownFields()
+
+		c := accessControlCheckCase{}
+
+		if err = decoder.Decode(&c); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		c.Line = line
+
+		if err = c.validate(); err != nil {
+			return nil, err
+		}
+
+		cases = append(cases, c)
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return cases, nil
+}
+
+func splitAccessControlCheckList(value string) (items []string) {
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+func (c *accessControlCheckCase) addAttribute(raw string) (err error) {
+	name, value, found := strings.Cut(raw, "=")
+	if !found || strings.TrimSpace(name) == "" {
+		return fmt.Errorf("failed to parse the attribute '%s': must be in the 'name=value' format", raw)
+	}
+
+	if c.Attributes == nil {
+		c.Attributes = map[string]string{}
+	}
+
+	c.Attributes[strings.TrimSpace(name)] = value
+
+	return nil
+}
+
+func (c accessControlCheckCase) subjectObject() (subject authorization.Subject, object authorization.Object, err error) {
+	parsedURL, err := url.ParseRequestURI(c.URL)
+	if err != nil {
+		return subject, object, err
+	}
+
+	var header http.Header
+
+	for _, rawHeader := range c.Headers {
+		name, value, found := strings.Cut(rawHeader, ":")
+		if !found || strings.TrimSpace(name) == "" {
+			return subject, object, fmt.Errorf("failed to parse the header '%s': must be in the 'Name: value' format", rawHeader)
+		}
+
+		if header == nil {
+			header = http.Header{}
+		}
+
+		header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
+	subject = authorization.Subject{
+		Username:   c.Username,
+		Groups:     c.Groups,
+		Emails:     c.Emails,
+		Attributes: c.Attributes,
+		IP:         net.ParseIP(c.IP),
+	}
+
+	object = authorization.NewObject(parsedURL, c.Method)
+	object.Header = header
+
+	return subject, object, nil
+}
+
+func (c *accessControlCheckCase) validate() (err error) {
+	if c.URL == "" {
+		return fmt.Errorf("line %d: the url is required", c.Line)
+	}
+
+	if c.Method == "" {
+		c.Method = fasthttp.MethodGet
+	}
+
+	if authorization.NewLevel(c.Expected).String() != c.Expected {
+		return fmt.Errorf("line %d: the expected policy '%s' is not valid, must be one of 'bypass', 'one_factor', 'two_factor', or 'deny'", c.Line, c.Expected)
+	}
+
+	if c.Time != "" {
+		if _, err = parseAccessControlCheckTime(c.Time); err != nil {
+			return fmt.Errorf("line %d: %w", c.Line, err)
+		}
+	}
+
+	return nil
+}
+
+// evaluateAccessControlCheckCases evaluates each case using the authorizer. The fixed clock must be the clock used by
+// the authorizer, it's set to the time of each case or the fallback time when the case doesn't specify one.
+func evaluateAccessControlCheckCases(authorizer *authorization.Authorizer, fixed *clock.Fixed, geo *geoip.Provider, fallback time.Time, cases []accessControlCheckCase) (results []accessControlCheckCaseResult, err error) {
+	results = make([]accessControlCheckCaseResult, len(cases))
+
+	for i, c := range cases {
+		result := accessControlCheckCaseResult{Case: c, Now: fallback}
+
+		if c.Time != "" {
+			if result.Now, err = parseAccessControlCheckTime(c.Time); err != nil {
+				return nil, fmt.Errorf("line %d: %w", c.Line, err)
+			}
+		}
+
+		if result.Subject, result.Object, err = c.subjectObject(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", c.Line, err)
+		}
+
+		if geo != nil && result.Subject.IP != nil {
+			result.Subject.GeoIP = geo.Lookup(result.Subject.IP)
+		}
+
+		fixed.Set(result.Now)
+
+		_, result.Policy = authorizer.GetRequiredPolicy(result.Subject, result.Object)
+		result.Results = authorizer.GetRuleMatchResults(result.Subject, result.Object)
+
+		results[i] = result
+	}
+
+	return results, nil
+}
+
+func accessControlCheckCasesWriteOutput(results []accessControlCheckCaseResult, trace bool) (failed int) {
+	fmt.Println()
+
+	for _, result := range results {
+		status := "PASS"
+
+		if !result.Passed() {
+			status = "FAIL"
+			failed++
+		}
+
+		rule := "the default policy"
+
+		if result.Policy.Position != 0 {
+			rule = fmt.Sprintf("rule #%d", result.Policy.Position)
+		}
+
+		fmt.Printf("%s line %d: %s '%s'", status, result.Case.Line, result.Object.Method, result.Object.String())
+
+		if result.Subject.Username != "" {
+			fmt.Printf(" username '%s'", result.Subject.Username)
+		}
+
+		if len(result.Subject.Groups) != 0 {
+			fmt.Printf(" groups '%s'", strings.Join(result.Subject.Groups, ","))
+		}
+
+		if result.Subject.IP != nil {
+			fmt.Printf(" from IP '%s'", result.Subject.IP.String())
+		}
+
+		fmt.Printf(" expected '%s' got '%s' from %s\n", result.Case.Expected, result.Policy.Level, rule)
+
+		if trace {
+			fmt.Println()
+			accessControlCheckWriteTrace(result.Results)
+			fmt.Println()
+		}
+	}
+
+	fmt.Printf("\n%d test cases, %d passed, %d failed.\n\n", len(results), len(results)-failed, failed)
+
+	return failed
+}
+
+// accessControlCheckWriteTrace writes every field of the rule match results.
+func accessControlCheckWriteTrace(results []authorization.RuleMatchResult) {
+	w := tabwriter.NewWriter(os.Stdout, 1, 1, 4, ' ', 0)
+
+	_, _ = fmt.Fprintln(w, "  #\tPolicy\tSkipped\tDomain\tResources\tQuery\tHeaders\tMethods\tNetworks\tSubjects\tSubjectsExact\tSchedule\tMatch\tPotentialMatch")
+
+	for _, result := range results {
+		_, _ = fmt.Fprintf(w, "  %d\t%s\t%t\t%t\t%t\t%t\t%t\t%t\t%t\t%t\t%t\t%t\t%t\t%t\n",
+			result.Rule.Position, result.Rule.Policy, result.Skipped,
+			result.MatchDomain, result.MatchResources, result.MatchQuery, result.MatchHeaders, result.MatchMethods,
+			result.MatchNetworks, result.MatchSubjects, result.MatchSubjectsExact, result.MatchSchedule,
+			result.IsMatch(), result.IsPotentialMatch())
+	}
+
+	_ = w.Flush()
+}
@@ -0,0 +1,200 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAccessControlCheckCasesCSV(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected []accessControlCheckCase
+		err      string
+	}{
+		{
+			"ShouldParse",
+			"url,method,username,groups,emails,ip,header,header,attribute,time,expected\n" +
+				"https://example.com/,,,,,,,,,,bypass\n" +
+				"https://app.example.com/admin,POST,john,\"admin, dev\",john@example.com,192.168.1.1,X-Api-Version: 2,X-Other: abc,department=finance,2026-10-18T09:30:00+10:00,two_factor\n",
+			[]accessControlCheckCase{
+				{Line: 2, URL: "https://example.com/", Method: "GET", Expected: "bypass"},
+				{Line: 3, URL: "https://app.example.com/admin", Method: "POST", Username: "john", Groups: []string{"admin", "dev"}, Emails: []string{"john@example.com"}, IP: "192.168.1.1", Headers: []string{"X-Api-Version: 2", "X-Other: abc"}, Attributes: map[string]string{"department": "finance"}, Time: "2026-10-18T09:30:00+10:00", Expected: "two_factor"},
+			},
+			"",
+		},
+		{
+			"ShouldParseColumnsInAnyOrderAndCase",
+			"Expected, URL\none_factor,https://example.com/\n",
+			[]accessControlCheckCase{
+				{Line: 2, URL: "https://example.com/", Method: "GET", Expected: "one_factor"},
+			},
+			"",
+		},
+		{
+			"ShouldParseEmpty",
+			"",
+			nil,
+			"",
+		},
+		{
+			"ShouldErrUnknownColumn",
+			"url,expected,policy\n",
+			nil,
+			"line 1: the column 'policy' is not known",
+		},
+		{
+			"ShouldErrMissingColumns",
+			"url,method\n",
+			nil,
+			"line 1: the columns 'url' and 'expected' are required",
+		},
+		{
+			"ShouldErrTooManyFields",
+			"url,expected\nhttps://example.com/,deny,abc\n",
+			nil,
+			"line 2: the record has 3 fields but the header only has 2",
+		},
+		{
+			"ShouldErrInvalidExpected",
+			"url,expected\nhttps://example.com/,deny\nhttps://example.com/,two-factor\n",
+			nil,
+			"line 3: the expected policy 'two-factor' is not valid, must be one of 'bypass', 'one_factor', 'two_factor', or 'deny'",
+		},
+		{
+			"ShouldErrInvalidAttribute",
+			"url,attribute,expected\nhttps://example.com/,department,deny\n",
+			nil,
+			"line 2: failed to parse the attribute 'department': must be in the 'name=value' format",
+		},
+		{
+			"ShouldErrMissingURL",
+			"url,expected\n,deny\n",
+			nil,
+			"line 2: the url is required",
+		},
+		{
+			"ShouldErrInvalidTime",
+			"url,time,expected\nhttps://example.com/,yesterday,deny\n",
+			nil,
+			"line 2: failed to parse the time 'yesterday': must be in the RFC3339 or 'YYYY-MM-DD HH:MM' format",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := parseAccessControlCheckCasesCSV(strings.NewReader(tc.have))
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, actual)
+			} else {
+				assert.EqualError(t, err, tc.err)
+				assert.Nil(t, actual)
+			}
+		})
+	}
+}
+
+func TestParseAccessControlCheckCasesJSONL(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected []accessControlCheckCase
+		err      string
+	}{
+		{
+			"ShouldParse",
+			"# public\n" +
+				`{"url":"https://example.com/","expected":"bypass"}` + "\n\n" +
+				`{"url":"https://app.example.com/admin","method":"POST","username":"john","groups":["admin","dev"],"emails":["john@example.com"],"attributes":{"department":"finance"},"ip":"192.168.1.1","headers":["X-Api-Version: 2"],"time":"2026-10-18 09:30","expected":"two_factor"}` + "\n",
+			[]accessControlCheckCase{
+				{Line: 2, URL: "https://example.com/", Method: "GET", Expected: "bypass"},
+				{Line: 4, URL: "https://app.example.com/admin", Method: "POST", Username: "john", Groups: []string{"admin", "dev"}, Emails: []string{"john@example.com"}, IP: "192.168.1.1", Headers: []string{"X-Api-Version: 2"}, Attributes: map[string]string{"department": "finance"}, Time: "2026-10-18 09:30", Expected: "two_factor"},
+			},
+			"",
+		},
+		{
+			"ShouldErrUnknownField",
+			`{"url":"https://example.com/","policy":"bypass"}`,
+			nil,
+			"line 1: json: unknown field \"policy\"",
+		},
+		{
+			"ShouldErrMalformed",
+			`{"url":"https://example.com/","expected":"bypass"}` + "\n" + `{"url":`,
+			nil,
+			"line 2: unexpected EOF",
+		},
+		{
+			"ShouldErrInvalidExpected",
+			`{"url":"https://example.com/"}`,
+			nil,
+			"line 1: the expected policy '' is not valid, must be one of 'bypass', 'one_factor', 'two_factor', or 'deny'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := parseAccessControlCheckCasesJSONL(strings.NewReader(tc.have))
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, actual)
+			} else {
+				assert.EqualError(t, err, tc.err)
+				assert.Nil(t, actual)
+			}
+		})
+	}
+}
+
+func TestLoadAccessControlCheckCases(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cases.csv"), []byte("url,expected\nhttps://example.com/,deny\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cases.jsonl"), []byte(`{"url":"https://example.com/","expected":"deny"}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cases.txt"), []byte(""), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "empty.csv"), []byte("url,expected\n"), 0600))
+
+	expected := []accessControlCheckCase{{URL: "https://example.com/", Method: "GET", Expected: "deny"}}
+
+	cases, err := loadAccessControlCheckCases(filepath.Join(dir, "cases.csv"))
+	assert.NoError(t, err)
+	require.Len(t, cases, 1)
+
+	cases[0].Line = 0
+	assert.Equal(t, expected, cases)
+
+	cases, err = loadAccessControlCheckCases(filepath.Join(dir, "cases.jsonl"))
+	assert.NoError(t, err)
+	require.Len(t, cases, 1)
+
+	cases[0].Line = 0
+	assert.Equal(t, expected, cases)
+
+	_, err = loadAccessControlCheckCases(filepath.Join(dir, "cases.txt"))
+	assert.EqualError(t, err, "failed to read the test cases file '"+filepath.Join(dir, "cases.txt")+"': the extension '.txt' is not supported, must be one of '.csv', '.jsonl', '.ndjson', or '.json'")
+
+	_, err = loadAccessControlCheckCases(filepath.Join(dir, "empty.csv"))
+	assert.EqualError(t, err, "failed to parse the test cases file '"+filepath.Join(dir, "empty.csv")+"': the file does not contain any test cases")
+}
+
+func TestParseAccessControlCheckTime(t *testing.T) {
+	actual, err := parseAccessControlCheckTime("2026-10-18T09:30:00+10:00")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 18, 9, 30, 0, 0, time.FixedZone("", 10*60*60)).Unix(), actual.Unix())
+
+	actual, err = parseAccessControlCheckTime("2026-10-18 09:30")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 18, 9, 30, 0, 0, time.Local), actual)
+
+	_, err = parseAccessControlCheckTime("abc")
+	assert.EqualError(t, err, "failed to parse the time 'abc': must be in the RFC3339 or 'YYYY-MM-DD HH:MM' format")
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration"
 )
 
@@ -70,13 +71,22 @@ func newConfigValidateCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 		DisableAutoGenTag: true,
 	}
 
+	cmd.Flags().Bool(cmdFlagNameLintAccessControl, false, "includes the access control rule analysis of the 'authelia access-control lint' command as warnings")
+
 	return cmd
 }
 
 // ConfigValidateRunE is the RunE for the authelia validate-config command.
-func (ctx *CmdCtx) ConfigValidateRunE(_ *cobra.Command, _ []string) (err error) {
+func (ctx *CmdCtx) ConfigValidateRunE(cmd *cobra.Command, _ []string) (err error) {
 	var isError bool
 
+	// The flag is only defined for the config validate command so the error is intentionally ignored.
+	if lint, _ := cmd.Flags().GetBool(cmdFlagNameLintAccessControl); lint {
+		for _, issue := range authorization.LintAccessControl(ctx.config.AccessControl) {
+			ctx.cconfig.validator.PushWarning(fmt.Errorf("access_control: %s (%s)", issue, issue.Suggestion))
+		}
+	}
+
 	buf := &bytes.Buffer{}
 
 	switch {
@@ -77,12 +77,69 @@ Notes:
 
 	A rule that potentially matches a request will cause a redirection to occur in order to perform one-factor
 	authentication. This is so Authelia can adequately determine if the rule actually matches.
+
+	Rules with a schedule are evaluated against the current time unless the --time flag is provided.
+
+	When GeoIP databases are configured the country, continent, and autonomous system of the --ip are resolved
+	and displayed.
+
+	The --trace flag prints every field of the match result of every rule i.e. whether the rule was skipped, each
+	individual criteria result, and whether the rule is a match or a potential match.
+
+Test Cases:
+
+	The --file flag checks every request in a CSV (.csv) or JSON Lines (.jsonl, .ndjson, or .json) file against
+	the expected policy instead of a single request. Each request which doesn't have the expected policy is reported
+	and the command exits with a non-zero status if any of them fail, which makes it suitable for CI.
+
+	Each test case has the url and expected fields, and the optional method, username, groups, emails, ip, headers,
+	attributes, and time fields which have the same meaning as the flags. The expected field is one of bypass, one_factor,
+	two_factor, or deny. The --time flag is used for cases without a time.
+
+	CSV files must have a header row naming the columns. The groups and emails columns are comma separated lists,
+	each column named header is a header in the 'Name: value' format, and each column named attribute is an
+	attribute in the 'name=value' format:
+
+		url,method,username,groups,header,expected
+		https://public.example.com/,GET,,,,bypass
+		https://admin.example.com/,GET,john,"admins,dev",X-Api-Version: 2,two_factor
+
+	JSON Lines files have one JSON object per line, blank lines and lines starting with # are ignored:
+
+		{"url":"https://public.example.com/","expected":"bypass"}
+		{"url":"https://admin.example.com/","username":"john","groups":["admins","dev"],"headers":["X-Api-Version: 2"],"expected":"two_factor"}
 `
 	cmdAutheliaAccessControlCheckPolicyExample = `authelia access-control check-policy --config config.yml --url https://example.com
 authelia access-control check-policy --config config.yml --url https://example.com --username john
 authelia access-control check-policy --config config.yml --url https://example.com --groups admin,public
 authelia access-control check-policy --config config.yml --url https://example.com --username john --method GET
-authelia access-control check-policy --config config.yml --url https://example.com --username john --method GET --verbose`
+authelia access-control check-policy --config config.yml --url https://example.com --username john --method GET --verbose
+authelia access-control check-policy --config config.yml --url https://example.com --time 2026-10-18T09:30:00+10:00
+authelia access-control check-policy --config config.yml --url https://example.com --time "2026-10-18 09:30"
+authelia access-control check-policy --config config.yml --url https://example.com --header "X-Api-Version: 2"
+authelia access-control check-policy --config config.yml --url https://example.com --username john --emails john@example.com --attribute department=finance
+authelia access-control check-policy --config config.yml --url https://example.com --username john --trace
+authelia access-control check-policy --config config.yml --file access-control-tests.csv
+authelia access-control check-policy --config config.yml --file access-control-tests.jsonl --trace`
+
+	cmdAutheliaAccessControlLintShort = "Analyses the access control rules for rules which are unreachable or likely misconfigured"
+
+	cmdAutheliaAccessControlLintLong = `
+Analyses the access control rules for rules which are unreachable or likely misconfigured.
+
+The following issues are reported along with the rule position and a suggestion:
+
+	unreachable                 The rule can never match as an earlier rule matches every request it would match.
+	duplicate                   The rule has the same criteria as an earlier rule.
+	broad-regex                 A domain or resource regex matches any value or is not anchored.
+	bypass-before-two-factor    A two_factor rule without subjects follows a bypass rule which matches all of its domains.
+	undefined-network           A network is not defined and is not an IP address, CIDR, or GeoIP network.
+
+The analysis is conservative so a rule is only reported as unreachable when it's certain. The command exits with a
+non-zero exit code if any issues are found.
+`
+
+	cmdAutheliaAccessControlLintExample = `authelia access-control lint --config config.yml`
 
 	cmdAutheliaStorageShort = "Manage the Authelia storage"
 
@@ -185,6 +242,107 @@ This subcommand allows manually adding an opaque identifier for a user to the da
 authelia storage user identifiers add john --identifier f0919359-9d15-4e15-bcba-83b41620a073 --config config.yml
 authelia storage user identifiers add john --identifier f0919359-9d15-4e15-bcba-83b41620a073 --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
 
+	cmdAutheliaStorageUserAccountsShort = "Manage users of the SQL authentication backend"
+
+	cmdAutheliaStorageUserAccountsLong = `Manage users of the SQL authentication backend.
+
+This subcommand allows adding, disabling, enabling, and deleting users stored in the database for the SQL authentication backend.`
+
+	cmdAutheliaStorageUserAccountsExample = `authelia storage user accounts --help`
+
+	cmdAutheliaStorageUserAccountsAddShort = "Add a user to the database"
+
+	cmdAutheliaStorageUserAccountsAddLong = `Add a user to the database.
+
+This subcommand allows adding a user to the database for the SQL authentication backend. The password is hashed using
+the password options configured for the SQL authentication backend or the defaults if it's not configured.`
+
+	cmdAutheliaStorageUserAccountsAddExample = `authelia storage user accounts add john --display-name "John Doe" --email john.doe@example.com --groups admins,dev
+authelia storage user accounts add john --display-name "John Doe" --email john.doe@example.com --password apple123
+authelia storage user accounts add john --display-name "John Doe" --config config.yml
+authelia storage user accounts add john --display-name "John Doe" --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageUserAccountsDisableShort = "Disable a user in the database"
+
+	cmdAutheliaStorageUserAccountsDisableLong = `Disable a user in the database.
+
+This subcommand allows disabling a user in the database for the SQL authentication backend which prevents them from
+signing in without removing them.`
+
+	cmdAutheliaStorageUserAccountsDisableExample = `authelia storage user accounts disable john
+authelia storage user accounts disable john --config config.yml
+authelia storage user accounts disable john --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageUserAccountsEnableShort = "Enable a user in the database"
+
+	cmdAutheliaStorageUserAccountsEnableLong = `Enable a user in the database.
+
+This subcommand allows enabling a user in the database for the SQL authentication backend which was previously disabled.`
+
+	cmdAutheliaStorageUserAccountsEnableExample = `authelia storage user accounts enable john
+authelia storage user accounts enable john --config config.yml
+authelia storage user accounts enable john --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageUserAccountsDeleteShort = "Delete a user from the database"
+
+	cmdAutheliaStorageUserAccountsDeleteLong = `Delete a user from the database.
+
+This subcommand allows deleting a user and their group memberships from the database for the SQL authentication backend.`
+
+	cmdAutheliaStorageUserAccountsDeleteExample = `authelia storage user accounts delete john
+authelia storage user accounts delete john --config config.yml
+authelia storage user accounts delete john --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageOIDCShort = "Manage OpenID Connect 1.0 data"
+
+	cmdAutheliaStorageOIDCLong = `Manage OpenID Connect 1.0 data.
+
+This subcommand allows interacting with the OpenID Connect 1.0 data stored in the database.`
+
+	cmdAutheliaStorageOIDCExample = `authelia storage oidc --help`
+
+	cmdAutheliaStorageOIDCClientsShort = "Manage dynamically registered OpenID Connect 1.0 clients"
+
+	cmdAutheliaStorageOIDCClientsLong = `Manage dynamically registered OpenID Connect 1.0 clients.
+
+This subcommand allows listing, showing, and deleting the OpenID Connect 1.0 clients which were registered using
+the Dynamic Client Registration endpoint. Clients configured statically in the configuration are not stored in the
+database and are not shown.`
+
+	cmdAutheliaStorageOIDCClientsExample = `authelia storage oidc clients --help`
+
+	cmdAutheliaStorageOIDCClientsListShort = "List dynamically registered OpenID Connect 1.0 clients"
+
+	cmdAutheliaStorageOIDCClientsListLong = `List dynamically registered OpenID Connect 1.0 clients.
+
+This subcommand allows listing the OpenID Connect 1.0 clients registered using the Dynamic Client Registration endpoint.`
+
+	cmdAutheliaStorageOIDCClientsListExample = `authelia storage oidc clients list
+authelia storage oidc clients list --config config.yml
+authelia storage oidc clients list --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageOIDCClientsShowShort = "Show a dynamically registered OpenID Connect 1.0 client"
+
+	cmdAutheliaStorageOIDCClientsShowLong = `Show a dynamically registered OpenID Connect 1.0 client.
+
+This subcommand allows showing the registered metadata of an OpenID Connect 1.0 client registered using the Dynamic
+Client Registration endpoint.`
+
+	cmdAutheliaStorageOIDCClientsShowExample = `authelia storage oidc clients show 6b0b2c4e-a4a0-4b5e-9d5b-0d8e5a0a9a3f
+authelia storage oidc clients show 6b0b2c4e-a4a0-4b5e-9d5b-0d8e5a0a9a3f --config config.yml
+authelia storage oidc clients show 6b0b2c4e-a4a0-4b5e-9d5b-0d8e5a0a9a3f --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageOIDCClientsDeleteShort = "Delete a dynamically registered OpenID Connect 1.0 client"
+
+	cmdAutheliaStorageOIDCClientsDeleteLong = `Delete a dynamically registered OpenID Connect 1.0 client.
+
+This subcommand allows deleting an OpenID Connect 1.0 client registered using the Dynamic Client Registration endpoint
+which immediately prevents it from being used.`
+
+	cmdAutheliaStorageOIDCClientsDeleteExample = `authelia storage oidc clients delete 6b0b2c4e-a4a0-4b5e-9d5b-0d8e5a0a9a3f
+authelia storage oidc clients delete 6b0b2c4e-a4a0-4b5e-9d5b-0d8e5a0a9a3f --config config.yml
+authelia storage oidc clients delete 6b0b2c4e-a4a0-4b5e-9d5b-0d8e5a0a9a3f --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
 	cmdAutheliaStorageUserWebAuthnShort = "Manage WebAuthn credentials"
 
 	cmdAutheliaStorageUserWebAuthnLong = `Manage WebAuthn credentials.
@@ -427,7 +585,8 @@ This subcommand allows validation of the YAML and Environment configurations so
 prior to deploying it.`
 
 	cmdAutheliaConfigValidateExample = `authelia config validate
-authelia config validate --config config.yml`
+authelia config validate --config config.yml
+authelia config validate --config config.yml --lint-access-control`
 
 	cmdAutheliaConfigValidateLegacyExample = `authelia validate-config
 authelia validate-config --config config.yml`
@@ -558,14 +717,129 @@ This subcommand allows generating an %s key pair.`
 	cmdAutheliaCryptoPairEd25519GenerateExample = `authelia crypto pair ed25519 generate --help`
 )
 
+const (
+	cmdAutheliaUsersShort = "Manage users of the file authentication backend"
+
+	cmdAutheliaUsersLong = `Manage users of the file authentication backend.
+
+This subcommand allows managing the users in the users database file used by the file authentication backend. The
+file path and password options are loaded from the configuration but the path can be overridden with the --file flag.
+
+Changes retain the comments and ordering of the existing file and are validated before the file is written.`
+
+	cmdAutheliaUsersExample = `authelia users --help`
+
+	cmdAutheliaUsersListShort = "List the users in the users database"
+
+	cmdAutheliaUsersListLong = `List the users in the users database.
+
+This subcommand allows listing the users in the users database along with their details.`
+
+	cmdAutheliaUsersListExample = `authelia users list
+authelia users list --config config.yml
+authelia users list --file users_database.yml`
+
+	cmdAutheliaUsersAddShort = "Add a user to the users database"
+
+	cmdAutheliaUsersAddLong = `Add a user to the users database.
+
+This subcommand allows adding a user to the users database. The password is hashed using the password options
+configured for the file authentication backend or the defaults if it's not configured.`
+
+	cmdAutheliaUsersAddExample = `authelia users add john --display-name "John Doe" --email john.doe@example.com --groups admins,dev
+authelia users add john --display-name "John Doe" --password apple123
+authelia users add john --display-name "John Doe" --random
+authelia users add john --display-name "John Doe" --config config.yml
+authelia users add john --display-name "John Doe" --file users_database.yml`
+
+	cmdAutheliaUsersDeleteShort = "Delete a user from the users database"
+
+	cmdAutheliaUsersDeleteLong = `Delete a user from the users database.
+
+This subcommand allows deleting a user from the users database.`
+
+	cmdAutheliaUsersDeleteExample = `authelia users delete john
+authelia users delete john --config config.yml
+authelia users delete john --file users_database.yml`
+
+	cmdAutheliaUsersDisableShort = "Disable a user in the users database"
+
+	cmdAutheliaUsersDisableLong = `Disable a user in the users database.
+
+This subcommand allows disabling a user in the users database which prevents them from signing in without removing
+them.`
+
+	cmdAutheliaUsersDisableExample = `authelia users disable john
+authelia users disable john --config config.yml
+authelia users disable john --file users_database.yml`
+
+	cmdAutheliaUsersEnableShort = "Enable a user in the users database"
+
+	cmdAutheliaUsersEnableLong = `Enable a user in the users database.
+
+This subcommand allows enabling a user in the users database which was previously disabled.`
+
+	cmdAutheliaUsersEnableExample = `authelia users enable john
+authelia users enable john --config config.yml
+authelia users enable john --file users_database.yml`
+
+	cmdAutheliaUsersSetPasswordShort = "Set the password of a user in the users database"
+
+	cmdAutheliaUsersSetPasswordLong = `Set the password of a user in the users database.
+
+This subcommand allows setting the password of a user in the users database. The password is hashed using the password
+options configured for the file authentication backend or the defaults if it's not configured.`
+
+	cmdAutheliaUsersSetPasswordExample = `authelia users set-password john
+authelia users set-password john --password apple123
+authelia users set-password john --random
+authelia users set-password john --config config.yml
+authelia users set-password john --file users_database.yml`
+
+	cmdAutheliaUsersAddGroupShort = "Add a user to one or more groups in the users database"
+
+	cmdAutheliaUsersAddGroupLong = `Add a user to one or more groups in the users database.
+
+This subcommand allows adding a user to one or more groups in the users database. Groups the user is already a member
+of are ignored.`
+
+	cmdAutheliaUsersAddGroupExample = `authelia users add-group john admins
+authelia users add-group john admins dev
+authelia users add-group john admins --config config.yml
+authelia users add-group john admins --file users_database.yml`
+
+	cmdAutheliaUsersRemoveGroupShort = "Remove a user from one or more groups in the users database"
+
+	cmdAutheliaUsersRemoveGroupLong = `Remove a user from one or more groups in the users database.
+
+This subcommand allows removing a user from one or more groups in the users database. Groups the user is not a member
+of are ignored.`
+
+	cmdAutheliaUsersRemoveGroupExample = `authelia users remove-group john admins
+authelia users remove-group john admins dev
+authelia users remove-group john admins --config config.yml
+authelia users remove-group john admins --file users_database.yml`
+)
+
 const (
 	storageMigrateDirectionUp   = "up"
 	storageMigrateDirectionDown = "down"
 )
 
+const (
+	extCSV    = ".csv"
+	extJSONL  = ".jsonl"
+	extNDJSON = ".ndjson"
+	extJSON   = ".json"
+)
+
 const (
 	cmdFlagNameDirectory = "directory"
 
+	cmdFlagNameLintAccessControl = "lint-access-control"
+
+	cmdFlagNameTrace = "trace"
+
 	cmdFlagNamePathCA  = "path.ca"
 	cmdFlagNameBundles = "bundles"
 	cmdFlagNameLegacy  = "legacy"
@@ -645,6 +919,10 @@ const (
 	cmdFlagNameSector      = "sector"
 	cmdFlagNameDescription = "description"
 	cmdFlagNameAll         = "all"
+	cmdFlagNameDisplayName = "display-name"
+	cmdFlagNameDisabled    = "disabled"
+	cmdFlagNameEmail       = "email"
+	cmdFlagNameGroups      = "groups"
 	cmdFlagNameKeyID       = "kid"
 	cmdFlagNameVerbose     = "verbose"
 	cmdFlagNameSecret      = "secret"
@@ -848,10 +1126,14 @@ const (
 	logFieldProvider            = "provider"
 	logMessageStartupCheckError = "Error occurred running a startup check"
 
-	providerNameNTP          = "ntp"
-	providerNameStorage      = "storage"
-	providerNameUser         = "user"
-	providerNameNotification = "notification"
+	providerNameNTP               = "ntp"
+	providerNameStorage           = "storage"
+	providerNameUser              = "user"
+	providerNameNotification      = "notification"
+	providerNameKerberos          = "kerberos"
+	providerNameClientCertificate = "client_certificate"
+	providerNameGeoIP             = "geoip"
+	providerNameAudit             = "audit"
 )
 
 const (
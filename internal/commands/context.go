@@ -15,12 +15,16 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"github.com/authelia/authelia/v4/internal/audit"
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/clientcert"
 	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/configuration/validator"
+	"github.com/authelia/authelia/v4/internal/geoip"
+	"github.com/authelia/authelia/v4/internal/kerberos"
 	"github.com/authelia/authelia/v4/internal/logging"
 	"github.com/authelia/authelia/v4/internal/metrics"
 	"github.com/authelia/authelia/v4/internal/middlewares"
@@ -72,12 +76,25 @@ func NewCmdCtxConfig() *CmdCtxConfig {
 
 // CmdCtxConfig is the configuration for the CmdCtx.
 type CmdCtxConfig struct {
-	files     []string
-	filters   []string
-	defaults  configuration.Source
-	sources   []configuration.Source
-	keys      []string
-	validator *schema.StructValidator
+	files      []string
+	filters    []string
+	bytes      []configuration.BytesFilter
+	defaults   configuration.Source
+	additional []configuration.Source
+	sources    []configuration.Source
+	keys       []string
+	validator  *schema.StructValidator
+}
+
+// NewSources returns new instances of the sources the configuration was loaded from so it can be loaded again.
+func (c *CmdCtxConfig) NewSources() (sources []configuration.Source) {
+	return configuration.NewDefaultSourcesWithDefaults(
+		c.files,
+		c.bytes,
+		configuration.DefaultEnvPrefix,
+		configuration.DefaultEnvDelimiter,
+		c.defaults,
+		c.additional...)
 }
 
 // CobraRunECmd describes a function that can be used as a *cobra.Command RunE, PreRunE, or PostRunE.
@@ -143,20 +160,42 @@ func (ctx *CmdCtx) LoadProviders() (warns, errs []error) {
 
 	ctx.providers.StorageProvider = getStorageProvider(ctx)
 
-	ctx.providers.Authorizer = authorization.NewAuthorizer(ctx.config)
+	if ctx.config.AccessControl.GeoIP.CountryDatabase != "" || ctx.config.AccessControl.GeoIP.ASNDatabase != "" {
+		ctx.providers.GeoIP = geoip.NewProvider(&ctx.config.AccessControl.GeoIP)
+	}
+
+	ctx.providers.Authorizer = authorization.NewAuthorizer(ctx.config, clock.New(), ctx.providers.GeoIP)
 	ctx.providers.NTP = ntp.NewProvider(&ctx.config.NTP)
 	ctx.providers.PasswordPolicy = middlewares.NewPasswordPolicyProvider(ctx.config.PasswordPolicy)
 	ctx.providers.Regulator = regulation.NewRegulator(ctx.config.Regulation, ctx.providers.StorageProvider, clock.New())
 	ctx.providers.SessionProvider = session.NewProvider(ctx.config.Session, ctx.trusted)
 	ctx.providers.TOTP = totp.NewTimeBasedProvider(ctx.config.TOTP)
 
+	if ctx.config.Telemetry.Metrics.Enabled {
+		ctx.providers.Metrics = metrics.NewPrometheus()
+	}
+
+	ctx.providers.Audit = audit.NewProvider(&ctx.config.AuditLog, ctx.trusted)
+
 	var err error
 
 	switch {
 	case ctx.config.AuthenticationBackend.File != nil:
 		ctx.providers.UserProvider = authentication.NewFileUserProvider(ctx.config.AuthenticationBackend.File)
 	case ctx.config.AuthenticationBackend.LDAP != nil:
-		ctx.providers.UserProvider = authentication.NewLDAPUserProvider(ctx.config.AuthenticationBackend, ctx.trusted)
+		ctx.providers.UserProvider = authentication.NewLDAPUserProvider(ctx.config.AuthenticationBackend, ctx.trusted, ctx.providers.Metrics)
+	case ctx.config.AuthenticationBackend.SQL != nil:
+		ctx.providers.UserProvider = authentication.NewSQLUserProvider(ctx.config.AuthenticationBackend.SQL, ctx.providers.StorageProvider)
+	case ctx.config.AuthenticationBackend.Chain != nil:
+		ctx.providers.UserProvider = authentication.NewChainedUserProvider(ctx.config.AuthenticationBackend, ctx.trusted, ctx.providers.StorageProvider, ctx.providers.Metrics)
+	}
+
+	if ctx.config.AuthenticationBackend.Kerberos != nil {
+		ctx.providers.Kerberos = kerberos.NewProvider(ctx.config.AuthenticationBackend.Kerberos)
+	}
+
+	if ctx.config.AuthenticationBackend.ClientCertificate != nil {
+		ctx.providers.ClientCertificate = clientcert.NewProvider(ctx.config.AuthenticationBackend.ClientCertificate)
 	}
 
 	if ctx.providers.Templates, err = templates.New(templates.Config{EmailTemplatesPath: ctx.config.Notifier.TemplatePath}); err != nil {
@@ -172,10 +211,6 @@ func (ctx *CmdCtx) LoadProviders() (warns, errs []error) {
 
 	ctx.providers.OpenIDConnect = oidc.NewOpenIDConnectProvider(ctx.config.IdentityProviders.OIDC, ctx.providers.StorageProvider, ctx.providers.Templates)
 
-	if ctx.config.Telemetry.Metrics.Enabled {
-		ctx.providers.Metrics = metrics.NewPrometheus()
-	}
-
 	return warns, errs
 }
 
@@ -414,13 +449,9 @@ func (ctx *CmdCtx) HelperConfigLoadRunE(cmd *cobra.Command, _ []string) (err err
 		ctx.cconfig.filters[i] = filter.Name()
 	}
 
-	ctx.cconfig.sources = configuration.NewDefaultSourcesWithDefaults(
-		ctx.cconfig.files,
-		filters,
-		configuration.DefaultEnvPrefix,
-		configuration.DefaultEnvDelimiter,
-		ctx.cconfig.defaults,
-		ctx.cconfig.sources...)
+	ctx.cconfig.bytes = filters
+	ctx.cconfig.additional = ctx.cconfig.sources
+	ctx.cconfig.sources = ctx.cconfig.NewSources()
 
 	if ctx.cconfig.keys, err = configuration.LoadAdvanced(
 		ctx.cconfig.validator,
@@ -0,0 +1,250 @@
+package commands
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/configuration/validator"
+	"github.com/authelia/authelia/v4/internal/oidc"
+)
+
+// NewConfigurationReloader creates a new ConfigurationReloader which reloads the access control rules and the
+// OpenID Connect 1.0 clients from the configuration sources of the CmdCtx.
+func NewConfigurationReloader(ctx *CmdCtx) (reloader *ConfigurationReloader) {
+	reloader = &ConfigurationReloader{
+		cconfig:    ctx.cconfig,
+		config:     ctx.config,
+		trusted:    ctx.trusted,
+		authorizer: ctx.providers.Authorizer,
+		log:        ctx.log.WithFields(map[string]any{logFieldService: serviceTypeWatcher, serviceTypeWatcher: "configuration"}),
+	}
+
+	if ctx.providers.OpenIDConnect != nil {
+		reloader.clients, _ = ctx.providers.OpenIDConnect.ClientStore.(*oidc.MemoryClientStore)
+	}
+
+	return reloader
+}
+
+// ConfigurationReloader is a ProviderReload which reloads the parts of the configuration which can be applied without
+// a restart.
+type ConfigurationReloader struct {
+	cconfig *CmdCtxConfig
+	config  *schema.Configuration
+	trusted *x509.CertPool
+
+	authorizer *authorization.Authorizer
+	clients    *oidc.MemoryClientStore
+
+	log *logrus.Entry
+
+	mu sync.Mutex
+}
+
+// Reload the configuration, validate it, and apply any changes to the access control rules and the OpenID Connect 1.0
+// clients. If the configuration is not valid the current configuration continues to be used.
+func (r *ConfigurationReloader) Reload() (reloaded bool, err error) {
+	r.mu.Lock()
+
+	defer r.mu.Unlock()
+
+	var config *schema.Configuration
+
+	if config, err = r.load(); err != nil {
+		return false, err
+	}
+
+	if err = r.check(config); err != nil {
+		return false, err
+	}
+
+	changes := diffAccessControl(r.config.AccessControl, config.AccessControl)
+
+	var clients []string
+
+	if r.clients != nil {
+		clients = diffOpenIDConnectClients(r.config.IdentityProviders.OIDC.Clients, config.IdentityProviders.OIDC.Clients)
+	}
+
+	if len(changes) == 0 && len(clients) == 0 {
+		return false, nil
+	}
+
+	for _, change := range changes {
+		r.log.Info(change)
+	}
+
+	for _, change := range clients {
+		r.log.Info(change)
+	}
+
+	r.authorizer.Update(config)
+
+	if len(clients) != 0 {
+		r.clients.Update(config.IdentityProviders.OIDC)
+	}
+
+	r.config = config
+
+	return true, nil
+}
+
+func (r *ConfigurationReloader) load() (config *schema.Configuration, err error) {
+	val := schema.NewStructValidator()
+
+	config = &schema.Configuration{}
+
+	var keys []string
+
+	if keys, err = configuration.LoadAdvanced(val, "", config, r.cconfig.NewSources()...); err != nil {
+		return nil, fmt.Errorf("error occurred loading the configuration: %w", err)
+	}
+
+	validator.ValidateKeys(keys, configuration.GetMultiKeyMappedDeprecationKeys(), configuration.DefaultEnvPrefix, val)
+
+	tc := &tls.Config{
+		RootCAs:    r.trusted,
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS13,
+	}
+
+	validator.ValidateConfiguration(config, val, validator.WithTLSConfig(tc))
+
+	errs := val.Errors()
+
+	if len(errs) == 0 {
+		return config, nil
+	}
+
+	for i, e := range errs {
+		if i == 0 {
+			err = e
+			continue
+		}
+
+		err = fmt.Errorf("%v, %w", err, e)
+	}
+
+	return nil, fmt.Errorf("errors occurred validating the configuration so the current configuration will continue to be used: %w", err)
+}
+
+// check returns an error if the configuration has changes which are relevant to the reload but can't be applied
+// without a restart.
+func (r *ConfigurationReloader) check(config *schema.Configuration) (err error) {
+	switch {
+	case r.config.AccessControl.GeoIP != config.AccessControl.GeoIP:
+		return fmt.Errorf("changes to the 'access_control.geoip' options require a restart so the current configuration will continue to be used")
+	case (r.config.IdentityProviders.OIDC == nil) != (config.IdentityProviders.OIDC == nil):
+		return fmt.Errorf("enabling or disabling the 'identity_providers.oidc' options requires a restart so the current configuration will continue to be used")
+	case !equalOpenIDConnectProvider(r.config.IdentityProviders.OIDC, config.IdentityProviders.OIDC):
+		return fmt.Errorf("changes to the 'identity_providers.oidc' options other than the 'clients', or changes to the 'clients' which affect the discovery metadata such as the algorithms they use, require a restart so the current configuration will continue to be used")
+	default:
+		return nil
+	}
+}
+
+// equalOpenIDConnectProvider returns true if two OpenID Connect 1.0 configurations only differ by their clients and
+// those differences don't affect the discovery metadata. The provider configuration, the issuer keys, and the
+// discovery metadata are only read when the provider is created so they can't be changed without a restart.
+func equalOpenIDConnectProvider(previous, current *schema.IdentityProvidersOpenIDConnect) bool {
+	if previous == nil || current == nil {
+		return previous == current
+	}
+
+	a, b := *previous, *current
+
+	a.Clients, b.Clients = nil, nil
+	a.Discovery, b.Discovery = sortedOpenIDConnectDiscovery(a.Discovery), sortedOpenIDConnectDiscovery(b.Discovery)
+
+	return reflect.DeepEqual(a, b)
+}
+
+// sortedOpenIDConnectDiscovery returns a copy of the discovery metadata with the lists sorted, as the order of some of
+// them is determined by map iteration or the order of the clients.
+func sortedOpenIDConnectDiscovery(discovery schema.IdentityProvidersOpenIDConnectDiscovery) schema.IdentityProvidersOpenIDConnectDiscovery {
+	for _, values := range []*[]string{
+		&discovery.AuthorizationPolicies,
+		&discovery.Lifespans,
+		&discovery.ResponseObjectSigningKeyIDs,
+		&discovery.ResponseObjectSigningAlgs,
+		&discovery.RequestObjectSigningAlgs,
+	} {
+		*values = slices.Clone(*values)
+
+		slices.Sort(*values)
+	}
+
+	return discovery
+}
+
+// diffAccessControl returns a description of each change between two access control configurations.
+func diffAccessControl(previous, current schema.AccessControl) (changes []string) {
+	if previous.DefaultPolicy != current.DefaultPolicy {
+		changes = append(changes, fmt.Sprintf("Access control default policy changed from '%s' to '%s'", previous.DefaultPolicy, current.DefaultPolicy))
+	}
+
+	if !reflect.DeepEqual(previous.Networks, current.Networks) {
+		changes = append(changes, "Access control networks changed")
+	}
+
+	for i := 0; i < len(previous.Rules) || i < len(current.Rules); i++ {
+		switch {
+		case i >= len(previous.Rules):
+			changes = append(changes, fmt.Sprintf("Access control rule %s was added", diffRuleDescriptor(i+1, current.Rules[i])))
+		case i >= len(current.Rules):
+			changes = append(changes, fmt.Sprintf("Access control rule %s was removed", diffRuleDescriptor(i+1, previous.Rules[i])))
+		case !reflect.DeepEqual(previous.Rules[i], current.Rules[i]):
+			changes = append(changes, fmt.Sprintf("Access control rule %s was changed", diffRuleDescriptor(i+1, current.Rules[i])))
+		}
+	}
+
+	return changes
+}
+
+// diffOpenIDConnectClients returns a description of each change between two lists of OpenID Connect 1.0 clients.
+func diffOpenIDConnectClients(previous, current []schema.IdentityProvidersOpenIDConnectClient) (changes []string) {
+	clients := make(map[string]schema.IdentityProvidersOpenIDConnectClient, len(previous))
+
+	for _, client := range previous {
+		clients[client.ID] = client
+	}
+
+	for _, client := range current {
+		existing, ok := clients[client.ID]
+
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("OpenID Connect 1.0 client '%s' was added", client.ID))
+		case !reflect.DeepEqual(existing, client):
+			changes = append(changes, fmt.Sprintf("OpenID Connect 1.0 client '%s' was changed", client.ID))
+		}
+
+		delete(clients, client.ID)
+	}
+
+	for _, client := range previous {
+		if _, ok := clients[client.ID]; ok {
+			changes = append(changes, fmt.Sprintf("OpenID Connect 1.0 client '%s' was removed", client.ID))
+		}
+	}
+
+	return changes
+}
+
+func diffRuleDescriptor(position int, rule schema.AccessControlRule) string {
+	if len(rule.Domains) == 0 {
+		return fmt.Sprintf("#%d", position)
+	}
+
+	return fmt.Sprintf("#%d (domain '%s')", position, strings.Join(rule.Domains, ","))
+}
@@ -0,0 +1,190 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestDiffAccessControl(t *testing.T) {
+	testCases := []struct {
+		name     string
+		previous schema.AccessControl
+		current  schema.AccessControl
+		expected []string
+	}{
+		{
+			"ShouldNotDiffEqual",
+			schema.AccessControl{DefaultPolicy: "deny", Rules: []schema.AccessControlRule{{Domains: []string{"example.com"}, Policy: "one_factor"}}},
+			schema.AccessControl{DefaultPolicy: "deny", Rules: []schema.AccessControlRule{{Domains: []string{"example.com"}, Policy: "one_factor"}}},
+			nil,
+		},
+		{
+			"ShouldDiffDefaultPolicy",
+			schema.AccessControl{DefaultPolicy: "deny"},
+			schema.AccessControl{DefaultPolicy: "two_factor"},
+			[]string{"Access control default policy changed from 'deny' to 'two_factor'"},
+		},
+		{
+			"ShouldDiffNetworks",
+			schema.AccessControl{DefaultPolicy: "deny", Networks: []schema.AccessControlNetwork{{Name: "internal", Networks: []string{"10.0.0.0/8"}}}},
+			schema.AccessControl{DefaultPolicy: "deny", Networks: []schema.AccessControlNetwork{{Name: "internal", Networks: []string{"192.168.0.0/16"}}}},
+			[]string{"Access control networks changed"},
+		},
+		{
+			"ShouldDiffRules",
+			schema.AccessControl{
+				DefaultPolicy: "deny",
+				Rules: []schema.AccessControlRule{
+					{Domains: []string{"example.com"}, Policy: "one_factor"},
+					{Domains: []string{"admin.example.com"}, Policy: "two_factor"},
+					{Policy: "bypass"},
+				},
+			},
+			schema.AccessControl{
+				DefaultPolicy: "deny",
+				Rules: []schema.AccessControlRule{
+					{Domains: []string{"example.com"}, Policy: "one_factor"},
+					{Domains: []string{"admin.example.com"}, Policy: "deny"},
+				},
+			},
+			[]string{
+				"Access control rule #2 (domain 'admin.example.com') was changed",
+				"Access control rule #3 was removed",
+			},
+		},
+		{
+			"ShouldDiffAddedRules",
+			schema.AccessControl{DefaultPolicy: "deny"},
+			schema.AccessControl{
+				DefaultPolicy: "deny",
+				Rules: []schema.AccessControlRule{
+					{Domains: []string{"example.com", "*.example.com"}, Policy: "one_factor"},
+				},
+			},
+			[]string{"Access control rule #1 (domain 'example.com,*.example.com') was added"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, diffAccessControl(tc.previous, tc.current))
+		})
+	}
+}
+
+func TestDiffOpenIDConnectClients(t *testing.T) {
+	previous := []schema.IdentityProvidersOpenIDConnectClient{
+		{ID: "one", AuthorizationPolicy: "one_factor"},
+		{ID: "two", AuthorizationPolicy: "two_factor"},
+		{ID: "three", AuthorizationPolicy: "two_factor"},
+	}
+
+	assert.Nil(t, diffOpenIDConnectClients(previous, previous))
+
+	current := []schema.IdentityProvidersOpenIDConnectClient{
+		{ID: "four", AuthorizationPolicy: "one_factor"},
+		{ID: "two", AuthorizationPolicy: "one_factor"},
+		{ID: "three", AuthorizationPolicy: "two_factor"},
+	}
+
+	assert.Equal(t, []string{
+		"OpenID Connect 1.0 client 'four' was added",
+		"OpenID Connect 1.0 client 'two' was changed",
+		"OpenID Connect 1.0 client 'one' was removed",
+	}, diffOpenIDConnectClients(previous, current))
+}
+
+func TestConfigurationReloaderCheck(t *testing.T) {
+	reloader := &ConfigurationReloader{
+		config: &schema.Configuration{},
+	}
+
+	assert.NoError(t, reloader.check(&schema.Configuration{}))
+
+	assert.EqualError(t, reloader.check(&schema.Configuration{
+		AccessControl: schema.AccessControl{GeoIP: schema.AccessControlGeoIP{CountryDatabase: "/config/country.mmdb"}},
+	}), "changes to the 'access_control.geoip' options require a restart so the current configuration will continue to be used")
+
+	assert.EqualError(t, reloader.check(&schema.Configuration{
+		IdentityProviders: schema.IdentityProviders{OIDC: &schema.IdentityProvidersOpenIDConnect{}},
+	}), "enabling or disabling the 'identity_providers.oidc' options requires a restart so the current configuration will continue to be used")
+
+	reloader.config = &schema.Configuration{
+		IdentityProviders: schema.IdentityProviders{OIDC: &schema.IdentityProvidersOpenIDConnect{
+			HMACSecret:  "secret",
+			JSONWebKeys: []schema.JWK{{KeyID: "one", Algorithm: "RS256"}},
+			Clients:     []schema.IdentityProvidersOpenIDConnectClient{{ID: "one"}},
+			Discovery: schema.IdentityProvidersOpenIDConnectDiscovery{
+				AuthorizationPolicies:     []string{"one_factor", "two_factor", "alpha", "beta"},
+				ResponseObjectSigningAlgs: []string{"RS256"},
+			},
+		}},
+	}
+
+	assert.NoError(t, reloader.check(&schema.Configuration{
+		IdentityProviders: schema.IdentityProviders{OIDC: &schema.IdentityProvidersOpenIDConnect{
+			HMACSecret:  "secret",
+			JSONWebKeys: []schema.JWK{{KeyID: "one", Algorithm: "RS256"}},
+			Clients:     []schema.IdentityProvidersOpenIDConnectClient{{ID: "one"}, {ID: "two"}},
+			Discovery: schema.IdentityProvidersOpenIDConnectDiscovery{
+				AuthorizationPolicies:     []string{"one_factor", "two_factor", "beta", "alpha"},
+				ResponseObjectSigningAlgs: []string{"RS256"},
+			},
+		}},
+	}))
+
+	testCases := []struct {
+		name string
+		have *schema.IdentityProvidersOpenIDConnect
+	}{
+		{
+			"ShouldRejectIssuerKeyChange",
+			&schema.IdentityProvidersOpenIDConnect{
+				HMACSecret:  "secret",
+				JSONWebKeys: []schema.JWK{{KeyID: "two", Algorithm: "ES256"}},
+				Clients:     []schema.IdentityProvidersOpenIDConnectClient{{ID: "one"}},
+				Discovery: schema.IdentityProvidersOpenIDConnectDiscovery{
+					AuthorizationPolicies:     []string{"one_factor", "two_factor", "alpha", "beta"},
+					ResponseObjectSigningAlgs: []string{"ES256"},
+				},
+			},
+		},
+		{
+			"ShouldRejectDynamicClientRegistrationChange",
+			&schema.IdentityProvidersOpenIDConnect{
+				HMACSecret:                "secret",
+				JSONWebKeys:               []schema.JWK{{KeyID: "one", Algorithm: "RS256"}},
+				Clients:                   []schema.IdentityProvidersOpenIDConnectClient{{ID: "one"}},
+				DynamicClientRegistration: schema.IdentityProvidersOpenIDConnectDynamicClientRegistration{Enabled: true},
+				Discovery: schema.IdentityProvidersOpenIDConnectDiscovery{
+					AuthorizationPolicies:     []string{"one_factor", "two_factor", "alpha", "beta"},
+					ResponseObjectSigningAlgs: []string{"RS256"},
+				},
+			},
+		},
+		{
+			"ShouldRejectClientDiscoveryChange",
+			&schema.IdentityProvidersOpenIDConnect{
+				HMACSecret:  "secret",
+				JSONWebKeys: []schema.JWK{{KeyID: "one", Algorithm: "RS256"}},
+				Clients:     []schema.IdentityProvidersOpenIDConnectClient{{ID: "one"}},
+				Discovery: schema.IdentityProvidersOpenIDConnectDiscovery{
+					AuthorizationPolicies:     []string{"one_factor", "two_factor", "alpha", "beta"},
+					ResponseObjectSigningAlgs: []string{"RS256"},
+					RequestObjectSigningAlgs:  []string{"ES256"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.EqualError(t, reloader.check(&schema.Configuration{
+				IdentityProviders: schema.IdentityProviders{OIDC: tc.have},
+			}), "changes to the 'identity_providers.oidc' options other than the 'clients', or changes to the 'clients' which affect the discovery metadata such as the algorithms they use, require a restart so the current configuration will continue to be used")
+		})
+	}
+}
@@ -46,6 +46,7 @@ func NewRootCmd() (cmd *cobra.Command) {
 		newBuildInfoCmd(ctx),
 		newCryptoCmd(ctx),
 		newStorageCmd(ctx),
+		newUsersCmd(ctx),
 		newConfigCmd(ctx),
 		newConfigValidateLegacyCmd(ctx),
 
@@ -122,6 +123,54 @@ func doStartupChecks(ctx *CmdCtx) {
 		ctx.log.WithFields(map[string]any{logFieldProvider: providerNameUser}).Trace("Startup Check Completed Successfully")
 	}
 
+	if ctx.providers.Kerberos != nil {
+		ctx.log.WithFields(map[string]any{logFieldProvider: providerNameKerberos}).Trace("Performing Startup Check")
+
+		if err = doStartupCheck(ctx, providerNameKerberos, ctx.providers.Kerberos, false); err != nil {
+			ctx.log.WithError(err).WithField(logFieldProvider, providerNameKerberos).Error(logMessageStartupCheckError)
+
+			failures = append(failures, providerNameKerberos)
+		} else {
+			ctx.log.WithFields(map[string]any{logFieldProvider: providerNameKerberos}).Trace("Startup Check Completed Successfully")
+		}
+	}
+
+	if ctx.providers.ClientCertificate != nil {
+		ctx.log.WithFields(map[string]any{logFieldProvider: providerNameClientCertificate}).Trace("Performing Startup Check")
+
+		if err = doStartupCheck(ctx, providerNameClientCertificate, ctx.providers.ClientCertificate, false); err != nil {
+			ctx.log.WithError(err).WithField(logFieldProvider, providerNameClientCertificate).Error(logMessageStartupCheckError)
+
+			failures = append(failures, providerNameClientCertificate)
+		} else {
+			ctx.log.WithFields(map[string]any{logFieldProvider: providerNameClientCertificate}).Trace("Startup Check Completed Successfully")
+		}
+	}
+
+	if ctx.providers.GeoIP != nil {
+		ctx.log.WithFields(map[string]any{logFieldProvider: providerNameGeoIP}).Trace("Performing Startup Check")
+
+		if err = doStartupCheck(ctx, providerNameGeoIP, ctx.providers.GeoIP, false); err != nil {
+			ctx.log.WithError(err).WithField(logFieldProvider, providerNameGeoIP).Error(logMessageStartupCheckError)
+
+			failures = append(failures, providerNameGeoIP)
+		} else {
+			ctx.log.WithFields(map[string]any{logFieldProvider: providerNameGeoIP}).Trace("Startup Check Completed Successfully")
+		}
+	}
+
+	if ctx.providers.Audit != nil {
+		ctx.log.WithFields(map[string]any{logFieldProvider: providerNameAudit}).Trace("Performing Startup Check")
+
+		if err = doStartupCheck(ctx, providerNameAudit, ctx.providers.Audit, false); err != nil {
+			ctx.log.WithError(err).WithField(logFieldProvider, providerNameAudit).Error(logMessageStartupCheckError)
+
+			failures = append(failures, providerNameAudit)
+		} else {
+			ctx.log.WithFields(map[string]any{logFieldProvider: providerNameAudit}).Trace("Startup Check Completed Successfully")
+		}
+	}
+
 	ctx.log.WithFields(map[string]any{logFieldProvider: providerNameNotification}).Trace("Performing Startup Check")
 
 	if err = doStartupCheck(ctx, providerNameNotification, ctx.providers.Notifier, ctx.config.Notifier.DisableStartupCheck); err != nil {
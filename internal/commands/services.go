@@ -3,6 +3,7 @@ package commands
 import (
 	"context"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"os/signal"
@@ -18,6 +19,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/geoip"
 	"github.com/authelia/authelia/v4/internal/server"
 )
 
@@ -286,6 +288,68 @@ func svcWatcherUsersFunc(ctx *CmdCtx) (service Service) {
 	return service
 }
 
+func svcWatcherGeoIPCountryFunc(ctx *CmdCtx) (service Service) {
+	if ctx.providers.GeoIP == nil {
+		return nil
+	}
+
+	return newGeoIPWatcherService(ctx, "geoip-country", ctx.providers.GeoIP.CountryDatabase())
+}
+
+func svcWatcherGeoIPASNFunc(ctx *CmdCtx) (service Service) {
+	if ctx.providers.GeoIP == nil {
+		return nil
+	}
+
+	return newGeoIPWatcherService(ctx, "geoip-asn", ctx.providers.GeoIP.ASNDatabase())
+}
+
+func newGeoIPWatcherService(ctx *CmdCtx, name string, database *geoip.Database) (service Service) {
+	if database == nil {
+		return nil
+	}
+
+	var err error
+
+	if service, err = NewFileWatcherService(name, database.Path(), database, ctx.log); err != nil {
+		ctx.log.WithError(err).Fatalf("Create Watcher Service (%s) returned error", name)
+	}
+
+	return service
+}
+
+// svcWatcherConfigurationFuncs returns a service func for each configuration path which watches the path for changes
+// and reloads the configuration using a shared ConfigurationReloader.
+func svcWatcherConfigurationFuncs(ctx *CmdCtx) (funcs []func(ctx *CmdCtx) Service) {
+	if ctx.cconfig == nil || len(ctx.cconfig.files) == 0 {
+		return nil
+	}
+
+	reloader := NewConfigurationReloader(ctx)
+
+	for i, path := range ctx.cconfig.files {
+		name := "configuration"
+
+		if len(ctx.cconfig.files) > 1 {
+			name = fmt.Sprintf("configuration-%d", i+1)
+		}
+
+		funcs = append(funcs, func(ctx *CmdCtx) (service Service) {
+			var err error
+
+			if service, err = NewFileWatcherService(name, path, reloader, ctx.log); err != nil {
+				ctx.log.WithError(err).Errorf("Create Watcher Service (%s) returned error so changes to the configuration will require a restart", name)
+
+				return nil
+			}
+
+			return service
+		})
+	}
+
+	return funcs
+}
+
 func connectionType(isTLS bool) string {
 	if isTLS {
 		return "TLS"
@@ -311,10 +375,14 @@ func servicesRun(ctx *CmdCtx) {
 		services []Service
 	)
 
-	for _, serviceFunc := range []func(ctx *CmdCtx) Service{
+	serviceFuncs := []func(ctx *CmdCtx) Service{
 		svcSvrMainFunc, svcSvrMetricsFunc,
-		svcWatcherUsersFunc,
-	} {
+		svcWatcherUsersFunc, svcWatcherGeoIPCountryFunc, svcWatcherGeoIPASNFunc,
+	}
+
+	serviceFuncs = append(serviceFuncs, svcWatcherConfigurationFuncs(ctx)...)
+
+	for _, serviceFunc := range serviceFuncs {
 		if service := serviceFunc(ctx); service != nil {
 			service.Log().Trace("Service Loaded")
 
@@ -363,6 +431,16 @@ func servicesRun(ctx *CmdCtx) {
 		ctx.log.WithError(err).Error("Error occurred closing database connections")
 	}
 
+	if closer, ok := ctx.providers.UserProvider.(io.Closer); ok {
+		if err = closer.Close(); err != nil {
+			ctx.log.WithError(err).Error("Error occurred closing the user provider")
+		}
+	}
+
+	if err = ctx.providers.Audit.Close(); err != nil {
+		ctx.log.WithError(err).Error("Error occurred closing the audit log")
+	}
+
 	if err = group.Wait(); err != nil {
 		ctx.log.WithError(err).Error("Error occurred waiting for shutdown")
 	}
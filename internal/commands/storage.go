@@ -52,6 +52,7 @@ func newStorageCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 		newStorageSchemaInfoCmd(ctx),
 		newStorageEncryptionCmd(ctx),
 		newStorageUserCmd(ctx),
+		newStorageOIDCCmd(ctx),
 	)
 
 	return cmd
@@ -122,6 +123,7 @@ func newStorageUserCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	}
 
 	cmd.AddCommand(
+		newStorageUserAccountsCmd(ctx),
 		newStorageUserIdentifiersCmd(ctx),
 		newStorageUserTOTPCmd(ctx),
 		newStorageUserWebAuthnCmd(ctx),
@@ -130,6 +132,94 @@ func newStorageUserCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	return cmd
 }
 
+func newStorageUserAccountsCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "accounts",
+		Short:   cmdAutheliaStorageUserAccountsShort,
+		Long:    cmdAutheliaStorageUserAccountsLong,
+		Example: cmdAutheliaStorageUserAccountsExample,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(
+		newStorageUserAccountsAddCmd(ctx),
+		newStorageUserAccountsDisableCmd(ctx),
+		newStorageUserAccountsEnableCmd(ctx),
+		newStorageUserAccountsDeleteCmd(ctx),
+	)
+
+	return cmd
+}
+
+func newStorageUserAccountsAddCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "add <username>",
+		Short:   cmdAutheliaStorageUserAccountsAddShort,
+		Long:    cmdAutheliaStorageUserAccountsAddLong,
+		Example: cmdAutheliaStorageUserAccountsAddExample,
+		RunE:    ctx.StorageUserAccountsAddRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String(cmdFlagNameDisplayName, "", "the display name for the user")
+	cmd.Flags().String(cmdFlagNameEmail, "", "the email for the user")
+	cmd.Flags().StringSlice(cmdFlagNameGroups, nil, "the groups for the user")
+	cmd.Flags().Bool(cmdFlagNameDisabled, false, "add the user in a disabled state")
+
+	cmdFlagPassword(cmd, true)
+
+	return cmd
+}
+
+func newStorageUserAccountsDisableCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "disable <username>",
+		Short:   cmdAutheliaStorageUserAccountsDisableShort,
+		Long:    cmdAutheliaStorageUserAccountsDisableLong,
+		Example: cmdAutheliaStorageUserAccountsDisableExample,
+		RunE:    ctx.NewStorageUserAccountsDisabledRunE(true),
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newStorageUserAccountsEnableCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "enable <username>",
+		Short:   cmdAutheliaStorageUserAccountsEnableShort,
+		Long:    cmdAutheliaStorageUserAccountsEnableLong,
+		Example: cmdAutheliaStorageUserAccountsEnableExample,
+		RunE:    ctx.NewStorageUserAccountsDisabledRunE(false),
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newStorageUserAccountsDeleteCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "delete <username>",
+		Short:   cmdAutheliaStorageUserAccountsDeleteShort,
+		Long:    cmdAutheliaStorageUserAccountsDeleteLong,
+		Example: cmdAutheliaStorageUserAccountsDeleteExample,
+		RunE:    ctx.StorageUserAccountsDeleteRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
 func newStorageUserIdentifiersCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	cmd = &cobra.Command{
 		Use:     "identifiers",
@@ -455,6 +545,89 @@ func newStorageUserTOTPExportPNGCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	return cmd
 }
 
+func newStorageOIDCCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "oidc",
+		Short:   cmdAutheliaStorageOIDCShort,
+		Long:    cmdAutheliaStorageOIDCLong,
+		Example: cmdAutheliaStorageOIDCExample,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(
+		newStorageOIDCClientsCmd(ctx),
+	)
+
+	return cmd
+}
+
+func newStorageOIDCClientsCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "clients",
+		Short:   cmdAutheliaStorageOIDCClientsShort,
+		Long:    cmdAutheliaStorageOIDCClientsLong,
+		Example: cmdAutheliaStorageOIDCClientsExample,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(
+		newStorageOIDCClientsListCmd(ctx),
+		newStorageOIDCClientsShowCmd(ctx),
+		newStorageOIDCClientsDeleteCmd(ctx),
+	)
+
+	return cmd
+}
+
+func newStorageOIDCClientsListCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "list",
+		Short:   cmdAutheliaStorageOIDCClientsListShort,
+		Long:    cmdAutheliaStorageOIDCClientsListLong,
+		Example: cmdAutheliaStorageOIDCClientsListExample,
+		RunE:    ctx.StorageOIDCClientsListRunE,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newStorageOIDCClientsShowCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "show <client_id>",
+		Short:   cmdAutheliaStorageOIDCClientsShowShort,
+		Long:    cmdAutheliaStorageOIDCClientsShowLong,
+		Example: cmdAutheliaStorageOIDCClientsShowExample,
+		RunE:    ctx.StorageOIDCClientsShowRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newStorageOIDCClientsDeleteCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "delete <client_id>",
+		Short:   cmdAutheliaStorageOIDCClientsDeleteShort,
+		Long:    cmdAutheliaStorageOIDCClientsDeleteLong,
+		Example: cmdAutheliaStorageOIDCClientsDeleteExample,
+		RunE:    ctx.StorageOIDCClientsDeleteRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
 func newStorageSchemaInfoCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	cmd = &cobra.Command{
 		Use:     "schema-info",
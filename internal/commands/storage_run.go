@@ -12,14 +12,19 @@ import (
 	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 
+	"github.com/go-crypt/crypt/algorithm"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/configuration/validator"
 	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
 	"github.com/authelia/authelia/v4/internal/random"
 	"github.com/authelia/authelia/v4/internal/storage"
 	"github.com/authelia/authelia/v4/internal/totp"
@@ -110,6 +115,10 @@ func (ctx *CmdCtx) ConfigValidateStorageRunE(_ *cobra.Command, _ []string) (err
 
 	validator.ValidateTOTP(ctx.config, ctx.cconfig.validator)
 
+	if sql := ctx.config.AuthenticationBackend.SQLBackend(); sql != nil {
+		validator.ValidatePasswordConfiguration(&sql.Password, ctx.cconfig.validator)
+	}
+
 	if errs := ctx.cconfig.validator.Errors(); len(errs) != 0 {
 		var (
 			i int
@@ -1292,3 +1301,232 @@ func (ctx *CmdCtx) StorageUserIdentifiersAddRunE(cmd *cobra.Command, args []stri
 
 	return nil
 }
+
+// StorageUserAccountsAddRunE is the RunE for the authelia storage user accounts add command.
+func (ctx *CmdCtx) StorageUserAccountsAddRunE(cmd *cobra.Command, args []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	user := model.User{
+		Username: args[0],
+	}
+
+	if user.DisplayName, err = cmd.Flags().GetString(cmdFlagNameDisplayName); err != nil {
+		return err
+	}
+
+	if user.Email, err = cmd.Flags().GetString(cmdFlagNameEmail); err != nil {
+		return err
+	}
+
+	if user.Groups, err = cmd.Flags().GetStringSlice(cmdFlagNameGroups); err != nil {
+		return err
+	}
+
+	if user.Disabled, err = cmd.Flags().GetBool(cmdFlagNameDisabled); err != nil {
+		return err
+	}
+
+	if user.DisplayName == "" {
+		user.DisplayName = user.Username
+	}
+
+	config := schema.DefaultPasswordConfig
+
+	if sql := ctx.config.AuthenticationBackend.SQLBackend(); sql != nil {
+		config = sql.Password
+	}
+
+	var (
+		hash     algorithm.Hash
+		digest   algorithm.Digest
+		password string
+	)
+
+	if hash, err = authentication.NewFileCryptoHashFromConfig(config); err != nil {
+		return err
+	}
+
+	if password, _, err = cmdCryptoHashGetPassword(cmd, nil, false, false); err != nil {
+		return err
+	}
+
+	if len(password) == 0 {
+		return fmt.Errorf("no password provided")
+	}
+
+	if digest, err = hash.Hash(password); err != nil {
+		return fmt.Errorf("error hashing password for user '%s': %w", user.Username, err)
+	}
+
+	user.Password = digest.Encode()
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = user.CreatedAt
+
+	if err = ctx.providers.StorageProvider.SaveUser(ctx, user); err != nil {
+		return fmt.Errorf("failed to add user '%s': %w", user.Username, err)
+	}
+
+	fmt.Printf("Successfully added user '%s'\n", user.Username)
+
+	return nil
+}
+
+// NewStorageUserAccountsDisabledRunE creates the RunE for the authelia storage user accounts disable and enable
+// commands.
+func (ctx *CmdCtx) NewStorageUserAccountsDisabledRunE(disabled bool) func(cmd *cobra.Command, args []string) (err error) {
+	return func(cmd *cobra.Command, args []string) (err error) {
+		defer func() {
+			_ = ctx.providers.StorageProvider.Close()
+		}()
+
+		if err = ctx.CheckSchema(); err != nil {
+			return storageWrapCheckSchemaErr(err)
+		}
+
+		action := "enable"
+
+		if disabled {
+			action = "disable"
+		}
+
+		if err = ctx.providers.StorageProvider.UpdateUserDisabled(ctx, args[0], disabled); err != nil {
+			return fmt.Errorf("failed to %s user '%s': %w", action, args[0], err)
+		}
+
+		fmt.Printf("Successfully %sd user '%s'\n", action, args[0])
+
+		return nil
+	}
+}
+
+// StorageUserAccountsDeleteRunE is the RunE for the authelia storage user accounts delete command.
+func (ctx *CmdCtx) StorageUserAccountsDeleteRunE(cmd *cobra.Command, args []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	if err = ctx.providers.StorageProvider.DeleteUser(ctx, args[0]); err != nil {
+		return fmt.Errorf("failed to delete user '%s': %w", args[0], err)
+	}
+
+	fmt.Printf("Successfully deleted user '%s'\n", args[0])
+
+	return nil
+}
+
+// StorageOIDCClientsListRunE is the RunE for the authelia storage oidc clients list command.
+func (ctx *CmdCtx) StorageOIDCClientsListRunE(_ *cobra.Command, _ []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	var clients []model.OAuth2Client
+
+	limit := 10
+
+	w := tabwriter.NewWriter(os.Stdout, 1, 1, 4, ' ', 0)
+
+	_, _ = fmt.Fprintln(w, "Client ID\tName\tCreated\tUpdated")
+
+	for page := 0; true; page++ {
+		if clients, err = ctx.providers.StorageProvider.LoadOAuth2Clients(ctx, limit, page); err != nil {
+			return fmt.Errorf("failed to list clients: %w", err)
+		}
+
+		if page == 0 && len(clients) == 0 {
+			return errors.New("no OpenID Connect 1.0 clients in database")
+		}
+
+		for _, client := range clients {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", client.ClientID, client.ClientName, client.CreatedAt.Format("2006-01-02 15:04:05 -0700"), client.UpdatedAt.Format("2006-01-02 15:04:05 -0700"))
+		}
+
+		if len(clients) < limit {
+			break
+		}
+	}
+
+	return w.Flush()
+}
+
+// StorageOIDCClientsShowRunE is the RunE for the authelia storage oidc clients show command.
+func (ctx *CmdCtx) StorageOIDCClientsShowRunE(_ *cobra.Command, args []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	var (
+		client   *model.OAuth2Client
+		metadata *oidc.ClientRegistrationMetadata
+	)
+
+	if client, err = ctx.providers.StorageProvider.LoadOAuth2Client(ctx, args[0]); err != nil {
+		if errors.Is(err, storage.ErrNoOAuth2Client) {
+			return fmt.Errorf("client '%s' does not exist", args[0])
+		}
+
+		return fmt.Errorf("failed to load client '%s': %w", args[0], err)
+	}
+
+	if metadata, err = oidc.NewClientRegistrationMetadataFromOAuth2Client(client); err != nil {
+		return fmt.Errorf("failed to decode the metadata for client '%s': %w", args[0], err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 1, 1, 4, ' ', 0)
+
+	_, _ = fmt.Fprintf(w, "Client ID:\t%s\n", client.ClientID)
+	_, _ = fmt.Fprintf(w, "Name:\t%s\n", client.ClientName)
+	_, _ = fmt.Fprintf(w, "Public:\t%t\n", metadata.IsPublic())
+	_, _ = fmt.Fprintf(w, "Token Endpoint Auth Method:\t%s\n", metadata.TokenEndpointAuthMethod)
+	_, _ = fmt.Fprintf(w, "Grant Types:\t%s\n", strings.Join(metadata.GrantTypes, ", "))
+	_, _ = fmt.Fprintf(w, "Response Types:\t%s\n", strings.Join(metadata.ResponseTypes, ", "))
+	_, _ = fmt.Fprintf(w, "Scopes:\t%s\n", strings.Join(metadata.GetScopes(), ", "))
+	_, _ = fmt.Fprintf(w, "Redirect URIs:\t%s\n", strings.Join(metadata.RedirectURIs, ", "))
+	_, _ = fmt.Fprintf(w, "Post Logout Redirect URIs:\t%s\n", strings.Join(metadata.PostLogoutRedirectURIs, ", "))
+	_, _ = fmt.Fprintf(w, "Created:\t%s\n", client.CreatedAt.Format("2006-01-02 15:04:05 -0700"))
+	_, _ = fmt.Fprintf(w, "Updated:\t%s\n", client.UpdatedAt.Format("2006-01-02 15:04:05 -0700"))
+
+	return w.Flush()
+}
+
+// StorageOIDCClientsDeleteRunE is the RunE for the authelia storage oidc clients delete command.
+func (ctx *CmdCtx) StorageOIDCClientsDeleteRunE(_ *cobra.Command, args []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	if err = ctx.providers.StorageProvider.DeleteOAuth2Client(ctx, args[0]); err != nil {
+		if errors.Is(err, storage.ErrNoOAuth2Client) {
+			return fmt.Errorf("client '%s' does not exist", args[0])
+		}
+
+		return fmt.Errorf("failed to delete client '%s': %w", args[0], err)
+	}
+
+	fmt.Printf("Successfully deleted client '%s'\n", args[0])
+
+	return nil
+}
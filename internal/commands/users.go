@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newUsersCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "users",
+		Short:   cmdAutheliaUsersShort,
+		Long:    cmdAutheliaUsersLong,
+		Example: cmdAutheliaUsersExample,
+		PersistentPreRunE: ctx.ChainRunE(
+			ctx.HelperConfigLoadRunE,
+		),
+		Args: cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.PersistentFlags().String(cmdFlagNameFile, "", "the path to the users database file, by default the path configured for the file authentication backend is used")
+
+	cmd.AddCommand(
+		newUsersListCmd(ctx),
+		newUsersAddCmd(ctx),
+		newUsersDeleteCmd(ctx),
+		newUsersDisableCmd(ctx),
+		newUsersEnableCmd(ctx),
+		newUsersSetPasswordCmd(ctx),
+		newUsersAddGroupCmd(ctx),
+		newUsersRemoveGroupCmd(ctx),
+	)
+
+	return cmd
+}
+
+func newUsersListCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "list",
+		Short:   cmdAutheliaUsersListShort,
+		Long:    cmdAutheliaUsersListLong,
+		Example: cmdAutheliaUsersListExample,
+		RunE:    ctx.UsersListRunE,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newUsersAddCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "add <username>",
+		Short:   cmdAutheliaUsersAddShort,
+		Long:    cmdAutheliaUsersAddLong,
+		Example: cmdAutheliaUsersAddExample,
+		RunE:    ctx.UsersAddRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String(cmdFlagNameDisplayName, "", "the display name for the user, by default the username is used")
+	cmd.Flags().String(cmdFlagNameEmail, "", "the email for the user")
+	cmd.Flags().StringSlice(cmdFlagNameGroups, nil, "the groups for the user")
+	cmd.Flags().Bool(cmdFlagNameDisabled, false, "add the user in a disabled state")
+
+	cmdFlagPassword(cmd, true)
+	cmdFlagRandomPassword(cmd)
+
+	return cmd
+}
+
+func newUsersDeleteCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "delete <username>",
+		Short:   cmdAutheliaUsersDeleteShort,
+		Long:    cmdAutheliaUsersDeleteLong,
+		Example: cmdAutheliaUsersDeleteExample,
+		RunE:    ctx.UsersDeleteRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newUsersDisableCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "disable <username>",
+		Short:   cmdAutheliaUsersDisableShort,
+		Long:    cmdAutheliaUsersDisableLong,
+		Example: cmdAutheliaUsersDisableExample,
+		RunE:    ctx.NewUsersDisabledRunE(true),
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newUsersEnableCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "enable <username>",
+		Short:   cmdAutheliaUsersEnableShort,
+		Long:    cmdAutheliaUsersEnableLong,
+		Example: cmdAutheliaUsersEnableExample,
+		RunE:    ctx.NewUsersDisabledRunE(false),
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newUsersSetPasswordCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "set-password <username>",
+		Short:   cmdAutheliaUsersSetPasswordShort,
+		Long:    cmdAutheliaUsersSetPasswordLong,
+		Example: cmdAutheliaUsersSetPasswordExample,
+		RunE:    ctx.UsersSetPasswordRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	cmdFlagPassword(cmd, true)
+	cmdFlagRandomPassword(cmd)
+
+	return cmd
+}
+
+func newUsersAddGroupCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "add-group <username> <group>...",
+		Short:   cmdAutheliaUsersAddGroupShort,
+		Long:    cmdAutheliaUsersAddGroupLong,
+		Example: cmdAutheliaUsersAddGroupExample,
+		RunE:    ctx.NewUsersGroupRunE(true),
+		Args:    cobra.MinimumNArgs(2),
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newUsersRemoveGroupCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "remove-group <username> <group>...",
+		Short:   cmdAutheliaUsersRemoveGroupShort,
+		Long:    cmdAutheliaUsersRemoveGroupLong,
+		Example: cmdAutheliaUsersRemoveGroupExample,
+		RunE:    ctx.NewUsersGroupRunE(false),
+		Args:    cobra.MinimumNArgs(2),
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
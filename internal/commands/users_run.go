@@ -0,0 +1,311 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/spf13/cobra"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// UsersListRunE is the RunE for the authelia users list command.
+func (ctx *CmdCtx) UsersListRunE(cmd *cobra.Command, _ []string) (err error) {
+	var database *authentication.FileUserDatabase
+
+	if database, err = ctx.usersLoadDatabase(cmd); err != nil {
+		return err
+	}
+
+	usernames := make([]string, 0, len(database.Users))
+
+	for username := range database.Users {
+		usernames = append(usernames, username)
+	}
+
+	sort.Strings(usernames)
+
+	w := tabwriter.NewWriter(os.Stdout, 1, 1, 4, ' ', 0)
+
+	_, _ = fmt.Fprintln(w, "Username\tDisplay Name\tEmail\tGroups\tDisabled")
+
+	for _, username := range usernames {
+		details := database.Users[username]
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\n", username, details.DisplayName, details.Email, strings.Join(details.Groups, ", "), details.Disabled)
+	}
+
+	return w.Flush()
+}
+
+// UsersAddRunE is the RunE for the authelia users add command.
+func (ctx *CmdCtx) UsersAddRunE(cmd *cobra.Command, args []string) (err error) {
+	var database *authentication.FileUserDatabase
+
+	if database, err = ctx.usersLoadDatabase(cmd); err != nil {
+		return err
+	}
+
+	if _, err = database.GetUserDetails(args[0]); err == nil {
+		return fmt.Errorf("failed to add user '%s': the user already exists", args[0])
+	}
+
+	details := authentication.FileUserDatabaseUserDetails{
+		Username: args[0],
+	}
+
+	if details.DisplayName, err = cmd.Flags().GetString(cmdFlagNameDisplayName); err != nil {
+		return err
+	}
+
+	if details.Email, err = cmd.Flags().GetString(cmdFlagNameEmail); err != nil {
+		return err
+	}
+
+	if details.Groups, err = cmd.Flags().GetStringSlice(cmdFlagNameGroups); err != nil {
+		return err
+	}
+
+	if details.Disabled, err = cmd.Flags().GetBool(cmdFlagNameDisabled); err != nil {
+		return err
+	}
+
+	if details.DisplayName == "" {
+		details.DisplayName = details.Username
+	}
+
+	if details.Password, err = ctx.usersPasswordDigest(cmd); err != nil {
+		return err
+	}
+
+	database.SetUserDetails(details.Username, &details)
+
+	if err = usersSaveDatabase(database); err != nil {
+		return fmt.Errorf("failed to add user '%s': %w", details.Username, err)
+	}
+
+	fmt.Printf("Successfully added user '%s'\n", details.Username)
+
+	return nil
+}
+
+// UsersDeleteRunE is the RunE for the authelia users delete command.
+func (ctx *CmdCtx) UsersDeleteRunE(cmd *cobra.Command, args []string) (err error) {
+	var (
+		database *authentication.FileUserDatabase
+		details  authentication.FileUserDatabaseUserDetails
+	)
+
+	if database, details, err = ctx.usersLoadDatabaseUser(cmd, args[0]); err != nil {
+		return err
+	}
+
+	database.DeleteUserDetails(details.Username)
+
+	if err = usersSaveDatabase(database); err != nil {
+		return fmt.Errorf("failed to delete user '%s': %w", details.Username, err)
+	}
+
+	fmt.Printf("Successfully deleted user '%s'\n", details.Username)
+
+	return nil
+}
+
+// NewUsersDisabledRunE creates the RunE for the authelia users disable and enable commands.
+func (ctx *CmdCtx) NewUsersDisabledRunE(disabled bool) func(cmd *cobra.Command, args []string) (err error) {
+	return func(cmd *cobra.Command, args []string) (err error) {
+		var (
+			database *authentication.FileUserDatabase
+			details  authentication.FileUserDatabaseUserDetails
+		)
+
+		action := "enable"
+
+		if disabled {
+			action = "disable"
+		}
+
+		if database, details, err = ctx.usersLoadDatabaseUser(cmd, args[0]); err != nil {
+			return err
+		}
+
+		details.Disabled = disabled
+
+		database.SetUserDetails(details.Username, &details)
+
+		if err = usersSaveDatabase(database); err != nil {
+			return fmt.Errorf("failed to %s user '%s': %w", action, details.Username, err)
+		}
+
+		fmt.Printf("Successfully %sd user '%s'\n", action, details.Username)
+
+		return nil
+	}
+}
+
+// UsersSetPasswordRunE is the RunE for the authelia users set-password command.
+func (ctx *CmdCtx) UsersSetPasswordRunE(cmd *cobra.Command, args []string) (err error) {
+	var (
+		database *authentication.FileUserDatabase
+		details  authentication.FileUserDatabaseUserDetails
+	)
+
+	if database, details, err = ctx.usersLoadDatabaseUser(cmd, args[0]); err != nil {
+		return err
+	}
+
+	if details.Password, err = ctx.usersPasswordDigest(cmd); err != nil {
+		return err
+	}
+
+	database.SetUserDetails(details.Username, &details)
+
+	if err = usersSaveDatabase(database); err != nil {
+		return fmt.Errorf("failed to set the password for user '%s': %w", details.Username, err)
+	}
+
+	fmt.Printf("Successfully set the password for user '%s'\n", details.Username)
+
+	return nil
+}
+
+// NewUsersGroupRunE creates the RunE for the authelia users add-group and remove-group commands.
+func (ctx *CmdCtx) NewUsersGroupRunE(add bool) func(cmd *cobra.Command, args []string) (err error) {
+	return func(cmd *cobra.Command, args []string) (err error) {
+		var (
+			database *authentication.FileUserDatabase
+			details  authentication.FileUserDatabaseUserDetails
+		)
+
+		if database, details, err = ctx.usersLoadDatabaseUser(cmd, args[0]); err != nil {
+			return err
+		}
+
+		groups := make([]string, 0, len(details.Groups)+len(args)-1)
+
+		if add {
+			groups = append(groups, details.Groups...)
+
+			for _, group := range args[1:] {
+				if !utils.IsStringInSlice(group, groups) {
+					groups = append(groups, group)
+				}
+			}
+		} else {
+			for _, group := range details.Groups {
+				if !utils.IsStringInSlice(group, args[1:]) {
+					groups = append(groups, group)
+				}
+			}
+		}
+
+		details.Groups = groups
+
+		database.SetUserDetails(details.Username, &details)
+
+		if err = usersSaveDatabase(database); err != nil {
+			return fmt.Errorf("failed to update the groups for user '%s': %w", details.Username, err)
+		}
+
+		fmt.Printf("Successfully updated the groups for user '%s' which are now '%s'\n", details.Username, strings.Join(details.Groups, "', '"))
+
+		return nil
+	}
+}
+
+func (ctx *CmdCtx) usersLoadDatabase(cmd *cobra.Command) (database *authentication.FileUserDatabase, err error) {
+	var (
+		path                  string
+		searchEmail, searchCI bool
+	)
+
+	if config := ctx.config.AuthenticationBackend.File; config != nil {
+		path, searchEmail, searchCI = config.Path, config.Search.Email, config.Search.CaseInsensitive
+	}
+
+	if cmd.Flags().Changed(cmdFlagNameFile) {
+		if path, err = cmd.Flags().GetString(cmdFlagNameFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if path == "" {
+		return nil, errors.New("the users database file path must either be configured using the file authentication backend or provided using the --file flag")
+	}
+
+	database = authentication.NewFileUserDatabase(path, searchEmail, searchCI)
+
+	if err = database.Load(); err != nil {
+		return nil, err
+	}
+
+	return database, nil
+}
+
+func (ctx *CmdCtx) usersLoadDatabaseUser(cmd *cobra.Command, username string) (database *authentication.FileUserDatabase, details authentication.FileUserDatabaseUserDetails, err error) {
+	if database, err = ctx.usersLoadDatabase(cmd); err != nil {
+		return nil, details, err
+	}
+
+	if details, err = database.GetUserDetails(username); err != nil {
+		return nil, details, fmt.Errorf("failed to find user '%s': %w", username, err)
+	}
+
+	return database, details, nil
+}
+
+func (ctx *CmdCtx) usersPasswordDigest(cmd *cobra.Command) (digest *schema.PasswordDigest, err error) {
+	config := schema.DefaultPasswordConfig
+
+	if ctx.config.AuthenticationBackend.File != nil {
+		if err = ctx.ConfigValidateSectionPasswordRunE(cmd, nil); err != nil {
+			return nil, err
+		}
+
+		config = ctx.config.AuthenticationBackend.File.Password
+	}
+
+	var (
+		hash     algorithm.Hash
+		d        algorithm.Digest
+		password string
+		random   bool
+	)
+
+	if hash, err = authentication.NewFileCryptoHashFromConfig(config); err != nil {
+		return nil, err
+	}
+
+	if password, random, err = cmdCryptoHashGetPassword(cmd, nil, false, true); err != nil {
+		return nil, err
+	}
+
+	if len(password) == 0 {
+		return nil, fmt.Errorf("no password provided")
+	}
+
+	if d, err = hash.Hash(password); err != nil {
+		return nil, err
+	}
+
+	if random {
+		fmt.Printf("Random Password: %s\n", password)
+	}
+
+	return schema.NewPasswordDigest(d), nil
+}
+
+func usersSaveDatabase(database *authentication.FileUserDatabase) (err error) {
+	if err = database.Validate(); err != nil {
+		return err
+	}
+
+	return database.Save()
+}
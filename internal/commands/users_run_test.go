@@ -0,0 +1,182 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+)
+
+func TestUsersCommands(t *testing.T) {
+	dir := t.TempDir()
+
+	database := filepath.Join(dir, "users_database.yml")
+	config := filepath.Join(dir, "configuration.yml")
+
+	require.NoError(t, os.WriteFile(database, []byte(testUsersDatabaseContent), 0600))
+	require.NoError(t, os.WriteFile(config, []byte(fmt.Sprintf(testUsersConfigurationContent, database)), 0600))
+
+	run := func(args ...string) error {
+		cmd := NewRootCmd()
+
+		cmd.SilenceErrors, cmd.SilenceUsage = true, true
+
+		cmd.SetArgs(append([]string{"users", "--config", config}, args...))
+
+		return cmd.Execute()
+	}
+
+	load := func(t *testing.T) (data string, db *authentication.FileUserDatabase) {
+		raw, err := os.ReadFile(database)
+
+		require.NoError(t, err)
+
+		db = authentication.NewFileUserDatabase(database, false, false)
+
+		require.NoError(t, db.Load())
+
+		return string(raw), db
+	}
+
+	assertRetained := func(t *testing.T, data string, db *authentication.FileUserDatabase) {
+		assert.Contains(t, data, "# yamllint disable rule:line-length\n---\n# The users database.\n\nusers:\n  # Harry is an administrator.\n  harry:\n")
+		assert.Contains(t, data, "# Password is 'password'")
+		assert.Contains(t, data, "- admins # Remove when Harry leaves.")
+		assert.Contains(t, data, "...\n# yamllint enable rule:line-length\n")
+
+		harry, err := db.GetUserDetails("harry")
+
+		require.NoError(t, err)
+
+		assert.Equal(t, "Harry Potter", harry.DisplayName)
+		assert.Equal(t, []string{"admins", "dev"}, harry.Groups)
+		assert.True(t, harry.Password.Match("password"))
+	}
+
+	t.Run("ShouldAddUser", func(t *testing.T) {
+		require.NoError(t, run("add", "john", "--password", "apple123", "--display-name", "John Doe", "--email", "john.doe@authelia.com", "--groups", "dev,ops"))
+
+		data, db := load(t)
+
+		assertRetained(t, data, db)
+
+		john, err := db.GetUserDetails("john")
+
+		require.NoError(t, err)
+
+		assert.Equal(t, "John Doe", john.DisplayName)
+		assert.Equal(t, "john.doe@authelia.com", john.Email)
+		assert.Equal(t, []string{"dev", "ops"}, john.Groups)
+		assert.False(t, john.Disabled)
+		assert.True(t, john.Password.Match("apple123"))
+	})
+
+	t.Run("ShouldNotAddExistingUser", func(t *testing.T) {
+		assert.EqualError(t, run("add", "john", "--password", "apple123"), "failed to add user 'john': the user already exists")
+	})
+
+	t.Run("ShouldSetPassword", func(t *testing.T) {
+		require.NoError(t, run("set-password", "john", "--password", "banana456"))
+
+		data, db := load(t)
+
+		assertRetained(t, data, db)
+
+		john, err := db.GetUserDetails("john")
+
+		require.NoError(t, err)
+
+		assert.True(t, john.Password.Match("banana456"))
+		assert.False(t, john.Password.Match("apple123"))
+		assert.Equal(t, "John Doe", john.DisplayName)
+	})
+
+	t.Run("ShouldDisableAndEnableUser", func(t *testing.T) {
+		require.NoError(t, run("disable", "john"))
+
+		data, db := load(t)
+
+		assertRetained(t, data, db)
+
+		john, err := db.GetUserDetails("john")
+
+		require.NoError(t, err)
+
+		assert.True(t, john.Disabled)
+
+		require.NoError(t, run("enable", "john"))
+
+		_, db = load(t)
+
+		john, err = db.GetUserDetails("john")
+
+		require.NoError(t, err)
+
+		assert.False(t, john.Disabled)
+	})
+
+	t.Run("ShouldUpdateGroups", func(t *testing.T) {
+		require.NoError(t, run("add-group", "john", "admins", "ops"))
+		require.NoError(t, run("remove-group", "john", "dev"))
+
+		data, db := load(t)
+
+		assertRetained(t, data, db)
+
+		john, err := db.GetUserDetails("john")
+
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"ops", "admins"}, john.Groups)
+	})
+
+	t.Run("ShouldDeleteUser", func(t *testing.T) {
+		require.NoError(t, run("delete", "john"))
+
+		data, db := load(t)
+
+		assertRetained(t, data, db)
+
+		_, err := db.GetUserDetails("john")
+
+		assert.ErrorIs(t, err, authentication.ErrUserNotFound)
+
+		assert.Equal(t, testUsersDatabaseContent, data)
+	})
+
+	t.Run("ShouldNotDeleteMissingUser", func(t *testing.T) {
+		assert.EqualError(t, run("delete", "john"), "failed to find user 'john': user not found")
+	})
+}
+
+const testUsersConfigurationContent = `---
+authentication_backend:
+  file:
+    path: '%s'
+    password:
+      algorithm: 'sha2crypt'
+      sha2crypt:
+        iterations: 1000
+`
+
+const testUsersDatabaseContent = `# yamllint disable rule:line-length
+---
+# The users database.
+
+users:
+  # Harry is an administrator.
+  harry:
+    displayname: "Harry Potter"
+    password: "$argon2id$v=19$m=65536,t=3,p=2$BpLnfgDsc2WD8F2q$o/vzA4myCqZZ36bUGsDY//8mKUYNZZaR0t4MFFSs+iM" # Password is 'password'
+    email: harry.potter@authelia.com
+    groups:
+      - admins # Remove when Harry leaves.
+      - dev
+...
+# yamllint enable rule:line-length
+`
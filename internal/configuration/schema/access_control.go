@@ -1,5 +1,9 @@
 package schema
 
+import (
+	"time"
+)
+
 // AccessControl represents the configuration related to ACLs.
 type AccessControl struct {
 	// The default policy if no other policy matches the request.
@@ -10,24 +14,48 @@ type AccessControl struct {
 
 	// The ACL rules list.
 	Rules []AccessControlRule `koanf:"rules" json:"rules" jsonschema:"title=Rules List" jsonschema_description:"The list of ACL rules to enumerate for requests."`
+
+	// The GeoIP databases used to resolve the country and autonomous system networks.
+	GeoIP AccessControlGeoIP `koanf:"geoip" json:"geoip" jsonschema:"title=GeoIP" jsonschema_description:"The GeoIP databases used by the country, continent, and asn networks."`
+}
+
+// AccessControlGeoIP represents the configuration related to the ACL GeoIP databases.
+type AccessControlGeoIP struct {
+	CountryDatabase string `koanf:"country_database" json:"country_database" jsonschema:"title=Country Database" jsonschema_description:"The path to the MaxMind DB file used to resolve the country and continent of an IP."`
+	ASNDatabase     string `koanf:"asn_database" json:"asn_database" jsonschema:"title=ASN Database" jsonschema_description:"The path to the MaxMind DB file used to resolve the autonomous system number of an IP."`
 }
 
 // AccessControlNetwork represents one ACL network group entry.
 type AccessControlNetwork struct {
 	Name     string                       `koanf:"name" json:"name" jsonschema:"required,title=Network Name" jsonschema_description:"The name of this network to be used in the networks section of the rules section."`
-	Networks AccessControlNetworkNetworks `koanf:"networks" json:"networks" jsonschema:"required,title=Networks" jsonschema_description:"The remote IP's or network ranges in CIDR notation that this rule applies to."`
+	Networks AccessControlNetworkNetworks `koanf:"networks" json:"networks" jsonschema:"required,title=Networks" jsonschema_description:"The remote IP's, network ranges in CIDR notation, countries, continents, or autonomous system numbers that this network group contains."`
 }
 
 // AccessControlRule represents one ACL rule entry.
 type AccessControlRule struct {
-	Domains      AccessControlRuleDomains   `koanf:"domain" json:"domain" jsonschema:"oneof_required=Domain,uniqueItems,title=Domain Literals" jsonschema_description:"The literal domains to match the domain against that this rule applies to."`
-	DomainsRegex AccessControlRuleRegex     `koanf:"domain_regex" json:"domain_regex" jsonschema:"oneof_required=Domain Regex,title=Domain Regex Patterns" jsonschema_description:"The regex patterns to match the domain against that this rule applies to."`
-	Policy       string                     `koanf:"policy" json:"policy" jsonschema:"required,enum=bypass,enum=deny,enum=one_factor,enum=two_factor,title=Rule Policy" jsonschema_description:"The policy this rule applies when all criteria match."`
-	Subjects     AccessControlRuleSubjects  `koanf:"subject" json:"subject" jsonschema:"title=AccessControlRuleSubjects" jsonschema_description:"The users or groups that this rule applies to."`
-	Networks     AccessControlRuleNetworks  `koanf:"networks" json:"networks" jsonschema:"title=Networks" jsonschema_description:"The remote IP's, network ranges in CIDR notation, or network names that this rule applies to."`
-	Resources    AccessControlRuleRegex     `koanf:"resources" json:"resources" jsonschema:"title=Resources or Paths" jsonschema_description:"The regex patterns to match the resource paths that this rule applies to."`
-	Methods      AccessControlRuleMethods   `koanf:"methods" json:"methods" jsonschema:"enum=GET,enum=HEAD,enum=POST,enum=PUT,enum=DELETE,enum=CONNECT,enum=OPTIONS,enum=TRACE,enum=PATCH,enum=PROPFIND,enum=PROPPATCH,enum=MKCOL,enum=COPY,enum=MOVE,enum=LOCK,enum=UNLOCK" jsonschema_description:"The list of request methods this rule applies to."`
-	Query        [][]AccessControlRuleQuery `koanf:"query" json:"query" jsonschema:"title=Query Rules" jsonschema_description:"The list of query parameter rules this rule applies to."`
+	Domains      AccessControlRuleDomains    `koanf:"domain" json:"domain" jsonschema:"oneof_required=Domain,uniqueItems,title=Domain Literals" jsonschema_description:"The literal domains to match the domain against that this rule applies to."`
+	DomainsRegex AccessControlRuleRegex      `koanf:"domain_regex" json:"domain_regex" jsonschema:"oneof_required=Domain Regex,title=Domain Regex Patterns" jsonschema_description:"The regex patterns to match the domain against that this rule applies to."`
+	Policy       string                      `koanf:"policy" json:"policy" jsonschema:"required,enum=bypass,enum=deny,enum=one_factor,enum=two_factor,title=Rule Policy" jsonschema_description:"The policy this rule applies when all criteria match."`
+	Subjects     AccessControlRuleSubjects   `koanf:"subject" json:"subject" jsonschema:"title=AccessControlRuleSubjects" jsonschema_description:"The users or groups that this rule applies to."`
+	Networks     AccessControlRuleNetworks   `koanf:"networks" json:"networks" jsonschema:"title=Networks" jsonschema_description:"The remote IP's, network ranges in CIDR notation, countries, continents, autonomous system numbers, or network names that this rule applies to."`
+	Resources    AccessControlRuleRegex      `koanf:"resources" json:"resources" jsonschema:"title=Resources or Paths" jsonschema_description:"The regex patterns to match the resource paths that this rule applies to."`
+	Methods      AccessControlRuleMethods    `koanf:"methods" json:"methods" jsonschema:"enum=GET,enum=HEAD,enum=POST,enum=PUT,enum=DELETE,enum=CONNECT,enum=OPTIONS,enum=TRACE,enum=PATCH,enum=PROPFIND,enum=PROPPATCH,enum=MKCOL,enum=COPY,enum=MOVE,enum=LOCK,enum=UNLOCK" jsonschema_description:"The list of request methods this rule applies to."`
+	Query        [][]AccessControlRuleQuery  `koanf:"query" json:"query" jsonschema:"title=Query Rules" jsonschema_description:"The list of query parameter rules this rule applies to."`
+	Headers      [][]AccessControlRuleHeader `koanf:"headers" json:"headers" jsonschema:"title=Header Rules" jsonschema_description:"The list of request header rules this rule applies to."`
+	Schedule     []AccessControlRuleSchedule `koanf:"schedule" json:"schedule" jsonschema:"title=Schedule" jsonschema_description:"The list of time windows this rule applies to."`
+
+	SecondFactorMethods []string      `koanf:"second_factor_methods" json:"second_factor_methods" jsonschema:"enum=totp,enum=mobile_push,enum=webauthn,enum=webauthn_hardware,enum=webauthn_software,enum=webauthn_user_verified,enum=phishing_resistant,uniqueItems,title=Second Factor Methods" jsonschema_description:"The second factor methods of which at least one must have been used to satisfy the two_factor policy."`
+	MaxAge              time.Duration `koanf:"max_age" json:"max_age" jsonschema:"title=Maximum Authentication Age" jsonschema_description:"The maximum duration since the factor required by the policy was last performed before the user must authenticate again."`
+}
+
+// AccessControlRuleSchedule represents the ACL time window criteria.
+type AccessControlRuleSchedule struct {
+	Timezone  string   `koanf:"timezone" json:"timezone" jsonschema:"default=UTC,title=Timezone" jsonschema_description:"The IANA timezone name the time window is evaluated in."`
+	Days      []string `koanf:"days" json:"days" jsonschema:"enum=monday,enum=tuesday,enum=wednesday,enum=thursday,enum=friday,enum=saturday,enum=sunday,uniqueItems,title=Days" jsonschema_description:"The days of the week the time window applies to. All days when empty."`
+	StartTime string   `koanf:"start_time" json:"start_time" jsonschema:"title=Start Time" jsonschema_description:"The time of day in the HH:MM format the time window starts at."`
+	EndTime   string   `koanf:"end_time" json:"end_time" jsonschema:"title=End Time" jsonschema_description:"The time of day in the HH:MM format the time window ends at."`
+	StartDate string   `koanf:"start_date" json:"start_date" jsonschema:"title=Start Date" jsonschema_description:"The date in the YYYY-MM-DD or YYYY-MM-DD HH:MM format the time window starts at."`
+	EndDate   string   `koanf:"end_date" json:"end_date" jsonschema:"title=End Date" jsonschema_description:"The date in the YYYY-MM-DD or YYYY-MM-DD HH:MM format the time window ends at."`
 }
 
 // AccessControlRuleQuery represents the ACL query criteria.
@@ -37,6 +65,13 @@ type AccessControlRuleQuery struct {
 	Value    any    `koanf:"value" json:"value" jsonschema:"title=Value" jsonschema_description:"The Query Parameter value for this rule."`
 }
 
+// AccessControlRuleHeader represents the ACL header criteria.
+type AccessControlRuleHeader struct {
+	Operator string `koanf:"operator" json:"operator" jsonschema:"enum=equal,enum=not equal,enum=present,enum=absent,enum=pattern,enum=not pattern,title=Operator" jsonschema_description:"The operator this header rule uses."`
+	Key      string `koanf:"key" json:"key" jsonschema:"required,title=Key" jsonschema_description:"The Header name this rule applies to."`
+	Value    any    `koanf:"value" json:"value" jsonschema:"title=Value" jsonschema_description:"The Header value for this rule."`
+}
+
 // DefaultACLNetwork represents the default configuration related to access control network group configuration.
 var DefaultACLNetwork = []AccessControlNetwork{
 	{
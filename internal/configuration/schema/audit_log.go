@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"crypto/tls"
+	"net/url"
+	"time"
+)
+
+// AuditLog represents the audit log configuration.
+type AuditLog struct {
+	File    *AuditLogFile    `koanf:"file" json:"file" jsonschema:"title=File" jsonschema_description:"The audit log file sink configuration."`
+	Syslog  *AuditLogSyslog  `koanf:"syslog" json:"syslog" jsonschema:"title=Syslog" jsonschema_description:"The audit log syslog sink configuration."`
+	Webhook *AuditLogWebhook `koanf:"webhook" json:"webhook" jsonschema:"title=Webhook" jsonschema_description:"The audit log webhook sink configuration."`
+}
+
+// AuditLogFile represents the audit log file sink configuration.
+type AuditLogFile struct {
+	Path       string `koanf:"path" json:"path" jsonschema:"title=Path" jsonschema_description:"The path of the file the audit records are written to."`
+	MaxSize    int    `koanf:"max_size" json:"max_size" jsonschema:"default=100,title=Maximum Size" jsonschema_description:"The maximum size in megabytes of the file before it's rotated."`
+	MaxBackups int    `koanf:"max_backups" json:"max_backups" jsonschema:"default=5,title=Maximum Backups" jsonschema_description:"The maximum number of rotated files to keep."`
+}
+
+// AuditLogSyslog represents the audit log syslog sink configuration.
+type AuditLogSyslog struct {
+	Address    *AddressUDP `koanf:"address" json:"address" jsonschema:"default=udp://127.0.0.1:514,title=Address" jsonschema_description:"The address of the syslog server."`
+	Facility   string      `koanf:"facility" json:"facility" jsonschema:"default=authpriv,enum=kern,enum=user,enum=mail,enum=daemon,enum=auth,enum=syslog,enum=lpr,enum=news,enum=uucp,enum=cron,enum=authpriv,enum=ftp,enum=local0,enum=local1,enum=local2,enum=local3,enum=local4,enum=local5,enum=local6,enum=local7,title=Facility" jsonschema_description:"The syslog facility of the audit records."`
+	Tag        string      `koanf:"tag" json:"tag" jsonschema:"default=authelia,title=Tag" jsonschema_description:"The syslog application name of the audit records."`
+	BufferSize int         `koanf:"buffer_size" json:"buffer_size" jsonschema:"default=10000,title=Buffer Size" jsonschema_description:"The maximum number of audit records held while waiting to be sent."`
+}
+
+// AuditLogWebhook represents the audit log webhook sink configuration.
+type AuditLogWebhook struct {
+	Address       *url.URL      `koanf:"address" json:"address" jsonschema:"format=uri,title=Address" jsonschema_description:"The URL the batches of audit records are sent to."`
+	BatchSize     int           `koanf:"batch_size" json:"batch_size" jsonschema:"default=100,title=Batch Size" jsonschema_description:"The maximum number of audit records sent in a single request."`
+	FlushInterval time.Duration `koanf:"flush_interval" json:"flush_interval" jsonschema:"default=5 seconds,title=Flush Interval" jsonschema_description:"The maximum amount of time audit records are held before they're sent."`
+	Timeout       time.Duration `koanf:"timeout" json:"timeout" jsonschema:"default=5 seconds,title=Timeout" jsonschema_description:"The timeout for each request."`
+	BufferSize    int           `koanf:"buffer_size" json:"buffer_size" jsonschema:"default=10000,title=Buffer Size" jsonschema_description:"The maximum number of audit records held while waiting to be sent."`
+	MaxRetries    int           `koanf:"max_retries" json:"max_retries" jsonschema:"default=3,title=Maximum Retries" jsonschema_description:"The maximum number of times a batch which failed to send is retried before it's dropped."`
+	TLS           *TLS          `koanf:"tls" json:"tls" jsonschema:"title=TLS" jsonschema_description:"The TLS configuration for the webhook requests."`
+}
+
+// DefaultAuditLogConfiguration is the default audit log configuration.
+var DefaultAuditLogConfiguration = AuditLog{
+	File: &AuditLogFile{
+		MaxSize:    100,
+		MaxBackups: 5,
+	},
+	Syslog: &AuditLogSyslog{
+		Address:    &AddressUDP{Address{true, false, -1, 514, &url.URL{Scheme: AddressSchemeUDP, Host: "127.0.0.1:514"}}},
+		Facility:   "authpriv",
+		Tag:        "authelia",
+		BufferSize: 10000,
+	},
+	Webhook: &AuditLogWebhook{
+		BatchSize:     100,
+		FlushInterval: 5 * time.Second,
+		Timeout:       5 * time.Second,
+		BufferSize:    10000,
+		MaxRetries:    3,
+		TLS: &TLS{
+			MinimumVersion: TLSVersion{tls.VersionTLS12},
+		},
+	},
+}
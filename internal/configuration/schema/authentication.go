@@ -3,6 +3,7 @@ package schema
 import (
 	"crypto/tls"
 	"net/url"
+	"regexp"
 	"time"
 )
 
@@ -15,6 +16,77 @@ type AuthenticationBackend struct {
 	// The file authentication backend configuration.
 	File *AuthenticationBackendFile `koanf:"file" json:"file" jsonschema:"title=File Backend" jsonschema_description:"The file authentication backend configuration."`
 	LDAP *AuthenticationBackendLDAP `koanf:"ldap" json:"ldap" jsonschema:"title=LDAP Backend" jsonschema_description:"The LDAP authentication backend configuration."`
+	SQL  *AuthenticationBackendSQL  `koanf:"sql" json:"sql" jsonschema:"title=SQL Backend" jsonschema_description:"The SQL authentication backend configuration which stores users in the storage backend."`
+
+	Chain *AuthenticationBackendChain `koanf:"chain" json:"chain" jsonschema:"title=Chain Backend" jsonschema_description:"The chained authentication backend configuration which tries multiple backends in order."`
+
+	Kerberos *AuthenticationBackendKerberos `koanf:"kerberos" json:"kerberos" jsonschema:"title=Kerberos" jsonschema_description:"The Kerberos SPNEGO single sign-on configuration."`
+
+	ClientCertificate *AuthenticationBackendClientCertificate `koanf:"client_certificate" json:"client_certificate" jsonschema:"title=Client Certificate" jsonschema_description:"The mutual TLS client certificate authentication configuration."`
+}
+
+// SQLBackend returns the SQL authentication backend configuration whether it's configured directly or as one of the
+// chained authentication backends.
+func (c *AuthenticationBackend) SQLBackend() *AuthenticationBackendSQL {
+	if c.SQL != nil || c.Chain == nil {
+		return c.SQL
+	}
+
+	for _, backend := range c.Chain.Backends {
+		if backend.SQL != nil {
+			return backend.SQL
+		}
+	}
+
+	return nil
+}
+
+// AuthenticationBackendChain represents the configuration related to the chained authentication backend.
+type AuthenticationBackendChain struct {
+	ConflictMode string `koanf:"conflict_mode" json:"conflict_mode" jsonschema:"default=precedence,enum=precedence,enum=deny,title=Conflict Mode" jsonschema_description:"The method used to handle a username which exists in more than one backend."`
+
+	Backends []AuthenticationBackendChainBackend `koanf:"backends" json:"backends" jsonschema:"title=Backends" jsonschema_description:"The list of backends in order of precedence."`
+}
+
+// AuthenticationBackendKerberos represents the configuration related to Kerberos SPNEGO single sign-on. The principals
+// are mapped to users of the configured authentication backend.
+type AuthenticationBackendKerberos struct {
+	Keytab           string        `koanf:"keytab" json:"keytab" jsonschema:"title=Keytab" jsonschema_description:"The path to the keytab file which contains the keys of the service principal."`
+	ServicePrincipal string        `koanf:"service_principal" json:"service_principal" jsonschema:"title=Service Principal" jsonschema_description:"The name of the service principal in the keytab to use, for example HTTP/auth.example.com. Defaults to the principal the ticket was issued for."`
+	Realms           []string      `koanf:"realms" json:"realms" jsonschema:"title=Realms" jsonschema_description:"The realms of the client principals which are permitted to sign in. Only the realms of the service principal entries of the keytab are permitted when empty."`
+	IncludeRealm     bool          `koanf:"include_realm" json:"include_realm" jsonschema:"default=false,title=Include Realm" jsonschema_description:"Maps the client principals to usernames in the user@REALM format instead of just the user."`
+	MaxClockSkew     time.Duration `koanf:"max_clock_skew" json:"max_clock_skew" jsonschema:"default=5 minutes,title=Maximum Clock Skew" jsonschema_description:"The maximum permitted difference between the clock of the client and the server."`
+}
+
+// AuthenticationBackendClientCertificate represents the configuration related to mutual TLS client certificate
+// authentication. The verified client certificates are mapped to users of the configured authentication backend.
+type AuthenticationBackendClientCertificate struct {
+	Mapping          string `koanf:"mapping" json:"mapping" jsonschema:"default=email,enum=email,enum=upn,enum=subject_dn,title=Mapping" jsonschema_description:"The certificate value which is mapped to the username."`
+	UsernameTemplate string `koanf:"username_template" json:"username_template" jsonschema:"title=Username Template" jsonschema_description:"The template used to produce the username from the certificate. Defaults to the value of the mapping."`
+
+	Forwarded AuthenticationBackendClientCertificateForwarded `koanf:"forwarded" json:"forwarded" jsonschema:"title=Forwarded" jsonschema_description:"The configuration for client certificates forwarded by trusted reverse proxies."`
+}
+
+// AuthenticationBackendClientCertificateForwarded represents the configuration related to client certificates
+// forwarded by trusted reverse proxies via a header.
+type AuthenticationBackendClientCertificateForwarded struct {
+	Header                 string   `koanf:"header" json:"header" jsonschema:"default=X-Forwarded-Client-Cert,title=Header" jsonschema_description:"The name of the header the reverse proxy forwards the client certificate with."`
+	TrustedProxies         []string `koanf:"trusted_proxies" json:"trusted_proxies" jsonschema:"title=Trusted Proxies" jsonschema_description:"The IP addresses or networks of the reverse proxies which are trusted to forward client certificates."`
+	CertificateAuthorities []string `koanf:"certificate_authorities" json:"certificate_authorities" jsonschema:"title=Certificate Authorities" jsonschema_description:"Paths to the certificate authorities used to verify the forwarded client certificates."`
+}
+
+// AuthenticationBackendChainBackend represents the configuration related to an individual chained authentication
+// backend.
+type AuthenticationBackendChainBackend struct {
+	Name     string `koanf:"name" json:"name" jsonschema:"title=Name" jsonschema_description:"The unique name of the backend."`
+	Optional bool   `koanf:"optional" json:"optional" jsonschema:"default=false,title=Optional" jsonschema_description:"Allows Authelia to start when the startup check for this backend fails."`
+
+	Domains         []string       `koanf:"domains" json:"domains" jsonschema:"title=Domains" jsonschema_description:"The username domains which are routed to this backend."`
+	UsernamePattern *regexp.Regexp `koanf:"username_pattern" json:"username_pattern" jsonschema:"title=Username Pattern" jsonschema_description:"The regex pattern which routes matching usernames to this backend."`
+
+	File *AuthenticationBackendFile `koanf:"file" json:"file" jsonschema:"title=File Backend" jsonschema_description:"The file authentication backend configuration."`
+	LDAP *AuthenticationBackendLDAP `koanf:"ldap" json:"ldap" jsonschema:"title=LDAP Backend" jsonschema_description:"The LDAP authentication backend configuration."`
+	SQL  *AuthenticationBackendSQL  `koanf:"sql" json:"sql" jsonschema:"title=SQL Backend" jsonschema_description:"The SQL authentication backend configuration which stores users in the storage backend."`
 }
 
 // AuthenticationBackendPasswordReset represents the configuration related to password reset functionality.
@@ -39,6 +111,19 @@ type AuthenticationBackendFileSearch struct {
 	CaseInsensitive bool `koanf:"case_insensitive" json:"case_insensitive" jsonschema:"default=false,title=Case Insensitive Searching" jsonschema_description:"Allows usernames to be any case during the search."`
 }
 
+// AuthenticationBackendSQL represents the configuration related to the SQL backend which stores users using the
+// storage provider.
+type AuthenticationBackendSQL struct {
+	Password AuthenticationBackendFilePassword `koanf:"password" json:"password" jsonschema:"title=Password Options" jsonschema_description:"Allows configuration of the password hashing options when the user passwords are changed directly by Authelia."`
+
+	Search AuthenticationBackendSQLSearch `koanf:"search" json:"search" jsonschema:"title=Search" jsonschema_description:"Configures the user searching behaviour."`
+}
+
+// AuthenticationBackendSQLSearch represents the configuration related to SQL backend searching.
+type AuthenticationBackendSQLSearch struct {
+	Email bool `koanf:"email" json:"email" jsonschema:"default=false,title=Email Searching" jsonschema_description:"Allows users to either use their username or their configured email as a username."`
+}
+
 // AuthenticationBackendFilePassword represents the configuration related to password hashing.
 type AuthenticationBackendFilePassword struct {
 	Algorithm string `koanf:"algorithm" json:"algorithm" jsonschema:"default=argon2,enum=argon2,enum=sha2crypt,enum=pbkdf2,enum=bcrypt,enum=scrypt,title=Algorithm" jsonschema_description:"The password hashing algorithm to use."`
@@ -119,10 +204,18 @@ type AuthenticationBackendLDAP struct {
 
 	AdditionalGroupsDN string `koanf:"additional_groups_dn" json:"additional_groups_dn" jsonschema:"title=Additional Group Base" jsonschema_description:"The base in addition to the Base DN for all directory server operations for groups."`
 	GroupsFilter       string `koanf:"groups_filter" json:"groups_filter" jsonschema:"title=Groups Filter" jsonschema_description:"The LDAP filter used to search for group objects."`
-	GroupSearchMode    string `koanf:"group_search_mode" json:"group_search_mode" jsonschema:"default=filter,enum=filter,enum=memberof,title=Groups Search Mode" jsonschema_description:"The LDAP group search mode used to search for group objects."`
+	GroupSearchMode    string `koanf:"group_search_mode" json:"group_search_mode" jsonschema:"default=filter,enum=filter,enum=memberof,enum=nested,title=Groups Search Mode" jsonschema_description:"The LDAP group search mode used to search for group objects."`
+
+	NestedGroupsMaxDepth int `koanf:"nested_groups_max_depth" json:"nested_groups_max_depth" jsonschema:"default=10,title=Nested Groups Maximum Depth" jsonschema_description:"The maximum depth of nested groups resolved when using the nested group search mode."`
 
 	Attributes AuthenticationBackendLDAPAttributes `koanf:"attributes" json:"attributes"`
 
+	Administration AuthenticationBackendLDAPAdministration `koanf:"administration" json:"administration" jsonschema:"title=Administration" jsonschema_description:"The LDAP directory server user and group administration configuration."`
+
+	Pooling AuthenticationBackendLDAPPooling `koanf:"pooling" json:"pooling" jsonschema:"title=Pooling" jsonschema_description:"The LDAP directory server connection pooling configuration."`
+
+	Failover AuthenticationBackendLDAPFailover `koanf:"failover" json:"failover" jsonschema:"title=Failover" jsonschema_description:"The LDAP directory server failover configuration."`
+
 	PermitReferrals               bool `koanf:"permit_referrals" json:"permit_referrals" jsonschema:"default=false,title=Permit Referrals" jsonschema_description:"Enables chasing LDAP referrals."`
 	PermitUnauthenticatedBind     bool `koanf:"permit_unauthenticated_bind" json:"permit_unauthenticated_bind" jsonschema:"default=false,title=Permit Unauthenticated Bind" jsonschema_description:"Enables omission of the password to perform an unauthenticated bind."`
 	PermitFeatureDetectionFailure bool `koanf:"permit_feature_detection_failure" json:"permit_feature_detection_failure" jsonschema:"default=false,title=Permit Feature Detection Failure" jsonschema_description:"Enables failures when detecting directory server features using the Root DSE lookup."`
@@ -139,12 +232,53 @@ type AuthenticationBackendLDAPAttributes struct {
 	Mail              string `koanf:"mail" json:"mail" jsonschema:"title=Attribute: User Mail" jsonschema_description:"The directory server attribute which contains the mail address for all users and groups."`
 	MemberOf          string `koanf:"member_of" jsonschema:"title=Attribute: Member Of" jsonschema_description:"The directory server attribute which contains the objects that an object is a member of."`
 	GroupName         string `koanf:"group_name" json:"group_name" jsonschema:"title=Attribute: Group Name" jsonschema_description:"The directory server attribute which contains the group name for all groups."`
+
+	Extra map[string]string `koanf:"extra" json:"extra" jsonschema:"title=Attribute: Extra" jsonschema_description:"The mapping of extended user attribute names to the directory server attributes which contain their values."`
+}
+
+// AuthenticationBackendLDAPAdministration represents the configuration related to administering LDAP users and groups.
+type AuthenticationBackendLDAPAdministration struct {
+	Enable                bool     `koanf:"enable" json:"enable" jsonschema:"default=false,title=Enable" jsonschema_description:"Enables creating users, disabling users, and managing group memberships via the directory server."`
+	UsersRDNAttribute     string   `koanf:"users_rdn_attribute" json:"users_rdn_attribute" jsonschema:"title=Users RDN Attribute" jsonschema_description:"The directory server attribute used as the relative distinguished name of newly created users."`
+	UsersObjectClasses    []string `koanf:"users_object_classes" json:"users_object_classes" jsonschema:"title=Users Object Classes" jsonschema_description:"The object classes of newly created users."`
+	GroupsObjectClass     string   `koanf:"groups_object_class" json:"groups_object_class" jsonschema:"title=Groups Object Class" jsonschema_description:"The object class used to find groups when managing group memberships."`
+	GroupsMemberAttribute string   `koanf:"groups_member_attribute" json:"groups_member_attribute" jsonschema:"title=Groups Member Attribute" jsonschema_description:"The directory server attribute which contains the members of a group."`
+}
+
+// AuthenticationBackendLDAPPooling represents the configuration related to pooling LDAP connections.
+type AuthenticationBackendLDAPPooling struct {
+	Enable              bool          `koanf:"enable" json:"enable" jsonschema:"default=false,title=Enable" jsonschema_description:"Enables pooling of the connections bound as the service account."`
+	MaxOpen             int           `koanf:"max_open" json:"max_open" jsonschema:"default=10,title=Maximum Open" jsonschema_description:"The maximum number of pooled connections which may be in use at any one time."`
+	MaxIdle             int           `koanf:"max_idle" json:"max_idle" jsonschema:"default=5,title=Maximum Idle" jsonschema_description:"The maximum number of idle connections retained by the pool."`
+	Timeout             time.Duration `koanf:"timeout" json:"timeout" jsonschema:"default=10 seconds,title=Timeout" jsonschema_description:"The maximum amount of time to wait for a pooled connection to become available."`
+	HealthCheckInterval time.Duration `koanf:"health_check_interval" json:"health_check_interval" jsonschema:"default=1 minute,title=Health Check Interval" jsonschema_description:"The interval between health checks of the idle connections."`
+}
+
+// AuthenticationBackendLDAPFailover represents the configuration related to failing over between LDAP servers.
+type AuthenticationBackendLDAPFailover struct {
+	Addresses  []*AddressLDAP `koanf:"addresses" json:"addresses" jsonschema:"title=Addresses" jsonschema_description:"The addresses of the additional LDAP directory servers which are used when the address is unavailable."`
+	Strategy   string         `koanf:"strategy" json:"strategy" jsonschema:"default=ordered,enum=ordered,enum=random,title=Strategy" jsonschema_description:"The strategy used to select the order the LDAP directory servers are connected to."`
+	Backoff    time.Duration  `koanf:"backoff" json:"backoff" jsonschema:"default=5 seconds,title=Backoff" jsonschema_description:"The initial amount of time a LDAP directory server is skipped for after it fails to dial or bind."`
+	MaxBackoff time.Duration  `koanf:"max_backoff" json:"max_backoff" jsonschema:"default=5 minutes,title=Maximum Backoff" jsonschema_description:"The maximum amount of time a LDAP directory server is skipped for after consecutive failures."`
 }
 
 var DefaultAuthenticationBackendConfig = AuthenticationBackend{
 	RefreshInterval: NewRefreshIntervalDuration(time.Minute * 5),
 }
 
+// DefaultAuthenticationBackendKerberos represents the default Kerberos configuration.
+var DefaultAuthenticationBackendKerberos = AuthenticationBackendKerberos{
+	MaxClockSkew: time.Minute * 5,
+}
+
+// DefaultAuthenticationBackendClientCertificate represents the default client certificate configuration.
+var DefaultAuthenticationBackendClientCertificate = AuthenticationBackendClientCertificate{
+	Mapping: ClientCertificateMappingEmail,
+	Forwarded: AuthenticationBackendClientCertificateForwarded{
+		Header: "X-Forwarded-Client-Cert",
+	},
+}
+
 // DefaultPasswordConfig represents the default configuration related to Argon2id hashing.
 var DefaultPasswordConfig = AuthenticationBackendFilePassword{
 	Algorithm: argon2,
@@ -196,6 +330,24 @@ var DefaultCIPasswordConfig = AuthenticationBackendFilePassword{
 	},
 }
 
+// DefaultLDAPAuthenticationBackendNestedGroupsMaxDepth represents the default maximum depth of nested LDAP groups.
+const DefaultLDAPAuthenticationBackendNestedGroupsMaxDepth = 10
+
+// DefaultLDAPAuthenticationBackendConfigurationPooling represents the default LDAP connection pooling config.
+var DefaultLDAPAuthenticationBackendConfigurationPooling = AuthenticationBackendLDAPPooling{
+	MaxOpen:             10,
+	MaxIdle:             5,
+	Timeout:             time.Second * 10,
+	HealthCheckInterval: time.Minute,
+}
+
+// DefaultLDAPAuthenticationBackendConfigurationFailover represents the default LDAP failover config.
+var DefaultLDAPAuthenticationBackendConfigurationFailover = AuthenticationBackendLDAPFailover{
+	Strategy:   LDAPFailoverStrategyOrdered,
+	Backoff:    time.Second * 5,
+	MaxBackoff: time.Minute * 5,
+}
+
 // DefaultLDAPAuthenticationBackendConfigurationImplementationCustom represents the default LDAP config.
 var DefaultLDAPAuthenticationBackendConfigurationImplementationCustom = AuthenticationBackendLDAP{
 	GroupSearchMode: ldapGroupSearchModeFilter,
@@ -205,6 +357,12 @@ var DefaultLDAPAuthenticationBackendConfigurationImplementationCustom = Authenti
 		Mail:        ldapAttrMail,
 		GroupName:   ldapAttrCommonName,
 	},
+	Administration: AuthenticationBackendLDAPAdministration{
+		UsersRDNAttribute:     ldapAttrUserID,
+		UsersObjectClasses:    []string{"top", "person", "organizationalPerson", "inetOrgPerson"},
+		GroupsObjectClass:     ldapObjectClassGroupOfNames,
+		GroupsMemberAttribute: ldapAttrMember,
+	},
 	Timeout: time.Second * 5,
 	TLS: &TLS{
 		MinimumVersion: TLSVersion{tls.VersionTLS12},
@@ -224,6 +382,12 @@ var DefaultLDAPAuthenticationBackendConfigurationImplementationActiveDirectory =
 		MemberOf:          ldapAttrMemberOf,
 		GroupName:         ldapAttrCommonName,
 	},
+	Administration: AuthenticationBackendLDAPAdministration{
+		UsersRDNAttribute:     ldapAttrCommonName,
+		UsersObjectClasses:    []string{"top", "person", "organizationalPerson", "user"},
+		GroupsObjectClass:     ldapObjectClassGroup,
+		GroupsMemberAttribute: ldapAttrMember,
+	},
 	Timeout: time.Second * 5,
 	TLS: &TLS{
 		MinimumVersion: TLSVersion{tls.VersionTLS12},
@@ -242,6 +406,12 @@ var DefaultLDAPAuthenticationBackendConfigurationImplementationRFC2307bis = Auth
 		MemberOf:    ldapAttrMemberOf,
 		GroupName:   ldapAttrCommonName,
 	},
+	Administration: AuthenticationBackendLDAPAdministration{
+		UsersRDNAttribute:     ldapAttrUserID,
+		UsersObjectClasses:    []string{"top", "person", "organizationalPerson", "inetOrgPerson"},
+		GroupsObjectClass:     ldapObjectClassGroupOfNames,
+		GroupsMemberAttribute: ldapAttrMember,
+	},
 	Timeout: time.Second * 5,
 	TLS: &TLS{
 		MinimumVersion: TLSVersion{tls.VersionTLS12},
@@ -260,6 +430,12 @@ var DefaultLDAPAuthenticationBackendConfigurationImplementationFreeIPA = Authent
 		MemberOf:    ldapAttrMemberOf,
 		GroupName:   ldapAttrCommonName,
 	},
+	Administration: AuthenticationBackendLDAPAdministration{
+		UsersRDNAttribute:     ldapAttrUserID,
+		UsersObjectClasses:    []string{"top", "person", "organizationalPerson", "inetOrgPerson", "inetUser"},
+		GroupsObjectClass:     ldapObjectClassGroupOfNames,
+		GroupsMemberAttribute: ldapAttrMember,
+	},
 	Timeout: time.Second * 5,
 	TLS: &TLS{
 		MinimumVersion: TLSVersion{tls.VersionTLS12},
@@ -280,6 +456,12 @@ var DefaultLDAPAuthenticationBackendConfigurationImplementationLLDAP = Authentic
 		MemberOf:    ldapAttrMemberOf,
 		GroupName:   ldapAttrCommonName,
 	},
+	Administration: AuthenticationBackendLDAPAdministration{
+		UsersRDNAttribute:     ldapAttrUserID,
+		UsersObjectClasses:    []string{"person", "inetOrgPerson"},
+		GroupsObjectClass:     ldapObjectClassGroupOfUniqueNames,
+		GroupsMemberAttribute: ldapAttrMember,
+	},
 	Timeout: time.Second * 5,
 	TLS: &TLS{
 		MinimumVersion: TLSVersion{tls.VersionTLS12},
@@ -81,6 +81,26 @@ const (
 
 	// LDAPGroupSearchModeMemberOf is the string for the memberOf group search mode.
 	LDAPGroupSearchModeMemberOf = "memberof"
+
+	// LDAPGroupSearchModeNested is the string for the nested group search mode.
+	LDAPGroupSearchModeNested = "nested"
+)
+
+const (
+	// LDAPFailoverStrategyOrdered is the string for the ordered LDAP failover strategy.
+	LDAPFailoverStrategyOrdered = "ordered"
+
+	// LDAPFailoverStrategyRandom is the string for the random LDAP failover strategy.
+	LDAPFailoverStrategyRandom = "random"
+)
+
+const (
+	// AuthenticationBackendChainConflictModePrecedence is the string for the precedence chained authentication backend
+	// conflict mode.
+	AuthenticationBackendChainConflictModePrecedence = "precedence"
+
+	// AuthenticationBackendChainConflictModeDeny is the string for the deny chained authentication backend conflict mode.
+	AuthenticationBackendChainConflictModeDeny = "deny"
 )
 
 // TOTP Algorithm.
@@ -151,6 +171,21 @@ const (
 	AuthzStrategyHeaderProxyAuthorization            = "HeaderProxyAuthorization"
 	AuthzStrategyHeaderAuthRequestProxyAuthorization = "HeaderAuthRequestProxyAuthorization"
 	AuthzStrategyHeaderLegacy                        = "HeaderLegacy"
+	AuthzStrategyHeaderSPNEGO                        = "SPNEGO"
+	AuthzStrategyClientCertificate                   = "ClientCertificate"
+)
+
+// Client certificate mapping values.
+const (
+	ClientCertificateMappingEmail     = "email"
+	ClientCertificateMappingUPN       = "upn"
+	ClientCertificateMappingSubjectDN = "subject_dn"
+)
+
+// Server TLS client authentication values.
+const (
+	ServerTLSClientAuthenticationRequire  = "require"
+	ServerTLSClientAuthenticationOptional = "optional"
 )
 
 const (
@@ -166,6 +201,13 @@ const (
 	ldapAttrDescription       = "description"
 	ldapAttrCommonName        = "cn"
 	ldapAttrMemberOf          = "memberOf"
+	ldapAttrMember            = "member"
+)
+
+const (
+	ldapObjectClassGroup              = "group"
+	ldapObjectClassGroupOfNames       = "groupOfNames"
+	ldapObjectClassGroupOfUniqueNames = "groupOfUniqueNames"
 )
 
 // Address Schemes.
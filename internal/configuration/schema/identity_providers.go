@@ -33,6 +33,8 @@ type IdentityProvidersOpenIDConnect struct {
 
 	Clients []IdentityProvidersOpenIDConnectClient `koanf:"clients" json:"clients" jsonschema:"title=Clients" jsonschema_description:"OpenID Connect 1.0 clients registry."`
 
+	DynamicClientRegistration IdentityProvidersOpenIDConnectDynamicClientRegistration `koanf:"dynamic_client_registration" json:"dynamic_client_registration" jsonschema:"title=Dynamic Client Registration" jsonschema_description:"Configuration options for OAuth 2.0 Dynamic Client Registration."`
+
 	AuthorizationPolicies map[string]IdentityProvidersOpenIDConnectPolicy `koanf:"authorization_policies" json:"authorization_policies" jsonschema:"title=Authorization Policies" jsonschema_description:"Custom client authorization policies."`
 	Lifespans             IdentityProvidersOpenIDConnectLifespans         `koanf:"lifespans" json:"lifespans" jsonschema:"title=Lifespans" jsonschema_description:"Token lifespans configuration."`
 
@@ -71,6 +73,8 @@ type IdentityProvidersOpenIDConnectDiscovery struct {
 type IdentityProvidersOpenIDConnectLifespans struct {
 	IdentityProvidersOpenIDConnectLifespanToken `koanf:",squash"`
 	JWTSecuredAuthorization                     time.Duration `koanf:"jwt_secured_authorization" json:"jwt_secured_authorization" jsonschema:"default=5 minutes,title=JARM" jsonschema_description:"Allows tuning the token lifespan for the JWT Secured Authorization Response Mode (JARM)."`
+	DeviceCode                                  time.Duration `koanf:"device_code" json:"device_code" jsonschema:"default=10 minutes,title=Device Code" jsonschema_description:"The duration a Device Code and User Code issued by the Device Authorization Endpoint are valid for."`
+	DeviceCodePollingInterval                   time.Duration `koanf:"device_code_polling_interval" json:"device_code_polling_interval" jsonschema:"default=10 seconds,title=Device Code Polling Interval" jsonschema_description:"The minimum duration clients must wait between polling requests to the Token Endpoint when using the Device Code Grant."`
 
 	Custom map[string]IdentityProvidersOpenIDConnectLifespan `koanf:"custom" json:"custom" jsonschema:"title=Custom Lifespans" jsonschema_description:"Allows creating custom lifespans to be used by individual clients."`
 }
@@ -89,6 +93,8 @@ type IdentityProvidersOpenIDConnectLifespanGrants struct {
 	ClientCredentials IdentityProvidersOpenIDConnectLifespanToken `koanf:"client_credentials" json:"client_credentials" jsonschema:"title=Client Credentials Grant" jsonschema_description:"Allows tuning the token lifespans for the client credentials grant."`
 	RefreshToken      IdentityProvidersOpenIDConnectLifespanToken `koanf:"refresh_token" json:"refresh_token" jsonschema:"title=Refresh Token Grant" jsonschema_description:"Allows tuning the token lifespans for the refresh token grant."`
 	JWTBearer         IdentityProvidersOpenIDConnectLifespanToken `koanf:"jwt_bearer" json:"jwt_bearer" jsonschema:"title=JWT Bearer Grant" jsonschema_description:"Allows tuning the token lifespans for the JWT bearer grant."`
+	DeviceCode        IdentityProvidersOpenIDConnectLifespanToken `koanf:"device_code" json:"device_code" jsonschema:"title=Device Code Grant" jsonschema_description:"Allows tuning the token lifespans for the device code grant."`
+	TokenExchange     IdentityProvidersOpenIDConnectLifespanToken `koanf:"token_exchange" json:"token_exchange" jsonschema:"title=Token Exchange Grant" jsonschema_description:"Allows tuning the token lifespans for the token exchange grant."`
 }
 
 // IdentityProvidersOpenIDConnectLifespanToken allows tuning the lifespans for each token type.
@@ -101,12 +107,21 @@ type IdentityProvidersOpenIDConnectLifespanToken struct {
 
 // IdentityProvidersOpenIDConnectCORS represents an OpenID Connect 1.0 CORS config.
 type IdentityProvidersOpenIDConnectCORS struct {
-	Endpoints      []string   `koanf:"endpoints" json:"endpoints" jsonschema:"uniqueItems,enum=authorization,enum=pushed-authorization-request,enum=token,enum=introspection,enum=revocation,enum=userinfo,title=Endpoints" jsonschema_description:"List of endpoints to enable CORS handling for."`
+	Endpoints      []string   `koanf:"endpoints" json:"endpoints" jsonschema:"uniqueItems,enum=authorization,enum=pushed-authorization-request,enum=token,enum=introspection,enum=revocation,enum=userinfo,enum=end-session,enum=device-authorization,title=Endpoints" jsonschema_description:"List of endpoints to enable CORS handling for."`
 	AllowedOrigins []*url.URL `koanf:"allowed_origins" json:"allowed_origins" jsonschema:"format=uri,title=Allowed Origins" jsonschema_description:"List of arbitrary allowed origins for CORS requests."`
 
 	AllowedOriginsFromClientRedirectURIs bool `koanf:"allowed_origins_from_client_redirect_uris" json:"allowed_origins_from_client_redirect_uris" jsonschema:"default=false,title=Allowed Origins From Client Redirect URIs" jsonschema_description:"Automatically include the redirect URIs from the registered clients."`
 }
 
+// IdentityProvidersOpenIDConnectDynamicClientRegistration represents an OpenID Connect 1.0 Dynamic Client Registration
+// config.
+type IdentityProvidersOpenIDConnectDynamicClientRegistration struct {
+	Enabled             bool   `koanf:"enabled" json:"enabled" jsonschema:"default=false,title=Enabled" jsonschema_description:"Enables the Dynamic Client Registration endpoint."`
+	InitialAccessToken  string `koanf:"initial_access_token" json:"initial_access_token" jsonschema:"title=Initial Access Token" jsonschema_description:"The Initial Access Token which must be provided as a bearer token to register clients."`
+	AuthorizationPolicy string `koanf:"authorization_policy" json:"authorization_policy" jsonschema:"default=two_factor,title=Authorization Policy" jsonschema_description:"The Authorization Policy to apply to dynamically registered clients."`
+	Lifespan            string `koanf:"lifespan" json:"lifespan" jsonschema:"title=Lifespan Name" jsonschema_description:"The name of the custom lifespan to utilize for dynamically registered clients."`
+}
+
 // IdentityProvidersOpenIDConnectClient represents a configuration for an OpenID Connect 1.0 client.
 type IdentityProvidersOpenIDConnectClient struct {
 	ID                  string          `koanf:"client_id" json:"client_id" jsonschema:"required,minLength=1,title=Client ID" jsonschema_description:"The Client ID."`
@@ -118,9 +133,16 @@ type IdentityProvidersOpenIDConnectClient struct {
 	RedirectURIs IdentityProvidersOpenIDConnectClientURIs `koanf:"redirect_uris" json:"redirect_uris" jsonschema:"title=Redirect URIs" jsonschema_description:"List of whitelisted redirect URIs."`
 	RequestURIs  IdentityProvidersOpenIDConnectClientURIs `koanf:"request_uris" json:"request_uris" jsonschema:"title=Request URIs" jsonschema_description:"List of whitelisted request URIs."`
 
+	PostLogoutRedirectURIs IdentityProvidersOpenIDConnectClientURIs `koanf:"post_logout_redirect_uris" json:"post_logout_redirect_uris" jsonschema:"title=Post Logout Redirect URIs" jsonschema_description:"List of whitelisted post logout redirect URIs."`
+
+	BackChannelLogoutURI              *url.URL `koanf:"backchannel_logout_uri" json:"backchannel_logout_uri" jsonschema:"title=Back-Channel Logout URI" jsonschema_description:"URI which the provider sends Logout Tokens to when the End-User logs out."`
+	BackChannelLogoutSessionRequired  bool     `koanf:"backchannel_logout_session_required" json:"backchannel_logout_session_required" jsonschema:"default=false,title=Back-Channel Logout Session Required" jsonschema_description:"Requires the 'sid' claim to be included in Logout Tokens sent to this client."`
+	FrontChannelLogoutURI             *url.URL `koanf:"frontchannel_logout_uri" json:"frontchannel_logout_uri" jsonschema:"title=Front-Channel Logout URI" jsonschema_description:"URI which the provider renders in an iframe when the End-User logs out."`
+	FrontChannelLogoutSessionRequired bool     `koanf:"frontchannel_logout_session_required" json:"frontchannel_logout_session_required" jsonschema:"default=false,title=Front-Channel Logout Session Required" jsonschema_description:"Requires the 'iss' and 'sid' query parameters to be included in the Front-Channel Logout URI for this client."`
+
 	Audience      []string `koanf:"audience" json:"audience" jsonschema:"uniqueItems,title=Audience" jsonschema_description:"List of authorized audiences."`
 	Scopes        []string `koanf:"scopes" json:"scopes" jsonschema:"required,enum=openid,enum=offline_access,enum=groups,enum=email,enum=profile,enum=authelia.bearer.authz,uniqueItems,title=Scopes" jsonschema_description:"The Scopes this client is allowed request and be granted."`
-	GrantTypes    []string `koanf:"grant_types" json:"grant_types" jsonschema:"enum=authorization_code,enum=implicit,enum=refresh_token,enum=client_credentials,uniqueItems,title=Grant Types" jsonschema_description:"The Grant Types this client is allowed to use for the protected endpoints."`
+	GrantTypes    []string `koanf:"grant_types" json:"grant_types" jsonschema:"enum=authorization_code,enum=implicit,enum=refresh_token,enum=client_credentials,enum=urn:ietf:params:oauth:grant-type:device_code,enum=urn:ietf:params:oauth:grant-type:token-exchange,uniqueItems,title=Grant Types" jsonschema_description:"The Grant Types this client is allowed to use for the protected endpoints."`
 	ResponseTypes []string `koanf:"response_types" json:"response_types" jsonschema:"enum=code,enum=id_token token,enum=id_token,enum=token,enum=code token,enum=code id_token,enum=code id_token token,uniqueItems,title=Response Types" jsonschema_description:"The Response Types the client is authorized to request."`
 	ResponseModes []string `koanf:"response_modes" json:"response_modes" jsonschema:"enum=form_post,enum=form_post.jwt,enum=query,enum=query.jwt,enum=fragment,enum=fragment.jwt,enum=jwt,uniqueItems,title=Response Modes" jsonschema_description:"The Response Modes this client is authorized request."`
 
@@ -131,6 +153,8 @@ type IdentityProvidersOpenIDConnectClient struct {
 	ConsentMode                  string         `koanf:"consent_mode" json:"consent_mode" jsonschema:"enum=auto,enum=explicit,enum=implicit,enum=pre-configured,title=Consent Mode" jsonschema_description:"The Consent Mode used for this client."`
 	ConsentPreConfiguredDuration *time.Duration `koanf:"pre_configured_consent_duration" json:"pre_configured_consent_duration" jsonschema:"default=7 days,title=Pre-Configured Consent Duration" jsonschema_description:"The Pre-Configured Consent Duration when using Consent Mode pre-configured for this client."`
 
+	TokenExchange IdentityProvidersOpenIDConnectClientTokenExchange `koanf:"token_exchange" json:"token_exchange" jsonschema:"title=Token Exchange" jsonschema_description:"The Token Exchange policy for this client."`
+
 	RequirePushedAuthorizationRequests bool `koanf:"require_pushed_authorization_requests" json:"require_pushed_authorization_requests" jsonschema:"default=false,title=Require Pushed Authorization Requests" jsonschema_description:"Requires Pushed Authorization Requests for this client to perform an authorization."`
 	RequirePKCE                        bool `koanf:"require_pkce" json:"require_pkce" jsonschema:"default=false,title=Require PKCE" jsonschema_description:"Requires a Proof Key for this client to perform Code Exchange."`
 
@@ -158,6 +182,13 @@ type IdentityProvidersOpenIDConnectClient struct {
 	Discovery IdentityProvidersOpenIDConnectDiscovery `json:"-"` // MetaData value. Not configurable by users.
 }
 
+// IdentityProvidersOpenIDConnectClientTokenExchange represents the Token Exchange policy for an OpenID Connect 1.0 client.
+type IdentityProvidersOpenIDConnectClientTokenExchange struct {
+	SubjectTokenAudiences []string `koanf:"subject_token_audiences" json:"subject_token_audiences" jsonschema:"uniqueItems,title=Subject Token Audiences" jsonschema_description:"List of audiences which a Subject Token must have been issued to (or the Client ID it was issued to) for this client to exchange it."`
+	TargetAudiences       []string `koanf:"target_audiences" json:"target_audiences" jsonschema:"uniqueItems,title=Target Audiences" jsonschema_description:"List of audiences this client is permitted to request when exchanging a Subject Token."`
+	ActorTokenClients     []string `koanf:"actor_token_clients" json:"actor_token_clients" jsonschema:"uniqueItems,title=Actor Token Clients" jsonschema_description:"List of Client IDs in addition to this client which an Actor Token may have been issued to for this client to use it."`
+}
+
 // DefaultOpenIDConnectConfiguration contains defaults for OIDC.
 var DefaultOpenIDConnectConfiguration = IdentityProvidersOpenIDConnect{
 	Lifespans: IdentityProvidersOpenIDConnectLifespans{
@@ -167,6 +198,8 @@ var DefaultOpenIDConnectConfiguration = IdentityProvidersOpenIDConnect{
 			IDToken:       time.Hour,
 			RefreshToken:  time.Minute * 90,
 		},
+		DeviceCode:                time.Minute * 10,
+		DeviceCodePollingInterval: time.Second * 10,
 	},
 	EnforcePKCE: "public_clients_only",
 }
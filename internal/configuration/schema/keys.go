@@ -41,6 +41,11 @@ var Keys = []string{
 	"identity_providers.oidc.clients[].public",
 	"identity_providers.oidc.clients[].redirect_uris",
 	"identity_providers.oidc.clients[].request_uris",
+	"identity_providers.oidc.clients[].post_logout_redirect_uris",
+	"identity_providers.oidc.clients[].backchannel_logout_uri",
+	"identity_providers.oidc.clients[].backchannel_logout_session_required",
+	"identity_providers.oidc.clients[].frontchannel_logout_uri",
+	"identity_providers.oidc.clients[].frontchannel_logout_session_required",
 	"identity_providers.oidc.clients[].audience",
 	"identity_providers.oidc.clients[].scopes",
 	"identity_providers.oidc.clients[].grant_types",
@@ -51,6 +56,9 @@ var Keys = []string{
 	"identity_providers.oidc.clients[].requested_audience_mode",
 	"identity_providers.oidc.clients[].consent_mode",
 	"identity_providers.oidc.clients[].pre_configured_consent_duration",
+	"identity_providers.oidc.clients[].token_exchange.subject_token_audiences",
+	"identity_providers.oidc.clients[].token_exchange.target_audiences",
+	"identity_providers.oidc.clients[].token_exchange.actor_token_clients",
 	"identity_providers.oidc.clients[].require_pushed_authorization_requests",
 	"identity_providers.oidc.clients[].require_pkce",
 	"identity_providers.oidc.clients[].pkce_challenge_method",
@@ -76,6 +84,10 @@ var Keys = []string{
 	"identity_providers.oidc.clients[].jwks[].key",
 	"identity_providers.oidc.clients[].jwks[].certificate_chain",
 	"identity_providers.oidc.clients[]",
+	"identity_providers.oidc.dynamic_client_registration.enabled",
+	"identity_providers.oidc.dynamic_client_registration.initial_access_token",
+	"identity_providers.oidc.dynamic_client_registration.authorization_policy",
+	"identity_providers.oidc.dynamic_client_registration.lifespan",
 	"identity_providers.oidc.authorization_policies",
 	"identity_providers.oidc.authorization_policies.*.default_policy",
 	"identity_providers.oidc.authorization_policies.*.rules",
@@ -86,6 +98,8 @@ var Keys = []string{
 	"identity_providers.oidc.lifespans.id_token",
 	"identity_providers.oidc.lifespans.refresh_token",
 	"identity_providers.oidc.lifespans.jwt_secured_authorization",
+	"identity_providers.oidc.lifespans.device_code",
+	"identity_providers.oidc.lifespans.device_code_polling_interval",
 	"identity_providers.oidc.lifespans.custom",
 	"identity_providers.oidc.lifespans.custom.*.access_token",
 	"identity_providers.oidc.lifespans.custom.*.authorize_code",
@@ -111,6 +125,14 @@ var Keys = []string{
 	"identity_providers.oidc.lifespans.custom.*.grants.jwt_bearer.authorize_code",
 	"identity_providers.oidc.lifespans.custom.*.grants.jwt_bearer.id_token",
 	"identity_providers.oidc.lifespans.custom.*.grants.jwt_bearer.refresh_token",
+	"identity_providers.oidc.lifespans.custom.*.grants.device_code.access_token",
+	"identity_providers.oidc.lifespans.custom.*.grants.device_code.authorize_code",
+	"identity_providers.oidc.lifespans.custom.*.grants.device_code.id_token",
+	"identity_providers.oidc.lifespans.custom.*.grants.device_code.refresh_token",
+	"identity_providers.oidc.lifespans.custom.*.grants.token_exchange.access_token",
+	"identity_providers.oidc.lifespans.custom.*.grants.token_exchange.authorize_code",
+	"identity_providers.oidc.lifespans.custom.*.grants.token_exchange.id_token",
+	"identity_providers.oidc.lifespans.custom.*.grants.token_exchange.refresh_token",
 	"identity_providers.oidc",
 	"identity_providers.oidc.issuer_certificate_chain",
 	"identity_providers.oidc.issuer_private_key",
@@ -162,17 +184,173 @@ var Keys = []string{
 	"authentication_backend.ldap.additional_groups_dn",
 	"authentication_backend.ldap.groups_filter",
 	"authentication_backend.ldap.group_search_mode",
+	"authentication_backend.ldap.nested_groups_max_depth",
 	"authentication_backend.ldap.attributes.distinguished_name",
 	"authentication_backend.ldap.attributes.username",
 	"authentication_backend.ldap.attributes.display_name",
 	"authentication_backend.ldap.attributes.mail",
 	"authentication_backend.ldap.attributes.member_of",
 	"authentication_backend.ldap.attributes.group_name",
+	"authentication_backend.ldap.attributes.extra",
+	"authentication_backend.ldap.administration.enable",
+	"authentication_backend.ldap.administration.users_rdn_attribute",
+	"authentication_backend.ldap.administration.users_object_classes",
+	"authentication_backend.ldap.administration.groups_object_class",
+	"authentication_backend.ldap.administration.groups_member_attribute",
+	"authentication_backend.ldap.pooling.enable",
+	"authentication_backend.ldap.pooling.max_open",
+	"authentication_backend.ldap.pooling.max_idle",
+	"authentication_backend.ldap.pooling.timeout",
+	"authentication_backend.ldap.pooling.health_check_interval",
+	"authentication_backend.ldap.failover.addresses",
+	"authentication_backend.ldap.failover.strategy",
+	"authentication_backend.ldap.failover.backoff",
+	"authentication_backend.ldap.failover.max_backoff",
 	"authentication_backend.ldap.permit_referrals",
 	"authentication_backend.ldap.permit_unauthenticated_bind",
 	"authentication_backend.ldap.permit_feature_detection_failure",
 	"authentication_backend.ldap.user",
 	"authentication_backend.ldap.password",
+	"authentication_backend.sql.password.algorithm",
+	"authentication_backend.sql.password.argon2.variant",
+	"authentication_backend.sql.password.argon2.iterations",
+	"authentication_backend.sql.password.argon2.memory",
+	"authentication_backend.sql.password.argon2.parallelism",
+	"authentication_backend.sql.password.argon2.key_length",
+	"authentication_backend.sql.password.argon2.salt_length",
+	"authentication_backend.sql.password.sha2crypt.variant",
+	"authentication_backend.sql.password.sha2crypt.iterations",
+	"authentication_backend.sql.password.sha2crypt.salt_length",
+	"authentication_backend.sql.password.pbkdf2.variant",
+	"authentication_backend.sql.password.pbkdf2.iterations",
+	"authentication_backend.sql.password.pbkdf2.salt_length",
+	"authentication_backend.sql.password.bcrypt.variant",
+	"authentication_backend.sql.password.bcrypt.cost",
+	"authentication_backend.sql.password.scrypt.iterations",
+	"authentication_backend.sql.password.scrypt.block_size",
+	"authentication_backend.sql.password.scrypt.parallelism",
+	"authentication_backend.sql.password.scrypt.key_length",
+	"authentication_backend.sql.password.scrypt.salt_length",
+	"authentication_backend.sql.password.iterations",
+	"authentication_backend.sql.password.memory",
+	"authentication_backend.sql.password.parallelism",
+	"authentication_backend.sql.password.key_length",
+	"authentication_backend.sql.password.salt_length",
+	"authentication_backend.sql.search.email",
+	"authentication_backend.chain.conflict_mode",
+	"authentication_backend.chain.backends",
+	"authentication_backend.chain.backends[].name",
+	"authentication_backend.chain.backends[].optional",
+	"authentication_backend.chain.backends[].domains",
+	"authentication_backend.chain.backends[].username_pattern",
+	"authentication_backend.chain.backends[].file.path",
+	"authentication_backend.chain.backends[].file.watch",
+	"authentication_backend.chain.backends[].file.password.algorithm",
+	"authentication_backend.chain.backends[].file.password.argon2.variant",
+	"authentication_backend.chain.backends[].file.password.argon2.iterations",
+	"authentication_backend.chain.backends[].file.password.argon2.memory",
+	"authentication_backend.chain.backends[].file.password.argon2.parallelism",
+	"authentication_backend.chain.backends[].file.password.argon2.key_length",
+	"authentication_backend.chain.backends[].file.password.argon2.salt_length",
+	"authentication_backend.chain.backends[].file.password.sha2crypt.variant",
+	"authentication_backend.chain.backends[].file.password.sha2crypt.iterations",
+	"authentication_backend.chain.backends[].file.password.sha2crypt.salt_length",
+	"authentication_backend.chain.backends[].file.password.pbkdf2.variant",
+	"authentication_backend.chain.backends[].file.password.pbkdf2.iterations",
+	"authentication_backend.chain.backends[].file.password.pbkdf2.salt_length",
+	"authentication_backend.chain.backends[].file.password.bcrypt.variant",
+	"authentication_backend.chain.backends[].file.password.bcrypt.cost",
+	"authentication_backend.chain.backends[].file.password.scrypt.iterations",
+	"authentication_backend.chain.backends[].file.password.scrypt.block_size",
+	"authentication_backend.chain.backends[].file.password.scrypt.parallelism",
+	"authentication_backend.chain.backends[].file.password.scrypt.key_length",
+	"authentication_backend.chain.backends[].file.password.scrypt.salt_length",
+	"authentication_backend.chain.backends[].file.password.iterations",
+	"authentication_backend.chain.backends[].file.password.memory",
+	"authentication_backend.chain.backends[].file.password.parallelism",
+	"authentication_backend.chain.backends[].file.password.key_length",
+	"authentication_backend.chain.backends[].file.password.salt_length",
+	"authentication_backend.chain.backends[].file.search.email",
+	"authentication_backend.chain.backends[].file.search.case_insensitive",
+	"authentication_backend.chain.backends[].ldap.address",
+	"authentication_backend.chain.backends[].ldap.implementation",
+	"authentication_backend.chain.backends[].ldap.timeout",
+	"authentication_backend.chain.backends[].ldap.start_tls",
+	"authentication_backend.chain.backends[].ldap.tls.minimum_version",
+	"authentication_backend.chain.backends[].ldap.tls.maximum_version",
+	"authentication_backend.chain.backends[].ldap.tls.skip_verify",
+	"authentication_backend.chain.backends[].ldap.tls.server_name",
+	"authentication_backend.chain.backends[].ldap.tls.private_key",
+	"authentication_backend.chain.backends[].ldap.tls.certificate_chain",
+	"authentication_backend.chain.backends[].ldap.base_dn",
+	"authentication_backend.chain.backends[].ldap.additional_users_dn",
+	"authentication_backend.chain.backends[].ldap.users_filter",
+	"authentication_backend.chain.backends[].ldap.additional_groups_dn",
+	"authentication_backend.chain.backends[].ldap.groups_filter",
+	"authentication_backend.chain.backends[].ldap.group_search_mode",
+	"authentication_backend.chain.backends[].ldap.nested_groups_max_depth",
+	"authentication_backend.chain.backends[].ldap.attributes.distinguished_name",
+	"authentication_backend.chain.backends[].ldap.attributes.username",
+	"authentication_backend.chain.backends[].ldap.attributes.display_name",
+	"authentication_backend.chain.backends[].ldap.attributes.mail",
+	"authentication_backend.chain.backends[].ldap.attributes.member_of",
+	"authentication_backend.chain.backends[].ldap.attributes.group_name",
+	"authentication_backend.chain.backends[].ldap.attributes.extra",
+	"authentication_backend.chain.backends[].ldap.administration.enable",
+	"authentication_backend.chain.backends[].ldap.administration.users_rdn_attribute",
+	"authentication_backend.chain.backends[].ldap.administration.users_object_classes",
+	"authentication_backend.chain.backends[].ldap.administration.groups_object_class",
+	"authentication_backend.chain.backends[].ldap.administration.groups_member_attribute",
+	"authentication_backend.chain.backends[].ldap.pooling.enable",
+	"authentication_backend.chain.backends[].ldap.pooling.max_open",
+	"authentication_backend.chain.backends[].ldap.pooling.max_idle",
+	"authentication_backend.chain.backends[].ldap.pooling.timeout",
+	"authentication_backend.chain.backends[].ldap.pooling.health_check_interval",
+	"authentication_backend.chain.backends[].ldap.failover.addresses",
+	"authentication_backend.chain.backends[].ldap.failover.strategy",
+	"authentication_backend.chain.backends[].ldap.failover.backoff",
+	"authentication_backend.chain.backends[].ldap.failover.max_backoff",
+	"authentication_backend.chain.backends[].ldap.permit_referrals",
+	"authentication_backend.chain.backends[].ldap.permit_unauthenticated_bind",
+	"authentication_backend.chain.backends[].ldap.permit_feature_detection_failure",
+	"authentication_backend.chain.backends[].ldap.user",
+	"authentication_backend.chain.backends[].ldap.password",
+	"authentication_backend.chain.backends[].sql.password.algorithm",
+	"authentication_backend.chain.backends[].sql.password.argon2.variant",
+	"authentication_backend.chain.backends[].sql.password.argon2.iterations",
+	"authentication_backend.chain.backends[].sql.password.argon2.memory",
+	"authentication_backend.chain.backends[].sql.password.argon2.parallelism",
+	"authentication_backend.chain.backends[].sql.password.argon2.key_length",
+	"authentication_backend.chain.backends[].sql.password.argon2.salt_length",
+	"authentication_backend.chain.backends[].sql.password.sha2crypt.variant",
+	"authentication_backend.chain.backends[].sql.password.sha2crypt.iterations",
+	"authentication_backend.chain.backends[].sql.password.sha2crypt.salt_length",
+	"authentication_backend.chain.backends[].sql.password.pbkdf2.variant",
+	"authentication_backend.chain.backends[].sql.password.pbkdf2.iterations",
+	"authentication_backend.chain.backends[].sql.password.pbkdf2.salt_length",
+	"authentication_backend.chain.backends[].sql.password.bcrypt.variant",
+	"authentication_backend.chain.backends[].sql.password.bcrypt.cost",
+	"authentication_backend.chain.backends[].sql.password.scrypt.iterations",
+	"authentication_backend.chain.backends[].sql.password.scrypt.block_size",
+	"authentication_backend.chain.backends[].sql.password.scrypt.parallelism",
+	"authentication_backend.chain.backends[].sql.password.scrypt.key_length",
+	"authentication_backend.chain.backends[].sql.password.scrypt.salt_length",
+	"authentication_backend.chain.backends[].sql.password.iterations",
+	"authentication_backend.chain.backends[].sql.password.memory",
+	"authentication_backend.chain.backends[].sql.password.parallelism",
+	"authentication_backend.chain.backends[].sql.password.key_length",
+	"authentication_backend.chain.backends[].sql.password.salt_length",
+	"authentication_backend.chain.backends[].sql.search.email",
+	"authentication_backend.kerberos.keytab",
+	"authentication_backend.kerberos.service_principal",
+	"authentication_backend.kerberos.realms",
+	"authentication_backend.kerberos.include_realm",
+	"authentication_backend.kerberos.max_clock_skew",
+	"authentication_backend.client_certificate.mapping",
+	"authentication_backend.client_certificate.username_template",
+	"authentication_backend.client_certificate.forwarded.header",
+	"authentication_backend.client_certificate.forwarded.trusted_proxies",
+	"authentication_backend.client_certificate.forwarded.certificate_authorities",
 	"session.name",
 	"session.same_site",
 	"session.expiration",
@@ -245,6 +423,21 @@ var Keys = []string{
 	"access_control.rules[].query[][].key",
 	"access_control.rules[].query[][].value",
 	"access_control.rules[].query",
+	"access_control.rules[].headers[][].operator",
+	"access_control.rules[].headers[][].key",
+	"access_control.rules[].headers[][].value",
+	"access_control.rules[].headers",
+	"access_control.rules[].schedule",
+	"access_control.rules[].schedule[].timezone",
+	"access_control.rules[].schedule[].days",
+	"access_control.rules[].schedule[].start_time",
+	"access_control.rules[].schedule[].end_time",
+	"access_control.rules[].schedule[].start_date",
+	"access_control.rules[].schedule[].end_date",
+	"access_control.rules[].second_factor_methods",
+	"access_control.rules[].max_age",
+	"access_control.geoip.country_database",
+	"access_control.geoip.asn_database",
 	"ntp.address",
 	"ntp.version",
 	"ntp.max_desync",
@@ -310,6 +503,7 @@ var Keys = []string{
 	"server.tls.certificate",
 	"server.tls.key",
 	"server.tls.client_certificates",
+	"server.tls.client_authentication",
 	"server.headers.csp_template",
 	"server.endpoints.enable_pprof",
 	"server.endpoints.enable_expvars",
@@ -355,5 +549,24 @@ var Keys = []string{
 	"identity_validation.elevated_session.characters",
 	"identity_validation.elevated_session.require_second_factor",
 	"identity_validation.elevated_session.skip_second_factor",
+	"audit_log.file.path",
+	"audit_log.file.max_size",
+	"audit_log.file.max_backups",
+	"audit_log.syslog.address",
+	"audit_log.syslog.facility",
+	"audit_log.syslog.tag",
+	"audit_log.syslog.buffer_size",
+	"audit_log.webhook.address",
+	"audit_log.webhook.batch_size",
+	"audit_log.webhook.flush_interval",
+	"audit_log.webhook.timeout",
+	"audit_log.webhook.buffer_size",
+	"audit_log.webhook.max_retries",
+	"audit_log.webhook.tls.minimum_version",
+	"audit_log.webhook.tls.maximum_version",
+	"audit_log.webhook.tls.skip_verify",
+	"audit_log.webhook.tls.server_name",
+	"audit_log.webhook.tls.private_key",
+	"audit_log.webhook.tls.certificate_chain",
 	"default_redirection_url",
 }
@@ -18,7 +18,13 @@ func IsPolicyValid(policy string) (isValid bool) {
 
 // IsSubjectValid check if a subject is valid.
 func IsSubjectValid(subject string) (isValid bool) {
-	return subject == "" || strings.HasPrefix(subject, "user:") || strings.HasPrefix(subject, "group:") || strings.HasPrefix(subject, "oauth2:client:")
+	if subject == "" {
+		return true
+	}
+
+	_, err := authorization.NewAccessControlSubject(subject)
+
+	return err == nil
 }
 
 // IsNetworkGroupValid check if a network group is valid.
@@ -64,13 +70,39 @@ func ValidateAccessControl(config *schema.Configuration, validator *schema.Struc
 
 	for _, n := range config.AccessControl.Networks {
 		for _, networks := range n.Networks {
-			if !IsNetworkValid(networks) {
+			switch {
+			case authorization.IsGeoIPNetwork(networks):
+				if err := validateGeoIPNetwork(networks, config.AccessControl.GeoIP); err != nil {
+					validator.Push(fmt.Errorf(errFmtAccessControlNetworkGroupGeoIPInvalid, n.Name, err))
+				}
+			case !IsNetworkValid(networks):
 				validator.Push(fmt.Errorf(errFmtAccessControlNetworkGroupIPCIDRInvalid, n.Name, networks))
 			}
 		}
 	}
 }
 
+func validateGeoIPNetwork(network string, config schema.AccessControlGeoIP) (err error) {
+	var matcher authorization.SubjectMatcher
+
+	if matcher, err = authorization.NewAccessControlGeoIPNetwork(network); err != nil {
+		return err
+	}
+
+	switch matcher.(type) {
+	case *authorization.AccessControlGeoIPASN:
+		if config.ASNDatabase == "" {
+			return fmt.Errorf(errFmtAccessControlGeoIPDatabaseRequired, network, "asn_database")
+		}
+	default:
+		if config.CountryDatabase == "" {
+			return fmt.Errorf(errFmtAccessControlGeoIPDatabaseRequired, network, "country_database")
+		}
+	}
+
+	return nil
+}
+
 // ValidateRules validates an ACL Rule configuration.
 func ValidateRules(config *schema.Configuration, validator *schema.StructValidator) {
 	if config.AccessControl.Rules == nil || len(config.AccessControl.Rules) == 0 {
@@ -107,6 +139,14 @@ func ValidateRules(config *schema.Configuration, validator *schema.StructValidat
 
 		validateQuery(i, rule, config, validator)
 
+		validateHeaders(i, rule, config, validator)
+
+		validateSchedule(rulePosition, rule, validator)
+
+		validateSecondFactorMethods(rulePosition, rule, validator)
+
+		validateMaxAge(rulePosition, rule, validator)
+
 		if rule.Policy == policyBypass {
 			validateBypass(rulePosition, rule, validator)
 		}
@@ -140,6 +180,14 @@ func validateDomains(rulePosition int, rule schema.AccessControlRule, validator
 
 func validateNetworks(rulePosition int, rule schema.AccessControlRule, config schema.AccessControl, validator *schema.StructValidator) {
 	for _, network := range rule.Networks {
+		if authorization.IsGeoIPNetwork(network) {
+			if err := validateGeoIPNetwork(network, config.GeoIP); err != nil {
+				validator.Push(fmt.Errorf(errFmtAccessControlRuleNetworksGeoIPInvalid, ruleDescriptor(rulePosition, rule), err))
+			}
+
+			continue
+		}
+
 		if !IsNetworkValid(network) {
 			if !IsNetworkGroupValid(config, network) {
 				validator.Push(fmt.Errorf(errFmtAccessControlRuleNetworksInvalid, ruleDescriptor(rulePosition, rule), network))
@@ -151,8 +199,12 @@ func validateNetworks(rulePosition int, rule schema.AccessControlRule, config sc
 func validateSubjects(rulePosition int, rule schema.AccessControlRule, validator *schema.StructValidator) {
 	for _, subjectRule := range rule.Subjects {
 		for _, subject := range subjectRule {
-			if !IsSubjectValid(subject) {
-				validator.Push(fmt.Errorf(errFmtAccessControlRuleSubjectInvalid, ruleDescriptor(rulePosition, rule), subject))
+			if subject == "" {
+				continue
+			}
+
+			if _, err := authorization.NewAccessControlSubject(subject); err != nil {
+				validator.Push(fmt.Errorf(errFmtAccessControlRuleSubjectInvalid, ruleDescriptor(rulePosition, rule), subject, err))
 			}
 		}
 	}
@@ -170,59 +222,130 @@ func validateMethods(rulePosition int, rule schema.AccessControlRule, validator
 	}
 }
 
-//nolint:gocyclo
+func validateSecondFactorMethods(rulePosition int, rule schema.AccessControlRule, validator *schema.StructValidator) {
+	if len(rule.SecondFactorMethods) == 0 {
+		return
+	}
+
+	if rule.Policy != policyTwoFactor {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleSecondFactorMethodsPolicy, ruleDescriptor(rulePosition, rule), policyTwoFactor, rule.Policy))
+	}
+
+	invalid, duplicates := validateList(rule.SecondFactorMethods, validACLRuleSecondFactorMethods, true)
+
+	if len(invalid) != 0 {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleInvalidEntries, ruleDescriptor(rulePosition, rule), "second_factor_methods", utils.StringJoinOr(validACLRuleSecondFactorMethods), utils.StringJoinAnd(invalid)))
+	}
+
+	if len(duplicates) != 0 {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleInvalidDuplicates, ruleDescriptor(rulePosition, rule), "second_factor_methods", utils.StringJoinAnd(duplicates)))
+	}
+}
+
+func validateMaxAge(rulePosition int, rule schema.AccessControlRule, validator *schema.StructValidator) {
+	switch {
+	case rule.MaxAge == 0:
+		return
+	case rule.MaxAge < 0:
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleMaxAgeNegative, ruleDescriptor(rulePosition, rule), rule.MaxAge))
+	case rule.Policy != policyOneFactor && rule.Policy != policyTwoFactor:
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleMaxAgePolicy, ruleDescriptor(rulePosition, rule), rule.Policy))
+	}
+}
+
+func validateSchedule(rulePosition int, rule schema.AccessControlRule, validator *schema.StructValidator) {
+	for i, schedule := range rule.Schedule {
+		invalid, duplicates := validateList(schedule.Days, validACLScheduleDays, true)
+
+		if len(invalid) != 0 {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleScheduleInvalidEntries, ruleDescriptor(rulePosition, rule), i+1, utils.StringJoinOr(validACLScheduleDays), utils.StringJoinAnd(invalid)))
+		}
+
+		if len(duplicates) != 0 {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleScheduleInvalidDuplicates, ruleDescriptor(rulePosition, rule), i+1, utils.StringJoinAnd(duplicates)))
+		}
+
+		// The days are validated above, this validates the remaining options.
+		schedule.Days = nil
+
+		if _, err := authorization.NewAccessControlSchedule(schedule); err != nil {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleScheduleInvalid, ruleDescriptor(rulePosition, rule), i+1, err))
+		}
+	}
+}
+
 func validateQuery(i int, rule schema.AccessControlRule, config *schema.Configuration, validator *schema.StructValidator) {
 	for j := 0; j < len(config.AccessControl.Rules[i].Query); j++ {
 		for k := 0; k < len(config.AccessControl.Rules[i].Query[j]); k++ {
-			if config.AccessControl.Rules[i].Query[j][k].Operator == "" {
-				if config.AccessControl.Rules[i].Query[j][k].Key != "" {
-					switch config.AccessControl.Rules[i].Query[j][k].Value {
-					case "", nil:
-						config.AccessControl.Rules[i].Query[j][k].Operator = operatorPresent
-					default:
-						config.AccessControl.Rules[i].Query[j][k].Operator = operatorEqual
-					}
-				}
-			} else if !utils.IsStringInSliceFold(config.AccessControl.Rules[i].Query[j][k].Operator, validACLRuleOperators) {
-				validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalid, ruleDescriptor(i+1, rule), utils.StringJoinOr(validACLRuleOperators), config.AccessControl.Rules[i].Query[j][k].Operator))
-			}
+			query := &config.AccessControl.Rules[i].Query[j][k]
 
-			if config.AccessControl.Rules[i].Query[j][k].Key == "" {
-				validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalidNoValue, ruleDescriptor(i+1, rule), "key"))
-			}
+			validateRuleMatcher(ruleDescriptor(i+1, rule), "query", &query.Operator, query.Key, &query.Value, validator)
+		}
+	}
+}
 
-			op := config.AccessControl.Rules[i].Query[j][k].Operator
+func validateHeaders(i int, rule schema.AccessControlRule, config *schema.Configuration, validator *schema.StructValidator) {
+	for j := 0; j < len(config.AccessControl.Rules[i].Headers); j++ {
+		for k := 0; k < len(config.AccessControl.Rules[i].Headers[j]); k++ {
+			header := &config.AccessControl.Rules[i].Headers[j][k]
 
-			if op == "" {
-				continue
-			}
+			validateRuleMatcher(ruleDescriptor(i+1, rule), "headers", &header.Operator, header.Key, &header.Value, validator)
+		}
+	}
+}
 
-			switch v := config.AccessControl.Rules[i].Query[j][k].Value.(type) {
-			case nil:
-				if op != operatorAbsent && op != operatorPresent {
-					validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalidNoValueOperator, ruleDescriptor(i+1, rule), "value", op))
-				}
-			case string:
-				switch op {
-				case operatorPresent, operatorAbsent:
-					if v != "" {
-						validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalidValue, ruleDescriptor(i+1, rule), "value", op))
-					}
-				case operatorPattern, operatorNotPattern:
-					var (
-						pattern *regexp.Regexp
-						err     error
-					)
-
-					if pattern, err = regexp.Compile(v); err != nil {
-						validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalidValueParse, ruleDescriptor(i+1, rule), "value", err))
-					} else {
-						config.AccessControl.Rules[i].Query[j][k].Value = pattern
-					}
-				}
+// validateRuleMatcher validates and sets the defaults of the key, operator, and value criteria used by the query and
+// headers options.
+//
+//nolint:gocyclo
+func validateRuleMatcher(descriptor, section string, operator *string, key string, value *any, validator *schema.StructValidator) {
+	if *operator == "" {
+		if key != "" {
+			switch *value {
+			case "", nil:
+				*operator = operatorPresent
 			default:
-				validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalidValueType, ruleDescriptor(i+1, rule), v))
+				*operator = operatorEqual
+			}
+		}
+	} else if !utils.IsStringInSliceFold(*operator, validACLRuleOperators) {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalid, descriptor, section, utils.StringJoinOr(validACLRuleOperators), *operator))
+	}
+
+	if key == "" {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalidNoValue, descriptor, section, "key"))
+	}
+
+	op := *operator
+
+	if op == "" {
+		return
+	}
+
+	switch v := (*value).(type) {
+	case nil:
+		if op != operatorAbsent && op != operatorPresent {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalidNoValueOperator, descriptor, section, "value", op))
+		}
+	case string:
+		switch op {
+		case operatorPresent, operatorAbsent:
+			if v != "" {
+				validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalidValue, descriptor, section, "value", op))
+			}
+		case operatorPattern, operatorNotPattern:
+			var (
+				pattern *regexp.Regexp
+				err     error
+			)
+
+			if pattern, err = regexp.Compile(v); err != nil {
+				validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalidValueParse, descriptor, section, "value", err))
+			} else {
+				*value = pattern
 			}
 		}
+	default:
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalidValueType, descriptor, section, v))
 	}
 }
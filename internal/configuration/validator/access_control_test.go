@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -89,6 +90,43 @@ func (suite *AccessControl) TestShouldRaiseErrorInvalidNetworkGroupNetwork() {
 	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: networks: network group 'internal' is invalid: the network 'abc.def.ghi.jkl' is not a valid IP or CIDR notation")
 }
 
+func (suite *AccessControl) TestShouldRaiseErrorInvalidNetworkGroupGeoIPNetwork() {
+	suite.config.AccessControl.Networks = []schema.AccessControlNetwork{
+		{
+			Name:     "internal",
+			Networks: []string{"country:AU", "asn:AS13335", "country:AUS"},
+		},
+	}
+
+	ValidateAccessControl(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 3)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: networks: network group 'internal' is invalid: the network 'country:AU' requires the 'geoip' option 'country_database' to be configured")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: networks: network group 'internal' is invalid: the network 'asn:AS13335' requires the 'geoip' option 'asn_database' to be configured")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "access_control: networks: network group 'internal' is invalid: the country 'AUS' is not a two letter ISO 3166-1 code")
+}
+
+func (suite *AccessControl) TestShouldNotRaiseErrorValidNetworkGroupGeoIPNetwork() {
+	suite.config.AccessControl.GeoIP = schema.AccessControlGeoIP{
+		CountryDatabase: "/config/GeoLite2-Country.mmdb",
+		ASNDatabase:     "/config/GeoLite2-ASN.mmdb",
+	}
+
+	suite.config.AccessControl.Networks = []schema.AccessControlNetwork{
+		{
+			Name:     "internal",
+			Networks: []string{"10.0.0.0/8", "country:AU", "continent:oc", "asn:AS13335", "asn:15169"},
+		},
+	}
+
+	ValidateAccessControl(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Assert().Len(suite.validator.Errors(), 0)
+}
+
 func (suite *AccessControl) TestShouldRaiseWarningOnBadDomain() {
 	suite.config.AccessControl.Rules = []schema.AccessControlRule{
 		{
@@ -181,6 +219,106 @@ func (suite *AccessControl) TestShouldRaiseErrorInvalidNetwork() {
 	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'public.example.com'): the network 'abc.def.ghi.jkl/32' is not a valid Group Name, IP, or CIDR notation")
 }
 
+func (suite *AccessControl) TestShouldRaiseErrorInvalidGeoIPNetwork() {
+	suite.config.AccessControl.GeoIP = schema.AccessControlGeoIP{
+		CountryDatabase: "/config/GeoLite2-Country.mmdb",
+	}
+
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains:  []string{"public.example.com"},
+			Policy:   "bypass",
+			Networks: []string{"country:AU", "continent:O1", "asn:AS0", "asn:AS13335"},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 3)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'public.example.com'): networks: the continent 'O1' is not a two letter continent code")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #1 (domain 'public.example.com'): networks: the autonomous system number 'AS0' is not a valid number")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "access_control: rule #1 (domain 'public.example.com'): networks: the network 'asn:AS13335' requires the 'geoip' option 'asn_database' to be configured")
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorInvalidSecondFactorMethods() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains:             []string{"public.example.com"},
+			Policy:              "one_factor",
+			SecondFactorMethods: []string{"webauthn", "sms", "webauthn"},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 3)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'public.example.com'): option 'second_factor_methods' is only valid with the 'two_factor' policy but the policy is 'one_factor'")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #1 (domain 'public.example.com'): option 'second_factor_methods' must only have the values 'totp', 'mobile_push', 'webauthn', 'webauthn_hardware', 'webauthn_software', 'webauthn_user_verified', or 'phishing_resistant' but the values 'sms' are present")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "access_control: rule #1 (domain 'public.example.com'): option 'second_factor_methods' must have unique values but the values 'webauthn' are duplicated")
+}
+
+func (suite *AccessControl) TestShouldNotRaiseErrorValidSecondFactorMethods() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains:             []string{"public.example.com"},
+			Policy:              "two_factor",
+			SecondFactorMethods: []string{"webauthn_hardware", "phishing_resistant"},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Assert().Len(suite.validator.Errors(), 0)
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorInvalidMaxAge() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: []string{"public.example.com"},
+			Policy:  "deny",
+			MaxAge:  time.Hour,
+		},
+		{
+			Domains: []string{"admin.example.com"},
+			Policy:  "two_factor",
+			MaxAge:  -time.Minute,
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 2)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'public.example.com'): option 'max_age' is only valid with the 'one_factor' or 'two_factor' policies but the policy is 'deny'")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #2 (domain 'admin.example.com'): option 'max_age' must be a positive duration but it's configured as '-1m0s'")
+}
+
+func (suite *AccessControl) TestShouldNotRaiseErrorValidMaxAge() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: []string{"public.example.com"},
+			Policy:  "one_factor",
+			MaxAge:  time.Hour,
+		},
+		{
+			Domains: []string{"admin.example.com"},
+			Policy:  "two_factor",
+			MaxAge:  time.Minute * 15,
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Assert().Len(suite.validator.Errors(), 0)
+}
+
 func (suite *AccessControl) TestShouldRaiseErrorInvalidMethod() {
 	suite.config.AccessControl.Rules = []schema.AccessControlRule{
 		{
@@ -215,6 +353,52 @@ func (suite *AccessControl) TestShouldRaiseErrorDuplicateMethod() {
 	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'public.example.com'): option 'methods' must have unique values but the values 'GET' are duplicated")
 }
 
+func (suite *AccessControl) TestShouldRaiseErrorInvalidSchedule() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: []string{"public.example.com"},
+			Policy:  "bypass",
+			Schedule: []schema.AccessControlRuleSchedule{
+				{
+					Timezone:  "Australia/Melbourne",
+					Days:      []string{"monday", "friday"},
+					StartTime: "08:00",
+					EndTime:   "18:00",
+				},
+				{
+					Timezone:  "Not/AZone",
+					Days:      []string{"monday", "funday", "monday"},
+					StartTime: "8am",
+					EndTime:   "18:00",
+				},
+				{
+					StartTime: "08:00",
+					EndTime:   "08:00",
+				},
+				{
+					StartDate: "2026-01-02",
+					EndDate:   "2026-01-01",
+				},
+				{
+					StartDate: "02/01/2026",
+				},
+			},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 6)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'public.example.com'): schedule #2: option 'days' must only have the values 'monday', 'tuesday', 'wednesday', 'thursday', 'friday', 'saturday', or 'sunday' but the values 'funday' are present")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #1 (domain 'public.example.com'): schedule #2: option 'days' must have unique values but the values 'monday' are duplicated")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "access_control: rule #1 (domain 'public.example.com'): schedule #2: option 'timezone' with value 'Not/AZone' is invalid: unknown time zone Not/AZone")
+	suite.Assert().EqualError(suite.validator.Errors()[3], "access_control: rule #1 (domain 'public.example.com'): schedule #3: options 'start_time' and 'end_time' must not be equal")
+	suite.Assert().EqualError(suite.validator.Errors()[4], "access_control: rule #1 (domain 'public.example.com'): schedule #4: option 'end_date' must be after option 'start_date'")
+	suite.Assert().EqualError(suite.validator.Errors()[5], "access_control: rule #1 (domain 'public.example.com'): schedule #5: option 'start_date' with value '02/01/2026' must be in the YYYY-MM-DD or YYYY-MM-DD HH:MM format")
+}
+
 func (suite *AccessControl) TestShouldRaiseErrorInvalidSubject() {
 	domains := []string{"public.example.com"}
 	subjects := [][]string{{testInvalid}}
@@ -231,10 +415,28 @@ func (suite *AccessControl) TestShouldRaiseErrorInvalidSubject() {
 	suite.Require().Len(suite.validator.Warnings(), 0)
 	suite.Require().Len(suite.validator.Errors(), 2)
 
-	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'public.example.com'): 'subject' option 'invalid' is invalid: must start with 'user:' or 'group:'")
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'public.example.com'): 'subject' option 'invalid' is invalid: must start with 'user:', 'group:', 'email:', 'attr:', 'oauth2:client:', 'user~', 'group~', or 'email~'")
 	suite.Assert().EqualError(suite.validator.Errors()[1], fmt.Sprintf(errAccessControlRuleBypassPolicyInvalidWithSubjects, ruleDescriptor(1, suite.config.AccessControl.Rules[0])))
 }
 
+func (suite *AccessControl) TestShouldRaiseErrorInvalidSubjectExpression() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains:  []string{"public.example.com"},
+			Policy:   "two_factor",
+			Subjects: [][]string{{"group~^team-(", "attr:department"}, {"attr:department=finance", "email~@example\\.com$"}},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Require().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 2)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'public.example.com'): 'subject' option 'group~^team-(' is invalid: the regular expression is not valid: error parsing regexp: missing closing ): `^team-(`")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #1 (domain 'public.example.com'): 'subject' option 'attr:department' is invalid: must be in the 'attr:<name>=<value>' or 'attr:<name>~<regex>' format")
+}
+
 func (suite *AccessControl) TestShouldRaiseErrorBypassWithSubjectDomainRegexGroup() {
 	suite.config.AccessControl.Rules = []schema.AccessControlRule{
 		{
@@ -394,6 +596,64 @@ func (suite *AccessControl) TestShouldErrorOnInvalidRulesQuery() {
 	suite.Assert().EqualError(suite.validator.Errors()[6], "access_control: rule #9 (domain 'public.example.com'): query: option 'value' is invalid: expected type was string but got int")
 }
 
+func (suite *AccessControl) TestShouldSetHeadersDefaults() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: []string{"api.example.com"},
+			Policy:  "bypass",
+			Headers: [][]schema.AccessControlRuleHeader{
+				{
+					{Operator: "", Key: "X-Tenant"},
+				},
+				{
+					{Operator: "", Key: "X-Api-Version", Value: "2"},
+					{Operator: "pattern", Key: "User-Agent", Value: "^curl/"},
+				},
+			},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Assert().Len(suite.validator.Errors(), 0)
+
+	suite.Assert().Equal("present", suite.config.AccessControl.Rules[0].Headers[0][0].Operator)
+	suite.Assert().Equal("equal", suite.config.AccessControl.Rules[0].Headers[1][0].Operator)
+	suite.Assert().IsType(&regexp.Regexp{}, suite.config.AccessControl.Rules[0].Headers[1][1].Value)
+}
+
+func (suite *AccessControl) TestShouldErrorOnInvalidRulesHeaders() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: []string{"api.example.com"},
+			Policy:  "bypass",
+			Headers: [][]schema.AccessControlRuleHeader{
+				{
+					{Operator: "equal", Key: "X-Api-Version"},
+					{Operator: "present"},
+					{Operator: "not", Key: "X-Tenant", Value: "a"},
+					{Operator: "pattern", Key: "User-Agent", Value: "(bad pattern"},
+					{Operator: "absent", Key: "X-Tenant", Value: "not good"},
+					{Operator: "equal", Key: "X-Tenant", Value: 5},
+				},
+			},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 6)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'api.example.com'): headers: option 'value' must be present when the option 'operator' is 'equal' but it's absent")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #1 (domain 'api.example.com'): headers: option 'key' is required but it's absent")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "access_control: rule #1 (domain 'api.example.com'): headers: option 'operator' must be one of 'present', 'absent', 'equal', 'not equal', 'pattern', or 'not pattern' but it's configured as 'not'")
+	suite.Assert().EqualError(suite.validator.Errors()[3], "access_control: rule #1 (domain 'api.example.com'): headers: option 'value' is invalid: error parsing regexp: missing closing ): `(bad pattern`")
+	suite.Assert().EqualError(suite.validator.Errors()[4], "access_control: rule #1 (domain 'api.example.com'): headers: option 'value' must not be present when the option 'operator' is 'absent' but it's present")
+	suite.Assert().EqualError(suite.validator.Errors()[5], "access_control: rule #1 (domain 'api.example.com'): headers: option 'value' is invalid: expected type was string but got int")
+}
+
 func TestAccessControl(t *testing.T) {
 	suite.Run(t, new(AccessControl))
 }
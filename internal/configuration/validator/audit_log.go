@@ -0,0 +1,109 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// ValidateAuditLog validates the audit log configuration.
+func ValidateAuditLog(config *schema.Configuration, validator *schema.StructValidator) {
+	if config.AuditLog.File != nil {
+		validateAuditLogFile(config.AuditLog.File, validator)
+	}
+
+	if config.AuditLog.Syslog != nil {
+		validateAuditLogSyslog(config.AuditLog.Syslog, validator)
+	}
+
+	if config.AuditLog.Webhook != nil {
+		validateAuditLogWebhook(config.AuditLog.Webhook, validator)
+	}
+}
+
+func validateAuditLogFile(config *schema.AuditLogFile, validator *schema.StructValidator) {
+	if config.Path == "" {
+		validator.Push(fmt.Errorf(errFmtAuditLogFileNoPath))
+	}
+
+	if config.MaxSize <= 0 {
+		config.MaxSize = schema.DefaultAuditLogConfiguration.File.MaxSize
+	}
+
+	if config.MaxBackups <= 0 {
+		config.MaxBackups = schema.DefaultAuditLogConfiguration.File.MaxBackups
+	}
+}
+
+func validateAuditLogSyslog(config *schema.AuditLogSyslog, validator *schema.StructValidator) {
+	switch {
+	case config.Address == nil:
+		config.Address = schema.DefaultAuditLogConfiguration.Syslog.Address
+	case !config.Address.IsUDP() && !config.Address.IsUnixDomainSocket():
+		validator.Push(fmt.Errorf(errFmtAuditLogSyslogAddressScheme, config.Address.String(), config.Address.Scheme()))
+	case config.Address.IsUDP() && config.Address.Port() == 0:
+		config.Address.SetPort(schema.DefaultAuditLogConfiguration.Syslog.Address.Port())
+	}
+
+	if config.Facility == "" {
+		config.Facility = schema.DefaultAuditLogConfiguration.Syslog.Facility
+	} else if !utils.IsStringInSlice(config.Facility, validSyslogFacilities) {
+		validator.Push(fmt.Errorf(errFmtAuditLogSyslogFacility, utils.StringJoinOr(validSyslogFacilities), config.Facility))
+	}
+
+	if config.Tag == "" {
+		config.Tag = schema.DefaultAuditLogConfiguration.Syslog.Tag
+	}
+
+	if config.BufferSize <= 0 {
+		config.BufferSize = schema.DefaultAuditLogConfiguration.Syslog.BufferSize
+	}
+}
+
+func validateAuditLogWebhook(config *schema.AuditLogWebhook, validator *schema.StructValidator) {
+	if config.Address == nil {
+		validator.Push(fmt.Errorf(errFmtAuditLogWebhookNoAddress))
+	} else if config.Address.Scheme != schemeHTTP && config.Address.Scheme != schemeHTTPS {
+		validator.Push(fmt.Errorf(errFmtAuditLogWebhookAddressScheme, config.Address.String(), config.Address.Scheme))
+	}
+
+	if config.BatchSize <= 0 {
+		config.BatchSize = schema.DefaultAuditLogConfiguration.Webhook.BatchSize
+	}
+
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = schema.DefaultAuditLogConfiguration.Webhook.FlushInterval
+	}
+
+	if config.Timeout <= 0 {
+		config.Timeout = schema.DefaultAuditLogConfiguration.Webhook.Timeout
+	}
+
+	switch {
+	case config.BufferSize <= 0:
+		config.BufferSize = max(schema.DefaultAuditLogConfiguration.Webhook.BufferSize, config.BatchSize)
+	case config.BufferSize < config.BatchSize:
+		validator.Push(fmt.Errorf(errFmtAuditLogWebhookBufferSize, config.BufferSize, config.BatchSize))
+	}
+
+	if config.MaxRetries <= 0 {
+		config.MaxRetries = schema.DefaultAuditLogConfiguration.Webhook.MaxRetries
+	}
+
+	if config.TLS == nil {
+		config.TLS = &schema.TLS{}
+	}
+
+	configDefaultTLS := &schema.TLS{
+		MinimumVersion: schema.DefaultAuditLogConfiguration.Webhook.TLS.MinimumVersion,
+	}
+
+	if config.Address != nil {
+		configDefaultTLS.ServerName = config.Address.Hostname()
+	}
+
+	if err := ValidateTLSConfig(config.TLS, configDefaultTLS); err != nil {
+		validator.Push(fmt.Errorf(errFmtAuditLogWebhookTLS, err))
+	}
+}
@@ -0,0 +1,161 @@
+package validator
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestValidateAuditLog(t *testing.T) {
+	mustParseAddress := func(a string) *schema.AddressUDP {
+		addr, err := schema.NewAddressDefault(a, schema.AddressSchemeUDP, schema.AddressSchemeUnix)
+		if err != nil {
+			panic(err)
+		}
+
+		return &schema.AddressUDP{Address: *addr}
+	}
+
+	mustParseURL := func(u string) *url.URL {
+		uri, err := url.Parse(u)
+		if err != nil {
+			panic(err)
+		}
+
+		return uri
+	}
+
+	testCases := []struct {
+		name     string
+		have     schema.AuditLog
+		expected schema.AuditLog
+		errs     []string
+	}{
+		{
+			"ShouldAllowEmpty",
+			schema.AuditLog{},
+			schema.AuditLog{},
+			nil,
+		},
+		{
+			"ShouldSetDefaultsFile",
+			schema.AuditLog{File: &schema.AuditLogFile{Path: "/var/log/authelia/audit.log"}},
+			schema.AuditLog{File: &schema.AuditLogFile{Path: "/var/log/authelia/audit.log", MaxSize: 100, MaxBackups: 5}},
+			nil,
+		},
+		{
+			"ShouldRaiseErrorFileNoPath",
+			schema.AuditLog{File: &schema.AuditLogFile{MaxSize: 10, MaxBackups: 2}},
+			schema.AuditLog{File: &schema.AuditLogFile{MaxSize: 10, MaxBackups: 2}},
+			[]string{"audit_log: file: option 'path' is required"},
+		},
+		{
+			"ShouldSetDefaultsSyslog",
+			schema.AuditLog{Syslog: &schema.AuditLogSyslog{}},
+			schema.AuditLog{Syslog: &schema.AuditLogSyslog{Address: mustParseAddress("udp://127.0.0.1:514"), Facility: "authpriv", Tag: "authelia", BufferSize: 10000}},
+			nil,
+		},
+		{
+			"ShouldSetDefaultSyslogPort",
+			schema.AuditLog{Syslog: &schema.AuditLogSyslog{Address: mustParseAddress("udp://syslog.example.com"), Facility: "local0", Tag: "auth"}},
+			schema.AuditLog{Syslog: &schema.AuditLogSyslog{Address: mustParseAddress("udp://syslog.example.com:514"), Facility: "local0", Tag: "auth", BufferSize: 10000}},
+			nil,
+		},
+		{
+			"ShouldAllowSyslogUnixSocket",
+			schema.AuditLog{Syslog: &schema.AuditLogSyslog{Address: mustParseAddress("/dev/log")}},
+			schema.AuditLog{Syslog: &schema.AuditLogSyslog{Address: mustParseAddress("unix:///dev/log"), Facility: "authpriv", Tag: "authelia", BufferSize: 10000}},
+			nil,
+		},
+		{
+			"ShouldRaiseErrorsSyslog",
+			schema.AuditLog{Syslog: &schema.AuditLogSyslog{Address: mustParseAddress("tcp://127.0.0.1:514"), Facility: "local8"}},
+			schema.AuditLog{Syslog: &schema.AuditLogSyslog{Address: mustParseAddress("tcp://127.0.0.1:514"), Facility: "local8", Tag: "authelia", BufferSize: 10000}},
+			[]string{
+				"audit_log: syslog: option 'address' with value 'tcp://127.0.0.1:514' is invalid: scheme must be one of 'udp', 'udp4', 'udp6', or 'unix' but is configured as 'tcp'",
+				"audit_log: syslog: option 'facility' must be one of 'kern', 'user', 'mail', 'daemon', 'auth', 'syslog', 'lpr', 'news', 'uucp', 'cron', 'authpriv', 'ftp', 'local0', 'local1', 'local2', 'local3', 'local4', 'local5', 'local6', or 'local7' but it's configured as 'local8'",
+			},
+		},
+		{
+			"ShouldSetDefaultsWebhook",
+			schema.AuditLog{Webhook: &schema.AuditLogWebhook{Address: mustParseURL("https://audit.example.com/events")}},
+			schema.AuditLog{Webhook: &schema.AuditLogWebhook{
+				Address:       mustParseURL("https://audit.example.com/events"),
+				BatchSize:     100,
+				FlushInterval: time.Second * 5,
+				Timeout:       time.Second * 5,
+				BufferSize:    10000,
+				MaxRetries:    3,
+				TLS:           &schema.TLS{ServerName: "audit.example.com", MinimumVersion: schema.DefaultAuditLogConfiguration.Webhook.TLS.MinimumVersion},
+			}},
+			nil,
+		},
+		{
+			"ShouldRaiseErrorWebhookNoAddress",
+			schema.AuditLog{Webhook: &schema.AuditLogWebhook{BatchSize: 10, FlushInterval: time.Second, Timeout: time.Second}},
+			schema.AuditLog{Webhook: &schema.AuditLogWebhook{
+				BatchSize:     10,
+				FlushInterval: time.Second,
+				Timeout:       time.Second,
+				BufferSize:    10000,
+				MaxRetries:    3,
+				TLS:           &schema.TLS{MinimumVersion: schema.DefaultAuditLogConfiguration.Webhook.TLS.MinimumVersion},
+			}},
+			[]string{"audit_log: webhook: option 'address' is required"},
+		},
+		{
+			"ShouldRaiseErrorWebhookScheme",
+			schema.AuditLog{Webhook: &schema.AuditLogWebhook{Address: mustParseURL("ftp://audit.example.com")}},
+			schema.AuditLog{Webhook: &schema.AuditLogWebhook{
+				Address:       mustParseURL("ftp://audit.example.com"),
+				BatchSize:     100,
+				FlushInterval: time.Second * 5,
+				Timeout:       time.Second * 5,
+				BufferSize:    10000,
+				MaxRetries:    3,
+				TLS:           &schema.TLS{ServerName: "audit.example.com", MinimumVersion: schema.DefaultAuditLogConfiguration.Webhook.TLS.MinimumVersion},
+			}},
+			[]string{"audit_log: webhook: option 'address' with value 'ftp://audit.example.com' must have the 'http' or 'https' scheme but has the 'ftp' scheme"},
+		},
+		{
+			"ShouldRaiseErrorWebhookBufferSize",
+			schema.AuditLog{Webhook: &schema.AuditLogWebhook{Address: mustParseURL("https://audit.example.com/events"), BatchSize: 100, BufferSize: 50, MaxRetries: 1}},
+			schema.AuditLog{Webhook: &schema.AuditLogWebhook{
+				Address:       mustParseURL("https://audit.example.com/events"),
+				BatchSize:     100,
+				FlushInterval: time.Second * 5,
+				Timeout:       time.Second * 5,
+				BufferSize:    50,
+				MaxRetries:    1,
+				TLS:           &schema.TLS{ServerName: "audit.example.com", MinimumVersion: schema.DefaultAuditLogConfiguration.Webhook.TLS.MinimumVersion},
+			}},
+			[]string{"audit_log: webhook: option 'buffer_size' must be greater than or equal to the 'batch_size' of 100 but it's configured as 50"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+
+			config := &schema.Configuration{AuditLog: tc.have}
+
+			ValidateAuditLog(config, validator)
+
+			assert.Equal(t, tc.expected, config.AuditLog)
+			assert.Len(t, validator.Warnings(), 0)
+
+			errs := validator.Errors()
+
+			require.Len(t, errs, len(tc.errs))
+
+			for i, expected := range tc.errs {
+				assert.EqualError(t, errs[i], expected)
+			}
+		})
+	}
+}
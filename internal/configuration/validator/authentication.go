@@ -2,6 +2,7 @@ package validator
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/go-crypt/crypt/algorithm/argon2"
@@ -16,8 +17,11 @@ import (
 
 // ValidateAuthenticationBackend validates and updates the authentication backend configuration.
 func ValidateAuthenticationBackend(config *schema.AuthenticationBackend, validator *schema.StructValidator) {
-	if config.LDAP == nil && config.File == nil {
+	switch n := countAuthenticationBackends(config); {
+	case n == 0:
 		validator.Push(fmt.Errorf(errFmtAuthBackendNotConfigured))
+	case n > 1:
+		validator.Push(fmt.Errorf(errFmtAuthBackendMultipleConfigured))
 	}
 
 	if !config.RefreshInterval.Valid() {
@@ -37,10 +41,6 @@ func ValidateAuthenticationBackend(config *schema.AuthenticationBackend, validat
 		}
 	}
 
-	if config.LDAP != nil && config.File != nil {
-		validator.Push(fmt.Errorf(errFmtAuthBackendMultipleConfigured))
-	}
-
 	if config.File != nil {
 		validateFileAuthenticationBackend(config.File, validator)
 	}
@@ -48,6 +48,181 @@ func ValidateAuthenticationBackend(config *schema.AuthenticationBackend, validat
 	if config.LDAP != nil {
 		validateLDAPAuthenticationBackend(config, validator)
 	}
+
+	if config.SQL != nil {
+		validateSQLAuthenticationBackend(config.SQL, validator)
+	}
+
+	if config.Chain != nil {
+		validateChainAuthenticationBackend(config, validator)
+	}
+
+	if config.Kerberos != nil {
+		validateKerberosAuthenticationBackend(config.Kerberos, validator)
+	}
+
+	if config.ClientCertificate != nil {
+		validateClientCertificateAuthenticationBackend(config.ClientCertificate, validator)
+	}
+}
+
+// validateKerberosAuthenticationBackend validates and updates the Kerberos configuration.
+func validateKerberosAuthenticationBackend(config *schema.AuthenticationBackendKerberos, validator *schema.StructValidator) {
+	if config.Keytab == "" {
+		validator.Push(fmt.Errorf(errFmtKerberosAuthBackendKeytabNotConfigured))
+	}
+
+	for _, realm := range config.Realms {
+		if realm == "" {
+			validator.Push(fmt.Errorf(errFmtKerberosAuthBackendRealmEmpty))
+
+			break
+		}
+	}
+
+	switch {
+	case config.MaxClockSkew == 0:
+		config.MaxClockSkew = schema.DefaultAuthenticationBackendKerberos.MaxClockSkew
+	case config.MaxClockSkew < 0:
+		validator.Push(fmt.Errorf(errFmtKerberosAuthBackendNegativeDuration, config.MaxClockSkew))
+	}
+}
+
+// validateClientCertificateAuthenticationBackend validates and updates the client certificate configuration.
+func validateClientCertificateAuthenticationBackend(config *schema.AuthenticationBackendClientCertificate, validator *schema.StructValidator) {
+	switch {
+	case config.Mapping == "":
+		config.Mapping = schema.DefaultAuthenticationBackendClientCertificate.Mapping
+	case !utils.IsStringInSlice(config.Mapping, validAuthBackendClientCertificateMappings):
+		validator.Push(fmt.Errorf(errFmtClientCertificateAuthBackendMapping, utils.StringJoinOr(validAuthBackendClientCertificateMappings), config.Mapping))
+	}
+
+	if config.Forwarded.Header == "" {
+		config.Forwarded.Header = schema.DefaultAuthenticationBackendClientCertificate.Forwarded.Header
+	}
+
+	for _, proxy := range config.Forwarded.TrustedProxies {
+		if !IsNetworkValid(proxy) {
+			validator.Push(fmt.Errorf(errFmtClientCertificateAuthBackendTrustedProxy, proxy))
+		}
+	}
+
+	if len(config.Forwarded.TrustedProxies) != 0 && len(config.Forwarded.CertificateAuthorities) == 0 {
+		validator.Push(fmt.Errorf(errFmtClientCertificateAuthBackendNoCertificateAuthorities))
+	}
+}
+
+func countAuthenticationBackends(config *schema.AuthenticationBackend) (n int) {
+	if config.File != nil {
+		n++
+	}
+
+	if config.LDAP != nil {
+		n++
+	}
+
+	if config.SQL != nil {
+		n++
+	}
+
+	if config.Chain != nil {
+		n++
+	}
+
+	return n
+}
+
+// validateChainAuthenticationBackend validates and updates the chained authentication backend configuration.
+func validateChainAuthenticationBackend(config *schema.AuthenticationBackend, validator *schema.StructValidator) {
+	switch {
+	case config.Chain.ConflictMode == "":
+		config.Chain.ConflictMode = schema.AuthenticationBackendChainConflictModePrecedence
+	case !utils.IsStringInSlice(config.Chain.ConflictMode, validAuthBackendChainConflictModes):
+		validator.Push(fmt.Errorf(errFmtChainAuthBackendConflictMode, utils.StringJoinOr(validAuthBackendChainConflictModes), config.Chain.ConflictMode))
+	}
+
+	if len(config.Chain.Backends) == 0 {
+		validator.Push(fmt.Errorf(errFmtChainAuthBackendNoBackends))
+
+		return
+	}
+
+	var (
+		names []string
+		sql   bool
+	)
+
+	for i := range config.Chain.Backends {
+		backend := &config.Chain.Backends[i]
+
+		switch {
+		case backend.Name == "":
+			validator.Push(fmt.Errorf(errFmtChainAuthBackendBackendNameMissing, i+1))
+		case !reAuthBackendName.MatchString(backend.Name):
+			validator.Push(fmt.Errorf(errFmtChainAuthBackendBackendNameInvalid, i+1, backend.Name))
+		case utils.IsStringInSlice(backend.Name, names):
+			validator.Push(fmt.Errorf(errFmtChainAuthBackendBackendNameNotUnique, i+1, backend.Name))
+		default:
+			names = append(names, backend.Name)
+		}
+
+		for j, domain := range backend.Domains {
+			if domain == "" {
+				validator.Push(fmt.Errorf(errFmtChainAuthBackendBackendDomainEmpty, i+1, backend.Name))
+
+				break
+			}
+
+			backend.Domains[j] = strings.ToLower(domain)
+		}
+
+		validateChainAuthenticationBackendBackend(config, i, backend, &sql, validator)
+	}
+}
+
+func validateChainAuthenticationBackendBackend(config *schema.AuthenticationBackend, i int, backend *schema.AuthenticationBackendChainBackend, sql *bool, validator *schema.StructValidator) {
+	switch n := countAuthenticationBackends(&schema.AuthenticationBackend{File: backend.File, LDAP: backend.LDAP, SQL: backend.SQL}); {
+	case n == 0:
+		validator.Push(fmt.Errorf(errFmtChainAuthBackendBackendNotConfigured, i+1, backend.Name))
+	case n > 1:
+		validator.Push(fmt.Errorf(errFmtChainAuthBackendBackendMultipleConfigured, i+1, backend.Name))
+	}
+
+	if backend.File != nil && backend.File.Watch {
+		validator.Push(fmt.Errorf(errFmtChainAuthBackendBackendFileWatch, i+1, backend.Name))
+	}
+
+	if backend.SQL != nil {
+		if *sql {
+			validator.Push(fmt.Errorf(errFmtChainAuthBackendBackendSQLMultiple, i+1, backend.Name))
+		}
+
+		*sql = true
+	}
+
+	// The individual backends are validated using the standard validation functions, and the errors are then prefixed
+	// with the chained backend information.
+	v := schema.NewStructValidator()
+
+	if backend.File != nil {
+		validateFileAuthenticationBackend(backend.File, v)
+	}
+
+	if backend.LDAP != nil {
+		validateLDAPAuthenticationBackend(&schema.AuthenticationBackend{PasswordReset: config.PasswordReset, LDAP: backend.LDAP}, v)
+	}
+
+	if backend.SQL != nil {
+		validateSQLAuthenticationBackend(backend.SQL, v)
+	}
+
+	for _, err := range v.Warnings() {
+		validator.PushWarning(fmt.Errorf(errFmtChainAuthBackendBackendValidation, i+1, backend.Name, strings.TrimPrefix(err.Error(), "authentication_backend: ")))
+	}
+
+	for _, err := range v.Errors() {
+		validator.Push(fmt.Errorf(errFmtChainAuthBackendBackendValidation, i+1, backend.Name, strings.TrimPrefix(err.Error(), "authentication_backend: ")))
+	}
 }
 
 // validateFileAuthenticationBackend validates and updates the file authentication backend configuration.
@@ -59,6 +234,11 @@ func validateFileAuthenticationBackend(config *schema.AuthenticationBackendFile,
 	ValidatePasswordConfiguration(&config.Password, validator)
 }
 
+// validateSQLAuthenticationBackend validates and updates the SQL authentication backend configuration.
+func validateSQLAuthenticationBackend(config *schema.AuthenticationBackendSQL, validator *schema.StructValidator) {
+	ValidatePasswordConfiguration(&config.Password, validator)
+}
+
 // ValidatePasswordConfiguration validates the file auth backend password configuration.
 func ValidatePasswordConfiguration(config *schema.AuthenticationBackendFilePassword, validator *schema.StructValidator) {
 	validateFileAuthenticationBackendPasswordConfigLegacy(config)
@@ -344,6 +524,10 @@ func validateLDAPAuthenticationBackend(config *schema.AuthenticationBackend, val
 	}
 
 	validateLDAPRequiredParameters(config, validator)
+	validateLDAPExtraAttributes(config, validator)
+	validateLDAPAdministration(config, validator)
+	validateLDAPPooling(config, validator)
+	validateLDAPFailover(config, validator)
 }
 
 func validateLDAPAuthenticationBackendImplementation(config *schema.AuthenticationBackend, validator *schema.StructValidator) *schema.TLS {
@@ -379,6 +563,7 @@ func validateLDAPAuthenticationBackendImplementation(config *schema.Authenticati
 		}
 
 		setDefaultImplementationLDAPAuthenticationBackendProfileAttributes(config.LDAP, implementation)
+		setDefaultImplementationLDAPAuthenticationBackendProfileAdministration(config.LDAP, implementation)
 	}
 
 	return tlsconfig
@@ -434,6 +619,28 @@ func setDefaultImplementationLDAPAuthenticationBackendProfileAttributes(config *
 	}
 }
 
+func setDefaultImplementationLDAPAuthenticationBackendProfileAdministration(config *schema.AuthenticationBackendLDAP, implementation *schema.AuthenticationBackendLDAP) {
+	if !config.Administration.Enable {
+		return
+	}
+
+	if ldapImplementationShouldSetStr(config.Administration.UsersRDNAttribute, implementation.Administration.UsersRDNAttribute) {
+		config.Administration.UsersRDNAttribute = implementation.Administration.UsersRDNAttribute
+	}
+
+	if len(config.Administration.UsersObjectClasses) == 0 && len(implementation.Administration.UsersObjectClasses) != 0 {
+		config.Administration.UsersObjectClasses = append([]string(nil), implementation.Administration.UsersObjectClasses...)
+	}
+
+	if ldapImplementationShouldSetStr(config.Administration.GroupsObjectClass, implementation.Administration.GroupsObjectClass) {
+		config.Administration.GroupsObjectClass = implementation.Administration.GroupsObjectClass
+	}
+
+	if ldapImplementationShouldSetStr(config.Administration.GroupsMemberAttribute, implementation.Administration.GroupsMemberAttribute) {
+		config.Administration.GroupsMemberAttribute = implementation.Administration.GroupsMemberAttribute
+	}
+}
+
 func validateLDAPAuthenticationAddress(config *schema.AuthenticationBackendLDAP, validator *schema.StructValidator) (hostname string) {
 	if config.Address == nil {
 		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendMissingOption, "address"))
@@ -501,6 +708,173 @@ func validateLDAPRequiredParameters(config *schema.AuthenticationBackend, valida
 	validateLDAPGroupFilter(config, validator)
 }
 
+func validateLDAPExtraAttributes(config *schema.AuthenticationBackend, validator *schema.StructValidator) {
+	names := make([]string, 0, len(config.LDAP.Attributes.Extra))
+
+	for name := range config.LDAP.Attributes.Extra {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		switch {
+		case !reUserAttributeName.MatchString(name):
+			validator.Push(fmt.Errorf(errFmtLDAPAuthBackendExtraAttributeInvalidName, name))
+		case utils.IsStringInSlice(name, reservedUserAttributeNames):
+			validator.Push(fmt.Errorf(errFmtLDAPAuthBackendExtraAttributeReservedName, name))
+		case config.LDAP.Attributes.Extra[name] == "":
+			validator.Push(fmt.Errorf(errFmtLDAPAuthBackendExtraAttributeMissingValue, name))
+		}
+	}
+}
+
+func validateLDAPAdministration(config *schema.AuthenticationBackend, validator *schema.StructValidator) {
+	if !config.LDAP.Administration.Enable {
+		return
+	}
+
+	if config.LDAP.Implementation == schema.LDAPImplementationGLAuth {
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendAdministrationImplementation, config.LDAP.Implementation))
+
+		return
+	}
+
+	if config.LDAP.PermitUnauthenticatedBind {
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendAdministrationUnauthenticatedBind))
+	}
+
+	if config.LDAP.Administration.UsersRDNAttribute == "" {
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendAdministrationMissingOption, "users_rdn_attribute"))
+	}
+
+	if len(config.LDAP.Administration.UsersObjectClasses) == 0 {
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendAdministrationMissingOption, "users_object_classes"))
+	}
+
+	if config.LDAP.Administration.GroupsObjectClass == "" {
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendAdministrationMissingOption, "groups_object_class"))
+	}
+
+	if config.LDAP.Administration.GroupsMemberAttribute == "" {
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendAdministrationMissingOption, "groups_member_attribute"))
+	}
+
+	if config.LDAP.Attributes.GroupName == "" {
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendAdministrationMissingAttribute, "group_name"))
+	}
+}
+
+func validateLDAPPooling(config *schema.AuthenticationBackend, validator *schema.StructValidator) {
+	if !config.LDAP.Pooling.Enable {
+		return
+	}
+
+	switch {
+	case config.LDAP.Pooling.MaxOpen == 0:
+		config.LDAP.Pooling.MaxOpen = schema.DefaultLDAPAuthenticationBackendConfigurationPooling.MaxOpen
+	case config.LDAP.Pooling.MaxOpen < 0:
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendPoolingNegative, "max_open", config.LDAP.Pooling.MaxOpen))
+	}
+
+	switch {
+	case config.LDAP.Pooling.MaxIdle == 0:
+		config.LDAP.Pooling.MaxIdle = min(schema.DefaultLDAPAuthenticationBackendConfigurationPooling.MaxIdle, config.LDAP.Pooling.MaxOpen)
+	case config.LDAP.Pooling.MaxIdle < 0:
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendPoolingNegative, "max_idle", config.LDAP.Pooling.MaxIdle))
+	case config.LDAP.Pooling.MaxOpen > 0 && config.LDAP.Pooling.MaxIdle > config.LDAP.Pooling.MaxOpen:
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendPoolingMaxIdleGreaterThanMaxOpen, config.LDAP.Pooling.MaxIdle, config.LDAP.Pooling.MaxOpen))
+	}
+
+	switch {
+	case config.LDAP.Pooling.Timeout == 0:
+		config.LDAP.Pooling.Timeout = schema.DefaultLDAPAuthenticationBackendConfigurationPooling.Timeout
+	case config.LDAP.Pooling.Timeout < 0:
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendPoolingNegativeDuration, "timeout", config.LDAP.Pooling.Timeout))
+	}
+
+	switch {
+	case config.LDAP.Pooling.HealthCheckInterval == 0:
+		config.LDAP.Pooling.HealthCheckInterval = schema.DefaultLDAPAuthenticationBackendConfigurationPooling.HealthCheckInterval
+	case config.LDAP.Pooling.HealthCheckInterval < 0:
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendPoolingNegativeDuration, "health_check_interval", config.LDAP.Pooling.HealthCheckInterval))
+	}
+}
+
+func validateLDAPFailover(config *schema.AuthenticationBackend, validator *schema.StructValidator) {
+	if len(config.LDAP.Failover.Addresses) == 0 {
+		return
+	}
+
+	addresses := make([]string, 0, len(config.LDAP.Failover.Addresses)+1)
+
+	if config.LDAP.Address != nil {
+		addresses = append(addresses, strings.ToLower(config.LDAP.Address.String()))
+	}
+
+	for _, address := range config.LDAP.Failover.Addresses {
+		if address == nil {
+			validator.Push(fmt.Errorf(errFmtLDAPAuthBackendFailoverAddressMissing))
+
+			continue
+		}
+
+		if err := address.ValidateLDAP(); err != nil {
+			validator.Push(fmt.Errorf(errFmtLDAPAuthBackendFailoverAddress, address.String(), err))
+
+			continue
+		}
+
+		if utils.IsStringInSlice(strings.ToLower(address.String()), addresses) {
+			validator.Push(fmt.Errorf(errFmtLDAPAuthBackendFailoverAddressDuplicate, address.String()))
+
+			continue
+		}
+
+		addresses = append(addresses, strings.ToLower(address.String()))
+	}
+
+	switch {
+	case config.LDAP.Failover.Strategy == "":
+		config.LDAP.Failover.Strategy = schema.DefaultLDAPAuthenticationBackendConfigurationFailover.Strategy
+	case !utils.IsStringInSlice(config.LDAP.Failover.Strategy, validLDAPFailoverStrategies):
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendFailoverOptionMustBeOneOf, "strategy", utils.StringJoinOr(validLDAPFailoverStrategies), config.LDAP.Failover.Strategy))
+	}
+
+	switch {
+	case config.LDAP.Failover.Backoff == 0:
+		config.LDAP.Failover.Backoff = schema.DefaultLDAPAuthenticationBackendConfigurationFailover.Backoff
+	case config.LDAP.Failover.Backoff < 0:
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendFailoverNegativeDuration, "backoff", config.LDAP.Failover.Backoff))
+	}
+
+	switch {
+	case config.LDAP.Failover.MaxBackoff == 0:
+		config.LDAP.Failover.MaxBackoff = max(schema.DefaultLDAPAuthenticationBackendConfigurationFailover.MaxBackoff, config.LDAP.Failover.Backoff)
+	case config.LDAP.Failover.MaxBackoff < 0:
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendFailoverNegativeDuration, "max_backoff", config.LDAP.Failover.MaxBackoff))
+	case config.LDAP.Failover.Backoff > 0 && config.LDAP.Failover.MaxBackoff < config.LDAP.Failover.Backoff:
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendFailoverMaxBackoffLessThanBackoff, config.LDAP.Failover.MaxBackoff, config.LDAP.Failover.Backoff))
+	}
+}
+
+func validateLDAPGroupFilterNested(config *schema.AuthenticationBackend, memberOf bool, validator *schema.StructValidator) {
+	if !strings.Contains(config.LDAP.GroupsFilter, "{dn}") {
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendFilterRequiredPlaceholderGroupSearchMode, "groups_filter", "{dn}", config.LDAP.GroupSearchMode))
+	}
+
+	if memberOf {
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendFilterUnsupportedPlaceholderGroupSearchMode, "groups_filter", utils.StringJoinOr([]string{"{memberof:rdn}", "{memberof:dn}"}), config.LDAP.GroupSearchMode))
+	}
+
+	switch {
+	case config.LDAP.NestedGroupsMaxDepth == 0:
+		config.LDAP.NestedGroupsMaxDepth = schema.DefaultLDAPAuthenticationBackendNestedGroupsMaxDepth
+	case config.LDAP.NestedGroupsMaxDepth < 0:
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendNestedGroupsMaxDepth, config.LDAP.NestedGroupsMaxDepth))
+	}
+}
+
 func validateLDAPGroupFilter(config *schema.AuthenticationBackend, validator *schema.StructValidator) {
 	if config.LDAP.GroupSearchMode == "" {
 		config.LDAP.GroupSearchMode = schema.LDAPGroupSearchModeFilter
@@ -512,10 +886,13 @@ func validateLDAPGroupFilter(config *schema.AuthenticationBackend, validator *sc
 
 	pMemberOfDN, pMemberOfRDN := strings.Contains(config.LDAP.GroupsFilter, "{memberof:dn}"), strings.Contains(config.LDAP.GroupsFilter, "{memberof:rdn}")
 
-	if config.LDAP.GroupSearchMode == schema.LDAPGroupSearchModeMemberOf {
+	switch config.LDAP.GroupSearchMode {
+	case schema.LDAPGroupSearchModeMemberOf:
 		if !pMemberOfDN && !pMemberOfRDN {
 			validator.Push(fmt.Errorf(errFmtLDAPAuthBackendFilterMissingPlaceholderGroupSearchMode, "groups_filter", utils.StringJoinOr([]string{"{memberof:rdn}", "{memberof:dn}"}), config.LDAP.GroupSearchMode))
 		}
+	case schema.LDAPGroupSearchModeNested:
+		validateLDAPGroupFilterNested(config, pMemberOfDN || pMemberOfRDN, validator)
 	}
 
 	if pMemberOfDN && config.LDAP.Attributes.DistinguishedName == "" {
@@ -25,7 +25,7 @@ func TestShouldRaiseErrorWhenBothBackendsProvided(t *testing.T) {
 	ValidateAuthenticationBackend(&backendConfig, validator)
 
 	require.Len(t, validator.Errors(), 7)
-	assert.EqualError(t, validator.Errors()[0], "authentication_backend: please ensure only one of the 'file' or 'ldap' backend is configured")
+	assert.EqualError(t, validator.Errors()[0], "authentication_backend: please ensure only one of the 'file', 'ldap', 'sql', or 'chain' backend is configured")
 	assert.EqualError(t, validator.Errors()[1], "authentication_backend: ldap: option 'address' is required")
 	assert.EqualError(t, validator.Errors()[2], "authentication_backend: ldap: option 'user' is required")
 	assert.EqualError(t, validator.Errors()[3], "authentication_backend: ldap: option 'password' is required")
@@ -41,7 +41,238 @@ func TestShouldRaiseErrorWhenNoBackendProvided(t *testing.T) {
 	ValidateAuthenticationBackend(&backendConfig, validator)
 
 	require.Len(t, validator.Errors(), 1)
-	assert.EqualError(t, validator.Errors()[0], "authentication_backend: you must ensure either the 'file' or 'ldap' authentication backend is configured")
+	assert.EqualError(t, validator.Errors()[0], "authentication_backend: you must ensure either the 'file', 'ldap', 'sql', or 'chain' authentication backend is configured")
+}
+
+func TestShouldRaiseErrorWhenFileAndSQLBackendsProvided(t *testing.T) {
+	validator := schema.NewStructValidator()
+	backendConfig := schema.AuthenticationBackend{}
+
+	backendConfig.SQL = &schema.AuthenticationBackendSQL{}
+	backendConfig.File = &schema.AuthenticationBackendFile{
+		Path: "/tmp",
+	}
+
+	ValidateAuthenticationBackend(&backendConfig, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "authentication_backend: please ensure only one of the 'file', 'ldap', 'sql', or 'chain' backend is configured")
+}
+
+func TestShouldValidateSQLBackend(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     *schema.AuthenticationBackendSQL
+		expected string
+		errs     []string
+	}{
+		{
+			"ShouldSetDefaults",
+			&schema.AuthenticationBackendSQL{},
+			schema.DefaultPasswordConfig.Algorithm,
+			nil,
+		},
+		{
+			"ShouldAllowConfiguredAlgorithm",
+			&schema.AuthenticationBackendSQL{Password: schema.AuthenticationBackendFilePassword{Algorithm: hashSHA2Crypt}},
+			hashSHA2Crypt,
+			nil,
+		},
+		{
+			"ShouldRaiseErrorOnUnknownAlgorithm",
+			&schema.AuthenticationBackendSQL{Password: schema.AuthenticationBackendFilePassword{Algorithm: "abc"}},
+			"abc",
+			[]string{
+				"authentication_backend: file: password: option 'algorithm' must be one of 'sha2crypt', 'pbkdf2', 'scrypt', 'bcrypt', or 'argon2' but it's configured as 'abc'",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+			config := &schema.AuthenticationBackend{SQL: tc.have}
+
+			ValidateAuthenticationBackend(config, validator)
+
+			assert.Len(t, validator.Warnings(), 0)
+			assert.Equal(t, tc.expected, config.SQL.Password.Algorithm)
+			assert.True(t, config.RefreshInterval.Valid())
+
+			errs := validator.Errors()
+
+			require.Len(t, errs, len(tc.errs))
+
+			for i, err := range errs {
+				assert.EqualError(t, err, tc.errs[i])
+			}
+		})
+	}
+}
+
+func TestShouldValidateChainBackend(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.AuthenticationBackend{
+		Chain: &schema.AuthenticationBackendChain{
+			Backends: []schema.AuthenticationBackendChainBackend{
+				{
+					Name:    "corp",
+					Domains: []string{"CORP.Example.com", "CORP"},
+					LDAP: &schema.AuthenticationBackendLDAP{
+						Implementation: schema.LDAPImplementationActiveDirectory,
+						Address:        &schema.AddressLDAP{Address: *testLDAPAddress},
+						User:           testLDAPUser,
+						Password:       testLDAPPassword,
+						BaseDN:         testLDAPBaseDN,
+					},
+				},
+				{
+					Name: "break-glass",
+					File: &schema.AuthenticationBackendFile{Path: "/config/users.yml"},
+				},
+			},
+		},
+	}
+
+	ValidateAuthenticationBackend(config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	assert.Len(t, validator.Errors(), 0)
+
+	assert.Equal(t, schema.AuthenticationBackendChainConflictModePrecedence, config.Chain.ConflictMode)
+	assert.Equal(t, []string{"corp.example.com", "corp"}, config.Chain.Backends[0].Domains)
+	assert.Equal(t, "sAMAccountName", config.Chain.Backends[0].LDAP.Attributes.Username)
+	assert.Equal(t, schema.DefaultPasswordConfig.Algorithm, config.Chain.Backends[1].File.Password.Algorithm)
+	assert.True(t, config.RefreshInterval.Valid())
+}
+
+func TestShouldRaiseErrorsOnInvalidChainBackend(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.AuthenticationBackend{
+		Chain: &schema.AuthenticationBackendChain{
+			ConflictMode: "abc",
+			Backends: []schema.AuthenticationBackendChainBackend{
+				{File: &schema.AuthenticationBackendFile{Path: "/config/users.yml"}},
+				{Name: "Bad", File: &schema.AuthenticationBackendFile{Path: "/config/users.yml"}},
+				{Name: "local", Domains: []string{""}, File: &schema.AuthenticationBackendFile{Path: "/config/users.yml", Watch: true}},
+				{Name: "local", SQL: &schema.AuthenticationBackendSQL{}},
+				{Name: "db", SQL: &schema.AuthenticationBackendSQL{}},
+				{Name: "none"},
+				{Name: "both", File: &schema.AuthenticationBackendFile{}, SQL: &schema.AuthenticationBackendSQL{}},
+			},
+		},
+	}
+
+	ValidateAuthenticationBackend(config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+
+	errs := validator.Errors()
+
+	require.Len(t, errs, 11)
+
+	assert.EqualError(t, errs[0], "authentication_backend: chain: option 'conflict_mode' must be one of 'precedence' or 'deny' but it's configured as 'abc'")
+	assert.EqualError(t, errs[1], "authentication_backend: chain: backends: #1: option 'name' is required")
+	assert.EqualError(t, errs[2], "authentication_backend: chain: backends: #2 (Bad): option 'name' must start with a lowercase letter and only contain lowercase letters, numbers, hyphens, and underscores")
+	assert.EqualError(t, errs[3], "authentication_backend: chain: backends: #3 (local): option 'domains' must not contain empty values")
+	assert.EqualError(t, errs[4], "authentication_backend: chain: backends: #3 (local): file: option 'watch' is not supported by the chain backend")
+	assert.EqualError(t, errs[5], "authentication_backend: chain: backends: #4 (local): option 'name' must be unique")
+	assert.EqualError(t, errs[6], "authentication_backend: chain: backends: #5 (db): the 'sql' backend may only be configured once")
+	assert.EqualError(t, errs[7], "authentication_backend: chain: backends: #6 (none): you must ensure either the 'file', 'ldap', or 'sql' authentication backend is configured")
+	assert.EqualError(t, errs[8], "authentication_backend: chain: backends: #7 (both): please ensure only one of the 'file', 'ldap', or 'sql' backend is configured")
+	assert.EqualError(t, errs[9], "authentication_backend: chain: backends: #7 (both): the 'sql' backend may only be configured once")
+	assert.EqualError(t, errs[10], "authentication_backend: chain: backends: #7 (both): file: option 'path' is required")
+}
+
+func TestShouldRaiseErrorOnEmptyChainBackend(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.AuthenticationBackend{Chain: &schema.AuthenticationBackendChain{}}
+
+	ValidateAuthenticationBackend(config, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "authentication_backend: chain: option 'backends' must contain at least one backend")
+}
+
+func TestShouldValidateKerberos(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.AuthenticationBackend{
+		File:     &schema.AuthenticationBackendFile{Path: "/config/users.yml"},
+		Kerberos: &schema.AuthenticationBackendKerberos{Keytab: "/config/krb5.keytab", Realms: []string{"EXAMPLE.COM"}},
+	}
+
+	ValidateAuthenticationBackend(config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	assert.Len(t, validator.Errors(), 0)
+
+	assert.Equal(t, schema.DefaultAuthenticationBackendKerberos.MaxClockSkew, config.Kerberos.MaxClockSkew)
+}
+
+func TestShouldRaiseErrorsOnInvalidKerberos(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.AuthenticationBackend{
+		File:     &schema.AuthenticationBackendFile{Path: "/config/users.yml"},
+		Kerberos: &schema.AuthenticationBackendKerberos{Realms: []string{"EXAMPLE.COM", ""}, MaxClockSkew: -time.Second},
+	}
+
+	ValidateAuthenticationBackend(config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+
+	errs := validator.Errors()
+
+	require.Len(t, errs, 3)
+
+	assert.EqualError(t, errs[0], "authentication_backend: kerberos: option 'keytab' is required")
+	assert.EqualError(t, errs[1], "authentication_backend: kerberos: option 'realms' must not contain empty values")
+	assert.EqualError(t, errs[2], "authentication_backend: kerberos: option 'max_clock_skew' must be greater than 0 but it's configured as '-1s'")
+}
+
+func TestShouldRaiseErrorWhenOnlyKerberosProvided(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.AuthenticationBackend{
+		Kerberos: &schema.AuthenticationBackendKerberos{Keytab: "/config/krb5.keytab"},
+	}
+
+	ValidateAuthenticationBackend(config, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "authentication_backend: you must ensure either the 'file', 'ldap', 'sql', or 'chain' authentication backend is configured")
+}
+
+func TestShouldValidateClientCertificate(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.AuthenticationBackend{
+		File:              &schema.AuthenticationBackendFile{Path: "/config/users_database.yml", Password: schema.DefaultPasswordConfig},
+		ClientCertificate: &schema.AuthenticationBackendClientCertificate{},
+	}
+
+	ValidateAuthenticationBackend(config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+	assert.Equal(t, schema.ClientCertificateMappingEmail, config.ClientCertificate.Mapping)
+	assert.Equal(t, "X-Forwarded-Client-Cert", config.ClientCertificate.Forwarded.Header)
+}
+
+func TestShouldRaiseErrorsOnInvalidClientCertificate(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.AuthenticationBackend{
+		File: &schema.AuthenticationBackendFile{Path: "/config/users_database.yml", Password: schema.DefaultPasswordConfig},
+		ClientCertificate: &schema.AuthenticationBackendClientCertificate{
+			Mapping: "cn",
+			Forwarded: schema.AuthenticationBackendClientCertificateForwarded{
+				TrustedProxies: []string{"10.0.0.0/8", "proxy.example.com"},
+			},
+		},
+	}
+
+	ValidateAuthenticationBackend(config, validator)
+
+	require.Len(t, validator.Errors(), 3)
+	assert.EqualError(t, validator.Errors()[0], "authentication_backend: client_certificate: option 'mapping' must be one of 'email', 'upn', or 'subject_dn' but it's configured as 'cn'")
+	assert.EqualError(t, validator.Errors()[1], "authentication_backend: client_certificate: forwarded: option 'trusted_proxies' must only contain IP addresses or networks in CIDR notation but it contains 'proxy.example.com'")
+	assert.EqualError(t, validator.Errors()[2], "authentication_backend: client_certificate: forwarded: option 'certificate_authorities' is required when option 'trusted_proxies' is configured")
 }
 
 type FileBasedAuthenticationBackend struct {
@@ -789,6 +1020,262 @@ func (suite *LDAPAuthenticationBackendSuite) TestShouldRaiseErrorOnBadFilterPlac
 	suite.EqualError(suite.validator.Errors()[3], "authentication_backend: ldap: option 'users_filter' must contain the placeholder '{input}' but it's absent")
 }
 
+func (suite *LDAPAuthenticationBackendSuite) TestShouldValidateExtraAttributes() {
+	suite.config.LDAP.Attributes.Extra = map[string]string{
+		"given_name":   "givenName",
+		"phone_number": "telephoneNumber",
+		"employee_id2": "employeeNumber",
+	}
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldRaiseErrorOnBadExtraAttributes() {
+	suite.config.LDAP.Attributes.Extra = map[string]string{
+		"GivenName":  "givenName",
+		"2fa":        "twoFactor",
+		"email":      "mail",
+		"department": "",
+	}
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 4)
+
+	suite.EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: attributes: extra: attribute '2fa' is invalid: the name must start with a lowercase letter and only contain lowercase letters, numbers, and underscores")
+	suite.EqualError(suite.validator.Errors()[1], "authentication_backend: ldap: attributes: extra: attribute 'GivenName' is invalid: the name must start with a lowercase letter and only contain lowercase letters, numbers, and underscores")
+	suite.EqualError(suite.validator.Errors()[2], "authentication_backend: ldap: attributes: extra: attribute 'department' must be configured with the name of a directory server attribute")
+	suite.EqualError(suite.validator.Errors()[3], "authentication_backend: ldap: attributes: extra: attribute 'email' is invalid: the name is reserved")
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldNotSetAdministrationDefaultsWhenDisabled() {
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal(schema.AuthenticationBackendLDAPAdministration{}, suite.config.LDAP.Administration)
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldSetAdministrationDefaults() {
+	suite.config.LDAP.Administration.Enable = true
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal("uid", suite.config.LDAP.Administration.UsersRDNAttribute)
+	suite.Equal([]string{"top", "person", "organizationalPerson", "inetOrgPerson"}, suite.config.LDAP.Administration.UsersObjectClasses)
+	suite.Equal("groupOfNames", suite.config.LDAP.Administration.GroupsObjectClass)
+	suite.Equal("member", suite.config.LDAP.Administration.GroupsMemberAttribute)
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldNotOverrideAdministrationOptions() {
+	suite.config.LDAP.Administration = schema.AuthenticationBackendLDAPAdministration{
+		Enable:                true,
+		UsersRDNAttribute:     "cn",
+		UsersObjectClasses:    []string{"posixAccount"},
+		GroupsObjectClass:     "posixGroup",
+		GroupsMemberAttribute: "memberUid",
+	}
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal("cn", suite.config.LDAP.Administration.UsersRDNAttribute)
+	suite.Equal([]string{"posixAccount"}, suite.config.LDAP.Administration.UsersObjectClasses)
+	suite.Equal("posixGroup", suite.config.LDAP.Administration.GroupsObjectClass)
+	suite.Equal("memberUid", suite.config.LDAP.Administration.GroupsMemberAttribute)
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldRaiseErrorOnAdministrationWithGLAuth() {
+	suite.config.LDAP.Implementation = schema.LDAPImplementationGLAuth
+	suite.config.LDAP.Administration.Enable = true
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: administration: option 'enable' can't be enabled with the 'glauth' implementation as it does not support administration")
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldRaiseErrorOnAdministrationWithUnauthenticatedBind() {
+	suite.config.LDAP.Administration.Enable = true
+	suite.config.LDAP.PermitUnauthenticatedBind = true
+	suite.config.LDAP.Password = ""
+	suite.config.PasswordReset.Disable = true
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: administration: option 'enable' can't be enabled when 'permit_unauthenticated_bind' is enabled")
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldNotSetPoolingDefaultsWhenDisabled() {
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal(schema.AuthenticationBackendLDAPPooling{}, suite.config.LDAP.Pooling)
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldSetPoolingDefaults() {
+	suite.config.LDAP.Pooling.Enable = true
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal(10, suite.config.LDAP.Pooling.MaxOpen)
+	suite.Equal(5, suite.config.LDAP.Pooling.MaxIdle)
+	suite.Equal(time.Second*10, suite.config.LDAP.Pooling.Timeout)
+	suite.Equal(time.Minute, suite.config.LDAP.Pooling.HealthCheckInterval)
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldSetPoolingMaxIdleDefaultFromMaxOpen() {
+	suite.config.LDAP.Pooling = schema.AuthenticationBackendLDAPPooling{
+		Enable:  true,
+		MaxOpen: 2,
+	}
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal(2, suite.config.LDAP.Pooling.MaxOpen)
+	suite.Equal(2, suite.config.LDAP.Pooling.MaxIdle)
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldRaiseErrorOnBadPooling() {
+	suite.config.LDAP.Pooling = schema.AuthenticationBackendLDAPPooling{
+		Enable:              true,
+		MaxOpen:             -1,
+		MaxIdle:             -1,
+		Timeout:             -time.Second,
+		HealthCheckInterval: -time.Minute,
+	}
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 4)
+
+	suite.EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: pooling: option 'max_open' must be greater than 0 but it's configured as '-1'")
+	suite.EqualError(suite.validator.Errors()[1], "authentication_backend: ldap: pooling: option 'max_idle' must be greater than 0 but it's configured as '-1'")
+	suite.EqualError(suite.validator.Errors()[2], "authentication_backend: ldap: pooling: option 'timeout' must be greater than 0 but it's configured as '-1s'")
+	suite.EqualError(suite.validator.Errors()[3], "authentication_backend: ldap: pooling: option 'health_check_interval' must be greater than 0 but it's configured as '-1m0s'")
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldRaiseErrorOnPoolingMaxIdleGreaterThanMaxOpen() {
+	suite.config.LDAP.Pooling = schema.AuthenticationBackendLDAPPooling{
+		Enable:  true,
+		MaxOpen: 2,
+		MaxIdle: 3,
+	}
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: pooling: option 'max_idle' is configured as '3' but must be less than or equal to option 'max_open' which is configured as '2'")
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldNotSetFailoverDefaultsWithoutAddresses() {
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal(schema.AuthenticationBackendLDAPFailover{}, suite.config.LDAP.Failover)
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldSetFailoverDefaults() {
+	suite.config.LDAP.Failover.Addresses = []*schema.AddressLDAP{{Address: *MustParseAddressPtr("ldaps://ldap2")}}
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal(schema.LDAPFailoverStrategyOrdered, suite.config.LDAP.Failover.Strategy)
+	suite.Equal(time.Second*5, suite.config.LDAP.Failover.Backoff)
+	suite.Equal(time.Minute*5, suite.config.LDAP.Failover.MaxBackoff)
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldSetFailoverMaxBackoffDefaultFromBackoff() {
+	suite.config.LDAP.Failover = schema.AuthenticationBackendLDAPFailover{
+		Addresses: []*schema.AddressLDAP{{Address: *MustParseAddressPtr("ldaps://ldap2")}},
+		Strategy:  schema.LDAPFailoverStrategyRandom,
+		Backoff:   time.Minute * 10,
+	}
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal(schema.LDAPFailoverStrategyRandom, suite.config.LDAP.Failover.Strategy)
+	suite.Equal(time.Minute*10, suite.config.LDAP.Failover.Backoff)
+	suite.Equal(time.Minute*10, suite.config.LDAP.Failover.MaxBackoff)
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldRaiseErrorOnBadFailover() {
+	suite.config.LDAP.Failover = schema.AuthenticationBackendLDAPFailover{
+		Addresses: []*schema.AddressLDAP{
+			nil,
+			{Address: *MustParseAddressPtr("tcp://ldap2:389")},
+			{Address: *MustParseAddressPtr(testLDAPURL)},
+			{Address: *MustParseAddressPtr("ldaps://ldap3")},
+			{Address: *MustParseAddressPtr("ldaps://LDAP3")},
+		},
+		Strategy:   "roundrobin",
+		Backoff:    -time.Second,
+		MaxBackoff: -time.Minute,
+	}
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 7)
+
+	suite.EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: failover: option 'addresses' must not contain empty values")
+	suite.EqualError(suite.validator.Errors()[1], "authentication_backend: ldap: failover: option 'addresses' with value 'tcp://ldap2:389' is invalid: scheme must be one of 'ldap', 'ldaps', or 'ldapi' but is configured as 'tcp'")
+	suite.EqualError(suite.validator.Errors()[2], "authentication_backend: ldap: failover: option 'addresses' with value 'ldap://ldap:389' is invalid: the address is already configured")
+	suite.EqualError(suite.validator.Errors()[3], "authentication_backend: ldap: failover: option 'addresses' with value 'ldaps://LDAP3:636' is invalid: the address is already configured")
+	suite.EqualError(suite.validator.Errors()[4], "authentication_backend: ldap: failover: option 'strategy' must be one of 'ordered' or 'random' but it's configured as 'roundrobin'")
+	suite.EqualError(suite.validator.Errors()[5], "authentication_backend: ldap: failover: option 'backoff' must be greater than 0 but it's configured as '-1s'")
+	suite.EqualError(suite.validator.Errors()[6], "authentication_backend: ldap: failover: option 'max_backoff' must be greater than 0 but it's configured as '-1m0s'")
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldRaiseErrorOnFailoverMaxBackoffLessThanBackoff() {
+	suite.config.LDAP.Failover = schema.AuthenticationBackendLDAPFailover{
+		Addresses:  []*schema.AddressLDAP{{Address: *MustParseAddressPtr("ldaps://ldap2")}},
+		Backoff:    time.Minute,
+		MaxBackoff: time.Second,
+	}
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: failover: option 'max_backoff' is configured as '1s' but must be greater than or equal to option 'backoff' which is configured as '1m0s'")
+}
+
 func (suite *LDAPAuthenticationBackendSuite) TestShouldSetDefaultGroupNameAttribute() {
 	ValidateAuthenticationBackend(&suite.config, suite.validator)
 
@@ -903,7 +1390,7 @@ func (suite *LDAPAuthenticationBackendSuite) TestShouldErrorOnBadSearchMode() {
 	suite.Len(suite.validator.Warnings(), 0)
 	suite.Require().Len(suite.validator.Errors(), 1)
 
-	suite.EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: option 'group_search_mode' must be one of 'filter' or 'memberof' but it's configured as 'memberOF'")
+	suite.EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: option 'group_search_mode' must be one of 'filter', 'memberof', or 'nested' but it's configured as 'memberOF'")
 }
 
 func (suite *LDAPAuthenticationBackendSuite) TestShouldNoErrorOnPlaceholderSearchMode() {
@@ -928,6 +1415,43 @@ func (suite *LDAPAuthenticationBackendSuite) TestShouldErrorOnMissingPlaceholder
 	suite.EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: option 'groups_filter' must contain one of the '{memberof:rdn}' or '{memberof:dn}' placeholders when using a group_search_mode of 'memberof' but they're absent")
 }
 
+func (suite *LDAPAuthenticationBackendSuite) TestShouldSetDefaultNestedGroupsMaxDepth() {
+	suite.config.LDAP.GroupSearchMode = schema.LDAPGroupSearchModeNested
+	suite.config.LDAP.GroupsFilter = "(&(member={dn})(objectClass=groupOfNames))"
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal(10, suite.config.LDAP.NestedGroupsMaxDepth)
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldNotSetDefaultNestedGroupsMaxDepthWhenNotNested() {
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal(0, suite.config.LDAP.NestedGroupsMaxDepth)
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldErrorOnBadNestedSearchMode() {
+	suite.config.LDAP.GroupSearchMode = schema.LDAPGroupSearchModeNested
+	suite.config.LDAP.GroupsFilter = filterMemberOfRDN
+	suite.config.LDAP.Attributes.MemberOf = memberOf
+	suite.config.LDAP.NestedGroupsMaxDepth = -1
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 3)
+
+	suite.EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: option 'groups_filter' must contain the placeholder '{dn}' when using a group_search_mode of 'nested' but it's absent")
+	suite.EqualError(suite.validator.Errors()[1], "authentication_backend: ldap: option 'groups_filter' must not contain the '{memberof:rdn}' or '{memberof:dn}' placeholders when using a group_search_mode of 'nested'")
+	suite.EqualError(suite.validator.Errors()[2], "authentication_backend: ldap: option 'nested_groups_max_depth' must be greater than 0 but it's configured as '-1'")
+}
+
 func (suite *LDAPAuthenticationBackendSuite) TestShouldErrorOnMissingDistinguishedNameDN() {
 	suite.config.LDAP.Attributes.DistinguishedName = ""
 	suite.config.LDAP.GroupsFilter = "(|({memberof:dn}))"
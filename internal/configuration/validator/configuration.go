@@ -72,6 +72,8 @@ func ValidateConfiguration(config *schema.Configuration, validator *schema.Struc
 	ValidatePasswordPolicy(&config.PasswordPolicy, validator)
 
 	ValidatePrivacyPolicy(&config.PrivacyPolicy, validator)
+
+	ValidateAuditLog(config, validator)
 }
 
 func validateDefault2FAMethod(config *schema.Configuration, validator *schema.StructValidator) {
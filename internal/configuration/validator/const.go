@@ -7,6 +7,7 @@ import (
 	"github.com/go-webauthn/webauthn/protocol"
 	"github.com/valyala/fasthttp"
 
+	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/logging"
 	"github.com/authelia/authelia/v4/internal/oidc"
@@ -85,15 +86,44 @@ const (
 
 // Authentication Backend Error constants.
 const (
-	errFmtAuthBackendNotConfigured = "authentication_backend: you must ensure either the 'file' or 'ldap' " +
+	errFmtAuthBackendNotConfigured = "authentication_backend: you must ensure either the 'file', 'ldap', 'sql', or 'chain' " +
 		"authentication backend is configured"
-	errFmtAuthBackendMultipleConfigured = "authentication_backend: please ensure only one of the 'file' or 'ldap' " +
+	errFmtAuthBackendMultipleConfigured = "authentication_backend: please ensure only one of the 'file', 'ldap', 'sql', or 'chain' " +
 		"backend is configured"
 	errFmtAuthBackendRefreshInterval = "authentication_backend: option 'refresh_interval' is configured to '%s' but " +
 		"it must be either in duration common syntax or one of 'disable', or 'always': %w"
 	errFmtAuthBackendPasswordResetCustomURLScheme = "authentication_backend: password_reset: option 'custom_url' is" +
 		" configured to '%s' which has the scheme '%s' but the scheme must be either 'http' or 'https'"
 
+	errFmtChainAuthBackendNoBackends   = "authentication_backend: chain: option 'backends' must contain at least one backend"
+	errFmtChainAuthBackendConflictMode = "authentication_backend: chain: option 'conflict_mode' must be one of %s but " +
+		"it's configured as '%s'"
+	errFmtChainAuthBackendBackend            = "authentication_backend: chain: backends: #%d (%s): "
+	errFmtChainAuthBackendBackendNameMissing = "authentication_backend: chain: backends: #%d: option 'name' is required"
+	errFmtChainAuthBackendBackendNameInvalid = errFmtChainAuthBackendBackend +
+		"option 'name' must start with a lowercase letter and only contain lowercase letters, numbers, hyphens, and underscores"
+	errFmtChainAuthBackendBackendNameNotUnique = errFmtChainAuthBackendBackend + "option 'name' must be unique"
+	errFmtChainAuthBackendBackendNotConfigured = errFmtChainAuthBackendBackend +
+		"you must ensure either the 'file', 'ldap', or 'sql' authentication backend is configured"
+	errFmtChainAuthBackendBackendMultipleConfigured = errFmtChainAuthBackendBackend +
+		"please ensure only one of the 'file', 'ldap', or 'sql' backend is configured"
+	errFmtChainAuthBackendBackendDomainEmpty = errFmtChainAuthBackendBackend + "option 'domains' must not contain empty values"
+	errFmtChainAuthBackendBackendFileWatch   = errFmtChainAuthBackendBackend + "file: option 'watch' is not supported by the chain backend"
+	errFmtChainAuthBackendBackendSQLMultiple = errFmtChainAuthBackendBackend + "the 'sql' backend may only be configured once"
+	errFmtChainAuthBackendBackendValidation  = errFmtChainAuthBackendBackend + "%s"
+
+	errFmtKerberosAuthBackendKeytabNotConfigured = "authentication_backend: kerberos: option 'keytab' is required"
+	errFmtKerberosAuthBackendRealmEmpty          = "authentication_backend: kerberos: option 'realms' must not contain empty values"
+	errFmtKerberosAuthBackendNegativeDuration    = "authentication_backend: kerberos: option 'max_clock_skew' " +
+		"must be greater than 0 but it's configured as '%s'"
+
+	errFmtClientCertificateAuthBackendMapping = "authentication_backend: client_certificate: option 'mapping' " +
+		errSuffixMustBeOneOf
+	errFmtClientCertificateAuthBackendTrustedProxy = "authentication_backend: client_certificate: forwarded: option " +
+		"'trusted_proxies' must only contain IP addresses or networks in CIDR notation but it contains '%s'"
+	errFmtClientCertificateAuthBackendNoCertificateAuthorities = "authentication_backend: client_certificate: forwarded: " +
+		"option 'certificate_authorities' is required when option 'trusted_proxies' is configured"
+
 	errFmtFileAuthBackendPathNotConfigured  = "authentication_backend: file: option 'path' is required"
 	errFmtFileAuthBackendPasswordUnknownAlg = "authentication_backend: file: password: option 'algorithm' " +
 		errSuffixMustBeOneOf
@@ -124,8 +154,46 @@ const (
 		"must contain the placeholder '{%s}' but it's absent"
 	errFmtLDAPAuthBackendFilterMissingPlaceholderGroupSearchMode = errFmtLDAPAuthBackendOption +
 		"must contain one of the %s placeholders when using a group_search_mode of '%s' but they're absent"
+	errFmtLDAPAuthBackendFilterRequiredPlaceholderGroupSearchMode = errFmtLDAPAuthBackendOption +
+		"must contain the placeholder '%s' when using a group_search_mode of '%s' but it's absent"
+	errFmtLDAPAuthBackendFilterUnsupportedPlaceholderGroupSearchMode = errFmtLDAPAuthBackendOption +
+		"must not contain the %s placeholders when using a group_search_mode of '%s'"
+	errFmtLDAPAuthBackendNestedGroupsMaxDepth = "authentication_backend: ldap: option 'nested_groups_max_depth' " +
+		"must be greater than 0 but it's configured as '%d'"
 	errFmtLDAPAuthBackendFilterMissingAttribute = "authentication_backend: ldap: attributes: option '%s' " +
 		"must be provided when using the %s placeholder but it's absent"
+	errFmtLDAPAuthBackendExtraAttributeInvalidName = "authentication_backend: ldap: attributes: extra: attribute '%s' " +
+		"is invalid: the name must start with a lowercase letter and only contain lowercase letters, numbers, and underscores"
+	errFmtLDAPAuthBackendExtraAttributeReservedName = "authentication_backend: ldap: attributes: extra: attribute '%s' " +
+		"is invalid: the name is reserved"
+	errFmtLDAPAuthBackendExtraAttributeMissingValue = "authentication_backend: ldap: attributes: extra: attribute '%s' " +
+		"must be configured with the name of a directory server attribute"
+	errFmtLDAPAuthBackendAdministrationMissingOption = "authentication_backend: ldap: administration: option '%s' " +
+		"is required when administration is enabled"
+	errFmtLDAPAuthBackendAdministrationMissingAttribute = "authentication_backend: ldap: attributes: option '%s' " +
+		"must be provided when administration is enabled but it's absent"
+	errFmtLDAPAuthBackendAdministrationImplementation = "authentication_backend: ldap: administration: option 'enable' " +
+		"can't be enabled with the '%s' implementation as it does not support administration"
+	errFmtLDAPAuthBackendAdministrationUnauthenticatedBind = "authentication_backend: ldap: administration: option 'enable' " +
+		"can't be enabled when 'permit_unauthenticated_bind' is enabled"
+	errFmtLDAPAuthBackendPoolingNegative = "authentication_backend: ldap: pooling: option '%s' " +
+		"must be greater than 0 but it's configured as '%d'"
+	errFmtLDAPAuthBackendPoolingNegativeDuration = "authentication_backend: ldap: pooling: option '%s' " +
+		"must be greater than 0 but it's configured as '%s'"
+	errFmtLDAPAuthBackendPoolingMaxIdleGreaterThanMaxOpen = "authentication_backend: ldap: pooling: option 'max_idle' " +
+		"is configured as '%d' but must be less than or equal to option 'max_open' which is configured as '%d'"
+	errFmtLDAPAuthBackendFailoverAddress = "authentication_backend: ldap: failover: option 'addresses' " +
+		"with value '%s' is invalid: %w"
+	errFmtLDAPAuthBackendFailoverAddressMissing = "authentication_backend: ldap: failover: option 'addresses' " +
+		"must not contain empty values"
+	errFmtLDAPAuthBackendFailoverAddressDuplicate = "authentication_backend: ldap: failover: option 'addresses' " +
+		"with value '%s' is invalid: the address is already configured"
+	errFmtLDAPAuthBackendFailoverOptionMustBeOneOf = "authentication_backend: ldap: failover: option '%s' " +
+		errSuffixMustBeOneOf
+	errFmtLDAPAuthBackendFailoverNegativeDuration = "authentication_backend: ldap: failover: option '%s' " +
+		"must be greater than 0 but it's configured as '%s'"
+	errFmtLDAPAuthBackendFailoverMaxBackoffLessThanBackoff = "authentication_backend: ldap: failover: option 'max_backoff' " +
+		"is configured as '%s' but must be greater than or equal to option 'backoff' which is configured as '%s'"
 )
 
 // TOTP Error constants.
@@ -160,6 +228,17 @@ const (
 	errFmtTelemetryMetricsAddress = "telemetry: metrics: option 'address' with value '%s' is invalid: %w"
 )
 
+// Audit Log Error constants.
+const (
+	errFmtAuditLogFileNoPath           = "audit_log: file: option 'path' is required"
+	errFmtAuditLogSyslogAddressScheme  = "audit_log: syslog: option 'address' with value '%s' is invalid: scheme must be one of 'udp', 'udp4', 'udp6', or 'unix' but is configured as '%s'"
+	errFmtAuditLogSyslogFacility       = "audit_log: syslog: option 'facility' must be one of %s but it's configured as '%s'"
+	errFmtAuditLogWebhookNoAddress     = "audit_log: webhook: option 'address' is required"
+	errFmtAuditLogWebhookAddressScheme = "audit_log: webhook: option 'address' with value '%s' must have the 'http' or 'https' scheme but has the '%s' scheme"
+	errFmtAuditLogWebhookBufferSize    = "audit_log: webhook: option 'buffer_size' must be greater than or equal to the 'batch_size' of %[2]d but it's configured as %[1]d"
+	errFmtAuditLogWebhookTLS           = "audit_log: webhook: tls: %w"
+)
+
 // OpenID Error constants.
 const (
 	errFmtOIDCProviderNoClientsConfigured = "identity_providers: oidc: option 'clients' must have one or " +
@@ -192,6 +271,10 @@ const (
 	errFmtOIDCCORSInvalidOriginWildcardWithClients = "identity_providers: oidc: cors: option 'allowed_origins' contains the wildcard origin '*' cannot be specified with option 'allowed_origins_from_client_redirect_uris' enabled"
 	errFmtOIDCCORSInvalidEndpoint                  = "identity_providers: oidc: cors: option 'endpoints' contains an invalid value '%s': must be one of %s"
 
+	errFmtOIDCDynamicClientRegistrationMissingOption = "identity_providers: oidc: dynamic_client_registration: option '%s' is required when dynamic client registration is enabled"
+	errFmtOIDCDynamicClientRegistrationInvalidValue  = "identity_providers: oidc: dynamic_client_registration: option '%s' must be one of %s but it's configured as '%s'"
+	errFmtOIDCDynamicClientRegistrationNoLifespans   = "identity_providers: oidc: dynamic_client_registration: option 'lifespan' must not be configured when no custom lifespans are configured but it's configured as '%s'"
+
 	errFmtOIDCPolicyInvalidName          = "identity_providers: oidc: authorization_policies: authorization policies must have a name but one with a blank name exists"
 	errFmtOIDCPolicyInvalidNameStandard  = "identity_providers: oidc: authorization_policies: policy '%s': option '%s' must not be one of %s but it's configured as '%s'"
 	errFmtOIDCPolicyMissingOption        = "identity_providers: oidc: authorization_policies: policy '%s': option '%s' is required"
@@ -227,6 +310,30 @@ const (
 	errFmtOIDCClientRedirectURIAbsolute = errFmtOIDCClientRedirectURIHas +
 		"an invalid value: redirect uri '%s' must have a scheme but it's absent"
 
+	errFmtOIDCClientPostLogoutRedirectURIHas          = errFmtOIDCClientOption + "'post_logout_redirect_uris' has "
+	errFmtOIDCClientPostLogoutRedirectURICantBeParsed = errFmtOIDCClientPostLogoutRedirectURIHas +
+		"an invalid value: post logout redirect uri '%s' could not be parsed: %v"
+	errFmtOIDCClientPostLogoutRedirectURINotAbsolute = errFmtOIDCClientPostLogoutRedirectURIHas +
+		"an invalid value: post logout redirect uri '%s' must have a scheme but it's absent"
+	errFmtOIDCClientPostLogoutRedirectURIFragment = errFmtOIDCClientPostLogoutRedirectURIHas +
+		"an invalid value: post logout redirect uri '%s' must not have a fragment"
+
+	errFmtOIDCClientLogoutURIAbsolute = errFmtOIDCClientOption +
+		"'%s' with value '%s': must be an absolute URI"
+	errFmtOIDCClientLogoutURIScheme = errFmtOIDCClientOption +
+		"'%s' with value '%s': must have the 'http' or 'https' scheme but has the '%s' scheme"
+	errFmtOIDCClientLogoutURIFragment = errFmtOIDCClientOption +
+		"'%s' with value '%s': must not have a fragment"
+
+	errFmtOIDCClientTokenExchangeWithoutGrantType = errFmtOIDCClientOption +
+		"'token_exchange' must only be configured when option 'grant_types' includes '%s'"
+	errFmtOIDCClientTokenExchangeSubjectTokenAudiences = errFmtOIDCClientOption +
+		"'token_exchange' option 'subject_token_audiences' is required when option 'grant_types' includes '%s'"
+	errFmtOIDCClientTokenExchangeTargetAudiences = errFmtOIDCClientOption +
+		"'token_exchange' option 'target_audiences' must only have values from option 'audience' but it has the values %s which are not"
+	errFmtOIDCClientTokenExchangeActorTokenClients = errFmtOIDCClientOption +
+		"'token_exchange' option 'actor_token_clients' must only have values which are the id of a registered client but it has the values %s which are not"
+
 	errFmtOIDCClientRequestURIHas          = errFmtOIDCClientOption + "'request_uris' has "
 	errFmtOIDCClientRequestURICantBeParsed = errFmtOIDCClientRequestURIHas +
 		"an invalid value: request uri '%s' could not be parsed: %v"
@@ -326,18 +433,27 @@ const (
 		"https://www.authelia.com/c/acl-match-concept-2"
 	errFmtAccessControlRuleNetworksInvalid = "access_control: rule %s: the network '%s' is not a " +
 		"valid Group Name, IP, or CIDR notation"
-	errFmtAccessControlRuleSubjectInvalid = "access_control: rule %s: 'subject' option '%s' is " +
-		"invalid: must start with 'user:' or 'group:'"
+	errFmtAccessControlRuleNetworksGeoIPInvalid = "access_control: rule %s: networks: %w"
+	errFmtAccessControlNetworkGroupGeoIPInvalid = "access_control: networks: network group '%s' is invalid: %w"
+	errFmtAccessControlGeoIPDatabaseRequired    = "the network '%s' requires the 'geoip' option '%s' to be configured"
+	errFmtAccessControlRuleSubjectInvalid       = "access_control: rule %s: 'subject' option '%s' is " +
+		"invalid: %w"
 	errFmtAccessControlRuleInvalidEntries              = "access_control: rule %s: option '%s' must only have the values %s but the values %s are present"
 	errFmtAccessControlRuleInvalidDuplicates           = "access_control: rule %s: option '%s' must have unique values but the values %s are duplicated"
-	errFmtAccessControlRuleQueryInvalid                = "access_control: rule %s: query: option 'operator' must be one of %s but it's configured as '%s'"
-	errFmtAccessControlRuleQueryInvalidNoValue         = "access_control: rule %s: query: option '%s' is required but it's absent"
-	errFmtAccessControlRuleQueryInvalidNoValueOperator = "access_control: rule %s: query: option '%s' must be present when the option 'operator' is '%s' but it's absent"
-	errFmtAccessControlRuleQueryInvalidValue           = "access_control: rule %s: query: option '%s' must not be present when the option 'operator' is '%s' but it's present"
-	errFmtAccessControlRuleQueryInvalidValueParse      = "access_control: rule %s: query: option '%s' is " +
+	errFmtAccessControlRuleQueryInvalid                = "access_control: rule %s: %s: option 'operator' must be one of %s but it's configured as '%s'"
+	errFmtAccessControlRuleQueryInvalidNoValue         = "access_control: rule %s: %s: option '%s' is required but it's absent"
+	errFmtAccessControlRuleQueryInvalidNoValueOperator = "access_control: rule %s: %s: option '%s' must be present when the option 'operator' is '%s' but it's absent"
+	errFmtAccessControlRuleQueryInvalidValue           = "access_control: rule %s: %s: option '%s' must not be present when the option 'operator' is '%s' but it's present"
+	errFmtAccessControlRuleQueryInvalidValueParse      = "access_control: rule %s: %s: option '%s' is " +
 		"invalid: %w"
-	errFmtAccessControlRuleQueryInvalidValueType = "access_control: rule %s: query: option 'value' is " +
+	errFmtAccessControlRuleQueryInvalidValueType = "access_control: rule %s: %s: option 'value' is " +
 		"invalid: expected type was string but got %T"
+	errFmtAccessControlRuleScheduleInvalidEntries    = "access_control: rule %s: schedule #%d: option 'days' must only have the values %s but the values %s are present"
+	errFmtAccessControlRuleScheduleInvalidDuplicates = "access_control: rule %s: schedule #%d: option 'days' must have unique values but the values %s are duplicated"
+	errFmtAccessControlRuleScheduleInvalid           = "access_control: rule %s: schedule #%d: %w"
+	errFmtAccessControlRuleSecondFactorMethodsPolicy = "access_control: rule %s: option 'second_factor_methods' is only valid with the '%s' policy but the policy is '%s'"
+	errFmtAccessControlRuleMaxAgePolicy              = "access_control: rule %s: option 'max_age' is only valid with the 'one_factor' or 'two_factor' policies but the policy is '%s'"
+	errFmtAccessControlRuleMaxAgeNegative            = "access_control: rule %s: option 'max_age' must be a positive duration but it's configured as '%s'"
 )
 
 // Theme Error constants.
@@ -392,6 +508,7 @@ const (
 	errFmtServerTLSCert             = "server: tls: option 'key' must also be accompanied by option 'certificate'"
 	errFmtServerTLSKey              = "server: tls: option 'certificate' must also be accompanied by option 'key'"
 	errFmtServerTLSClientAuthNoAuth = "server: tls: client authentication cannot be configured if no server certificate and key are provided"
+	errFmtServerTLSClientAuth       = "server: tls: option 'client_authentication' " + errSuffixMustBeOneOf
 
 	errFmtServerAddress = "server: option 'address' with value '%s' is invalid: %w"
 
@@ -403,6 +520,8 @@ const (
 	errFmtServerEndpointsAuthzSchemes                   = "server: endpoints: authz: %s: authn_strategies: strategy #%d (%s): option 'schemes' must only include the values %s but has '%s'"
 	errFmtServerEndpointsAuthzSchemesInvalidForStrategy = "server: endpoints: authz: %s: authn_strategies: strategy #%d (%s): option 'schemes' is not valid for the strategy"
 	errFmtServerEndpointsAuthzStrategyNoName            = "server: endpoints: authz: %s: authn_strategies: strategy #%d: option 'name' must be configured"
+	errFmtServerEndpointsAuthzStrategySPNEGO            = "server: endpoints: authz: %s: authn_strategies: strategy #%d (%s): the 'authentication_backend' section must have the 'kerberos' section configured to use this strategy"
+	errFmtServerEndpointsAuthzStrategyClientCertificate = "server: endpoints: authz: %s: authn_strategies: strategy #%d (%s): the 'authentication_backend' section must have the 'client_certificate' section configured to use this strategy"
 	errFmtServerEndpointsAuthzStrategyDuplicate         = "server: endpoints: authz: %s: authn_strategies: duplicate strategy name detected with name '%s'"
 	errFmtServerEndpointsAuthzPrefixDuplicate           = "server: endpoints: authz: %s: endpoint starts with the same prefix as the '%s' endpoint with the '%s' implementation which accepts prefixes as part of its implementation"
 	errFmtServerEndpointsAuthzInvalidName               = "server: endpoints: authz: %s: contains invalid characters"
@@ -464,7 +583,7 @@ const (
 
 var (
 	validAuthzImplementations       = []string{schema.AuthzImplementationAuthRequest, schema.AuthzImplementationForwardAuth, schema.AuthzImplementationExtAuthz, schema.AuthzImplementationLegacy}
-	validAuthzAuthnStrategies       = []string{schema.AuthzStrategyHeaderCookieSession, schema.AuthzStrategyHeaderAuthorization, schema.AuthzStrategyHeaderProxyAuthorization, schema.AuthzStrategyHeaderAuthRequestProxyAuthorization, schema.AuthzStrategyHeaderLegacy}
+	validAuthzAuthnStrategies       = []string{schema.AuthzStrategyHeaderCookieSession, schema.AuthzStrategyHeaderAuthorization, schema.AuthzStrategyHeaderProxyAuthorization, schema.AuthzStrategyHeaderAuthRequestProxyAuthorization, schema.AuthzStrategyHeaderLegacy, schema.AuthzStrategyHeaderSPNEGO, schema.AuthzStrategyClientCertificate}
 	validAuthzAuthnHeaderStrategies = []string{schema.AuthzStrategyHeaderAuthorization, schema.AuthzStrategyHeaderProxyAuthorization, schema.AuthzStrategyHeaderAuthRequestProxyAuthorization}
 	validAuthzAuthnStrategySchemes  = []string{schema.SchemeBasic, schema.SchemeBearer}
 )
@@ -482,6 +601,28 @@ var (
 	validLDAPGroupSearchModes = []string{
 		schema.LDAPGroupSearchModeFilter,
 		schema.LDAPGroupSearchModeMemberOf,
+		schema.LDAPGroupSearchModeNested,
+	}
+
+	validLDAPFailoverStrategies = []string{
+		schema.LDAPFailoverStrategyOrdered,
+		schema.LDAPFailoverStrategyRandom,
+	}
+
+	validAuthBackendChainConflictModes = []string{
+		schema.AuthenticationBackendChainConflictModePrecedence,
+		schema.AuthenticationBackendChainConflictModeDeny,
+	}
+
+	validAuthBackendClientCertificateMappings = []string{
+		schema.ClientCertificateMappingEmail,
+		schema.ClientCertificateMappingUPN,
+		schema.ClientCertificateMappingSubjectDN,
+	}
+
+	validServerTLSClientAuthentication = []string{
+		schema.ServerTLSClientAuthenticationRequire,
+		schema.ServerTLSClientAuthenticationOptional,
 	}
 )
 
@@ -503,45 +644,56 @@ var (
 	validWebAuthnUserVerificationRequirement = []string{string(protocol.VerificationDiscouraged), string(protocol.VerificationPreferred), string(protocol.VerificationRequired)}
 	validRFC7231HTTPMethodVerbs              = []string{fasthttp.MethodGet, fasthttp.MethodHead, fasthttp.MethodPost, fasthttp.MethodPut, fasthttp.MethodPatch, fasthttp.MethodDelete, fasthttp.MethodTrace, fasthttp.MethodConnect, fasthttp.MethodOptions}
 	validRFC4918HTTPMethodVerbs              = []string{"COPY", "LOCK", "MKCOL", "MOVE", "PROPFIND", "PROPPATCH", "UNLOCK"}
+	validSyslogFacilities                    = []string{"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news", "uucp", "cron", "authpriv", "ftp", "local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7"}
 )
 
 var (
 	validACLHTTPMethodVerbs = append(validRFC7231HTTPMethodVerbs, validRFC4918HTTPMethodVerbs...)
 	validACLRulePolicies    = []string{policyBypass, policyOneFactor, policyTwoFactor, policyDeny}
 	validACLRuleOperators   = []string{operatorPresent, operatorAbsent, operatorEqual, operatorNotEqual, operatorPattern, operatorNotPattern}
+	validACLScheduleDays    = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+	validACLRuleSecondFactorMethods = []string{
+		authorization.SecondFactorMethodTOTP, authorization.SecondFactorMethodDuo, authorization.SecondFactorMethodWebAuthn,
+		authorization.SecondFactorMethodWebAuthnHardware, authorization.SecondFactorMethodWebAuthnSoftware,
+		authorization.SecondFactorMethodWebAuthnUserVerified, authorization.SecondFactorMethodPhishingResistant,
+	}
 )
 
 var validDefault2FAMethods = []string{"totp", "webauthn", "mobile_push"}
 
 const (
-	attrOIDCKey                   = "key"
-	attrOIDCKeyID                 = "key_id"
-	attrOIDCKeyUse                = "use"
-	attrOIDCAlgorithm             = "algorithm"
-	attrOIDCScopes                = "scopes"
-	attrOIDCResponseTypes         = "response_types"
-	attrOIDCResponseModes         = "response_modes"
-	attrOIDCGrantTypes            = "grant_types"
-	attrOIDCRedirectURIs          = "redirect_uris"
-	attrOIDCRequestURIs           = "request_uris"
-	attrOIDCTokenAuthMethod       = "token_endpoint_auth_method"
-	attrOIDCDiscoSigAlg           = "discovery_signed_response_alg"
-	attrOIDCDiscoSigKID           = "discovery_signed_response_key_id"
-	attrOIDCUsrSigAlg             = "userinfo_signed_response_alg"
-	attrOIDCUsrSigKID             = "userinfo_signed_response_key_id"
-	attrOIDCIntrospectionSigAlg   = "introspection_signed_response_alg"
-	attrOIDCIntrospectionSigKID   = "introspection_signed_response_key_id"
-	attrOIDCAuthorizationSigAlg   = "authorization_signed_response_alg"
-	attrOIDCAuthorizationSigKID   = "authorization_signed_response_key_id"
-	attrOIDCIDTokenSigAlg         = "id_token_signed_response_alg"
-	attrOIDCIDTokenSigKID         = "id_token_signed_response_key_id"
-	attrOIDCAccessTokenSigAlg     = "access_token_signed_response_alg"
-	attrOIDCAccessTokenSigKID     = "access_token_signed_response_key_id"
-	attrOIDCPKCEChallengeMethod   = "pkce_challenge_method"
-	attrOIDCRequestedAudienceMode = "requested_audience_mode"
-	attrSessionAutheliaURL        = "authelia_url"
-	attrSessionDomain             = "domain"
-	attrDefaultRedirectionURL     = "default_redirection_url"
+	attrOIDCKey                    = "key"
+	attrOIDCKeyID                  = "key_id"
+	attrOIDCKeyUse                 = "use"
+	attrOIDCAlgorithm              = "algorithm"
+	attrOIDCScopes                 = "scopes"
+	attrOIDCResponseTypes          = "response_types"
+	attrOIDCResponseModes          = "response_modes"
+	attrOIDCGrantTypes             = "grant_types"
+	attrOIDCRedirectURIs           = "redirect_uris"
+	attrOIDCRequestURIs            = "request_uris"
+	attrOIDCPostLogoutRedirectURIs = "post_logout_redirect_uris"
+	attrOIDCBackChannelLogoutURI   = "backchannel_logout_uri"
+	attrOIDCFrontChannelLogoutURI  = "frontchannel_logout_uri"
+	attrOIDCTokenAuthMethod        = "token_endpoint_auth_method"
+	attrOIDCDiscoSigAlg            = "discovery_signed_response_alg"
+	attrOIDCDiscoSigKID            = "discovery_signed_response_key_id"
+	attrOIDCUsrSigAlg              = "userinfo_signed_response_alg"
+	attrOIDCUsrSigKID              = "userinfo_signed_response_key_id"
+	attrOIDCIntrospectionSigAlg    = "introspection_signed_response_alg"
+	attrOIDCIntrospectionSigKID    = "introspection_signed_response_key_id"
+	attrOIDCAuthorizationSigAlg    = "authorization_signed_response_alg"
+	attrOIDCAuthorizationSigKID    = "authorization_signed_response_key_id"
+	attrOIDCIDTokenSigAlg          = "id_token_signed_response_alg"
+	attrOIDCIDTokenSigKID          = "id_token_signed_response_key_id"
+	attrOIDCAccessTokenSigAlg      = "access_token_signed_response_alg"
+	attrOIDCAccessTokenSigKID      = "access_token_signed_response_key_id"
+	attrOIDCPKCEChallengeMethod    = "pkce_challenge_method"
+	attrOIDCRequestedAudienceMode  = "requested_audience_mode"
+	attrSessionAutheliaURL         = "authelia_url"
+	attrSessionDomain              = "domain"
+	attrDefaultRedirectionURL      = "default_redirection_url"
 )
 
 var (
@@ -549,16 +701,16 @@ var (
 )
 
 var (
-	validOIDCCORSEndpoints = []string{oidc.EndpointAuthorization, oidc.EndpointPushedAuthorizationRequest, oidc.EndpointToken, oidc.EndpointIntrospection, oidc.EndpointRevocation, oidc.EndpointUserinfo}
+	validOIDCCORSEndpoints = []string{oidc.EndpointAuthorization, oidc.EndpointPushedAuthorizationRequest, oidc.EndpointToken, oidc.EndpointIntrospection, oidc.EndpointRevocation, oidc.EndpointUserinfo, oidc.EndpointEndSession, oidc.EndpointDeviceAuthorization}
 
-	validOIDCClientScopes                    = []string{oidc.ScopeOpenID, oidc.ScopeEmail, oidc.ScopeProfile, oidc.ScopeGroups, oidc.ScopeOfflineAccess, oidc.ScopeOffline, oidc.ScopeAutheliaBearerAuthz}
+	validOIDCClientScopes                    = []string{oidc.ScopeOpenID, oidc.ScopeEmail, oidc.ScopeProfile, oidc.ScopeGroups, oidc.ScopePhone, oidc.ScopeAddress, oidc.ScopeOfflineAccess, oidc.ScopeOffline, oidc.ScopeAutheliaBearerAuthz}
 	validOIDCClientConsentModes              = []string{auto, oidc.ClientConsentModeImplicit.String(), oidc.ClientConsentModeExplicit.String(), oidc.ClientConsentModePreConfigured.String()}
 	validOIDCClientResponseModes             = []string{oidc.ResponseModeFormPost, oidc.ResponseModeQuery, oidc.ResponseModeFragment, oidc.ResponseModeJWT, oidc.ResponseModeFormPostJWT, oidc.ResponseModeQueryJWT, oidc.ResponseModeFragmentJWT}
 	validOIDCClientResponseTypes             = []string{oidc.ResponseTypeAuthorizationCodeFlow, oidc.ResponseTypeImplicitFlowIDToken, oidc.ResponseTypeImplicitFlowToken, oidc.ResponseTypeImplicitFlowBoth, oidc.ResponseTypeHybridFlowIDToken, oidc.ResponseTypeHybridFlowToken, oidc.ResponseTypeHybridFlowBoth}
 	validOIDCClientResponseTypesImplicitFlow = []string{oidc.ResponseTypeImplicitFlowIDToken, oidc.ResponseTypeImplicitFlowToken, oidc.ResponseTypeImplicitFlowBoth}
 	validOIDCClientResponseTypesHybridFlow   = []string{oidc.ResponseTypeHybridFlowIDToken, oidc.ResponseTypeHybridFlowToken, oidc.ResponseTypeHybridFlowBoth}
 	validOIDCClientResponseTypesRefreshToken = []string{oidc.ResponseTypeAuthorizationCodeFlow, oidc.ResponseTypeHybridFlowIDToken, oidc.ResponseTypeHybridFlowToken, oidc.ResponseTypeHybridFlowBoth}
-	validOIDCClientGrantTypes                = []string{oidc.GrantTypeAuthorizationCode, oidc.GrantTypeImplicit, oidc.GrantTypeClientCredentials, oidc.GrantTypeRefreshToken}
+	validOIDCClientGrantTypes                = []string{oidc.GrantTypeAuthorizationCode, oidc.GrantTypeImplicit, oidc.GrantTypeClientCredentials, oidc.GrantTypeRefreshToken, oidc.GrantTypeDeviceCode, oidc.GrantTypeTokenExchange}
 
 	validOIDCClientTokenEndpointAuthMethods                = []string{oidc.ClientAuthMethodNone, oidc.ClientAuthMethodClientSecretPost, oidc.ClientAuthMethodClientSecretBasic, oidc.ClientAuthMethodPrivateKeyJWT, oidc.ClientAuthMethodClientSecretJWT}
 	validOIDCClientTokenEndpointAuthMethodsConfidential    = []string{oidc.ClientAuthMethodClientSecretPost, oidc.ClientAuthMethodClientSecretBasic, oidc.ClientAuthMethodPrivateKeyJWT}
@@ -571,12 +723,17 @@ var (
 	validOIDCClientGrantTypesBearerAuthz    = []string{oidc.GrantTypeAuthorizationCode, oidc.GrantTypeRefreshToken, oidc.GrantTypeClientCredentials}
 )
 
+// reservedUserAttributeNames are the extended user attribute names which conflict with the standard user attributes.
+var reservedUserAttributeNames = []string{"user", "username", "name", "display_name", "email", "emails", "groups"}
+
 var (
 	reKeyReplacer       = regexp.MustCompile(`\[\d+]`)
 	reDomainCharacters  = regexp.MustCompile(`^[a-z0-9-]+(\.[a-z0-9-]+)+[a-z0-9]$`)
 	reAuthzEndpointName = regexp.MustCompile(`^[a-zA-Z](([a-zA-Z0-9/._-]*)([a-zA-Z]))?$`)
 	reOpenIDConnectKID  = regexp.MustCompile(`^([a-zA-Z0-9](([a-zA-Z0-9._~-]*)([a-zA-Z0-9]))?)?$`)
 	reRFC3986Unreserved = regexp.MustCompile(`^[a-zA-Z0-9._~-]+$`)
+	reUserAttributeName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	reAuthBackendName   = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
 )
 
 var replacedKeys = map[string]string{
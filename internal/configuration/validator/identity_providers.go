@@ -3,6 +3,7 @@ package validator
 import (
 	"crypto/ecdsa"
 	"crypto/rsa"
+	"errors"
 	"fmt"
 	"net/url"
 	"sort"
@@ -50,8 +51,9 @@ func validateOIDC(ctx *ValidateCtx, config *schema.IdentityProvidersOpenIDConnec
 	}
 
 	validateOIDCOptionsCORS(config, validator)
+	validateOIDCDynamicClientRegistration(config, validator)
 
-	if len(config.Clients) == 0 {
+	if len(config.Clients) == 0 && !config.DynamicClientRegistration.Enabled {
 		validator.Push(fmt.Errorf(errFmtOIDCProviderNoClientsConfigured))
 	} else {
 		validateOIDCClients(ctx, config, validator)
@@ -297,6 +299,14 @@ func setOIDCDefaults(config *schema.IdentityProvidersOpenIDConnect) {
 		config.Lifespans.RefreshToken = schema.DefaultOpenIDConnectConfiguration.Lifespans.RefreshToken
 	}
 
+	if config.Lifespans.DeviceCode == durationZero {
+		config.Lifespans.DeviceCode = schema.DefaultOpenIDConnectConfiguration.Lifespans.DeviceCode
+	}
+
+	if config.Lifespans.DeviceCodePollingInterval == durationZero {
+		config.Lifespans.DeviceCodePollingInterval = schema.DefaultOpenIDConnectConfiguration.Lifespans.DeviceCodePollingInterval
+	}
+
 	if config.EnforcePKCE == "" {
 		config.EnforcePKCE = schema.DefaultOpenIDConnectConfiguration.EnforcePKCE
 	}
@@ -361,6 +371,34 @@ func validateOIDCOptionsCORSEndpoints(config *schema.IdentityProvidersOpenIDConn
 	}
 }
 
+func validateOIDCDynamicClientRegistration(config *schema.IdentityProvidersOpenIDConnect, validator *schema.StructValidator) {
+	if !config.DynamicClientRegistration.Enabled {
+		return
+	}
+
+	if config.DynamicClientRegistration.InitialAccessToken == "" {
+		validator.Push(fmt.Errorf(errFmtOIDCDynamicClientRegistrationMissingOption, "initial_access_token"))
+	}
+
+	switch {
+	case config.DynamicClientRegistration.AuthorizationPolicy == "":
+		config.DynamicClientRegistration.AuthorizationPolicy = schema.DefaultOpenIDConnectClientConfiguration.AuthorizationPolicy
+	case utils.IsStringInSlice(config.DynamicClientRegistration.AuthorizationPolicy, config.Discovery.AuthorizationPolicies):
+		break
+	default:
+		validator.Push(fmt.Errorf(errFmtOIDCDynamicClientRegistrationInvalidValue, "authorization_policy", utils.StringJoinOr(config.Discovery.AuthorizationPolicies), config.DynamicClientRegistration.AuthorizationPolicy))
+	}
+
+	switch {
+	case config.DynamicClientRegistration.Lifespan == "", utils.IsStringInSlice(config.DynamicClientRegistration.Lifespan, config.Discovery.Lifespans):
+		break
+	case len(config.Discovery.Lifespans) == 0:
+		validator.Push(fmt.Errorf(errFmtOIDCDynamicClientRegistrationNoLifespans, config.DynamicClientRegistration.Lifespan))
+	default:
+		validator.Push(fmt.Errorf(errFmtOIDCDynamicClientRegistrationInvalidValue, "lifespan", utils.StringJoinOr(config.Discovery.Lifespans), config.DynamicClientRegistration.Lifespan))
+	}
+}
+
 func validateOIDCClients(ctx *ValidateCtx, config *schema.IdentityProvidersOpenIDConnect, validator *schema.StructValidator) {
 	var (
 		errDeprecated bool
@@ -469,6 +507,10 @@ func validateOIDCClient(ctx *ValidateCtx, c int, config *schema.IdentityProvider
 	validateOIDCClientGrantTypes(c, config, validator, setDefaults, errDeprecatedFunc)
 	validateOIDCClientRedirectURIs(c, config, validator, errDeprecatedFunc)
 	validateOIDCClientRequestURIs(c, config, validator)
+	validateOIDCClientPostLogoutRedirectURIs(c, config, validator)
+	validateOIDCClientLogoutURI(c, config, attrOIDCBackChannelLogoutURI, config.Clients[c].BackChannelLogoutURI, validator)
+	validateOIDCClientLogoutURI(c, config, attrOIDCFrontChannelLogoutURI, config.Clients[c].FrontChannelLogoutURI, validator)
+	validateOIDCClientTokenExchange(c, config, validator)
 
 	validateOIDDClientSigningAlgs(c, config, validator)
 
@@ -673,7 +715,8 @@ func validateOIDCClientScopes(c int, config *schema.IdentityProvidersOpenIDConne
 	}
 
 	if utils.IsStringSliceContainsAny([]string{oidc.ScopeOfflineAccess, oidc.ScopeOffline}, config.Clients[c].Scopes) &&
-		!utils.IsStringSliceContainsAny(validOIDCClientResponseTypesRefreshToken, config.Clients[c].ResponseTypes) {
+		!utils.IsStringSliceContainsAny(validOIDCClientResponseTypesRefreshToken, config.Clients[c].ResponseTypes) &&
+		!utils.IsStringInSlice(oidc.GrantTypeDeviceCode, config.Clients[c].GrantTypes) {
 		errDeprecatedFunc()
 
 		validator.PushWarning(fmt.Errorf(errFmtOIDCClientInvalidRefreshTokenOptionWithoutCodeResponseType,
@@ -886,9 +929,9 @@ func validateOIDCClientGrantTypesCheckRelated(c int, config *schema.IdentityProv
 
 				validator.PushWarning(fmt.Errorf(errFmtOIDCClientInvalidGrantTypeMatch, config.Clients[c].ID, grantType, "for either the implicit or hybrid flow", utils.StringJoinOr(append(append([]string{}, validOIDCClientResponseTypesImplicitFlow...), validOIDCClientResponseTypesHybridFlow...)), utils.StringJoinAnd(config.Clients[c].ResponseTypes)))
 			}
-		case oidc.GrantTypeClientCredentials:
+		case oidc.GrantTypeClientCredentials, oidc.GrantTypeTokenExchange:
 			if config.Clients[c].Public {
-				validator.Push(fmt.Errorf(errFmtOIDCClientInvalidGrantTypePublic, config.Clients[c].ID, oidc.GrantTypeClientCredentials))
+				validator.Push(fmt.Errorf(errFmtOIDCClientInvalidGrantTypePublic, config.Clients[c].ID, grantType))
 			}
 		case oidc.GrantTypeRefreshToken:
 			if !utils.IsStringSliceContainsAny([]string{oidc.ScopeOfflineAccess, oidc.ScopeOffline}, config.Clients[c].Scopes) {
@@ -897,7 +940,7 @@ func validateOIDCClientGrantTypesCheckRelated(c int, config *schema.IdentityProv
 				validator.PushWarning(fmt.Errorf(errFmtOIDCClientInvalidGrantTypeRefresh, config.Clients[c].ID))
 			}
 
-			if !utils.IsStringSliceContainsAny(validOIDCClientResponseTypesRefreshToken, config.Clients[c].ResponseTypes) {
+			if !utils.IsStringSliceContainsAny(validOIDCClientResponseTypesRefreshToken, config.Clients[c].ResponseTypes) && !utils.IsStringInSlice(oidc.GrantTypeDeviceCode, config.Clients[c].GrantTypes) {
 				errDeprecatedFunc()
 
 				validator.PushWarning(fmt.Errorf(errFmtOIDCClientInvalidRefreshTokenOptionWithoutCodeResponseType,
@@ -911,29 +954,18 @@ func validateOIDCClientGrantTypesCheckRelated(c int, config *schema.IdentityProv
 }
 
 func validateOIDCClientRedirectURIs(c int, config *schema.IdentityProvidersOpenIDConnect, validator *schema.StructValidator, errDeprecatedFunc func()) {
-	var (
-		parsedRedirectURI *url.URL
-		err               error
-	)
+	var err error
 
 	for _, redirectURI := range config.Clients[c].RedirectURIs {
-		if redirectURI == oidc.RedirectURISpecialOAuth2InstalledApp {
-			if config.Clients[c].Public {
-				continue
-			}
-
-			validator.Push(fmt.Errorf(errFmtOIDCClientRedirectURIPublic, config.Clients[c].ID, oidc.RedirectURISpecialOAuth2InstalledApp))
-
+		switch _, err = oidc.ValidateRedirectURI(redirectURI, config.Clients[c].Public); {
+		case err == nil:
 			continue
-		}
-
-		if parsedRedirectURI, err = url.Parse(redirectURI); err != nil {
-			validator.Push(fmt.Errorf(errFmtOIDCClientRedirectURICantBeParsed, config.Clients[c].ID, redirectURI, err))
-			continue
-		}
-
-		if !parsedRedirectURI.IsAbs() || (!config.Clients[c].Public && parsedRedirectURI.Scheme == "") {
+		case errors.Is(err, oidc.ErrRedirectURIPublicOnly):
+			validator.Push(fmt.Errorf(errFmtOIDCClientRedirectURIPublic, config.Clients[c].ID, redirectURI))
+		case errors.Is(err, oidc.ErrRedirectURINotAbsolute):
 			validator.Push(fmt.Errorf(errFmtOIDCClientRedirectURIAbsolute, config.Clients[c].ID, redirectURI))
+		default:
+			validator.Push(fmt.Errorf(errFmtOIDCClientRedirectURICantBeParsed, config.Clients[c].ID, redirectURI, err))
 		}
 	}
 
@@ -972,6 +1004,109 @@ func validateOIDCClientRequestURIs(c int, config *schema.IdentityProvidersOpenID
 	}
 }
 
+func validateOIDCClientPostLogoutRedirectURIs(c int, config *schema.IdentityProvidersOpenIDConnect, validator *schema.StructValidator) {
+	var (
+		parsedRedirectURI *url.URL
+		err               error
+	)
+
+	for _, redirectURI := range config.Clients[c].PostLogoutRedirectURIs {
+		if parsedRedirectURI, err = url.Parse(redirectURI); err != nil {
+			validator.Push(fmt.Errorf(errFmtOIDCClientPostLogoutRedirectURICantBeParsed, config.Clients[c].ID, redirectURI, err))
+			continue
+		}
+
+		if !parsedRedirectURI.IsAbs() {
+			validator.Push(fmt.Errorf(errFmtOIDCClientPostLogoutRedirectURINotAbsolute, config.Clients[c].ID, redirectURI))
+		}
+
+		if parsedRedirectURI.Fragment != "" {
+			validator.Push(fmt.Errorf(errFmtOIDCClientPostLogoutRedirectURIFragment, config.Clients[c].ID, redirectURI))
+		}
+	}
+
+	_, duplicates := validateList(config.Clients[c].PostLogoutRedirectURIs, nil, true)
+
+	if len(duplicates) != 0 {
+		validator.Push(fmt.Errorf(errFmtOIDCClientInvalidEntryDuplicates, config.Clients[c].ID, attrOIDCPostLogoutRedirectURIs, utils.StringJoinAnd(duplicates)))
+	}
+}
+
+func validateOIDCClientLogoutURI(c int, config *schema.IdentityProvidersOpenIDConnect, attr string, uri *url.URL, validator *schema.StructValidator) {
+	if uri == nil {
+		return
+	}
+
+	switch {
+	case !uri.IsAbs():
+		validator.Push(fmt.Errorf(errFmtOIDCClientLogoutURIAbsolute, config.Clients[c].ID, attr, uri.String()))
+	case uri.Scheme != schemeHTTP && uri.Scheme != schemeHTTPS:
+		validator.Push(fmt.Errorf(errFmtOIDCClientLogoutURIScheme, config.Clients[c].ID, attr, uri.String(), uri.Scheme))
+	}
+
+	if uri.Fragment != "" {
+		validator.Push(fmt.Errorf(errFmtOIDCClientLogoutURIFragment, config.Clients[c].ID, attr, uri.String()))
+	}
+}
+
+func validateOIDCClientTokenExchange(c int, config *schema.IdentityProvidersOpenIDConnect, validator *schema.StructValidator) {
+	if !utils.IsStringInSlice(oidc.GrantTypeTokenExchange, config.Clients[c].GrantTypes) {
+		if len(config.Clients[c].TokenExchange.SubjectTokenAudiences) != 0 || len(config.Clients[c].TokenExchange.TargetAudiences) != 0 || len(config.Clients[c].TokenExchange.ActorTokenClients) != 0 {
+			validator.Push(fmt.Errorf(errFmtOIDCClientTokenExchangeWithoutGrantType, config.Clients[c].ID, oidc.GrantTypeTokenExchange))
+		}
+
+		return
+	}
+
+	if len(config.Clients[c].TokenExchange.SubjectTokenAudiences) == 0 {
+		validator.Push(fmt.Errorf(errFmtOIDCClientTokenExchangeSubjectTokenAudiences, config.Clients[c].ID, oidc.GrantTypeTokenExchange))
+	}
+
+	validateOIDCClientTokenExchangeActorTokenClients(c, config, validator)
+
+	if len(config.Clients[c].TokenExchange.TargetAudiences) == 0 {
+		config.Clients[c].TokenExchange.TargetAudiences = config.Clients[c].Audience
+
+		return
+	}
+
+	var invalid []string
+
+	for _, audience := range config.Clients[c].TokenExchange.TargetAudiences {
+		if !utils.IsStringInSlice(audience, config.Clients[c].Audience) {
+			invalid = append(invalid, audience)
+		}
+	}
+
+	if len(invalid) != 0 {
+		validator.Push(fmt.Errorf(errFmtOIDCClientTokenExchangeTargetAudiences, config.Clients[c].ID, utils.StringJoinAnd(invalid)))
+	}
+}
+
+func validateOIDCClientTokenExchangeActorTokenClients(c int, config *schema.IdentityProvidersOpenIDConnect, validator *schema.StructValidator) {
+	var invalid []string
+
+	for _, id := range config.Clients[c].TokenExchange.ActorTokenClients {
+		found := false
+
+		for _, client := range config.Clients {
+			if client.ID == id {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			invalid = append(invalid, id)
+		}
+	}
+
+	if len(invalid) != 0 {
+		validator.Push(fmt.Errorf(errFmtOIDCClientTokenExchangeActorTokenClients, config.Clients[c].ID, utils.StringJoinAnd(invalid)))
+	}
+}
+
 //nolint:gocyclo
 func validateOIDCClientTokenEndpointAuth(c int, config *schema.IdentityProvidersOpenIDConnect, validator *schema.StructValidator) {
 	implicit := len(config.Clients[c].ResponseTypes) != 0 && utils.IsStringSliceContainsAll(config.Clients[c].ResponseTypes, validOIDCClientResponseTypesImplicitFlow)
@@ -110,7 +110,7 @@ func TestShouldRaiseErrorWhenCORSEndpointsNotValid(t *testing.T) {
 
 	require.Len(t, validator.Errors(), 1)
 
-	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: cors: option 'endpoints' contains an invalid value 'invalid_endpoint': must be one of 'authorization', 'pushed-authorization-request', 'token', 'introspection', 'revocation', or 'userinfo'")
+	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: cors: option 'endpoints' contains an invalid value 'invalid_endpoint': must be one of 'authorization', 'pushed-authorization-request', 'token', 'introspection', 'revocation', 'userinfo', 'end-session', or 'device-authorization'")
 }
 
 func TestShouldRaiseErrorWhenOIDCPKCEEnforceValueInvalid(t *testing.T) {
@@ -183,6 +183,25 @@ func TestShouldRaiseErrorWhenOIDCServerNoClients(t *testing.T) {
 	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: option 'clients' must have one or more clients configured")
 }
 
+func TestShouldNotRaiseErrorWhenOIDCServerNoClientsWithDynamicClientRegistration(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.IdentityProviders{
+		OIDC: &schema.IdentityProvidersOpenIDConnect{
+			HMACSecret:       "rLABDrx87et5KvRHVUgTm3pezWWd8LMN",
+			IssuerPrivateKey: keyRSA2048,
+			DynamicClientRegistration: schema.IdentityProvidersOpenIDConnectDynamicClientRegistration{
+				Enabled:            true,
+				InitialAccessToken: "tQVkxeCxArHvXaVTr6cHtmJ5HkCbhHzh",
+			},
+		},
+	}
+
+	ValidateIdentityProviders(NewValidateCtx(), config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+	assert.Equal(t, "two_factor", config.OIDC.DynamicClientRegistration.AuthorizationPolicy)
+}
+
 func TestShouldRaiseErrorWhenOIDCServerClientBadValues(t *testing.T) {
 	mux := http.NewServeMux()
 
@@ -392,6 +411,67 @@ func TestShouldRaiseErrorWhenOIDCServerClientBadValues(t *testing.T) {
 				"identity_providers: oidc: clients: client 'client-check-uri-parse': option 'request_uris' has an invalid scheme: scheme must be 'https' but request uri 'http://example.com' has a 'http' scheme",
 			},
 		},
+		{
+			name: "PostLogoutRedirectURINotAbsolute",
+			clients: []schema.IdentityProvidersOpenIDConnectClient{
+				{
+					ID:                  "client-check-uri-parse",
+					Secret:              tOpenIDConnectPlainTextClientSecret,
+					AuthorizationPolicy: policyTwoFactor,
+					PostLogoutRedirectURIs: []string{
+						exampleDotCom,
+					},
+				},
+			},
+			errors: []string{
+				"identity_providers: oidc: clients: client 'client-check-uri-parse': option 'post_logout_redirect_uris' has an invalid value: post logout redirect uri 'example.com' must have a scheme but it's absent",
+			},
+		},
+		{
+			name: "PostLogoutRedirectURIFragment",
+			clients: []schema.IdentityProvidersOpenIDConnectClient{
+				{
+					ID:                  "client-check-uri-parse",
+					Secret:              tOpenIDConnectPlainTextClientSecret,
+					AuthorizationPolicy: policyTwoFactor,
+					PostLogoutRedirectURIs: []string{
+						"https://example.com/logout#done",
+					},
+				},
+			},
+			errors: []string{
+				"identity_providers: oidc: clients: client 'client-check-uri-parse': option 'post_logout_redirect_uris' has an invalid value: post logout redirect uri 'https://example.com/logout#done' must not have a fragment",
+			},
+		},
+		{
+			name: "BackChannelLogoutURINotAbsolute",
+			clients: []schema.IdentityProvidersOpenIDConnectClient{
+				{
+					ID:                   "client-check-uri-parse",
+					Secret:               tOpenIDConnectPlainTextClientSecret,
+					AuthorizationPolicy:  policyTwoFactor,
+					BackChannelLogoutURI: mustParseURL("example.com/logout#done"),
+				},
+			},
+			errors: []string{
+				"identity_providers: oidc: clients: client 'client-check-uri-parse': option 'backchannel_logout_uri' with value 'example.com/logout#done': must be an absolute URI",
+				"identity_providers: oidc: clients: client 'client-check-uri-parse': option 'backchannel_logout_uri' with value 'example.com/logout#done': must not have a fragment",
+			},
+		},
+		{
+			name: "FrontChannelLogoutURIScheme",
+			clients: []schema.IdentityProvidersOpenIDConnectClient{
+				{
+					ID:                    "client-check-uri-parse",
+					Secret:                tOpenIDConnectPlainTextClientSecret,
+					AuthorizationPolicy:   policyTwoFactor,
+					FrontChannelLogoutURI: mustParseURL("ftp://example.com/logout"),
+				},
+			},
+			errors: []string{
+				"identity_providers: oidc: clients: client 'client-check-uri-parse': option 'frontchannel_logout_uri' with value 'ftp://example.com/logout': must have the 'http' or 'https' scheme but has the 'ftp' scheme",
+			},
+		},
 		{
 			name: "ValidSectorIdentifier",
 			clients: []schema.IdentityProvidersOpenIDConnectClient{
@@ -658,7 +738,7 @@ func TestShouldRaiseErrorWhenOIDCClientConfiguredWithBadGrantTypes(t *testing.T)
 	ValidateIdentityProviders(NewValidateCtx(), config, validator)
 
 	require.Len(t, validator.Errors(), 1)
-	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: clients: client 'good_id': option 'grant_types' must only have the values 'authorization_code', 'implicit', 'client_credentials', or 'refresh_token' but the values 'bad_grant_type' are present")
+	assert.EqualError(t, validator.Errors()[0], "identity_providers: oidc: clients: client 'good_id': option 'grant_types' must only have the values 'authorization_code', 'implicit', 'client_credentials', 'refresh_token', 'urn:ietf:params:oauth:grant-type:device_code', or 'urn:ietf:params:oauth:grant-type:token-exchange' but the values 'bad_grant_type' are present")
 }
 
 func TestShouldNotErrorOnCertificateValid(t *testing.T) {
@@ -1203,9 +1283,172 @@ func TestValidateOIDCClients(t *testing.T) {
 				[]string{oidc.GrantTypeAuthorizationCode},
 			},
 			[]string{
-				"identity_providers: oidc: clients: client 'test': option 'scopes' only expects the values 'openid', 'email', 'profile', 'groups', 'offline_access', 'offline', or 'authelia.bearer.authz' but the unknown values 'group' are present and should generally only be used if a particular client requires a scope outside of our standard scopes",
+				"identity_providers: oidc: clients: client 'test': option 'scopes' only expects the values 'openid', 'email', 'profile', 'groups', 'phone', 'address', 'offline_access', 'offline', or 'authelia.bearer.authz' but the unknown values 'group' are present and should generally only be used if a particular client requires a scope outside of our standard scopes",
+			},
+			nil,
+		},
+		{
+			"ShouldAllowDeviceCodeGrantWithRefreshToken",
+			nil,
+			nil,
+			tcv{
+				[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeOfflineAccess},
+				nil,
+				nil,
+				[]string{oidc.GrantTypeDeviceCode, oidc.GrantTypeRefreshToken},
+			},
+			tcv{
+				[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeOfflineAccess},
+				[]string{oidc.ResponseTypeAuthorizationCodeFlow},
+				[]string{oidc.ResponseModeFormPost, oidc.ResponseModeQuery},
+				[]string{oidc.GrantTypeDeviceCode, oidc.GrantTypeRefreshToken},
+			},
+			nil,
+			nil,
+		},
+		{
+			"ShouldAllowTokenExchangeGrantAndSetDefaultTargetAudiences",
+			func(have *schema.IdentityProvidersOpenIDConnect) {
+				have.Clients[0].Audience = []string{"https://orders.example.com", "https://billing.example.com"}
+				have.Clients[0].TokenExchange.SubjectTokenAudiences = []string{"frontend"}
+			},
+			func(t *testing.T, have *schema.IdentityProvidersOpenIDConnect) {
+				assert.Equal(t, []string{"https://orders.example.com", "https://billing.example.com"}, have.Clients[0].TokenExchange.TargetAudiences)
+			},
+			tcv{
+				nil,
+				nil,
+				nil,
+				[]string{oidc.GrantTypeTokenExchange},
+			},
+			tcv{
+				[]string{oidc.ScopeOpenID, oidc.ScopeGroups, oidc.ScopeProfile, oidc.ScopeEmail},
+				[]string{oidc.ResponseTypeAuthorizationCodeFlow},
+				[]string{oidc.ResponseModeFormPost, oidc.ResponseModeQuery},
+				[]string{oidc.GrantTypeTokenExchange},
+			},
+			nil,
+			nil,
+		},
+		{
+			"ShouldRaiseErrorOnTokenExchangeGrantWithoutSubjectTokenAudiences",
+			func(have *schema.IdentityProvidersOpenIDConnect) {
+				have.Clients[0].Audience = []string{"https://orders.example.com"}
+			},
+			nil,
+			tcv{
+				nil,
+				nil,
+				nil,
+				[]string{oidc.GrantTypeTokenExchange},
+			},
+			tcv{
+				[]string{oidc.ScopeOpenID, oidc.ScopeGroups, oidc.ScopeProfile, oidc.ScopeEmail},
+				[]string{oidc.ResponseTypeAuthorizationCodeFlow},
+				[]string{oidc.ResponseModeFormPost, oidc.ResponseModeQuery},
+				[]string{oidc.GrantTypeTokenExchange},
+			},
+			nil,
+			[]string{
+				"identity_providers: oidc: clients: client 'test': option 'token_exchange' option 'subject_token_audiences' is required when option 'grant_types' includes 'urn:ietf:params:oauth:grant-type:token-exchange'",
+			},
+		},
+		{
+			"ShouldRaiseErrorOnTokenExchangeGrantWithInvalidTargetAudiences",
+			func(have *schema.IdentityProvidersOpenIDConnect) {
+				have.Clients[0].Audience = []string{"https://orders.example.com"}
+				have.Clients[0].TokenExchange.SubjectTokenAudiences = []string{"frontend"}
+				have.Clients[0].TokenExchange.TargetAudiences = []string{"https://orders.example.com", "https://billing.example.com", "https://other.example.com"}
+			},
+			nil,
+			tcv{
+				nil,
+				nil,
+				nil,
+				[]string{oidc.GrantTypeTokenExchange},
+			},
+			tcv{
+				[]string{oidc.ScopeOpenID, oidc.ScopeGroups, oidc.ScopeProfile, oidc.ScopeEmail},
+				[]string{oidc.ResponseTypeAuthorizationCodeFlow},
+				[]string{oidc.ResponseModeFormPost, oidc.ResponseModeQuery},
+				[]string{oidc.GrantTypeTokenExchange},
+			},
+			nil,
+			[]string{
+				"identity_providers: oidc: clients: client 'test': option 'token_exchange' option 'target_audiences' must only have values from option 'audience' but it has the values 'https://billing.example.com' and 'https://other.example.com' which are not",
+			},
+		},
+		{
+			"ShouldRaiseErrorOnTokenExchangeGrantWithUnknownActorTokenClients",
+			func(have *schema.IdentityProvidersOpenIDConnect) {
+				have.Clients[0].TokenExchange.SubjectTokenAudiences = []string{"frontend"}
+				have.Clients[0].TokenExchange.ActorTokenClients = []string{"test", "gateway"}
+			},
+			nil,
+			tcv{
+				nil,
+				nil,
+				nil,
+				[]string{oidc.GrantTypeTokenExchange},
+			},
+			tcv{
+				[]string{oidc.ScopeOpenID, oidc.ScopeGroups, oidc.ScopeProfile, oidc.ScopeEmail},
+				[]string{oidc.ResponseTypeAuthorizationCodeFlow},
+				[]string{oidc.ResponseModeFormPost, oidc.ResponseModeQuery},
+				[]string{oidc.GrantTypeTokenExchange},
+			},
+			nil,
+			[]string{
+				"identity_providers: oidc: clients: client 'test': option 'token_exchange' option 'actor_token_clients' must only have values which are the id of a registered client but it has the values 'gateway' which are not",
+			},
+		},
+		{
+			"ShouldRaiseErrorOnTokenExchangeOptionsWithoutGrantType",
+			func(have *schema.IdentityProvidersOpenIDConnect) {
+				have.Clients[0].TokenExchange.SubjectTokenAudiences = []string{"frontend"}
+			},
+			nil,
+			tcv{
+				nil,
+				nil,
+				nil,
+				[]string{oidc.GrantTypeAuthorizationCode},
+			},
+			tcv{
+				[]string{oidc.ScopeOpenID, oidc.ScopeGroups, oidc.ScopeProfile, oidc.ScopeEmail},
+				[]string{oidc.ResponseTypeAuthorizationCodeFlow},
+				[]string{oidc.ResponseModeFormPost, oidc.ResponseModeQuery},
+				[]string{oidc.GrantTypeAuthorizationCode},
+			},
+			nil,
+			[]string{
+				"identity_providers: oidc: clients: client 'test': option 'token_exchange' must only be configured when option 'grant_types' includes 'urn:ietf:params:oauth:grant-type:token-exchange'",
+			},
+		},
+		{
+			"ShouldRaiseErrorOnTokenExchangeGrantForPublicClient",
+			func(have *schema.IdentityProvidersOpenIDConnect) {
+				have.Clients[0].Public = true
+				have.Clients[0].Secret = nil
+				have.Clients[0].TokenExchange.SubjectTokenAudiences = []string{"frontend"}
 			},
 			nil,
+			tcv{
+				nil,
+				nil,
+				nil,
+				[]string{oidc.GrantTypeTokenExchange},
+			},
+			tcv{
+				[]string{oidc.ScopeOpenID, oidc.ScopeGroups, oidc.ScopeProfile, oidc.ScopeEmail},
+				[]string{oidc.ResponseTypeAuthorizationCodeFlow},
+				[]string{oidc.ResponseModeFormPost, oidc.ResponseModeQuery},
+				[]string{oidc.GrantTypeTokenExchange},
+			},
+			nil,
+			[]string{
+				"identity_providers: oidc: clients: client 'test': option 'grant_types' should only have the 'urn:ietf:params:oauth:grant-type:token-exchange' value if it is of the confidential client type but it's of the public client type",
+			},
 		},
 		{
 			"ShouldRaiseErrorOnMissingAuthorizationCodeFlowResponseTypeWithRefreshTokenValues",
@@ -1352,7 +1595,7 @@ func TestValidateOIDCClients(t *testing.T) {
 			},
 			nil,
 			[]string{
-				"identity_providers: oidc: clients: client 'test': option 'grant_types' must only have the values 'authorization_code', 'implicit', 'client_credentials', or 'refresh_token' but the values 'invalid' are present",
+				"identity_providers: oidc: clients: client 'test': option 'grant_types' must only have the values 'authorization_code', 'implicit', 'client_credentials', 'refresh_token', 'urn:ietf:params:oauth:grant-type:device_code', or 'urn:ietf:params:oauth:grant-type:token-exchange' but the values 'invalid' are present",
 			},
 		},
 		{
@@ -3997,6 +4240,128 @@ func TestValidateOIDCAuthorizationPolicies(t *testing.T) {
 	}
 }
 
+func TestValidateOIDCDynamicClientRegistration(t *testing.T) {
+	testCases := []struct {
+		name    string
+		have    schema.IdentityProvidersOpenIDConnectDynamicClientRegistration
+		custom  map[string]schema.IdentityProvidersOpenIDConnectLifespan
+		expectf func(t *testing.T, actual schema.IdentityProvidersOpenIDConnectDynamicClientRegistration)
+		errors  []string
+	}{
+		{
+			"ShouldIgnoreDisabled",
+			schema.IdentityProvidersOpenIDConnectDynamicClientRegistration{
+				AuthorizationPolicy: "example",
+			},
+			nil,
+			func(t *testing.T, actual schema.IdentityProvidersOpenIDConnectDynamicClientRegistration) {
+				assert.Equal(t, "example", actual.AuthorizationPolicy)
+			},
+			nil,
+		},
+		{
+			"ShouldSetDefaults",
+			schema.IdentityProvidersOpenIDConnectDynamicClientRegistration{
+				Enabled:            true,
+				InitialAccessToken: "tQVkxeCxArHvXaVTr6cHtmJ5HkCbhHzh",
+			},
+			nil,
+			func(t *testing.T, actual schema.IdentityProvidersOpenIDConnectDynamicClientRegistration) {
+				assert.Equal(t, "two_factor", actual.AuthorizationPolicy)
+				assert.Equal(t, "", actual.Lifespan)
+			},
+			nil,
+		},
+		{
+			"ShouldAllowValidValues",
+			schema.IdentityProvidersOpenIDConnectDynamicClientRegistration{
+				Enabled:             true,
+				InitialAccessToken:  "tQVkxeCxArHvXaVTr6cHtmJ5HkCbhHzh",
+				AuthorizationPolicy: "one_factor",
+				Lifespan:            "custom",
+			},
+			map[string]schema.IdentityProvidersOpenIDConnectLifespan{
+				"custom": {},
+			},
+			func(t *testing.T, actual schema.IdentityProvidersOpenIDConnectDynamicClientRegistration) {
+				assert.Equal(t, "one_factor", actual.AuthorizationPolicy)
+				assert.Equal(t, "custom", actual.Lifespan)
+			},
+			nil,
+		},
+		{
+			"ShouldErrorOnMissingInitialAccessToken",
+			schema.IdentityProvidersOpenIDConnectDynamicClientRegistration{
+				Enabled: true,
+			},
+			nil,
+			nil,
+			[]string{
+				"identity_providers: oidc: dynamic_client_registration: option 'initial_access_token' is required when dynamic client registration is enabled",
+			},
+		},
+		{
+			"ShouldErrorOnInvalidValues",
+			schema.IdentityProvidersOpenIDConnectDynamicClientRegistration{
+				Enabled:             true,
+				InitialAccessToken:  "tQVkxeCxArHvXaVTr6cHtmJ5HkCbhHzh",
+				AuthorizationPolicy: "example",
+				Lifespan:            "example",
+			},
+			map[string]schema.IdentityProvidersOpenIDConnectLifespan{
+				"custom": {},
+			},
+			nil,
+			[]string{
+				"identity_providers: oidc: dynamic_client_registration: option 'authorization_policy' must be one of 'one_factor' or 'two_factor' but it's configured as 'example'",
+				"identity_providers: oidc: dynamic_client_registration: option 'lifespan' must be one of 'custom' but it's configured as 'example'",
+			},
+		},
+		{
+			"ShouldErrorOnLifespanWithoutCustomLifespans",
+			schema.IdentityProvidersOpenIDConnectDynamicClientRegistration{
+				Enabled:            true,
+				InitialAccessToken: "tQVkxeCxArHvXaVTr6cHtmJ5HkCbhHzh",
+				Lifespan:           "example",
+			},
+			nil,
+			nil,
+			[]string{
+				"identity_providers: oidc: dynamic_client_registration: option 'lifespan' must not be configured when no custom lifespans are configured but it's configured as 'example'",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+
+			config := &schema.IdentityProvidersOpenIDConnect{
+				DynamicClientRegistration: tc.have,
+				Lifespans: schema.IdentityProvidersOpenIDConnectLifespans{
+					Custom: tc.custom,
+				},
+			}
+
+			validateOIDCAuthorizationPolicies(config, validator)
+			validateOIDCLifespans(config, validator)
+			validateOIDCDynamicClientRegistration(config, validator)
+
+			require.Len(t, validator.Errors(), len(tc.errors))
+
+			for i, err := range tc.errors {
+				t.Run(fmt.Sprintf("Error%d", i+1), func(t *testing.T) {
+					assert.EqualError(t, validator.Errors()[i], err)
+				})
+			}
+
+			if tc.expectf != nil {
+				tc.expectf(t, config.DynamicClientRegistration)
+			}
+		})
+	}
+}
+
 func MustDecodeSecret(value string) *schema.PasswordDigest {
 	if secret, err := schema.DecodePasswordDigest(value); err != nil {
 		panic(err)
@@ -34,6 +34,13 @@ func ValidateServerTLS(config *schema.Configuration, validator *schema.StructVal
 	for _, clientCertPath := range config.Server.TLS.ClientCertificates {
 		validateServerTLSFileExists("client_certificates", clientCertPath, validator)
 	}
+
+	switch {
+	case config.Server.TLS.ClientAuthentication == "":
+		config.Server.TLS.ClientAuthentication = schema.ServerTLSClientAuthenticationRequire
+	case !utils.IsStringInSlice(config.Server.TLS.ClientAuthentication, validServerTLSClientAuthentication):
+		validator.Push(fmt.Errorf(errFmtServerTLSClientAuth, utils.StringJoinOr(validServerTLSClientAuthentication), config.Server.TLS.ClientAuthentication))
+	}
 }
 
 // validateServerTLSFileExists checks whether a file exist.
@@ -154,6 +161,15 @@ func ValidateServerEndpoints(config *schema.Configuration, validator *schema.Str
 		}
 
 		validateServerEndpointsAuthzStrategies(name, endpoint.Implementation, endpoint.AuthnStrategies, validator)
+
+		for i, strategy := range endpoint.AuthnStrategies {
+			switch {
+			case strategy.Name == schema.AuthzStrategyHeaderSPNEGO && config.AuthenticationBackend.Kerberos == nil:
+				validator.Push(fmt.Errorf(errFmtServerEndpointsAuthzStrategySPNEGO, name, i+1, strategy.Name))
+			case strategy.Name == schema.AuthzStrategyClientCertificate && config.AuthenticationBackend.ClientCertificate == nil:
+				validator.Push(fmt.Errorf(errFmtServerEndpointsAuthzStrategyClientCertificate, name, i+1, strategy.Name))
+			}
+		}
 	}
 }
 
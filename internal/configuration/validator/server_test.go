@@ -317,6 +317,24 @@ func TestShouldRaiseErrorWhenTLSClientAuthIsDefinedButNotServerCertificate(t *te
 	assert.EqualError(t, validator.Errors()[0], "server: tls: client authentication cannot be configured if no server certificate and key are provided")
 }
 
+func TestShouldValidateTLSClientAuthentication(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultConfig()
+
+	ValidateServer(&config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+	assert.Equal(t, schema.ServerTLSClientAuthenticationRequire, config.Server.TLS.ClientAuthentication)
+
+	validator = schema.NewStructValidator()
+	config.Server.TLS.ClientAuthentication = "sometimes"
+
+	ValidateServer(&config, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "server: tls: option 'client_authentication' must be one of 'require' or 'optional' but it's configured as 'sometimes'")
+}
+
 func TestShouldNotUpdateConfig(t *testing.T) {
 	validator := schema.NewStructValidator()
 	config := newDefaultConfig()
@@ -387,7 +405,7 @@ func TestServerAuthzEndpointErrors(t *testing.T) {
 				"example": {Implementation: "ExtAuthz", AuthnStrategies: []schema.ServerEndpointsAuthzAuthnStrategy{{Name: "bad-name"}}},
 			},
 			[]string{
-				"server: endpoints: authz: example: authn_strategies: option 'name' must be one of 'CookieSession', 'HeaderAuthorization', 'HeaderProxyAuthorization', 'HeaderAuthRequestProxyAuthorization', or 'HeaderLegacy' but it's configured as 'bad-name'",
+				"server: endpoints: authz: example: authn_strategies: option 'name' must be one of 'CookieSession', 'HeaderAuthorization', 'HeaderProxyAuthorization', 'HeaderAuthRequestProxyAuthorization', 'HeaderLegacy', 'SPNEGO', or 'ClientCertificate' but it's configured as 'bad-name'",
 			},
 		},
 		{
@@ -404,6 +422,30 @@ func TestServerAuthzEndpointErrors(t *testing.T) {
 			},
 			[]string{"server: endpoints: authz: example: authn_strategies: strategy #1 (CookieSession): option 'schemes' is not valid for the strategy"},
 		},
+		{
+			"ShouldErrorOnSPNEGOStrategyWithoutKerberos",
+			map[string]schema.ServerEndpointsAuthz{
+				"example": {Implementation: "ForwardAuth", AuthnStrategies: []schema.ServerEndpointsAuthzAuthnStrategy{{Name: "CookieSession"}, {Name: "SPNEGO"}}},
+			},
+			[]string{"server: endpoints: authz: example: authn_strategies: strategy #2 (SPNEGO): the 'authentication_backend' section must have the 'kerberos' section configured to use this strategy"},
+		},
+		{
+			"ShouldErrorOnSchemesForSPNEGOStrategy",
+			map[string]schema.ServerEndpointsAuthz{
+				"example": {Implementation: "ForwardAuth", AuthnStrategies: []schema.ServerEndpointsAuthzAuthnStrategy{{Name: "SPNEGO", Schemes: []string{"basic"}}}},
+			},
+			[]string{
+				"server: endpoints: authz: example: authn_strategies: strategy #1 (SPNEGO): option 'schemes' is not valid for the strategy",
+				"server: endpoints: authz: example: authn_strategies: strategy #1 (SPNEGO): the 'authentication_backend' section must have the 'kerberos' section configured to use this strategy",
+			},
+		},
+		{
+			"ShouldErrorOnClientCertificateStrategyWithoutClientCertificate",
+			map[string]schema.ServerEndpointsAuthz{
+				"example": {Implementation: "ForwardAuth", AuthnStrategies: []schema.ServerEndpointsAuthzAuthnStrategy{{Name: "ClientCertificate"}, {Name: "CookieSession"}}},
+			},
+			[]string{"server: endpoints: authz: example: authn_strategies: strategy #1 (ClientCertificate): the 'authentication_backend' section must have the 'client_certificate' section configured to use this strategy"},
+		},
 		{
 			"ShouldNotErrorOnSchemeCase",
 			map[string]schema.ServerEndpointsAuthz{
@@ -0,0 +1,177 @@
+package geoip
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/oschwald/maxminddb-golang"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+)
+
+// NewProvider instantiates a GeoIP provider given a configuration.
+func NewProvider(config *schema.AccessControlGeoIP) *Provider {
+	provider := &Provider{
+		config: config,
+		log:    logging.Logger(),
+	}
+
+	if config.CountryDatabase != "" {
+		provider.country = NewDatabase(config.CountryDatabase)
+	}
+
+	if config.ASNDatabase != "" {
+		provider.asn = NewDatabase(config.ASNDatabase)
+	}
+
+	return provider
+}
+
+// StartupCheck implements the startup check provider interface.
+func (p *Provider) StartupCheck() (err error) {
+	for _, database := range []*Database{p.country, p.asn} {
+		if database == nil {
+			continue
+		}
+
+		if _, err = database.Reload(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CountryDatabase returns the country *Database or nil if it's not configured.
+func (p *Provider) CountryDatabase() *Database {
+	return p.country
+}
+
+// ASNDatabase returns the autonomous system *Database or nil if it's not configured.
+func (p *Provider) ASNDatabase() *Database {
+	return p.asn
+}
+
+// Lookup resolves the Record for an IP. Lookup errors are logged and result in the relevant fields being empty.
+func (p *Provider) Lookup(ip net.IP) (record Record) {
+	if p == nil || ip == nil {
+		return record
+	}
+
+	for _, database := range []*Database{p.country, p.asn} {
+		if database == nil {
+			continue
+		}
+
+		values, found, err := database.Lookup(ip)
+
+		switch {
+		case err != nil:
+			p.log.WithError(err).WithField("ip", ip.String()).WithField("database", database.path).Error("Error occurred performing GeoIP lookup")
+		case found:
+			record.merge(values)
+		}
+	}
+
+	return record
+}
+
+// NewDatabase returns a new *Database for a MaxMind DB file path. The file is not read until it's reloaded.
+func NewDatabase(path string) *Database {
+	return &Database{path: path}
+}
+
+// Reload implements the ProviderReload interface, reading the database file if it has changed since it was last read.
+// The file is read into memory rather than memory mapped so that it can be replaced in place without affecting lookups
+// performed against the previous version.
+func (d *Database) Reload() (reloaded bool, err error) {
+	var info os.FileInfo
+
+	if info, err = os.Stat(d.path); err != nil {
+		return false, fmt.Errorf("error occurred reading the GeoIP database '%s': %w", d.path, err)
+	}
+
+	d.mu.RLock()
+	unchanged := d.reader != nil && info.ModTime().Equal(d.modified) && info.Size() == d.size
+	d.mu.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	var (
+		data   []byte
+		reader *maxminddb.Reader
+	)
+
+	if data, err = os.ReadFile(d.path); err != nil {
+		return false, fmt.Errorf("error occurred reading the GeoIP database '%s': %w", d.path, err)
+	}
+
+	if reader, err = maxminddb.FromBytes(data); err != nil {
+		return false, fmt.Errorf("error occurred reading the GeoIP database '%s': %w", d.path, err)
+	}
+
+	if reader.Metadata.BinaryFormatMajorVersion != 2 {
+		return false, fmt.Errorf("error occurred reading the GeoIP database '%s': unsupported binary format major version %d", d.path, reader.Metadata.BinaryFormatMajorVersion)
+	}
+
+	d.mu.Lock()
+
+	d.reader, d.modified, d.size = reader, info.ModTime(), info.Size()
+
+	d.mu.Unlock()
+
+	return true, nil
+}
+
+// Path returns the path of the database file.
+func (d *Database) Path() string {
+	return d.path
+}
+
+// Lookup performs a lookup of an IP in the database, decoding only the fields used by the access control rules.
+func (d *Database) Lookup(ip net.IP) (record DatabaseRecord, found bool, err error) {
+	d.mu.RLock()
+	reader := d.reader
+	d.mu.RUnlock()
+
+	if reader == nil {
+		return record, false, fmt.Errorf("the database is not loaded")
+	}
+
+	if ip.To4() == nil && reader.Metadata.IPVersion == 4 {
+		return record, false, nil
+	}
+
+	if _, found, err = reader.LookupNetwork(ip, &record); err != nil {
+		return DatabaseRecord{}, false, fmt.Errorf("error occurred performing lookup: %w", err)
+	}
+
+	return record, found, nil
+}
+
+func (r *Record) merge(values DatabaseRecord) {
+	if r.Country == "" {
+		if r.Country = values.Country.ISOCode; r.Country == "" {
+			r.Country = values.RegisteredCountry.ISOCode
+		}
+	}
+
+	if r.Continent == "" {
+		r.Continent = values.Continent.Code
+	}
+
+	if r.ASN == 0 {
+		r.ASN = values.AutonomousSystemNumber
+	}
+
+	if r.Organization == "" {
+		r.Organization = values.AutonomousSystemOrganization
+	}
+
+	r.Country, r.Continent = strings.ToUpper(r.Country), strings.ToUpper(r.Continent)
+}
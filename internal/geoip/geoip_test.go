@@ -0,0 +1,473 @@
+package geoip
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestProviderLookup(t *testing.T) {
+	dir := t.TempDir()
+
+	config := &schema.AccessControlGeoIP{
+		CountryDatabase: filepath.Join(dir, "country.mmdb"),
+		ASNDatabase:     filepath.Join(dir, "asn.mmdb"),
+	}
+
+	require.NoError(t, os.WriteFile(config.CountryDatabase, newTestDatabase(t, 6, 24, map[string]map[string]any{
+		"1.1.1.0/24": {
+			keyCountry:   map[string]any{keyISOCode: "AU"},
+			keyContinent: map[string]any{keyCode: "OC"},
+		},
+		"8.8.8.0/24": {
+			keyRegisteredCountry: map[string]any{keyISOCode: "us"},
+			keyContinent:         map[string]any{keyCode: "na"},
+		},
+	}), 0600))
+
+	require.NoError(t, os.WriteFile(config.ASNDatabase, newTestDatabase(t, 6, 28, map[string]map[string]any{
+		"1.1.1.0/24": {
+			keyAutonomousSystemNumber:       uint64(13335),
+			keyAutonomousSystemOrganization: "CLOUDFLARENET",
+		},
+	}), 0600))
+
+	provider := NewProvider(config)
+
+	require.NoError(t, provider.StartupCheck())
+
+	assert.Equal(t, config.CountryDatabase, provider.CountryDatabase().Path())
+	assert.Equal(t, config.ASNDatabase, provider.ASNDatabase().Path())
+
+	assert.Equal(t, Record{Country: "AU", Continent: "OC", ASN: 13335, Organization: "CLOUDFLARENET"}, provider.Lookup(net.ParseIP("1.1.1.1")))
+	assert.Equal(t, Record{Country: "US", Continent: "NA"}, provider.Lookup(net.ParseIP("8.8.8.8")))
+
+	record := provider.Lookup(net.ParseIP("9.9.9.9"))
+
+	assert.Equal(t, Record{}, record)
+	assert.True(t, record.IsEmpty())
+
+	assert.True(t, provider.Lookup(nil).IsEmpty())
+
+	reloaded, err := provider.CountryDatabase().Reload()
+
+	assert.NoError(t, err)
+	assert.False(t, reloaded)
+
+	require.NoError(t, os.WriteFile(config.CountryDatabase, newTestDatabase(t, 4, 32, map[string]map[string]any{
+		"9.9.9.0/24": {
+			keyCountry: map[string]any{keyISOCode: "CH"},
+		},
+	}), 0600))
+
+	reloaded, err = provider.CountryDatabase().Reload()
+
+	assert.NoError(t, err)
+	assert.True(t, reloaded)
+
+	assert.Equal(t, Record{Country: "CH"}, provider.Lookup(net.ParseIP("9.9.9.9")))
+	assert.Equal(t, Record{ASN: 13335, Organization: "CLOUDFLARENET"}, provider.Lookup(net.ParseIP("1.1.1.1")))
+}
+
+func TestProviderShouldHandleNil(t *testing.T) {
+	var provider *Provider
+
+	assert.True(t, provider.Lookup(net.ParseIP("1.1.1.1")).IsEmpty())
+
+	provider = NewProvider(&schema.AccessControlGeoIP{})
+
+	assert.Nil(t, provider.CountryDatabase())
+	assert.Nil(t, provider.ASNDatabase())
+	assert.NoError(t, provider.StartupCheck())
+	assert.True(t, provider.Lookup(net.ParseIP("1.1.1.1")).IsEmpty())
+}
+
+func TestProviderShouldErrorStartupCheck(t *testing.T) {
+	dir := t.TempDir()
+
+	config := &schema.AccessControlGeoIP{
+		CountryDatabase: filepath.Join(dir, "country.mmdb"),
+	}
+
+	provider := NewProvider(config)
+
+	err := provider.StartupCheck()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error occurred reading the GeoIP database '"+config.CountryDatabase+"': ")
+
+	require.NoError(t, os.WriteFile(config.CountryDatabase, []byte("invalid"), 0600))
+
+	assert.EqualError(t, provider.StartupCheck(), "error occurred reading the GeoIP database '"+config.CountryDatabase+"': error opening database: invalid MaxMind DB file")
+
+	_, _, err = provider.CountryDatabase().Lookup(net.ParseIP("1.1.1.1"))
+
+	assert.EqualError(t, err, "the database is not loaded")
+}
+
+func TestDatabaseLookup(t *testing.T) {
+	networks := map[string]map[string]any{
+		"1.1.1.0/24": {
+			keyCountry:   map[string]any{keyISOCode: "AU"},
+			keyContinent: map[string]any{keyCode: "OC"},
+		},
+		"8.8.8.0/24": {
+			keyRegisteredCountry: map[string]any{keyISOCode: "us"},
+			keyContinent:         map[string]any{keyCode: "na"},
+		},
+		"2001:db8::/32": {
+			keyAutonomousSystemNumber:       uint64(64496),
+			keyAutonomousSystemOrganization: "Example",
+		},
+	}
+
+	for _, ipVersion := range []int{4, 6} {
+		for _, recordSize := range []int{24, 28, 32} {
+			t.Run(fmt.Sprintf("IPv%dRecordSize%d", ipVersion, recordSize), func(t *testing.T) {
+				n := networks
+
+				if ipVersion == 4 {
+					n = map[string]map[string]any{}
+
+					for network, values := range networks {
+						if network != "2001:db8::/32" {
+							n[network] = values
+						}
+					}
+				}
+
+				path := filepath.Join(t.TempDir(), "test.mmdb")
+
+				require.NoError(t, os.WriteFile(path, newTestDatabase(t, ipVersion, recordSize, n), 0600))
+
+				database := NewDatabase(path)
+
+				reloaded, err := database.Reload()
+				require.NoError(t, err)
+				require.True(t, reloaded)
+
+				record, found, err := database.Lookup(net.ParseIP("1.1.1.1"))
+				require.NoError(t, err)
+				require.True(t, found)
+				assert.Equal(t, "AU", record.Country.ISOCode)
+				assert.Equal(t, "OC", record.Continent.Code)
+
+				record, found, err = database.Lookup(net.ParseIP("8.8.8.8"))
+				require.NoError(t, err)
+				require.True(t, found)
+				assert.Equal(t, "", record.Country.ISOCode)
+				assert.Equal(t, "us", record.RegisteredCountry.ISOCode)
+
+				record, found, err = database.Lookup(net.ParseIP("9.9.9.9"))
+				assert.NoError(t, err)
+				assert.False(t, found)
+				assert.Equal(t, DatabaseRecord{}, record)
+
+				record, found, err = database.Lookup(net.ParseIP("2001:db8::1"))
+				require.NoError(t, err)
+
+				if ipVersion == 4 {
+					assert.False(t, found)
+
+					return
+				}
+
+				require.True(t, found)
+				assert.Equal(t, uint64(64496), record.AutonomousSystemNumber)
+				assert.Equal(t, "Example", record.AutonomousSystemOrganization)
+			})
+		}
+	}
+}
+
+func TestDatabaseShouldErrorUnsupportedBinaryFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.mmdb")
+
+	data := append(make([]byte, dataSectionSeparatorSize), metadataStartMarker...)
+	data = append(data, encodeTestValue(map[string]any{
+		"binary_format_major_version": uint64(1),
+		"ip_version":                  uint64(6),
+		"node_count":                  uint64(0),
+		"record_size":                 uint64(24),
+	})...)
+
+	require.NoError(t, os.WriteFile(path, data, 0600))
+
+	_, err := NewDatabase(path).Reload()
+
+	assert.EqualError(t, err, "error occurred reading the GeoIP database '"+path+"': unsupported binary format major version 1")
+}
+
+func TestDatabaseShouldNotPanicTruncatedOrCorrupted(t *testing.T) {
+	valid := newTestDatabase(t, 6, 24, map[string]map[string]any{
+		"1.1.1.0/24": {
+			keyCountry:                      map[string]any{keyISOCode: "AU"},
+			keyAutonomousSystemNumber:       uint64(13335),
+			keyAutonomousSystemOrganization: "CLOUDFLARENET",
+		},
+	})
+
+	var candidates [][]byte
+
+	for i := 0; i < len(valid); i++ {
+		candidates = append(candidates, valid[:i])
+
+		corrupted := append([]byte(nil), valid...)
+		corrupted[i] ^= 0xff
+
+		candidates = append(candidates, corrupted)
+	}
+
+	path := filepath.Join(t.TempDir(), "test.mmdb")
+
+	for _, candidate := range candidates {
+		require.NoError(t, os.WriteFile(path, candidate, 0600))
+
+		assert.NotPanics(t, func() {
+			database := NewDatabase(path)
+
+			if _, err := database.Reload(); err != nil {
+				return
+			}
+
+			for _, ip := range []string{"1.1.1.1", "9.9.9.9", "2001:db8::1"} {
+				_, _, _ = database.Lookup(net.ParseIP(ip))
+			}
+		})
+	}
+}
+
+const (
+	metadataStartMarker      = "\xAB\xCD\xEFMaxMind.com"
+	dataSectionSeparatorSize = 16
+)
+
+const (
+	typeExtended = iota
+	_
+	typeString
+	_
+	_
+	_
+	_
+	typeMap
+	typeInt32
+	typeUint64
+)
+
+const (
+	keyCountry                      = "country"
+	keyRegisteredCountry            = "registered_country"
+	keyContinent                    = "continent"
+	keyISOCode                      = "iso_code"
+	keyCode                         = "code"
+	keyAutonomousSystemNumber       = "autonomous_system_number"
+	keyAutonomousSystemOrganization = "autonomous_system_organization"
+)
+
+type testNode struct {
+	children [2]*testNode
+	value    map[string]any
+}
+
+// newTestDatabase builds a MaxMind DB file containing the provided networks.
+func newTestDatabase(t *testing.T, ipVersion, recordSize int, networks map[string]map[string]any) []byte {
+	t.Helper()
+
+	root := &testNode{}
+
+	keys := make([]string, 0, len(networks))
+
+	for network := range networks {
+		keys = append(keys, network)
+	}
+
+	sort.Strings(keys)
+
+	for _, network := range keys {
+		_, ipnet, err := net.ParseCIDR(network)
+		require.NoError(t, err)
+
+		ip, ones := ipnet.IP, 0
+
+		if ip4 := ip.To4(); ip4 != nil {
+			ones, _ = ipnet.Mask.Size()
+
+			if ipVersion == 6 {
+				ip, ones = ip4.To16(), ones+96
+				ip[10], ip[11] = 0, 0
+			} else {
+				ip = ip4
+			}
+		} else {
+			ones, _ = ipnet.Mask.Size()
+		}
+
+		node := root
+
+		for i := 0; i < ones; i++ {
+			bit := (ip[i>>3] >> (7 - (i & 7))) & 1
+
+			if node.children[bit] == nil {
+				node.children[bit] = &testNode{}
+			}
+
+			node = node.children[bit]
+		}
+
+		node.value = networks[network]
+	}
+
+	var (
+		nodes   []*testNode
+		indexes = map[*testNode]int{}
+	)
+
+	for queue := []*testNode{root}; len(queue) != 0; queue = queue[1:] {
+		node := queue[0]
+
+		indexes[node] = len(nodes)
+		nodes = append(nodes, node)
+
+		for _, child := range node.children {
+			if child != nil && child.value == nil {
+				queue = append(queue, child)
+			}
+		}
+	}
+
+	var (
+		data    []byte
+		offsets = map[*testNode]int{}
+	)
+
+	for _, node := range nodes {
+		for _, child := range node.children {
+			if child != nil && child.value != nil {
+				offsets[child] = len(data)
+				data = append(data, encodeTestValue(child.value)...)
+			}
+		}
+	}
+
+	var tree []byte
+
+	for _, node := range nodes {
+		var records [2]uint32
+
+		for i, child := range node.children {
+			switch {
+			case child == nil:
+				records[i] = uint32(len(nodes))
+			case child.value != nil:
+				records[i] = uint32(len(nodes) + dataSectionSeparatorSize + offsets[child])
+			default:
+				records[i] = uint32(indexes[child])
+			}
+		}
+
+		tree = append(tree, encodeTestRecords(recordSize, records)...)
+	}
+
+	buffer := append(tree, make([]byte, dataSectionSeparatorSize)...)
+	buffer = append(buffer, data...)
+	buffer = append(buffer, []byte(metadataStartMarker)...)
+
+	return append(buffer, encodeTestValue(map[string]any{
+		"binary_format_major_version": uint64(2),
+		"database_type":               "Test-DB",
+		"build_epoch":                 uint64(1700000000),
+		"ip_version":                  uint64(ipVersion),
+		"node_count":                  uint64(len(nodes)),
+		"record_size":                 uint64(recordSize),
+	})...)
+}
+
+func encodeTestRecords(recordSize int, records [2]uint32) (b []byte) {
+	switch recordSize {
+	case 24:
+		return []byte{
+			byte(records[0] >> 16), byte(records[0] >> 8), byte(records[0]),
+			byte(records[1] >> 16), byte(records[1] >> 8), byte(records[1]),
+		}
+	case 28:
+		return []byte{
+			byte(records[0] >> 16), byte(records[0] >> 8), byte(records[0]),
+			byte((records[0]>>24)<<4) | byte(records[1]>>24&0x0f),
+			byte(records[1] >> 16), byte(records[1] >> 8), byte(records[1]),
+		}
+	default:
+		b = make([]byte, 8)
+
+		binary.BigEndian.PutUint32(b[:4], records[0])
+		binary.BigEndian.PutUint32(b[4:], records[1])
+
+		return b
+	}
+}
+
+func encodeTestValue(value any) (b []byte) {
+	switch v := value.(type) {
+	case string:
+		return append(encodeTestControl(typeString, len(v)), v...)
+	case uint64:
+		var raw []byte
+
+		for ; v != 0; v >>= 8 {
+			raw = append([]byte{byte(v)}, raw...)
+		}
+
+		return append(encodeTestControl(typeUint64, len(raw)), raw...)
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		b = encodeTestControl(typeMap, len(v))
+
+		for _, key := range keys {
+			b = append(b, encodeTestValue(key)...)
+			b = append(b, encodeTestValue(v[key])...)
+		}
+
+		return b
+	default:
+		panic(fmt.Sprintf("unsupported type %T", value))
+	}
+}
+
+func encodeTestControl(kind, size int) (b []byte) {
+	control := kind
+
+	if kind >= typeInt32 {
+		control = typeExtended
+	}
+
+	if size < 29 {
+		b = []byte{byte(control<<5 | size)}
+	} else {
+		b = []byte{byte(control<<5 | 29)}
+	}
+
+	if kind >= typeInt32 {
+		b = append(b, byte(kind-7))
+	}
+
+	if size >= 29 {
+		b = append(b, byte(size-29))
+	}
+
+	return b
+}
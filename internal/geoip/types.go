@@ -0,0 +1,64 @@
+package geoip
+
+import (
+	"sync"
+	"time"
+
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// Provider resolves the country, continent, and autonomous system of an IP using MaxMind DB files.
+type Provider struct {
+	config *schema.AccessControlGeoIP
+
+	country *Database
+	asn     *Database
+
+	log *logrus.Logger
+}
+
+// Database is a MaxMind DB file which can be reloaded when it changes.
+type Database struct {
+	path string
+
+	mu     sync.RWMutex
+	reader *maxminddb.Reader
+
+	modified time.Time
+	size     int64
+}
+
+// Record is the information resolved for an IP.
+type Record struct {
+	Country      string
+	Continent    string
+	ASN          uint64
+	Organization string
+}
+
+// IsEmpty returns true if no information was resolved.
+func (r Record) IsEmpty() bool {
+	return r.Country == "" && r.Continent == "" && r.ASN == 0
+}
+
+// DatabaseRecord is the subset of a GeoIP2 / GeoLite2 Country, City, or ASN record which is used by the access control
+// rules. Decoding into this type rather than a generic map means the remaining fields of the record are skipped.
+type DatabaseRecord struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+
+	RegisteredCountry struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"registered_country"`
+
+	Continent struct {
+		Code string `maxminddb:"code"`
+	} `maxminddb:"continent"`
+
+	AutonomousSystemNumber       uint64 `maxminddb:"autonomous_system_number"`
+	AutonomousSystemOrganization string `maxminddb:"autonomous_system_organization"`
+}
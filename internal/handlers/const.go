@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"errors"
+	"time"
 
 	"github.com/valyala/fasthttp"
 )
@@ -27,14 +28,17 @@ var (
 	headerRemoteGroups    = []byte("Remote-Groups")
 	headerRemoteName      = []byte("Remote-Name")
 	headerRemoteEmail     = []byte("Remote-Email")
+	headerRemotePrefix    = []byte("Remote-")
 )
 
 const (
-	headerAuthorizationSchemeBasic = "basic"
+	headerAuthorizationSchemeBasic     = "basic"
+	headerAuthorizationSchemeNegotiate = "negotiate"
 )
 
 var (
-	headerValueAuthenticateBasic = []byte(`Basic realm="Authorization Required"`)
+	headerValueAuthenticateBasic     = []byte(`Basic realm="Authorization Required"`)
+	headerValueAuthenticateNegotiate = []byte(`Negotiate`)
 )
 
 const (
@@ -45,6 +49,7 @@ const (
 	queryArgConsentID  = "consent_id"
 	queryArgWorkflow   = "workflow"
 	queryArgWorkflowID = "workflow_id"
+	queryArgStatus     = "status"
 )
 
 var (
@@ -77,6 +82,18 @@ const (
 	workflowOpenIDConnect = "openid_connect"
 )
 
+const (
+	deviceCodeStatusAuthorized = "authorized"
+	deviceCodeStatusDenied     = "denied"
+	deviceCodeStatusInvalid    = "invalid"
+)
+
+const (
+	// oidcBackChannelLogoutTimeout is the maximum duration spent sending a Logout Token to a Back-Channel Logout URI
+	// including all retries.
+	oidcBackChannelLogoutTimeout = time.Minute
+)
+
 const (
 	logFmtActionAuthentication = "authentication"
 	logFmtActionRegistration   = "registration"
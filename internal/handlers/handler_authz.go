@@ -3,7 +3,9 @@ package handlers
 import (
 	"fmt"
 	"net/url"
+	"time"
 
+	"github.com/authelia/authelia/v4/internal/audit"
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/middlewares"
@@ -13,6 +15,8 @@ import (
 
 // Handler is the middlewares.RequestHandler for Authz.
 func (authz *Authz) Handler(ctx *middlewares.AutheliaCtx) {
+	started := time.Now()
+
 	var (
 		object      authorization.Object
 		autheliaURL *url.URL
@@ -25,6 +29,8 @@ func (authz *Authz) Handler(ctx *middlewares.AutheliaCtx) {
 
 		ctx.ReplyStatusCode(authz.config.StatusCodeBadRequest)
 
+		authz.audit(ctx, started, &Authn{Object: object}, nil, AuthzResultInvalid, err)
+
 		return
 	}
 
@@ -33,6 +39,8 @@ func (authz *Authz) Handler(ctx *middlewares.AutheliaCtx) {
 
 		ctx.ReplyStatusCode(authz.config.StatusCodeBadRequest)
 
+		authz.audit(ctx, started, &Authn{Object: object}, nil, AuthzResultInvalid, fmt.Errorf("target url has the insecure scheme '%s'", object.URL.Scheme))
+
 		return
 	}
 
@@ -41,6 +49,8 @@ func (authz *Authz) Handler(ctx *middlewares.AutheliaCtx) {
 
 		ctx.ReplyStatusCode(authz.config.StatusCodeBadRequest)
 
+		authz.audit(ctx, started, &Authn{Object: object}, nil, AuthzResultInvalid, err)
+
 		return
 	}
 
@@ -49,6 +59,8 @@ func (authz *Authz) Handler(ctx *middlewares.AutheliaCtx) {
 
 		ctx.ReplyStatusCode(authz.config.StatusCodeBadRequest)
 
+		authz.audit(ctx, started, &Authn{Object: object}, nil, AuthzResultInvalid, err)
+
 		return
 	}
 
@@ -62,16 +74,20 @@ func (authz *Authz) Handler(ctx *middlewares.AutheliaCtx) {
 	authn.Object = object
 	authn.Method = friendlyMethod(authn.Object.Method)
 
-	ruleHasSubject, required := ctx.Providers.Authorizer.GetRequiredLevel(
+	ruleHasSubject, policy := ctx.Providers.Authorizer.GetRequiredPolicy(
 		authorization.Subject{
-			Username: authn.Details.Username,
-			Groups:   authn.Details.Groups,
-			ClientID: authn.ClientID,
-			IP:       ctx.RemoteIP(),
+			Username:   authn.Details.Username,
+			Groups:     authn.Details.Groups,
+			Emails:     authn.Details.Emails,
+			Attributes: authn.Details.Attributes,
+			ClientID:   authn.ClientID,
+			IP:         ctx.RemoteIP(),
 		},
 		object,
 	)
 
+	required := policy.Level
+
 	if err != nil {
 		authn.Object = object
 
@@ -84,6 +100,8 @@ func (authz *Authz) Handler(ctx *middlewares.AutheliaCtx) {
 
 				strategy.HandleUnauthorized(ctx, authn, authz.getRedirectionURL(&object, autheliaURL))
 
+				authz.audit(ctx, started, authn, &policy, AuthzResultUnauthorized, err)
+
 				return
 			}
 		}
@@ -91,7 +109,21 @@ func (authz *Authz) Handler(ctx *middlewares.AutheliaCtx) {
 		ctx.Logger.WithError(err).Debug("Error occurred while attempting to authenticate a request but the matched rule was a bypass rule")
 	}
 
-	switch isAuthzResult(authn.Level, required, ruleHasSubject) {
+	result := isAuthzResult(authn.Level, required, ruleHasSubject)
+
+	if result == AuthzResultAuthorized && required == authorization.TwoFactor && !authn.AuthenticationMethodRefs.SatisfiesSecondFactorMethods(policy.SecondFactorMethods) {
+		ctx.Logger.Infof("Access to '%s' requires user '%s' to authenticate with one of the second factor methods %s", object.URL.String(), authn.Username, utils.StringJoinOr(policy.SecondFactorMethods))
+
+		result = AuthzResultUnauthorized
+	}
+
+	if result == AuthzResultAuthorized && authn.Type == AuthnTypeCookie && isAuthnStale(ctx.Clock.Now(), authn.FirstFactorTime, authn.SecondFactorTime, required, policy.MaxAge) {
+		ctx.Logger.Infof("Access to '%s' requires user '%s' to authenticate again as the %s authentication is older than the maximum age of %s", object.URL.String(), authn.Username, required, policy.MaxAge)
+
+		result = AuthzResultUnauthorized
+	}
+
+	switch result {
 	case AuthzResultForbidden:
 		ctx.Logger.Infof("Access to '%s' is forbidden to user '%s'", object.URL.String(), authn.Username)
 		ctx.ReplyForbidden()
@@ -108,6 +140,48 @@ func (authz *Authz) Handler(ctx *middlewares.AutheliaCtx) {
 	case AuthzResultAuthorized:
 		authz.handleAuthorized(ctx, authn)
 	}
+
+	authz.audit(ctx, started, authn, &policy, result, err)
+}
+
+// audit records the authorization decision with the audit provider if one is configured. The policy is nil when the
+// request was rejected before a decision could be made, and the error is the reason the request was rejected or
+// failed to authenticate if any.
+func (authz *Authz) audit(ctx *middlewares.AutheliaCtx, started time.Time, authn *Authn, policy *authorization.RequiredPolicy, result AuthzResult, err error) {
+	if ctx.Providers.Audit == nil {
+		return
+	}
+
+	record := &audit.AuthzRecord{
+		Time: ctx.Clock.Now(),
+		Subject: audit.AuthzSubject{
+			Username: authn.Details.Username,
+			Groups:   authn.Details.Groups,
+			ClientID: authn.ClientID,
+			IP:       ctx.RemoteIP().String(),
+		},
+		Object: audit.AuthzObject{
+			Method: authn.Object.Method,
+		},
+		Result:         result.String(),
+		AuthnType:      authn.Type.String(),
+		Implementation: authz.implementation.String(),
+		Latency:        float64(time.Since(started).Microseconds()) / 1000,
+	}
+
+	if authn.Object.URL != nil {
+		record.Object.URL = authn.Object.URL.String()
+	}
+
+	if policy != nil {
+		record.Rule, record.Policy = policy.Position, policy.Level.String()
+	}
+
+	if err != nil {
+		record.Error = err.Error()
+	}
+
+	ctx.Providers.Audit.RecordAuthz(record)
 }
 
 func (authz *Authz) getAutheliaURL(ctx *middlewares.AutheliaCtx, provider *session.Session) (autheliaURL *url.URL, err error) {
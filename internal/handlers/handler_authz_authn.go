@@ -3,9 +3,11 @@ package handlers
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"maps"
 	"net/url"
 	"strings"
 	"time"
@@ -16,7 +18,9 @@ import (
 
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/clientcert"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/kerberos"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/oidc"
@@ -76,6 +80,18 @@ func NewHeaderLegacyAuthnStrategy() *HeaderLegacyAuthnStrategy {
 	return &HeaderLegacyAuthnStrategy{}
 }
 
+// NewSPNEGOAuthnStrategy creates a new SPNEGOAuthnStrategy using the Authorization and WWW-Authenticate headers with the
+// Negotiate scheme, and the 401 Unauthorized response.
+func NewSPNEGOAuthnStrategy() *SPNEGOAuthnStrategy {
+	return &SPNEGOAuthnStrategy{}
+}
+
+// NewClientCertificateAuthnStrategy creates a new ClientCertificateAuthnStrategy using the verified client certificate
+// presented via mutual TLS or forwarded by a trusted proxy.
+func NewClientCertificateAuthnStrategy() *ClientCertificateAuthnStrategy {
+	return &ClientCertificateAuthnStrategy{}
+}
+
 // CookieSessionAuthnStrategy is a session cookie AuthnStrategy.
 type CookieSessionAuthnStrategy struct {
 	refresh schema.RefreshIntervalDuration
@@ -110,6 +126,8 @@ func (s *CookieSessionAuthnStrategy) Get(ctx *middlewares.AutheliaCtx, provider
 	}
 
 	if invalid := handleAuthnCookieValidate(ctx, provider, &userSession, s.refresh); invalid {
+		oidcLogoutPropagate(ctx, userSession, nil)
+
 		if err = ctx.DestroySession(); err != nil {
 			ctx.Logger.WithError(err).Errorf("Unable to destroy user session")
 		}
@@ -135,9 +153,13 @@ func (s *CookieSessionAuthnStrategy) Get(ctx *middlewares.AutheliaCtx, provider
 			DisplayName: userSession.DisplayName,
 			Emails:      userSession.Emails,
 			Groups:      userSession.Groups,
+			Attributes:  userSession.Attributes,
 		},
-		Level: userSession.AuthenticationLevel,
-		Type:  AuthnTypeCookie,
+		Level:                    userSession.AuthenticationLevel,
+		AuthenticationMethodRefs: userSession.AuthenticationMethodRefs,
+		FirstFactorTime:          time.Unix(userSession.FirstFactorAuthnTimestamp, 0),
+		SecondFactorTime:         time.Unix(userSession.SecondFactorAuthnTimestamp, 0),
+		Type:                     AuthnTypeCookie,
 	}, nil
 }
 
@@ -380,6 +402,168 @@ func (s *HeaderLegacyAuthnStrategy) HandleUnauthorized(ctx *middlewares.Authelia
 	handleAuthzUnauthorizedAuthorizationBasic(ctx, authn)
 }
 
+// SPNEGOAuthnStrategy is a Kerberos SPNEGO AuthnStrategy which validates the tickets presented via the Negotiate
+// scheme of the Authorization header.
+type SPNEGOAuthnStrategy struct{}
+
+// Get returns the Authn information for this AuthnStrategy.
+func (s *SPNEGOAuthnStrategy) Get(ctx *middlewares.AutheliaCtx, _ *session.Session, _ *authorization.Object) (authn *Authn, err error) {
+	var (
+		token   []byte
+		details *authentication.UserDetails
+	)
+
+	authn = &Authn{
+		Type:     AuthnTypeAuthorization,
+		Level:    authentication.NotAuthenticated,
+		Username: anonymous,
+	}
+
+	if token, err = headerAuthorizationParseNegotiate(ctx.Request.Header.PeekBytes(headerAuthorization)); err != nil {
+		return authn, fmt.Errorf("failed to parse content of %s header: %w", headerAuthorization, err)
+	}
+
+	if token == nil {
+		return authn, nil
+	}
+
+	if details, err = handleAuthnNegotiate(ctx, token); err != nil {
+		return authn, err
+	}
+
+	authn.Username = friendlyUsername(details.Username)
+	authn.Details = *details
+	authn.Level = authentication.OneFactor
+
+	return authn, nil
+}
+
+// CanHandleUnauthorized returns true if this AuthnStrategy should handle Unauthorized requests.
+func (s *SPNEGOAuthnStrategy) CanHandleUnauthorized() (handle bool) {
+	return true
+}
+
+// HeaderStrategy returns true if this AuthnStrategy is header based.
+func (s *SPNEGOAuthnStrategy) HeaderStrategy() (header bool) {
+	return true
+}
+
+// HandleUnauthorized is the Unauthorized handler for the SPNEGO AuthnStrategy.
+func (s *SPNEGOAuthnStrategy) HandleUnauthorized(ctx *middlewares.AutheliaCtx, _ *Authn, _ *url.URL) {
+	ctx.Logger.Debugf("Responding %d %s", fasthttp.StatusUnauthorized, headerWWWAuthenticate)
+
+	ctx.ReplyStatusCode(fasthttp.StatusUnauthorized)
+
+	ctx.Response.Header.SetBytesKV(headerWWWAuthenticate, headerValueAuthenticateNegotiate)
+}
+
+// handleAuthnNegotiate validates a Kerberos token presented via the Negotiate scheme and maps the principal to the
+// details of a user from the user provider.
+func handleAuthnNegotiate(ctx *middlewares.AutheliaCtx, token []byte) (details *authentication.UserDetails, err error) {
+	if ctx.Providers.Kerberos == nil {
+		return nil, fmt.Errorf("failed to validate %s header with %s scheme: kerberos is not configured", headerAuthorization, headerAuthorizationSchemeNegotiate)
+	}
+
+	var principal *kerberos.Principal
+
+	if principal, err = ctx.Providers.Kerberos.Authenticate(token); err != nil {
+		return nil, fmt.Errorf("failed to validate %s header with %s scheme: %w", headerAuthorization, headerAuthorizationSchemeNegotiate, err)
+	}
+
+	if details, err = ctx.Providers.UserProvider.GetDetails(principal.Username); err != nil {
+		if errors.Is(err, authentication.ErrUserNotFound) {
+			ctx.Logger.WithFields(map[string]any{"username": principal.Username, "principal": principal.String()}).Error("Error occurred while attempting to get user details for kerberos principal: the user was not found indicating they were deleted, disabled, or otherwise no longer authorized to login")
+
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("unable to retrieve details for user '%s' of kerberos principal '%s': %w", principal.Username, principal, err)
+	}
+
+	return details, nil
+}
+
+// ClientCertificateAuthnStrategy is a client certificate AuthnStrategy which maps the verified client certificate of
+// the request to a user.
+type ClientCertificateAuthnStrategy struct{}
+
+// Get returns the Authn information for this AuthnStrategy.
+func (s *ClientCertificateAuthnStrategy) Get(ctx *middlewares.AutheliaCtx, _ *session.Session, _ *authorization.Object) (authn *Authn, err error) {
+	var details *authentication.UserDetails
+
+	authn = &Authn{
+		Type:     AuthnTypeClientCertificate,
+		Level:    authentication.NotAuthenticated,
+		Username: anonymous,
+	}
+
+	if details, err = handleAuthnClientCertificate(ctx); err != nil || details == nil {
+		return authn, err
+	}
+
+	authn.Username = friendlyUsername(details.Username)
+	authn.Details = *details
+	authn.Level = authentication.OneFactor
+
+	return authn, nil
+}
+
+// CanHandleUnauthorized returns true if this AuthnStrategy should handle Unauthorized requests.
+func (s *ClientCertificateAuthnStrategy) CanHandleUnauthorized() (handle bool) {
+	return false
+}
+
+// HeaderStrategy returns true if this AuthnStrategy is header based.
+func (s *ClientCertificateAuthnStrategy) HeaderStrategy() (header bool) {
+	return false
+}
+
+// HandleUnauthorized is the Unauthorized handler for the client certificate AuthnStrategy.
+func (s *ClientCertificateAuthnStrategy) HandleUnauthorized(_ *middlewares.AutheliaCtx, _ *Authn, _ *url.URL) {
+}
+
+// handleAuthnClientCertificate maps the verified client certificate of the request to the details of a user from the
+// user provider. The details are nil when the request does not include a client certificate.
+func handleAuthnClientCertificate(ctx *middlewares.AutheliaCtx) (details *authentication.UserDetails, err error) {
+	if ctx.Providers.ClientCertificate == nil {
+		return nil, fmt.Errorf("failed to validate client certificate: client certificate authentication is not configured")
+	}
+
+	var (
+		identity *clientcert.Identity
+		state    *tls.ConnectionState
+		username string
+	)
+
+	if ctx.IsTLS() {
+		state = ctx.TLSConnectionState()
+	}
+
+	if identity, err = ctx.Providers.ClientCertificate.Identify(state, ctx.RequestCtx.RemoteIP(), ctx.Request.Header.Peek(ctx.Providers.ClientCertificate.Header())); err != nil {
+		return nil, fmt.Errorf("failed to validate client certificate: %w", err)
+	}
+
+	if identity == nil {
+		return nil, nil
+	}
+
+	if username, err = ctx.Providers.ClientCertificate.Username(identity); err != nil {
+		return nil, fmt.Errorf("failed to validate client certificate: %w", err)
+	}
+
+	if details, err = ctx.Providers.UserProvider.GetDetails(username); err != nil {
+		if errors.Is(err, authentication.ErrUserNotFound) {
+			ctx.Logger.WithFields(map[string]any{"username": username, "subject": identity.SubjectDN}).Error("Error occurred while attempting to get user details for client certificate: the user was not found indicating they were deleted, disabled, or otherwise no longer authorized to login")
+
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("unable to retrieve details for user '%s' of client certificate with subject '%s': %w", username, identity.SubjectDN, err)
+	}
+
+	return details, nil
+}
+
 func handleAuthnCookieValidate(ctx *middlewares.AutheliaCtx, provider *session.Session, userSession *session.UserSession, refresh schema.RefreshIntervalDuration) (invalid bool) {
 	isAnonymous := userSession.Username == ""
 
@@ -453,17 +637,18 @@ func handleSessionValidateRefresh(ctx *middlewares.AutheliaCtx, userSession *ses
 	}
 
 	var (
-		diffEmails, diffGroups, diffDisplayName bool
+		diffEmails, diffGroups, diffDisplayName, diffAttributes bool
 	)
 
 	diffEmails, diffGroups = utils.IsStringSlicesDifferent(userSession.Emails, details.Emails), utils.IsStringSlicesDifferent(userSession.Groups, details.Groups)
 	diffDisplayName = userSession.DisplayName != details.DisplayName
+	diffAttributes = !maps.Equal(userSession.Attributes, details.Attributes)
 
 	if !refresh.Always() {
 		userSession.RefreshTTL = ctx.Clock.Now().Add(refresh.Value())
 	}
 
-	if !diffEmails && !diffGroups && !diffDisplayName {
+	if !diffEmails && !diffGroups && !diffDisplayName && !diffAttributes {
 		ctx.Logger.WithField("username", userSession.Username).Trace("Updated profile not detected for user")
 
 		return false
@@ -476,6 +661,7 @@ func handleSessionValidateRefresh(ctx *middlewares.AutheliaCtx, userSession *ses
 	}
 
 	userSession.Emails, userSession.Groups, userSession.DisplayName = details.Emails, details.Groups, details.DisplayName
+	userSession.Attributes = details.Attributes
 
 	return false
 }
@@ -592,6 +778,26 @@ func headerAuthorizationParse(value []byte) (username, password string, err erro
 	}
 }
 
+// headerAuthorizationParseNegotiate returns the decoded token of an Authorization header value with the Negotiate
+// scheme. A nil token is returned without an error when the value is empty or uses another scheme.
+func headerAuthorizationParseNegotiate(value []byte) (token []byte, err error) {
+	scheme, encoded, found := strings.Cut(string(value), " ")
+
+	if !found || !strings.EqualFold(scheme, headerAuthorizationSchemeNegotiate) {
+		return nil, nil
+	}
+
+	if encoded = strings.TrimSpace(encoded); encoded == "" {
+		return nil, fmt.Errorf("header is malformed: empty token")
+	}
+
+	if token, err = base64.StdEncoding.DecodeString(encoded); err != nil {
+		return nil, fmt.Errorf("header is malformed: could not decode token: %w", err)
+	}
+
+	return token, nil
+}
+
 func headerAuthorizationParseBasic(value string) (username, password string, err error) {
 	var content []byte
 
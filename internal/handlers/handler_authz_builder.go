@@ -95,6 +95,10 @@ func (b *AuthzBuilder) WithEndpointConfig(config schema.ServerEndpointsAuthz) *A
 			b.strategies = append(b.strategies, NewHeaderProxyAuthorizationAuthRequestAuthnStrategy(strategy.Schemes...))
 		case AuthnStrategyHeaderLegacy:
 			b.strategies = append(b.strategies, NewHeaderLegacyAuthnStrategy())
+		case AuthnStrategySPNEGO:
+			b.strategies = append(b.strategies, NewSPNEGOAuthnStrategy())
+		case AuthnStrategyClientCertificate:
+			b.strategies = append(b.strategies, NewClientCertificateAuthnStrategy())
 		}
 	}
 
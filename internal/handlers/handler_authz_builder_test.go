@@ -86,8 +86,12 @@ func TestAuthzBuilder_WithEndpointConfig(t *testing.T) {
 			{Name: "HeaderAuthRequestProxyAuthorization"},
 			{Name: "HeaderLegacy"},
 			{Name: "CookieSession"},
+			{Name: "SPNEGO"},
+			{Name: "ClientCertificate"},
 		},
 	})
 
-	assert.Len(t, builder.strategies, 5)
+	assert.Len(t, builder.strategies, 7)
+	assert.IsType(t, &SPNEGOAuthnStrategy{}, builder.strategies[5])
+	assert.IsType(t, &ClientCertificateAuthnStrategy{}, builder.strategies[6])
 }
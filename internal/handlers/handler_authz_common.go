@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"fmt"
+	"net/textproto"
 	"net/url"
 	"strings"
 
@@ -72,9 +73,19 @@ func handleAuthzAuthorizedStandard(ctx *middlewares.AutheliaCtx, authn *Authn) {
 		default:
 			ctx.Response.Header.SetBytesK(headerRemoteEmail, authn.Details.Emails[0])
 		}
+
+		for name, value := range authn.Details.Attributes {
+			ctx.Response.Header.SetBytesK(getAuthzAttributeHeaderName(name), value)
+		}
 	}
 }
 
+// getAuthzAttributeHeaderName returns the canonical header name for an extended user attribute, i.e. the attribute
+// named phone_number is returned as the Remote-Phone-Number header.
+func getAuthzAttributeHeaderName(name string) (header []byte) {
+	return append(append([]byte(nil), headerRemotePrefix...), textproto.CanonicalMIMEHeaderKey(strings.ReplaceAll(name, "_", "-"))...)
+}
+
 func handleAuthzUnauthorizedAuthorizationBasic(ctx *middlewares.AutheliaCtx, authn *Authn) {
 	ctx.Logger.Infof("Access to '%s' is not authorized to user '%s', sending 401 response with WWW-Authenticate header requesting Basic scheme", authn.Object.URL.String(), authn.Username)
 
@@ -246,7 +246,7 @@ func (s *AuthRequestAuthzSuite) TestShouldHandleInvalidURLForCVE202132637() {
 					defer mock.Close()
 
 					mock.Ctx.Configuration.AccessControl.DefaultPolicy = testBypass
-					mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&mock.Ctx.Configuration)
+					mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&mock.Ctx.Configuration, &mock.Clock, nil)
 
 					s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
 
@@ -1,12 +1,15 @@
 package handlers
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/valyala/fasthttp"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/mocks"
 	"github.com/authelia/authelia/v4/internal/session"
 )
@@ -16,11 +19,30 @@ func TestAuthzImplementation(t *testing.T) {
 	assert.Equal(t, "", AuthzImplementation(-1).String())
 }
 
+func TestAuthnType(t *testing.T) {
+	assert.Equal(t, "Cookie", AuthnTypeCookie.String())
+	assert.Equal(t, "ClientCertificate", AuthnTypeClientCertificate.String())
+	assert.Equal(t, "", AuthnType(-1).String())
+}
+
+func TestAuthzResult(t *testing.T) {
+	assert.Equal(t, "forbidden", AuthzResultForbidden.String())
+	assert.Equal(t, "authorized", AuthzResultAuthorized.String())
+	assert.Equal(t, "invalid", AuthzResultInvalid.String())
+	assert.Equal(t, "", AuthzResult(-1).String())
+}
+
 func TestFriendlyMethod(t *testing.T) {
 	assert.Equal(t, "unknown", friendlyMethod(""))
 	assert.Equal(t, "GET", friendlyMethod(fasthttp.MethodGet))
 }
 
+func TestGetAuthzAttributeHeaderName(t *testing.T) {
+	assert.Equal(t, []byte("Remote-Locale"), getAuthzAttributeHeaderName("locale"))
+	assert.Equal(t, []byte("Remote-Phone-Number"), getAuthzAttributeHeaderName("phone_number"))
+	assert.Equal(t, []byte("Remote-Employee-Id2"), getAuthzAttributeHeaderName("employee_id2"))
+}
+
 func TestGenerateVerifySessionHasUpToDateProfileTraceLogs(t *testing.T) {
 	mock := mocks.NewMockAutheliaCtx(t)
 
@@ -29,3 +51,37 @@ func TestGenerateVerifySessionHasUpToDateProfileTraceLogs(t *testing.T) {
 	generateVerifySessionHasUpToDateProfileTraceLogs(mock.Ctx, &session.UserSession{Username: "john", DisplayName: "example", Emails: []string{"abc@example.com"}}, &authentication.UserDetails{Username: "john", DisplayName: "example"})
 	generateVerifySessionHasUpToDateProfileTraceLogs(mock.Ctx, &session.UserSession{Username: "john", DisplayName: "example"}, &authentication.UserDetails{Username: "john", DisplayName: "example", Emails: []string{"abc@example.com"}})
 }
+
+func TestHandleAuthzGetObjectHeader(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	defer mock.Close()
+
+	mock.Ctx.Request.Header.Set("X-Api-Version", "2")
+	mock.Ctx.Request.Header.Add("X-Tenant", "one")
+	mock.Ctx.Request.Header.Add("X-Tenant", "two")
+	mock.Ctx.Request.Header.Set("X-Other", "ignored")
+
+	assert.Nil(t, handleAuthzGetObjectHeader(mock.Ctx))
+
+	mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&schema.Configuration{
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: "deny",
+			Rules: []schema.AccessControlRule{
+				{
+					Domains: []string{"api.example.com"},
+					Headers: [][]schema.AccessControlRuleHeader{
+						{
+							{Operator: "equal", Key: "x-api-version", Value: "2"},
+							{Operator: "present", Key: "X-Tenant"},
+							{Operator: "present", Key: "X-Missing"},
+						},
+					},
+					Policy: "bypass",
+				},
+			},
+		},
+	}, &mock.Clock, nil)
+
+	assert.Equal(t, http.Header{"X-Api-Version": []string{"2"}, "X-Tenant": []string{"one", "two"}}, handleAuthzGetObjectHeader(mock.Ctx))
+}
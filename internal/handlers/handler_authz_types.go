@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/url"
+	"time"
 
 	oauthelia2 "authelia.com/provider/oauth2"
 
@@ -66,8 +67,29 @@ const (
 
 	// AuthnTypeAuthorization is an Authentication AuthnType based on the Authorization header.
 	AuthnTypeAuthorization
+
+	// AuthnTypeClientCertificate is an Authentication AuthnType based on a verified client certificate.
+	AuthnTypeClientCertificate
 )
 
+// String returns the text representation of this AuthnType.
+func (t AuthnType) String() string {
+	switch t {
+	case AuthnTypeNone:
+		return "None"
+	case AuthnTypeCookie:
+		return "Cookie"
+	case AuthnTypeProxyAuthorization:
+		return "ProxyAuthorization"
+	case AuthnTypeAuthorization:
+		return "Authorization"
+	case AuthnTypeClientCertificate:
+		return "ClientCertificate"
+	default:
+		return ""
+	}
+}
+
 // Authn is authentication.
 type Authn struct {
 	Username string
@@ -79,6 +101,13 @@ type Authn struct {
 	Object  authorization.Object
 	Type    AuthnType
 
+	AuthenticationMethodRefs oidc.AuthenticationMethodsReferences
+
+	// FirstFactorTime and SecondFactorTime are the times each factor was last performed. They're only populated by
+	// strategies which do not authenticate every request.
+	FirstFactorTime  time.Time
+	SecondFactorTime time.Time
+
 	Header HeaderAuthorization
 }
 
@@ -125,8 +154,27 @@ const (
 
 	// AuthzResultAuthorized means the user is authorized given her current permissions.
 	AuthzResultAuthorized
+
+	// AuthzResultInvalid means the request was rejected before an authorization decision could be made.
+	AuthzResultInvalid
 )
 
+// String returns the text representation of this AuthzResult.
+func (r AuthzResult) String() string {
+	switch r {
+	case AuthzResultForbidden:
+		return "forbidden"
+	case AuthzResultUnauthorized:
+		return "unauthorized"
+	case AuthzResultAuthorized:
+		return "authorized"
+	case AuthzResultInvalid:
+		return "invalid"
+	default:
+		return ""
+	}
+}
+
 // AuthzImplementation represents an Authz implementation.
 type AuthzImplementation int
 
@@ -137,6 +185,8 @@ const (
 	AuthnStrategyHeaderProxyAuthorization            = "HeaderProxyAuthorization"
 	AuthnStrategyHeaderAuthRequestProxyAuthorization = "HeaderAuthRequestProxyAuthorization"
 	AuthnStrategyHeaderLegacy                        = "HeaderLegacy"
+	AuthnStrategySPNEGO                              = "SPNEGO"
+	AuthnStrategyClientCertificate                   = "ClientCertificate"
 )
 
 const (
@@ -1,6 +1,9 @@
 package handlers
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/middlewares"
@@ -8,6 +11,25 @@ import (
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
+// handleAuthzGetObjectHeader returns the original request headers the access control rules match against.
+func handleAuthzGetObjectHeader(ctx *middlewares.AutheliaCtx) (header http.Header) {
+	names := ctx.Providers.Authorizer.HeaderNames()
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	header = make(http.Header, len(names))
+
+	for _, name := range names {
+		for _, value := range ctx.Request.Header.PeekAll(name) {
+			header.Add(name, string(value))
+		}
+	}
+
+	return header
+}
+
 func friendlyMethod(m string) (fm string) {
 	switch m {
 	case "":
@@ -43,6 +65,22 @@ func isAuthzResult(level authentication.Level, required authorization.Level, rul
 	}
 }
 
+// isAuthnStale returns true if the factor required by the level was last performed longer ago than the maximum age.
+func isAuthnStale(now, firstFactor, secondFactor time.Time, required authorization.Level, maxAge time.Duration) bool {
+	if maxAge <= 0 {
+		return false
+	}
+
+	switch required {
+	case authorization.OneFactor:
+		return now.Sub(firstFactor) > maxAge
+	case authorization.TwoFactor:
+		return now.Sub(secondFactor) > maxAge
+	default:
+		return false
+	}
+}
+
 // generateVerifySessionHasUpToDateProfileTraceLogs is used to generate trace logs only when trace logging is enabled.
 // The information calculated in this function is completely useless other than trace for now.
 func generateVerifySessionHasUpToDateProfileTraceLogs(ctx *middlewares.AutheliaCtx, userSession *session.UserSession,
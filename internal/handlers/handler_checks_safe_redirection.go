@@ -3,12 +3,22 @@ package handlers
 import (
 	"fmt"
 	"net/url"
+	"time"
 
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/session"
 )
 
 // CheckSafeRedirectionPOST handler checking whether the redirection to a given URL provided in body is safe.
+//
+// When it's safe the response also hints at the second factor methods the user must step-up with, or the factor they
+// must perform again, before being redirected. These hints are derived from the URL alone as the headers of the request
+// the user will make are not known, so the header criteria of the rules are matched as if there were no headers. The
+// authz endpoint still enforces the rule the request matches when the request is made.
 func CheckSafeRedirectionPOST(ctx *middlewares.AutheliaCtx) {
 	var (
 		s   session.UserSession
@@ -40,7 +50,34 @@ func CheckSafeRedirectionPOST(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
-	if err = ctx.SetJSONBody(checkURIWithinDomainResponseBody{OK: ctx.IsSafeRedirectionTargetURI(targetURI)}); err != nil {
+	body := checkURIWithinDomainResponseBody{OK: ctx.IsSafeRedirectionTargetURI(targetURI)}
+
+	if body.OK {
+		_, policy := ctx.Providers.Authorizer.GetRequiredPolicy(
+			authorization.Subject{
+				Username:   s.Username,
+				Groups:     s.Groups,
+				Emails:     s.Emails,
+				Attributes: s.Attributes,
+				IP:         ctx.RemoteIP(),
+			},
+			authorization.NewObject(targetURI, fasthttp.MethodGet))
+
+		if isAuthzResult(s.AuthenticationLevel, policy.Level, false) == AuthzResultAuthorized {
+			switch {
+			case policy.Level == authorization.TwoFactor && !s.AuthenticationMethodRefs.SatisfiesSecondFactorMethods(policy.SecondFactorMethods):
+				body.StepUpMethods = policy.SecondFactorMethods
+			case isAuthnStale(ctx.Clock.Now(), time.Unix(s.FirstFactorAuthnTimestamp, 0), time.Unix(s.SecondFactorAuthnTimestamp, 0), policy.Level, policy.MaxAge):
+				if policy.Level == authorization.OneFactor {
+					body.ReauthenticationLevel = authentication.OneFactor
+				} else {
+					body.ReauthenticationLevel = authentication.TwoFactor
+				}
+			}
+		}
+	}
+
+	if err = ctx.SetJSONBody(body); err != nil {
 		ctx.Error(fmt.Errorf("unable to create response body: %w", err), messageOperationFailed)
 		return
 	}
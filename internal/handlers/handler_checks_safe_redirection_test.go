@@ -2,12 +2,17 @@ package handlers
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/valyala/fasthttp"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/oidc"
 	"github.com/authelia/authelia/v4/internal/session"
 )
 
@@ -112,3 +117,126 @@ func TestShouldFailOnInvalidURL(t *testing.T) {
 	CheckSafeRedirectionPOST(mock.Ctx)
 	mock.Assert200KO(t, "Operation failed.")
 }
+
+func TestCheckSafeRedirectionStepUp(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     oidc.AuthenticationMethodsReferences
+		uri      string
+		expected []string
+	}{
+		{
+			"ShouldRequireStepUp",
+			oidc.AuthenticationMethodsReferences{UsernameAndPassword: true, TOTP: true},
+			"https://secure.example.com",
+			[]string{"webauthn_hardware"},
+		},
+		{
+			"ShouldNotRequireStepUpWhenSatisfied",
+			oidc.AuthenticationMethodsReferences{UsernameAndPassword: true, WebAuthn: true, WebAuthnHardware: true},
+			"https://secure.example.com",
+			nil,
+		},
+		{
+			"ShouldNotRequireStepUpWithoutMethods",
+			oidc.AuthenticationMethodsReferences{UsernameAndPassword: true, TOTP: true},
+			"https://myapp.example.com",
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtxWithUserSession(t, session.UserSession{
+				CookieDomain:             "example.com",
+				Username:                 "john",
+				AuthenticationLevel:      authentication.TwoFactor,
+				AuthenticationMethodRefs: tc.have,
+			})
+			defer mock.Close()
+
+			mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&schema.Configuration{
+				AccessControl: schema.AccessControl{
+					DefaultPolicy: "two_factor",
+					Rules: []schema.AccessControlRule{
+						{
+							Domains:             []string{"secure.example.com"},
+							Policy:              "two_factor",
+							SecondFactorMethods: []string{"webauthn_hardware"},
+						},
+					},
+				},
+			}, &mock.Clock, nil)
+
+			mock.SetRequestBody(t, checkURIWithinDomainRequestBody{
+				URI: tc.uri,
+			})
+
+			CheckSafeRedirectionPOST(mock.Ctx)
+
+			mock.Assert200OK(t, checkURIWithinDomainResponseBody{
+				OK:            true,
+				StepUpMethods: tc.expected,
+			})
+		})
+	}
+}
+
+func TestCheckSafeRedirectionReauthentication(t *testing.T) {
+	testCases := []struct {
+		name                      string
+		uri                       string
+		firstFactor, secondFactor time.Duration
+		expected                  authentication.Level
+	}{
+		{"ShouldRequireOneFactor", "https://one-factor.example.com", time.Hour * 2, time.Minute, authentication.OneFactor},
+		{"ShouldNotRequireOneFactorFresh", "https://one-factor.example.com", time.Minute * 30, time.Hour * 2, authentication.NotAuthenticated},
+		{"ShouldRequireTwoFactor", "https://two-factor.example.com", time.Minute, time.Minute * 20, authentication.TwoFactor},
+		{"ShouldNotRequireTwoFactorFresh", "https://two-factor.example.com", time.Hour * 2, time.Minute * 5, authentication.NotAuthenticated},
+		{"ShouldNotRequireWithoutMaxAge", "https://myapp.example.com", time.Hour * 24, time.Hour * 24, authentication.NotAuthenticated},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+			defer mock.Close()
+
+			mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&schema.Configuration{
+				AccessControl: schema.AccessControl{
+					DefaultPolicy: "two_factor",
+					Rules: []schema.AccessControlRule{
+						{
+							Domains: []string{"one-factor.example.com"},
+							Policy:  "one_factor",
+							MaxAge:  time.Hour,
+						},
+						{
+							Domains: []string{"two-factor.example.com"},
+							Policy:  "two_factor",
+							MaxAge:  time.Minute * 15,
+						},
+					},
+				},
+			}, &mock.Clock, nil)
+
+			require.NoError(t, mock.Ctx.SaveSession(session.UserSession{
+				CookieDomain:               "example.com",
+				Username:                   "john",
+				AuthenticationLevel:        authentication.TwoFactor,
+				FirstFactorAuthnTimestamp:  mock.Clock.Now().Add(-tc.firstFactor).Unix(),
+				SecondFactorAuthnTimestamp: mock.Clock.Now().Add(-tc.secondFactor).Unix(),
+			}))
+
+			mock.SetRequestBody(t, checkURIWithinDomainRequestBody{
+				URI: tc.uri,
+			})
+
+			CheckSafeRedirectionPOST(mock.Ctx)
+
+			mock.Assert200OK(t, checkURIWithinDomainResponseBody{
+				OK:                    true,
+				ReauthenticationLevel: tc.expected,
+			})
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/regulation"
 )
@@ -153,7 +154,99 @@ func FirstFactorPOST(delayFunc middlewares.TimingAttackDelayFunc) middlewares.Re
 		if bodyJSON.Workflow == workflowOpenIDConnect {
 			handleOIDCWorkflowResponse(ctx, &userSession, bodyJSON.TargetURL, bodyJSON.WorkflowID)
 		} else {
-			Handle1FAResponse(ctx, bodyJSON.TargetURL, bodyJSON.RequestMethod, userSession.Username, userSession.Groups)
+			Handle1FAResponse(ctx, bodyJSON.TargetURL, bodyJSON.RequestMethod, &userSession)
 		}
 	}
 }
+
+// handleFirstFactorExternal completes the first factor for a user who was authenticated using credentials which are
+// not part of the request body such as Kerberos SPNEGO or a client certificate.
+func handleFirstFactorExternal(ctx *middlewares.AutheliaCtx, bodyJSON bodyFirstFactorExternalRequest, details *authentication.UserDetails, authType string) {
+	var err error
+
+	if bannedUntil, err := ctx.Providers.Regulator.Regulate(ctx, details.Username); err != nil {
+		if errors.Is(err, regulation.ErrUserIsBanned) {
+			_ = markAuthenticationAttempt(ctx, false, &bannedUntil, details.Username, authType, nil)
+
+			respondUnauthorized(ctx, messageAuthenticationFailed)
+
+			return
+		}
+
+		ctx.Logger.WithError(err).Errorf(logFmtErrRegulationFail, authType, details.Username)
+
+		respondUnauthorized(ctx, messageAuthenticationFailed)
+
+		return
+	}
+
+	if err = markAuthenticationAttempt(ctx, true, nil, details.Username, authType, nil); err != nil {
+		respondUnauthorized(ctx, messageAuthenticationFailed)
+
+		return
+	}
+
+	provider, err := ctx.GetSessionProvider()
+	if err != nil {
+		ctx.Logger.WithError(err).Errorf("Failed to get session provider during %s attempt", authType)
+
+		respondUnauthorized(ctx, messageAuthenticationFailed)
+
+		return
+	}
+
+	userSession, err := provider.GetSession(ctx.RequestCtx)
+	if err != nil {
+		ctx.Logger.Errorf("%s", err)
+
+		respondUnauthorized(ctx, messageAuthenticationFailed)
+
+		return
+	}
+
+	// Reset all values from previous session except OIDC workflow before regenerating the cookie.
+	if err = ctx.SaveSession(provider.NewDefaultUserSession()); err != nil {
+		ctx.Logger.WithError(err).Errorf(logFmtErrSessionReset, authType, details.Username)
+
+		respondUnauthorized(ctx, messageAuthenticationFailed)
+
+		return
+	}
+
+	if err = ctx.RegenerateSession(); err != nil {
+		ctx.Logger.WithError(err).Errorf(logFmtErrSessionRegenerate, authType, details.Username)
+
+		respondUnauthorized(ctx, messageAuthenticationFailed)
+
+		return
+	}
+
+	ctx.Logger.Tracef(logFmtTraceProfileDetails, details.Username, details.Groups, details.Emails)
+
+	switch authType {
+	case regulation.AuthTypeSPNEGO:
+		userSession.SetOneFactorKerberos(ctx.Clock.Now(), details)
+	case regulation.AuthTypeMTLS:
+		userSession.SetOneFactorCertificate(ctx.Clock.Now(), details)
+	default:
+		userSession.SetOneFactor(ctx.Clock.Now(), details, false)
+	}
+
+	if ctx.Configuration.AuthenticationBackend.RefreshInterval.Update() {
+		userSession.RefreshTTL = ctx.Clock.Now().Add(ctx.Configuration.AuthenticationBackend.RefreshInterval.Value())
+	}
+
+	if err = ctx.SaveSession(userSession); err != nil {
+		ctx.Logger.WithError(err).Errorf(logFmtErrSessionSave, "updated profile", authType, logFmtActionAuthentication, details.Username)
+
+		respondUnauthorized(ctx, messageAuthenticationFailed)
+
+		return
+	}
+
+	if bodyJSON.Workflow == workflowOpenIDConnect {
+		handleOIDCWorkflowResponse(ctx, &userSession, bodyJSON.TargetURL, bodyJSON.WorkflowID)
+	} else {
+		Handle1FAResponse(ctx, bodyJSON.TargetURL, bodyJSON.RequestMethod, &userSession)
+	}
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/regulation"
+)
+
+// FirstFactorClientCertificatePOST is the handler performing the first factor using the verified client certificate
+// presented via mutual TLS or forwarded by a trusted proxy.
+func FirstFactorClientCertificatePOST(ctx *middlewares.AutheliaCtx) {
+	var (
+		details *authentication.UserDetails
+		err     error
+	)
+
+	bodyJSON := bodyFirstFactorExternalRequest{}
+
+	if err = ctx.ParseBody(&bodyJSON); err != nil {
+		ctx.Logger.WithError(err).Errorf(logFmtErrParseRequestBody, regulation.AuthTypeMTLS)
+
+		respondUnauthorized(ctx, messageAuthenticationFailed)
+
+		return
+	}
+
+	if details, err = handleAuthnClientCertificate(ctx); err != nil {
+		ctx.Logger.WithError(err).Errorf("Unsuccessful %s authentication attempt", regulation.AuthTypeMTLS)
+
+		respondUnauthorized(ctx, messageAuthenticationFailed)
+
+		return
+	}
+
+	if details == nil {
+		ctx.Logger.Debugf("Unsuccessful %s authentication attempt as the request did not include a client certificate", regulation.AuthTypeMTLS)
+
+		respondUnauthorized(ctx, messageAuthenticationFailed)
+
+		return
+	}
+
+	handleFirstFactorExternal(ctx, bodyJSON, details, regulation.AuthTypeMTLS)
+}
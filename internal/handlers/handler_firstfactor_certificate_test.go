@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/clientcert"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/oidc"
+)
+
+type FirstFactorClientCertificateSuite struct {
+	suite.Suite
+
+	mock *mocks.MockAutheliaCtx
+
+	certificate string
+}
+
+func (s *FirstFactorClientCertificateSuite) SetupTest() {
+	s.mock = mocks.NewMockAutheliaCtx(s.T())
+
+	ca, leaf := newTestClientCertificates(s.T(), "john@example.com")
+
+	path := filepath.Join(s.T().TempDir(), "ca.pem")
+
+	s.Require().NoError(os.WriteFile(path, ca, 0600))
+
+	s.mock.Ctx.Providers.ClientCertificate = clientcert.NewProvider(&schema.AuthenticationBackendClientCertificate{
+		Mapping: schema.ClientCertificateMappingEmail,
+		Forwarded: schema.AuthenticationBackendClientCertificateForwarded{
+			Header:                 "X-Forwarded-Client-Cert",
+			TrustedProxies:         []string{"10.0.0.1"},
+			CertificateAuthorities: []string{path},
+		},
+	})
+
+	s.Require().NoError(s.mock.Ctx.Providers.ClientCertificate.StartupCheck())
+
+	s.mock.Ctx.SetRemoteAddr(&net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 443})
+
+	s.certificate = base64.StdEncoding.EncodeToString(leaf)
+}
+
+func (s *FirstFactorClientCertificateSuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *FirstFactorClientCertificateSuite) TestShouldFailIfBodyIsNil() {
+	FirstFactorClientCertificatePOST(s.mock.Ctx)
+
+	AssertLogEntryMessageAndError(s.T(), s.mock.Hook.LastEntry(), "Failed to parse mTLS request body", "unable to parse body: unexpected end of JSON input")
+	s.mock.Assert401KO(s.T(), "Authentication failed. Check your credentials.")
+}
+
+func (s *FirstFactorClientCertificateSuite) TestShouldFailWithoutCertificate() {
+	s.mock.Ctx.Request.SetBodyString(`{}`)
+
+	FirstFactorClientCertificatePOST(s.mock.Ctx)
+
+	s.mock.Assert401KO(s.T(), "Authentication failed. Check your credentials.")
+}
+
+func (s *FirstFactorClientCertificateSuite) TestShouldFailWithoutProvider() {
+	s.mock.Ctx.Providers.ClientCertificate = nil
+	s.mock.Ctx.Request.SetBodyString(`{}`)
+
+	FirstFactorClientCertificatePOST(s.mock.Ctx)
+
+	AssertLogEntryMessageAndError(s.T(), s.mock.Hook.LastEntry(), "Unsuccessful mTLS authentication attempt", "failed to validate client certificate: client certificate authentication is not configured")
+	s.mock.Assert401KO(s.T(), "Authentication failed. Check your credentials.")
+}
+
+func (s *FirstFactorClientCertificateSuite) TestShouldFailWithInvalidCertificate() {
+	s.mock.Ctx.Request.SetBodyString(`{}`)
+	s.mock.Ctx.Request.Header.Set("X-Forwarded-Client-Cert", "abc!")
+
+	FirstFactorClientCertificatePOST(s.mock.Ctx)
+
+	AssertLogEntryMessageAndError(s.T(), s.mock.Hook.LastEntry(), "Unsuccessful mTLS authentication attempt", "failed to validate client certificate: error occurred parsing the forwarded client certificate: the value is not a PEM or base64 encoded certificate: illegal base64 data at input byte 3")
+	s.mock.Assert401KO(s.T(), "Authentication failed. Check your credentials.")
+}
+
+func (s *FirstFactorClientCertificateSuite) TestShouldFailIfUserNotFound() {
+	s.mock.UserProviderMock.
+		EXPECT().
+		GetDetails(gomock.Eq("john@example.com")).
+		Return(nil, authentication.ErrUserNotFound)
+
+	s.mock.Ctx.Request.SetBodyString(`{}`)
+	s.mock.Ctx.Request.Header.Set("X-Forwarded-Client-Cert", s.certificate)
+
+	FirstFactorClientCertificatePOST(s.mock.Ctx)
+
+	s.mock.Assert401KO(s.T(), "Authentication failed. Check your credentials.")
+}
+
+func (s *FirstFactorClientCertificateSuite) TestShouldAuthenticateUser() {
+	s.mock.UserProviderMock.
+		EXPECT().
+		GetDetails(gomock.Eq("john@example.com")).
+		Return(&authentication.UserDetails{
+			Username: "john",
+			Emails:   []string{"john@example.com"},
+			Groups:   []string{"dev"},
+		}, nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
+		Return(nil)
+
+	s.mock.Ctx.Request.SetBodyString(`{}`)
+	s.mock.Ctx.Request.Header.Set("X-Forwarded-Client-Cert", s.certificate)
+
+	FirstFactorClientCertificatePOST(s.mock.Ctx)
+
+	s.mock.Assert200OK(s.T(), nil)
+
+	userSession, err := s.mock.Ctx.GetSession()
+
+	s.Require().NoError(err)
+
+	s.Equal("john", userSession.Username)
+	s.Equal(authentication.OneFactor, userSession.AuthenticationLevel)
+	s.Equal([]string{"dev"}, userSession.Groups)
+	s.Equal(oidc.AuthenticationMethodsReferences{Certificate: true}, userSession.AuthenticationMethodRefs)
+	s.Equal([]string{oidc.AMRSmartCard}, userSession.AuthenticationMethodRefs.MarshalRFC8176())
+}
+
+func TestFirstFactorClientCertificateSuite(t *testing.T) {
+	suite.Run(t, new(FirstFactorClientCertificateSuite))
+}
+
+func TestClientCertificateAuthnStrategy(t *testing.T) {
+	strategy := NewClientCertificateAuthnStrategy()
+
+	assert.False(t, strategy.CanHandleUnauthorized())
+	assert.False(t, strategy.HeaderStrategy())
+
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	defer mock.Close()
+
+	authn, err := strategy.Get(mock.Ctx, nil, nil)
+
+	assert.EqualError(t, err, "failed to validate client certificate: client certificate authentication is not configured")
+	assert.Equal(t, authentication.NotAuthenticated, authn.Level)
+	assert.Equal(t, AuthnTypeClientCertificate, authn.Type)
+
+	mock.Ctx.Providers.ClientCertificate = clientcert.NewProvider(&schema.AuthenticationBackendClientCertificate{Mapping: schema.ClientCertificateMappingEmail})
+
+	require.NoError(t, mock.Ctx.Providers.ClientCertificate.StartupCheck())
+
+	authn, err = strategy.Get(mock.Ctx, nil, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, authentication.NotAuthenticated, authn.Level)
+	assert.Equal(t, anonymous, authn.Username)
+}
+
+func newTestClientCertificates(t *testing.T, email string) (ca, leaf []byte) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	require.NoError(t, err)
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Example CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	require.NoError(t, err)
+
+	leafTemplate := &x509.Certificate{
+		SerialNumber:   big.NewInt(2),
+		Subject:        pkix.Name{CommonName: "john"},
+		EmailAddresses: []string{email},
+		NotBefore:      time.Now().Add(-time.Hour),
+		NotAfter:       time.Now().Add(time.Hour),
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	leaf, err = x509.CreateCertificate(rand.Reader, leafTemplate, caTemplate, &key.PublicKey, caKey)
+
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), leaf
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/regulation"
+)
+
+// FirstFactorSPNEGOPOST is the handler performing the first factor using a Kerberos ticket presented via SPNEGO. When
+// the request does not include a ticket the Negotiate challenge is sent so the browser is able to provide one.
+func FirstFactorSPNEGOPOST(ctx *middlewares.AutheliaCtx) {
+	var (
+		token   []byte
+		details *authentication.UserDetails
+		err     error
+	)
+
+	bodyJSON := bodyFirstFactorExternalRequest{}
+
+	if err = ctx.ParseBody(&bodyJSON); err != nil {
+		ctx.Logger.WithError(err).Errorf(logFmtErrParseRequestBody, regulation.AuthTypeSPNEGO)
+
+		respondUnauthorized(ctx, messageAuthenticationFailed)
+
+		return
+	}
+
+	if token, err = headerAuthorizationParseNegotiate(ctx.Request.Header.PeekBytes(headerAuthorization)); err != nil {
+		ctx.Logger.WithError(err).Errorf("Failed to parse content of %s header during %s authentication", headerAuthorization, regulation.AuthTypeSPNEGO)
+
+		respondUnauthorized(ctx, messageAuthenticationFailed)
+
+		return
+	}
+
+	if token == nil {
+		ctx.Logger.Debugf("Responding with the %s challenge as the request did not include a ticket", regulation.AuthTypeSPNEGO)
+
+		respondUnauthorized(ctx, messageAuthenticationFailed)
+
+		ctx.Response.Header.SetBytesKV(headerWWWAuthenticate, headerValueAuthenticateNegotiate)
+
+		return
+	}
+
+	if details, err = handleAuthnNegotiate(ctx, token); err != nil {
+		ctx.Logger.WithError(err).Errorf("Unsuccessful %s authentication attempt", regulation.AuthTypeSPNEGO)
+
+		respondUnauthorized(ctx, messageAuthenticationFailed)
+
+		return
+	}
+
+	handleFirstFactorExternal(ctx, bodyJSON, details, regulation.AuthTypeSPNEGO)
+}
@@ -325,7 +325,7 @@ func (s *FirstFactorRedirectionSuite) SetupTest() {
 			Policy:  "one_factor",
 		},
 	}
-	s.mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&s.mock.Ctx.Configuration)
+	s.mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&s.mock.Ctx.Configuration, &s.mock.Clock, nil)
 
 	s.mock.UserProviderMock.
 		EXPECT().
@@ -409,7 +409,7 @@ func (s *FirstFactorRedirectionSuite) TestShouldReply200WhenNoTargetURLProvidedA
 		AccessControl: schema.AccessControl{
 			DefaultPolicy: "two_factor",
 		},
-	})
+	}, &s.mock.Clock, nil)
 	s.mock.Ctx.Request.SetBodyString(`{
 		"username": "test",
 		"password": "hello",
@@ -444,7 +444,7 @@ func (s *FirstFactorRedirectionSuite) TestShouldReply200WhenUnsafeTargetURLProvi
 					Policy:  "two_factor",
 				},
 			},
-		}})
+		}}, &s.mock.Clock, nil)
 	s.mock.Ctx.Request.SetBodyString(`{
 		"username": "test",
 		"password": "hello",
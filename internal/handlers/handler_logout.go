@@ -12,7 +12,8 @@ type logoutBody struct {
 }
 
 type logoutResponseBody struct {
-	SafeTargetURL bool `json:"safeTargetURL"`
+	SafeTargetURL         bool   `json:"safeTargetURL"`
+	FrontChannelLogoutURL string `json:"frontChannelLogoutURL,omitempty"`
 }
 
 // LogoutPOST is the handler logging out the user attached to the given cookie.
@@ -25,16 +26,28 @@ func LogoutPOST(ctx *middlewares.AutheliaCtx) {
 		ctx.Error(fmt.Errorf("unable to parse body during logout: %w", err), messageOperationFailed)
 	}
 
-	err = ctx.DestroySession()
-	if err != nil {
-		ctx.Error(fmt.Errorf("unable to destroy session during logout: %w", err), messageOperationFailed)
-	}
-
 	redirectionURL, err := url.ParseRequestURI(body.TargetURL)
 	if err == nil {
 		responseBody.SafeTargetURL = ctx.IsSafeRedirectionTargetURI(redirectionURL)
 	}
 
+	if userSession, err := ctx.GetSession(); err == nil {
+		location := ctx.RootURLSlash()
+
+		if responseBody.SafeTargetURL {
+			location = redirectionURL
+		}
+
+		if redirect := oidcLogoutPropagate(ctx, userSession, location); redirect != location {
+			responseBody.FrontChannelLogoutURL = redirect.String()
+		}
+	}
+
+	err = ctx.DestroySession()
+	if err != nil {
+		ctx.Error(fmt.Errorf("unable to destroy session during logout: %w", err), messageOperationFailed)
+	}
+
 	if body.TargetURL != "" {
 		ctx.Logger.Debugf("Logout target url is %s, safe %t", body.TargetURL, responseBody.SafeTargetURL)
 	}
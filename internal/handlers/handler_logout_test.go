@@ -1,13 +1,24 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
 	"strings"
 	"testing"
 
+	oauthelia2 "authelia.com/provider/oauth2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/storage"
 )
 
 type LogoutSuite struct {
@@ -45,3 +56,70 @@ func TestRunLogoutSuite(t *testing.T) {
 	s := new(LogoutSuite)
 	suite.Run(t, s)
 }
+
+func TestLogoutPOSTShouldRevokeOpenIDConnectTokens(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	defer mock.Close()
+
+	mock.Ctx.Providers.OpenIDConnect = oidc.NewOpenIDConnectProvider(&schema.IdentityProvidersOpenIDConnect{
+		HMACSecret: "asbdhaaskmdlkamdklasmdlkams",
+		Clients: []schema.IdentityProvidersOpenIDConnectClient{
+			{
+				ID:         "app",
+				Scopes:     []string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess},
+				GrantTypes: []string{oidc.GrantTypeAuthorizationCode, oidc.GrantTypeRefreshToken},
+			},
+		},
+	}, mock.StorageMock, nil)
+
+	data, err := json.Marshal(oidc.NewSession())
+
+	require.NoError(t, err)
+
+	active := true
+
+	mock.StorageMock.EXPECT().
+		LoadOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeRefreshToken, "refresh").
+		DoAndReturn(func(_ context.Context, _ storage.OAuth2SessionType, signature string) (*model.OAuth2Session, error) {
+			return &model.OAuth2Session{RequestID: "req", ClientID: "app", Signature: signature, Active: active, Session: data}, nil
+		}).
+		Times(2)
+
+	gomock.InOrder(
+		mock.StorageMock.EXPECT().RevokeOAuth2SessionByRequestID(gomock.Any(), storage.OAuth2SessionTypeAccessToken, "req").Return(nil),
+		mock.StorageMock.EXPECT().
+			DeactivateOAuth2SessionByRequestID(gomock.Any(), storage.OAuth2SessionTypeRefreshToken, "req").
+			DoAndReturn(func(_ context.Context, _ storage.OAuth2SessionType, _ string) error {
+				active = false
+
+				return nil
+			}),
+	)
+
+	_, err = mock.Ctx.Providers.OpenIDConnect.GetRefreshTokenSession(mock.Ctx, "refresh", oidc.NewSession())
+
+	require.NoError(t, err)
+
+	provider, err := mock.Ctx.GetSessionProvider()
+
+	require.NoError(t, err)
+
+	userSession, err := provider.GetSession(mock.Ctx.RequestCtx)
+
+	require.NoError(t, err)
+
+	userSession.Username = testUsername
+	userSession.AuthenticationLevel = authentication.OneFactor
+	userSession.OpenIDConnect = &session.OpenIDConnect{SessionID: "sid", RequestIDs: []string{"req"}}
+
+	require.NoError(t, provider.SaveSession(mock.Ctx.RequestCtx, userSession))
+
+	mock.Ctx.Request.SetBodyString(`{}`)
+
+	LogoutPOST(mock.Ctx)
+
+	_, err = mock.Ctx.Providers.OpenIDConnect.GetRefreshTokenSession(mock.Ctx, "refresh", oidc.NewSession())
+
+	assert.ErrorIs(t, err, oauthelia2.ErrInactiveToken)
+}
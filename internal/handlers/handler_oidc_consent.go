@@ -87,7 +87,7 @@ func OpenIDConnectConsentPOST(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
-	if !client.IsAuthenticationLevelSufficient(userSession.AuthenticationLevel, authorization.Subject{Username: userSession.Username, Groups: userSession.Groups, IP: ctx.RemoteIP()}) {
+	if !client.IsAuthenticationLevelSufficient(userSession.AuthenticationLevel, authorization.Subject{Username: userSession.Username, Groups: userSession.Groups, Emails: userSession.Emails, Attributes: userSession.Attributes, IP: ctx.RemoteIP()}) {
 		ctx.Logger.Errorf("User '%s' can't consent to authorization request for client with id '%s' as they are not sufficiently authenticated",
 			userSession.Username, consent.ClientID)
 		ctx.SetJSONError(messageOperationFailed)
@@ -95,11 +95,23 @@ func OpenIDConnectConsentPOST(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
+	var query url.Values
+
+	if query, err = url.ParseQuery(consent.Form); err != nil {
+		ctx.Logger.Errorf("Failed to parse the consent form values: %+v", err)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
 	if bodyJSON.Consent {
 		consent.Grant()
 
 		if bodyJSON.PreConfigure {
-			if client.GetConsentPolicy().Mode == oidc.ClientConsentModePreConfigured {
+			switch {
+			case query.Has(oidc.FormParameterUserCode):
+				ctx.Logger.Warnf("Consent session with id '%s' for user '%s': consent pre-configuration was requested and was ignored because it is not permitted for device authorization requests", consent.ChallengeID, userSession.Username)
+			case client.GetConsentPolicy().Mode == oidc.ClientConsentModePreConfigured:
 				config := model.OAuth2ConsentPreConfig{
 					ClientID:  consent.ClientID,
 					Subject:   consent.Subject.UUID,
@@ -121,7 +133,7 @@ func OpenIDConnectConsentPOST(ctx *middlewares.AutheliaCtx) {
 				consent.PreConfiguration = sql.NullInt64{Int64: id, Valid: true}
 
 				ctx.Logger.Debugf("Consent session with id '%s' for user '%s': pre-configured and set to expire at %v", consent.ChallengeID, userSession.Username, config.ExpiresAt.Time)
-			} else {
+			default:
 				ctx.Logger.Warnf("Consent session with id '%s' for user '%s': consent pre-configuration was requested and was ignored because it is not permitted on this client", consent.ChallengeID, userSession.Username)
 			}
 		}
@@ -134,23 +146,16 @@ func OpenIDConnectConsentPOST(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
-	var (
-		redirectURI *url.URL
-		query       url.Values
-	)
-
-	redirectURI = ctx.RootURL()
+	redirectURI := ctx.RootURL()
 
-	if query, err = url.ParseQuery(consent.Form); err != nil {
-		ctx.Logger.Errorf("Failed to parse the consent form values: %+v", err)
-		ctx.SetJSONError(messageOperationFailed)
+	query.Set(queryArgConsentID, consent.ChallengeID.String())
 
-		return
+	if query.Has(oidc.FormParameterUserCode) {
+		redirectURI.Path = path.Join(redirectURI.Path, oidc.EndpointPathRFC8628UserVerificationURL)
+	} else {
+		redirectURI.Path = path.Join(redirectURI.Path, oidc.EndpointPathAuthorization)
 	}
 
-	query.Set(queryArgConsentID, consent.ChallengeID.String())
-
-	redirectURI.Path = path.Join(redirectURI.Path, oidc.EndpointPathAuthorization)
 	redirectURI.RawQuery = query.Encode()
 
 	response := oidc.ConsentPostResponseBody{RedirectURI: redirectURI.String()}
@@ -215,7 +220,7 @@ func handleOpenIDConnectConsentGetSessionsAndClient(ctx *middlewares.AutheliaCtx
 		}
 	}
 
-	if !client.IsAuthenticationLevelSufficient(userSession.AuthenticationLevel, authorization.Subject{Username: userSession.Username, Groups: userSession.Groups, IP: ctx.RemoteIP()}) {
+	if !client.IsAuthenticationLevelSufficient(userSession.AuthenticationLevel, authorization.Subject{Username: userSession.Username, Groups: userSession.Groups, Emails: userSession.Emails, Attributes: userSession.Attributes, IP: ctx.RemoteIP()}) {
 		ctx.Logger.Errorf("Unable to perform OpenID Connect Consent for user '%s' and client id '%s': the user is not sufficiently authenticated", userSession.Username, consent.ClientID)
 		ctx.ReplyForbidden()
 
@@ -0,0 +1,444 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	oauthelia2 "authelia.com/provider/oauth2"
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/random"
+	"github.com/authelia/authelia/v4/internal/regulation"
+	"github.com/authelia/authelia/v4/internal/session"
+)
+
+// OpenIDConnectDeviceAuthorizationPOST handles POST requests to the OAuth 2.0 Device Authorization endpoint.
+//
+// https://datatracker.ietf.org/doc/html/rfc8628#section-3.1
+func OpenIDConnectDeviceAuthorizationPOST(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, r *http.Request) {
+	var (
+		request  *oauthelia2.Request
+		response *oidc.DeviceAuthorizationResponse
+		body     []byte
+		err      error
+	)
+
+	if request, err = ctx.Providers.OpenIDConnect.NewDeviceAuthorizationRequest(ctx, r); err != nil {
+		ctx.Logger.Errorf("Device Authorization Request failed with error: %s", oauthelia2.ErrorToDebugRFC6749Error(err))
+
+		ctx.Providers.OpenIDConnect.WriteAccessError(ctx, rw, nil, err)
+
+		return
+	}
+
+	ctx.Logger.Debugf("Device Authorization Request with id '%s' on client with id '%s' is being processed", request.GetID(), request.GetClient().GetID())
+
+	if response, err = ctx.Providers.OpenIDConnect.NewDeviceAuthorizationResponse(ctx, request); err != nil {
+		ctx.Logger.Errorf("Device Authorization Response for Request with id '%s' failed to be created with error: %s", request.GetID(), oauthelia2.ErrorToDebugRFC6749Error(err))
+
+		ctx.Providers.OpenIDConnect.WriteAccessError(ctx, rw, nil, err)
+
+		return
+	}
+
+	if body, err = json.Marshal(response); err != nil {
+		ctx.Logger.Errorf("Device Authorization Response for Request with id '%s' failed to be encoded with error: %+v", request.GetID(), err)
+
+		ctx.Providers.OpenIDConnect.WriteAccessError(ctx, rw, nil, oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+
+		return
+	}
+
+	ctx.Logger.Debugf("Device Authorization Request with id '%s' on client with id '%s' has successfully been processed", request.GetID(), request.GetClient().GetID())
+
+	rw.Header().Set(fasthttp.HeaderContentType, "application/json; charset=utf-8")
+	rw.Header().Set(fasthttp.HeaderCacheControl, "no-store")
+	rw.Header().Set(fasthttp.HeaderPragma, "no-cache")
+	rw.WriteHeader(http.StatusOK)
+
+	_, _ = rw.Write(body)
+}
+
+// OpenIDConnectDeviceCodeUserVerificationGET handles the End-User entering the user code of a Device Authorization
+// Request. The End-User is sent through the usual authentication and consent flows and the Device Authorization
+// Request is marked as authorized or denied once these have completed. The End-User must be authenticated before the
+// user code is checked so unsuccessful attempts can be regulated.
+//
+// https://datatracker.ietf.org/doc/html/rfc8628#section-3.3
+//
+//nolint:gocyclo
+func OpenIDConnectDeviceCodeUserVerificationGET(ctx *middlewares.AutheliaCtx) {
+	var (
+		issuer      *url.URL
+		device      *model.OAuth2DeviceCodeSession
+		request     *oauthelia2.Request
+		client      oidc.Client
+		userSession session.UserSession
+		subject     uuid.UUID
+		consent     *model.OAuth2ConsentSession
+		handled     bool
+		err         error
+	)
+
+	if issuer, err = ctx.IssuerURL(); err != nil {
+		ctx.Logger.WithError(err).Error("Device Code User Verification could not be processed: error occurred determining the issuer")
+		ctx.ReplyBadRequest()
+
+		return
+	}
+
+	userCode := string(ctx.QueryArgs().Peek(oidc.FormParameterUserCode))
+
+	if userCode == "" {
+		handleOIDCDeviceCodeRedirect(ctx, issuer, "", "")
+
+		return
+	}
+
+	if userSession, err = ctx.GetSession(); err != nil {
+		ctx.Logger.WithError(err).Error("Device Code User Verification could not be processed: error occurred obtaining session information")
+		ctx.ReplyBadRequest()
+
+		return
+	}
+
+	if userSession.IsAnonymous() {
+		ctx.Logger.Debug("Device Code User Verification requires the user to authenticate")
+
+		ctx.SpecialRedirect(handleOIDCDeviceCodeGetAuthenticationURL(ctx, issuer).String(), fasthttp.StatusFound)
+
+		return
+	}
+
+	if handled = handleOIDCDeviceCodeRegulate(ctx, issuer, userSession, userCode); handled {
+		return
+	}
+
+	if device, err = ctx.Providers.OpenIDConnect.LoadDeviceCodeSessionByUserCode(ctx, userCode); err != nil {
+		if errors.Is(err, oauthelia2.ErrNotFound) {
+			_ = markAuthenticationAttempt(ctx, false, nil, userSession.Username, regulation.AuthTypeUserCode, nil)
+		} else {
+			ctx.Logger.WithError(err).Error("Device Code User Verification could not be processed: error occurred loading the device code session")
+		}
+
+		handleOIDCDeviceCodeRedirect(ctx, issuer, userCode, deviceCodeStatusInvalid)
+
+		return
+	}
+
+	if !device.Active || device.Revoked || device.Status != model.OAuth2DeviceCodeStatusPending || device.IsExpired(ctx.Clock.Now()) {
+		ctx.Logger.Debugf("Device Code User Verification for Request with id '%s' on client with id '%s' could not be processed: the device code session is no longer pending", device.RequestID, device.ClientID)
+
+		handleOIDCDeviceCodeRedirect(ctx, issuer, userCode, deviceCodeStatusInvalid)
+
+		return
+	}
+
+	if client, err = ctx.Providers.OpenIDConnect.GetRegisteredClient(ctx, device.ClientID); err != nil {
+		ctx.Logger.WithError(err).Errorf("Device Code User Verification for Request with id '%s' on client with id '%s' could not be processed: failed to find client", device.RequestID, device.ClientID)
+
+		handleOIDCDeviceCodeRedirect(ctx, issuer, userCode, deviceCodeStatusInvalid)
+
+		return
+	}
+
+	if request, err = device.ToRequest(ctx, oidc.NewSession(), ctx.Providers.OpenIDConnect.Store); err != nil {
+		ctx.Logger.WithError(err).Errorf("Device Code User Verification for Request with id '%s' on client with id '%s' could not be processed: failed to restore the request", device.RequestID, client.GetID())
+
+		handleOIDCDeviceCodeRedirect(ctx, issuer, userCode, deviceCodeStatusInvalid)
+
+		return
+	}
+
+	policy := client.GetAuthorizationPolicy()
+	level := policy.GetRequiredLevel(authorization.Subject{Username: userSession.Username, Groups: userSession.Groups, Emails: userSession.Emails, Attributes: userSession.Attributes, IP: ctx.RemoteIP()})
+
+	switch {
+	case !authorization.IsAuthLevelSufficient(userSession.AuthenticationLevel, level) && level != authorization.Denied:
+		ctx.Logger.Debugf("Device Code User Verification for Request with id '%s' on client with id '%s' requires the user to authenticate", request.GetID(), client.GetID())
+
+		ctx.SpecialRedirect(handleOIDCDeviceCodeGetAuthenticationURL(ctx, issuer).String(), fasthttp.StatusFound)
+
+		return
+	case level == authorization.Denied:
+		ctx.Logger.Errorf("Device Code User Verification for Request with id '%s' on client with id '%s' could not be processed: the user '%s' is not authorized to use this client", request.GetID(), client.GetID(), userSession.Username)
+
+		handleOIDCDeviceCodeDeny(ctx, issuer, device, userCode)
+
+		return
+	}
+
+	if subject, err = ctx.Providers.OpenIDConnect.GetSubject(ctx, client.GetSectorIdentifierURI(), userSession.Username); err != nil {
+		ctx.Logger.WithError(err).Errorf("Device Code User Verification for Request with id '%s' on client with id '%s' could not be processed: error occurred retrieving subject identifier for user '%s'", request.GetID(), client.GetID(), userSession.Username)
+
+		handleOIDCDeviceCodeRedirect(ctx, issuer, userCode, deviceCodeStatusInvalid)
+
+		return
+	}
+
+	if consent, handled = handleOIDCDeviceCodeConsent(ctx, issuer, client, subject, device, request, userCode); handled {
+		return
+	}
+
+	handleOIDCDeviceCodeAuthorize(ctx, issuer, client, userSession, device, request, consent, userCode)
+}
+
+// handleOIDCDeviceCodeRegulate prevents the user codes being guessed by applying the regulation to the user which is
+// entering them, as recommended by RFC8628 Section 5.1. Each user code which is not found is marked as an unsuccessful
+// attempt.
+func handleOIDCDeviceCodeRegulate(ctx *middlewares.AutheliaCtx, issuer *url.URL, userSession session.UserSession, userCode string) (handled bool) {
+	bannedUntil, err := ctx.Providers.Regulator.Regulate(ctx, userSession.Username)
+
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, regulation.ErrUserIsBanned):
+		_ = markAuthenticationAttempt(ctx, false, &bannedUntil, userSession.Username, regulation.AuthTypeUserCode, nil)
+	default:
+		ctx.Logger.WithError(err).Errorf(logFmtErrRegulationFail, regulation.AuthTypeUserCode, userSession.Username)
+	}
+
+	handleOIDCDeviceCodeRedirect(ctx, issuer, userCode, deviceCodeStatusInvalid)
+
+	return true
+}
+
+func handleOIDCDeviceCodeConsent(ctx *middlewares.AutheliaCtx, issuer *url.URL, client oidc.Client, subject uuid.UUID,
+	device *model.OAuth2DeviceCodeSession, request *oauthelia2.Request, userCode string) (consent *model.OAuth2ConsentSession, handled bool) {
+	var err error
+
+	if bytesConsentID := ctx.QueryArgs().PeekBytes(qryArgConsentID); len(bytesConsentID) != 0 {
+		var (
+			consentID uuid.UUID
+			form      url.Values
+		)
+
+		if consentID, err = uuid.ParseBytes(bytesConsentID); err != nil || consentID == uuid.Nil {
+			ctx.Logger.Errorf("Device Code User Verification for Request with id '%s' on client with id '%s' could not be processed: the consent id '%s' is malformed", request.GetID(), client.GetID(), bytesConsentID)
+
+			handleOIDCDeviceCodeRedirect(ctx, issuer, userCode, deviceCodeStatusInvalid)
+
+			return nil, true
+		}
+
+		if consent, err = ctx.Providers.StorageProvider.LoadOAuth2ConsentSessionByChallengeID(ctx, consentID); err != nil {
+			ctx.Logger.WithError(err).Errorf("Device Code User Verification for Request with id '%s' on client with id '%s' could not be processed: error occurred loading consent session with id '%s'", request.GetID(), client.GetID(), consentID)
+
+			handleOIDCDeviceCodeRedirect(ctx, issuer, userCode, deviceCodeStatusInvalid)
+
+			return nil, true
+		}
+
+		if consent.ClientID != client.GetID() || consent.Subject.UUID != subject {
+			ctx.Logger.Errorf("Device Code User Verification for Request with id '%s' on client with id '%s' could not be processed: the consent session with id '%s' does not belong to this client and user", request.GetID(), client.GetID(), consentID)
+
+			handleOIDCDeviceCodeRedirect(ctx, issuer, userCode, deviceCodeStatusInvalid)
+
+			return nil, true
+		}
+
+		if form, err = consent.GetForm(); err != nil || form.Get(oidc.FormParameterUserCode) != userCode {
+			ctx.Logger.Errorf("Device Code User Verification for Request with id '%s' on client with id '%s' could not be processed: the consent session with id '%s' was not issued for this user code", request.GetID(), client.GetID(), consentID)
+
+			handleOIDCDeviceCodeRedirect(ctx, issuer, userCode, deviceCodeStatusInvalid)
+
+			return nil, true
+		}
+
+		switch {
+		case !consent.CanGrant():
+			ctx.Logger.Errorf("Device Code User Verification for Request with id '%s' on client with id '%s' could not be processed: the consent session with id '%s' cannot be granted", request.GetID(), client.GetID(), consentID)
+
+			handleOIDCDeviceCodeRedirect(ctx, issuer, userCode, deviceCodeStatusInvalid)
+
+			return nil, true
+		case consent.IsAuthorized():
+			return consent, false
+		case consent.Responded():
+			ctx.Logger.Debugf("Device Code User Verification for Request with id '%s' on client with id '%s' was rejected by the user during consent", request.GetID(), client.GetID())
+
+			handleOIDCDeviceCodeDeny(ctx, issuer, device, userCode)
+
+			return nil, true
+		default:
+			handleOIDCDeviceCodeConsentRedirect(ctx, issuer, consent)
+
+			return nil, true
+		}
+	}
+
+	if consent, err = model.NewOAuth2ConsentSessionWithForm(subject, request, url.Values{oidc.FormParameterUserCode: []string{userCode}}); err != nil {
+		ctx.Logger.WithError(err).Errorf("Device Code User Verification for Request with id '%s' on client with id '%s' could not be processed: error occurred generating consent session", request.GetID(), client.GetID())
+
+		handleOIDCDeviceCodeRedirect(ctx, issuer, userCode, deviceCodeStatusInvalid)
+
+		return nil, true
+	}
+
+	// The consent mode of the client is deliberately not considered here. The user code is typed in by the End-User
+	// and may have been supplied by an attacker, so the Device Authorization Request is only ever authorized after the
+	// End-User has explicitly confirmed the client and user code on the consent page.
+	return handleOIDCDeviceCodeConsentGenerate(ctx, issuer, client, request, consent, userCode)
+}
+
+func handleOIDCDeviceCodeConsentGenerate(ctx *middlewares.AutheliaCtx, issuer *url.URL, client oidc.Client,
+	request *oauthelia2.Request, consent *model.OAuth2ConsentSession, userCode string) (_ *model.OAuth2ConsentSession, handled bool) {
+	if err := ctx.Providers.StorageProvider.SaveOAuth2ConsentSession(ctx, *consent); err != nil {
+		ctx.Logger.WithError(err).Errorf("Device Code User Verification for Request with id '%s' on client with id '%s' could not be processed: error occurred saving consent session", request.GetID(), client.GetID())
+
+		handleOIDCDeviceCodeRedirect(ctx, issuer, userCode, deviceCodeStatusInvalid)
+
+		return nil, true
+	}
+
+	handleOIDCDeviceCodeConsentRedirect(ctx, issuer, consent)
+
+	return nil, true
+}
+
+func handleOIDCDeviceCodeAuthorize(ctx *middlewares.AutheliaCtx, issuer *url.URL, client oidc.Client, userSession session.UserSession,
+	device *model.OAuth2DeviceCodeSession, request *oauthelia2.Request, consent *model.OAuth2ConsentSession, userCode string) {
+	var (
+		details  *authentication.UserDetails
+		authTime time.Time
+		data     []byte
+		err      error
+	)
+
+	if details, err = ctx.Providers.UserProvider.GetDetails(userSession.Username); err != nil {
+		ctx.Logger.WithError(err).Errorf("Device Code User Verification for Request with id '%s' on client with id '%s' could not be processed: error occurred retrieving user details for '%s' from the backend", request.GetID(), client.GetID(), userSession.Username)
+
+		handleOIDCDeviceCodeRedirect(ctx, issuer, userCode, deviceCodeStatusInvalid)
+
+		return
+	}
+
+	if authTime, err = userSession.AuthenticatedTime(client.GetAuthorizationPolicyRequiredLevel(authorization.Subject{Username: details.Username, Groups: details.Groups, Emails: details.Emails, Attributes: details.Attributes, IP: ctx.RemoteIP()})); err != nil {
+		ctx.Logger.WithError(err).Errorf("Device Code User Verification for Request with id '%s' on client with id '%s' could not be processed: error occurred checking authentication time", request.GetID(), client.GetID())
+
+		handleOIDCDeviceCodeRedirect(ctx, issuer, userCode, deviceCodeStatusInvalid)
+
+		return
+	}
+
+	requester := &oauthelia2.AuthorizeRequest{Request: *request}
+
+	extraClaims := oidcGrantRequests(requester, consent, details)
+
+	sid := userSession.SetOpenIDConnectClient(ctx.Providers.Random.StringCustom(32, random.CharSetAlphaNumeric), client.GetID(), request.GetID())
+
+	if err = ctx.SaveSession(userSession); err != nil {
+		ctx.Logger.WithError(err).Errorf("Device Code User Verification for Request with id '%s' on client with id '%s' could not be processed: error occurred saving session information", request.GetID(), client.GetID())
+
+		handleOIDCDeviceCodeRedirect(ctx, issuer, userCode, deviceCodeStatusInvalid)
+
+		return
+	}
+
+	session := oidc.NewSessionWithAuthorizeRequest(ctx, issuer, ctx.Providers.OpenIDConnect.KeyManager.GetKeyID(ctx, client.GetIDTokenSignedResponseKeyID(), client.GetIDTokenSignedResponseAlg()), details.Username, userSession.AuthenticationMethodRefs.MarshalRFC8176(), extraClaims, authTime, consent, requester)
+
+	session.Claims.Add(oidc.ClaimSessionID, sid)
+
+	if data, err = json.Marshal(session); err != nil {
+		ctx.Logger.WithError(err).Errorf("Device Code User Verification for Request with id '%s' on client with id '%s' could not be processed: error occurred encoding the session", request.GetID(), client.GetID())
+
+		handleOIDCDeviceCodeRedirect(ctx, issuer, userCode, deviceCodeStatusInvalid)
+
+		return
+	}
+
+	device.Status = model.OAuth2DeviceCodeStatusAuthorized
+	device.SetSubject(consent.Subject.UUID.String())
+	device.GrantedScopes = model.StringSlicePipeDelimited(requester.GetGrantedScopes())
+	device.GrantedAudience = model.StringSlicePipeDelimited(requester.GetGrantedAudience())
+	device.Session = data
+
+	if err = ctx.Providers.OpenIDConnect.UpdateDeviceCodeSession(ctx, device); err != nil {
+		ctx.Logger.WithError(err).Errorf("Device Code User Verification for Request with id '%s' on client with id '%s' could not be processed: error occurred saving the device code session", request.GetID(), client.GetID())
+
+		handleOIDCDeviceCodeRedirect(ctx, issuer, userCode, deviceCodeStatusInvalid)
+
+		return
+	}
+
+	if err = ctx.Providers.StorageProvider.SaveOAuth2ConsentSessionGranted(ctx, consent.ID); err != nil {
+		ctx.Logger.WithError(err).Errorf("Device Code User Verification for Request with id '%s' on client with id '%s' could not be processed: error occurred saving consent session", request.GetID(), client.GetID())
+
+		handleOIDCDeviceCodeRedirect(ctx, issuer, userCode, deviceCodeStatusInvalid)
+
+		return
+	}
+
+	ctx.Logger.Debugf("Device Code User Verification for Request with id '%s' on client with id '%s' was successfully authorized by user '%s'", request.GetID(), client.GetID(), userSession.Username)
+
+	handleOIDCDeviceCodeRedirect(ctx, issuer, "", deviceCodeStatusAuthorized)
+}
+
+func handleOIDCDeviceCodeDeny(ctx *middlewares.AutheliaCtx, issuer *url.URL, device *model.OAuth2DeviceCodeSession, userCode string) {
+	device.Status = model.OAuth2DeviceCodeStatusDenied
+
+	if err := ctx.Providers.OpenIDConnect.UpdateDeviceCodeSession(ctx, device); err != nil {
+		ctx.Logger.WithError(err).Errorf("Device Code User Verification for Request with id '%s' on client with id '%s' could not be processed: error occurred saving the device code session", device.RequestID, device.ClientID)
+
+		handleOIDCDeviceCodeRedirect(ctx, issuer, userCode, deviceCodeStatusInvalid)
+
+		return
+	}
+
+	handleOIDCDeviceCodeRedirect(ctx, issuer, "", deviceCodeStatusDenied)
+}
+
+func handleOIDCDeviceCodeConsentRedirect(ctx *middlewares.AutheliaCtx, issuer *url.URL, consent *model.OAuth2ConsentSession) {
+	location, _ := url.ParseRequestURI(issuer.String())
+	location.Path = path.Join(location.Path, oidc.EndpointPathConsent)
+	location.RawQuery = url.Values{queryArgID: []string{consent.ChallengeID.String()}}.Encode()
+
+	ctx.SpecialRedirect(location.String(), fasthttp.StatusFound)
+}
+
+// handleOIDCDeviceCodeGetAuthenticationURL returns the portal URL which authenticates the End-User and then returns them
+// to the current user verification request.
+func handleOIDCDeviceCodeGetAuthenticationURL(ctx *middlewares.AutheliaCtx, issuer *url.URL) (location *url.URL) {
+	location, _ = url.ParseRequestURI(issuer.String())
+	location.Path = path.Join(location.Path, "/")
+
+	rd, _ := url.ParseRequestURI(issuer.String())
+	rd.Path = path.Join(rd.Path, oidc.EndpointPathRFC8628UserVerificationURL)
+	rd.RawQuery = string(ctx.QueryArgs().QueryString())
+
+	location.RawQuery = url.Values{
+		queryArgWorkflow: []string{workflowOpenIDConnect},
+		queryArgRD:       []string{rd.String()},
+	}.Encode()
+
+	return location
+}
+
+func handleOIDCDeviceCodeRedirect(ctx *middlewares.AutheliaCtx, issuer *url.URL, userCode, status string) {
+	location, _ := url.ParseRequestURI(issuer.String())
+	location.Path = path.Join(location.Path, oidc.EndpointPathDeviceCode)
+
+	query := url.Values{}
+
+	if userCode != "" {
+		query.Set(oidc.FormParameterUserCode, userCode)
+	}
+
+	if status != "" {
+		query.Set(queryArgStatus, status)
+	}
+
+	location.RawQuery = query.Encode()
+
+	ctx.SpecialRedirect(location.String(), fasthttp.StatusFound)
+}
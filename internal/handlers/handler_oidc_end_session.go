@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/session"
+)
+
+// OpenIDConnectEndSession handles GET/POST requests to the OpenID Connect 1.0 RP-Initiated Logout endpoint. The session
+// is only destroyed without confirmation from the End-User when the id_token_hint was issued to the End-User who owns
+// the current session, otherwise the End-User is redirected to the logout confirmation page.
+//
+// https://openid.net/specs/openid-connect-rpinitiated-1_0.html#RPLogout
+func OpenIDConnectEndSession(ctx *middlewares.AutheliaCtx) {
+	var (
+		issuer      *url.URL
+		hint        *oidc.IDTokenHint
+		client      oidc.Client
+		location    *url.URL
+		userSession session.UserSession
+		err         error
+	)
+
+	if issuer, err = ctx.IssuerURL(); err != nil {
+		ctx.Logger.WithError(err).Error("End Session Request could not be processed: error occurred determining the issuer")
+		ctx.ReplyBadRequest()
+
+		return
+	}
+
+	clientID := string(ctx.FormValue(oidc.FormParameterClientID))
+	redirectURI := string(ctx.FormValue(oidc.FormParameterPostLogoutRedirectURI))
+	state := string(ctx.FormValue(oidc.FormParameterState))
+
+	if rawHint := string(ctx.FormValue(oidc.FormParameterIDTokenHint)); rawHint != "" {
+		if hint, err = ctx.Providers.OpenIDConnect.KeyManager.ValidateIDTokenHint(ctx, rawHint, issuer.String()); err != nil {
+			ctx.Logger.WithError(err).Error("End Session Request could not be processed: error occurred validating the id_token_hint")
+			ctx.ReplyBadRequest()
+
+			return
+		}
+	}
+
+	if client, err = handleOIDCEndSessionGetClient(ctx, clientID, hint); err != nil {
+		ctx.Logger.WithError(err).Error("End Session Request could not be processed: error occurred determining the client")
+		ctx.ReplyBadRequest()
+
+		return
+	}
+
+	if location, err = handleOIDCEndSessionGetRedirectURI(ctx, client, redirectURI, state); err != nil {
+		ctx.Logger.WithError(err).Error("End Session Request could not be processed: error occurred validating the post_logout_redirect_uri")
+		ctx.ReplyBadRequest()
+
+		return
+	}
+
+	if userSession, err = ctx.GetSession(); err != nil {
+		ctx.Logger.WithError(err).Error("End Session Request could not be processed: error occurred retrieving the user session")
+		ctx.ReplyBadRequest()
+
+		return
+	}
+
+	switch {
+	case userSession.IsAnonymous():
+		ctx.Logger.Debug("End Session Request does not have an authenticated user session to destroy")
+	case handleOIDCEndSessionIsSubject(ctx, client, hint, userSession):
+		location = oidcLogoutPropagate(ctx, userSession, location)
+
+		if err = ctx.DestroySession(); err != nil {
+			ctx.Logger.WithError(err).Errorf("End Session Request for user '%s' could not be processed: error occurred destroying the user session", userSession.Username)
+			ctx.ReplyBadRequest()
+
+			return
+		}
+
+		ctx.Logger.Debugf("End Session Request for user '%s' destroyed the user session", userSession.Username)
+	default:
+		location = issuer.JoinPath(oidc.EndpointPathLogoutConfirmation)
+
+		query := url.Values{}
+
+		if client != nil {
+			query.Set(oidc.FormParameterClientID, client.GetID())
+		}
+
+		if redirectURI != "" {
+			query.Set(oidc.FormParameterPostLogoutRedirectURI, redirectURI)
+		}
+
+		if state != "" {
+			query.Set(oidc.FormParameterState, state)
+		}
+
+		location.RawQuery = query.Encode()
+
+		ctx.Logger.Debugf("End Session Request for user '%s' requires confirmation", userSession.Username)
+	}
+
+	ctx.SpecialRedirect(location.String(), fasthttp.StatusFound)
+}
+
+// OpenIDConnectLogoutGET handles requests to provide the details for the OpenID Connect 1.0 logout confirmation.
+func OpenIDConnectLogoutGET(ctx *middlewares.AutheliaCtx) {
+	var (
+		client oidc.Client
+		body   oidc.LogoutGetResponseBody
+		err    error
+	)
+
+	if client, err = handleOIDCEndSessionGetClient(ctx, string(ctx.QueryArgs().Peek(oidc.FormParameterClientID)), nil); err != nil {
+		ctx.Logger.WithError(err).Error("Logout Confirmation could not be processed: error occurred determining the client")
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if client != nil {
+		body.ClientID, body.ClientDescription = client.GetID(), client.GetName()
+	}
+
+	if err = ctx.SetJSONBody(body); err != nil {
+		ctx.Error(fmt.Errorf("unable to set JSON body: %w", err), messageOperationFailed)
+	}
+}
+
+// OpenIDConnectLogoutPOST handles the End-User confirming the OpenID Connect 1.0 logout.
+func OpenIDConnectLogoutPOST(ctx *middlewares.AutheliaCtx) {
+	var (
+		bodyJSON    oidc.LogoutPostRequestBody
+		client      oidc.Client
+		location    *url.URL
+		userSession session.UserSession
+		err         error
+	)
+
+	if err = json.Unmarshal(ctx.Request.Body(), &bodyJSON); err != nil {
+		ctx.Logger.WithError(err).Error("Logout Confirmation could not be processed: error occurred parsing the body")
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if client, err = handleOIDCEndSessionGetClient(ctx, bodyJSON.ClientID, nil); err != nil {
+		ctx.Logger.WithError(err).Error("Logout Confirmation could not be processed: error occurred determining the client")
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if location, err = handleOIDCEndSessionGetRedirectURI(ctx, client, bodyJSON.PostLogoutRedirectURI, bodyJSON.State); err != nil {
+		ctx.Logger.WithError(err).Error("Logout Confirmation could not be processed: error occurred validating the post_logout_redirect_uri")
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if userSession, err = ctx.GetSession(); err != nil {
+		ctx.Logger.WithError(err).Error("Logout Confirmation could not be processed: error occurred retrieving the user session")
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	location = oidcLogoutPropagate(ctx, userSession, location)
+
+	if err = ctx.DestroySession(); err != nil {
+		ctx.Logger.WithError(err).Error("Logout Confirmation could not be processed: error occurred destroying the user session")
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if err = ctx.SetJSONBody(oidc.LogoutPostResponseBody{RedirectURI: location.String()}); err != nil {
+		ctx.Error(fmt.Errorf("unable to set JSON body: %w", err), messageOperationFailed)
+	}
+}
+
+// handleOIDCEndSessionGetClient returns the client identified by the client id or the id_token_hint. If both are
+// provided the id_token_hint must have been issued to the client. A nil client is returned if neither identify one.
+func handleOIDCEndSessionGetClient(ctx *middlewares.AutheliaCtx, clientID string, hint *oidc.IDTokenHint) (client oidc.Client, err error) {
+	if hint != nil {
+		switch {
+		case clientID == "":
+			clientID = hint.GetClientID()
+		case !hint.IsIssuedTo(clientID):
+			return nil, fmt.Errorf("the id_token_hint was not issued to the client with id '%s'", clientID)
+		}
+	}
+
+	if clientID == "" {
+		return nil, nil
+	}
+
+	if client, err = ctx.Providers.OpenIDConnect.GetRegisteredClient(ctx, clientID); err != nil {
+		return nil, fmt.Errorf("the client with id '%s' could not be found: %w", clientID, err)
+	}
+
+	return client, nil
+}
+
+// handleOIDCEndSessionGetRedirectURI returns the location the End-User is redirected to after logout. This is the
+// post_logout_redirect_uri with the state appended if it's registered for the client, otherwise it's the portal.
+func handleOIDCEndSessionGetRedirectURI(ctx *middlewares.AutheliaCtx, client oidc.Client, redirectURI, state string) (location *url.URL, err error) {
+	if redirectURI == "" {
+		return ctx.RootURLSlash(), nil
+	}
+
+	if client == nil {
+		return nil, fmt.Errorf("the post_logout_redirect_uri '%s' requires the client to be identified by the client_id or id_token_hint", redirectURI)
+	}
+
+	if !client.IsPostLogoutRedirectURIAllowed(redirectURI) {
+		return nil, fmt.Errorf("the post_logout_redirect_uri '%s' is not registered for the client with id '%s'", redirectURI, client.GetID())
+	}
+
+	if location, err = url.ParseRequestURI(redirectURI); err != nil {
+		return nil, fmt.Errorf("the post_logout_redirect_uri '%s' could not be parsed: %w", redirectURI, err)
+	}
+
+	if state != "" {
+		query := location.Query()
+		query.Set(oidc.FormParameterState, state)
+
+		location.RawQuery = query.Encode()
+	}
+
+	return location, nil
+}
+
+// handleOIDCEndSessionIsSubject returns true if the id_token_hint was issued to the user of the user session.
+func handleOIDCEndSessionIsSubject(ctx *middlewares.AutheliaCtx, client oidc.Client, hint *oidc.IDTokenHint, userSession session.UserSession) bool {
+	if hint == nil || client == nil {
+		return false
+	}
+
+	var (
+		subject uuid.UUID
+		err     error
+	)
+
+	if subject, err = ctx.Providers.OpenIDConnect.GetSubject(ctx, client.GetSectorIdentifierURI(), userSession.Username); err != nil {
+		ctx.Logger.WithError(err).Errorf("End Session Request for user '%s' could not determine the subject", userSession.Username)
+
+		return false
+	}
+
+	return subject.String() == hint.Subject
+}
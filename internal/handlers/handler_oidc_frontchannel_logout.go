@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/templates"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// OpenIDConnectFrontChannelLogout handles GET requests to render the OpenID Connect Front-Channel Logout 1.0 iframes
+// for the relying parties of a user session which has already been destroyed, then redirects the End-User.
+//
+// https://openid.net/specs/openid-connect-frontchannel-1_0.html#OPLogout
+func OpenIDConnectFrontChannelLogout(ctx *middlewares.AutheliaCtx) {
+	var (
+		issuer *url.URL
+		state  *oidc.FrontChannelLogoutState
+		client oidc.Client
+		uri    *url.URL
+		err    error
+	)
+
+	if issuer, err = ctx.IssuerURL(); err != nil {
+		ctx.Logger.WithError(err).Error("Front-Channel Logout could not be processed: error occurred determining the issuer")
+		ctx.ReplyBadRequest()
+
+		return
+	}
+
+	if state, err = ctx.Providers.OpenIDConnect.ValidateFrontChannelLogoutState(ctx, string(ctx.QueryArgs().Peek(oidc.FormParameterFrontChannelLogout)), issuer.String()); err != nil {
+		ctx.Logger.WithError(err).Error("Front-Channel Logout could not be processed: error occurred validating the state")
+		ctx.ReplyBadRequest()
+
+		return
+	}
+
+	var (
+		values  = templates.OpenIDConnectFrontChannelLogoutValues{RedirectURI: state.RedirectURI}
+		sources []string
+	)
+
+	for _, clientID := range state.ClientIDs {
+		if client, err = ctx.Providers.OpenIDConnect.GetRegisteredClient(ctx, clientID); err != nil {
+			ctx.Logger.WithError(err).Errorf("Front-Channel Logout could not be propagated to the client with id '%s': error occurred retrieving the client", clientID)
+
+			continue
+		}
+
+		if uri, err = oidc.NewFrontChannelLogoutURI(client, issuer.String(), state.SessionID); err != nil {
+			ctx.Logger.WithError(err).Errorf("Front-Channel Logout could not be propagated to the client with id '%s'", clientID)
+
+			continue
+		}
+
+		if uri == nil {
+			continue
+		}
+
+		values.FrontChannelLogoutURIs = append(values.FrontChannelLogoutURIs, uri.String())
+
+		if source := uri.Scheme + "://" + uri.Host; !utils.IsStringInSlice(source, sources) {
+			sources = append(sources, source)
+		}
+	}
+
+	ctx.SetUserValue(middlewares.UserValueKeyOpenIDConnectFrameSources, sources)
+	ctx.SetContentTypeTextHTML()
+
+	if err = ctx.Providers.Templates.GetOpenIDConnectFrontChannelLogoutTemplate().Execute(ctx.Response.BodyWriter(), values); err != nil {
+		ctx.Logger.WithError(err).Error("Front-Channel Logout could not be processed: error occurred rendering the template")
+		ctx.ReplyStatusCode(fasthttp.StatusInternalServerError)
+	}
+}
+
+// oidcLogoutPropagate propagates the logout of the user session to the relying parties which were issued tokens during
+// the session. The Access Tokens and Refresh Tokens issued during the session are revoked. The Logout Tokens are sent to the Back-Channel Logout URIs in the background. If any of the relying
+// parties have a Front-Channel Logout URI the location of the Front-Channel Logout page which renders them and then
+// redirects to the provided location is returned, otherwise the provided location is returned. The Front-Channel Logout
+// is skipped when the provided location is nil as there is no user agent to render it.
+func oidcLogoutPropagate(ctx *middlewares.AutheliaCtx, userSession session.UserSession, location *url.URL) (redirect *url.URL) {
+	if ctx.Providers.OpenIDConnect == nil || userSession.OpenIDConnect == nil || userSession.IsAnonymous() {
+		return location
+	}
+
+	oidcLogoutRevoke(ctx, userSession)
+
+	var (
+		issuer    *url.URL
+		client    oidc.Client
+		clientIDs []string
+		token     string
+		err       error
+	)
+
+	if issuer, err = ctx.IssuerURL(); err != nil {
+		ctx.Logger.WithError(err).Errorf("Logout for user '%s' could not be propagated to the relying parties: error occurred determining the issuer", userSession.Username)
+
+		return location
+	}
+
+	sid := userSession.OpenIDConnect.SessionID
+
+	for _, clientID := range userSession.OpenIDConnect.Clients {
+		if client, err = ctx.Providers.OpenIDConnect.GetRegisteredClient(ctx, clientID); err != nil {
+			ctx.Logger.WithError(err).Errorf("Logout for user '%s' could not be propagated to the client with id '%s': error occurred retrieving the client", userSession.Username, clientID)
+
+			continue
+		}
+
+		if client.GetBackChannelLogoutURI() != "" {
+			oidcBackChannelLogout(ctx, client, issuer.String(), sid, userSession.Username)
+		}
+
+		if client.GetFrontChannelLogoutURI() != "" {
+			clientIDs = append(clientIDs, clientID)
+		}
+	}
+
+	if len(clientIDs) == 0 || location == nil {
+		return location
+	}
+
+	if token, err = ctx.Providers.OpenIDConnect.GenerateFrontChannelLogoutState(ctx, issuer.String(), sid, clientIDs, location.String(), ctx.Clock.Now()); err != nil {
+		ctx.Logger.WithError(err).Errorf("Logout for user '%s' could not be propagated to the relying parties via the front-channel", userSession.Username)
+
+		return location
+	}
+
+	redirect = issuer.JoinPath(oidc.EndpointPathFrontChannelLogout)
+	redirect.RawQuery = url.Values{oidc.FormParameterFrontChannelLogout: []string{token}}.Encode()
+
+	return redirect
+}
+
+// oidcLogoutRevoke revokes the Access Tokens and Refresh Tokens of the requests which were issued tokens during the user
+// session so they can't be used after the logout.
+func oidcLogoutRevoke(ctx *middlewares.AutheliaCtx, userSession session.UserSession) {
+	var err error
+
+	for _, requestID := range userSession.OpenIDConnect.RequestIDs {
+		if err = ctx.Providers.OpenIDConnect.RevokeAccessToken(ctx, requestID); err != nil {
+			ctx.Logger.WithError(err).Errorf("Logout for user '%s' could not revoke the Access Tokens for the request with id '%s'", userSession.Username, requestID)
+		}
+
+		if err = ctx.Providers.OpenIDConnect.RevokeRefreshToken(ctx, requestID); err != nil {
+			ctx.Logger.WithError(err).Errorf("Logout for user '%s' could not revoke the Refresh Tokens for the request with id '%s'", userSession.Username, requestID)
+		}
+	}
+}
+
+// oidcBackChannelLogout sends the Logout Token to the Back-Channel Logout URI of the client in the background so the
+// retries don't delay the response to the End-User.
+func oidcBackChannelLogout(ctx *middlewares.AutheliaCtx, client oidc.Client, issuer, sid, username string) {
+	var (
+		subject uuid.UUID
+		err     error
+	)
+
+	if subject, err = ctx.Providers.OpenIDConnect.GetSubject(ctx, client.GetSectorIdentifierURI(), username); err != nil {
+		ctx.Logger.WithError(err).Errorf("Logout for user '%s' could not be propagated to the client with id '%s' via the back-channel: error occurred determining the subject", username, client.GetID())
+
+		return
+	}
+
+	provider, logger := ctx.Providers.OpenIDConnect, ctx.Logger
+
+	go func() {
+		c, cancel := context.WithTimeout(context.Background(), oidcBackChannelLogoutTimeout)
+
+		defer cancel()
+
+		if err := provider.SendBackChannelLogout(c, client, issuer, subject.String(), sid); err != nil {
+			logger.WithError(err).Errorf("Logout for user '%s' could not be propagated to the client with id '%s' via the back-channel", username, client.GetID())
+
+			return
+		}
+
+		logger.Debugf("Logout for user '%s' was propagated to the client with id '%s' via the back-channel", username, client.GetID())
+	}()
+}
@@ -0,0 +1,348 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	oauthelia2 "authelia.com/provider/oauth2"
+	"authelia.com/provider/oauth2/x/errorsx"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+// OpenIDConnectDynamicClientRegistrationPOST handles POST requests to the OAuth 2.0 Dynamic Client Registration
+// endpoint. The request must be authorized with the configured initial access token as a bearer token.
+//
+// https://datatracker.ietf.org/doc/html/rfc7591#section-3
+func OpenIDConnectDynamicClientRegistrationPOST(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, r *http.Request) {
+	var (
+		metadata   *oidc.ClientRegistrationMetadata
+		registered *model.OAuth2Client
+		issuer     *url.URL
+		digest     *schema.PasswordDigest
+
+		clientID, secret, token, signature string
+		err                                error
+	)
+
+	expected := ctx.Configuration.IdentityProviders.OIDC.DynamicClientRegistration.InitialAccessToken
+
+	if value := oauthelia2.AccessTokenFromRequest(r); value == "" || subtle.ConstantTimeCompare([]byte(value), []byte(expected)) != 1 {
+		ctx.Logger.Errorf("Dynamic Client Registration Request failed with error: the initial access token is missing or invalid")
+
+		handleOIDCRegistrationError(rw, r, oidc.ErrInvalidToken.WithHint("The initial access token is missing or invalid."))
+
+		return
+	}
+
+	if metadata, err = handleOIDCRegistrationDecodeMetadata(r, nil); err != nil {
+		ctx.Logger.Errorf("Dynamic Client Registration Request failed with error: %s", oauthelia2.ErrorToDebugRFC6749Error(err))
+
+		handleOIDCRegistrationError(rw, r, err)
+
+		return
+	}
+
+	if issuer, err = ctx.IssuerURL(); err != nil {
+		ctx.Logger.Errorf("Dynamic Client Registration Request failed to determine the issuer with error: %+v", err)
+
+		handleOIDCRegistrationError(rw, r, oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+
+		return
+	}
+
+	if clientID, err = oidc.GenerateRegisteredClientID(); err != nil {
+		ctx.Logger.Errorf("Dynamic Client Registration Request failed to generate the client id with error: %+v", err)
+
+		handleOIDCRegistrationError(rw, r, oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+
+		return
+	}
+
+	if !metadata.IsPublic() {
+		if secret, digest, err = oidc.GenerateRegisteredClientSecret(ctx); err != nil {
+			ctx.Logger.Errorf("Dynamic Client Registration Request for client with id '%s' failed to generate the client secret with error: %+v", clientID, err)
+
+			handleOIDCRegistrationError(rw, r, oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+
+			return
+		}
+	}
+
+	if token, err = oidc.GenerateRegistrationAccessToken(ctx); err != nil {
+		ctx.Logger.Errorf("Dynamic Client Registration Request for client with id '%s' failed to generate the registration access token with error: %+v", clientID, err)
+
+		handleOIDCRegistrationError(rw, r, oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+
+		return
+	}
+
+	if signature, err = ctx.Providers.OpenIDConnect.Config.RegistrationAccessTokenSignature(ctx, token); err != nil {
+		ctx.Logger.Errorf("Dynamic Client Registration Request for client with id '%s' failed to sign the registration access token with error: %+v", clientID, err)
+
+		handleOIDCRegistrationError(rw, r, oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+
+		return
+	}
+
+	if registered, err = oidc.NewOAuth2Client(clientID, metadata, digest, signature, ctx.Clock.Now()); err != nil {
+		ctx.Logger.Errorf("Dynamic Client Registration Request for client with id '%s' failed with error: %+v", clientID, err)
+
+		handleOIDCRegistrationError(rw, r, oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+
+		return
+	}
+
+	if err = ctx.Providers.StorageProvider.SaveOAuth2Client(ctx, *registered); err != nil {
+		ctx.Logger.Errorf("Dynamic Client Registration Request for client with id '%s' failed to save the client with error: %+v", clientID, err)
+
+		handleOIDCRegistrationError(rw, r, oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+
+		return
+	}
+
+	ctx.Logger.Infof("Dynamic Client Registration Request has successfully registered the client with id '%s' and name '%s'", clientID, metadata.ClientName)
+
+	handleOIDCRegistrationResponse(ctx, rw, http.StatusCreated, oidc.NewClientRegistrationResponse(issuer, registered, metadata, secret, token))
+}
+
+// OpenIDConnectDynamicClientManagementGET handles GET requests to the OAuth 2.0 Dynamic Client Registration
+// Management client configuration endpoint.
+//
+// https://datatracker.ietf.org/doc/html/rfc7592#section-2.1
+func OpenIDConnectDynamicClientManagementGET(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, r *http.Request) {
+	var (
+		registered *model.OAuth2Client
+		metadata   *oidc.ClientRegistrationMetadata
+		issuer     *url.URL
+		token      string
+		err        error
+	)
+
+	if registered, token, err = handleOIDCRegistrationManagementAuthorize(ctx, r); err != nil {
+		ctx.Logger.Errorf("Dynamic Client Registration Management Read Request failed with error: %s", oauthelia2.ErrorToDebugRFC6749Error(err))
+
+		handleOIDCRegistrationError(rw, r, err)
+
+		return
+	}
+
+	if metadata, err = oidc.NewClientRegistrationMetadataFromOAuth2Client(registered); err != nil {
+		ctx.Logger.Errorf("Dynamic Client Registration Management Read Request for client with id '%s' failed with error: %+v", registered.ClientID, err)
+
+		handleOIDCRegistrationError(rw, r, oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+
+		return
+	}
+
+	if issuer, err = ctx.IssuerURL(); err != nil {
+		ctx.Logger.Errorf("Dynamic Client Registration Management Read Request for client with id '%s' failed to determine the issuer with error: %+v", registered.ClientID, err)
+
+		handleOIDCRegistrationError(rw, r, oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+
+		return
+	}
+
+	handleOIDCRegistrationResponse(ctx, rw, http.StatusOK, oidc.NewClientRegistrationResponse(issuer, registered, metadata, "", token))
+}
+
+// OpenIDConnectDynamicClientManagementPUT handles PUT requests to the OAuth 2.0 Dynamic Client Registration
+// Management client configuration endpoint. The client metadata is replaced entirely by the metadata in the request.
+//
+// https://datatracker.ietf.org/doc/html/rfc7592#section-2.2
+func OpenIDConnectDynamicClientManagementPUT(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, r *http.Request) {
+	var (
+		registered, updated *model.OAuth2Client
+		current, metadata   *oidc.ClientRegistrationMetadata
+		issuer              *url.URL
+		token               string
+		err                 error
+	)
+
+	if registered, token, err = handleOIDCRegistrationManagementAuthorize(ctx, r); err != nil {
+		ctx.Logger.Errorf("Dynamic Client Registration Management Update Request failed with error: %s", oauthelia2.ErrorToDebugRFC6749Error(err))
+
+		handleOIDCRegistrationError(rw, r, err)
+
+		return
+	}
+
+	if current, err = oidc.NewClientRegistrationMetadataFromOAuth2Client(registered); err != nil {
+		ctx.Logger.Errorf("Dynamic Client Registration Management Update Request for client with id '%s' failed with error: %+v", registered.ClientID, err)
+
+		handleOIDCRegistrationError(rw, r, oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+
+		return
+	}
+
+	if metadata, err = handleOIDCRegistrationDecodeMetadata(r, registered); err != nil {
+		ctx.Logger.Errorf("Dynamic Client Registration Management Update Request for client with id '%s' failed with error: %s", registered.ClientID, oauthelia2.ErrorToDebugRFC6749Error(err))
+
+		handleOIDCRegistrationError(rw, r, err)
+
+		return
+	}
+
+	if metadata.IsPublic() != current.IsPublic() {
+		err = oidc.ErrInvalidClientMetadata.WithHintf("The 'token_endpoint_auth_method' value '%s' would change the client type which is not permitted.", metadata.TokenEndpointAuthMethod)
+
+		ctx.Logger.Errorf("Dynamic Client Registration Management Update Request for client with id '%s' failed with error: %s", registered.ClientID, oauthelia2.ErrorToDebugRFC6749Error(err))
+
+		handleOIDCRegistrationError(rw, r, err)
+
+		return
+	}
+
+	if updated, err = oidc.NewOAuth2Client(registered.ClientID, metadata, nil, registered.RegistrationAccessTokenSignature, registered.CreatedAt); err != nil {
+		ctx.Logger.Errorf("Dynamic Client Registration Management Update Request for client with id '%s' failed with error: %+v", registered.ClientID, err)
+
+		handleOIDCRegistrationError(rw, r, oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+
+		return
+	}
+
+	updated.ClientSecret = registered.ClientSecret
+	updated.UpdatedAt = ctx.Clock.Now()
+
+	if err = ctx.Providers.StorageProvider.UpdateOAuth2Client(ctx, *updated); err != nil {
+		ctx.Logger.Errorf("Dynamic Client Registration Management Update Request for client with id '%s' failed to update the client with error: %+v", registered.ClientID, err)
+
+		handleOIDCRegistrationError(rw, r, oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+
+		return
+	}
+
+	if issuer, err = ctx.IssuerURL(); err != nil {
+		ctx.Logger.Errorf("Dynamic Client Registration Management Update Request for client with id '%s' failed to determine the issuer with error: %+v", registered.ClientID, err)
+
+		handleOIDCRegistrationError(rw, r, oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+
+		return
+	}
+
+	ctx.Logger.Infof("Dynamic Client Registration Management Update Request has successfully updated the client with id '%s'", registered.ClientID)
+
+	handleOIDCRegistrationResponse(ctx, rw, http.StatusOK, oidc.NewClientRegistrationResponse(issuer, updated, metadata, "", token))
+}
+
+// OpenIDConnectDynamicClientManagementDELETE handles DELETE requests to the OAuth 2.0 Dynamic Client Registration
+// Management client configuration endpoint.
+//
+// https://datatracker.ietf.org/doc/html/rfc7592#section-2.3
+func OpenIDConnectDynamicClientManagementDELETE(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, r *http.Request) {
+	var (
+		registered *model.OAuth2Client
+		err        error
+	)
+
+	if registered, _, err = handleOIDCRegistrationManagementAuthorize(ctx, r); err != nil {
+		ctx.Logger.Errorf("Dynamic Client Registration Management Delete Request failed with error: %s", oauthelia2.ErrorToDebugRFC6749Error(err))
+
+		handleOIDCRegistrationError(rw, r, err)
+
+		return
+	}
+
+	if err = ctx.Providers.StorageProvider.DeleteOAuth2Client(ctx, registered.ClientID); err != nil {
+		ctx.Logger.Errorf("Dynamic Client Registration Management Delete Request for client with id '%s' failed to delete the client with error: %+v", registered.ClientID, err)
+
+		handleOIDCRegistrationError(rw, r, oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error()))
+
+		return
+	}
+
+	ctx.Logger.Infof("Dynamic Client Registration Management Delete Request has successfully deleted the client with id '%s'", registered.ClientID)
+
+	rw.Header().Set(fasthttp.HeaderCacheControl, "no-store")
+	rw.Header().Set(fasthttp.HeaderPragma, "no-cache")
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// handleOIDCRegistrationManagementAuthorize loads the registered client from the path and ensures the request is
+// authorized with the registration access token issued for that client.
+func handleOIDCRegistrationManagementAuthorize(ctx *middlewares.AutheliaCtx, r *http.Request) (registered *model.OAuth2Client, token string, err error) {
+	clientID, _ := ctx.UserValue("client_id").(string)
+
+	if token = oauthelia2.AccessTokenFromRequest(r); token == "" {
+		return nil, "", oidc.ErrInvalidToken.WithHint("The registration access token is missing.")
+	}
+
+	if registered, err = ctx.Providers.StorageProvider.LoadOAuth2Client(ctx, clientID); err != nil {
+		if errors.Is(err, storage.ErrNoOAuth2Client) {
+			return nil, "", oidc.ErrInvalidToken.WithHint("The registration access token is invalid.").WithDebugf("The client with id '%s' does not exist.", clientID)
+		}
+
+		return nil, "", oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	if !ctx.Providers.OpenIDConnect.Config.IsRegistrationAccessTokenValid(ctx, registered, token) {
+		return nil, "", oidc.ErrInvalidToken.WithHint("The registration access token is invalid.").WithDebugf("The registration access token was not issued for the client with id '%s'.", clientID)
+	}
+
+	return registered, token, nil
+}
+
+// handleOIDCRegistrationDecodeMetadata decodes and validates the client metadata from the request body. When the
+// registered client is provided the request is treated as a client update request which must include the client id.
+func handleOIDCRegistrationDecodeMetadata(r *http.Request, registered *model.OAuth2Client) (metadata *oidc.ClientRegistrationMetadata, err error) {
+	request := struct {
+		ClientID string `json:"client_id"`
+
+		oidc.ClientRegistrationMetadata
+	}{}
+
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, oidc.ErrInvalidClientMetadata.WithHint("The request body could not be decoded.").WithWrap(err).WithDebug(err.Error())
+	}
+
+	if registered != nil && request.ClientID != registered.ClientID {
+		return nil, oidc.ErrInvalidClientMetadata.WithHintf("The 'client_id' value '%s' does not match the client being updated.", request.ClientID)
+	}
+
+	metadata = &request.ClientRegistrationMetadata
+
+	if err = metadata.Validate(); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+func handleOIDCRegistrationResponse(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, status int, response *oidc.ClientRegistrationResponse) {
+	var (
+		body []byte
+		err  error
+	)
+
+	if body, err = json.Marshal(response); err != nil {
+		ctx.Logger.Errorf("Dynamic Client Registration Response for client with id '%s' failed to be encoded with error: %+v", response.ClientID, err)
+
+		rw.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	rw.Header().Set(fasthttp.HeaderContentType, "application/json; charset=utf-8")
+	rw.Header().Set(fasthttp.HeaderCacheControl, "no-store")
+	rw.Header().Set(fasthttp.HeaderPragma, "no-cache")
+	rw.WriteHeader(status)
+
+	_, _ = rw.Write(body)
+}
+
+func handleOIDCRegistrationError(rw http.ResponseWriter, r *http.Request, err error) {
+	if rfc := oauthelia2.ErrorToRFC6749Error(err); rfc.StatusCode() == http.StatusUnauthorized {
+		rw.Header().Set(fasthttp.HeaderWWWAuthenticate, fmt.Sprintf(`Bearer %s`, oidc.RFC6750Header("", "", rfc)))
+	}
+
+	errorsx.WriteJSONError(rw, r, err)
+}
@@ -5,6 +5,8 @@ import (
 
 	oauthelia2 "authelia.com/provider/oauth2"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/oidc"
 )
@@ -51,6 +53,16 @@ func OpenIDConnectTokenPOST(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter
 		}
 	}
 
+	if requester.GetGrantTypes().ExactOne(oidc.GrantTypeTokenExchange) {
+		if err = handleOIDCTokenExchangeAuthorizationPolicy(ctx, client, session); err != nil {
+			ctx.Logger.Errorf("Access Response for Request with id '%s' failed to be created with error: %s", requester.GetID(), oauthelia2.ErrorToDebugRFC6749Error(err))
+
+			ctx.Providers.OpenIDConnect.WriteAccessError(ctx, rw, requester, err)
+
+			return
+		}
+	}
+
 	ctx.Logger.Tracef("Access Request with id '%s' on client with id '%s' response is being generated for session with type '%T'", requester.GetID(), client.GetID(), requester.GetSession())
 
 	if responder, err = ctx.Providers.OpenIDConnect.NewAccessResponse(ctx, requester); err != nil {
@@ -67,3 +79,29 @@ func OpenIDConnectTokenPOST(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter
 
 	ctx.Providers.OpenIDConnect.WriteAccessResponse(ctx, rw, requester, responder)
 }
+
+// handleOIDCTokenExchangeAuthorizationPolicy enforces the authorization policy of the client performing a Token Exchange
+// against the End-User the subject token was issued for. Subject tokens issued via the client credentials grant have no
+// End-User and are not subject to the authorization policy. The request is made by the client rather than the End-User
+// so the subject has no IP, and criteria which match the IP of the End-User never match.
+func handleOIDCTokenExchangeAuthorizationPolicy(ctx *middlewares.AutheliaCtx, client oauthelia2.Client, session *oidc.Session) (err error) {
+	var (
+		c       oidc.Client
+		details *authentication.UserDetails
+		ok      bool
+	)
+
+	if c, ok = client.(oidc.Client); !ok {
+		return oauthelia2.ErrServerError.WithDebug("Failed to get the client for the request.")
+	}
+
+	if session.ClientCredentials || session.Username == "" {
+		return nil
+	}
+
+	if details, err = ctx.Providers.UserProvider.GetDetails(session.Username); err != nil {
+		return oauthelia2.ErrAccessDenied.WithHintf("The user the '%s' was issued for could not be found.", oidc.FormParameterSubjectToken).WithWrap(err).WithDebug(err.Error())
+	}
+
+	return oidc.ValidateTokenExchangeSubjectAuthorization(c, session, authorization.Subject{Username: details.Username, Groups: details.Groups, Emails: details.Emails, Attributes: details.Attributes})
+}
@@ -37,6 +37,14 @@ func oidcApplyScopeClaims(claims map[string]any, scopes []string, detailer oidc.
 		case oidc.ScopeProfile:
 			claims[oidc.ClaimPreferredUsername] = detailer.GetUsername()
 			claims[oidc.ClaimFullName] = detailer.GetDisplayName()
+
+			oidcApplyAttributeClaims(claims, detailer.GetAttributes(), oidcClaimsProfileAttributes...)
+		case oidc.ScopePhone:
+			oidcApplyAttributeClaims(claims, detailer.GetAttributes(), oidc.ClaimPhoneNumber)
+		case oidc.ScopeAddress:
+			if address := oidcGetAddressClaim(detailer.GetAttributes()); len(address) != 0 {
+				claims[oidc.ClaimAddress] = address
+			}
 		case oidc.ScopeEmail:
 			if emails := detailer.GetEmails(); len(emails) != 0 {
 				claims[oidc.ClaimPreferredEmail] = emails[0]
@@ -51,6 +59,28 @@ func oidcApplyScopeClaims(claims map[string]any, scopes []string, detailer oidc.
 	}
 }
 
+func oidcApplyAttributeClaims(claims map[string]any, attributes map[string]string, names ...string) {
+	for _, name := range names {
+		if value, ok := attributes[name]; ok && value != "" {
+			claims[name] = value
+		}
+	}
+}
+
+// oidcGetAddressClaim returns the address claim from the extended user attributes. The formatted member of the address
+// claim is sourced from the address attribute.
+func oidcGetAddressClaim(attributes map[string]string) (address map[string]any) {
+	address = map[string]any{}
+
+	if value, ok := attributes[oidc.ClaimAddress]; ok && value != "" {
+		address[oidc.ClaimAddressFormatted] = value
+	}
+
+	oidcApplyAttributeClaims(address, attributes, oidcClaimsAddressAttributes...)
+
+	return address
+}
+
 func oidcGetAudience(claims map[string]any) (audience []string, ok bool) {
 	var aud any
 
@@ -88,7 +118,11 @@ func oidcApplyUserInfoClaims(clientID string, scopes oauthelia2.Arguments, origi
 		case oidc.ClaimJWTID, oidc.ClaimSessionID, oidc.ClaimAccessTokenHash, oidc.ClaimCodeHash, oidc.ClaimExpirationTime, oidc.ClaimNonce, oidc.ClaimStateHash:
 			// Skip special OpenID Connect 1.0 Claims.
 			continue
-		case oidc.ClaimPreferredUsername, oidc.ClaimPreferredEmail, oidc.ClaimEmailVerified, oidc.ClaimEmailAlts, oidc.ClaimGroups, oidc.ClaimFullName:
+		case oidc.ClaimPreferredUsername, oidc.ClaimPreferredEmail, oidc.ClaimEmailVerified, oidc.ClaimEmailAlts, oidc.ClaimGroups, oidc.ClaimFullName,
+			oidc.ClaimPhoneNumber, oidc.ClaimAddress:
+			continue
+		case oidc.ClaimGivenName, oidc.ClaimFamilyName, oidc.ClaimMiddleName, oidc.ClaimNickname, oidc.ClaimProfile, oidc.ClaimPicture,
+			oidc.ClaimWebsite, oidc.ClaimGender, oidc.ClaimBirthdate, oidc.ClaimZoneinfo, oidc.ClaimLocale:
 			continue
 		default:
 			claims[claim] = value
@@ -128,7 +162,7 @@ func oidcApplyUserInfoDetailsClaims(scopes oauthelia2.Arguments, claims map[stri
 }
 
 func oidcApplyUserInfoDetailsClaimsGetSubject(scopes oauthelia2.Arguments, claims map[string]any) (subject uuid.UUID, ok bool) {
-	if !scopes.HasOneOf(oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeGroups) {
+	if !scopes.HasOneOf(oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeGroups, oidc.ScopePhone, oidc.ScopeAddress) {
 		return uuid.UUID{}, false
 	}
 
@@ -173,3 +207,14 @@ func oidcCtxDetailResolver(ctx *middlewares.AutheliaCtx) oidcDetailResolver {
 }
 
 type oidcDetailResolver func(subject uuid.UUID) (detailer oidc.UserDetailer, err error)
+
+var (
+	oidcClaimsProfileAttributes = []string{
+		oidc.ClaimGivenName, oidc.ClaimFamilyName, oidc.ClaimMiddleName, oidc.ClaimNickname, oidc.ClaimProfile,
+		oidc.ClaimPicture, oidc.ClaimWebsite, oidc.ClaimGender, oidc.ClaimBirthdate, oidc.ClaimZoneinfo, oidc.ClaimLocale,
+	}
+
+	oidcClaimsAddressAttributes = []string{
+		oidc.ClaimStreetAddress, oidc.ClaimLocality, oidc.ClaimRegion, oidc.ClaimPostalCode, oidc.ClaimCountry,
+	}
+)
@@ -110,6 +110,28 @@ func TestShouldGrantAppropriateClaimsForScopeOpenIDAndProfile(t *testing.T) {
 	assert.Equal(t, extraClaims[oidc.ClaimFullName], "Fred Smith")
 }
 
+func TestShouldGrantAppropriateClaimsForExtendedAttributes(t *testing.T) {
+	consent := &model.OAuth2ConsentSession{
+		GrantedScopes: []string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopePhone, oidc.ScopeAddress},
+	}
+
+	extraClaims := oidcGrantRequests(nil, consent, &oidcUserSessionJane)
+
+	assert.Len(t, extraClaims, 6)
+
+	assert.Equal(t, "jane", extraClaims[oidc.ClaimPreferredUsername])
+	assert.Equal(t, "Jane Smith", extraClaims[oidc.ClaimFullName])
+	assert.Equal(t, "Jane", extraClaims[oidc.ClaimGivenName])
+	assert.Equal(t, "en-AU", extraClaims[oidc.ClaimLocale])
+	assert.Equal(t, "+61 2 5550 0100", extraClaims[oidc.ClaimPhoneNumber])
+	assert.Equal(t, map[string]any{oidc.ClaimLocality: "Sydney", oidc.ClaimCountry: "AU"}, extraClaims[oidc.ClaimAddress])
+
+	extraClaims = oidcGrantRequests(nil, consent, &oidcUserSessionJohn)
+
+	assert.Len(t, extraClaims, 2)
+	assert.NotContains(t, extraClaims, oidc.ClaimAddress)
+}
+
 func TestOIDCApplyUserInfoClaims(t *testing.T) {
 	testCases := []struct {
 		name               string
@@ -274,4 +296,19 @@ var (
 		DisplayName: "Fred Smith",
 		Emails:      []string{"f.smith@authelia.com"},
 	}
+
+	oidcUserSessionJane = session.UserSession{
+		Username:    "jane",
+		Groups:      []string{"dev"},
+		DisplayName: "Jane Smith",
+		Emails:      []string{"jane.smith@authelia.com"},
+		Attributes: map[string]string{
+			"given_name":   "Jane",
+			"locale":       "en-AU",
+			"phone_number": "+61 2 5550 0100",
+			"locality":     "Sydney",
+			"country":      "AU",
+			"department":   "Engineering",
+		},
+	}
 )
@@ -17,7 +17,7 @@ import (
 )
 
 // Handle1FAResponse handle the redirection upon 1FA authentication.
-func Handle1FAResponse(ctx *middlewares.AutheliaCtx, targetURI, requestMethod string, username string, groups []string) {
+func Handle1FAResponse(ctx *middlewares.AutheliaCtx, targetURI, requestMethod string, userSession *session.UserSession) {
 	var err error
 
 	if len(targetURI) == 0 {
@@ -44,9 +44,11 @@ func Handle1FAResponse(ctx *middlewares.AutheliaCtx, targetURI, requestMethod st
 
 	_, requiredLevel := ctx.Providers.Authorizer.GetRequiredLevel(
 		authorization.Subject{
-			Username: username,
-			Groups:   groups,
-			IP:       ctx.RemoteIP(),
+			Username:   userSession.Username,
+			Groups:     userSession.Groups,
+			Emails:     userSession.Emails,
+			Attributes: userSession.Attributes,
+			IP:         ctx.RemoteIP(),
 		},
 		authorization.NewObject(targetURL, requestMethod))
 
@@ -211,7 +213,7 @@ func handleOIDCWorkflowResponseWithID(ctx *middlewares.AutheliaCtx, userSession
 		return
 	}
 
-	level := client.GetAuthorizationPolicyRequiredLevel(authorization.Subject{Username: userSession.Username, Groups: userSession.Groups, IP: ctx.RemoteIP()})
+	level := client.GetAuthorizationPolicyRequiredLevel(authorization.Subject{Username: userSession.Username, Groups: userSession.Groups, Emails: userSession.Emails, Attributes: userSession.Attributes, IP: ctx.RemoteIP()})
 
 	switch {
 	case authorization.IsAuthLevelSufficient(userSession.AuthenticationLevel, level), level == authorization.Denied:
@@ -103,6 +103,15 @@ type bodyFirstFactorRequest struct {
 	// TODO(c.michaud): add required validation once the above PR is merged.
 }
 
+// bodyFirstFactorExternalRequest represents the JSON body received by the endpoints performing the first factor using
+// credentials which are not part of the body such as Kerberos SPNEGO or a client certificate.
+type bodyFirstFactorExternalRequest struct {
+	TargetURL     string `json:"targetURL"`
+	Workflow      string `json:"workflow"`
+	WorkflowID    string `json:"workflowID"`
+	RequestMethod string `json:"requestMethod"`
+}
+
 // checkURIWithinDomainRequestBody represents the JSON body received by the endpoint checking if an URI is within
 // the configured domain.
 type checkURIWithinDomainRequestBody struct {
@@ -111,6 +120,14 @@ type checkURIWithinDomainRequestBody struct {
 
 type checkURIWithinDomainResponseBody struct {
 	OK bool `json:"ok"`
+
+	// StepUpMethods is the list of second factor methods of which the user must authenticate with at least one before
+	// the target URI is accessible.
+	StepUpMethods []string `json:"step_up_methods,omitempty"`
+
+	// ReauthenticationLevel is the authentication level of the factor the user must perform again before the target URI
+	// is accessible as it was last performed longer ago than the maximum authentication age.
+	ReauthenticationLevel authentication.Level `json:"reauthentication_level,omitempty"`
 }
 
 // redirectResponse represent the response sent by the first factor endpoint
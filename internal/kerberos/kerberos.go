@@ -0,0 +1,198 @@
+package kerberos
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jcmturner/gokrb5/v8/credentials"
+	"github.com/jcmturner/gokrb5/v8/gssapi"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/jcmturner/gokrb5/v8/service"
+	"github.com/jcmturner/gokrb5/v8/spnego"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+)
+
+// NewProvider instantiates a Kerberos provider given a configuration.
+func NewProvider(config *schema.AuthenticationBackendKerberos) *Provider {
+	return &Provider{
+		config: config,
+		log:    logging.Logger(),
+	}
+}
+
+// StartupCheck implements the startup check provider interface.
+func (p *Provider) StartupCheck() (err error) {
+	var kt *keytab.Keytab
+
+	if kt, err = keytab.Load(p.config.Keytab); err != nil {
+		return fmt.Errorf("error occurred loading the keytab file '%s': %w", p.config.Keytab, err)
+	}
+
+	if len(kt.Entries) == 0 {
+		return fmt.Errorf("the keytab file '%s' does not contain any entries", p.config.Keytab)
+	}
+
+	if p.config.ServicePrincipal != "" && !keytabHasPrincipal(kt, p.config.ServicePrincipal) {
+		return fmt.Errorf("the keytab file '%s' does not contain any entries for the service principal '%s'", p.config.Keytab, p.config.ServicePrincipal)
+	}
+
+	p.mu.Lock()
+
+	p.keytab, p.realms = kt, keytabRealms(kt, p.config.ServicePrincipal)
+
+	p.mu.Unlock()
+
+	p.log.WithFields(map[string]any{"keytab": p.config.Keytab, "entries": len(kt.Entries)}).Debug("Loaded the kerberos keytab")
+
+	return nil
+}
+
+// Authenticate validates a SPNEGO or raw Kerberos v5 token provided by a client via the Negotiate authorization scheme
+// and returns the principal of the client.
+func (p *Provider) Authenticate(token []byte) (principal *Principal, err error) {
+	p.mu.RLock()
+
+	kt := p.keytab
+
+	p.mu.RUnlock()
+
+	if kt == nil {
+		return nil, errors.New("the keytab has not been loaded")
+	}
+
+	var krb5 spnego.KRB5Token
+
+	if krb5, err = unmarshalToken(token); err != nil {
+		return nil, err
+	}
+
+	if !krb5.IsAPReq() {
+		return nil, errors.New("the kerberos token is not an AP-REQ")
+	}
+
+	opts := []func(*service.Settings){
+		service.MaxClockSkew(p.config.MaxClockSkew),
+		service.DecodePAC(false),
+	}
+
+	if p.config.ServicePrincipal != "" {
+		opts = append(opts, service.KeytabPrincipal(p.config.ServicePrincipal))
+	}
+
+	var (
+		valid bool
+		creds *credentials.Credentials
+	)
+
+	if valid, creds, err = service.VerifyAPREQ(&krb5.APReq, service.NewSettings(kt, opts...)); err != nil {
+		return nil, fmt.Errorf("error occurred verifying the kerberos AP-REQ: %w", err)
+	}
+
+	if !valid || creds == nil {
+		return nil, errors.New("the kerberos AP-REQ is not valid")
+	}
+
+	principal = &Principal{
+		Username: creds.UserName(),
+		Realm:    creds.Domain(),
+	}
+
+	if !p.IsRealmPermitted(principal.Realm) {
+		return nil, fmt.Errorf("the realm '%s' of the principal '%s' is not permitted", principal.Realm, principal)
+	}
+
+	if p.config.IncludeRealm {
+		principal.Username = principal.String()
+	}
+
+	return principal, nil
+}
+
+// IsRealmPermitted returns true if the realm is permitted by the configuration. When no realms are configured only the
+// realms of the service principal entries of the keytab are permitted, as otherwise a principal of a trusted realm could
+// be mapped to the username of a principal of the service realm.
+func (p *Provider) IsRealmPermitted(realm string) bool {
+	realms := p.config.Realms
+
+	if len(realms) == 0 {
+		p.mu.RLock()
+
+		realms = p.realms
+
+		p.mu.RUnlock()
+	}
+
+	for _, r := range realms {
+		if strings.EqualFold(r, realm) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func unmarshalToken(token []byte) (krb5 spnego.KRB5Token, err error) {
+	var negotiate spnego.SPNEGOToken
+
+	if err = negotiate.Unmarshal(token); err != nil {
+		if errKRB5 := krb5.Unmarshal(token); errKRB5 != nil {
+			return krb5, fmt.Errorf("error occurred unmarshalling the token as either a SPNEGO or kerberos token: %w", err)
+		}
+
+		return krb5, nil
+	}
+
+	if !negotiate.Init {
+		return krb5, errors.New("the SPNEGO token is not a NegTokenInit")
+	}
+
+	if len(negotiate.NegTokenInit.MechTypes) == 0 {
+		return krb5, errors.New("the SPNEGO token does not contain any mechanism types")
+	}
+
+	if mech := negotiate.NegTokenInit.MechTypes[0]; !mech.Equal(gssapi.OIDKRB5.OID()) && !mech.Equal(gssapi.OIDMSLegacyKRB5.OID()) {
+		return krb5, fmt.Errorf("the SPNEGO token mechanism type '%s' is not kerberos", mech)
+	}
+
+	if err = krb5.Unmarshal(negotiate.NegTokenInit.MechTokenBytes); err != nil {
+		return krb5, fmt.Errorf("error occurred unmarshalling the SPNEGO mechanism token: %w", err)
+	}
+
+	return krb5, nil
+}
+
+func keytabHasPrincipal(kt *keytab.Keytab, principal string) bool {
+	for _, entry := range kt.Entries {
+		if isKeytabPrincipal(entry.Principal.Components, entry.Principal.Realm, principal) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// keytabRealms returns the realms of the entries of the keytab for the service principal, or of all entries if the
+// service principal is empty.
+func keytabRealms(kt *keytab.Keytab, principal string) (realms []string) {
+	for _, entry := range kt.Entries {
+		if principal != "" && !isKeytabPrincipal(entry.Principal.Components, entry.Principal.Realm, principal) {
+			continue
+		}
+
+		if !slices.ContainsFunc(realms, func(realm string) bool { return strings.EqualFold(realm, entry.Principal.Realm) }) {
+			realms = append(realms, entry.Principal.Realm)
+		}
+	}
+
+	return realms
+}
+
+func isKeytabPrincipal(components []string, realm, principal string) bool {
+	name := strings.Join(components, "/")
+
+	return strings.EqualFold(name, principal) || strings.EqualFold(fmt.Sprintf("%s@%s", name, realm), principal)
+}
@@ -0,0 +1,288 @@
+package kerberos
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jcmturner/gokrb5/v8/client"
+	"github.com/jcmturner/gokrb5/v8/config"
+	"github.com/jcmturner/gokrb5/v8/gssapi"
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+	"github.com/jcmturner/gokrb5/v8/iana/nametype"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/jcmturner/gokrb5/v8/messages"
+	"github.com/jcmturner/gokrb5/v8/spnego"
+	"github.com/jcmturner/gokrb5/v8/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestShouldFailStartupCheckWhenKeytabMissing(t *testing.T) {
+	provider := NewProvider(&schema.AuthenticationBackendKerberos{Keytab: filepath.Join(t.TempDir(), "krb5.keytab")})
+
+	assert.ErrorContains(t, provider.StartupCheck(), "error occurred loading the keytab file")
+}
+
+func TestShouldFailStartupCheckWhenServicePrincipalMissing(t *testing.T) {
+	provider := NewProvider(&schema.AuthenticationBackendKerberos{
+		Keytab:           newTestKeytabFile(t, newTestKeytab(t)),
+		ServicePrincipal: "HTTP/other.example.com",
+	})
+
+	assert.ErrorContains(t, provider.StartupCheck(), "does not contain any entries for the service principal 'HTTP/other.example.com'")
+}
+
+func TestShouldAuthenticateSPNEGOToken(t *testing.T) {
+	kt := newTestKeytab(t)
+
+	provider := NewProvider(&schema.AuthenticationBackendKerberos{
+		Keytab:           newTestKeytabFile(t, kt),
+		ServicePrincipal: testServicePrincipal,
+		Realms:           []string{"example.com"},
+		MaxClockSkew:     time.Minute * 5,
+	})
+
+	require.NoError(t, provider.StartupCheck())
+
+	token := newTestSPNEGOToken(t, kt, "john")
+
+	principal, err := provider.Authenticate(token)
+
+	require.NoError(t, err)
+	require.NotNil(t, principal)
+
+	assert.Equal(t, "john", principal.Username)
+	assert.Equal(t, testRealm, principal.Realm)
+	assert.Equal(t, "john@EXAMPLE.COM", principal.String())
+
+	principal, err = provider.Authenticate(token)
+
+	assert.Nil(t, principal)
+	assert.ErrorContains(t, err, "replay detected")
+}
+
+func TestShouldAuthenticateKerberosToken(t *testing.T) {
+	kt := newTestKeytab(t)
+
+	provider := NewProvider(&schema.AuthenticationBackendKerberos{
+		Keytab:       newTestKeytabFile(t, kt),
+		MaxClockSkew: time.Minute * 5,
+	})
+
+	require.NoError(t, provider.StartupCheck())
+
+	principal, err := provider.Authenticate(newTestKRB5Token(t, kt, "harry"))
+
+	require.NoError(t, err)
+	require.NotNil(t, principal)
+
+	assert.Equal(t, "harry", principal.Username)
+	assert.Equal(t, testRealm, principal.Realm)
+}
+
+func TestShouldAuthenticateTokenIncludingRealm(t *testing.T) {
+	kt := newTestKeytab(t)
+
+	provider := NewProvider(&schema.AuthenticationBackendKerberos{
+		Keytab:       newTestKeytabFile(t, kt),
+		IncludeRealm: true,
+		MaxClockSkew: time.Minute * 5,
+	})
+
+	require.NoError(t, provider.StartupCheck())
+
+	principal, err := provider.Authenticate(newTestSPNEGOToken(t, kt, "fred"))
+
+	require.NoError(t, err)
+	require.NotNil(t, principal)
+
+	assert.Equal(t, "fred@EXAMPLE.COM", principal.Username)
+	assert.Equal(t, testRealm, principal.Realm)
+	assert.Equal(t, "fred@EXAMPLE.COM", principal.String())
+}
+
+func TestShouldNotAuthenticateTokenFromRealmNotPermitted(t *testing.T) {
+	kt := newTestKeytab(t)
+
+	provider := NewProvider(&schema.AuthenticationBackendKerberos{
+		Keytab:       newTestKeytabFile(t, kt),
+		Realms:       []string{"CORP.EXAMPLE.COM"},
+		MaxClockSkew: time.Minute * 5,
+	})
+
+	require.NoError(t, provider.StartupCheck())
+
+	principal, err := provider.Authenticate(newTestSPNEGOToken(t, kt, "bob"))
+
+	assert.Nil(t, principal)
+	assert.EqualError(t, err, "the realm 'EXAMPLE.COM' of the principal 'bob@EXAMPLE.COM' is not permitted")
+}
+
+func TestShouldNotAuthenticateTokenFromTrustedRealmByDefault(t *testing.T) {
+	kt := newTestKeytab(t)
+
+	provider := NewProvider(&schema.AuthenticationBackendKerberos{
+		Keytab:       newTestKeytabFile(t, kt),
+		MaxClockSkew: time.Minute * 5,
+	})
+
+	require.NoError(t, provider.StartupCheck())
+
+	principal, err := provider.Authenticate(newTestKRB5TokenRealm(t, kt, "alice", "TRUSTED-OTHER.REALM"))
+
+	assert.Nil(t, principal)
+	assert.EqualError(t, err, "the realm 'TRUSTED-OTHER.REALM' of the principal 'alice@TRUSTED-OTHER.REALM' is not permitted")
+
+	provider = NewProvider(&schema.AuthenticationBackendKerberos{
+		Keytab:       newTestKeytabFile(t, kt),
+		Realms:       []string{testRealm, "TRUSTED-OTHER.REALM"},
+		IncludeRealm: true,
+		MaxClockSkew: time.Minute * 5,
+	})
+
+	require.NoError(t, provider.StartupCheck())
+
+	principal, err = provider.Authenticate(newTestKRB5TokenRealm(t, kt, "alice", "TRUSTED-OTHER.REALM"))
+
+	require.NoError(t, err)
+	require.NotNil(t, principal)
+
+	assert.Equal(t, "alice@TRUSTED-OTHER.REALM", principal.Username)
+}
+
+func TestShouldNotAuthenticateInvalidToken(t *testing.T) {
+	kt := newTestKeytab(t)
+
+	provider := NewProvider(&schema.AuthenticationBackendKerberos{
+		Keytab:       newTestKeytabFile(t, kt),
+		MaxClockSkew: time.Minute * 5,
+	})
+
+	principal, err := provider.Authenticate([]byte("abc"))
+
+	assert.Nil(t, principal)
+	assert.EqualError(t, err, "the keytab has not been loaded")
+
+	require.NoError(t, provider.StartupCheck())
+
+	principal, err = provider.Authenticate([]byte("abc"))
+
+	assert.Nil(t, principal)
+	assert.ErrorContains(t, err, "error occurred unmarshalling the token as either a SPNEGO or kerberos token")
+
+	token, err := newTestSPNEGOTokenMech(gssapi.OIDSPNEGO, []byte("abc"))
+
+	require.NoError(t, err)
+
+	principal, err = provider.Authenticate(token)
+
+	assert.Nil(t, principal)
+	assert.EqualError(t, err, "the SPNEGO token mechanism type '1.3.6.1.5.5.2' is not kerberos")
+}
+
+func TestShouldCheckRealmPermitted(t *testing.T) {
+	provider := NewProvider(&schema.AuthenticationBackendKerberos{})
+
+	assert.False(t, provider.IsRealmPermitted("EXAMPLE.COM"))
+
+	kt := newTestKeytab(t)
+
+	require.NoError(t, kt.AddEntry("HTTP/auth.corp.example.com", "CORP.EXAMPLE.COM", "password", time.Now(), 1, etypeID.AES256_CTS_HMAC_SHA1_96))
+
+	provider = NewProvider(&schema.AuthenticationBackendKerberos{Keytab: newTestKeytabFile(t, kt)})
+
+	require.NoError(t, provider.StartupCheck())
+
+	assert.True(t, provider.IsRealmPermitted("example.com"))
+	assert.True(t, provider.IsRealmPermitted("CORP.EXAMPLE.COM"))
+	assert.False(t, provider.IsRealmPermitted("OTHER.EXAMPLE.COM"))
+
+	provider = NewProvider(&schema.AuthenticationBackendKerberos{Keytab: newTestKeytabFile(t, kt), ServicePrincipal: testServicePrincipal})
+
+	require.NoError(t, provider.StartupCheck())
+
+	assert.True(t, provider.IsRealmPermitted("EXAMPLE.COM"))
+	assert.False(t, provider.IsRealmPermitted("CORP.EXAMPLE.COM"))
+
+	provider = NewProvider(&schema.AuthenticationBackendKerberos{Realms: []string{"EXAMPLE.COM", "corp.example.com"}})
+
+	assert.True(t, provider.IsRealmPermitted("example.com"))
+	assert.True(t, provider.IsRealmPermitted("CORP.EXAMPLE.COM"))
+	assert.False(t, provider.IsRealmPermitted("OTHER.EXAMPLE.COM"))
+}
+
+const (
+	testRealm            = "EXAMPLE.COM"
+	testServicePrincipal = "HTTP/auth.example.com"
+)
+
+func newTestKeytab(t *testing.T) *keytab.Keytab {
+	kt := keytab.New()
+
+	require.NoError(t, kt.AddEntry(testServicePrincipal, testRealm, "password", time.Now(), 1, etypeID.AES256_CTS_HMAC_SHA1_96))
+
+	return kt
+}
+
+func newTestKeytabFile(t *testing.T, kt *keytab.Keytab) string {
+	data, err := kt.Marshal()
+
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "krb5.keytab")
+
+	require.NoError(t, os.WriteFile(path, data, 0600))
+
+	return path
+}
+
+func newTestKRB5Token(t *testing.T, kt *keytab.Keytab, username string) []byte {
+	return newTestKRB5TokenRealm(t, kt, username, testRealm)
+}
+
+func newTestKRB5TokenRealm(t *testing.T, kt *keytab.Keytab, username, realm string) []byte {
+	now := time.Now().UTC()
+
+	ticket, key, err := messages.NewTicket(
+		types.NewPrincipalName(nametype.KRB_NT_PRINCIPAL, username), realm,
+		types.NewPrincipalName(nametype.KRB_NT_PRINCIPAL, testServicePrincipal), testRealm,
+		types.NewKrbFlags(), kt, etypeID.AES256_CTS_HMAC_SHA1_96, 1, now, now, now.Add(time.Hour), now.Add(time.Hour),
+	)
+
+	require.NoError(t, err)
+
+	token, err := spnego.NewKRB5TokenAPREQ(client.NewWithPassword(username, realm, "password", config.New()), ticket, key, []int{gssapi.ContextFlagInteg, gssapi.ContextFlagConf}, []int{})
+
+	require.NoError(t, err)
+
+	data, err := token.Marshal()
+
+	require.NoError(t, err)
+
+	return data
+}
+
+func newTestSPNEGOToken(t *testing.T, kt *keytab.Keytab, username string) []byte {
+	data, err := newTestSPNEGOTokenMech(gssapi.OIDKRB5, newTestKRB5Token(t, kt, username))
+
+	require.NoError(t, err)
+
+	return data
+}
+
+func newTestSPNEGOTokenMech(mech gssapi.OIDName, token []byte) ([]byte, error) {
+	negotiate := &spnego.SPNEGOToken{
+		Init: true,
+		NegTokenInit: spnego.NegTokenInit{
+			MechTokenBytes: token,
+		},
+	}
+
+	negotiate.NegTokenInit.MechTypes = append(negotiate.NegTokenInit.MechTypes, mech.OID())
+
+	return negotiate.Marshal()
+}
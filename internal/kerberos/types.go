@@ -0,0 +1,38 @@
+package kerberos
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// Provider validates Kerberos tickets presented via SPNEGO using the keys of a keytab.
+type Provider struct {
+	config *schema.AuthenticationBackendKerberos
+	log    *logrus.Logger
+
+	keytab *keytab.Keytab
+	realms []string
+
+	mu sync.RWMutex
+}
+
+// Principal represents an authenticated Kerberos client principal. The Username is the name of the user the principal
+// is mapped to.
+type Principal struct {
+	Username string
+	Realm    string
+}
+
+// String returns the text representation of this Principal.
+func (p Principal) String() string {
+	if p.Realm == "" || strings.Contains(p.Username, "@") {
+		return p.Username
+	}
+
+	return p.Username + "@" + p.Realm
+}
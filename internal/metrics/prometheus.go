@@ -26,6 +26,10 @@ type Prometheus struct {
 	authzCounter    *prometheus.CounterVec
 	authnCounter    *prometheus.CounterVec
 	authn2FACounter *prometheus.CounterVec
+
+	ldapPoolConnections *prometheus.GaugeVec
+	ldapPoolAcquire     *prometheus.HistogramVec
+	ldapPoolHealthCheck *prometheus.CounterVec
 }
 
 // RecordRequest takes the statusCode string, requestMethod string, and the elapsed time.Duration to record the request and request duration metrics.
@@ -59,6 +63,22 @@ func (r *Prometheus) RecordAuthenticationDuration(success bool, elapsed time.Dur
 	r.authnDuration.WithLabelValues(strconv.FormatBool(success)).Observe(elapsed.Seconds())
 }
 
+// RecordLDAPPoolConnections takes the backend name string, and the open and idle connection counts to record the LDAP connection pool metrics.
+func (r *Prometheus) RecordLDAPPoolConnections(backend string, open, idle int) {
+	r.ldapPoolConnections.WithLabelValues(backend, "open").Set(float64(open))
+	r.ldapPoolConnections.WithLabelValues(backend, "idle").Set(float64(idle))
+}
+
+// RecordLDAPPoolAcquire takes the backend name string, result string, and the elapsed time.Duration to record the LDAP connection pool acquisition metrics.
+func (r *Prometheus) RecordLDAPPoolAcquire(backend, result string, elapsed time.Duration) {
+	r.ldapPoolAcquire.WithLabelValues(backend, result).Observe(elapsed.Seconds())
+}
+
+// RecordLDAPPoolHealthCheck takes the backend name string and the healthy boolean to record the LDAP connection pool health check metrics.
+func (r *Prometheus) RecordLDAPPoolHealthCheck(backend string, healthy bool) {
+	r.ldapPoolHealthCheck.WithLabelValues(backend, strconv.FormatBool(healthy)).Inc()
+}
+
 func (r *Prometheus) register() {
 	r.authnDuration = promauto.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -125,4 +145,31 @@ func (r *Prometheus) register() {
 		},
 		[]string{"success", "banned", "type"},
 	)
+	r.ldapPoolConnections = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: "authelia",
+			Name:      "ldap_pool_connections",
+			Help:      "The number of connections in the LDAP connection pool.",
+		},
+		[]string{"backend", "state"},
+	)
+
+	r.ldapPoolAcquire = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: "authelia",
+			Name:      "ldap_pool_acquire_duration",
+			Help:      "The time acquiring a connection from the LDAP connection pool takes in seconds.",
+			Buckets:   []float64{.0005, .001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{"backend", "result"},
+	)
+
+	r.ldapPoolHealthCheck = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: "authelia",
+			Name:      "ldap_pool_health_check",
+			Help:      "The number of LDAP connection pool health checks performed.",
+		},
+		[]string{"backend", "healthy"},
+	)
 }
@@ -17,4 +17,7 @@ func TestNewPrometheus(t *testing.T) {
 	p.RecordAuthn(true, false, "WebAuthn")
 	p.RecordAuthn(true, false, "1fa")
 	p.RecordAuthenticationDuration(true, time.Second)
+	p.RecordLDAPPoolConnections("ldap", 2, 1)
+	p.RecordLDAPPoolAcquire("ldap", "reused", time.Millisecond)
+	p.RecordLDAPPoolHealthCheck("ldap", true)
 }
@@ -1,6 +1,8 @@
 package middlewares
 
 import (
+	"strings"
+
 	"github.com/valyala/fasthttp"
 )
 
@@ -67,7 +69,8 @@ func SecurityHeadersCSPNone(next fasthttp.RequestHandler) fasthttp.RequestHandle
 }
 
 // SecurityHeadersCSPNoneOpenIDConnect middleware adds the Content-Security-Policy header with the value
-// "default-src 'none'" except in special circumstances.
+// "default-src 'none'" except in special circumstances such as the form post response mode or when the response
+// renders the Front-Channel Logout iframes.
 func SecurityHeadersCSPNoneOpenIDConnect(next fasthttp.RequestHandler) fasthttp.RequestHandler {
 	return func(ctx *fasthttp.RequestCtx) {
 		ctx.SetUserValue(UserValueKeyOpenIDConnectResponseModeFormPost, false)
@@ -76,6 +79,8 @@ func SecurityHeadersCSPNoneOpenIDConnect(next fasthttp.RequestHandler) fasthttp.
 
 		if modeFormPost, ok := ctx.UserValue(UserValueKeyOpenIDConnectResponseModeFormPost).(bool); ok && modeFormPost {
 			ctx.Response.Header.SetBytesKV(headerContentSecurityPolicy, headerValueCSPNoneFormPost)
+		} else if sources, ok := ctx.UserValue(UserValueKeyOpenIDConnectFrameSources).([]string); ok && len(sources) != 0 {
+			ctx.Response.Header.SetBytesK(headerContentSecurityPolicy, headerValueCSPNoneFrameSrc+strings.Join(sources, " "))
 		} else {
 			ctx.Response.Header.SetBytesKV(headerContentSecurityPolicy, headerValueCSPNone)
 		}
@@ -4,10 +4,14 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/valyala/fasthttp"
 
+	"github.com/authelia/authelia/v4/internal/audit"
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/clientcert"
 	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/geoip"
+	"github.com/authelia/authelia/v4/internal/kerberos"
 	"github.com/authelia/authelia/v4/internal/metrics"
 	"github.com/authelia/authelia/v4/internal/notification"
 	"github.com/authelia/authelia/v4/internal/ntp"
@@ -35,19 +39,23 @@ type AutheliaCtx struct {
 
 // Providers contain all provider provided to Authelia.
 type Providers struct {
-	Authorizer      *authorization.Authorizer
-	SessionProvider *session.Provider
-	Regulator       *regulation.Regulator
-	OpenIDConnect   *oidc.OpenIDConnectProvider
-	Metrics         metrics.Provider
-	NTP             *ntp.Provider
-	UserProvider    authentication.UserProvider
-	StorageProvider storage.Provider
-	Notifier        notification.Notifier
-	Templates       *templates.Provider
-	TOTP            totp.Provider
-	PasswordPolicy  PasswordPolicyProvider
-	Random          random.Provider
+	Authorizer        *authorization.Authorizer
+	SessionProvider   *session.Provider
+	Regulator         *regulation.Regulator
+	OpenIDConnect     *oidc.OpenIDConnectProvider
+	Metrics           metrics.Provider
+	NTP               *ntp.Provider
+	Kerberos          *kerberos.Provider
+	ClientCertificate *clientcert.Provider
+	GeoIP             *geoip.Provider
+	Audit             *audit.Provider
+	UserProvider      authentication.UserProvider
+	StorageProvider   storage.Provider
+	Notifier          notification.Notifier
+	Templates         *templates.Provider
+	TOTP              totp.Provider
+	PasswordPolicy    PasswordPolicyProvider
+	Random            random.Provider
 }
 
 // RequestHandler represents an Authelia request handler.
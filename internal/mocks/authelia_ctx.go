@@ -196,8 +196,7 @@ func NewMockAutheliaCtx(t *testing.T) *MockAutheliaCtx {
 	mockAuthelia.NotifierMock = NewMockNotifier(mockAuthelia.Ctrl)
 	providers.Notifier = mockAuthelia.NotifierMock
 
-	providers.Authorizer = authorization.NewAuthorizer(
-		&config)
+	providers.Authorizer = authorization.NewAuthorizer(&config, &mockAuthelia.Clock, nil)
 
 	providers.SessionProvider = session.NewProvider(
 		config.Session, nil)
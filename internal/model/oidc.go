@@ -142,6 +142,57 @@ func NewOAuth2PARContext(contextID string, r oauthelia2.AuthorizeRequester) (con
 	}, nil
 }
 
+// NewOAuth2DeviceCodeSession creates a new OAuth2DeviceCodeSession from the signatures of the device code and user code
+// and the oauthelia2.Requester of the Device Authorization Request.
+func NewOAuth2DeviceCodeSession(signature, userCodeSignature string, r oauthelia2.Requester, expires time.Time, interval time.Duration) (session *OAuth2DeviceCodeSession, err error) {
+	if r == nil {
+		return nil, fmt.Errorf("failed to create new *model.OAuth2DeviceCodeSession: the oauthelia2.Requester was nil")
+	}
+
+	var (
+		s           OpenIDSession
+		ok          bool
+		sessionData []byte
+	)
+
+	if s, ok = r.GetSession().(OpenIDSession); !ok {
+		return nil, fmt.Errorf("failed to create new *model.OAuth2DeviceCodeSession: the session type OpenIDSession was expected but the type '%T' was used", r.GetSession())
+	}
+
+	if sessionData, err = json.Marshal(s); err != nil {
+		return nil, fmt.Errorf("failed to create new *model.OAuth2DeviceCodeSession: an error was returned while attempting to marshal the session data to json: %w", err)
+	}
+
+	requested, granted := r.GetRequestedScopes(), r.GetGrantedScopes()
+
+	if requested == nil {
+		requested = oauthelia2.Arguments{}
+	}
+
+	if granted == nil {
+		granted = oauthelia2.Arguments{}
+	}
+
+	return &OAuth2DeviceCodeSession{
+		RequestID:         r.GetID(),
+		ClientID:          r.GetClient().GetID(),
+		Signature:         signature,
+		UserCodeSignature: userCodeSignature,
+		Status:            OAuth2DeviceCodeStatusPending,
+		RequestedAt:       r.GetRequestedAt(),
+		ExpiresAt:         expires,
+		PollingInterval:   int(interval.Seconds()),
+		RequestedScopes:   StringSlicePipeDelimited(requested),
+		GrantedScopes:     StringSlicePipeDelimited(granted),
+		RequestedAudience: StringSlicePipeDelimited(r.GetRequestedAudience()),
+		GrantedAudience:   StringSlicePipeDelimited(r.GetGrantedAudience()),
+		Active:            true,
+		Revoked:           false,
+		Form:              r.GetRequestForm().Encode(),
+		Session:           sessionData,
+	}, nil
+}
+
 // OAuth2ConsentPreConfig stores information about an OAuth2.0 Pre-Configured Consent.
 type OAuth2ConsentPreConfig struct {
 	ID       int64     `db:"id"`
@@ -393,6 +444,111 @@ func (par *OAuth2PARContext) ToAuthorizeRequest(ctx context.Context, session oau
 	return request, nil
 }
 
+// OAuth2DeviceCodeStatus represents the status of an OAuth 2.0 Device Authorization Grant session.
+type OAuth2DeviceCodeStatus int
+
+const (
+	// OAuth2DeviceCodeStatusPending represents a session the end user has not yet responded to.
+	OAuth2DeviceCodeStatusPending OAuth2DeviceCodeStatus = iota
+
+	// OAuth2DeviceCodeStatusAuthorized represents a session the end user has authorized.
+	OAuth2DeviceCodeStatusAuthorized
+
+	// OAuth2DeviceCodeStatusDenied represents a session the end user has denied.
+	OAuth2DeviceCodeStatusDenied
+)
+
+// OAuth2DeviceCodeSession represents an OAuth 2.0 Device Authorization Grant session.
+type OAuth2DeviceCodeSession struct {
+	ID                int                      `db:"id"`
+	RequestID         string                   `db:"request_id"`
+	ClientID          string                   `db:"client_id"`
+	Signature         string                   `db:"signature"`
+	UserCodeSignature string                   `db:"user_code_signature"`
+	Status            OAuth2DeviceCodeStatus   `db:"status"`
+	Subject           sql.NullString           `db:"subject"`
+	RequestedAt       time.Time                `db:"requested_at"`
+	CheckedAt         sql.NullTime             `db:"checked_at"`
+	ExpiresAt         time.Time                `db:"expires_at"`
+	PollingInterval   int                      `db:"polling_interval"`
+	RequestedScopes   StringSlicePipeDelimited `db:"requested_scopes"`
+	GrantedScopes     StringSlicePipeDelimited `db:"granted_scopes"`
+	RequestedAudience StringSlicePipeDelimited `db:"requested_audience"`
+	GrantedAudience   StringSlicePipeDelimited `db:"granted_audience"`
+	Active            bool                     `db:"active"`
+	Revoked           bool                     `db:"revoked"`
+	Form              string                   `db:"form_data"`
+	Session           []byte                   `db:"session_data"`
+}
+
+// SetSubject sets the subject of the end user who responded to the session.
+func (s *OAuth2DeviceCodeSession) SetSubject(subject string) {
+	s.Subject = sql.NullString{String: subject, Valid: len(subject) > 0}
+}
+
+// GetPollingInterval returns the minimum amount of time the client must wait between polling requests.
+func (s *OAuth2DeviceCodeSession) GetPollingInterval() time.Duration {
+	return time.Duration(s.PollingInterval) * time.Second
+}
+
+// IsExpired returns true if the device code is expired relative to the provided time.
+func (s *OAuth2DeviceCodeSession) IsExpired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
+
+// IsPollingTooFast returns true if the client has polled the token endpoint before the polling interval elapsed.
+func (s *OAuth2DeviceCodeSession) IsPollingTooFast(now time.Time) bool {
+	return s.CheckedAt.Valid && now.Before(s.CheckedAt.Time.Add(s.GetPollingInterval()))
+}
+
+// ToRequest converts an OAuth2DeviceCodeSession into a oauthelia2.Request given a oauthelia2.Session and
+// oauthelia2.Storage.
+func (s *OAuth2DeviceCodeSession) ToRequest(ctx context.Context, session oauthelia2.Session, store oauthelia2.Storage) (request *oauthelia2.Request, err error) {
+	if session != nil {
+		if err = json.Unmarshal(s.Session, session); err != nil {
+			return nil, fmt.Errorf("error occurred while mapping OAuth 2.0 Device Code Session back to a Request while trying to unmarshal the JSON session data: %w", err)
+		}
+	}
+
+	var (
+		client oauthelia2.Client
+		values url.Values
+	)
+
+	if client, err = store.GetClient(ctx, s.ClientID); err != nil {
+		return nil, fmt.Errorf("error occurred while mapping OAuth 2.0 Device Code Session back to a Request while trying to lookup the registered client: %w", err)
+	}
+
+	if values, err = url.ParseQuery(s.Form); err != nil {
+		return nil, fmt.Errorf("error occurred while mapping OAuth 2.0 Device Code Session back to a Request while trying to parse the original form: %w", err)
+	}
+
+	return &oauthelia2.Request{
+		ID:                s.RequestID,
+		RequestedAt:       s.RequestedAt,
+		Client:            client,
+		RequestedScope:    oauthelia2.Arguments(s.RequestedScopes),
+		GrantedScope:      oauthelia2.Arguments(s.GrantedScopes),
+		RequestedAudience: oauthelia2.Arguments(s.RequestedAudience),
+		GrantedAudience:   oauthelia2.Arguments(s.GrantedAudience),
+		Form:              values,
+		Session:           session,
+	}, nil
+}
+
+// OAuth2Client represents an OAuth2.0 Client registered via the Dynamic Client Registration endpoint. The Metadata is
+// the JSON encoded client metadata.
+type OAuth2Client struct {
+	ID                               int            `db:"id"`
+	ClientID                         string         `db:"client_id"`
+	ClientName                       string         `db:"client_name"`
+	ClientSecret                     sql.NullString `db:"client_secret"`
+	RegistrationAccessTokenSignature string         `db:"registration_access_token_signature"`
+	CreatedAt                        time.Time      `db:"created_at"`
+	UpdatedAt                        time.Time      `db:"updated_at"`
+	Metadata                         []byte         `db:"metadata"`
+}
+
 // OpenIDSession represents the types available for an oidc.Session that are required in the models package.
 type OpenIDSession interface {
 	oauthelia2.Session
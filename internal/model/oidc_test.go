@@ -130,6 +130,117 @@ func TestNewOAuth2SessionFromRequest(t *testing.T) {
 	}
 }
 
+func TestNewOAuth2DeviceCodeSession(t *testing.T) {
+	session := &oidc.Session{
+		DefaultSession: &openid.DefaultSession{},
+	}
+
+	sessionBytes, _ := json.Marshal(session)
+
+	requestedAt := time.Unix(1000000, 0)
+	expires := requestedAt.Add(time.Minute * 10)
+
+	testCases := []struct {
+		name     string
+		have     oauthelia2.Requester
+		expected *model.OAuth2DeviceCodeSession
+		err      string
+	}{
+		{
+			"ShouldNewUpStandard",
+			&oauthelia2.Request{
+				ID:          "example",
+				RequestedAt: requestedAt,
+				Client: &oauthelia2.DefaultClient{
+					ID: "client_id",
+				},
+				Session:           session,
+				RequestedScope:    oauthelia2.Arguments{oidc.ScopeOpenID},
+				RequestedAudience: oauthelia2.Arguments{"https://app.example.com"},
+				Form:              url.Values{oidc.FormParameterClientID: []string{"client_id"}},
+			},
+			&model.OAuth2DeviceCodeSession{
+				RequestID:         "example",
+				ClientID:          "client_id",
+				Signature:         "abc",
+				UserCodeSignature: "xyz",
+				Status:            model.OAuth2DeviceCodeStatusPending,
+				RequestedAt:       requestedAt,
+				ExpiresAt:         expires,
+				PollingInterval:   10,
+				RequestedScopes:   model.StringSlicePipeDelimited{oidc.ScopeOpenID},
+				GrantedScopes:     model.StringSlicePipeDelimited{},
+				RequestedAudience: model.StringSlicePipeDelimited{"https://app.example.com"},
+				Active:            true,
+				Form:              "client_id=client_id",
+				Session:           sessionBytes,
+			},
+			"",
+		},
+		{
+			"ShouldRaiseErrorOnInvalidSessionType",
+			&oauthelia2.Request{
+				ID: "example",
+				Client: &oauthelia2.DefaultClient{
+					ID: "client_id",
+				},
+				Session: &openid.DefaultSession{},
+			},
+			nil,
+			"failed to create new *model.OAuth2DeviceCodeSession: the session type OpenIDSession was expected but the type '*openid.DefaultSession' was used",
+		},
+		{
+			"ShouldRaiseErrorOnNilRequester",
+			nil,
+			nil,
+			"failed to create new *model.OAuth2DeviceCodeSession: the oauthelia2.Requester was nil",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := model.NewOAuth2DeviceCodeSession("abc", "xyz", tc.have, expires, time.Second*10)
+
+			if len(tc.err) > 0 {
+				assert.Nil(t, actual)
+				assert.EqualError(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+				require.NotNil(t, actual)
+
+				assert.Equal(t, tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestOAuth2DeviceCodeSession(t *testing.T) {
+	now := time.Unix(1000000, 0)
+
+	session := &model.OAuth2DeviceCodeSession{
+		ExpiresAt:       now.Add(time.Minute),
+		PollingInterval: 5,
+	}
+
+	assert.Equal(t, time.Second*5, session.GetPollingInterval())
+	assert.False(t, session.IsExpired(now))
+	assert.True(t, session.IsExpired(now.Add(time.Minute)))
+	assert.False(t, session.IsPollingTooFast(now))
+
+	session.CheckedAt = sql.NullTime{Time: now, Valid: true}
+
+	assert.True(t, session.IsPollingTooFast(now.Add(time.Second*4)))
+	assert.False(t, session.IsPollingTooFast(now.Add(time.Second*5)))
+
+	session.SetSubject("")
+
+	assert.Equal(t, sql.NullString{}, session.Subject)
+
+	session.SetSubject("abc")
+
+	assert.Equal(t, sql.NullString{String: "abc", Valid: true}, session.Subject)
+}
+
 func TestOAuth2Session_SetSubject(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+)
+
+// User represents a user row in the user database of the SQL authentication backend.
+type User struct {
+	ID          int       `db:"id"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+	Username    string    `db:"username"`
+	DisplayName string    `db:"display_name"`
+	Email       string    `db:"email"`
+	Password    string    `db:"password"`
+	Disabled    bool      `db:"disabled"`
+
+	// Groups is populated from the user groups table.
+	Groups []string `db:"-"`
+}
+
+// GetEmails returns the users emails as a slice.
+func (u User) GetEmails() (emails []string) {
+	if u.Email == "" {
+		return nil
+	}
+
+	return []string{u.Email}
+}
@@ -1,10 +1,19 @@
 package oidc
 
+import (
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
+)
+
 func NewAuthenticationMethodsReferencesFromClaim(claim []string) (amr AuthenticationMethodsReferences) {
 	for _, ref := range claim {
 		switch ref {
 		case AMRPasswordBasedAuthentication:
 			amr.UsernameAndPassword = true
+		case AMRWindowsIntegratedAuthentication:
+			amr.Kerberos = true
+		case AMRSmartCard:
+			amr.Certificate = true
 		case AMROneTimePassword:
 			amr.TOTP = true
 		case AMRShortMessageService:
@@ -28,6 +37,8 @@ func NewAuthenticationMethodsReferencesFromClaim(claim []string) (amr Authentica
 // AuthenticationMethodsReferences holds AMR information.
 type AuthenticationMethodsReferences struct {
 	UsernameAndPassword  bool
+	Kerberos             bool
+	Certificate          bool
 	TOTP                 bool
 	Duo                  bool
 	WebAuthn             bool
@@ -39,12 +50,12 @@ type AuthenticationMethodsReferences struct {
 
 // FactorKnowledge returns true if a "something you know" factor of authentication was used.
 func (r AuthenticationMethodsReferences) FactorKnowledge() bool {
-	return r.UsernameAndPassword
+	return r.UsernameAndPassword || r.Kerberos
 }
 
 // FactorPossession returns true if a "something you have" factor of authentication was used.
 func (r AuthenticationMethodsReferences) FactorPossession() bool {
-	return r.TOTP || r.Duo || r.WebAuthn || r.WebAuthnHardware || r.WebAuthnSoftware
+	return r.Certificate || r.TOTP || r.Duo || r.WebAuthn || r.WebAuthnHardware || r.WebAuthnSoftware
 }
 
 // MultiFactorAuthentication returns true if multiple factors were used.
@@ -52,9 +63,21 @@ func (r AuthenticationMethodsReferences) MultiFactorAuthentication() bool {
 	return r.FactorKnowledge() && r.FactorPossession()
 }
 
+// AuthenticationLevel returns the authentication.Level the references represent.
+func (r AuthenticationMethodsReferences) AuthenticationLevel() authentication.Level {
+	switch {
+	case r.MultiFactorAuthentication():
+		return authentication.TwoFactor
+	case r.FactorKnowledge(), r.FactorPossession():
+		return authentication.OneFactor
+	default:
+		return authentication.NotAuthenticated
+	}
+}
+
 // ChannelBrowser returns true if a browser was used to authenticate.
 func (r AuthenticationMethodsReferences) ChannelBrowser() bool {
-	return r.UsernameAndPassword || r.TOTP || r.WebAuthn || r.WebAuthnHardware || r.WebAuthnSoftware
+	return r.UsernameAndPassword || r.Kerberos || r.Certificate || r.TOTP || r.WebAuthn || r.WebAuthnHardware || r.WebAuthnSoftware
 }
 
 // ChannelService returns true if a non-browser service was used to authenticate.
@@ -67,6 +90,42 @@ func (r AuthenticationMethodsReferences) MultiChannelAuthentication() bool {
 	return r.ChannelBrowser() && r.ChannelService()
 }
 
+// SatisfiesSecondFactorMethods returns true if at least one of the second factor methods was used, or if there are no
+// methods.
+func (r AuthenticationMethodsReferences) SatisfiesSecondFactorMethods(methods []string) bool {
+	if len(methods) == 0 {
+		return true
+	}
+
+	for _, method := range methods {
+		if r.SatisfiesSecondFactorMethod(method) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SatisfiesSecondFactorMethod returns true if the second factor method was used.
+func (r AuthenticationMethodsReferences) SatisfiesSecondFactorMethod(method string) bool {
+	switch method {
+	case authorization.SecondFactorMethodTOTP:
+		return r.TOTP
+	case authorization.SecondFactorMethodDuo:
+		return r.Duo
+	case authorization.SecondFactorMethodWebAuthn, authorization.SecondFactorMethodPhishingResistant:
+		return r.WebAuthn || r.WebAuthnHardware || r.WebAuthnSoftware
+	case authorization.SecondFactorMethodWebAuthnHardware:
+		return r.WebAuthnHardware
+	case authorization.SecondFactorMethodWebAuthnSoftware:
+		return r.WebAuthnSoftware
+	case authorization.SecondFactorMethodWebAuthnUserVerified:
+		return r.WebAuthnUserVerified && (r.WebAuthn || r.WebAuthnHardware || r.WebAuthnSoftware)
+	default:
+		return false
+	}
+}
+
 // MarshalRFC8176 returns the AMR claim slice of strings in the RFC8176 format.
 // https://datatracker.ietf.org/doc/html/rfc8176
 func (r AuthenticationMethodsReferences) MarshalRFC8176() []string {
@@ -76,6 +135,14 @@ func (r AuthenticationMethodsReferences) MarshalRFC8176() []string {
 		amr = append(amr, AMRPasswordBasedAuthentication)
 	}
 
+	if r.Kerberos {
+		amr = append(amr, AMRWindowsIntegratedAuthentication)
+	}
+
+	if r.Certificate {
+		amr = append(amr, AMRSmartCard)
+	}
+
 	if r.TOTP {
 		amr = append(amr, AMROneTimePassword)
 	}
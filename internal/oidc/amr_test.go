@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/oidc"
 )
 
@@ -25,6 +26,16 @@ func TestNewAuthenticationMethodsReferencesFromClaim(t *testing.T) {
 			[]string{"pop", "hwk", "mca", "mfa", "pwd", "sms", "user"},
 			oidc.AuthenticationMethodsReferences{WebAuthn: true, WebAuthnHardware: true, UsernameAndPassword: true, Duo: true, WebAuthnUserVerified: true},
 		},
+		{
+			"ShouldHandleCertificate",
+			[]string{"sc"},
+			oidc.AuthenticationMethodsReferences{Certificate: true},
+		},
+		{
+			"ShouldHandleKerberos",
+			[]string{"wia", "otp", "mfa"},
+			oidc.AuthenticationMethodsReferences{Kerberos: true, TOTP: true},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -35,6 +46,14 @@ func TestNewAuthenticationMethodsReferencesFromClaim(t *testing.T) {
 	}
 }
 
+func TestAuthenticationMethodsReferences_AuthenticationLevel(t *testing.T) {
+	assert.Equal(t, authentication.NotAuthenticated, oidc.AuthenticationMethodsReferences{}.AuthenticationLevel())
+	assert.Equal(t, authentication.NotAuthenticated, oidc.AuthenticationMethodsReferences{WebAuthnUserPresence: true}.AuthenticationLevel())
+	assert.Equal(t, authentication.OneFactor, oidc.AuthenticationMethodsReferences{UsernameAndPassword: true}.AuthenticationLevel())
+	assert.Equal(t, authentication.OneFactor, oidc.AuthenticationMethodsReferences{WebAuthn: true, WebAuthnHardware: true}.AuthenticationLevel())
+	assert.Equal(t, authentication.TwoFactor, oidc.AuthenticationMethodsReferences{UsernameAndPassword: true, TOTP: true}.AuthenticationLevel())
+}
+
 func TestAuthenticationMethodsReferences(t *testing.T) {
 	testCases := []struct {
 		desc string
@@ -56,6 +75,48 @@ func TestAuthenticationMethodsReferences(t *testing.T) {
 				RFC8176:                    []string{"pwd"},
 			},
 		},
+		{
+			desc: "Kerberos",
+
+			is: oidc.AuthenticationMethodsReferences{Kerberos: true},
+			want: testAMRWant{
+				FactorKnowledge:            true,
+				FactorPossession:           false,
+				MultiFactorAuthentication:  false,
+				ChannelBrowser:             true,
+				ChannelService:             false,
+				MultiChannelAuthentication: false,
+				RFC8176:                    []string{"wia"},
+			},
+		},
+		{
+			desc: "Kerberos with TOTP",
+
+			is: oidc.AuthenticationMethodsReferences{Kerberos: true, TOTP: true},
+			want: testAMRWant{
+				FactorKnowledge:            true,
+				FactorPossession:           true,
+				MultiFactorAuthentication:  true,
+				ChannelBrowser:             true,
+				ChannelService:             false,
+				MultiChannelAuthentication: false,
+				RFC8176:                    []string{"wia", "otp", "mfa"},
+			},
+		},
+		{
+			desc: "Certificate",
+
+			is: oidc.AuthenticationMethodsReferences{Certificate: true},
+			want: testAMRWant{
+				FactorKnowledge:            false,
+				FactorPossession:           true,
+				MultiFactorAuthentication:  false,
+				ChannelBrowser:             true,
+				ChannelService:             false,
+				MultiChannelAuthentication: false,
+				RFC8176:                    []string{"sc"},
+			},
+		},
 		{
 			desc: "TOTP",
 
@@ -230,3 +291,33 @@ type testAMRWant struct {
 
 	RFC8176 []string
 }
+
+func TestAuthenticationMethodsReferences_SatisfiesSecondFactorMethods(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     oidc.AuthenticationMethodsReferences
+		methods  []string
+		expected bool
+	}{
+		{"ShouldSatisfyNoMethods", oidc.AuthenticationMethodsReferences{UsernameAndPassword: true}, nil, true},
+		{"ShouldSatisfyTOTP", oidc.AuthenticationMethodsReferences{TOTP: true}, []string{"totp"}, true},
+		{"ShouldNotSatisfyTOTP", oidc.AuthenticationMethodsReferences{Duo: true}, []string{"totp"}, false},
+		{"ShouldSatisfyDuo", oidc.AuthenticationMethodsReferences{Duo: true}, []string{"mobile_push"}, true},
+		{"ShouldSatisfyWebAuthn", oidc.AuthenticationMethodsReferences{WebAuthn: true, WebAuthnSoftware: true}, []string{"webauthn"}, true},
+		{"ShouldSatisfyWebAuthnHardware", oidc.AuthenticationMethodsReferences{WebAuthn: true, WebAuthnHardware: true}, []string{"webauthn_hardware"}, true},
+		{"ShouldNotSatisfyWebAuthnHardware", oidc.AuthenticationMethodsReferences{WebAuthn: true, WebAuthnSoftware: true}, []string{"webauthn_hardware"}, false},
+		{"ShouldSatisfyWebAuthnSoftware", oidc.AuthenticationMethodsReferences{WebAuthn: true, WebAuthnSoftware: true}, []string{"webauthn_software"}, true},
+		{"ShouldSatisfyWebAuthnUserVerified", oidc.AuthenticationMethodsReferences{WebAuthn: true, WebAuthnUserVerified: true}, []string{"webauthn_user_verified"}, true},
+		{"ShouldNotSatisfyWebAuthnUserVerified", oidc.AuthenticationMethodsReferences{WebAuthn: true, WebAuthnUserPresence: true}, []string{"webauthn_user_verified"}, false},
+		{"ShouldSatisfyPhishingResistant", oidc.AuthenticationMethodsReferences{WebAuthn: true}, []string{"phishing_resistant"}, true},
+		{"ShouldNotSatisfyPhishingResistant", oidc.AuthenticationMethodsReferences{TOTP: true, Duo: true}, []string{"phishing_resistant"}, false},
+		{"ShouldSatisfyAnyMethod", oidc.AuthenticationMethodsReferences{TOTP: true}, []string{"webauthn_hardware", "totp"}, true},
+		{"ShouldNotSatisfyUnknownMethod", oidc.AuthenticationMethodsReferences{TOTP: true}, []string{"unknown"}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.have.SatisfiesSecondFactorMethods(tc.methods))
+		})
+	}
+}
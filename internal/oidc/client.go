@@ -12,6 +12,7 @@ import (
 	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // NewClient creates a new Client.
@@ -22,13 +23,15 @@ func NewClient(config schema.IdentityProvidersOpenIDConnectClient, c *schema.Ide
 		SectorIdentifierURI: config.SectorIdentifierURI,
 		Public:              config.Public,
 
-		Audience:      config.Audience,
-		Scopes:        config.Scopes,
-		RedirectURIs:  config.RedirectURIs,
-		RequestURIs:   config.RequestURIs,
-		GrantTypes:    config.GrantTypes,
-		ResponseTypes: config.ResponseTypes,
-		ResponseModes: []oauthelia2.ResponseModeType{},
+		Audience:     config.Audience,
+		Scopes:       config.Scopes,
+		RedirectURIs: config.RedirectURIs,
+		RequestURIs:  config.RequestURIs,
+
+		PostLogoutRedirectURIs: config.PostLogoutRedirectURIs,
+		GrantTypes:             config.GrantTypes,
+		ResponseTypes:          config.ResponseTypes,
+		ResponseModes:          []oauthelia2.ResponseModeType{},
 
 		RequirePKCE:                config.RequirePKCE || config.PKCEChallengeMethod != "",
 		RequirePKCEChallengeMethod: config.PKCEChallengeMethod != "",
@@ -41,6 +44,7 @@ func NewClient(config schema.IdentityProvidersOpenIDConnectClient, c *schema.Ide
 		AuthorizationPolicy:   NewClientAuthorizationPolicy(config.AuthorizationPolicy, c),
 		ConsentPolicy:         NewClientConsentPolicy(config.ConsentMode, config.ConsentPreConfiguredDuration),
 		RequestedAudienceMode: NewClientRequestedAudienceMode(config.RequestedAudienceMode),
+		TokenExchangePolicy:   NewClientTokenExchangePolicy(config.TokenExchange),
 
 		AuthorizationSignedResponseAlg:   config.AuthorizationSignedResponseAlg,
 		AuthorizationSignedResponseKeyID: config.AuthorizationSignedResponseKeyID,
@@ -58,6 +62,11 @@ func NewClient(config schema.IdentityProvidersOpenIDConnectClient, c *schema.Ide
 
 		JSONWebKeysURI: config.JSONWebKeysURI,
 		JSONWebKeys:    NewPublicJSONWebKeySetFromSchemaJWK(config.JSONWebKeys),
+
+		BackChannelLogoutURI:              config.BackChannelLogoutURI,
+		BackChannelLogoutSessionRequired:  config.BackChannelLogoutSessionRequired,
+		FrontChannelLogoutURI:             config.FrontChannelLogoutURI,
+		FrontChannelLogoutSessionRequired: config.FrontChannelLogoutSessionRequired,
 	}
 
 	if config.Secret != nil && config.Secret.Digest != nil {
@@ -121,6 +130,44 @@ func (c *RegisteredClient) GetRedirectURIs() (redirectURIs []string) {
 	return c.RedirectURIs
 }
 
+// GetPostLogoutRedirectURIs returns the PostLogoutRedirectURIs.
+func (c *RegisteredClient) GetPostLogoutRedirectURIs() (redirectURIs []string) {
+	return c.PostLogoutRedirectURIs
+}
+
+// IsPostLogoutRedirectURIAllowed returns true if the redirect URI exactly matches one of the PostLogoutRedirectURIs.
+func (c *RegisteredClient) IsPostLogoutRedirectURIAllowed(redirectURI string) (allowed bool) {
+	return redirectURI != "" && utils.IsStringInSlice(redirectURI, c.PostLogoutRedirectURIs)
+}
+
+// GetBackChannelLogoutURI returns the BackChannelLogoutURI.
+func (c *RegisteredClient) GetBackChannelLogoutURI() (uri string) {
+	if c.BackChannelLogoutURI == nil {
+		return ""
+	}
+
+	return c.BackChannelLogoutURI.String()
+}
+
+// GetBackChannelLogoutSessionRequired returns the BackChannelLogoutSessionRequired.
+func (c *RegisteredClient) GetBackChannelLogoutSessionRequired() (required bool) {
+	return c.BackChannelLogoutSessionRequired
+}
+
+// GetFrontChannelLogoutURI returns the FrontChannelLogoutURI.
+func (c *RegisteredClient) GetFrontChannelLogoutURI() (uri string) {
+	if c.FrontChannelLogoutURI == nil {
+		return ""
+	}
+
+	return c.FrontChannelLogoutURI.String()
+}
+
+// GetFrontChannelLogoutSessionRequired returns the FrontChannelLogoutSessionRequired.
+func (c *RegisteredClient) GetFrontChannelLogoutSessionRequired() (required bool) {
+	return c.FrontChannelLogoutSessionRequired
+}
+
 // GetGrantTypes returns the GrantTypes.
 func (c *RegisteredClient) GetGrantTypes() (types oauthelia2.Arguments) {
 	if len(c.GrantTypes) == 0 {
@@ -328,6 +375,13 @@ func (c *RegisteredClient) GetConsentResponseBody(consent *model.OAuth2ConsentSe
 	if consent != nil {
 		body.Scopes = consent.RequestedScopes
 		body.Audience = consent.RequestedAudience
+
+		// Device Authorization Requests must always be explicitly confirmed by the End-User, so the User Code is shown
+		// and consent pre-configuration is not offered.
+		if form, err := consent.GetForm(); err == nil && form.Has(FormParameterUserCode) {
+			body.UserCode = form.Get(FormParameterUserCode)
+			body.PreConfiguration = false
+		}
 	}
 
 	return body
@@ -357,6 +411,11 @@ func (c *RegisteredClient) GetAuthorizationPolicy() (policy ClientAuthorizationP
 	return c.AuthorizationPolicy
 }
 
+// GetTokenExchangePolicy returns the ClientTokenExchangePolicy.
+func (c *RegisteredClient) GetTokenExchangePolicy() (policy ClientTokenExchangePolicy) {
+	return c.TokenExchangePolicy
+}
+
 // IsPublic returns the value of the Public property.
 func (c *RegisteredClient) IsPublic() (public bool) {
 	return c.Public
@@ -502,6 +561,10 @@ func (c *RegisteredClient) getGrantTypeLifespan(gt oauthelia2.GrantType) (gtl sc
 		return c.Lifespans.Grants.RefreshToken
 	case oauthelia2.GrantTypeJWTBearer:
 		return c.Lifespans.Grants.JWTBearer
+	case GrantTypeDeviceCode:
+		return c.Lifespans.Grants.DeviceCode
+	case GrantTypeTokenExchange:
+		return c.Lifespans.Grants.TokenExchange
 	default:
 		return gtl
 	}
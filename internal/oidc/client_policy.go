@@ -5,6 +5,7 @@ import (
 
 	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // NewClientAuthorizationPolicy creates a new ClientAuthorizationPolicy.
@@ -59,6 +60,15 @@ func NewClientRequestedAudienceMode(mode string) ClientRequestedAudienceMode {
 	}
 }
 
+// NewClientTokenExchangePolicy converts the config options into an oidc.ClientTokenExchangePolicy.
+func NewClientTokenExchangePolicy(config schema.IdentityProvidersOpenIDConnectClientTokenExchange) ClientTokenExchangePolicy {
+	return ClientTokenExchangePolicy{
+		SubjectTokenAudiences: config.SubjectTokenAudiences,
+		TargetAudiences:       config.TargetAudiences,
+		ActorTokenClients:     config.ActorTokenClients,
+	}
+}
+
 // ClientAuthorizationPolicy controls and represents a client policy.
 type ClientAuthorizationPolicy struct {
 	Name          string
@@ -107,6 +117,34 @@ func (p *ClientAuthorizationPolicyRule) IsMatch(subject authorization.Subject) (
 	return p.MatchesSubjects(subject)
 }
 
+// ClientTokenExchangePolicy is the Token Exchange configuration for a client.
+type ClientTokenExchangePolicy struct {
+	SubjectTokenAudiences []string
+	TargetAudiences       []string
+	ActorTokenClients     []string
+}
+
+// IsSubjectTokenAllowed returns true if a subject token issued to the provided client id with the provided granted
+// audience is permitted to be exchanged by the client.
+func (p *ClientTokenExchangePolicy) IsSubjectTokenAllowed(clientID string, audience []string) (allowed bool) {
+	if len(p.SubjectTokenAudiences) == 0 {
+		return false
+	}
+
+	return utils.IsStringInSlice(clientID, p.SubjectTokenAudiences) || utils.IsStringSliceContainsAny(audience, p.SubjectTokenAudiences)
+}
+
+// IsActorTokenAllowed returns true if an actor token issued to the provided client id is permitted to be used by the
+// client with the provided client id. Actor tokens issued to the client itself are always permitted.
+func (p *ClientTokenExchangePolicy) IsActorTokenAllowed(clientID, actorClientID string) (allowed bool) {
+	return clientID == actorClientID || utils.IsStringInSlice(actorClientID, p.ActorTokenClients)
+}
+
+// IsTargetAudienceAllowed returns true if the requested audience is a permitted target audience for the client.
+func (p *ClientTokenExchangePolicy) IsTargetAudienceAllowed(audience string) (allowed bool) {
+	return utils.IsStringInSlice(audience, p.TargetAudiences)
+}
+
 // ClientConsentPolicy is the consent configuration for a client.
 type ClientConsentPolicy struct {
 	Mode     ClientConsentMode
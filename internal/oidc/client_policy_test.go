@@ -198,3 +198,62 @@ func TestNewClientRequestedAudienceMode(t *testing.T) {
 
 	assert.Equal(t, "", oidc.ClientConsentMode(-1).String())
 }
+
+func TestNewClientTokenExchangePolicy(t *testing.T) {
+	policy := oidc.NewClientTokenExchangePolicy(schema.IdentityProvidersOpenIDConnectClientTokenExchange{
+		SubjectTokenAudiences: []string{"frontend", "https://api.example.com"},
+		TargetAudiences:       []string{"https://orders.example.com", "https://billing.example.com"},
+		ActorTokenClients:     []string{"gateway"},
+	})
+
+	assert.Equal(t, []string{"frontend", "https://api.example.com"}, policy.SubjectTokenAudiences)
+	assert.Equal(t, []string{"https://orders.example.com", "https://billing.example.com"}, policy.TargetAudiences)
+	assert.Equal(t, []string{"gateway"}, policy.ActorTokenClients)
+
+	testCases := []struct {
+		name     string
+		clientID string
+		audience []string
+		expected bool
+	}{
+		{
+			"ShouldAllowSubjectTokenIssuedToClient",
+			"frontend",
+			nil,
+			true,
+		},
+		{
+			"ShouldAllowSubjectTokenWithGrantedAudience",
+			"other",
+			[]string{"https://api.example.com"},
+			true,
+		},
+		{
+			"ShouldNotAllowSubjectTokenWithOtherAudience",
+			"other",
+			[]string{"https://other.example.com"},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, policy.IsSubjectTokenAllowed(tc.clientID, tc.audience))
+		})
+	}
+
+	assert.True(t, policy.IsTargetAudienceAllowed("https://orders.example.com"))
+	assert.True(t, policy.IsTargetAudienceAllowed("https://billing.example.com"))
+	assert.False(t, policy.IsTargetAudienceAllowed("https://other.example.com"))
+
+	assert.True(t, policy.IsActorTokenAllowed("backend", "backend"))
+	assert.True(t, policy.IsActorTokenAllowed("backend", "gateway"))
+	assert.False(t, policy.IsActorTokenAllowed("backend", "frontend"))
+
+	empty := oidc.NewClientTokenExchangePolicy(schema.IdentityProvidersOpenIDConnectClientTokenExchange{})
+
+	assert.False(t, empty.IsSubjectTokenAllowed("frontend", []string{"https://api.example.com"}))
+	assert.False(t, empty.IsTargetAudienceAllowed("https://orders.example.com"))
+	assert.True(t, empty.IsActorTokenAllowed("backend", "backend"))
+	assert.False(t, empty.IsActorTokenAllowed("backend", "gateway"))
+}
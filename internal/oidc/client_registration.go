@@ -0,0 +1,324 @@
+package oidc
+
+import (
+	"context"
+	"crypto/hmac"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/pbkdf2"
+	"github.com/google/uuid"
+
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/random"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// ClientRegistrationMetadata is the subset of the OAuth 2.0 Dynamic Client Registration Client Metadata which is
+// supported by this provider. All other metadata values are ignored.
+//
+// https://datatracker.ietf.org/doc/html/rfc7591#section-2
+type ClientRegistrationMetadata struct {
+	ClientName              string   `json:"client_name,omitempty"`
+	RedirectURIs            []string `json:"redirect_uris,omitempty"`
+	PostLogoutRedirectURIs  []string `json:"post_logout_redirect_uris,omitempty"`
+	GrantTypes              []string `json:"grant_types,omitempty"`
+	ResponseTypes           []string `json:"response_types,omitempty"`
+	Scope                   string   `json:"scope,omitempty"`
+	TokenEndpointAuthMethod string   `json:"token_endpoint_auth_method,omitempty"`
+}
+
+// ClientRegistrationResponse is the Client Information Response returned by the Dynamic Client Registration Endpoint
+// and the Client Configuration Endpoint.
+//
+// https://datatracker.ietf.org/doc/html/rfc7591#section-3.2.1 and
+// https://datatracker.ietf.org/doc/html/rfc7592#section-3
+type ClientRegistrationResponse struct {
+	ClientID                string `json:"client_id"`
+	ClientSecret            string `json:"client_secret,omitempty"`
+	ClientIDIssuedAt        int64  `json:"client_id_issued_at"`
+	ClientSecretExpiresAt   int64  `json:"client_secret_expires_at"`
+	RegistrationAccessToken string `json:"registration_access_token"`
+	RegistrationClientURI   string `json:"registration_client_uri"`
+
+	ClientRegistrationMetadata
+}
+
+// IsPublic returns true if the metadata describes a public client.
+func (m *ClientRegistrationMetadata) IsPublic() bool {
+	return m.TokenEndpointAuthMethod == ClientAuthMethodNone
+}
+
+// GetScopes returns the space delimited scope value as a slice.
+func (m *ClientRegistrationMetadata) GetScopes() (scopes []string) {
+	return strings.Fields(m.Scope)
+}
+
+// Validate validates the metadata and applies the defaults described by RFC7591. The error returned is an
+// *oauthelia2.RFC6749Error suitable for the Client Registration Error Response.
+//
+//nolint:gocyclo // Complexity is necessary to remain readable.
+func (m *ClientRegistrationMetadata) Validate() (err error) {
+	switch m.TokenEndpointAuthMethod {
+	case "":
+		m.TokenEndpointAuthMethod = ClientAuthMethodClientSecretBasic
+	case ClientAuthMethodNone, ClientAuthMethodClientSecretBasic, ClientAuthMethodClientSecretPost:
+		break
+	default:
+		return ErrInvalidClientMetadata.WithHintf("The 'token_endpoint_auth_method' value '%s' is not supported.", m.TokenEndpointAuthMethod)
+	}
+
+	if len(m.GrantTypes) == 0 {
+		m.GrantTypes = []string{GrantTypeAuthorizationCode}
+	}
+
+	for _, grantType := range m.GrantTypes {
+		switch grantType {
+		case GrantTypeAuthorizationCode, GrantTypeRefreshToken, GrantTypeDeviceCode:
+			break
+		case GrantTypeClientCredentials:
+			if m.IsPublic() {
+				return ErrInvalidClientMetadata.WithHintf("The 'grant_types' value '%s' is not permitted for public clients.", grantType)
+			}
+		default:
+			return ErrInvalidClientMetadata.WithHintf("The 'grant_types' value '%s' is not supported.", grantType)
+		}
+	}
+
+	code := utils.IsStringInSlice(GrantTypeAuthorizationCode, m.GrantTypes)
+
+	if len(m.ResponseTypes) == 0 && code {
+		m.ResponseTypes = []string{ResponseTypeAuthorizationCodeFlow}
+	}
+
+	for _, responseType := range m.ResponseTypes {
+		if responseType != ResponseTypeAuthorizationCodeFlow {
+			return ErrInvalidClientMetadata.WithHintf("The 'response_types' value '%s' is not supported.", responseType)
+		}
+	}
+
+	if code != utils.IsStringInSlice(ResponseTypeAuthorizationCodeFlow, m.ResponseTypes) {
+		return ErrInvalidClientMetadata.WithHintf("The 'grant_types' value '%s' must be registered with the 'response_types' value '%s' and vice versa.", GrantTypeAuthorizationCode, ResponseTypeAuthorizationCodeFlow)
+	}
+
+	if code && len(m.RedirectURIs) == 0 {
+		return ErrInvalidRedirectURI.WithHintf("The 'redirect_uris' value is required when using the 'grant_types' value '%s'.", GrantTypeAuthorizationCode)
+	}
+
+	for _, redirectURI := range m.RedirectURIs {
+		if err = ValidateRegistrationRedirectURI(redirectURI, m.IsPublic()); err != nil {
+			return ErrInvalidRedirectURI.WithHintf("The 'redirect_uris' value '%s' is invalid: %s.", redirectURI, err)
+		}
+	}
+
+	for _, redirectURI := range m.PostLogoutRedirectURIs {
+		if err = ValidateRegistrationRedirectURI(redirectURI, m.IsPublic()); err != nil {
+			return ErrInvalidClientMetadata.WithHintf("The 'post_logout_redirect_uris' value '%s' is invalid: %s.", redirectURI, err)
+		}
+	}
+
+	ccg := utils.IsStringInSlice(GrantTypeClientCredentials, m.GrantTypes)
+
+	if m.Scope == "" && !ccg {
+		m.Scope = strings.Join(schema.DefaultOpenIDConnectClientConfiguration.Scopes, " ")
+	}
+
+	scopes := m.GetScopes()
+
+	for _, scope := range scopes {
+		switch scope {
+		case ScopeOpenID, ScopeOffline, ScopeOfflineAccess:
+			if ccg {
+				return ErrInvalidClientMetadata.WithHintf("The 'scope' value '%s' is not permitted when using the 'grant_types' value '%s'.", scope, GrantTypeClientCredentials)
+			}
+		case ScopeProfile, ScopeEmail, ScopeGroups, ScopePhone, ScopeAddress:
+			break
+		default:
+			return ErrInvalidClientMetadata.WithHintf("The 'scope' value '%s' is not supported.", scope)
+		}
+	}
+
+	if utils.IsStringSliceContainsAny([]string{ScopeOffline, ScopeOfflineAccess}, scopes) != utils.IsStringInSlice(GrantTypeRefreshToken, m.GrantTypes) {
+		return ErrInvalidClientMetadata.WithHintf("The 'grant_types' value '%s' must be registered with the 'scope' value '%s' and vice versa.", GrantTypeRefreshToken, ScopeOfflineAccess)
+	}
+
+	return nil
+}
+
+// ToClientConfiguration returns the metadata as a schema.IdentityProvidersOpenIDConnectClient using the provider
+// defaults for every option which can't be registered. The metadata must have been validated beforehand.
+func (m *ClientRegistrationMetadata) ToClientConfiguration(id string, secret *schema.PasswordDigest, config *schema.IdentityProvidersOpenIDConnect) (client schema.IdentityProvidersOpenIDConnectClient) {
+	client = schema.IdentityProvidersOpenIDConnectClient{
+		ID:                             id,
+		Name:                           m.ClientName,
+		Secret:                         secret,
+		Public:                         m.IsPublic(),
+		RedirectURIs:                   m.RedirectURIs,
+		PostLogoutRedirectURIs:         m.PostLogoutRedirectURIs,
+		Scopes:                         m.GetScopes(),
+		GrantTypes:                     m.GrantTypes,
+		ResponseTypes:                  m.ResponseTypes,
+		AuthorizationPolicy:            config.DynamicClientRegistration.AuthorizationPolicy,
+		Lifespan:                       config.DynamicClientRegistration.Lifespan,
+		RequestedAudienceMode:          ClientRequestedAudienceModeExplicit.String(),
+		ConsentMode:                    ClientConsentModeExplicit.String(),
+		AuthorizationSignedResponseAlg: SigningAlgNone,
+		IDTokenSignedResponseAlg:       SigningAlgRSAUsingSHA256,
+		AccessTokenSignedResponseAlg:   SigningAlgNone,
+		UserinfoSignedResponseAlg:      SigningAlgNone,
+		IntrospectionSignedResponseAlg: SigningAlgNone,
+		TokenEndpointAuthMethod:        m.TokenEndpointAuthMethod,
+	}
+
+	if client.Name == "" {
+		client.Name = id
+	}
+
+	if len(m.ResponseTypes) != 0 {
+		client.ResponseModes = []string{ResponseModeFormPost, ResponseModeQuery}
+	}
+
+	if utils.IsStringInSlice(GrantTypeClientCredentials, m.GrantTypes) {
+		client.AuthorizationPolicy = authorization.OneFactor.String()
+	}
+
+	return client
+}
+
+// NewClientRegistrationMetadataFromOAuth2Client decodes the metadata of a *model.OAuth2Client.
+func NewClientRegistrationMetadataFromOAuth2Client(registered *model.OAuth2Client) (metadata *ClientRegistrationMetadata, err error) {
+	metadata = &ClientRegistrationMetadata{}
+
+	if err = json.Unmarshal(registered.Metadata, metadata); err != nil {
+		return nil, fmt.Errorf("error decoding the metadata of the client with id '%s': %w", registered.ClientID, err)
+	}
+
+	return metadata, nil
+}
+
+// NewClientFromOAuth2Client returns a Client from a *model.OAuth2Client which was registered via the Dynamic Client
+// Registration Endpoint.
+func NewClientFromOAuth2Client(registered *model.OAuth2Client, config *schema.IdentityProvidersOpenIDConnect) (client Client, err error) {
+	var (
+		metadata *ClientRegistrationMetadata
+		secret   *schema.PasswordDigest
+	)
+
+	if metadata, err = NewClientRegistrationMetadataFromOAuth2Client(registered); err != nil {
+		return nil, err
+	}
+
+	if registered.ClientSecret.Valid {
+		if secret, err = schema.DecodePasswordDigest(registered.ClientSecret.String); err != nil {
+			return nil, fmt.Errorf("error decoding the secret of the client with id '%s': %w", registered.ClientID, err)
+		}
+	}
+
+	return NewClient(metadata.ToClientConfiguration(registered.ClientID, secret, config), config), nil
+}
+
+// NewOAuth2Client returns a *model.OAuth2Client for the given metadata and registration values.
+func NewOAuth2Client(clientID string, metadata *ClientRegistrationMetadata, secret *schema.PasswordDigest, signature string, now time.Time) (client *model.OAuth2Client, err error) {
+	client = &model.OAuth2Client{
+		ClientID:                         clientID,
+		ClientName:                       metadata.ClientName,
+		RegistrationAccessTokenSignature: signature,
+		CreatedAt:                        now,
+		UpdatedAt:                        now,
+	}
+
+	if secret != nil {
+		client.ClientSecret = sql.NullString{String: secret.Encode(), Valid: true}
+	}
+
+	if client.Metadata, err = json.Marshal(metadata); err != nil {
+		return nil, fmt.Errorf("error encoding the metadata of the client with id '%s': %w", clientID, err)
+	}
+
+	return client, nil
+}
+
+// GenerateRegisteredClientID generates a new client id for a client registered via the Dynamic Client Registration
+// Endpoint.
+func GenerateRegisteredClientID() (clientID string, err error) {
+	var id uuid.UUID
+
+	if id, err = uuid.NewRandom(); err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+// GenerateRegisteredClientSecret generates a new client secret and the digest of it for a client registered via the
+// Dynamic Client Registration Endpoint.
+func GenerateRegisteredClientSecret(ctx Context) (secret string, digest *schema.PasswordDigest, err error) {
+	if secret, err = ctx.GetRandom().StringCustomErr(RegisteredClientSecretEntropy, random.CharSetAlphaNumeric); err != nil {
+		return "", nil, err
+	}
+
+	var (
+		hasher *pbkdf2.Hasher
+		d      algorithm.Digest
+	)
+
+	if hasher, err = pbkdf2.New(pbkdf2.WithVariant(pbkdf2.VariantSHA512), pbkdf2.WithIterations(RegisteredClientSecretIterations)); err != nil {
+		return "", nil, err
+	}
+
+	if d, err = hasher.Hash(secret); err != nil {
+		return "", nil, err
+	}
+
+	return secret, schema.NewPasswordDigest(d), nil
+}
+
+// GenerateRegistrationAccessToken generates a new Registration Access Token.
+func GenerateRegistrationAccessToken(ctx Context) (token string, err error) {
+	var value string
+
+	if value, err = ctx.GetRandom().StringCustomErr(RegistrationAccessTokenEntropy, random.CharSetAlphaNumeric); err != nil {
+		return "", err
+	}
+
+	return RegistrationAccessTokenPrefix + value, nil
+}
+
+// RegistrationAccessTokenSignature returns the signature of a Registration Access Token which is stored alongside the
+// registered client.
+func (c *Config) RegistrationAccessTokenSignature(ctx context.Context, token string) (signature string, err error) {
+	return c.globalSecretSignature(ctx, token)
+}
+
+// IsRegistrationAccessTokenValid returns true if the token matches the signature stored for the registered client.
+func (c *Config) IsRegistrationAccessTokenValid(ctx context.Context, registered *model.OAuth2Client, token string) bool {
+	if !strings.HasPrefix(token, RegistrationAccessTokenPrefix) {
+		return false
+	}
+
+	signature, err := c.RegistrationAccessTokenSignature(ctx, token)
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal([]byte(signature), []byte(registered.RegistrationAccessTokenSignature))
+}
+
+// NewClientRegistrationResponse returns the Client Information Response for a registered client.
+func NewClientRegistrationResponse(issuer *url.URL, registered *model.OAuth2Client, metadata *ClientRegistrationMetadata, secret, token string) (response *ClientRegistrationResponse) {
+	return &ClientRegistrationResponse{
+		ClientID:                   registered.ClientID,
+		ClientSecret:               secret,
+		ClientIDIssuedAt:           registered.CreatedAt.Unix(),
+		RegistrationAccessToken:    token,
+		RegistrationClientURI:      issuer.JoinPath(EndpointPathRegistration, registered.ClientID).String(),
+		ClientRegistrationMetadata: *metadata,
+	}
+}
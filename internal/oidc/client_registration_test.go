@@ -0,0 +1,468 @@
+package oidc_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	oauthelia2 "authelia.com/provider/oauth2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestClientRegistrationMetadata_Validate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     oidc.ClientRegistrationMetadata
+		expected oidc.ClientRegistrationMetadata
+		err      string
+		hint     string
+	}{
+		{
+			"ShouldApplyDefaults",
+			oidc.ClientRegistrationMetadata{
+				RedirectURIs: []string{"https://app.example.com/callback"},
+			},
+			oidc.ClientRegistrationMetadata{
+				RedirectURIs:            []string{"https://app.example.com/callback"},
+				GrantTypes:              []string{oidc.GrantTypeAuthorizationCode},
+				ResponseTypes:           []string{oidc.ResponseTypeAuthorizationCodeFlow},
+				Scope:                   "openid groups profile email",
+				TokenEndpointAuthMethod: oidc.ClientAuthMethodClientSecretBasic,
+			},
+			"",
+			"",
+		},
+		{
+			"ShouldAllowPublicClientWithLoopbackRedirectURIs",
+			oidc.ClientRegistrationMetadata{
+				RedirectURIs:            []string{"http://127.0.0.1:8080/callback", "http://[::1]/callback", "http://localhost/callback"},
+				TokenEndpointAuthMethod: oidc.ClientAuthMethodNone,
+			},
+			oidc.ClientRegistrationMetadata{
+				RedirectURIs:            []string{"http://127.0.0.1:8080/callback", "http://[::1]/callback", "http://localhost/callback"},
+				GrantTypes:              []string{oidc.GrantTypeAuthorizationCode},
+				ResponseTypes:           []string{oidc.ResponseTypeAuthorizationCodeFlow},
+				Scope:                   "openid groups profile email",
+				TokenEndpointAuthMethod: oidc.ClientAuthMethodNone,
+			},
+			"",
+			"",
+		},
+		{
+			"ShouldAllowPublicClientWithRefreshToken",
+			oidc.ClientRegistrationMetadata{
+				RedirectURIs:            []string{"com.example.app:/callback"},
+				GrantTypes:              []string{oidc.GrantTypeAuthorizationCode, oidc.GrantTypeRefreshToken},
+				Scope:                   "openid offline_access",
+				TokenEndpointAuthMethod: oidc.ClientAuthMethodNone,
+			},
+			oidc.ClientRegistrationMetadata{
+				RedirectURIs:            []string{"com.example.app:/callback"},
+				GrantTypes:              []string{oidc.GrantTypeAuthorizationCode, oidc.GrantTypeRefreshToken},
+				ResponseTypes:           []string{oidc.ResponseTypeAuthorizationCodeFlow},
+				Scope:                   "openid offline_access",
+				TokenEndpointAuthMethod: oidc.ClientAuthMethodNone,
+			},
+			"",
+			"",
+		},
+		{
+			"ShouldAllowClientCredentialsWithoutScope",
+			oidc.ClientRegistrationMetadata{
+				GrantTypes: []string{oidc.GrantTypeClientCredentials},
+			},
+			oidc.ClientRegistrationMetadata{
+				GrantTypes:              []string{oidc.GrantTypeClientCredentials},
+				TokenEndpointAuthMethod: oidc.ClientAuthMethodClientSecretBasic,
+			},
+			"",
+			"",
+		},
+		{
+			"ShouldRaiseErrorBadAuthMethod",
+			oidc.ClientRegistrationMetadata{
+				RedirectURIs:            []string{"https://app.example.com/callback"},
+				TokenEndpointAuthMethod: oidc.ClientAuthMethodPrivateKeyJWT,
+			},
+			oidc.ClientRegistrationMetadata{},
+			"invalid_client_metadata",
+			"The 'token_endpoint_auth_method' value 'private_key_jwt' is not supported.",
+		},
+		{
+			"ShouldRaiseErrorPublicClientCredentials",
+			oidc.ClientRegistrationMetadata{
+				GrantTypes:              []string{oidc.GrantTypeClientCredentials},
+				TokenEndpointAuthMethod: oidc.ClientAuthMethodNone,
+			},
+			oidc.ClientRegistrationMetadata{},
+			"invalid_client_metadata",
+			"The 'grant_types' value 'client_credentials' is not permitted for public clients.",
+		},
+		{
+			"ShouldRaiseErrorBadGrantType",
+			oidc.ClientRegistrationMetadata{
+				GrantTypes: []string{oidc.GrantTypeImplicit},
+			},
+			oidc.ClientRegistrationMetadata{},
+			"invalid_client_metadata",
+			"The 'grant_types' value 'implicit' is not supported.",
+		},
+		{
+			"ShouldRaiseErrorBadResponseType",
+			oidc.ClientRegistrationMetadata{
+				RedirectURIs:  []string{"https://app.example.com/callback"},
+				ResponseTypes: []string{oidc.ResponseTypeImplicitFlowIDToken},
+			},
+			oidc.ClientRegistrationMetadata{},
+			"invalid_client_metadata",
+			"The 'response_types' value 'id_token' is not supported.",
+		},
+		{
+			"ShouldRaiseErrorCodeResponseTypeWithoutGrant",
+			oidc.ClientRegistrationMetadata{
+				GrantTypes:    []string{oidc.GrantTypeClientCredentials},
+				ResponseTypes: []string{oidc.ResponseTypeAuthorizationCodeFlow},
+			},
+			oidc.ClientRegistrationMetadata{},
+			"invalid_client_metadata",
+			"The 'grant_types' value 'authorization_code' must be registered with the 'response_types' value 'code' and vice versa.",
+		},
+		{
+			"ShouldRaiseErrorNoRedirectURIs",
+			oidc.ClientRegistrationMetadata{},
+			oidc.ClientRegistrationMetadata{},
+			"invalid_redirect_uri",
+			"The 'redirect_uris' value is required when using the 'grant_types' value 'authorization_code'.",
+		},
+		{
+			"ShouldRaiseErrorRelativeRedirectURI",
+			oidc.ClientRegistrationMetadata{
+				RedirectURIs: []string{"/callback"},
+			},
+			oidc.ClientRegistrationMetadata{},
+			"invalid_redirect_uri",
+			"The 'redirect_uris' value '/callback' is invalid: the redirect uri must have a scheme.",
+		},
+		{
+			"ShouldRaiseErrorRedirectURIFragment",
+			oidc.ClientRegistrationMetadata{
+				RedirectURIs: []string{"https://app.example.com/callback#fragment"},
+			},
+			oidc.ClientRegistrationMetadata{},
+			"invalid_redirect_uri",
+			"The 'redirect_uris' value 'https://app.example.com/callback#fragment' is invalid: the redirect uri must not have a fragment.",
+		},
+		{
+			"ShouldRaiseErrorJavaScriptRedirectURI",
+			oidc.ClientRegistrationMetadata{
+				RedirectURIs:            []string{"javascript:alert(1)"},
+				TokenEndpointAuthMethod: oidc.ClientAuthMethodNone,
+			},
+			oidc.ClientRegistrationMetadata{},
+			"invalid_redirect_uri",
+			"The 'redirect_uris' value 'javascript:alert(1)' is invalid: the redirect uri scheme is not permitted.",
+		},
+		{
+			"ShouldRaiseErrorDataRedirectURI",
+			oidc.ClientRegistrationMetadata{
+				RedirectURIs:            []string{"data:text/html,<script>alert(1)</script>"},
+				TokenEndpointAuthMethod: oidc.ClientAuthMethodNone,
+			},
+			oidc.ClientRegistrationMetadata{},
+			"invalid_redirect_uri",
+			"The 'redirect_uris' value 'data:text/html,<script>alert(1)</script>' is invalid: the redirect uri scheme is not permitted.",
+		},
+		{
+			"ShouldRaiseErrorFileRedirectURI",
+			oidc.ClientRegistrationMetadata{
+				RedirectURIs:            []string{"file:///etc/passwd"},
+				TokenEndpointAuthMethod: oidc.ClientAuthMethodNone,
+			},
+			oidc.ClientRegistrationMetadata{},
+			"invalid_redirect_uri",
+			"The 'redirect_uris' value 'file:///etc/passwd' is invalid: the redirect uri scheme is not permitted.",
+		},
+		{
+			"ShouldRaiseErrorHTTPRedirectURI",
+			oidc.ClientRegistrationMetadata{
+				RedirectURIs: []string{"http://app.example.com/callback"},
+			},
+			oidc.ClientRegistrationMetadata{},
+			"invalid_redirect_uri",
+			"The 'redirect_uris' value 'http://app.example.com/callback' is invalid: the redirect uri must use the 'https' scheme, or for a public client either a loopback 'http' uri or a private-use scheme.",
+		},
+		{
+			"ShouldRaiseErrorHTTPLoopbackRedirectURIConfidentialClient",
+			oidc.ClientRegistrationMetadata{
+				RedirectURIs: []string{"http://127.0.0.1:8080/callback"},
+			},
+			oidc.ClientRegistrationMetadata{},
+			"invalid_redirect_uri",
+			"The 'redirect_uris' value 'http://127.0.0.1:8080/callback' is invalid: the redirect uri must use the 'https' scheme, or for a public client either a loopback 'http' uri or a private-use scheme.",
+		},
+		{
+			"ShouldRaiseErrorHTTPNonLoopbackRedirectURIPublicClient",
+			oidc.ClientRegistrationMetadata{
+				RedirectURIs:            []string{"http://app.example.com/callback"},
+				TokenEndpointAuthMethod: oidc.ClientAuthMethodNone,
+			},
+			oidc.ClientRegistrationMetadata{},
+			"invalid_redirect_uri",
+			"The 'redirect_uris' value 'http://app.example.com/callback' is invalid: the redirect uri must use the 'https' scheme, or for a public client either a loopback 'http' uri or a private-use scheme.",
+		},
+		{
+			"ShouldRaiseErrorPrivateUseRedirectURIConfidentialClient",
+			oidc.ClientRegistrationMetadata{
+				RedirectURIs: []string{"com.example.app:/callback"},
+			},
+			oidc.ClientRegistrationMetadata{},
+			"invalid_redirect_uri",
+			"The 'redirect_uris' value 'com.example.app:/callback' is invalid: the redirect uri must use the 'https' scheme, or for a public client either a loopback 'http' uri or a private-use scheme.",
+		},
+		{
+			"ShouldRaiseErrorNonPrivateUseCustomSchemeRedirectURI",
+			oidc.ClientRegistrationMetadata{
+				RedirectURIs:            []string{"myapp:/callback"},
+				TokenEndpointAuthMethod: oidc.ClientAuthMethodNone,
+			},
+			oidc.ClientRegistrationMetadata{},
+			"invalid_redirect_uri",
+			"The 'redirect_uris' value 'myapp:/callback' is invalid: the redirect uri must use the 'https' scheme, or for a public client either a loopback 'http' uri or a private-use scheme.",
+		},
+		{
+			"ShouldRaiseErrorOutOfBandRedirectURI",
+			oidc.ClientRegistrationMetadata{
+				RedirectURIs:            []string{oidc.RedirectURISpecialOAuth2InstalledApp},
+				TokenEndpointAuthMethod: oidc.ClientAuthMethodNone,
+			},
+			oidc.ClientRegistrationMetadata{},
+			"invalid_redirect_uri",
+			"The 'redirect_uris' value 'urn:ietf:wg:oauth:2.0:oob' is invalid: the out-of-band redirect uri is not accepted for dynamic client registration.",
+		},
+		{
+			"ShouldRaiseErrorRelativePostLogoutRedirectURI",
+			oidc.ClientRegistrationMetadata{
+				RedirectURIs:           []string{"https://app.example.com/callback"},
+				PostLogoutRedirectURIs: []string{"/logout"},
+			},
+			oidc.ClientRegistrationMetadata{},
+			"invalid_client_metadata",
+			"The 'post_logout_redirect_uris' value '/logout' is invalid: the redirect uri must have a scheme.",
+		},
+		{
+			"ShouldRaiseErrorBadScope",
+			oidc.ClientRegistrationMetadata{
+				RedirectURIs: []string{"https://app.example.com/callback"},
+				Scope:        "openid authelia.bearer.authz",
+			},
+			oidc.ClientRegistrationMetadata{},
+			"invalid_client_metadata",
+			"The 'scope' value 'authelia.bearer.authz' is not supported.",
+		},
+		{
+			"ShouldRaiseErrorClientCredentialsOpenIDScope",
+			oidc.ClientRegistrationMetadata{
+				GrantTypes: []string{oidc.GrantTypeClientCredentials},
+				Scope:      "openid",
+			},
+			oidc.ClientRegistrationMetadata{},
+			"invalid_client_metadata",
+			"The 'scope' value 'openid' is not permitted when using the 'grant_types' value 'client_credentials'.",
+		},
+		{
+			"ShouldRaiseErrorOfflineAccessWithoutRefreshToken",
+			oidc.ClientRegistrationMetadata{
+				RedirectURIs: []string{"https://app.example.com/callback"},
+				Scope:        "openid offline_access",
+			},
+			oidc.ClientRegistrationMetadata{},
+			"invalid_client_metadata",
+			"The 'grant_types' value 'refresh_token' must be registered with the 'scope' value 'offline_access' and vice versa.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.have.Validate()
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, tc.have)
+			} else {
+				require.EqualError(t, err, tc.err)
+				assert.Equal(t, tc.hint, oauthelia2.ErrorToRFC6749Error(err).HintField)
+			}
+		})
+	}
+}
+
+func TestClientRegistrationMetadata_ToClientConfiguration(t *testing.T) {
+	config := &schema.IdentityProvidersOpenIDConnect{
+		DynamicClientRegistration: schema.IdentityProvidersOpenIDConnectDynamicClientRegistration{
+			Enabled:             true,
+			AuthorizationPolicy: "two_factor",
+			Lifespan:            "example",
+		},
+	}
+
+	metadata := &oidc.ClientRegistrationMetadata{
+		RedirectURIs: []string{"https://app.example.com/callback"},
+	}
+
+	require.NoError(t, metadata.Validate())
+
+	client := metadata.ToClientConfiguration("abc", nil, config)
+
+	assert.Equal(t, "abc", client.ID)
+	assert.Equal(t, "abc", client.Name)
+	assert.False(t, client.Public)
+	assert.Equal(t, "two_factor", client.AuthorizationPolicy)
+	assert.Equal(t, "example", client.Lifespan)
+	assert.Equal(t, []string{oidc.ScopeOpenID, oidc.ScopeGroups, oidc.ScopeProfile, oidc.ScopeEmail}, client.Scopes)
+	assert.Equal(t, []string{oidc.ResponseModeFormPost, oidc.ResponseModeQuery}, client.ResponseModes)
+	assert.Equal(t, oidc.ClientConsentModeExplicit.String(), client.ConsentMode)
+	assert.Equal(t, oidc.SigningAlgRSAUsingSHA256, client.IDTokenSignedResponseAlg)
+
+	metadata = &oidc.ClientRegistrationMetadata{
+		ClientName: "Service",
+		GrantTypes: []string{oidc.GrantTypeClientCredentials},
+	}
+
+	require.NoError(t, metadata.Validate())
+
+	client = metadata.ToClientConfiguration("service", nil, config)
+
+	assert.Equal(t, "Service", client.Name)
+	assert.Equal(t, authorization.OneFactor.String(), client.AuthorizationPolicy)
+	assert.Nil(t, client.ResponseModes)
+}
+
+func TestConfig_IsRegistrationAccessTokenValid(t *testing.T) {
+	ctx := context.Background()
+
+	config := &oidc.Config{GlobalSecret: []byte("a-very-long-and-secure-global-secret")}
+	other := &oidc.Config{GlobalSecret: []byte("another-very-long-and-secure-secret")}
+
+	token := oidc.RegistrationAccessTokenPrefix + "abc123"
+
+	signature, err := config.RegistrationAccessTokenSignature(ctx, token)
+	require.NoError(t, err)
+
+	registered := &model.OAuth2Client{ClientID: "abc", RegistrationAccessTokenSignature: signature}
+
+	assert.True(t, config.IsRegistrationAccessTokenValid(ctx, registered, token))
+	assert.False(t, config.IsRegistrationAccessTokenValid(ctx, registered, token+"a"))
+	assert.False(t, config.IsRegistrationAccessTokenValid(ctx, registered, "abc123"))
+	assert.False(t, other.IsRegistrationAccessTokenValid(ctx, registered, token))
+}
+
+func TestNewClientRegistrationResponse(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	metadata := &oidc.ClientRegistrationMetadata{
+		RedirectURIs: []string{"https://app.example.com/callback"},
+	}
+
+	require.NoError(t, metadata.Validate())
+
+	registered, err := oidc.NewOAuth2Client("abc", metadata, nil, "signature", now)
+	require.NoError(t, err)
+
+	assert.False(t, registered.ClientSecret.Valid)
+	assert.Equal(t, now, registered.CreatedAt)
+	assert.Equal(t, now, registered.UpdatedAt)
+
+	decoded, err := oidc.NewClientRegistrationMetadataFromOAuth2Client(registered)
+	require.NoError(t, err)
+	assert.Equal(t, metadata, decoded)
+
+	response := oidc.NewClientRegistrationResponse(&url.URL{Scheme: "https", Host: "auth.example.com"}, registered, metadata, "secret", "token")
+
+	assert.Equal(t, "abc", response.ClientID)
+	assert.Equal(t, "secret", response.ClientSecret)
+	assert.Equal(t, int64(1700000000), response.ClientIDIssuedAt)
+	assert.Equal(t, int64(0), response.ClientSecretExpiresAt)
+	assert.Equal(t, "token", response.RegistrationAccessToken)
+	assert.Equal(t, "https://auth.example.com/api/oidc/registration/abc", response.RegistrationClientURI)
+}
+
+func TestOpenIDConnectStore_GetRegisteredClient_DynamicClientRegistration(t *testing.T) {
+	config := &schema.IdentityProvidersOpenIDConnect{
+		IssuerCertificateChain: schema.X509CertificateChain{},
+		IssuerPrivateKey:       x509PrivateKeyRSA2048,
+		Clients: []schema.IdentityProvidersOpenIDConnectClient{
+			{
+				ID:                  myclient,
+				Name:                myclientdesc,
+				AuthorizationPolicy: onefactor,
+				Scopes:              []string{oidc.ScopeOpenID, oidc.ScopeProfile},
+				Secret:              tOpenIDConnectPlainTextClientSecret,
+			},
+		},
+		DynamicClientRegistration: schema.IdentityProvidersOpenIDConnectDynamicClientRegistration{
+			Enabled:             true,
+			AuthorizationPolicy: "two_factor",
+		},
+	}
+
+	metadata := &oidc.ClientRegistrationMetadata{
+		ClientName:              "Registered",
+		RedirectURIs:            []string{"https://app.example.com/callback"},
+		TokenEndpointAuthMethod: oidc.ClientAuthMethodNone,
+	}
+
+	require.NoError(t, metadata.Validate())
+
+	registered, err := oidc.NewOAuth2Client("registered", metadata, nil, "signature", time.Now())
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := mocks.NewMockStorage(ctrl)
+
+	ctx := context.Background()
+
+	s := oidc.NewStore(config, mock)
+
+	assert.True(t, s.IsDynamicClientRegistrationEnabled())
+
+	gomock.InOrder(
+		mock.EXPECT().LoadOAuth2Client(ctx, "registered").Return(registered, nil),
+		mock.EXPECT().LoadOAuth2Client(ctx, "missing").Return(nil, storage.ErrNoOAuth2Client),
+	)
+
+	client, err := s.GetRegisteredClient(ctx, myclient)
+	require.NoError(t, err)
+	assert.Equal(t, myclientdesc, client.GetName())
+
+	client, err = s.GetRegisteredClient(ctx, "registered")
+	require.NoError(t, err)
+	assert.Equal(t, "registered", client.GetID())
+	assert.Equal(t, "Registered", client.GetName())
+	assert.True(t, client.IsPublic())
+	assert.Equal(t, []string{"https://app.example.com/callback"}, client.GetRedirectURIs())
+	assert.Equal(t, authorization.TwoFactor, client.GetAuthorizationPolicyRequiredLevel(authorization.Subject{}))
+
+	client, err = s.GetRegisteredClient(ctx, "missing")
+	assert.Nil(t, client)
+	assert.EqualError(t, err, "invalid_client")
+
+	config.DynamicClientRegistration.Enabled = false
+
+	assert.False(t, s.IsDynamicClientRegistrationEnabled())
+
+	client, err = s.GetRegisteredClient(ctx, "registered")
+	assert.Nil(t, client)
+	assert.EqualError(t, err, "invalid_client")
+}
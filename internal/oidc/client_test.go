@@ -276,6 +276,18 @@ func TestClient_GetConsentResponseBody(t *testing.T) {
 	assert.Equal(t, myclientdesc, consentRequestBody.ClientDescription)
 	assert.Equal(t, expectedScopes, consentRequestBody.Scopes)
 	assert.Equal(t, expectedAudiences, consentRequestBody.Audience)
+	assert.Equal(t, "", consentRequestBody.UserCode)
+
+	c.ConsentPolicy = oidc.ClientConsentPolicy{Mode: oidc.ClientConsentModePreConfigured}
+
+	consentRequestBody = c.GetConsentResponseBody(consent)
+	assert.True(t, consentRequestBody.PreConfiguration)
+
+	consent.Form = "user_code=ABCD-EFGH"
+
+	consentRequestBody = c.GetConsentResponseBody(consent)
+	assert.Equal(t, "ABCD-EFGH", consentRequestBody.UserCode)
+	assert.False(t, consentRequestBody.PreConfiguration)
 }
 
 func TestClient_GetAudience(t *testing.T) {
@@ -351,6 +363,41 @@ func TestClient_GetRedirectURIs(t *testing.T) {
 	assert.Equal(t, examplecom, redirectURIs[0])
 }
 
+func TestClient_GetPostLogoutRedirectURIs(t *testing.T) {
+	c := &oidc.RegisteredClient{}
+
+	require.Len(t, c.GetPostLogoutRedirectURIs(), 0)
+	assert.False(t, c.IsPostLogoutRedirectURIAllowed(""))
+	assert.False(t, c.IsPostLogoutRedirectURIAllowed("https://app.example.com/logged-out"))
+
+	c.PostLogoutRedirectURIs = []string{"https://app.example.com/logged-out"}
+
+	require.Len(t, c.GetPostLogoutRedirectURIs(), 1)
+	assert.True(t, c.IsPostLogoutRedirectURIAllowed("https://app.example.com/logged-out"))
+	assert.False(t, c.IsPostLogoutRedirectURIAllowed("https://app.example.com/logged-out/"))
+	assert.False(t, c.IsPostLogoutRedirectURIAllowed("https://app.example.com/logged-out?next=1"))
+	assert.False(t, c.IsPostLogoutRedirectURIAllowed(""))
+}
+
+func TestClient_GetChannelLogout(t *testing.T) {
+	c := &oidc.RegisteredClient{}
+
+	assert.Equal(t, "", c.GetBackChannelLogoutURI())
+	assert.False(t, c.GetBackChannelLogoutSessionRequired())
+	assert.Equal(t, "", c.GetFrontChannelLogoutURI())
+	assert.False(t, c.GetFrontChannelLogoutSessionRequired())
+
+	c.BackChannelLogoutURI = MustParseRequestURI("https://app.example.com/backchannel-logout")
+	c.BackChannelLogoutSessionRequired = true
+	c.FrontChannelLogoutURI = MustParseRequestURI("https://app.example.com/frontchannel-logout")
+	c.FrontChannelLogoutSessionRequired = true
+
+	assert.Equal(t, "https://app.example.com/backchannel-logout", c.GetBackChannelLogoutURI())
+	assert.True(t, c.GetBackChannelLogoutSessionRequired())
+	assert.Equal(t, "https://app.example.com/frontchannel-logout", c.GetFrontChannelLogoutURI())
+	assert.True(t, c.GetFrontChannelLogoutSessionRequired())
+}
+
 func TestClient_GetResponseModes(t *testing.T) {
 	c := &oidc.RegisteredClient{}
 
@@ -18,6 +18,7 @@ import (
 	"authelia.com/provider/oauth2/token/jwt"
 	retryablehttp "github.com/hashicorp/go-retryablehttp"
 
+	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/templates"
 	"github.com/authelia/authelia/v4/internal/utils"
@@ -31,6 +32,8 @@ func NewConfig(config *schema.IdentityProvidersOpenIDConnect, signer jwt.Signer,
 		MinParameterEntropy:        config.MinimumParameterEntropy,
 		Lifespans: LifespansConfig{
 			IdentityProvidersOpenIDConnectLifespanToken: config.Lifespans.IdentityProvidersOpenIDConnectLifespanToken,
+			RFC8628Code:    config.Lifespans.DeviceCode,
+			RFC8628Polling: config.Lifespans.DeviceCodePollingInterval,
 		},
 		ProofKeyCodeExchange: ProofKeyCodeExchangeConfig{
 			Enforce:                   config.EnforcePKCE == "always",
@@ -298,6 +301,29 @@ func (c *Config) LoadHandlers(store *Store) {
 			OpenIDConnectRequestValidator: validator,
 			OpenIDConnectRequestStorage:   store,
 		},
+		&DeviceCodeGrantHandler{
+			HandleHelper: &oauth2.HandleHelper{
+				AccessTokenStrategy: c.Strategy.Core,
+				AccessTokenStorage:  store,
+				Config:              c,
+			},
+			IDTokenHandleHelper: &openid.IDTokenHandleHelper{
+				IDTokenStrategy: c.Strategy.OpenID,
+			},
+			RefreshTokenStrategy: c.Strategy.Core,
+			Storage:              store,
+			Config:               c,
+		},
+		&TokenExchangeGrantHandler{
+			HandleHelper: &oauth2.HandleHelper{
+				AccessTokenStrategy: c.Strategy.Core,
+				AccessTokenStorage:  store,
+				Config:              c,
+			},
+			RefreshTokenStrategy: c.Strategy.Core,
+			Storage:              store,
+			Config:               c,
+		},
 		&openid.OpenIDConnectRefreshHandler{
 			IDTokenHandleHelper: &openid.IDTokenHandleHelper{
 				IDTokenStrategy: c.Strategy.OpenID,
@@ -461,6 +487,15 @@ func (c *Config) GetIssuerFallback(ctx context.Context, fallback string) (issuer
 	return fallback
 }
 
+// GetClock returns the clock from the ctx or returns the real clock.
+func (c *Config) GetClock(ctx context.Context) (provider clock.Provider) {
+	if octx, ok := ctx.(Context); ok {
+		return octx.GetClock()
+	}
+
+	return clock.New()
+}
+
 // GetIDTokenIssuer returns the ID token issuer.
 func (c *Config) GetIDTokenIssuer(ctx context.Context) (issuer string) {
 	return c.GetIssuerFallback(ctx, c.Issuers.IDToken)
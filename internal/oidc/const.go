@@ -12,6 +12,8 @@ const (
 	ScopeProfile       = "profile"
 	ScopeEmail         = "email"
 	ScopeGroups        = "groups"
+	ScopePhone         = "phone"
+	ScopeAddress       = "address"
 
 	ScopeAutheliaBearerAuthz = "authelia.bearer.authz"
 )
@@ -47,6 +49,32 @@ const (
 	ClaimActive                              = "active"
 	ClaimUsername                            = "username"
 	ClaimTokenIntrospection                  = "token_introspection"
+	ClaimEvents                              = "events"
+	ClaimActor                               = "act"
+)
+
+// Standard Claim strings. See https://openid.net/specs/openid-connect-core-1_0.html#StandardClaims.
+const (
+	ClaimGivenName     = "given_name"
+	ClaimFamilyName    = "family_name"
+	ClaimMiddleName    = "middle_name"
+	ClaimNickname      = "nickname"
+	ClaimProfile       = "profile"
+	ClaimPicture       = "picture"
+	ClaimWebsite       = "website"
+	ClaimGender        = "gender"
+	ClaimBirthdate     = "birthdate"
+	ClaimZoneinfo      = "zoneinfo"
+	ClaimLocale        = "locale"
+	ClaimPhoneNumber   = "phone_number"
+	ClaimAddress       = "address"
+	ClaimStreetAddress = "street_address"
+	ClaimLocality      = "locality"
+	ClaimRegion        = "region"
+	ClaimPostalCode    = "postal_code"
+	ClaimCountry       = "country"
+
+	ClaimAddressFormatted = "formatted"
 )
 
 const (
@@ -91,6 +119,16 @@ const (
 	GrantTypeRefreshToken      = valueRefreshToken
 	GrantTypeAuthorizationCode = "authorization_code"
 	GrantTypeClientCredentials = "client_credentials"
+	GrantTypeDeviceCode        = "urn:ietf:params:oauth:grant-type:device_code"
+	GrantTypeTokenExchange     = "urn:ietf:params:oauth:grant-type:token-exchange"
+)
+
+// Token Type Identifier strings. See https://datatracker.ietf.org/doc/html/rfc8693#section-3.
+const (
+	TokenTypeIdentifierAccessToken  = "urn:ietf:params:oauth:token-type:access_token"
+	TokenTypeIdentifierRefreshToken = "urn:ietf:params:oauth:token-type:refresh_token"
+	TokenTypeIdentifierIDToken      = "urn:ietf:params:oauth:token-type:id_token"
+	TokenTypeIdentifierJWT          = "urn:ietf:params:oauth:token-type:jwt"
 )
 
 // Client Auth Method strings.
@@ -173,6 +211,24 @@ const (
 	FormParameterScope        = valueScope
 	FormParameterIssuer       = valueIss
 	FormParameterPrompt       = "prompt"
+
+	FormParameterIDTokenHint           = "id_token_hint"
+	FormParameterPostLogoutRedirectURI = "post_logout_redirect_uri"
+	FormParameterLogoutToken           = "logout_token"
+	FormParameterSessionID             = "sid"
+	FormParameterFrontChannelLogout    = "logout"
+
+	FormParameterClientSecret = "client_secret"
+	FormParameterAudience     = "audience"
+	FormParameterDeviceCode   = "device_code"
+	FormParameterUserCode     = "user_code"
+
+	FormParameterSubjectToken       = "subject_token"
+	FormParameterSubjectTokenType   = "subject_token_type"
+	FormParameterActorToken         = "actor_token"
+	FormParameterActorTokenType     = "actor_token_type"
+	FormParameterRequestedTokenType = "requested_token_type"
+	FormParameterResource           = "resource"
 )
 
 const (
@@ -190,6 +246,9 @@ const (
 	EndpointIntrospection              = "introspection"
 	EndpointRevocation                 = "revocation"
 	EndpointPushedAuthorizationRequest = "pushed-authorization-request"
+	EndpointEndSession                 = "end-session"
+	EndpointDeviceAuthorization        = "device-authorization"
+	EndpointRegistration               = "registration"
 )
 
 // JWT Headers.
@@ -207,11 +266,65 @@ const (
 const (
 	JWTHeaderTypeValueTokenIntrospectionJWT = "token-introspection+jwt"
 	JWTHeaderTypeValueAccessTokenJWT        = "at+jwt"
+	JWTHeaderTypeValueLogoutTokenJWT        = "logout+jwt"
+)
+
+const (
+	// LogoutTokenEventBackChannelLogout is the member of the Logout Token events claim which identifies it as a
+	// Logout Token. See https://openid.net/specs/openid-connect-backchannel-1_0.html#LogoutToken.
+	LogoutTokenEventBackChannelLogout = "http://schemas.openid.net/event/backchannel-logout"
+
+	// LogoutTokenLifespan is the duration a Logout Token is valid for.
+	LogoutTokenLifespan = 2 * time.Minute
+
+	// FrontChannelLogoutStateLifespan is the duration the state used to render the Front-Channel Logout page is valid
+	// for.
+	FrontChannelLogoutStateLifespan = 5 * time.Minute
+)
+
+const (
+	// DeviceCodePrefix is the prefix used for the device codes issued by the Device Authorization Endpoint.
+	DeviceCodePrefix = "authelia_dc_"
+
+	// DeviceCodeEntropy is the number of random characters in a device code excluding the prefix.
+	DeviceCodeEntropy = 64
+
+	// UserCodeCharSet is the set of characters used to generate user codes. It's the base-20 set recommended by
+	// RFC8628 which has no vowels and is easy to enter on devices with limited input. See
+	// https://datatracker.ietf.org/doc/html/rfc8628#section-6.1.
+	UserCodeCharSet = "BCDFGHJKLMNPQRSTVWXZ"
+
+	// UserCodeLength is the number of characters in a user code excluding the separator.
+	UserCodeLength = 8
+
+	// DeviceCodeSlowDownIncrement is the amount the polling interval is increased by each time a client is told to
+	// slow down. See https://datatracker.ietf.org/doc/html/rfc8628#section-3.5.
+	DeviceCodeSlowDownIncrement = 5 * time.Second
+)
+
+const (
+	// RegistrationAccessTokenPrefix is the prefix used for the Registration Access Tokens issued by the Dynamic Client
+	// Registration Endpoint.
+	RegistrationAccessTokenPrefix = "authelia_rat_"
+
+	// RegistrationAccessTokenEntropy is the number of random characters in a Registration Access Token excluding the
+	// prefix.
+	RegistrationAccessTokenEntropy = 64
+
+	// RegisteredClientSecretEntropy is the number of random characters in a Client Secret issued by the Dynamic Client
+	// Registration Endpoint.
+	RegisteredClientSecretEntropy = 72
+
+	// RegisteredClientSecretIterations is the number of PBKDF2 iterations used to digest a Client Secret issued by the
+	// Dynamic Client Registration Endpoint.
+	RegisteredClientSecretIterations = 310000
 )
 
 // Paths.
 const (
 	EndpointPathConsent                           = "/consent"
+	EndpointPathLogoutConfirmation                = "/logout/confirm"
+	EndpointPathDeviceCode                        = "/device"
 	EndpointPathWellKnownOpenIDConfiguration      = "/.well-known/openid-configuration"
 	EndpointPathWellKnownOAuthAuthorizationServer = "/.well-known/oauth-authorization-server"
 	EndpointPathJWKs                              = "/jwks.json"
@@ -223,8 +336,13 @@ const (
 	EndpointPathUserinfo      = EndpointPathRoot + "/" + EndpointUserinfo
 	EndpointPathIntrospection = EndpointPathRoot + "/" + EndpointIntrospection
 	EndpointPathRevocation    = EndpointPathRoot + "/" + EndpointRevocation
+	EndpointPathEndSession    = EndpointPathRoot + "/" + EndpointEndSession
+
+	EndpointPathFrontChannelLogout = EndpointPathRoot + "/frontchannel-logout"
 
 	EndpointPathPushedAuthorizationRequest = EndpointPathRoot + "/" + EndpointPushedAuthorizationRequest
+	EndpointPathDeviceAuthorization        = EndpointPathRoot + "/" + EndpointDeviceAuthorization
+	EndpointPathRegistration               = EndpointPathRoot + "/" + EndpointRegistration
 
 	EndpointPathRFC8628UserVerificationURL = EndpointPathRoot + "/device-code/user-verification"
 )
@@ -291,6 +409,21 @@ const (
 	// RFC4949: https://datatracker.ietf.org/doc/html/rfc4949
 	AMRPasswordBasedAuthentication = "pwd"
 
+	// AMRWindowsIntegratedAuthentication is an RFC8176 Authentication Method Reference Value that represents Windows
+	// integrated authentication as per Microsoft.
+	//
+	// Authelia utilizes this when a user has performed 1FA via Kerberos SPNEGO. Factor: Know, Channel: Browser.
+	//
+	// RFC8176: https://datatracker.ietf.org/doc/html/rfc8176
+	AMRWindowsIntegratedAuthentication = "wia"
+
+	// AMRSmartCard is an RFC8176 Authentication Method Reference Value that represents authentication via a smart card.
+	//
+	// Authelia utilizes this when a user has performed 1FA via a TLS client certificate. Factor: Have, Channel: Browser.
+	//
+	// RFC8176: https://datatracker.ietf.org/doc/html/rfc8176
+	AMRSmartCard = "sc"
+
 	// AMROneTimePassword is an RFC8176 Authentication Method Reference Value that represents authentication via a
 	// Time-based One-Time Password as per RFC4949. One-time password specifications that this authentication method
 	// applies to include RFC4226 and RFC6238.
@@ -345,6 +478,8 @@ const (
 	valueNone          = "none"
 	valueRefreshToken  = "refresh_token"
 	valueIss           = "iss"
+
+	valueIssuedTokenType = "issued_token_type"
 )
 
 const (
@@ -357,3 +492,12 @@ const (
 	fieldRFC6750Realm            = "realm"
 	fieldRFC6750Scope            = valueScope
 )
+
+const (
+	schemeHTTP  = "http"
+	schemeHTTPS = "https"
+)
+
+var (
+	forbiddenRedirectURISchemes = []string{"javascript", "data", "file", "vbscript", "blob", "about"}
+)
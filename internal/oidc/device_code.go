@@ -0,0 +1,460 @@
+package oidc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	oauthelia2 "authelia.com/provider/oauth2"
+	"authelia.com/provider/oauth2/handler/oauth2"
+	"authelia.com/provider/oauth2/handler/openid"
+	"github.com/google/uuid"
+
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/random"
+)
+
+// NewDeviceAuthorizationRequest validates a Device Authorization Request and returns the resulting request. The client
+// is authenticated using the client_secret_basic, client_secret_post, or none methods.
+//
+// https://datatracker.ietf.org/doc/html/rfc8628#section-3.1
+func (p *OpenIDConnectProvider) NewDeviceAuthorizationRequest(ctx Context, r *http.Request) (request *oauthelia2.Request, err error) {
+	if r.Method != http.MethodPost {
+		return nil, oauthelia2.ErrInvalidRequest.WithHintf("HTTP method is '%s', expected 'POST'.", r.Method)
+	}
+
+	if err = r.ParseForm(); err != nil {
+		return nil, oauthelia2.ErrInvalidRequest.WithHint("Unable to parse HTTP body, make sure to send a properly formatted form request body.").WithWrap(err).WithDebug(err.Error())
+	}
+
+	form := r.PostForm
+
+	var client Client
+
+	if client, err = p.authenticateDeviceAuthorizationClient(ctx, r, form); err != nil {
+		return nil, err
+	}
+
+	if !client.GetGrantTypes().Has(GrantTypeDeviceCode) {
+		return nil, oauthelia2.ErrUnauthorizedClient.WithHintf("The OAuth 2.0 Client is not allowed to use authorization grant '%s'.", GrantTypeDeviceCode)
+	}
+
+	scopes := oauthelia2.RemoveEmpty(strings.Split(form.Get(FormParameterScope), " "))
+
+	for _, scope := range scopes {
+		if !p.Config.GetScopeStrategy(ctx)(client.GetScopes(), scope) {
+			return nil, oauthelia2.ErrInvalidScope.WithHintf("The OAuth 2.0 Client is not allowed to request scope '%s'.", scope)
+		}
+	}
+
+	audience := oauthelia2.RemoveEmpty(strings.Split(form.Get(FormParameterAudience), " "))
+
+	if err = p.Config.GetAudienceStrategy(ctx)(client.GetAudience(), audience); err != nil {
+		return nil, err
+	}
+
+	request = oauthelia2.NewRequest()
+
+	request.ID = uuid.New().String()
+	request.RequestedAt = ctx.GetClock().Now().UTC()
+	request.Client = client
+	request.Form = form
+	request.Session = NewSession()
+	request.SetRequestedScopes(scopes)
+	request.SetRequestedAudience(audience)
+
+	return request, nil
+}
+
+// NewDeviceAuthorizationResponse generates the device code and user code for a validated Device Authorization Request,
+// stores the session, and returns the response which is rendered to the client.
+//
+// https://datatracker.ietf.org/doc/html/rfc8628#section-3.2
+func (p *OpenIDConnectProvider) NewDeviceAuthorizationResponse(ctx Context, request *oauthelia2.Request) (response *DeviceAuthorizationResponse, err error) {
+	var (
+		deviceCode, userCode, signature, userCodeSignature string
+		session                                            *model.OAuth2DeviceCodeSession
+	)
+
+	if deviceCode, err = GenerateDeviceCode(ctx); err != nil {
+		return nil, oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to generate the device code with error: %s.", err.Error())
+	}
+
+	if userCode, err = GenerateUserCode(ctx); err != nil {
+		return nil, oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to generate the user code with error: %s.", err.Error())
+	}
+
+	if signature, err = p.Config.DeviceCodeSignature(ctx, deviceCode); err != nil {
+		return nil, oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to generate the device code signature with error: %s.", err.Error())
+	}
+
+	if userCodeSignature, err = p.Config.DeviceCodeSignature(ctx, NormalizeUserCode(userCode)); err != nil {
+		return nil, oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to generate the user code signature with error: %s.", err.Error())
+	}
+
+	lifespan, interval := p.Config.GetRFC8628CodeLifespan(ctx), p.Config.GetRFC8628TokenPollingInterval(ctx)
+
+	if session, err = model.NewOAuth2DeviceCodeSession(signature, userCodeSignature, request, request.GetRequestedAt().Add(lifespan), interval); err != nil {
+		return nil, oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to create the device code session with error: %s.", err.Error())
+	}
+
+	if err = p.Store.CreateDeviceCodeSession(ctx, session); err != nil {
+		return nil, oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to save the device code session with error: %s.", err.Error())
+	}
+
+	verification := p.Config.GetRFC8628UserVerificationURL(ctx)
+
+	complete, err := url.ParseRequestURI(verification)
+	if err != nil {
+		return nil, oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to parse the user verification uri with error: %s.", err.Error())
+	}
+
+	complete.RawQuery = url.Values{FormParameterUserCode: []string{userCode}}.Encode()
+
+	return &DeviceAuthorizationResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                userCode,
+		VerificationURI:         ctx.RootURL().JoinPath(EndpointPathDeviceCode).String(),
+		VerificationURIComplete: complete.String(),
+		ExpiresIn:               int64(lifespan.Seconds()),
+		Interval:                int64(interval.Seconds()),
+	}, nil
+}
+
+// LoadDeviceCodeSessionByUserCode loads the session for a Device Authorization Request given the user code entered by
+// the end user. The oauthelia2.ErrNotFound error is returned if the user code is malformed or does not exist.
+func (p *OpenIDConnectProvider) LoadDeviceCodeSessionByUserCode(ctx context.Context, userCode string) (session *model.OAuth2DeviceCodeSession, err error) {
+	normalized := NormalizeUserCode(userCode)
+
+	if !IsUserCodeValid(normalized) {
+		return nil, oauthelia2.ErrNotFound
+	}
+
+	var signature string
+
+	if signature, err = p.Config.DeviceCodeSignature(ctx, normalized); err != nil {
+		return nil, err
+	}
+
+	return p.Store.GetDeviceCodeSessionByUserCode(ctx, signature)
+}
+
+func (p *OpenIDConnectProvider) authenticateDeviceAuthorizationClient(ctx context.Context, r *http.Request, form url.Values) (client Client, err error) {
+	id, secret, basic := r.BasicAuth()
+
+	if basic {
+		if id, err = url.QueryUnescape(id); err != nil {
+			return nil, oauthelia2.ErrInvalidClient.WithHint("The client id in the HTTP authorization header could not be decoded from 'application/x-www-form-urlencoded'.").WithWrap(err).WithDebug(err.Error())
+		}
+
+		if secret, err = url.QueryUnescape(secret); err != nil {
+			return nil, oauthelia2.ErrInvalidClient.WithHint("The client secret in the HTTP authorization header could not be decoded from 'application/x-www-form-urlencoded'.").WithWrap(err).WithDebug(err.Error())
+		}
+	} else {
+		id, secret = form.Get(FormParameterClientID), form.Get(FormParameterClientSecret)
+	}
+
+	if id == "" {
+		return nil, oauthelia2.ErrInvalidClient.WithHint("Client credentials missing or malformed in both HTTP Authorization header and HTTP POST body.")
+	}
+
+	if client, err = p.Store.GetRegisteredClient(ctx, id); err != nil {
+		return nil, oauthelia2.ErrInvalidClient.WithWrap(err).WithDebug(err.Error())
+	}
+
+	method := client.GetTokenEndpointAuthMethod()
+
+	switch {
+	case method == ClientAuthMethodNone:
+		if basic || secret != "" {
+			return nil, oauthelia2.ErrInvalidClient.WithHintf("The OAuth 2.0 Client supports client authentication method '%s', but a client secret was provided in the request.", method)
+		}
+
+		return client, nil
+	case method == ClientAuthMethodClientSecretBasic && basic, method == ClientAuthMethodClientSecretPost && !basic:
+		if err = compareClientSecret(ctx, client, []byte(secret)); err != nil {
+			return nil, oauthelia2.ErrInvalidClient.WithHint("The provided client secret did not match the registered client secret.").WithWrap(err).WithDebug(err.Error())
+		}
+
+		return client, nil
+	default:
+		return nil, oauthelia2.ErrInvalidClient.WithHintf("The OAuth 2.0 Client supports client authentication method '%s', but this method is not supported by the device authorization endpoint or was not used in the request.", method)
+	}
+}
+
+func compareClientSecret(ctx context.Context, client Client, secret []byte) (err error) {
+	if len(secret) == 0 {
+		return errClientSecretMismatch
+	}
+
+	if err = client.GetClientSecret().Compare(ctx, secret); err == nil {
+		return nil
+	}
+
+	if rotated, ok := client.(oauthelia2.RotatedClientSecretsClient); ok {
+		for _, s := range rotated.GetRotatedClientSecrets() {
+			if s.Compare(ctx, secret) == nil {
+				return nil
+			}
+		}
+	}
+
+	return err
+}
+
+// GenerateDeviceCode generates a new device code.
+func GenerateDeviceCode(ctx Context) (code string, err error) {
+	var value string
+
+	if value, err = ctx.GetRandom().StringCustomErr(DeviceCodeEntropy, random.CharSetAlphaNumeric); err != nil {
+		return "", err
+	}
+
+	return DeviceCodePrefix + value, nil
+}
+
+// GenerateUserCode generates a new user code in the XXXX-XXXX format.
+func GenerateUserCode(ctx Context) (code string, err error) {
+	var value string
+
+	if value, err = ctx.GetRandom().StringCustomErr(UserCodeLength, UserCodeCharSet); err != nil {
+		return "", err
+	}
+
+	return value[:UserCodeLength/2] + "-" + value[UserCodeLength/2:], nil
+}
+
+// NormalizeUserCode normalizes a user code entered by the end user by removing separators and whitespace and converting
+// it to uppercase.
+func NormalizeUserCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', ' ', '\t':
+			return -1
+		default:
+			return r
+		}
+	}, strings.ToUpper(code))
+}
+
+// IsUserCodeValid returns true if the normalized user code has the correct length and only contains characters from the
+// UserCodeCharSet.
+func IsUserCodeValid(code string) bool {
+	if len(code) != UserCodeLength {
+		return false
+	}
+
+	for _, r := range code {
+		if !strings.ContainsRune(UserCodeCharSet, r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DeviceCodeSignature returns the signature of a device code or normalized user code which is used to lookup the
+// session in storage.
+func (c *Config) DeviceCodeSignature(ctx context.Context, code string) (signature string, err error) {
+	return c.globalSecretSignature(ctx, code)
+}
+
+// globalSecretSignature returns the hex encoded HMAC-SHA256 of a value keyed with the global secret.
+func (c *Config) globalSecretSignature(ctx context.Context, value string) (signature string, err error) {
+	var secret []byte
+
+	if secret, err = c.GetGlobalSecret(ctx); err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, secret)
+
+	mac.Write([]byte(value))
+
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// DeviceCodeGrantHandler is the oauthelia2.TokenEndpointHandler for the OAuth 2.0 Device Authorization Grant.
+//
+// https://datatracker.ietf.org/doc/html/rfc8628#section-3.4
+type DeviceCodeGrantHandler struct {
+	*oauth2.HandleHelper
+	*openid.IDTokenHandleHelper
+
+	RefreshTokenStrategy oauth2.RefreshTokenStrategy
+	Storage              *Store
+	Config               *Config
+}
+
+// HandleTokenEndpointRequest implements oauthelia2.TokenEndpointHandler.
+func (h *DeviceCodeGrantHandler) HandleTokenEndpointRequest(ctx context.Context, requester oauthelia2.AccessRequester) (err error) {
+	if !h.CanHandleTokenEndpointRequest(ctx, requester) {
+		return oauthelia2.ErrUnknownRequest
+	}
+
+	client := requester.GetClient()
+
+	if !client.GetGrantTypes().Has(GrantTypeDeviceCode) {
+		return oauthelia2.ErrUnauthorizedClient.WithHintf("The OAuth 2.0 Client is not allowed to use authorization grant '%s'.", GrantTypeDeviceCode)
+	}
+
+	code := requester.GetRequestForm().Get(FormParameterDeviceCode)
+
+	if code == "" {
+		return oauthelia2.ErrInvalidRequest.WithHintf("The '%s' parameter is missing.", FormParameterDeviceCode)
+	}
+
+	var (
+		signature string
+		session   *model.OAuth2DeviceCodeSession
+	)
+
+	if signature, err = h.Config.DeviceCodeSignature(ctx, code); err != nil {
+		return oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	if session, err = h.Storage.GetDeviceCodeSession(ctx, signature); err != nil {
+		if errors.Is(err, oauthelia2.ErrNotFound) {
+			return oauthelia2.ErrInvalidGrant.WithHint("The device code is not valid.")
+		}
+
+		return oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	if session.ClientID != client.GetID() {
+		return oauthelia2.ErrInvalidGrant.WithHint("The OAuth 2.0 Client ID from this request does not match the one from the device authorization request.")
+	}
+
+	if !session.Active || session.Revoked {
+		return oauthelia2.ErrInvalidGrant.WithHint("The device code has already been used.")
+	}
+
+	now := h.Config.GetClock(ctx).Now().UTC()
+
+	if session.IsExpired(now) {
+		return ErrExpiredToken
+	}
+
+	switch session.Status {
+	case model.OAuth2DeviceCodeStatusAuthorized:
+		return h.handleTokenEndpointRequestAuthorized(ctx, requester, session, now)
+	case model.OAuth2DeviceCodeStatusDenied:
+		session.Active = false
+
+		if err = h.Storage.UpdateDeviceCodeSession(ctx, session); err != nil {
+			return oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error())
+		}
+
+		return ErrDeviceCodeAccessDenied
+	default:
+		slow := session.IsPollingTooFast(now)
+
+		if slow {
+			session.PollingInterval += int(DeviceCodeSlowDownIncrement.Seconds())
+		}
+
+		session.CheckedAt.Time, session.CheckedAt.Valid = now, true
+
+		if err = h.Storage.UpdateDeviceCodeSession(ctx, session); err != nil {
+			return oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error())
+		}
+
+		if slow {
+			return ErrSlowDown
+		}
+
+		return ErrAuthorizationPending
+	}
+}
+
+func (h *DeviceCodeGrantHandler) handleTokenEndpointRequestAuthorized(ctx context.Context, requester oauthelia2.AccessRequester, session *model.OAuth2DeviceCodeSession, now time.Time) (err error) {
+	var original *oauthelia2.Request
+
+	if original, err = session.ToRequest(ctx, requester.GetSession(), h.Storage); err != nil {
+		return oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	session.Active = false
+	session.CheckedAt.Time, session.CheckedAt.Valid = now, true
+
+	if err = h.Storage.UpdateDeviceCodeSession(ctx, session); err != nil {
+		return oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	requester.SetID(original.GetID())
+	requester.SetRequestedScopes(original.GetRequestedScopes())
+	requester.SetRequestedAudience(original.GetRequestedAudience())
+
+	for _, scope := range original.GetGrantedScopes() {
+		requester.GrantScope(scope)
+	}
+
+	for _, audience := range original.GetGrantedAudience() {
+		requester.GrantAudience(audience)
+	}
+
+	client := requester.GetClient()
+
+	requester.GetSession().SetExpiresAt(oauthelia2.AccessToken, now.Add(oauthelia2.GetEffectiveLifespan(client, GrantTypeDeviceCode, oauthelia2.AccessToken, h.Config.GetAccessTokenLifespan(ctx))).Round(time.Second))
+
+	if lifespan := oauthelia2.GetEffectiveLifespan(client, GrantTypeDeviceCode, oauthelia2.RefreshToken, h.Config.GetRefreshTokenLifespan(ctx)); lifespan > -1 {
+		requester.GetSession().SetExpiresAt(oauthelia2.RefreshToken, now.Add(lifespan).Round(time.Second))
+	}
+
+	return nil
+}
+
+// PopulateTokenEndpointResponse implements oauthelia2.TokenEndpointHandler.
+func (h *DeviceCodeGrantHandler) PopulateTokenEndpointResponse(ctx context.Context, requester oauthelia2.AccessRequester, responder oauthelia2.AccessResponder) (err error) {
+	if !h.CanHandleTokenEndpointRequest(ctx, requester) {
+		return oauthelia2.ErrUnknownRequest
+	}
+
+	client := requester.GetClient()
+
+	if err = h.IssueAccessToken(ctx, oauthelia2.GetEffectiveLifespan(client, GrantTypeDeviceCode, oauthelia2.AccessToken, h.Config.GetAccessTokenLifespan(ctx)), requester, responder); err != nil {
+		return err
+	}
+
+	if requester.GetGrantedScopes().HasOneOf(ScopeOffline, ScopeOfflineAccess) && client.GetGrantTypes().Has(GrantTypeRefreshToken) {
+		var refresh, signature string
+
+		if refresh, signature, err = h.RefreshTokenStrategy.GenerateRefreshToken(ctx, requester); err != nil {
+			return oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error())
+		}
+
+		if err = h.Storage.CreateRefreshTokenSession(ctx, signature, requester.Sanitize([]string{})); err != nil {
+			return oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error())
+		}
+
+		responder.SetExtra(valueRefreshToken, refresh)
+	}
+
+	if requester.GetGrantedScopes().Has(ScopeOpenID) {
+		if err = h.IssueExplicitIDToken(ctx, oauthelia2.GetEffectiveLifespan(client, GrantTypeDeviceCode, oauthelia2.IDToken, h.Config.GetIDTokenLifespan(ctx)), requester, responder); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CanSkipClientAuth implements oauthelia2.TokenEndpointHandler.
+func (h *DeviceCodeGrantHandler) CanSkipClientAuth(ctx context.Context, requester oauthelia2.AccessRequester) bool {
+	return false
+}
+
+// CanHandleTokenEndpointRequest implements oauthelia2.TokenEndpointHandler.
+func (h *DeviceCodeGrantHandler) CanHandleTokenEndpointRequest(ctx context.Context, requester oauthelia2.AccessRequester) bool {
+	return requester.GetGrantTypes().ExactOne(GrantTypeDeviceCode)
+}
+
+var (
+	_ oauthelia2.TokenEndpointHandler = (*DeviceCodeGrantHandler)(nil)
+)
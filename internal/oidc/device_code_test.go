@@ -0,0 +1,351 @@
+package oidc_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	oauthelia2 "authelia.com/provider/oauth2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
+)
+
+func TestGenerateDeviceCode(t *testing.T) {
+	ctx := &TestContext{Context: context.Background()}
+
+	code, err := oidc.GenerateDeviceCode(ctx)
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(code, oidc.DeviceCodePrefix))
+	assert.Len(t, code, len(oidc.DeviceCodePrefix)+oidc.DeviceCodeEntropy)
+
+	other, err := oidc.GenerateDeviceCode(ctx)
+
+	require.NoError(t, err)
+	assert.NotEqual(t, code, other)
+}
+
+func TestGenerateUserCode(t *testing.T) {
+	ctx := &TestContext{Context: context.Background()}
+
+	code, err := oidc.GenerateUserCode(ctx)
+
+	require.NoError(t, err)
+	assert.Len(t, code, oidc.UserCodeLength+1)
+	assert.Equal(t, "-", code[oidc.UserCodeLength/2:oidc.UserCodeLength/2+1])
+	assert.True(t, oidc.IsUserCodeValid(oidc.NormalizeUserCode(code)))
+}
+
+func TestNormalizeUserCode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected string
+		valid    bool
+	}{
+		{"ShouldHandleStandard", "BCDF-GHJK", "BCDFGHJK", true},
+		{"ShouldHandleLowercase", "bcdf-ghjk", "BCDFGHJK", true},
+		{"ShouldHandleWhitespace", " bcdf ghjk ", "BCDFGHJK", true},
+		{"ShouldHandleNoSeparator", "BCDFGHJK", "BCDFGHJK", true},
+		{"ShouldNotAllowVowels", "BCDF-GHJA", "BCDFGHJA", false},
+		{"ShouldNotAllowDigits", "BCDF-GHJ1", "BCDFGHJ1", false},
+		{"ShouldNotAllowShort", "BCDF-GHJ", "BCDFGHJ", false},
+		{"ShouldNotAllowLong", "BCDF-GHJKL", "BCDFGHJKL", false},
+		{"ShouldNotAllowEmpty", "", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := oidc.NormalizeUserCode(tc.have)
+
+			assert.Equal(t, tc.expected, actual)
+			assert.Equal(t, tc.valid, oidc.IsUserCodeValid(actual))
+		})
+	}
+}
+
+func TestConfig_DeviceCodeSignature(t *testing.T) {
+	ctx := context.Background()
+
+	config := &oidc.Config{GlobalSecret: []byte("a-very-long-and-secure-global-secret")}
+	other := &oidc.Config{GlobalSecret: []byte("another-very-long-and-secure-secret")}
+
+	signature, err := config.DeviceCodeSignature(ctx, "BCDFGHJK")
+
+	require.NoError(t, err)
+	assert.Len(t, signature, 64)
+
+	again, err := config.DeviceCodeSignature(ctx, "BCDFGHJK")
+
+	require.NoError(t, err)
+	assert.Equal(t, signature, again)
+
+	different, err := config.DeviceCodeSignature(ctx, "BCDFGHJL")
+
+	require.NoError(t, err)
+	assert.NotEqual(t, signature, different)
+
+	secret, err := other.DeviceCodeSignature(ctx, "BCDFGHJK")
+
+	require.NoError(t, err)
+	assert.NotEqual(t, signature, secret)
+}
+
+func TestDeviceCodeGrantHandler_CanHandleTokenEndpointRequest(t *testing.T) {
+	handler := &oidc.DeviceCodeGrantHandler{}
+
+	ctx := context.Background()
+
+	assert.True(t, handler.CanHandleTokenEndpointRequest(ctx, &oauthelia2.AccessRequest{GrantTypes: oauthelia2.Arguments{oidc.GrantTypeDeviceCode}}))
+	assert.False(t, handler.CanHandleTokenEndpointRequest(ctx, &oauthelia2.AccessRequest{GrantTypes: oauthelia2.Arguments{oidc.GrantTypeAuthorizationCode}}))
+	assert.False(t, handler.CanSkipClientAuth(ctx, &oauthelia2.AccessRequest{GrantTypes: oauthelia2.Arguments{oidc.GrantTypeDeviceCode}}))
+}
+
+func TestDeviceCodeGrantHandler_HandleTokenEndpointRequest(t *testing.T) {
+	const code = "authelia_dc_example"
+
+	config := &oidc.Config{GlobalSecret: []byte("a-very-long-and-secure-global-secret")}
+
+	signature, err := config.DeviceCodeSignature(context.Background(), code)
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+
+	pending := func() *model.OAuth2DeviceCodeSession {
+		return &model.OAuth2DeviceCodeSession{
+			ID:              1,
+			ClientID:        "app",
+			Signature:       signature,
+			Status:          model.OAuth2DeviceCodeStatusPending,
+			ExpiresAt:       now.Add(time.Minute),
+			PollingInterval: 5,
+			Active:          true,
+			Session:         []byte("{}"),
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		grants []string
+		code   string
+		setup  func(mock *mocks.MockStorage)
+		err    string
+	}{
+		{
+			"ShouldFailUnauthorizedClient",
+			[]string{oidc.GrantTypeAuthorizationCode},
+			code,
+			nil,
+			"unauthorized_client",
+		},
+		{
+			"ShouldFailMissingDeviceCode",
+			[]string{oidc.GrantTypeDeviceCode},
+			"",
+			nil,
+			"invalid_request",
+		},
+		{
+			"ShouldFailNotFound",
+			[]string{oidc.GrantTypeDeviceCode},
+			code,
+			func(mock *mocks.MockStorage) {
+				mock.EXPECT().LoadOAuth2DeviceCodeSession(gomock.Any(), signature).Return(nil, sql.ErrNoRows)
+			},
+			"invalid_grant",
+		},
+		{
+			"ShouldFailStorageError",
+			[]string{oidc.GrantTypeDeviceCode},
+			code,
+			func(mock *mocks.MockStorage) {
+				mock.EXPECT().LoadOAuth2DeviceCodeSession(gomock.Any(), signature).Return(nil, fmt.Errorf("bad conn"))
+			},
+			"server_error",
+		},
+		{
+			"ShouldFailClientMismatch",
+			[]string{oidc.GrantTypeDeviceCode},
+			code,
+			func(mock *mocks.MockStorage) {
+				session := pending()
+				session.ClientID = "other"
+
+				mock.EXPECT().LoadOAuth2DeviceCodeSession(gomock.Any(), signature).Return(session, nil)
+			},
+			"invalid_grant",
+		},
+		{
+			"ShouldFailInactive",
+			[]string{oidc.GrantTypeDeviceCode},
+			code,
+			func(mock *mocks.MockStorage) {
+				session := pending()
+				session.Active = false
+
+				mock.EXPECT().LoadOAuth2DeviceCodeSession(gomock.Any(), signature).Return(session, nil)
+			},
+			"invalid_grant",
+		},
+		{
+			"ShouldFailExpired",
+			[]string{oidc.GrantTypeDeviceCode},
+			code,
+			func(mock *mocks.MockStorage) {
+				session := pending()
+				session.ExpiresAt = now.Add(-time.Minute)
+
+				mock.EXPECT().LoadOAuth2DeviceCodeSession(gomock.Any(), signature).Return(session, nil)
+			},
+			"expired_token",
+		},
+		{
+			"ShouldFailAuthorizationPending",
+			[]string{oidc.GrantTypeDeviceCode},
+			code,
+			func(mock *mocks.MockStorage) {
+				gomock.InOrder(
+					mock.EXPECT().LoadOAuth2DeviceCodeSession(gomock.Any(), signature).Return(pending(), nil),
+					mock.EXPECT().UpdateOAuth2DeviceCodeSession(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, session model.OAuth2DeviceCodeSession) error {
+						assert.True(t, session.CheckedAt.Valid)
+						assert.Equal(t, 5, session.PollingInterval)
+
+						return nil
+					}),
+				)
+			},
+			"authorization_pending",
+		},
+		{
+			"ShouldFailSlowDown",
+			[]string{oidc.GrantTypeDeviceCode},
+			code,
+			func(mock *mocks.MockStorage) {
+				session := pending()
+				session.CheckedAt = sql.NullTime{Time: now, Valid: true}
+
+				gomock.InOrder(
+					mock.EXPECT().LoadOAuth2DeviceCodeSession(gomock.Any(), signature).Return(session, nil),
+					mock.EXPECT().UpdateOAuth2DeviceCodeSession(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, session model.OAuth2DeviceCodeSession) error {
+						assert.Equal(t, 10, session.PollingInterval)
+
+						return nil
+					}),
+				)
+			},
+			"slow_down",
+		},
+		{
+			"ShouldFailDenied",
+			[]string{oidc.GrantTypeDeviceCode},
+			code,
+			func(mock *mocks.MockStorage) {
+				session := pending()
+				session.Status = model.OAuth2DeviceCodeStatusDenied
+
+				gomock.InOrder(
+					mock.EXPECT().LoadOAuth2DeviceCodeSession(gomock.Any(), signature).Return(session, nil),
+					mock.EXPECT().UpdateOAuth2DeviceCodeSession(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, session model.OAuth2DeviceCodeSession) error {
+						assert.False(t, session.Active)
+
+						return nil
+					}),
+				)
+			},
+			"access_denied",
+		},
+		{
+			"ShouldFailUpdateError",
+			[]string{oidc.GrantTypeDeviceCode},
+			code,
+			func(mock *mocks.MockStorage) {
+				gomock.InOrder(
+					mock.EXPECT().LoadOAuth2DeviceCodeSession(gomock.Any(), signature).Return(pending(), nil),
+					mock.EXPECT().UpdateOAuth2DeviceCodeSession(gomock.Any(), gomock.Any()).Return(fmt.Errorf("bad conn")),
+				)
+			},
+			"server_error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mock := mocks.NewMockStorage(ctrl)
+
+			if tc.setup != nil {
+				tc.setup(mock)
+			}
+
+			handler := &oidc.DeviceCodeGrantHandler{
+				Storage: oidc.NewStore(&schema.IdentityProvidersOpenIDConnect{}, mock),
+				Config:  config,
+			}
+
+			requester := &oauthelia2.AccessRequest{
+				GrantTypes: oauthelia2.Arguments{oidc.GrantTypeDeviceCode},
+				Request: oauthelia2.Request{
+					Client:  &oidc.RegisteredClient{ID: "app", GrantTypes: tc.grants},
+					Form:    url.Values{oidc.FormParameterDeviceCode: []string{tc.code}},
+					Session: oidc.NewSession(),
+				},
+			}
+
+			assert.EqualError(t, handler.HandleTokenEndpointRequest(context.Background(), requester), tc.err)
+		})
+	}
+}
+
+func TestDeviceCodeGrantHandler_HandleTokenEndpointRequestShouldUseContextClock(t *testing.T) {
+	const code = "authelia_dc_example"
+
+	config := &oidc.Config{GlobalSecret: []byte("a-very-long-and-secure-global-secret")}
+
+	signature, err := config.DeviceCodeSignature(context.Background(), code)
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+
+	ctrl := gomock.NewController(t)
+	mock := mocks.NewMockStorage(ctrl)
+
+	mock.EXPECT().LoadOAuth2DeviceCodeSession(gomock.Any(), signature).Return(&model.OAuth2DeviceCodeSession{
+		ID:              1,
+		ClientID:        "app",
+		Signature:       signature,
+		Status:          model.OAuth2DeviceCodeStatusPending,
+		ExpiresAt:       now.Add(time.Minute),
+		PollingInterval: 5,
+		Active:          true,
+		Session:         []byte("{}"),
+	}, nil)
+
+	handler := &oidc.DeviceCodeGrantHandler{
+		Storage: oidc.NewStore(&schema.IdentityProvidersOpenIDConnect{}, mock),
+		Config:  config,
+	}
+
+	requester := &oauthelia2.AccessRequest{
+		GrantTypes: oauthelia2.Arguments{oidc.GrantTypeDeviceCode},
+		Request: oauthelia2.Request{
+			Client:  &oidc.RegisteredClient{ID: "app", GrantTypes: []string{oidc.GrantTypeDeviceCode}},
+			Form:    url.Values{oidc.FormParameterDeviceCode: []string{code}},
+			Session: oidc.NewSession(),
+		},
+	}
+
+	ctx := &TestContext{Context: context.Background(), Clock: clock.NewFixed(now.Add(time.Minute * 2))}
+
+	assert.EqualError(t, handler.HandleTokenEndpointRequest(ctx, requester), "expired_token")
+}
@@ -30,6 +30,8 @@ func NewOpenIDConnectWellKnownConfiguration(c *schema.IdentityProvidersOpenIDCon
 					GrantTypeImplicit,
 					GrantTypeClientCredentials,
 					GrantTypeRefreshToken,
+					GrantTypeDeviceCode,
+					GrantTypeTokenExchange,
 				},
 				ResponseModesSupported: []string{
 					ResponseModeFormPost,
@@ -46,6 +48,8 @@ func NewOpenIDConnectWellKnownConfiguration(c *schema.IdentityProvidersOpenIDCon
 					ScopeProfile,
 					ScopeGroups,
 					ScopeEmail,
+					ScopePhone,
+					ScopeAddress,
 				},
 				ClaimsSupported: []string{
 					ClaimAuthenticationMethodsReference,
@@ -66,6 +70,19 @@ func NewOpenIDConnectWellKnownConfiguration(c *schema.IdentityProvidersOpenIDCon
 					ClaimGroups,
 					ClaimPreferredUsername,
 					ClaimFullName,
+					ClaimGivenName,
+					ClaimFamilyName,
+					ClaimMiddleName,
+					ClaimNickname,
+					ClaimProfile,
+					ClaimPicture,
+					ClaimWebsite,
+					ClaimGender,
+					ClaimBirthdate,
+					ClaimZoneinfo,
+					ClaimLocale,
+					ClaimPhoneNumber,
+					ClaimAddress,
 				},
 				TokenEndpointAuthMethodsSupported: []string{
 					ClientAuthMethodClientSecretBasic,
@@ -121,6 +138,7 @@ func NewOpenIDConnectWellKnownConfiguration(c *schema.IdentityProvidersOpenIDCon
 					ClientAuthMethodPrivateKeyJWT,
 				},
 			},
+			OAuth2DeviceAuthorizationGrantDiscoveryOptions: &OAuth2DeviceAuthorizationGrantDiscoveryOptions{},
 			OAuth2JWTIntrospectionResponseDiscoveryOptions: &OAuth2JWTIntrospectionResponseDiscoveryOptions{
 				IntrospectionSigningAlgValuesSupported: []string{
 					SigningAlgRSAUsingSHA256,
@@ -163,6 +181,15 @@ func NewOpenIDConnectWellKnownConfiguration(c *schema.IdentityProvidersOpenIDCon
 			RequestURIParameterSupported:  true,
 			RequireRequestURIRegistration: true,
 		},
+		OpenIDConnectFrontChannelLogoutDiscoveryOptions: &OpenIDConnectFrontChannelLogoutDiscoveryOptions{
+			FrontChannelLogoutSupported:        true,
+			FrontChannelLogoutSessionSupported: true,
+		},
+		OpenIDConnectBackChannelLogoutDiscoveryOptions: &OpenIDConnectBackChannelLogoutDiscoveryOptions{
+			BackChannelLogoutSupported:        true,
+			BackChannelLogoutSessionSupported: true,
+		},
+		OpenIDConnectRPInitiatedLogoutDiscoveryOptions: &OpenIDConnectRPInitiatedLogoutDiscoveryOptions{},
 		OpenIDConnectPromptCreateDiscoveryOptions: &OpenIDConnectPromptCreateDiscoveryOptions{
 			PromptValuesSupported: []string{
 				PromptNone,
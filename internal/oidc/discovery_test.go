@@ -168,17 +168,26 @@ func TestNewOpenIDConnectProvider_GetOpenIDConnectWellKnownConfiguration(t *test
 	assert.Equal(t, "https://example.com/api/oidc/userinfo", disco.UserinfoEndpoint)
 	assert.Equal(t, "https://example.com/api/oidc/introspection", disco.IntrospectionEndpoint)
 	assert.Equal(t, "https://example.com/api/oidc/revocation", disco.RevocationEndpoint)
+	assert.Equal(t, "https://example.com/api/oidc/end-session", disco.EndSessionEndpoint)
+	assert.Equal(t, "https://example.com/api/oidc/device-authorization", disco.DeviceAuthorizationEndpoint)
 	assert.Equal(t, "", disco.RegistrationEndpoint)
 
+	assert.True(t, disco.BackChannelLogoutSupported)
+	assert.True(t, disco.BackChannelLogoutSessionSupported)
+	assert.True(t, disco.FrontChannelLogoutSupported)
+	assert.True(t, disco.FrontChannelLogoutSessionSupported)
+
 	assert.Len(t, disco.CodeChallengeMethodsSupported, 1)
 	assert.Contains(t, disco.CodeChallengeMethodsSupported, oidc.PKCEChallengeMethodSHA256)
 
-	assert.Len(t, disco.ScopesSupported, 5)
+	assert.Len(t, disco.ScopesSupported, 7)
 	assert.Contains(t, disco.ScopesSupported, oidc.ScopeOpenID)
 	assert.Contains(t, disco.ScopesSupported, oidc.ScopeOfflineAccess)
 	assert.Contains(t, disco.ScopesSupported, oidc.ScopeProfile)
 	assert.Contains(t, disco.ScopesSupported, oidc.ScopeGroups)
 	assert.Contains(t, disco.ScopesSupported, oidc.ScopeEmail)
+	assert.Contains(t, disco.ScopesSupported, oidc.ScopePhone)
+	assert.Contains(t, disco.ScopesSupported, oidc.ScopeAddress)
 
 	assert.Len(t, disco.ResponseModesSupported, 7)
 	assert.Contains(t, disco.ResponseModesSupported, oidc.ResponseModeFormPost)
@@ -217,14 +226,14 @@ func TestNewOpenIDConnectProvider_GetOpenIDConnectWellKnownConfiguration(t *test
 	assert.Contains(t, disco.RevocationEndpointAuthMethodsSupported, oidc.ClientAuthMethodNone)
 
 	assert.Equal(t, []string{oidc.ClientAuthMethodClientSecretBasic, oidc.ClientAuthMethodClientSecretPost, oidc.ClientAuthMethodClientSecretJWT, oidc.ClientAuthMethodPrivateKeyJWT}, disco.IntrospectionEndpointAuthMethodsSupported)
-	assert.Equal(t, []string{oidc.GrantTypeAuthorizationCode, oidc.GrantTypeImplicit, oidc.GrantTypeClientCredentials, oidc.GrantTypeRefreshToken}, disco.GrantTypesSupported)
+	assert.Equal(t, []string{oidc.GrantTypeAuthorizationCode, oidc.GrantTypeImplicit, oidc.GrantTypeClientCredentials, oidc.GrantTypeRefreshToken, oidc.GrantTypeDeviceCode, oidc.GrantTypeTokenExchange}, disco.GrantTypesSupported)
 	assert.Equal(t, []string{oidc.SigningAlgHMACUsingSHA256, oidc.SigningAlgHMACUsingSHA384, oidc.SigningAlgHMACUsingSHA512, oidc.SigningAlgRSAUsingSHA256, oidc.SigningAlgRSAUsingSHA384, oidc.SigningAlgRSAUsingSHA512, oidc.SigningAlgECDSAUsingP256AndSHA256, oidc.SigningAlgECDSAUsingP384AndSHA384, oidc.SigningAlgECDSAUsingP521AndSHA512, oidc.SigningAlgRSAPSSUsingSHA256, oidc.SigningAlgRSAPSSUsingSHA384, oidc.SigningAlgRSAPSSUsingSHA512}, disco.RevocationEndpointAuthSigningAlgValuesSupported)
 	assert.Equal(t, []string{oidc.SigningAlgHMACUsingSHA256, oidc.SigningAlgHMACUsingSHA384, oidc.SigningAlgHMACUsingSHA512, oidc.SigningAlgRSAUsingSHA256, oidc.SigningAlgRSAUsingSHA384, oidc.SigningAlgRSAUsingSHA512, oidc.SigningAlgECDSAUsingP256AndSHA256, oidc.SigningAlgECDSAUsingP384AndSHA384, oidc.SigningAlgECDSAUsingP521AndSHA512, oidc.SigningAlgRSAPSSUsingSHA256, oidc.SigningAlgRSAPSSUsingSHA384, oidc.SigningAlgRSAPSSUsingSHA512}, disco.TokenEndpointAuthSigningAlgValuesSupported)
 	assert.Equal(t, []string{oidc.SigningAlgRSAUsingSHA256, oidc.SigningAlgNone}, disco.IDTokenSigningAlgValuesSupported)
 	assert.Equal(t, []string{oidc.SigningAlgRSAUsingSHA256, oidc.SigningAlgNone}, disco.UserinfoSigningAlgValuesSupported)
 	assert.Equal(t, []string{oidc.SigningAlgRSAUsingSHA256, oidc.SigningAlgRSAUsingSHA384, oidc.SigningAlgRSAUsingSHA512, oidc.SigningAlgECDSAUsingP256AndSHA256, oidc.SigningAlgECDSAUsingP384AndSHA384, oidc.SigningAlgECDSAUsingP521AndSHA512, oidc.SigningAlgRSAPSSUsingSHA256, oidc.SigningAlgRSAPSSUsingSHA384, oidc.SigningAlgRSAPSSUsingSHA512, oidc.SigningAlgNone}, disco.RequestObjectSigningAlgValuesSupported)
 
-	assert.Len(t, disco.ClaimsSupported, 18)
+	assert.Len(t, disco.ClaimsSupported, 31)
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimAuthenticationMethodsReference)
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimAudience)
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimAuthorizedParty)
@@ -243,6 +252,19 @@ func TestNewOpenIDConnectProvider_GetOpenIDConnectWellKnownConfiguration(t *test
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimGroups)
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimPreferredUsername)
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimFullName)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimGivenName)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimFamilyName)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimMiddleName)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimNickname)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimProfile)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimPicture)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimWebsite)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimGender)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimBirthdate)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimZoneinfo)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimLocale)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimPhoneNumber)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimAddress)
 
 	assert.Len(t, disco.PromptValuesSupported, 2)
 	assert.Contains(t, disco.PromptValuesSupported, oidc.PromptConsent)
@@ -276,17 +298,20 @@ func TestNewOpenIDConnectProvider_GetOAuth2WellKnownConfiguration(t *testing.T)
 	assert.Equal(t, "https://example.com/api/oidc/token", disco.TokenEndpoint)
 	assert.Equal(t, "https://example.com/api/oidc/introspection", disco.IntrospectionEndpoint)
 	assert.Equal(t, "https://example.com/api/oidc/revocation", disco.RevocationEndpoint)
+	assert.Equal(t, "https://example.com/api/oidc/device-authorization", disco.DeviceAuthorizationEndpoint)
 	assert.Equal(t, "", disco.RegistrationEndpoint)
 
 	require.Len(t, disco.CodeChallengeMethodsSupported, 1)
 	assert.Equal(t, "S256", disco.CodeChallengeMethodsSupported[0])
 
-	assert.Len(t, disco.ScopesSupported, 5)
+	assert.Len(t, disco.ScopesSupported, 7)
 	assert.Contains(t, disco.ScopesSupported, oidc.ScopeOpenID)
 	assert.Contains(t, disco.ScopesSupported, oidc.ScopeOfflineAccess)
 	assert.Contains(t, disco.ScopesSupported, oidc.ScopeProfile)
 	assert.Contains(t, disco.ScopesSupported, oidc.ScopeGroups)
 	assert.Contains(t, disco.ScopesSupported, oidc.ScopeEmail)
+	assert.Contains(t, disco.ScopesSupported, oidc.ScopePhone)
+	assert.Contains(t, disco.ScopesSupported, oidc.ScopeAddress)
 
 	assert.Len(t, disco.ResponseModesSupported, 7)
 	assert.Contains(t, disco.ResponseModesSupported, oidc.ResponseModeFormPost)
@@ -317,13 +342,15 @@ func TestNewOpenIDConnectProvider_GetOAuth2WellKnownConfiguration(t *testing.T)
 	assert.Contains(t, disco.TokenEndpointAuthMethodsSupported, oidc.ClientAuthMethodPrivateKeyJWT)
 	assert.Contains(t, disco.TokenEndpointAuthMethodsSupported, oidc.ClientAuthMethodNone)
 
-	assert.Len(t, disco.GrantTypesSupported, 4)
+	assert.Len(t, disco.GrantTypesSupported, 6)
 	assert.Contains(t, disco.GrantTypesSupported, oidc.GrantTypeAuthorizationCode)
 	assert.Contains(t, disco.GrantTypesSupported, oidc.GrantTypeImplicit)
 	assert.Contains(t, disco.GrantTypesSupported, oidc.GrantTypeClientCredentials)
 	assert.Contains(t, disco.GrantTypesSupported, oidc.GrantTypeRefreshToken)
+	assert.Contains(t, disco.GrantTypesSupported, oidc.GrantTypeDeviceCode)
+	assert.Contains(t, disco.GrantTypesSupported, oidc.GrantTypeTokenExchange)
 
-	assert.Len(t, disco.ClaimsSupported, 18)
+	assert.Len(t, disco.ClaimsSupported, 31)
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimAuthenticationMethodsReference)
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimAudience)
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimAuthorizedParty)
@@ -342,6 +369,19 @@ func TestNewOpenIDConnectProvider_GetOAuth2WellKnownConfiguration(t *testing.T)
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimGroups)
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimPreferredUsername)
 	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimFullName)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimGivenName)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimFamilyName)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimMiddleName)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimNickname)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimProfile)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimPicture)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimWebsite)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimGender)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimBirthdate)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimZoneinfo)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimLocale)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimPhoneNumber)
+	assert.Contains(t, disco.ClaimsSupported, oidc.ClaimAddress)
 }
 
 func TestNewOpenIDConnectProvider_GetOpenIDConnectWellKnownConfigurationWithPlainPKCE(t *testing.T) {
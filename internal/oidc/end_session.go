@@ -0,0 +1,103 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// IDTokenHint is the validated representation of the id_token_hint parameter of an OpenID Connect RP-Initiated Logout
+// 1.0 request.
+type IDTokenHint struct {
+	Subject         string
+	Audience        []string
+	AuthorizedParty string
+	SessionID       string
+}
+
+// GetClientID returns the client id the ID Token was issued to. This is the authorized party if present, otherwise it's
+// the audience if it only has a single value.
+func (h *IDTokenHint) GetClientID() (clientID string) {
+	if h.AuthorizedParty != "" {
+		return h.AuthorizedParty
+	}
+
+	if len(h.Audience) == 1 {
+		return h.Audience[0]
+	}
+
+	return ""
+}
+
+// IsIssuedTo returns true if the ID Token was issued to the provided client id.
+func (h *IDTokenHint) IsIssuedTo(clientID string) (issued bool) {
+	return clientID != "" && (h.AuthorizedParty == clientID || utils.IsStringInSlice(clientID, h.Audience))
+}
+
+// ValidateIDTokenHint validates the signature and issuer of an ID Token used as an id_token_hint. The time based claims
+// are intentionally not validated as the OpenID Connect RP-Initiated Logout 1.0 specification allows expired ID Tokens
+// to be used as a hint.
+func (m *KeyManager) ValidateIDTokenHint(ctx context.Context, tokenString, issuer string) (hint *IDTokenHint, err error) {
+	var (
+		token  *jwt.Token
+		claims = jwt.MapClaims{}
+	)
+
+	parser := jwt.NewParser(jwt.WithoutClaimsValidation())
+
+	if token, err = parser.ParseWithClaims(tokenString, claims, m.idTokenHintKeyFunc(ctx)); err != nil {
+		return nil, fmt.Errorf("failed to validate the id_token_hint: %w", err)
+	}
+
+	if !token.Valid {
+		return nil, errors.New("failed to validate the id_token_hint: the token is not valid")
+	}
+
+	hint = &IDTokenHint{}
+
+	var iss string
+
+	if iss, err = claims.GetIssuer(); err != nil || iss != issuer {
+		return nil, fmt.Errorf("failed to validate the id_token_hint: the issuer '%s' does not match the expected issuer '%s'", iss, issuer)
+	}
+
+	if hint.Subject, err = claims.GetSubject(); err != nil || hint.Subject == "" {
+		return nil, errors.New("failed to validate the id_token_hint: the subject is absent")
+	}
+
+	if hint.Audience, err = claims.GetAudience(); err != nil || len(hint.Audience) == 0 {
+		return nil, errors.New("failed to validate the id_token_hint: the audience is absent")
+	}
+
+	hint.AuthorizedParty, _ = claims[ClaimAuthorizedParty].(string)
+	hint.SessionID, _ = claims[ClaimSessionID].(string)
+
+	return hint, nil
+}
+
+func (m *KeyManager) idTokenHintKeyFunc(ctx context.Context) jwt.Keyfunc {
+	return func(token *jwt.Token) (key any, err error) {
+		kid, _ := token.Header[JWTHeaderKeyIdentifier].(string)
+		alg, _ := token.Header[JWTHeaderKeyAlgorithm].(string)
+
+		if alg == "" || alg == SigningAlgNone {
+			return nil, fmt.Errorf("the token has the unsupported alg '%s'", alg)
+		}
+
+		jwk := m.Get(ctx, kid, alg)
+
+		if jwk == nil {
+			return nil, fmt.Errorf("the token kid '%s' and alg '%s' do not match a managed jwk", kid, alg)
+		}
+
+		if jwk.Algorithm() != alg || token.Method.Alg() != alg {
+			return nil, fmt.Errorf("the token alg '%s' does not match the alg '%s' of the managed jwk", alg, jwk.Algorithm())
+		}
+
+		return jwk.JWK().Key, nil
+	}
+}
@@ -0,0 +1,157 @@
+package oidc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/oidc"
+)
+
+func TestKeyManager_ValidateIDTokenHint(t *testing.T) {
+	config := &schema.IdentityProvidersOpenIDConnect{
+		JSONWebKeys: []schema.JWK{
+			{
+				KeyID:            "kid-RS256-sig",
+				Use:              oidc.KeyUseSignature,
+				Algorithm:        oidc.SigningAlgRSAUsingSHA256,
+				Key:              x509PrivateKeyRSA2048,
+				CertificateChain: x509CertificateChainRSA2048,
+			},
+			{
+				KeyID:            "kid-ES256-sig",
+				Use:              oidc.KeyUseSignature,
+				Algorithm:        oidc.SigningAlgECDSAUsingP256AndSHA256,
+				Key:              x509PrivateKeyECDSAP256,
+				CertificateChain: x509CertificateChainECDSAP256,
+			},
+		},
+	}
+
+	config.Discovery.DefaultKeyIDs = map[string]string{
+		oidc.SigningAlgRSAUsingSHA256:          "kid-RS256-sig",
+		oidc.SigningAlgECDSAUsingP256AndSHA256: "kid-ES256-sig",
+	}
+
+	manager := oidc.NewKeyManager(config)
+
+	sign := func(method jwt.SigningMethod, kid string, key any, claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(method, claims)
+
+		if kid != "" {
+			token.Header[oidc.JWTHeaderKeyIdentifier] = kid
+		}
+
+		value, err := token.SignedString(key)
+		require.NoError(t, err)
+
+		return value
+	}
+
+	expired := time.Now().Add(-time.Hour).Unix()
+
+	testCases := []struct {
+		name     string
+		have     string
+		expected *oidc.IDTokenHint
+		err      string
+	}{
+		{
+			"ShouldValidateExpired",
+			sign(jwt.SigningMethodRS256, "kid-RS256-sig", x509PrivateKeyRSA2048, jwt.MapClaims{oidc.ClaimIssuer: examplecom, oidc.ClaimSubject: "abc", oidc.ClaimAudience: "app", oidc.ClaimExpirationTime: expired, oidc.ClaimSessionID: "sid1"}),
+			&oidc.IDTokenHint{Subject: "abc", Audience: []string{"app"}, SessionID: "sid1"},
+			"",
+		},
+		{
+			"ShouldValidateWithoutKeyID",
+			sign(jwt.SigningMethodES256, "", x509PrivateKeyECDSAP256, jwt.MapClaims{oidc.ClaimIssuer: examplecom, oidc.ClaimSubject: "abc", oidc.ClaimAudience: []string{"app", "api"}, oidc.ClaimAuthorizedParty: "app"}),
+			&oidc.IDTokenHint{Subject: "abc", Audience: []string{"app", "api"}, AuthorizedParty: "app"},
+			"",
+		},
+		{
+			"ShouldErrIssuer",
+			sign(jwt.SigningMethodRS256, "kid-RS256-sig", x509PrivateKeyRSA2048, jwt.MapClaims{oidc.ClaimIssuer: "https://other.com", oidc.ClaimSubject: "abc", oidc.ClaimAudience: "app"}),
+			nil,
+			"failed to validate the id_token_hint: the issuer 'https://other.com' does not match the expected issuer 'https://example.com'",
+		},
+		{
+			"ShouldErrSubject",
+			sign(jwt.SigningMethodRS256, "kid-RS256-sig", x509PrivateKeyRSA2048, jwt.MapClaims{oidc.ClaimIssuer: examplecom, oidc.ClaimAudience: "app"}),
+			nil,
+			"failed to validate the id_token_hint: the subject is absent",
+		},
+		{
+			"ShouldErrAudience",
+			sign(jwt.SigningMethodRS256, "kid-RS256-sig", x509PrivateKeyRSA2048, jwt.MapClaims{oidc.ClaimIssuer: examplecom, oidc.ClaimSubject: "abc"}),
+			nil,
+			"failed to validate the id_token_hint: the audience is absent",
+		},
+		{
+			"ShouldErrUnknownKey",
+			sign(jwt.SigningMethodRS256, "kid-other", x509PrivateKeyRSA2048, jwt.MapClaims{oidc.ClaimIssuer: examplecom, oidc.ClaimSubject: "abc", oidc.ClaimAudience: "app"}),
+			nil,
+			"failed to validate the id_token_hint: token is unverifiable: error while executing keyfunc: the token kid 'kid-other' and alg 'RS256' do not match a managed jwk",
+		},
+		{
+			"ShouldErrAlgorithmMismatch",
+			sign(jwt.SigningMethodRS384, "kid-RS256-sig", x509PrivateKeyRSA2048, jwt.MapClaims{oidc.ClaimIssuer: examplecom, oidc.ClaimSubject: "abc", oidc.ClaimAudience: "app"}),
+			nil,
+			"failed to validate the id_token_hint: token is unverifiable: error while executing keyfunc: the token alg 'RS384' does not match the alg 'RS256' of the managed jwk",
+		},
+		{
+			"ShouldErrSignature",
+			sign(jwt.SigningMethodRS256, "kid-RS256-sig", x509PrivateKeyRSA4096, jwt.MapClaims{oidc.ClaimIssuer: examplecom, oidc.ClaimSubject: "abc", oidc.ClaimAudience: "app"}),
+			nil,
+			"failed to validate the id_token_hint: token signature is invalid: crypto/rsa: verification error",
+		},
+		{
+			"ShouldErrNone",
+			sign(jwt.SigningMethodNone, "", jwt.UnsafeAllowNoneSignatureType, jwt.MapClaims{oidc.ClaimIssuer: examplecom, oidc.ClaimSubject: "abc", oidc.ClaimAudience: "app"}),
+			nil,
+			"failed to validate the id_token_hint: token is unverifiable: error while executing keyfunc: the token has the unsupported alg 'none'",
+		},
+		{
+			"ShouldErrMalformed",
+			"abc",
+			nil,
+			"failed to validate the id_token_hint: token is malformed: token contains an invalid number of segments",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := manager.ValidateIDTokenHint(context.Background(), tc.have, examplecom)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, actual)
+			} else {
+				assert.EqualError(t, err, tc.err)
+				assert.Nil(t, actual)
+			}
+		})
+	}
+}
+
+func TestIDTokenHint(t *testing.T) {
+	hint := &oidc.IDTokenHint{Audience: []string{"app", "api"}, AuthorizedParty: "app"}
+
+	assert.Equal(t, "app", hint.GetClientID())
+	assert.True(t, hint.IsIssuedTo("app"))
+	assert.True(t, hint.IsIssuedTo("api"))
+	assert.False(t, hint.IsIssuedTo("other"))
+	assert.False(t, hint.IsIssuedTo(""))
+
+	hint = &oidc.IDTokenHint{Audience: []string{"app", "api"}}
+
+	assert.Equal(t, "", hint.GetClientID())
+
+	hint = &oidc.IDTokenHint{Audience: []string{"api"}}
+
+	assert.Equal(t, "api", hint.GetClientID())
+}
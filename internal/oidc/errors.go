@@ -2,6 +2,7 @@ package oidc
 
 import (
 	"errors"
+	"net/http"
 
 	oauthelia2 "authelia.com/provider/oauth2"
 )
@@ -10,6 +11,28 @@ var (
 	errClientSecretMismatch = errors.New("The provided client secret did not match the registered client secret.")
 )
 
+// Redirect URI validation errors.
+var (
+	// ErrRedirectURIPublicOnly is returned when a redirect URI is only valid for the public client type.
+	ErrRedirectURIPublicOnly = errors.New("the redirect uri is only valid for the public client type")
+
+	// ErrRedirectURINotAbsolute is returned when a redirect URI does not have a scheme.
+	ErrRedirectURINotAbsolute = errors.New("the redirect uri must have a scheme")
+
+	// ErrRedirectURIFragment is returned when a redirect URI has a fragment.
+	ErrRedirectURIFragment = errors.New("the redirect uri must not have a fragment")
+
+	// ErrRedirectURIForbiddenScheme is returned when a redirect URI has a scheme which is never permitted.
+	ErrRedirectURIForbiddenScheme = errors.New("the redirect uri scheme is not permitted")
+
+	// ErrRedirectURIInsecureScheme is returned when a redirect URI does not use the https scheme, a loopback http uri,
+	// or a private-use scheme when one of these is required.
+	ErrRedirectURIInsecureScheme = errors.New("the redirect uri must use the 'https' scheme, or for a public client either a loopback 'http' uri or a private-use scheme")
+
+	// ErrRedirectURIOutOfBand is returned when the out-of-band redirect URI is used by a dynamically registered client.
+	ErrRedirectURIOutOfBand = errors.New("the out-of-band redirect uri is not accepted for dynamic client registration")
+)
+
 var (
 	// ErrSubjectCouldNotLookup is sent when the Subject Identifier for a user couldn't be generated or obtained from the database.
 	ErrSubjectCouldNotLookup = oauthelia2.ErrServerError.WithHint("Could not lookup user subject.")
@@ -31,3 +54,69 @@ var (
 
 	ErrClientAuthorizationUserAccessDenied = oauthelia2.ErrAccessDenied.WithHint("The user was denied access to this client.")
 )
+
+// Device Authorization Grant errors. See https://datatracker.ietf.org/doc/html/rfc8628#section-3.5.
+var (
+	// ErrAuthorizationPending is sent when the user has not yet completed the user interaction steps.
+	ErrAuthorizationPending = &oauthelia2.RFC6749Error{
+		ErrorField:       "authorization_pending",
+		DescriptionField: "The authorization request is still pending as the end user hasn't yet completed the user-interaction steps.",
+		CodeField:        http.StatusBadRequest,
+	}
+
+	// ErrSlowDown is sent when the client is polling the token endpoint faster than the polling interval allows.
+	ErrSlowDown = &oauthelia2.RFC6749Error{
+		ErrorField:       "slow_down",
+		DescriptionField: "The authorization request is still pending and polling should continue, but the interval must be increased by 5 seconds for this and all subsequent requests.",
+		CodeField:        http.StatusBadRequest,
+	}
+
+	// ErrExpiredToken is sent when the device code has expired.
+	ErrExpiredToken = &oauthelia2.RFC6749Error{
+		ErrorField:       "expired_token",
+		DescriptionField: "The device code has expired, and the device authorization session has concluded.",
+		CodeField:        http.StatusBadRequest,
+	}
+
+	// ErrDeviceCodeAccessDenied is sent when the user denied the authorization request.
+	ErrDeviceCodeAccessDenied = oauthelia2.ErrAccessDenied.WithHint("The end user denied the authorization request.")
+)
+
+// Token Exchange errors. See https://datatracker.ietf.org/doc/html/rfc8693#section-2.2.2.
+var (
+	// ErrInvalidTarget is sent when the requested audience or resource is unknown or not permitted for the client.
+	ErrInvalidTarget = &oauthelia2.RFC6749Error{
+		ErrorField:       "invalid_target",
+		DescriptionField: "The authorization server is unwilling or unable to issue a token for any target service indicated by the 'resource' or 'audience' parameters.",
+		CodeField:        http.StatusBadRequest,
+	}
+)
+
+// Bearer Token errors. See https://datatracker.ietf.org/doc/html/rfc6750#section-3.1.
+var (
+	// ErrInvalidToken is sent when the bearer token is missing, expired, revoked, malformed, or invalid for other
+	// reasons.
+	ErrInvalidToken = &oauthelia2.RFC6749Error{
+		ErrorField:       "invalid_token",
+		DescriptionField: "The access token provided is expired, revoked, malformed, or invalid for other reasons.",
+		CodeField:        http.StatusUnauthorized,
+	}
+)
+
+// Dynamic Client Registration errors. See https://datatracker.ietf.org/doc/html/rfc7591#section-3.2.2.
+var (
+	// ErrInvalidRedirectURI is sent when the value of one or more redirection URIs is invalid.
+	ErrInvalidRedirectURI = &oauthelia2.RFC6749Error{
+		ErrorField:       "invalid_redirect_uri",
+		DescriptionField: "The value of one or more redirection URIs is invalid.",
+		CodeField:        http.StatusBadRequest,
+	}
+
+	// ErrInvalidClientMetadata is sent when the value of one of the client metadata fields is invalid and the server
+	// has rejected this request.
+	ErrInvalidClientMetadata = &oauthelia2.RFC6749Error{
+		ErrorField:       "invalid_client_metadata",
+		DescriptionField: "The value of one of the client metadata fields is invalid and the server has rejected this request.",
+		CodeField:        http.StatusBadRequest,
+	}
+)
@@ -0,0 +1,214 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	fjwt "authelia.com/provider/oauth2/token/jwt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+)
+
+// GenerateLogoutToken generates a signed OpenID Connect Back-Channel Logout 1.0 Logout Token for the client using the
+// same key the client uses to validate ID Tokens.
+//
+// https://openid.net/specs/openid-connect-backchannel-1_0.html#LogoutToken
+func (m *KeyManager) GenerateLogoutToken(ctx context.Context, client Client, issuer, subject, sid string, now time.Time) (tokenString string, err error) {
+	if sid == "" && client.GetBackChannelLogoutSessionRequired() {
+		return "", fmt.Errorf("failed to generate the logout token: the client with id '%s' requires the session id but it's absent", client.GetID())
+	}
+
+	jwk := m.Get(ctx, client.GetIDTokenSignedResponseKeyID(), client.GetIDTokenSignedResponseAlg())
+
+	if jwk == nil {
+		return "", fmt.Errorf("failed to generate the logout token: the client with id '%s' does not have a managed jwk for kid '%s' and alg '%s'", client.GetID(), client.GetIDTokenSignedResponseKeyID(), client.GetIDTokenSignedResponseAlg())
+	}
+
+	var jti uuid.UUID
+
+	if jti, err = uuid.NewRandom(); err != nil {
+		return "", fmt.Errorf("failed to generate the logout token: error occurred generating the jti: %w", err)
+	}
+
+	claims := fjwt.MapClaims{
+		ClaimJWTID:          jti.String(),
+		ClaimIssuer:         issuer,
+		ClaimSubject:        subject,
+		ClaimAudience:       []string{client.GetID()},
+		ClaimIssuedAt:       now.UTC().Unix(),
+		ClaimExpirationTime: now.UTC().Add(LogoutTokenLifespan).Unix(),
+		ClaimEvents: map[string]any{
+			LogoutTokenEventBackChannelLogout: map[string]any{},
+		},
+	}
+
+	if sid != "" {
+		claims[ClaimSessionID] = sid
+	}
+
+	headers := &fjwt.Headers{
+		Extra: map[string]any{
+			JWTHeaderKeyIdentifier: jwk.KeyID(),
+			JWTHeaderKeyType:       JWTHeaderTypeValueLogoutTokenJWT,
+		},
+	}
+
+	if tokenString, _, err = jwk.Strategy().Generate(ctx, claims, headers); err != nil {
+		return "", fmt.Errorf("failed to generate the logout token: %w", err)
+	}
+
+	return tokenString, nil
+}
+
+// SendBackChannelLogout generates a Logout Token and sends it to the Back-Channel Logout URI of the client. The request
+// is retried with a backoff by the HTTP client when the relying party is unavailable or responds with a server error.
+//
+// https://openid.net/specs/openid-connect-backchannel-1_0.html#BCRequest
+func (p *OpenIDConnectProvider) SendBackChannelLogout(ctx context.Context, client Client, issuer, subject, sid string) (err error) {
+	uri := client.GetBackChannelLogoutURI()
+
+	if uri == "" {
+		return nil
+	}
+
+	var token string
+
+	if token, err = p.KeyManager.GenerateLogoutToken(ctx, client, issuer, subject, sid, time.Now()); err != nil {
+		return err
+	}
+
+	return SendBackChannelLogoutToken(ctx, p.Config.GetHTTPClient(ctx), uri, token)
+}
+
+// SendBackChannelLogoutToken sends a Logout Token to a Back-Channel Logout URI using the provided HTTP client.
+func SendBackChannelLogoutToken(ctx context.Context, client *retryablehttp.Client, uri, token string) (err error) {
+	form := url.Values{}
+
+	form.Set(FormParameterLogoutToken, token)
+
+	var (
+		req  *retryablehttp.Request
+		resp *http.Response
+	)
+
+	if req, err = retryablehttp.NewRequestWithContext(ctx, http.MethodPost, uri, strings.NewReader(form.Encode())); err != nil {
+		return fmt.Errorf("failed to send the logout token to the back-channel logout uri '%s': %w", uri, err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if resp, err = client.Do(req); err != nil {
+		return fmt.Errorf("failed to send the logout token to the back-channel logout uri '%s': %w", uri, err)
+	}
+
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	default:
+		return fmt.Errorf("failed to send the logout token to the back-channel logout uri '%s': the relying party responded with status code %d", uri, resp.StatusCode)
+	}
+}
+
+// NewFrontChannelLogoutURI returns the Front-Channel Logout URI of the client which is rendered in an iframe. The iss
+// and sid query parameters are included when the session id is known.
+//
+// https://openid.net/specs/openid-connect-frontchannel-1_0.html#OPLogout
+func NewFrontChannelLogoutURI(client Client, issuer, sid string) (uri *url.URL, err error) {
+	raw := client.GetFrontChannelLogoutURI()
+
+	if raw == "" {
+		return nil, nil
+	}
+
+	if sid == "" && client.GetFrontChannelLogoutSessionRequired() {
+		return nil, fmt.Errorf("the client with id '%s' requires the session id for the front-channel logout uri but it's absent", client.GetID())
+	}
+
+	if uri, err = url.ParseRequestURI(raw); err != nil {
+		return nil, fmt.Errorf("the front-channel logout uri '%s' for the client with id '%s' could not be parsed: %w", raw, client.GetID(), err)
+	}
+
+	if sid != "" {
+		query := uri.Query()
+
+		query.Set(FormParameterIssuer, issuer)
+		query.Set(FormParameterSessionID, sid)
+
+		uri.RawQuery = query.Encode()
+	}
+
+	return uri, nil
+}
+
+// FrontChannelLogoutState is the state used to render the Front-Channel Logout page after the user session has been
+// destroyed. It's signed with the global secret so neither the clients nor the redirect can be tampered with.
+type FrontChannelLogoutState struct {
+	jwt.RegisteredClaims
+
+	SessionID   string   `json:"sid"`
+	ClientIDs   []string `json:"client_ids"`
+	RedirectURI string   `json:"redirect_uri"`
+}
+
+// GenerateFrontChannelLogoutState generates the signed state for the Front-Channel Logout page.
+func (p *OpenIDConnectProvider) GenerateFrontChannelLogoutState(ctx context.Context, issuer, sid string, clientIDs []string, redirectURI string, now time.Time) (tokenString string, err error) {
+	var secret []byte
+
+	if secret, err = p.Config.GetGlobalSecret(ctx); err != nil {
+		return "", fmt.Errorf("failed to generate the front-channel logout state: %w", err)
+	}
+
+	state := &FrontChannelLogoutState{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Audience:  jwt.ClaimStrings{issuer + EndpointPathFrontChannelLogout},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(FrontChannelLogoutStateLifespan)),
+		},
+		SessionID:   sid,
+		ClientIDs:   clientIDs,
+		RedirectURI: redirectURI,
+	}
+
+	if tokenString, err = jwt.NewWithClaims(jwt.SigningMethodHS256, state).SignedString(secret); err != nil {
+		return "", fmt.Errorf("failed to generate the front-channel logout state: %w", err)
+	}
+
+	return tokenString, nil
+}
+
+// ValidateFrontChannelLogoutState validates the signed state for the Front-Channel Logout page.
+func (p *OpenIDConnectProvider) ValidateFrontChannelLogoutState(ctx context.Context, tokenString, issuer string) (state *FrontChannelLogoutState, err error) {
+	var secret []byte
+
+	if secret, err = p.Config.GetGlobalSecret(ctx); err != nil {
+		return nil, fmt.Errorf("failed to validate the front-channel logout state: %w", err)
+	}
+
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(issuer),
+		jwt.WithAudience(issuer+EndpointPathFrontChannelLogout),
+		jwt.WithExpirationRequired(),
+	)
+
+	state = &FrontChannelLogoutState{}
+
+	if _, err = parser.ParseWithClaims(tokenString, state, func(token *jwt.Token) (any, error) {
+		return secret, nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to validate the front-channel logout state: %w", err)
+	}
+
+	return state, nil
+}
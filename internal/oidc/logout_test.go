@@ -0,0 +1,197 @@
+package oidc_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/oidc"
+)
+
+func TestKeyManager_GenerateLogoutToken(t *testing.T) {
+	config := &schema.IdentityProvidersOpenIDConnect{
+		JSONWebKeys: []schema.JWK{
+			{
+				KeyID:            "kid-RS256-sig",
+				Use:              oidc.KeyUseSignature,
+				Algorithm:        oidc.SigningAlgRSAUsingSHA256,
+				Key:              x509PrivateKeyRSA2048,
+				CertificateChain: x509CertificateChainRSA2048,
+			},
+		},
+	}
+
+	config.Discovery.DefaultKeyIDs = map[string]string{
+		oidc.SigningAlgRSAUsingSHA256: "kid-RS256-sig",
+	}
+
+	manager := oidc.NewKeyManager(config)
+
+	now := time.Unix(1000000, 0)
+
+	client := &oidc.RegisteredClient{
+		ID:                       "app",
+		IDTokenSignedResponseAlg: oidc.SigningAlgRSAUsingSHA256,
+	}
+
+	tokenString, err := manager.GenerateLogoutToken(context.Background(), client, examplecom, "abc", "sid1", now)
+	require.NoError(t, err)
+
+	claims := jwt.MapClaims{}
+
+	token, err := jwt.NewParser(jwt.WithoutClaimsValidation()).ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
+		return &x509PrivateKeyRSA2048.PublicKey, nil
+	})
+
+	require.NoError(t, err)
+	require.True(t, token.Valid)
+
+	assert.Equal(t, oidc.JWTHeaderTypeValueLogoutTokenJWT, token.Header[oidc.JWTHeaderKeyType])
+	assert.Equal(t, "kid-RS256-sig", token.Header[oidc.JWTHeaderKeyIdentifier])
+	assert.Equal(t, oidc.SigningAlgRSAUsingSHA256, token.Header[oidc.JWTHeaderKeyAlgorithm])
+
+	assert.Equal(t, examplecom, claims[oidc.ClaimIssuer])
+	assert.Equal(t, "abc", claims[oidc.ClaimSubject])
+	assert.Equal(t, []any{"app"}, claims[oidc.ClaimAudience])
+	assert.Equal(t, "sid1", claims[oidc.ClaimSessionID])
+	assert.Equal(t, float64(1000000), claims[oidc.ClaimIssuedAt])
+	assert.Equal(t, float64(1000120), claims[oidc.ClaimExpirationTime])
+	assert.NotEmpty(t, claims[oidc.ClaimJWTID])
+	assert.Equal(t, map[string]any{oidc.LogoutTokenEventBackChannelLogout: map[string]any{}}, claims[oidc.ClaimEvents])
+	assert.NotContains(t, claims, oidc.ClaimNonce)
+
+	tokenString, err = manager.GenerateLogoutToken(context.Background(), client, examplecom, "abc", "", now)
+	require.NoError(t, err)
+
+	claims = jwt.MapClaims{}
+
+	_, _, err = jwt.NewParser().ParseUnverified(tokenString, claims)
+	require.NoError(t, err)
+
+	assert.NotContains(t, claims, oidc.ClaimSessionID)
+
+	client.BackChannelLogoutSessionRequired = true
+
+	tokenString, err = manager.GenerateLogoutToken(context.Background(), client, examplecom, "abc", "", now)
+	assert.EqualError(t, err, "failed to generate the logout token: the client with id 'app' requires the session id but it's absent")
+	assert.Equal(t, "", tokenString)
+
+	client.IDTokenSignedResponseKeyID = "kid-other"
+
+	tokenString, err = manager.GenerateLogoutToken(context.Background(), client, examplecom, "abc", "sid1", now)
+	assert.EqualError(t, err, "failed to generate the logout token: the client with id 'app' does not have a managed jwk for kid 'kid-other' and alg 'RS256'")
+	assert.Equal(t, "", tokenString)
+}
+
+func TestSendBackChannelLogoutToken(t *testing.T) {
+	var (
+		attempts int
+		received url.Values
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+
+		body, _ := io.ReadAll(r.Body)
+		received, _ = url.ParseQuery(string(body))
+
+		switch r.URL.Path {
+		case "/retry":
+			if attempts < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+
+				return
+			}
+		case "/bad":
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	defer server.Close()
+
+	client := retryablehttp.NewClient()
+	client.RetryWaitMin, client.RetryWaitMax, client.RetryMax = time.Millisecond, time.Millisecond, 3
+	client.Logger = nil
+
+	assert.NoError(t, oidc.SendBackChannelLogoutToken(context.Background(), client, server.URL+"/ok", "token1"))
+	assert.Equal(t, 1, attempts)
+	assert.Equal(t, "token1", received.Get(oidc.FormParameterLogoutToken))
+
+	attempts = 0
+
+	assert.NoError(t, oidc.SendBackChannelLogoutToken(context.Background(), client, server.URL+"/retry", "token2"))
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, "token2", received.Get(oidc.FormParameterLogoutToken))
+
+	attempts = 0
+
+	assert.EqualError(t, oidc.SendBackChannelLogoutToken(context.Background(), client, server.URL+"/bad", "token3"), "failed to send the logout token to the back-channel logout uri '"+server.URL+"/bad': the relying party responded with status code 400")
+	assert.Equal(t, 1, attempts)
+}
+
+func TestNewFrontChannelLogoutURI(t *testing.T) {
+	client := &oidc.RegisteredClient{ID: "app"}
+
+	uri, err := oidc.NewFrontChannelLogoutURI(client, examplecom, "sid1")
+	assert.NoError(t, err)
+	assert.Nil(t, uri)
+
+	client.FrontChannelLogoutURI = MustParseRequestURI("https://app.example.com/logout?a=b")
+
+	uri, err = oidc.NewFrontChannelLogoutURI(client, examplecom, "sid1")
+	require.NoError(t, err)
+	assert.Equal(t, "https://app.example.com/logout?a=b&iss=https%3A%2F%2Fexample.com&sid=sid1", uri.String())
+
+	uri, err = oidc.NewFrontChannelLogoutURI(client, examplecom, "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://app.example.com/logout?a=b", uri.String())
+
+	client.FrontChannelLogoutSessionRequired = true
+
+	uri, err = oidc.NewFrontChannelLogoutURI(client, examplecom, "")
+	assert.EqualError(t, err, "the client with id 'app' requires the session id for the front-channel logout uri but it's absent")
+	assert.Nil(t, uri)
+}
+
+func TestOpenIDConnectProvider_FrontChannelLogoutState(t *testing.T) {
+	provider := &oidc.OpenIDConnectProvider{Config: &oidc.Config{GlobalSecret: []byte("a-very-long-and-secure-global-secret")}}
+	other := &oidc.OpenIDConnectProvider{Config: &oidc.Config{GlobalSecret: []byte("another-very-long-and-secure-secret")}}
+
+	ctx := context.Background()
+
+	tokenString, err := provider.GenerateFrontChannelLogoutState(ctx, examplecom, "sid1", []string{"app", "api"}, "https://app.example.com/done", time.Now())
+	require.NoError(t, err)
+
+	state, err := provider.ValidateFrontChannelLogoutState(ctx, tokenString, examplecom)
+	require.NoError(t, err)
+
+	assert.Equal(t, "sid1", state.SessionID)
+	assert.Equal(t, []string{"app", "api"}, state.ClientIDs)
+	assert.Equal(t, "https://app.example.com/done", state.RedirectURI)
+
+	_, err = provider.ValidateFrontChannelLogoutState(ctx, tokenString, "https://other.com")
+	assert.EqualError(t, err, "failed to validate the front-channel logout state: token has invalid claims: token has invalid audience, token has invalid issuer")
+
+	_, err = other.ValidateFrontChannelLogoutState(ctx, tokenString, examplecom)
+	assert.EqualError(t, err, "failed to validate the front-channel logout state: token signature is invalid: signature is invalid")
+
+	tokenString, err = provider.GenerateFrontChannelLogoutState(ctx, examplecom, "sid1", []string{"app"}, "https://app.example.com/done", time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+
+	_, err = provider.ValidateFrontChannelLogoutState(ctx, tokenString, examplecom)
+	assert.EqualError(t, err, "failed to validate the front-channel logout state: token has invalid claims: token is expired")
+}
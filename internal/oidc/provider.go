@@ -45,6 +45,11 @@ func (p *OpenIDConnectProvider) GetOAuth2WellKnownConfiguration(issuer string) O
 	options.TokenEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathToken)
 	options.IntrospectionEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathIntrospection)
 	options.RevocationEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathRevocation)
+	options.DeviceAuthorizationEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathDeviceAuthorization)
+
+	if p.Store.IsDynamicClientRegistrationEnabled() {
+		options.RegistrationEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathRegistration)
+	}
 
 	return options
 }
@@ -62,6 +67,12 @@ func (p *OpenIDConnectProvider) GetOpenIDConnectWellKnownConfiguration(issuer st
 	options.UserinfoEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathUserinfo)
 	options.IntrospectionEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathIntrospection)
 	options.RevocationEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathRevocation)
+	options.DeviceAuthorizationEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathDeviceAuthorization)
+	options.EndSessionEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathEndSession)
+
+	if p.Store.IsDynamicClientRegistrationEnabled() {
+		options.RegistrationEndpoint = fmt.Sprintf("%s%s", issuer, EndpointPathRegistration)
+	}
 
 	return options
 }
@@ -80,6 +80,7 @@ type Session struct {
 	ClientCredentials     bool           `json:"client_credentials"`
 	ExcludeNotBeforeClaim bool           `json:"exclude_nbf_claim"`
 	AllowedTopLevelClaims []string       `json:"allowed_top_level_claims"`
+	Actor                 map[string]any `json:"act,omitempty"`
 	Extra                 map[string]any `json:"extra"`
 }
 
@@ -153,6 +154,10 @@ func (s *Session) GetJWTClaims() jwt.JWTClaimsContainer {
 		claims.Extra[ClaimClientIdentifier] = s.ClientID
 	}
 
+	if len(s.Actor) != 0 {
+		claims.Extra[ClaimActor] = s.Actor
+	}
+
 	return claims
 }
 
@@ -165,9 +170,22 @@ func (s *Session) GetIDTokenClaims() *jwt.IDTokenClaims {
 	return s.DefaultSession.Claims
 }
 
-// GetExtraClaims returns the Extra/Unregistered claims for this session.
+// GetExtraClaims returns the Extra/Unregistered claims for this session. If the session was the result of a Token
+// Exchange delegation the actor claim is also included.
 func (s *Session) GetExtraClaims() map[string]any {
-	return s.Extra
+	if len(s.Actor) == 0 {
+		return s.Extra
+	}
+
+	extra := make(map[string]any, len(s.Extra)+1)
+
+	for k, v := range s.Extra {
+		extra[k] = v
+	}
+
+	extra[ClaimActor] = s.Actor
+
+	return extra
 }
 
 // Clone copies the OpenIDSession to a new oauthelia2.Session.
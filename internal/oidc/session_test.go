@@ -65,6 +65,21 @@ func TestOpenIDSession_GetExtraClaims(t *testing.T) {
 				"a": 1,
 			},
 		},
+		{
+			"ShouldReturnExtraWithActor",
+			&oidc.Session{
+				Actor: map[string]any{
+					oidc.ClaimSubject: abc,
+				},
+				Extra: map[string]any{
+					"a": 1,
+				},
+			},
+			map[string]any{
+				"a":             1,
+				oidc.ClaimActor: map[string]any{oidc.ClaimSubject: abc},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -171,6 +186,11 @@ func TestSession_GetJWTClaims(t *testing.T) {
 			}, Extra: map[string]any{}, ClientID: abc, AllowedTopLevelClaims: []string{oidc.ClaimClientIdentifier, oidc.ClaimAuthenticationMethodsReference}},
 			&jwt.JWTClaims{Extra: map[string]any{oidc.ClaimAuthenticationMethodsReference: []string{oidc.AMRMultiFactorAuthentication}, oidc.ClaimClientIdentifier: abc}},
 		},
+		{
+			"ShouldIncludeActor",
+			&oidc.Session{DefaultSession: openid.NewDefaultSession(), ClientID: abc, Actor: map[string]any{oidc.ClaimSubject: "service"}},
+			&jwt.JWTClaims{Extra: map[string]any{oidc.ClaimClientIdentifier: abc, oidc.ClaimActor: map[string]any{oidc.ClaimSubject: "service"}}},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -22,6 +22,7 @@ import (
 func NewStore(config *schema.IdentityProvidersOpenIDConnect, provider storage.Provider) (store *Store) {
 	store = &Store{
 		ClientStore: NewMemoryClientStore(config),
+		config:      config,
 		provider:    provider,
 	}
 
@@ -29,25 +30,40 @@ func NewStore(config *schema.IdentityProvidersOpenIDConnect, provider storage.Pr
 }
 
 func NewMemoryClientStore(config *schema.IdentityProvidersOpenIDConnect) (store *MemoryClientStore) {
+	store = &MemoryClientStore{}
+
+	store.Update(config)
+
+	return store
+}
+
+// Update atomically replaces the registered clients with the clients from the given configuration.
+func (s *MemoryClientStore) Update(config *schema.IdentityProvidersOpenIDConnect) {
 	logger := logging.Logger()
 
-	store = &MemoryClientStore{
-		clients: map[string]Client{},
-	}
+	clients := make(map[string]Client, len(config.Clients))
 
 	for _, client := range config.Clients {
 		policy := authorization.NewLevel(client.AuthorizationPolicy)
 		logger.Debugf("Registering client %s with policy %s (%v)", client.ID, client.AuthorizationPolicy, policy)
 
-		store.clients[client.ID] = NewClient(client, config)
+		clients[client.ID] = NewClient(client, config)
 	}
 
-	return store
+	s.mu.Lock()
+
+	s.clients = clients
+
+	s.mu.Unlock()
 }
 
 // GetRegisteredClient returns a Client matching the provided id.
 func (s *MemoryClientStore) GetRegisteredClient(_ context.Context, id string) (client Client, err error) {
+	s.mu.RLock()
+
 	client, ok := s.clients[id]
+
+	s.mu.RUnlock()
 	if !ok {
 		return nil, oauthelia2.ErrInvalidClient.WithDebugf("Client with id '%s' does not appear to be a registered client.", id)
 	}
@@ -55,6 +71,37 @@ func (s *MemoryClientStore) GetRegisteredClient(_ context.Context, id string) (c
 	return client, nil
 }
 
+// GetRegisteredClient returns a Client matching the provided id. The clients from the configuration take precedence
+// and when Dynamic Client Registration is enabled the clients registered via the Dynamic Client Registration Endpoint
+// are loaded from the storage provider.
+func (s *Store) GetRegisteredClient(ctx context.Context, id string) (client Client, err error) {
+	if client, err = s.ClientStore.GetRegisteredClient(ctx, id); err == nil || !s.IsDynamicClientRegistrationEnabled() {
+		return client, err
+	}
+
+	var registered *model.OAuth2Client
+
+	if registered, err = s.provider.LoadOAuth2Client(ctx, id); err != nil {
+		if errors.Is(err, storage.ErrNoOAuth2Client) {
+			return nil, oauthelia2.ErrInvalidClient.WithDebugf("Client with id '%s' does not appear to be a registered client.", id)
+		}
+
+		return nil, oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to load the client with id '%s': %s", id, err.Error())
+	}
+
+	if client, err = NewClientFromOAuth2Client(registered, s.config); err != nil {
+		return nil, oauthelia2.ErrServerError.WithWrap(err).WithDebugf("Failed to load the client with id '%s': %s", id, err.Error())
+	}
+
+	return client, nil
+}
+
+// IsDynamicClientRegistrationEnabled returns true if clients may be registered via the Dynamic Client Registration
+// Endpoint.
+func (s *Store) IsDynamicClientRegistrationEnabled() bool {
+	return s.config != nil && s.config.DynamicClientRegistration.Enabled && s.provider != nil
+}
+
 // GenerateOpaqueUserID either retrieves or creates an opaque user id from a sectorID and username.
 func (s *Store) GenerateOpaqueUserID(ctx context.Context, sectorID, username string) (opaqueID *model.UserOpaqueIdentifier, err error) {
 	if opaqueID, err = s.provider.LoadUserOpaqueIdentifierBySignature(ctx, "openid", sectorID, username); err != nil {
@@ -293,6 +340,42 @@ func (s *Store) DeletePARSession(ctx context.Context, requestURI string) (err er
 	return s.provider.RevokeOAuth2PARContext(ctx, requestURI)
 }
 
+// CreateDeviceCodeSession stores the session for a Device Authorization Request.
+func (s *Store) CreateDeviceCodeSession(ctx context.Context, session *model.OAuth2DeviceCodeSession) (err error) {
+	return s.provider.SaveOAuth2DeviceCodeSession(ctx, *session)
+}
+
+// UpdateDeviceCodeSession updates the session for a Device Authorization Request.
+func (s *Store) UpdateDeviceCodeSession(ctx context.Context, session *model.OAuth2DeviceCodeSession) (err error) {
+	return s.provider.UpdateOAuth2DeviceCodeSession(ctx, *session)
+}
+
+// GetDeviceCodeSession loads the session for a Device Authorization Request given the device code signature.
+func (s *Store) GetDeviceCodeSession(ctx context.Context, signature string) (session *model.OAuth2DeviceCodeSession, err error) {
+	if session, err = s.provider.LoadOAuth2DeviceCodeSession(ctx, signature); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, oauthelia2.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// GetDeviceCodeSessionByUserCode loads the session for a Device Authorization Request given the user code signature.
+func (s *Store) GetDeviceCodeSessionByUserCode(ctx context.Context, signature string) (session *model.OAuth2DeviceCodeSession, err error) {
+	if session, err = s.provider.LoadOAuth2DeviceCodeSessionByUserCode(ctx, signature); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, oauthelia2.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return session, nil
+}
+
 // IsJWTUsed implements an interface required for RFC7523.
 func (s *Store) IsJWTUsed(ctx context.Context, jti string) (used bool, err error) {
 	if err = s.ClientAssertionJWTValid(ctx, jti); err != nil {
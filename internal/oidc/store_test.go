@@ -126,6 +126,52 @@ func TestOpenIDConnectStore_IsValidClientID(t *testing.T) {
 	assert.False(t, invalidClient)
 }
 
+func TestOpenIDConnectStore_UpdateClients(t *testing.T) {
+	ctx := context.Background()
+
+	config := &schema.IdentityProvidersOpenIDConnect{
+		IssuerCertificateChain: schema.X509CertificateChain{},
+		IssuerPrivateKey:       x509PrivateKeyRSA2048,
+		Clients: []schema.IdentityProvidersOpenIDConnectClient{
+			{
+				ID:                  myclient,
+				Name:                myclientdesc,
+				AuthorizationPolicy: onefactor,
+				Scopes:              []string{oidc.ScopeOpenID, oidc.ScopeProfile},
+				Secret:              tOpenIDConnectPlainTextClientSecret,
+			},
+		},
+	}
+
+	s := oidc.NewStore(config, nil)
+
+	assert.True(t, s.IsValidClientID(ctx, myclient))
+	assert.False(t, s.IsValidClientID(ctx, "another-client"))
+
+	store, ok := s.ClientStore.(*oidc.MemoryClientStore)
+	require.True(t, ok)
+
+	store.Update(&schema.IdentityProvidersOpenIDConnect{
+		IssuerCertificateChain: schema.X509CertificateChain{},
+		IssuerPrivateKey:       x509PrivateKeyRSA2048,
+		Clients: []schema.IdentityProvidersOpenIDConnectClient{
+			{
+				ID:                  "another-client",
+				Name:                myclientdesc,
+				AuthorizationPolicy: twofactor,
+				Scopes:              []string{oidc.ScopeOpenID},
+				Secret:              tOpenIDConnectPlainTextClientSecret,
+			},
+		},
+	})
+
+	assert.False(t, s.IsValidClientID(ctx, myclient))
+
+	client, err := s.GetRegisteredClient(ctx, "another-client")
+	require.NoError(t, err)
+	assert.Equal(t, authorization.TwoFactor, client.GetAuthorizationPolicyRequiredLevel(authorization.Subject{}))
+}
+
 func TestStoreSuite(t *testing.T) {
 	suite.Run(t, &StoreSuite{})
 }
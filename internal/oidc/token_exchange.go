@@ -0,0 +1,281 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	oauthelia2 "authelia.com/provider/oauth2"
+	"authelia.com/provider/oauth2/handler/oauth2"
+
+	"github.com/authelia/authelia/v4/internal/authorization"
+)
+
+// TokenExchangeGrantHandler is the oauthelia2.TokenEndpointHandler for the OAuth 2.0 Token Exchange Grant. It allows a
+// client to exchange an access token or refresh token issued by this provider for a new access token with a narrowed
+// audience and scope. If an actor token is provided the exchange is a delegation and the resulting access token
+// includes the 'act' claim, otherwise it's an impersonation.
+//
+// https://datatracker.ietf.org/doc/html/rfc8693
+type TokenExchangeGrantHandler struct {
+	*oauth2.HandleHelper
+
+	RefreshTokenStrategy oauth2.RefreshTokenStrategy
+	Storage              *Store
+	Config               *Config
+}
+
+// HandleTokenEndpointRequest implements oauthelia2.TokenEndpointHandler.
+//
+//nolint:gocyclo // Complexity is necessary to remain readable.
+func (h *TokenExchangeGrantHandler) HandleTokenEndpointRequest(ctx context.Context, requester oauthelia2.AccessRequester) (err error) {
+	if !h.CanHandleTokenEndpointRequest(ctx, requester) {
+		return oauthelia2.ErrUnknownRequest
+	}
+
+	var (
+		client  Client
+		session *Session
+		ok      bool
+	)
+
+	if client, ok = requester.GetClient().(Client); !ok {
+		return oauthelia2.ErrServerError.WithDebug("Failed to get the client for the request.")
+	}
+
+	if !client.GetGrantTypes().Has(GrantTypeTokenExchange) {
+		return oauthelia2.ErrUnauthorizedClient.WithHintf("The OAuth 2.0 Client is not allowed to use authorization grant '%s'.", GrantTypeTokenExchange)
+	}
+
+	if session, ok = requester.GetSession().(*Session); !ok {
+		return oauthelia2.ErrServerError.WithDebug("Failed to get the session for the request.")
+	}
+
+	form := requester.GetRequestForm()
+
+	if tokenType := form.Get(FormParameterRequestedTokenType); tokenType != "" && tokenType != TokenTypeIdentifierAccessToken {
+		return oauthelia2.ErrInvalidRequest.WithHintf("The '%s' parameter value '%s' is not supported.", FormParameterRequestedTokenType, tokenType)
+	}
+
+	var (
+		subject, actor               oauthelia2.Requester
+		subjectTokenType             oauthelia2.TokenType
+		subjectSession, actorSession = NewSession(), NewSession()
+	)
+
+	if form.Get(FormParameterSubjectToken) == "" {
+		return oauthelia2.ErrInvalidRequest.WithHintf("The '%s' parameter is missing.", FormParameterSubjectToken)
+	}
+
+	if subject, subjectTokenType, err = h.loadTokenRequester(ctx, form, FormParameterSubjectToken, FormParameterSubjectTokenType, subjectSession); err != nil {
+		return err
+	}
+
+	policy := client.GetTokenExchangePolicy()
+
+	if !policy.IsSubjectTokenAllowed(subject.GetClient().GetID(), subject.GetGrantedAudience()) {
+		return oauthelia2.ErrInvalidRequest.WithHintf("The OAuth 2.0 Client is not allowed to exchange the '%s'.", FormParameterSubjectToken).WithDebugf("The '%s' was issued to the OAuth 2.0 Client with id '%s' and granted the audience '%s'.", FormParameterSubjectToken, subject.GetClient().GetID(), subject.GetGrantedAudience())
+	}
+
+	switch {
+	case form.Get(FormParameterActorToken) != "":
+		if actor, _, err = h.loadTokenRequester(ctx, form, FormParameterActorToken, FormParameterActorTokenType, actorSession); err != nil {
+			return err
+		}
+
+		if !policy.IsActorTokenAllowed(client.GetID(), actor.GetClient().GetID()) {
+			return oauthelia2.ErrInvalidRequest.WithHintf("The OAuth 2.0 Client is not allowed to use the '%s'.", FormParameterActorToken).WithDebugf("The '%s' was issued to the OAuth 2.0 Client with id '%s'.", FormParameterActorToken, actor.GetClient().GetID())
+		}
+	case form.Get(FormParameterActorTokenType) != "":
+		return oauthelia2.ErrInvalidRequest.WithHintf("The '%s' parameter must not be included when the '%s' parameter is missing.", FormParameterActorTokenType, FormParameterActorToken)
+	}
+
+	audience := requester.GetRequestedAudience()
+
+	for _, resource := range oauthelia2.RemoveEmpty(form[FormParameterResource]) {
+		if !audience.Has(resource) {
+			audience = append(audience, resource)
+		}
+	}
+
+	for _, aud := range audience {
+		if !policy.IsTargetAudienceAllowed(aud) {
+			return ErrInvalidTarget.WithHintf("The OAuth 2.0 Client is not allowed to request the target audience '%s'.", aud)
+		}
+	}
+
+	scopes := requester.GetRequestedScopes()
+
+	if len(scopes) == 0 {
+		for _, scope := range subject.GetGrantedScopes() {
+			if scope == ScopeOffline || scope == ScopeOfflineAccess {
+				continue
+			}
+
+			scopes = append(scopes, scope)
+		}
+	}
+
+	for _, scope := range scopes {
+		switch {
+		case scope == ScopeOffline || scope == ScopeOfflineAccess:
+			return oauthelia2.ErrInvalidScope.WithHintf("The scope '%s' can't be requested when using authorization grant '%s'.", scope, GrantTypeTokenExchange)
+		case !h.Config.GetScopeStrategy(ctx)(client.GetScopes(), scope):
+			return oauthelia2.ErrInvalidScope.WithHintf("The OAuth 2.0 Client is not allowed to request scope '%s'.", scope)
+		case !subject.GetGrantedScopes().Has(scope):
+			return oauthelia2.ErrInvalidScope.WithHintf("The scope '%s' was not granted to the '%s'.", scope, FormParameterSubjectToken)
+		}
+	}
+
+	if clone, ok := subjectSession.Clone().(*Session); ok {
+		*session = *clone
+	}
+
+	session.ClientID = client.GetID()
+	session.DefaultSession.ExpiresAt = nil
+
+	if actor != nil {
+		session.Actor = NewTokenExchangeActorClaim(actor, actorSession, subjectSession.Actor)
+	}
+
+	requester.SetRequestedScopes(scopes)
+	requester.SetRequestedAudience(audience)
+
+	for _, scope := range scopes {
+		requester.GrantScope(scope)
+	}
+
+	for _, aud := range audience {
+		requester.GrantAudience(aud)
+	}
+
+	expires := h.Config.GetClock(ctx).Now().UTC().Add(oauthelia2.GetEffectiveLifespan(client, GrantTypeTokenExchange, oauthelia2.AccessToken, h.Config.GetAccessTokenLifespan(ctx))).Round(time.Second)
+
+	// The exchanged token must not outlive the subject token it was derived from.
+	if exp := subjectSession.GetExpiresAt(subjectTokenType); !exp.IsZero() && exp.Before(expires) {
+		expires = exp
+	}
+
+	session.SetExpiresAt(oauthelia2.AccessToken, expires)
+
+	return nil
+}
+
+func (h *TokenExchangeGrantHandler) loadTokenRequester(ctx context.Context, form url.Values, parameter, parameterType string, session *Session) (requester oauthelia2.Requester, tokenType oauthelia2.TokenType, err error) {
+	token := form.Get(parameter)
+
+	switch value := form.Get(parameterType); value {
+	case TokenTypeIdentifierAccessToken:
+		tokenType = oauthelia2.AccessToken
+
+		requester, err = h.Storage.GetAccessTokenSession(ctx, h.AccessTokenStrategy.AccessTokenSignature(ctx, token), session)
+	case TokenTypeIdentifierRefreshToken:
+		tokenType = oauthelia2.RefreshToken
+
+		requester, err = h.Storage.GetRefreshTokenSession(ctx, h.RefreshTokenStrategy.RefreshTokenSignature(ctx, token), session)
+	case "":
+		return nil, tokenType, oauthelia2.ErrInvalidRequest.WithHintf("The '%s' parameter is missing.", parameterType)
+	default:
+		return nil, tokenType, oauthelia2.ErrInvalidRequest.WithHintf("The '%s' parameter value '%s' is not supported.", parameterType, value)
+	}
+
+	if err != nil {
+		if errors.Is(err, oauthelia2.ErrNotFound) || errors.Is(err, oauthelia2.ErrInactiveToken) {
+			return nil, tokenType, oauthelia2.ErrInvalidRequest.WithHintf("The '%s' is not valid.", parameter).WithWrap(err).WithDebug(err.Error())
+		}
+
+		return nil, tokenType, oauthelia2.ErrServerError.WithWrap(err).WithDebug(err.Error())
+	}
+
+	if tokenType == oauthelia2.AccessToken {
+		err = h.AccessTokenStrategy.ValidateAccessToken(ctx, requester, token)
+	} else {
+		err = h.RefreshTokenStrategy.ValidateRefreshToken(ctx, requester, token)
+	}
+
+	if err != nil {
+		return nil, tokenType, oauthelia2.ErrInvalidRequest.WithHintf("The '%s' is not valid.", parameter).WithWrap(err).WithDebug(err.Error())
+	}
+
+	return requester, tokenType, nil
+}
+
+// PopulateTokenEndpointResponse implements oauthelia2.TokenEndpointHandler.
+func (h *TokenExchangeGrantHandler) PopulateTokenEndpointResponse(ctx context.Context, requester oauthelia2.AccessRequester, responder oauthelia2.AccessResponder) (err error) {
+	if !h.CanHandleTokenEndpointRequest(ctx, requester) {
+		return oauthelia2.ErrUnknownRequest
+	}
+
+	if err = h.IssueAccessToken(ctx, oauthelia2.GetEffectiveLifespan(requester.GetClient(), GrantTypeTokenExchange, oauthelia2.AccessToken, h.Config.GetAccessTokenLifespan(ctx)), requester, responder); err != nil {
+		return err
+	}
+
+	responder.SetExtra(valueIssuedTokenType, TokenTypeIdentifierAccessToken)
+
+	return nil
+}
+
+// CanSkipClientAuth implements oauthelia2.TokenEndpointHandler.
+func (h *TokenExchangeGrantHandler) CanSkipClientAuth(ctx context.Context, requester oauthelia2.AccessRequester) bool {
+	return false
+}
+
+// CanHandleTokenEndpointRequest implements oauthelia2.TokenEndpointHandler.
+func (h *TokenExchangeGrantHandler) CanHandleTokenEndpointRequest(ctx context.Context, requester oauthelia2.AccessRequester) bool {
+	return requester.GetGrantTypes().ExactOne(GrantTypeTokenExchange)
+}
+
+// ValidateTokenExchangeSubjectAuthorization ensures the End-User a subject token was issued for is permitted to use the
+// client performing the Token Exchange by its authorization policy, and that the authentication methods recorded in the
+// subject token satisfy the level the policy requires. This mirrors the checks performed by the Authorization Endpoint.
+func ValidateTokenExchangeSubjectAuthorization(client Client, session *Session, subject authorization.Subject) (err error) {
+	if client == nil || session == nil {
+		return oauthelia2.ErrServerError.WithDebug("Failed to get the client or session for the request.")
+	}
+
+	level := client.GetAuthorizationPolicyRequiredLevel(subject)
+
+	if level == authorization.Denied {
+		return oauthelia2.ErrAccessDenied.WithHintf("The user the '%s' was issued for is not authorized to use the OAuth 2.0 Client.", FormParameterSubjectToken).WithDebugf("The authorization policy '%s' denied the user '%s'.", client.GetAuthorizationPolicy().Name, subject.Username)
+	}
+
+	var amr []string
+
+	if session.DefaultSession != nil && session.Claims != nil {
+		amr = session.Claims.AuthenticationMethodsReferences
+	}
+
+	if actual := NewAuthenticationMethodsReferencesFromClaim(amr).AuthenticationLevel(); !authorization.IsAuthLevelSufficient(actual, level) {
+		return oauthelia2.ErrAccessDenied.WithHintf("The '%s' was not issued with an authentication level sufficient for the OAuth 2.0 Client.", FormParameterSubjectToken).WithDebugf("The authorization policy '%s' requires the level '%s' for the user '%s' but the level was '%s'.", client.GetAuthorizationPolicy().Name, level, subject.Username, actual)
+	}
+
+	return nil
+}
+
+// NewTokenExchangeActorClaim returns the 'act' claim value for a delegated Token Exchange. The subject of the actor is
+// the End-User the actor token was issued for, or the client it was issued to when it was issued via the client
+// credentials grant. Any actor claim from the subject token is nested to retain the delegation chain.
+//
+// https://datatracker.ietf.org/doc/html/rfc8693#section-4.1
+func NewTokenExchangeActorClaim(actor oauthelia2.Requester, session *Session, prior map[string]any) (claim map[string]any) {
+	claim = map[string]any{}
+
+	if sub := session.GetSubject(); sub != "" {
+		claim[ClaimSubject] = sub
+	} else {
+		claim[ClaimSubject] = actor.GetClient().GetID()
+	}
+
+	claim[ClaimClientIdentifier] = actor.GetClient().GetID()
+
+	if len(prior) != 0 {
+		claim[ClaimActor] = prior
+	}
+
+	return claim
+}
+
+var (
+	_ oauthelia2.TokenEndpointHandler = (*TokenExchangeGrantHandler)(nil)
+)
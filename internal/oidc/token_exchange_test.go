@@ -0,0 +1,635 @@
+package oidc_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	oauthelia2 "authelia.com/provider/oauth2"
+	"authelia.com/provider/oauth2/handler/oauth2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestTokenExchangeGrantHandler_CanHandleTokenEndpointRequest(t *testing.T) {
+	handler := &oidc.TokenExchangeGrantHandler{}
+
+	ctx := context.Background()
+
+	assert.True(t, handler.CanHandleTokenEndpointRequest(ctx, &oauthelia2.AccessRequest{GrantTypes: oauthelia2.Arguments{oidc.GrantTypeTokenExchange}}))
+	assert.False(t, handler.CanHandleTokenEndpointRequest(ctx, &oauthelia2.AccessRequest{GrantTypes: oauthelia2.Arguments{oidc.GrantTypeClientCredentials}}))
+	assert.False(t, handler.CanSkipClientAuth(ctx, &oauthelia2.AccessRequest{GrantTypes: oauthelia2.Arguments{oidc.GrantTypeTokenExchange}}))
+}
+
+func TestTokenExchangeGrantHandler_HandleTokenEndpointRequest(t *testing.T) {
+	const (
+		subjectToken     = "authelia_at_subject"
+		subjectSignature = "subject"
+		actorToken       = "authelia_at_actor"
+		actorSignature   = "actor"
+		subjectUUID      = "2f5ac3b0-9b53-4c1e-8a9c-0fa7b2f5f8a1"
+	)
+
+	expires := time.Now().UTC().Add(time.Minute * 10).Round(time.Second)
+
+	sessionData := func(t *testing.T, subject, clientID string, actor map[string]any) []byte {
+		session := oidc.NewSession()
+		session.Subject = subject
+		session.Username = "john"
+		session.ClientID = clientID
+		session.Actor = actor
+		session.SetExpiresAt(oauthelia2.AccessToken, expires)
+
+		data, err := json.Marshal(session)
+		require.NoError(t, err)
+
+		return data
+	}
+
+	subjectModel := func(t *testing.T, actor map[string]any) *model.OAuth2Session {
+		return &model.OAuth2Session{
+			RequestID:       "a5dc6bb5-12f8-4f49-94ef-e0bd9b5cba8c",
+			ClientID:        "frontend",
+			Signature:       subjectSignature,
+			Subject:         sql.NullString{String: subjectUUID, Valid: true},
+			GrantedScopes:   model.StringSlicePipeDelimited{oidc.ScopeOpenID, oidc.ScopeOfflineAccess, "orders"},
+			GrantedAudience: model.StringSlicePipeDelimited{"https://api.example.com"},
+			Active:          true,
+			Session:         sessionData(t, subjectUUID, "frontend", actor),
+		}
+	}
+
+	config := &schema.IdentityProvidersOpenIDConnect{
+		Clients: []schema.IdentityProvidersOpenIDConnectClient{
+			{
+				ID:         "frontend",
+				Scopes:     []string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess, "orders"},
+				Audience:   []string{"https://api.example.com"},
+				GrantTypes: []string{oidc.GrantTypeAuthorizationCode, oidc.GrantTypeRefreshToken},
+			},
+			{
+				ID:         "service",
+				Scopes:     []string{"orders"},
+				GrantTypes: []string{oidc.GrantTypeClientCredentials},
+			},
+		},
+	}
+
+	form := func(values ...string) url.Values {
+		v := url.Values{}
+
+		for i := 0; i+1 < len(values); i += 2 {
+			v.Add(values[i], values[i+1])
+		}
+
+		return v
+	}
+
+	subjectForm := []string{oidc.FormParameterSubjectToken, subjectToken, oidc.FormParameterSubjectTokenType, oidc.TokenTypeIdentifierAccessToken}
+
+	expectSubject := func(strategy *mocks.MockAccessTokenStrategy, mock *mocks.MockStorage, actor map[string]any) {
+		strategy.EXPECT().AccessTokenSignature(gomock.Any(), subjectToken).Return(subjectSignature)
+		mock.EXPECT().LoadOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeAccessToken, subjectSignature).Return(subjectModel(t, actor), nil)
+		strategy.EXPECT().ValidateAccessToken(gomock.Any(), gomock.Any(), subjectToken).Return(nil)
+	}
+
+	testCases := []struct {
+		name     string
+		grants   []string
+		form     url.Values
+		scopes   oauthelia2.Arguments
+		audience oauthelia2.Arguments
+		setup    func(strategy *mocks.MockAccessTokenStrategy, mock *mocks.MockStorage)
+		err      string
+		expected func(t *testing.T, requester *oauthelia2.AccessRequest, session *oidc.Session)
+	}{
+		{
+			"ShouldFailUnauthorizedClient",
+			[]string{oidc.GrantTypeClientCredentials},
+			form(subjectForm...),
+			nil,
+			nil,
+			nil,
+			"unauthorized_client",
+			nil,
+		},
+		{
+			"ShouldFailUnsupportedRequestedTokenType",
+			nil,
+			form(append(subjectForm, oidc.FormParameterRequestedTokenType, oidc.TokenTypeIdentifierIDToken)...),
+			nil,
+			nil,
+			nil,
+			"invalid_request",
+			nil,
+		},
+		{
+			"ShouldFailMissingSubjectToken",
+			nil,
+			form(oidc.FormParameterSubjectTokenType, oidc.TokenTypeIdentifierAccessToken),
+			nil,
+			nil,
+			nil,
+			"invalid_request",
+			nil,
+		},
+		{
+			"ShouldFailMissingSubjectTokenType",
+			nil,
+			form(oidc.FormParameterSubjectToken, subjectToken),
+			nil,
+			nil,
+			nil,
+			"invalid_request",
+			nil,
+		},
+		{
+			"ShouldFailUnsupportedSubjectTokenType",
+			nil,
+			form(oidc.FormParameterSubjectToken, subjectToken, oidc.FormParameterSubjectTokenType, oidc.TokenTypeIdentifierJWT),
+			nil,
+			nil,
+			nil,
+			"invalid_request",
+			nil,
+		},
+		{
+			"ShouldFailSubjectTokenNotFound",
+			nil,
+			form(subjectForm...),
+			nil,
+			nil,
+			func(strategy *mocks.MockAccessTokenStrategy, mock *mocks.MockStorage) {
+				strategy.EXPECT().AccessTokenSignature(gomock.Any(), subjectToken).Return(subjectSignature)
+				mock.EXPECT().LoadOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeAccessToken, subjectSignature).Return(nil, sql.ErrNoRows)
+			},
+			"invalid_request",
+			nil,
+		},
+		{
+			"ShouldFailSubjectTokenInactive",
+			nil,
+			form(subjectForm...),
+			nil,
+			nil,
+			func(strategy *mocks.MockAccessTokenStrategy, mock *mocks.MockStorage) {
+				session := subjectModel(t, nil)
+				session.Active = false
+
+				strategy.EXPECT().AccessTokenSignature(gomock.Any(), subjectToken).Return(subjectSignature)
+				mock.EXPECT().LoadOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeAccessToken, subjectSignature).Return(session, nil)
+			},
+			"invalid_request",
+			nil,
+		},
+		{
+			"ShouldFailSubjectTokenStorageError",
+			nil,
+			form(subjectForm...),
+			nil,
+			nil,
+			func(strategy *mocks.MockAccessTokenStrategy, mock *mocks.MockStorage) {
+				strategy.EXPECT().AccessTokenSignature(gomock.Any(), subjectToken).Return(subjectSignature)
+				mock.EXPECT().LoadOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeAccessToken, subjectSignature).Return(nil, fmt.Errorf("bad conn"))
+			},
+			"server_error",
+			nil,
+		},
+		{
+			"ShouldFailSubjectTokenValidation",
+			nil,
+			form(subjectForm...),
+			nil,
+			nil,
+			func(strategy *mocks.MockAccessTokenStrategy, mock *mocks.MockStorage) {
+				strategy.EXPECT().AccessTokenSignature(gomock.Any(), subjectToken).Return(subjectSignature)
+				mock.EXPECT().LoadOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeAccessToken, subjectSignature).Return(subjectModel(t, nil), nil)
+				strategy.EXPECT().ValidateAccessToken(gomock.Any(), gomock.Any(), subjectToken).Return(oauthelia2.ErrTokenExpired)
+			},
+			"invalid_request",
+			nil,
+		},
+		{
+			"ShouldFailSubjectTokenAudienceNotAllowed",
+			nil,
+			form(subjectForm...),
+			nil,
+			nil,
+			func(strategy *mocks.MockAccessTokenStrategy, mock *mocks.MockStorage) {
+				session := subjectModel(t, nil)
+				session.ClientID = "service"
+				session.GrantedAudience = model.StringSlicePipeDelimited{"https://other.example.com"}
+
+				strategy.EXPECT().AccessTokenSignature(gomock.Any(), subjectToken).Return(subjectSignature)
+				mock.EXPECT().LoadOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeAccessToken, subjectSignature).Return(session, nil)
+				strategy.EXPECT().ValidateAccessToken(gomock.Any(), gomock.Any(), subjectToken).Return(nil)
+			},
+			"invalid_request",
+			nil,
+		},
+		{
+			"ShouldFailActorTokenTypeWithoutActorToken",
+			nil,
+			form(append(subjectForm, oidc.FormParameterActorTokenType, oidc.TokenTypeIdentifierAccessToken)...),
+			nil,
+			nil,
+			func(strategy *mocks.MockAccessTokenStrategy, mock *mocks.MockStorage) {
+				expectSubject(strategy, mock, nil)
+			},
+			"invalid_request",
+			nil,
+		},
+		{
+			"ShouldFailTargetAudienceNotAllowed",
+			nil,
+			form(subjectForm...),
+			nil,
+			oauthelia2.Arguments{"https://orders.example.com", "https://other.example.com"},
+			func(strategy *mocks.MockAccessTokenStrategy, mock *mocks.MockStorage) {
+				expectSubject(strategy, mock, nil)
+			},
+			"invalid_target",
+			nil,
+		},
+		{
+			"ShouldFailTargetResourceNotAllowed",
+			nil,
+			form(append(subjectForm, oidc.FormParameterResource, "https://other.example.com")...),
+			nil,
+			nil,
+			func(strategy *mocks.MockAccessTokenStrategy, mock *mocks.MockStorage) {
+				expectSubject(strategy, mock, nil)
+			},
+			"invalid_target",
+			nil,
+		},
+		{
+			"ShouldFailScopeNotGrantedToSubjectToken",
+			nil,
+			form(subjectForm...),
+			oauthelia2.Arguments{"billing"},
+			nil,
+			func(strategy *mocks.MockAccessTokenStrategy, mock *mocks.MockStorage) {
+				expectSubject(strategy, mock, nil)
+			},
+			"invalid_scope",
+			nil,
+		},
+		{
+			"ShouldFailScopeOfflineAccess",
+			nil,
+			form(subjectForm...),
+			oauthelia2.Arguments{oidc.ScopeOfflineAccess},
+			nil,
+			func(strategy *mocks.MockAccessTokenStrategy, mock *mocks.MockStorage) {
+				expectSubject(strategy, mock, nil)
+			},
+			"invalid_scope",
+			nil,
+		},
+		{
+			"ShouldHandleImpersonation",
+			nil,
+			form(subjectForm...),
+			oauthelia2.Arguments{"orders"},
+			oauthelia2.Arguments{"https://orders.example.com"},
+			func(strategy *mocks.MockAccessTokenStrategy, mock *mocks.MockStorage) {
+				expectSubject(strategy, mock, nil)
+			},
+			"",
+			func(t *testing.T, requester *oauthelia2.AccessRequest, session *oidc.Session) {
+				assert.Equal(t, oauthelia2.Arguments{"orders"}, requester.GetGrantedScopes())
+				assert.Equal(t, oauthelia2.Arguments{"https://orders.example.com"}, requester.GetGrantedAudience())
+				assert.Equal(t, subjectUUID, session.Subject)
+				assert.Equal(t, "john", session.Username)
+				assert.Equal(t, "backend", session.ClientID)
+				assert.Nil(t, session.Actor)
+				assert.Equal(t, expires, session.GetExpiresAt(oauthelia2.AccessToken))
+			},
+		},
+		{
+			"ShouldHandleImpersonationDefaultScopes",
+			nil,
+			form(append(subjectForm, oidc.FormParameterResource, "https://orders.example.com")...),
+			nil,
+			nil,
+			func(strategy *mocks.MockAccessTokenStrategy, mock *mocks.MockStorage) {
+				expectSubject(strategy, mock, nil)
+			},
+			"",
+			func(t *testing.T, requester *oauthelia2.AccessRequest, session *oidc.Session) {
+				assert.Equal(t, oauthelia2.Arguments{oidc.ScopeOpenID, "orders"}, requester.GetGrantedScopes())
+				assert.Equal(t, oauthelia2.Arguments{"https://orders.example.com"}, requester.GetGrantedAudience())
+			},
+		},
+		{
+			"ShouldHandleDelegation",
+			nil,
+			form(append(subjectForm, oidc.FormParameterActorToken, actorToken, oidc.FormParameterActorTokenType, oidc.TokenTypeIdentifierAccessToken)...),
+			oauthelia2.Arguments{"orders"},
+			nil,
+			func(strategy *mocks.MockAccessTokenStrategy, mock *mocks.MockStorage) {
+				expectSubject(strategy, mock, map[string]any{oidc.ClaimSubject: "gateway"})
+
+				strategy.EXPECT().AccessTokenSignature(gomock.Any(), actorToken).Return(actorSignature)
+				mock.EXPECT().LoadOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeAccessToken, actorSignature).Return(&model.OAuth2Session{
+					RequestID: "f0b3f7a6-1d4c-4b8e-a5c8-7fb7e4cf0a2b",
+					ClientID:  "service",
+					Signature: actorSignature,
+					Active:    true,
+					Session:   sessionData(t, "", "service", nil),
+				}, nil)
+				strategy.EXPECT().ValidateAccessToken(gomock.Any(), gomock.Any(), actorToken).Return(nil)
+			},
+			"",
+			func(t *testing.T, requester *oauthelia2.AccessRequest, session *oidc.Session) {
+				assert.Equal(t, subjectUUID, session.Subject)
+				assert.Equal(t, map[string]any{
+					oidc.ClaimSubject:          "service",
+					oidc.ClaimClientIdentifier: "service",
+					oidc.ClaimActor:            map[string]any{oidc.ClaimSubject: "gateway"},
+				}, session.Actor)
+				assert.Equal(t, session.Actor, session.GetExtraClaims()[oidc.ClaimActor])
+			},
+		},
+		{
+			"ShouldFailDelegationWithActorTokenIssuedToOtherClient",
+			nil,
+			form(append(subjectForm, oidc.FormParameterActorToken, actorToken, oidc.FormParameterActorTokenType, oidc.TokenTypeIdentifierAccessToken)...),
+			oauthelia2.Arguments{"orders"},
+			nil,
+			func(strategy *mocks.MockAccessTokenStrategy, mock *mocks.MockStorage) {
+				expectSubject(strategy, mock, nil)
+
+				strategy.EXPECT().AccessTokenSignature(gomock.Any(), actorToken).Return(actorSignature)
+				mock.EXPECT().LoadOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeAccessToken, actorSignature).Return(&model.OAuth2Session{
+					RequestID: "0c1c4a2e-7f1b-4b8e-9d3a-2b1e5f6a7c8d",
+					ClientID:  "frontend",
+					Signature: actorSignature,
+					Subject:   sql.NullString{String: subjectUUID, Valid: true},
+					Active:    true,
+					Session:   sessionData(t, subjectUUID, "frontend", nil),
+				}, nil)
+				strategy.EXPECT().ValidateAccessToken(gomock.Any(), gomock.Any(), actorToken).Return(nil)
+			},
+			"invalid_request",
+			func(t *testing.T, requester *oauthelia2.AccessRequest, session *oidc.Session) {
+				assert.Nil(t, session.Actor)
+				assert.Len(t, requester.GetGrantedScopes(), 0)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			strategy := mocks.NewMockAccessTokenStrategy(ctrl)
+			mock := mocks.NewMockStorage(ctrl)
+
+			if tc.setup != nil {
+				tc.setup(strategy, mock)
+			}
+
+			handler := &oidc.TokenExchangeGrantHandler{
+				HandleHelper: &oauth2.HandleHelper{
+					AccessTokenStrategy: strategy,
+				},
+				Storage: oidc.NewStore(config, mock),
+				Config:  &oidc.Config{},
+			}
+
+			grants := tc.grants
+			if grants == nil {
+				grants = []string{oidc.GrantTypeTokenExchange}
+			}
+
+			session := oidc.NewSession()
+
+			requester := &oauthelia2.AccessRequest{
+				GrantTypes: oauthelia2.Arguments{oidc.GrantTypeTokenExchange},
+				Request: oauthelia2.Request{
+					Client: &oidc.RegisteredClient{
+						ID:         "backend",
+						Scopes:     []string{oidc.ScopeOpenID, oidc.ScopeOfflineAccess, "orders", "billing"},
+						GrantTypes: grants,
+						TokenExchangePolicy: oidc.ClientTokenExchangePolicy{
+							SubjectTokenAudiences: []string{"https://api.example.com"},
+							TargetAudiences:       []string{"https://orders.example.com"},
+							ActorTokenClients:     []string{"service"},
+						},
+					},
+					Form:              tc.form,
+					RequestedScope:    tc.scopes,
+					RequestedAudience: tc.audience,
+					Session:           session,
+				},
+			}
+
+			err := handler.HandleTokenEndpointRequest(context.Background(), requester)
+
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+
+			if tc.expected != nil {
+				tc.expected(t, requester, session)
+			}
+		})
+	}
+}
+
+func TestTokenExchangeGrantHandler_HandleTokenEndpointRequestExpiration(t *testing.T) {
+	const (
+		subjectToken     = "authelia_at_subject"
+		subjectSignature = "subject"
+		subjectUUID      = "2f5ac3b0-9b53-4c1e-8a9c-0fa7b2f5f8a1"
+	)
+
+	expires := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	subject := oidc.NewSession()
+	subject.Subject = subjectUUID
+	subject.Username = "john"
+	subject.ClientID = "frontend"
+	subject.SetExpiresAt(oauthelia2.AccessToken, expires)
+
+	data, err := json.Marshal(subject)
+	require.NoError(t, err)
+
+	config := &schema.IdentityProvidersOpenIDConnect{
+		Clients: []schema.IdentityProvidersOpenIDConnectClient{
+			{
+				ID:         "frontend",
+				Scopes:     []string{oidc.ScopeOpenID, "orders"},
+				Audience:   []string{"https://api.example.com"},
+				GrantTypes: []string{oidc.GrantTypeAuthorizationCode},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		now      time.Time
+		expected time.Time
+	}{
+		{
+			"ShouldNotOutliveSubjectToken",
+			expires.Add(-time.Minute * 10),
+			expires,
+		},
+		{
+			"ShouldUseClientLifespan",
+			expires.Add(-time.Hour * 3),
+			expires.Add(-time.Hour),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			strategy := mocks.NewMockAccessTokenStrategy(ctrl)
+			mock := mocks.NewMockStorage(ctrl)
+
+			strategy.EXPECT().AccessTokenSignature(gomock.Any(), subjectToken).Return(subjectSignature)
+			mock.EXPECT().LoadOAuth2Session(gomock.Any(), storage.OAuth2SessionTypeAccessToken, subjectSignature).Return(&model.OAuth2Session{
+				RequestID:       "a5dc6bb5-12f8-4f49-94ef-e0bd9b5cba8c",
+				ClientID:        "frontend",
+				Signature:       subjectSignature,
+				Subject:         sql.NullString{String: subjectUUID, Valid: true},
+				GrantedScopes:   model.StringSlicePipeDelimited{oidc.ScopeOpenID, "orders"},
+				GrantedAudience: model.StringSlicePipeDelimited{"https://api.example.com"},
+				Active:          true,
+				Session:         data,
+			}, nil)
+			strategy.EXPECT().ValidateAccessToken(gomock.Any(), gomock.Any(), subjectToken).Return(nil)
+
+			handler := &oidc.TokenExchangeGrantHandler{
+				HandleHelper: &oauth2.HandleHelper{
+					AccessTokenStrategy: strategy,
+				},
+				Storage: oidc.NewStore(config, mock),
+				Config:  &oidc.Config{},
+			}
+
+			session := oidc.NewSession()
+
+			requester := &oauthelia2.AccessRequest{
+				GrantTypes: oauthelia2.Arguments{oidc.GrantTypeTokenExchange},
+				Request: oauthelia2.Request{
+					Client: &oidc.RegisteredClient{
+						ID:         "backend",
+						Scopes:     []string{"orders"},
+						GrantTypes: []string{oidc.GrantTypeTokenExchange},
+						Lifespans: schema.IdentityProvidersOpenIDConnectLifespan{
+							IdentityProvidersOpenIDConnectLifespanToken: schema.IdentityProvidersOpenIDConnectLifespanToken{AccessToken: time.Hour * 2},
+						},
+						TokenExchangePolicy: oidc.ClientTokenExchangePolicy{
+							SubjectTokenAudiences: []string{"https://api.example.com"},
+						},
+					},
+					Form: url.Values{
+						oidc.FormParameterSubjectToken:     []string{subjectToken},
+						oidc.FormParameterSubjectTokenType: []string{oidc.TokenTypeIdentifierAccessToken},
+					},
+					RequestedScope: oauthelia2.Arguments{"orders"},
+					Session:        session,
+				},
+			}
+
+			ctx := &TestContext{Context: context.Background(), Clock: clock.NewFixed(tc.now)}
+
+			require.NoError(t, handler.HandleTokenEndpointRequest(ctx, requester))
+
+			assert.Equal(t, tc.expected, session.GetExpiresAt(oauthelia2.AccessToken))
+		})
+	}
+}
+
+func TestValidateTokenExchangeSubjectAuthorization(t *testing.T) {
+	client := &oidc.RegisteredClient{
+		ID: "backend",
+		AuthorizationPolicy: oidc.ClientAuthorizationPolicy{
+			Name:          "policy",
+			DefaultPolicy: authorization.TwoFactor,
+			Rules: []oidc.ClientAuthorizationPolicyRule{
+				{
+					Subjects: []authorization.AccessControlSubjects{{Subjects: []authorization.SubjectMatcher{authorization.AccessControlUser{Name: "bob"}}}},
+					Policy:   authorization.Denied,
+				},
+			},
+		},
+	}
+
+	session := func(amr ...string) *oidc.Session {
+		s := oidc.NewSession()
+		s.Username = "john"
+		s.Claims.AuthenticationMethodsReferences = amr
+
+		return s
+	}
+
+	testCases := []struct {
+		name    string
+		session *oidc.Session
+		subject authorization.Subject
+		err     string
+	}{
+		{
+			"ShouldAllowSufficientLevel",
+			session(oidc.AMRPasswordBasedAuthentication, oidc.AMROneTimePassword, oidc.AMRMultiFactorAuthentication),
+			authorization.Subject{Username: "john"},
+			"",
+		},
+		{
+			"ShouldDenySubjectBelowRequiredLevel",
+			session(oidc.AMRPasswordBasedAuthentication),
+			authorization.Subject{Username: "john"},
+			"access_denied",
+		},
+		{
+			"ShouldDenySubjectWithoutAuthenticationMethods",
+			session(),
+			authorization.Subject{Username: "john"},
+			"access_denied",
+		},
+		{
+			"ShouldDenySubjectDeniedByPolicy",
+			session(oidc.AMRPasswordBasedAuthentication, oidc.AMROneTimePassword, oidc.AMRMultiFactorAuthentication),
+			authorization.Subject{Username: "bob"},
+			"access_denied",
+		},
+		{
+			"ShouldFailWithoutSession",
+			nil,
+			authorization.Subject{Username: "john"},
+			"server_error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := oidc.ValidateTokenExchangeSubjectAuthorization(client, tc.session, tc.subject)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	oauthelia2 "authelia.com/provider/oauth2"
@@ -40,6 +41,7 @@ type OpenIDConnectProvider struct {
 type Store struct {
 	ClientStore
 
+	config   *schema.IdentityProvidersOpenIDConnect
 	provider storage.Provider
 }
 
@@ -52,6 +54,8 @@ type ClientStore interface {
 // MemoryClientStore is an implementation of the ClientStore which just stores the clients in memory.
 type MemoryClientStore struct {
 	clients map[string]Client
+
+	mu sync.RWMutex
 }
 
 // RegisteredClient represents a registered client.
@@ -108,10 +112,17 @@ type RegisteredClient struct {
 
 	ConsentPolicy         ClientConsentPolicy
 	RequestedAudienceMode ClientRequestedAudienceMode
+	TokenExchangePolicy   ClientTokenExchangePolicy
+
+	RequestURIs            []string
+	PostLogoutRedirectURIs []string
+	JSONWebKeys            *jose.JSONWebKeySet
+	JSONWebKeysURI         *url.URL
 
-	RequestURIs    []string
-	JSONWebKeys    *jose.JSONWebKeySet
-	JSONWebKeysURI *url.URL
+	BackChannelLogoutURI              *url.URL
+	BackChannelLogoutSessionRequired  bool
+	FrontChannelLogoutURI             *url.URL
+	FrontChannelLogoutSessionRequired bool
 }
 
 // Client represents the internal client definitions.
@@ -122,6 +133,12 @@ type Client interface {
 
 	GetName() (name string)
 	GetSectorIdentifierURI() (sector string)
+	GetPostLogoutRedirectURIs() (redirectURIs []string)
+	IsPostLogoutRedirectURIAllowed(redirectURI string) (allowed bool)
+	GetBackChannelLogoutURI() (uri string)
+	GetBackChannelLogoutSessionRequired() (required bool)
+	GetFrontChannelLogoutURI() (uri string)
+	GetFrontChannelLogoutSessionRequired() (required bool)
 
 	GetAuthorizationSignedResponseAlg() (alg string)
 	GetAuthorizationSignedResponseKeyID() (kid string)
@@ -152,6 +169,7 @@ type Client interface {
 	IsAuthenticationLevelSufficient(level authentication.Level, subject authorization.Subject) (sufficient bool)
 	GetAuthorizationPolicyRequiredLevel(subject authorization.Subject) (level authorization.Level)
 	GetAuthorizationPolicy() (policy ClientAuthorizationPolicy)
+	GetTokenExchangePolicy() (policy ClientTokenExchangePolicy)
 
 	GetEffectiveLifespan(gt oauthelia2.GrantType, tt oauthelia2.TokenType, fallback time.Duration) (lifespan time.Duration)
 }
@@ -221,6 +239,19 @@ type UserDetailer interface {
 	GetGroups() (groups []string)
 	GetDisplayName() (name string)
 	GetEmails() (emails []string)
+	GetAttributes() (attributes map[string]string)
+}
+
+// DeviceAuthorizationResponse is the response body of the Device Authorization Endpoint.
+//
+// https://datatracker.ietf.org/doc/html/rfc8628#section-3.2
+type DeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int64  `json:"expires_in"`
+	Interval                int64  `json:"interval,omitempty"`
 }
 
 // ConsentGetResponseBody schema of the response body of the consent GET endpoint.
@@ -230,6 +261,7 @@ type ConsentGetResponseBody struct {
 	Scopes            []string `json:"scopes"`
 	Audience          []string `json:"audience"`
 	PreConfiguration  bool     `json:"pre_configuration"`
+	UserCode          string   `json:"user_code,omitempty"`
 }
 
 // ConsentPostRequestBody schema of the request body of the consent POST endpoint.
@@ -245,6 +277,24 @@ type ConsentPostResponseBody struct {
 	RedirectURI string `json:"redirect_uri"`
 }
 
+// LogoutGetResponseBody schema of the response body of the logout confirmation GET endpoint.
+type LogoutGetResponseBody struct {
+	ClientID          string `json:"client_id,omitempty"`
+	ClientDescription string `json:"client_description,omitempty"`
+}
+
+// LogoutPostRequestBody schema of the request body of the logout confirmation POST endpoint.
+type LogoutPostRequestBody struct {
+	ClientID              string `json:"client_id"`
+	PostLogoutRedirectURI string `json:"post_logout_redirect_uri"`
+	State                 string `json:"state"`
+}
+
+// LogoutPostResponseBody schema of the response body of the logout confirmation POST endpoint.
+type LogoutPostResponseBody struct {
+	RedirectURI string `json:"redirect_uri"`
+}
+
 /*
 CommonDiscoveryOptions represents the discovery options used in both OAuth 2.0 and OpenID Connect.
 See Also:
@@ -3,6 +3,7 @@ package oidc
 import (
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"sort"
@@ -368,6 +369,80 @@ func IsMaybeSignedJWT(value string) (is bool) {
 	return strings.Count(value, ".") == 2
 }
 
+// ValidateRedirectURI performs the validation of a redirect URI common to every client regardless of how it was
+// registered. The special out-of-band value is only permitted for the public client type and the returned URL is nil
+// in this instance.
+func ValidateRedirectURI(redirectURI string, public bool) (uri *url.URL, err error) {
+	if redirectURI == RedirectURISpecialOAuth2InstalledApp {
+		if public {
+			return nil, nil
+		}
+
+		return nil, ErrRedirectURIPublicOnly
+	}
+
+	if uri, err = url.Parse(redirectURI); err != nil {
+		return nil, err
+	}
+
+	if !uri.IsAbs() {
+		return nil, ErrRedirectURINotAbsolute
+	}
+
+	return uri, nil
+}
+
+// ValidateRegistrationRedirectURI validates a redirect URI for a dynamically registered client. In addition to the
+// validation performed by ValidateRedirectURI this requires the https scheme with the exception of loopback http URIs
+// and private-use schemes for the public client type as described by RFC8252, and never permits schemes which can
+// be abused to execute or read content in the context of the user agent. The out-of-band redirect URI is not accepted.
+//
+// https://datatracker.ietf.org/doc/html/rfc8252#section-7
+func ValidateRegistrationRedirectURI(redirectURI string, public bool) (err error) {
+	if redirectURI == RedirectURISpecialOAuth2InstalledApp {
+		return ErrRedirectURIOutOfBand
+	}
+
+	var uri *url.URL
+
+	if uri, err = ValidateRedirectURI(redirectURI, public); err != nil {
+		return err
+	}
+
+	if uri.Fragment != "" {
+		return ErrRedirectURIFragment
+	}
+
+	switch scheme := strings.ToLower(uri.Scheme); {
+	case scheme == schemeHTTPS:
+		return nil
+	case utils.IsStringInSlice(scheme, forbiddenRedirectURISchemes):
+		return ErrRedirectURIForbiddenScheme
+	case !public:
+		return ErrRedirectURIInsecureScheme
+	case scheme == schemeHTTP:
+		if isLoopbackHost(uri.Hostname()) {
+			return nil
+		}
+
+		return ErrRedirectURIInsecureScheme
+	case strings.Contains(scheme, "."):
+		return nil
+	default:
+		return ErrRedirectURIInsecureScheme
+	}
+}
+
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
+
 func ValidateSectorIdentifierURI(ctx ClientContext, cache map[string][]string, sectorURI *url.URL, redirectURIs []string) (err error) {
 	var (
 		sectorRedirectURIs []string
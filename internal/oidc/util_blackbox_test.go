@@ -221,3 +221,71 @@ func (t TestGetLangRequester) Merge(requester oauthelia2.Requester) {}
 func (t TestGetLangRequester) Sanitize(allowedParameters []string) oauthelia2.Requester {
 	return nil
 }
+
+func TestValidateRedirectURI(t *testing.T) {
+	testCases := []struct {
+		name   string
+		have   string
+		public bool
+		err    string
+	}{
+		{"ShouldAllowHTTPS", "https://app.example.com/callback", false, ""},
+		{"ShouldAllowHTTP", "http://app.example.com/callback", false, ""},
+		{"ShouldAllowOutOfBandPublic", oidc.RedirectURISpecialOAuth2InstalledApp, true, ""},
+		{"ShouldNotAllowOutOfBandConfidential", oidc.RedirectURISpecialOAuth2InstalledApp, false, "the redirect uri is only valid for the public client type"},
+		{"ShouldNotAllowRelative", "/callback", true, "the redirect uri must have a scheme"},
+		{"ShouldNotAllowUnparsable", "http://abc@%two", false, "parse \"http://abc@%two\": invalid URL escape \"%tw\""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := oidc.ValidateRedirectURI(tc.have, tc.public)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestValidateRegistrationRedirectURI(t *testing.T) {
+	testCases := []struct {
+		name   string
+		have   string
+		public bool
+		err    error
+	}{
+		{"ShouldAllowHTTPS", "https://app.example.com/callback", false, nil},
+		{"ShouldAllowHTTPSUpperCaseScheme", "HTTPS://app.example.com/callback", false, nil},
+		{"ShouldAllowLoopbackIPv4Public", "http://127.0.0.1:51004/callback", true, nil},
+		{"ShouldAllowLoopbackIPv6Public", "http://[::1]:51004/callback", true, nil},
+		{"ShouldAllowLocalhostPublic", "http://localhost/callback", true, nil},
+		{"ShouldAllowPrivateUseSchemePublic", "com.example.app:/oauth2redirect", true, nil},
+		{"ShouldNotAllowLoopbackConfidential", "http://127.0.0.1:51004/callback", false, oidc.ErrRedirectURIInsecureScheme},
+		{"ShouldNotAllowPrivateUseSchemeConfidential", "com.example.app:/oauth2redirect", false, oidc.ErrRedirectURIInsecureScheme},
+		{"ShouldNotAllowHTTPPublic", "http://app.example.com/callback", true, oidc.ErrRedirectURIInsecureScheme},
+		{"ShouldNotAllowCustomSchemePublic", "myapp:/callback", true, oidc.ErrRedirectURIInsecureScheme},
+		{"ShouldNotAllowOutOfBandPublic", oidc.RedirectURISpecialOAuth2InstalledApp, true, oidc.ErrRedirectURIOutOfBand},
+		{"ShouldNotAllowOutOfBandConfidential", oidc.RedirectURISpecialOAuth2InstalledApp, false, oidc.ErrRedirectURIOutOfBand},
+		{"ShouldNotAllowJavaScript", "javascript:alert(1)", true, oidc.ErrRedirectURIForbiddenScheme},
+		{"ShouldNotAllowJavaScriptMixedCase", "JavaScript:alert(1)", true, oidc.ErrRedirectURIForbiddenScheme},
+		{"ShouldNotAllowData", "data:text/html;base64,PHNjcmlwdD4=", true, oidc.ErrRedirectURIForbiddenScheme},
+		{"ShouldNotAllowFile", "file:///etc/passwd", true, oidc.ErrRedirectURIForbiddenScheme},
+		{"ShouldNotAllowFragment", "https://app.example.com/callback#frag", false, oidc.ErrRedirectURIFragment},
+		{"ShouldNotAllowRelative", "/callback", false, oidc.ErrRedirectURINotAbsolute},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := oidc.ValidateRegistrationRedirectURI(tc.have, tc.public)
+
+			if tc.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
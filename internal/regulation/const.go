@@ -9,6 +9,12 @@ const (
 	// AuthType1FA is the string representing an auth log for first-factor authentication.
 	AuthType1FA = "1FA"
 
+	// AuthTypeSPNEGO is the string representing an auth log for first-factor authentication via Kerberos SPNEGO.
+	AuthTypeSPNEGO = "SPNEGO"
+
+	// AuthTypeMTLS is the string representing an auth log for first-factor authentication via a client certificate.
+	AuthTypeMTLS = "mTLS"
+
 	// AuthTypeTOTP is the string representing an auth log for second-factor authentication via TOTP.
 	AuthTypeTOTP = "TOTP"
 
@@ -17,4 +23,7 @@ const (
 
 	// AuthTypeDuo is the string representing an auth log for second-factor authentication via DUO.
 	AuthTypeDuo = "Duo"
+
+	// AuthTypeUserCode is the string representing an auth log for the user code of a Device Authorization Request.
+	AuthTypeUserCode = "UserCode"
 )
@@ -252,6 +252,15 @@ func handleRouter(config *schema.Configuration, providers middlewares.Providers)
 	delayFunc := middlewares.TimingAttackDelay(10, 250, 85, time.Second, true)
 
 	r.POST("/api/firstfactor", middlewareAPI(handlers.FirstFactorPOST(delayFunc)))
+
+	if config.AuthenticationBackend.Kerberos != nil {
+		r.POST("/api/firstfactor/spnego", middlewareAPI(handlers.FirstFactorSPNEGOPOST))
+	}
+
+	if config.AuthenticationBackend.ClientCertificate != nil {
+		r.POST("/api/firstfactor/certificate", middlewareAPI(handlers.FirstFactorClientCertificatePOST))
+	}
+
 	r.POST("/api/logout", middlewareAPI(handlers.LogoutPOST))
 
 	// Only register endpoints if forgot password is not disabled.
@@ -342,6 +351,9 @@ func handleRouter(config *schema.Configuration, providers middlewares.Providers)
 		r.GET("/api/oidc/consent", bridgeOIDC(handlers.OpenIDConnectConsentGET))
 		r.POST("/api/oidc/consent", bridgeOIDC(handlers.OpenIDConnectConsentPOST))
 
+		r.GET("/api/oidc/logout", bridgeOIDC(handlers.OpenIDConnectLogoutGET))
+		r.POST("/api/oidc/logout", bridgeOIDC(handlers.OpenIDConnectLogoutPOST))
+
 		allowedOrigins := utils.StringSliceFromURLs(config.IdentityProviders.OIDC.CORS.AllowedOrigins)
 
 		r.OPTIONS(oidc.EndpointPathWellKnownOpenIDConfiguration, policyCORSPublicGET.HandleOPTIONS)
@@ -431,6 +443,39 @@ func handleRouter(config *schema.Configuration, providers middlewares.Providers)
 		// TODO (james-d-elliott): Remove in GA. This is a legacy implementation of the above endpoint.
 		r.OPTIONS("/api/oidc/revoke", policyCORSRevocation.HandleOPTIONS)
 		r.POST("/api/oidc/revoke", middlewares.Wrap(middlewares.NewMetricsRequestOpenIDConnect(providers.Metrics, oidc.EndpointRevocation), policyCORSRevocation.Middleware(bridgeOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OAuthRevocationPOST)))))
+
+		policyCORSEndSession := middlewares.NewCORSPolicyBuilder().
+			WithAllowedMethods(fasthttp.MethodOptions, fasthttp.MethodGet, fasthttp.MethodPost).
+			WithAllowedOrigins(allowedOrigins...).
+			WithEnabled(utils.IsStringInSlice(oidc.EndpointEndSession, config.IdentityProviders.OIDC.CORS.Endpoints)).
+			Build()
+
+		endSession := middlewares.Wrap(middlewares.NewMetricsRequestOpenIDConnect(providers.Metrics, oidc.EndpointEndSession), policyCORSEndSession.Middleware(bridgeOIDC(handlers.OpenIDConnectEndSession)))
+
+		r.OPTIONS(oidc.EndpointPathEndSession, policyCORSEndSession.HandleOnlyOPTIONS)
+		r.GET(oidc.EndpointPathEndSession, endSession)
+		r.POST(oidc.EndpointPathEndSession, endSession)
+
+		r.GET(oidc.EndpointPathFrontChannelLogout, bridgeOIDC(handlers.OpenIDConnectFrontChannelLogout))
+
+		policyCORSDeviceAuthorization := middlewares.NewCORSPolicyBuilder().
+			WithAllowedMethods(fasthttp.MethodOptions, fasthttp.MethodPost).
+			WithAllowedOrigins(allowedOrigins...).
+			WithEnabled(utils.IsStringInSlice(oidc.EndpointDeviceAuthorization, config.IdentityProviders.OIDC.CORS.Endpoints)).
+			Build()
+
+		r.OPTIONS(oidc.EndpointPathDeviceAuthorization, policyCORSDeviceAuthorization.HandleOPTIONS)
+		r.POST(oidc.EndpointPathDeviceAuthorization, middlewares.Wrap(middlewares.NewMetricsRequestOpenIDConnect(providers.Metrics, oidc.EndpointDeviceAuthorization), policyCORSDeviceAuthorization.Middleware(bridgeOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OpenIDConnectDeviceAuthorizationPOST)))))
+
+		r.GET(oidc.EndpointPathRFC8628UserVerificationURL, middlewares.Wrap(middlewares.NewMetricsRequestOpenIDConnect(providers.Metrics, "device_code_user_verification"), bridgeOIDC(handlers.OpenIDConnectDeviceCodeUserVerificationGET)))
+
+		if config.IdentityProviders.OIDC.DynamicClientRegistration.Enabled {
+			r.POST(oidc.EndpointPathRegistration, middlewares.Wrap(middlewares.NewMetricsRequestOpenIDConnect(providers.Metrics, oidc.EndpointRegistration), bridgeOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OpenIDConnectDynamicClientRegistrationPOST))))
+
+			r.GET(oidc.EndpointPathRegistration+"/{client_id}", middlewares.Wrap(middlewares.NewMetricsRequestOpenIDConnect(providers.Metrics, oidc.EndpointRegistration), bridgeOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OpenIDConnectDynamicClientManagementGET))))
+			r.PUT(oidc.EndpointPathRegistration+"/{client_id}", middlewares.Wrap(middlewares.NewMetricsRequestOpenIDConnect(providers.Metrics, oidc.EndpointRegistration), bridgeOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OpenIDConnectDynamicClientManagementPUT))))
+			r.DELETE(oidc.EndpointPathRegistration+"/{client_id}", middlewares.Wrap(middlewares.NewMetricsRequestOpenIDConnect(providers.Metrics, oidc.EndpointRegistration), bridgeOIDC(middlewares.NewHTTPToAutheliaHandlerAdaptor(handlers.OpenIDConnectDynamicClientManagementDELETE))))
+		}
 	}
 
 	r.RedirectFixedPath = false
@@ -64,7 +64,13 @@ func CreateDefaultServer(config *schema.Configuration, providers middlewares.Pro
 			// ClientCAs should never be nil, otherwise the system cert pool is used for client authentication
 			// but we don't want everybody on the Internet to be able to authenticate.
 			server.TLSConfig.ClientCAs = caCertPool
-			server.TLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+
+			switch config.Server.TLS.ClientAuthentication {
+			case schema.ServerTLSClientAuthenticationOptional:
+				server.TLSConfig.ClientAuth = tls.VerifyClientCertIfGiven
+			default:
+				server.TLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+			}
 		}
 
 		listener = tls.NewListener(listener, server.TLSConfig.Clone())
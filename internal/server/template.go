@@ -269,6 +269,8 @@ func NewTemplatedFileOptions(config *schema.Configuration) (opts *TemplatedFileO
 		RememberMe:             strconv.FormatBool(!config.Session.DisableRememberMe),
 		ResetPassword:          strconv.FormatBool(!config.AuthenticationBackend.PasswordReset.Disable),
 		ResetPasswordCustomURL: config.AuthenticationBackend.PasswordReset.CustomURL.String(),
+		SPNEGO:                 strconv.FormatBool(config.AuthenticationBackend.Kerberos != nil),
+		ClientCertificate:      strconv.FormatBool(config.AuthenticationBackend.ClientCertificate != nil),
 		PrivacyPolicyURL:       "",
 		PrivacyPolicyAccept:    strFalse,
 		Theme:                  config.Theme,
@@ -300,6 +302,8 @@ type TemplatedFileOptions struct {
 	RememberMe             string
 	ResetPassword          string
 	ResetPasswordCustomURL string
+	SPNEGO                 string
+	ClientCertificate      string
 	PrivacyPolicyURL       string
 	PrivacyPolicyAccept    string
 	Session                string
@@ -330,6 +334,8 @@ func (options *TemplatedFileOptions) CommonData(base, baseURL, domain, nonce, lo
 		RememberMe:             options.RememberMe,
 		ResetPassword:          options.ResetPassword,
 		ResetPasswordCustomURL: options.ResetPasswordCustomURL,
+		SPNEGO:                 options.SPNEGO,
+		ClientCertificate:      options.ClientCertificate,
 		PrivacyPolicyURL:       options.PrivacyPolicyURL,
 		PrivacyPolicyAccept:    options.PrivacyPolicyAccept,
 		Session:                options.Session,
@@ -349,6 +355,8 @@ func (options *TemplatedFileOptions) commonDataWithRememberMe(base, baseURL, dom
 		RememberMe:             rememberMe,
 		ResetPassword:          options.ResetPassword,
 		ResetPasswordCustomURL: options.ResetPasswordCustomURL,
+		SPNEGO:                 options.SPNEGO,
+		ClientCertificate:      options.ClientCertificate,
 		Session:                options.Session,
 		Theme:                  options.Theme,
 	}
@@ -383,6 +391,8 @@ type TemplatedFileCommonData struct {
 	RememberMe             string
 	ResetPassword          string
 	ResetPasswordCustomURL string
+	SPNEGO                 string
+	ClientCertificate      string
 	PrivacyPolicyURL       string
 	PrivacyPolicyAccept    string
 	Session                string
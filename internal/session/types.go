@@ -27,6 +27,8 @@ type UserSession struct {
 	Groups []string
 	Emails []string
 
+	Attributes map[string]string
+
 	KeepMeLoggedIn      bool
 	AuthenticationLevel authentication.Level
 	LastActivity        int64
@@ -47,6 +49,17 @@ type UserSession struct {
 	RefreshTTL time.Time
 
 	Elevations Elevations
+
+	// OpenIDConnect holds the OpenID Connect 1.0 session data used to propagate the logout to the relying parties.
+	OpenIDConnect *OpenIDConnect
+}
+
+// OpenIDConnect holds the OpenID Connect 1.0 session identifier used as the 'sid' claim and the client ids of the
+// relying parties which were issued tokens during this session.
+type OpenIDConnect struct {
+	SessionID  string
+	Clients    []string
+	RequestIDs []string
 }
 
 // TOTP holds the TOTP registration session data.
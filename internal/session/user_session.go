@@ -6,6 +6,8 @@ import (
 
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // NewDefaultUserSession create a default user session.
@@ -22,11 +24,41 @@ func (s *UserSession) IsAnonymous() bool {
 	return s.Username == "" || s.AuthenticationLevel == authentication.NotAuthenticated
 }
 
-// SetOneFactor sets the 1FA AMR's and expected property values for one factor authentication.
+// SetOneFactor sets the 1FA AMR's and expected property values for one factor authentication. A session which the same
+// user already authenticated with two factors retains the second factor so that only the first factor is re-prompted
+// when it exceeds the maximum authentication age, otherwise any second factor state is cleared.
 func (s *UserSession) SetOneFactor(now time.Time, details *authentication.UserDetails, keepMeLoggedIn bool) {
+	s.setOneFactor(now, details, keepMeLoggedIn)
+
+	s.AuthenticationMethodRefs.UsernameAndPassword = true
+}
+
+// SetOneFactorKerberos sets the relevant Kerberos AMR's and expected property values for one factor authentication
+// performed via Kerberos SPNEGO. See SetOneFactor for how existing second factor state is handled.
+func (s *UserSession) SetOneFactorKerberos(now time.Time, details *authentication.UserDetails) {
+	s.setOneFactor(now, details, false)
+
+	s.AuthenticationMethodRefs.Kerberos = true
+}
+
+// SetOneFactorCertificate sets the relevant client certificate AMR's and expected property values for one factor
+// authentication performed via a TLS client certificate. See SetOneFactor for how existing second factor state is
+// handled.
+func (s *UserSession) SetOneFactorCertificate(now time.Time, details *authentication.UserDetails) {
+	s.setOneFactor(now, details, false)
+
+	s.AuthenticationMethodRefs.Certificate = true
+}
+
+func (s *UserSession) setOneFactor(now time.Time, details *authentication.UserDetails, keepMeLoggedIn bool) {
+	if s.Username != details.Username || s.AuthenticationLevel < authentication.TwoFactor {
+		s.AuthenticationLevel = authentication.OneFactor
+		s.SecondFactorAuthnTimestamp = 0
+		s.AuthenticationMethodRefs = oidc.AuthenticationMethodsReferences{}
+	}
+
 	s.FirstFactorAuthnTimestamp = now.Unix()
 	s.LastActivity = now.Unix()
-	s.AuthenticationLevel = authentication.OneFactor
 
 	s.KeepMeLoggedIn = keepMeLoggedIn
 
@@ -34,8 +66,7 @@ func (s *UserSession) SetOneFactor(now time.Time, details *authentication.UserDe
 	s.DisplayName = details.DisplayName
 	s.Groups = details.Groups
 	s.Emails = details.Emails
-
-	s.AuthenticationMethodRefs.UsernameAndPassword = true
+	s.Attributes = details.Attributes
 }
 
 func (s *UserSession) setTwoFactor(now time.Time) {
@@ -113,3 +144,26 @@ func (s *UserSession) GetDisplayName() (name string) {
 func (s *UserSession) GetEmails() (emails []string) {
 	return s.Emails
 }
+
+func (s *UserSession) GetAttributes() (attributes map[string]string) {
+	return s.Attributes
+}
+
+// SetOpenIDConnectClient records the client id of a relying party and the id of the request which it was issued tokens
+// for during this session and returns the OpenID Connect 1.0 session identifier, using the provided session identifier
+// if one doesn't exist.
+func (s *UserSession) SetOpenIDConnectClient(sessionID, clientID, requestID string) (sid string) {
+	if s.OpenIDConnect == nil || s.OpenIDConnect.SessionID == "" {
+		s.OpenIDConnect = &OpenIDConnect{SessionID: sessionID}
+	}
+
+	if !utils.IsStringInSlice(clientID, s.OpenIDConnect.Clients) {
+		s.OpenIDConnect.Clients = append(s.OpenIDConnect.Clients, clientID)
+	}
+
+	if !utils.IsStringInSlice(requestID, s.OpenIDConnect.RequestIDs) {
+		s.OpenIDConnect.RequestIDs = append(s.OpenIDConnect.RequestIDs, requestID)
+	}
+
+	return s.OpenIDConnect.SessionID
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/oidc"
 )
 
@@ -55,6 +56,89 @@ func TestUserSession_SetTwoFactorWebAuthn(t *testing.T) {
 	}
 }
 
+func TestUserSession_SetOneFactor(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		have                 UserSession
+		username             string
+		expectedLevel        authentication.Level
+		expectedSecondFactor int64
+		expected             oidc.AuthenticationMethodsReferences
+	}{
+		{
+			"ShouldSetOneFactor",
+			UserSession{},
+			"john",
+			authentication.OneFactor,
+			0,
+			oidc.AuthenticationMethodsReferences{UsernameAndPassword: true},
+		},
+		{
+			"ShouldRetainSecondFactorSameUser",
+			UserSession{Username: "john", AuthenticationLevel: authentication.TwoFactor, SecondFactorAuthnTimestamp: 500, AuthenticationMethodRefs: oidc.AuthenticationMethodsReferences{UsernameAndPassword: true, TOTP: true}},
+			"john",
+			authentication.TwoFactor,
+			500,
+			oidc.AuthenticationMethodsReferences{UsernameAndPassword: true, TOTP: true},
+		},
+		{
+			"ShouldClearSecondFactorDifferentUser",
+			UserSession{Username: "harry", AuthenticationLevel: authentication.TwoFactor, SecondFactorAuthnTimestamp: 500, AuthenticationMethodRefs: oidc.AuthenticationMethodsReferences{UsernameAndPassword: true, WebAuthn: true}},
+			"john",
+			authentication.OneFactor,
+			0,
+			oidc.AuthenticationMethodsReferences{UsernameAndPassword: true},
+		},
+		{
+			"ShouldClearSecondFactorStateOneFactor",
+			UserSession{Username: "john", AuthenticationLevel: authentication.OneFactor, AuthenticationMethodRefs: oidc.AuthenticationMethodsReferences{TOTP: true}},
+			"john",
+			authentication.OneFactor,
+			0,
+			oidc.AuthenticationMethodsReferences{UsernameAndPassword: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.have
+
+			actual.SetOneFactor(time.Unix(1000, 0), &authentication.UserDetails{Username: tc.username}, false)
+
+			assert.Equal(t, tc.username, actual.Username)
+			assert.Equal(t, tc.expectedLevel, actual.AuthenticationLevel)
+			assert.Equal(t, int64(1000), actual.FirstFactorAuthnTimestamp)
+			assert.Equal(t, tc.expectedSecondFactor, actual.SecondFactorAuthnTimestamp)
+			assert.Equal(t, tc.expected, actual.AuthenticationMethodRefs)
+		})
+	}
+}
+
+func TestUserSession_SetOneFactorKerberos(t *testing.T) {
+	session := UserSession{}
+
+	session.SetOneFactorKerberos(time.Unix(1000, 0), &authentication.UserDetails{Username: "john"})
+
+	assert.Equal(t, "john", session.Username)
+	assert.Equal(t, authentication.OneFactor, session.AuthenticationLevel)
+	assert.Equal(t, int64(1000), session.FirstFactorAuthnTimestamp)
+	assert.False(t, session.KeepMeLoggedIn)
+	assert.Equal(t, oidc.AuthenticationMethodsReferences{Kerberos: true}, session.AuthenticationMethodRefs)
+	assert.Equal(t, []string{oidc.AMRWindowsIntegratedAuthentication}, session.AuthenticationMethodRefs.MarshalRFC8176())
+}
+
+func TestUserSession_SetOneFactorCertificate(t *testing.T) {
+	session := UserSession{}
+
+	session.SetOneFactorCertificate(time.Unix(1000, 0), &authentication.UserDetails{Username: "john"})
+
+	assert.Equal(t, "john", session.Username)
+	assert.Equal(t, authentication.OneFactor, session.AuthenticationLevel)
+	assert.Equal(t, int64(1000), session.FirstFactorAuthnTimestamp)
+	assert.Equal(t, oidc.AuthenticationMethodsReferences{Certificate: true}, session.AuthenticationMethodRefs)
+	assert.Equal(t, []string{oidc.AMRSmartCard}, session.AuthenticationMethodRefs.MarshalRFC8176())
+}
+
 func TestUserSession_Misc(t *testing.T) {
 	session := &UserSession{}
 
@@ -89,3 +173,14 @@ func TestUserSession_Misc(t *testing.T) {
 	assert.Equal(t, []string{"abc@example.com", "xyz@example.com"}, session.GetEmails())
 	assert.Equal(t, []string{"agroup", "bgroup"}, session.GetGroups())
 }
+
+func TestUserSession_SetOpenIDConnectClient(t *testing.T) {
+	session := &UserSession{}
+
+	assert.Equal(t, "sid1", session.SetOpenIDConnectClient("sid1", "app", "req1"))
+	assert.Equal(t, "sid1", session.SetOpenIDConnectClient("sid2", "api", "req2"))
+	assert.Equal(t, "sid1", session.SetOpenIDConnectClient("sid3", "app", "req3"))
+	assert.Equal(t, "sid1", session.SetOpenIDConnectClient("sid4", "app", "req3"))
+
+	assert.Equal(t, &OpenIDConnect{SessionID: "sid1", Clients: []string{"app", "api"}, RequestIDs: []string{"req1", "req2", "req3"}}, session.OpenIDConnect)
+}
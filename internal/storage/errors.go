@@ -14,6 +14,12 @@ var (
 	// ErrNoWebAuthnCredential error thrown when no WebAuthn credential handle has been found in DB.
 	ErrNoWebAuthnCredential = errors.New("no WebAuthn credential found")
 
+	// ErrNoUser error thrown when no user has been found in the user database tables.
+	ErrNoUser = errors.New("no user found")
+
+	// ErrNoOAuth2Client error thrown when no dynamically registered OAuth 2.0 client has been found in DB.
+	ErrNoOAuth2Client = errors.New("no oauth2 client found")
+
 	// ErrNoDuoDevice error thrown when no Duo device and method has been found in DB.
 	ErrNoDuoDevice = errors.New("no Duo device and method saved")
 
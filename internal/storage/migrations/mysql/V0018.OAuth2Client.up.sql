@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS oauth2_client (
+    id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+    client_id VARCHAR(255) NOT NULL,
+    client_name VARCHAR(255) NOT NULL,
+    client_secret TEXT NULL,
+    registration_access_token_signature VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    metadata TEXT NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_520_ci;
+
+CREATE UNIQUE INDEX oauth2_client_client_id_key ON oauth2_client (client_id);
+CREATE UNIQUE INDEX oauth2_client_registration_access_token_signature_key ON oauth2_client (registration_access_token_signature);
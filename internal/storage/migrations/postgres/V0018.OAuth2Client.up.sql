@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS oauth2_client (
+    id SERIAL CONSTRAINT oauth2_client_pkey PRIMARY KEY,
+    client_id VARCHAR(255) NOT NULL,
+    client_name VARCHAR(255) NOT NULL,
+    client_secret TEXT NULL DEFAULT NULL,
+    registration_access_token_signature VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    metadata TEXT NOT NULL
+);
+
+CREATE UNIQUE INDEX oauth2_client_client_id_key ON oauth2_client (client_id);
+CREATE UNIQUE INDEX oauth2_client_registration_access_token_signature_key ON oauth2_client (registration_access_token_signature);
@@ -182,6 +182,26 @@ type Provider interface {
 	// purpose of deletion.
 	LoadOneTimeCodeByPublicID(ctx context.Context, id uuid.UUID) (code *model.OneTimeCode, err error)
 
+	/*
+		Implementation for OAuth2.0 Dynamically Registered Clients.
+	*/
+
+	// SaveOAuth2Client saves a dynamically registered OAuth2.0 client to the storage provider.
+	SaveOAuth2Client(ctx context.Context, client model.OAuth2Client) (err error)
+
+	// UpdateOAuth2Client updates the metadata of an existing dynamically registered OAuth2.0 client in the storage
+	// provider.
+	UpdateOAuth2Client(ctx context.Context, client model.OAuth2Client) (err error)
+
+	// DeleteOAuth2Client deletes a dynamically registered OAuth2.0 client from the storage provider given the client id.
+	DeleteOAuth2Client(ctx context.Context, clientID string) (err error)
+
+	// LoadOAuth2Client loads a dynamically registered OAuth2.0 client from the storage provider given the client id.
+	LoadOAuth2Client(ctx context.Context, clientID string) (client *model.OAuth2Client, err error)
+
+	// LoadOAuth2Clients loads dynamically registered OAuth2.0 clients from the storage provider.
+	LoadOAuth2Clients(ctx context.Context, limit, page int) (clients []model.OAuth2Client, err error)
+
 	/*
 		Implementation for OAuth2.0 Consent Pre-Configurations.
 	*/
@@ -235,6 +255,27 @@ type Provider interface {
 	// LoadOAuth2Session saves an OAuth2.0 session from the storage provider.
 	LoadOAuth2Session(ctx context.Context, sessionType OAuth2SessionType, signature string) (session *model.OAuth2Session, err error)
 
+	/*
+		Implementation for OAuth2.0 Device Authorization Grant Sessions.
+	*/
+
+	// SaveOAuth2DeviceCodeSession saves an OAuth2.0 device code session to the storage provider.
+	SaveOAuth2DeviceCodeSession(ctx context.Context, session model.OAuth2DeviceCodeSession) (err error)
+
+	// UpdateOAuth2DeviceCodeSession updates an existing OAuth2.0 device code session in the storage provider.
+	UpdateOAuth2DeviceCodeSession(ctx context.Context, session model.OAuth2DeviceCodeSession) (err error)
+
+	// RevokeOAuth2DeviceCodeSession marks an OAuth2.0 device code session as revoked in the storage provider.
+	RevokeOAuth2DeviceCodeSession(ctx context.Context, signature string) (err error)
+
+	// LoadOAuth2DeviceCodeSession loads an OAuth2.0 device code session from the storage provider given the device
+	// code signature.
+	LoadOAuth2DeviceCodeSession(ctx context.Context, signature string) (session *model.OAuth2DeviceCodeSession, err error)
+
+	// LoadOAuth2DeviceCodeSessionByUserCode loads an OAuth2.0 device code session from the storage provider given the
+	// user code signature.
+	LoadOAuth2DeviceCodeSessionByUserCode(ctx context.Context, userCodeSignature string) (session *model.OAuth2DeviceCodeSession, err error)
+
 	/*
 		Implementation for OAuth2.0 PAR Contexts.
 	*/
@@ -296,6 +337,7 @@ type Provider interface {
 	SchemaEncryptionCheckKey(ctx context.Context, verbose bool) (result EncryptionValidationResult, err error)
 
 	RegulatorProvider
+	UserDatabaseProvider
 }
 
 // RegulatorProvider is an interface providing storage capabilities for persisting any kind of data related to the regulator.
@@ -306,3 +348,29 @@ type RegulatorProvider interface {
 	// LoadAuthenticationLogs loads authentication attempts from the storage provider (paginated).
 	LoadAuthenticationLogs(ctx context.Context, username string, fromDate time.Time, limit, page int) (attempts []model.AuthenticationAttempt, err error)
 }
+
+// UserDatabaseProvider is an interface providing storage capabilities for persisting users for the SQL authentication
+// backend.
+type UserDatabaseProvider interface {
+	// SaveUser saves a new user along with their groups to the storage provider.
+	SaveUser(ctx context.Context, user model.User) (err error)
+
+	// UpdateUserPassword updates the password digest of a user in the storage provider.
+	UpdateUserPassword(ctx context.Context, username, password string) (err error)
+
+	// UpdateUserDisabled updates the disabled status of a user in the storage provider.
+	UpdateUserDisabled(ctx context.Context, username string, disabled bool) (err error)
+
+	// DeleteUser deletes a user along with their groups from the storage provider.
+	DeleteUser(ctx context.Context, username string) (err error)
+
+	// LoadUser loads a user along with their groups from the storage provider given a username.
+	LoadUser(ctx context.Context, username string) (user *model.User, err error)
+
+	// LoadUserByEmail loads a user along with their groups from the storage provider given an email. If more than one
+	// user has the email ErrNoUser is returned.
+	LoadUserByEmail(ctx context.Context, email string) (user *model.User, err error)
+
+	// LoadUsers loads a page of users along with their groups from the storage provider.
+	LoadUsers(ctx context.Context, limit, page int) (users []model.User, err error)
+}
@@ -85,6 +85,17 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 		sqlSelectPreferred2FAMethod: fmt.Sprintf(queryFmtSelectPreferred2FAMethod, tableUserPreferences),
 		sqlSelectUserInfo:           fmt.Sprintf(queryFmtSelectUserInfo, tableTOTPConfigurations, tableWebAuthnCredentials, tableDuoDevices, tableUserPreferences),
 
+		sqlInsertUser:             fmt.Sprintf(queryFmtInsertUser, tableUsers),
+		sqlSelectUser:             fmt.Sprintf(queryFmtSelectUser, tableUsers),
+		sqlSelectUserByEmail:      fmt.Sprintf(queryFmtSelectUserByEmail, tableUsers),
+		sqlSelectUsers:            fmt.Sprintf(queryFmtSelectUsers, tableUsers),
+		sqlUpdateUserPassword:     fmt.Sprintf(queryFmtUpdateUserPassword, tableUsers),
+		sqlUpdateUserDisabled:     fmt.Sprintf(queryFmtUpdateUserDisabled, tableUsers),
+		sqlDeleteUser:             fmt.Sprintf(queryFmtDeleteUser, tableUsers),
+		sqlInsertUserGroup:        fmt.Sprintf(queryFmtInsertUserGroup, tableUserGroups),
+		sqlSelectUserGroups:       fmt.Sprintf(queryFmtSelectUserGroups, tableUserGroups),
+		sqlDeleteUserGroupsByUser: fmt.Sprintf(queryFmtDeleteUserGroups, tableUserGroups),
+
 		sqlInsertUserOpaqueIdentifier:            fmt.Sprintf(queryFmtInsertUserOpaqueIdentifier, tableUserOpaqueIdentifier),
 		sqlSelectUserOpaqueIdentifier:            fmt.Sprintf(queryFmtSelectUserOpaqueIdentifier, tableUserOpaqueIdentifier),
 		sqlSelectUserOpaqueIdentifiers:           fmt.Sprintf(queryFmtSelectUserOpaqueIdentifiers, tableUserOpaqueIdentifier),
@@ -93,11 +104,23 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 		sqlUpsertOAuth2BlacklistedJTI: fmt.Sprintf(queryFmtUpsertOAuth2BlacklistedJTI, tableOAuth2BlacklistedJTI),
 		sqlSelectOAuth2BlacklistedJTI: fmt.Sprintf(queryFmtSelectOAuth2BlacklistedJTI, tableOAuth2BlacklistedJTI),
 
+		sqlInsertOAuth2DeviceCodeSession:           fmt.Sprintf(queryFmtInsertOAuth2DeviceCodeSession, tableOAuth2DeviceCodeSession),
+		sqlUpdateOAuth2DeviceCodeSession:           fmt.Sprintf(queryFmtUpdateOAuth2DeviceCodeSession, tableOAuth2DeviceCodeSession),
+		sqlSelectOAuth2DeviceCodeSession:           fmt.Sprintf(queryFmtSelectOAuth2DeviceCodeSession, tableOAuth2DeviceCodeSession),
+		sqlSelectOAuth2DeviceCodeSessionByUserCode: fmt.Sprintf(queryFmtSelectOAuth2DeviceCodeSessionByUserCodeSignature, tableOAuth2DeviceCodeSession),
+		sqlRevokeOAuth2DeviceCodeSession:           fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2DeviceCodeSession),
+
 		sqlInsertOAuth2PARContext: fmt.Sprintf(queryFmtInsertOAuth2PARContext, tableOAuth2PARContext),
 		sqlUpdateOAuth2PARContext: fmt.Sprintf(queryFmtUpdateOAuth2PARContext, tableOAuth2PARContext),
 		sqlSelectOAuth2PARContext: fmt.Sprintf(queryFmtSelectOAuth2PARContext, tableOAuth2PARContext),
 		sqlRevokeOAuth2PARContext: fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2PARContext),
 
+		sqlInsertOAuth2Client:  fmt.Sprintf(queryFmtInsertOAuth2Client, tableOAuth2Client),
+		sqlUpdateOAuth2Client:  fmt.Sprintf(queryFmtUpdateOAuth2Client, tableOAuth2Client),
+		sqlDeleteOAuth2Client:  fmt.Sprintf(queryFmtDeleteOAuth2Client, tableOAuth2Client),
+		sqlSelectOAuth2Client:  fmt.Sprintf(queryFmtSelectOAuth2Client, tableOAuth2Client),
+		sqlSelectOAuth2Clients: fmt.Sprintf(queryFmtSelectOAuth2Clients, tableOAuth2Client),
+
 		sqlInsertOAuth2ConsentPreConfiguration:  fmt.Sprintf(queryFmtInsertOAuth2ConsentPreConfiguration, tableOAuth2ConsentPreConfiguration),
 		sqlSelectOAuth2ConsentPreConfigurations: fmt.Sprintf(queryFmtSelectOAuth2ConsentPreConfigurations, tableOAuth2ConsentPreConfiguration),
 
@@ -229,6 +252,20 @@ type SQLProvider struct {
 	sqlSelectPreferred2FAMethod string
 	sqlSelectUserInfo           string
 
+	// Table: users.
+	sqlInsertUser         string
+	sqlSelectUser         string
+	sqlSelectUserByEmail  string
+	sqlSelectUsers        string
+	sqlUpdateUserPassword string
+	sqlUpdateUserDisabled string
+	sqlDeleteUser         string
+
+	// Table: user_groups.
+	sqlInsertUserGroup        string
+	sqlSelectUserGroups       string
+	sqlDeleteUserGroupsByUser string
+
 	// Table: user_opaque_identifier.
 	sqlInsertUserOpaqueIdentifier            string
 	sqlSelectUserOpaqueIdentifier            string
@@ -244,6 +281,13 @@ type SQLProvider struct {
 	sqlUpsertEncryptionValue string
 	sqlSelectEncryptionValue string
 
+	// Table: oauth2_client.
+	sqlInsertOAuth2Client  string
+	sqlUpdateOAuth2Client  string
+	sqlDeleteOAuth2Client  string
+	sqlSelectOAuth2Client  string
+	sqlSelectOAuth2Clients string
+
 	// Table: oauth2_consent_preconfiguration.
 	sqlInsertOAuth2ConsentPreConfiguration  string
 	sqlSelectOAuth2ConsentPreConfigurations string
@@ -279,6 +323,13 @@ type SQLProvider struct {
 	sqlDeactivateOAuth2OpenIDConnectSession            string
 	sqlDeactivateOAuth2OpenIDConnectSessionByRequestID string
 
+	// Table: oauth2_device_code_session.
+	sqlInsertOAuth2DeviceCodeSession           string
+	sqlUpdateOAuth2DeviceCodeSession           string
+	sqlSelectOAuth2DeviceCodeSession           string
+	sqlSelectOAuth2DeviceCodeSessionByUserCode string
+	sqlRevokeOAuth2DeviceCodeSession           string
+
 	// Table: oauth2_par_context.
 	sqlInsertOAuth2PARContext string
 	sqlUpdateOAuth2PARContext string
@@ -506,6 +557,167 @@ func (p *SQLProvider) LoadUserOpaqueIdentifierBySignature(ctx context.Context, s
 	return subject, nil
 }
 
+// SaveUser saves a new user along with their groups to the storage provider.
+func (p *SQLProvider) SaveUser(ctx context.Context, user model.User) (err error) {
+	var tx *sqlx.Tx
+
+	if tx, err = p.db.BeginTxx(ctx, nil); err != nil {
+		return fmt.Errorf("error beginning transaction to insert user '%s': %w", user.Username, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, p.sqlInsertUser, user.CreatedAt, user.UpdatedAt, user.Username, user.DisplayName, user.Email, user.Password, user.Disabled); err != nil {
+		return p.rollbackUserTx(tx, fmt.Errorf("error inserting user '%s': %w", user.Username, err))
+	}
+
+	for _, group := range user.Groups {
+		if _, err = tx.ExecContext(ctx, p.sqlInsertUserGroup, user.Username, group); err != nil {
+			return p.rollbackUserTx(tx, fmt.Errorf("error inserting group '%s' for user '%s': %w", group, user.Username, err))
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction to insert user '%s': %w", user.Username, err)
+	}
+
+	return nil
+}
+
+// UpdateUserPassword updates the password digest of a user in the storage provider.
+func (p *SQLProvider) UpdateUserPassword(ctx context.Context, username, password string) (err error) {
+	var result sql.Result
+
+	if result, err = p.db.ExecContext(ctx, p.sqlUpdateUserPassword, password, username); err != nil {
+		return fmt.Errorf("error updating password for user '%s': %w", username, err)
+	}
+
+	return userRowsAffected(result, username)
+}
+
+// UpdateUserDisabled updates the disabled status of a user in the storage provider.
+func (p *SQLProvider) UpdateUserDisabled(ctx context.Context, username string, disabled bool) (err error) {
+	var result sql.Result
+
+	if result, err = p.db.ExecContext(ctx, p.sqlUpdateUserDisabled, disabled, username); err != nil {
+		return fmt.Errorf("error updating disabled status for user '%s': %w", username, err)
+	}
+
+	return userRowsAffected(result, username)
+}
+
+// DeleteUser deletes a user along with their groups from the storage provider.
+func (p *SQLProvider) DeleteUser(ctx context.Context, username string) (err error) {
+	var (
+		tx     *sqlx.Tx
+		result sql.Result
+	)
+
+	if tx, err = p.db.BeginTxx(ctx, nil); err != nil {
+		return fmt.Errorf("error beginning transaction to delete user '%s': %w", username, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, p.sqlDeleteUserGroupsByUser, username); err != nil {
+		return p.rollbackUserTx(tx, fmt.Errorf("error deleting groups for user '%s': %w", username, err))
+	}
+
+	if result, err = tx.ExecContext(ctx, p.sqlDeleteUser, username); err != nil {
+		return p.rollbackUserTx(tx, fmt.Errorf("error deleting user '%s': %w", username, err))
+	}
+
+	if err = userRowsAffected(result, username); err != nil {
+		return p.rollbackUserTx(tx, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction to delete user '%s': %w", username, err)
+	}
+
+	return nil
+}
+
+// LoadUser loads a user along with their groups from the storage provider given a username.
+func (p *SQLProvider) LoadUser(ctx context.Context, username string) (user *model.User, err error) {
+	user = &model.User{}
+
+	if err = p.db.GetContext(ctx, user, p.sqlSelectUser, username); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoUser
+		}
+
+		return nil, fmt.Errorf("error selecting user '%s': %w", username, err)
+	}
+
+	if err = p.db.SelectContext(ctx, &user.Groups, p.sqlSelectUserGroups, user.Username); err != nil {
+		return nil, fmt.Errorf("error selecting groups for user '%s': %w", user.Username, err)
+	}
+
+	return user, nil
+}
+
+// LoadUserByEmail loads a user along with their groups from the storage provider given an email. As the email is not
+// unique ErrNoUser is returned when more than one user has the email so an arbitrary user is never selected.
+func (p *SQLProvider) LoadUserByEmail(ctx context.Context, email string) (user *model.User, err error) {
+	var users []model.User
+
+	if err = p.db.SelectContext(ctx, &users, p.sqlSelectUserByEmail, email); err != nil {
+		return nil, fmt.Errorf("error selecting user with email '%s': %w", email, err)
+	}
+
+	if len(users) != 1 {
+		return nil, ErrNoUser
+	}
+
+	user = &users[0]
+
+	if err = p.db.SelectContext(ctx, &user.Groups, p.sqlSelectUserGroups, user.Username); err != nil {
+		return nil, fmt.Errorf("error selecting groups for user '%s': %w", user.Username, err)
+	}
+
+	return user, nil
+}
+
+// LoadUsers loads a page of users along with their groups from the storage provider.
+func (p *SQLProvider) LoadUsers(ctx context.Context, limit, page int) (users []model.User, err error) {
+	users = make([]model.User, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &users, p.sqlSelectUsers, limit, limit*page); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error selecting users: %w", err)
+	}
+
+	for i, u := range users {
+		if err = p.db.SelectContext(ctx, &users[i].Groups, p.sqlSelectUserGroups, u.Username); err != nil {
+			return nil, fmt.Errorf("error selecting groups for user '%s': %w", u.Username, err)
+		}
+	}
+
+	return users, nil
+}
+
+func (p *SQLProvider) rollbackUserTx(tx *sqlx.Tx, err error) error {
+	if rerr := tx.Rollback(); rerr != nil {
+		return fmt.Errorf("%w: error occurred rolling back the transaction: %v", err, rerr)
+	}
+
+	return err
+}
+
+func userRowsAffected(result sql.Result, username string) (err error) {
+	var n int64
+
+	if n, err = result.RowsAffected(); err != nil {
+		return fmt.Errorf("error determining affected rows for user '%s': %w", username, err)
+	}
+
+	if n == 0 {
+		return ErrNoUser
+	}
+
+	return nil
+}
+
 // SaveTOTPConfiguration save a TOTP configuration of a given user in the storage provider.
 func (p *SQLProvider) SaveTOTPConfiguration(ctx context.Context, config model.TOTPConfiguration) (err error) {
 	if config.Secret, err = p.encrypt(config.Secret); err != nil {
@@ -970,6 +1182,85 @@ func (p *SQLProvider) LoadOneTimeCodeByPublicID(ctx context.Context, id uuid.UUI
 	return code, nil
 }
 
+// SaveOAuth2Client saves a dynamically registered OAuth2.0 client to the storage provider.
+func (p *SQLProvider) SaveOAuth2Client(ctx context.Context, client model.OAuth2Client) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertOAuth2Client,
+		client.ClientID, client.ClientName, client.ClientSecret, client.RegistrationAccessTokenSignature,
+		client.CreatedAt, client.UpdatedAt, client.Metadata); err != nil {
+		return fmt.Errorf("error inserting oauth2 client with client id '%s': %w", client.ClientID, err)
+	}
+
+	return nil
+}
+
+// UpdateOAuth2Client updates the metadata of an existing dynamically registered OAuth2.0 client in the storage
+// provider.
+func (p *SQLProvider) UpdateOAuth2Client(ctx context.Context, client model.OAuth2Client) (err error) {
+	var result sql.Result
+
+	if result, err = p.db.ExecContext(ctx, p.sqlUpdateOAuth2Client,
+		client.ClientName, client.UpdatedAt, client.Metadata, client.ClientID); err != nil {
+		return fmt.Errorf("error updating oauth2 client with client id '%s': %w", client.ClientID, err)
+	}
+
+	return oauth2ClientRowsAffected(result, client.ClientID)
+}
+
+// DeleteOAuth2Client deletes a dynamically registered OAuth2.0 client from the storage provider given the client id.
+func (p *SQLProvider) DeleteOAuth2Client(ctx context.Context, clientID string) (err error) {
+	var result sql.Result
+
+	if result, err = p.db.ExecContext(ctx, p.sqlDeleteOAuth2Client, clientID); err != nil {
+		return fmt.Errorf("error deleting oauth2 client with client id '%s': %w", clientID, err)
+	}
+
+	return oauth2ClientRowsAffected(result, clientID)
+}
+
+// LoadOAuth2Client loads a dynamically registered OAuth2.0 client from the storage provider given the client id.
+func (p *SQLProvider) LoadOAuth2Client(ctx context.Context, clientID string) (client *model.OAuth2Client, err error) {
+	client = &model.OAuth2Client{}
+
+	if err = p.db.GetContext(ctx, client, p.sqlSelectOAuth2Client, clientID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoOAuth2Client
+		}
+
+		return nil, fmt.Errorf("error selecting oauth2 client with client id '%s': %w", clientID, err)
+	}
+
+	return client, nil
+}
+
+// LoadOAuth2Clients loads dynamically registered OAuth2.0 clients from the storage provider.
+func (p *SQLProvider) LoadOAuth2Clients(ctx context.Context, limit, page int) (clients []model.OAuth2Client, err error) {
+	clients = make([]model.OAuth2Client, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &clients, p.sqlSelectOAuth2Clients, limit, limit*page); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error selecting oauth2 clients: %w", err)
+	}
+
+	return clients, nil
+}
+
+func oauth2ClientRowsAffected(result sql.Result, clientID string) (err error) {
+	var n int64
+
+	if n, err = result.RowsAffected(); err != nil {
+		return fmt.Errorf("error determining affected rows for oauth2 client with client id '%s': %w", clientID, err)
+	}
+
+	if n == 0 {
+		return ErrNoOAuth2Client
+	}
+
+	return nil
+}
+
 // SaveOAuth2ConsentPreConfiguration inserts an OAuth2.0 consent pre-configuration in the storage provider.
 func (p *SQLProvider) SaveOAuth2ConsentPreConfiguration(ctx context.Context, config model.OAuth2ConsentPreConfig) (insertedID int64, err error) {
 	switch p.name {
@@ -1232,6 +1523,83 @@ func (p *SQLProvider) LoadOAuth2Session(ctx context.Context, sessionType OAuth2S
 	return session, nil
 }
 
+// SaveOAuth2DeviceCodeSession saves an OAuth2.0 device code session to the storage provider.
+func (p *SQLProvider) SaveOAuth2DeviceCodeSession(ctx context.Context, session model.OAuth2DeviceCodeSession) (err error) {
+	if session.Session, err = p.encrypt(session.Session); err != nil {
+		return fmt.Errorf("error encrypting oauth2 device code session data with signature '%s' and request id '%s': %w", session.Signature, session.RequestID, err)
+	}
+
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertOAuth2DeviceCodeSession,
+		session.RequestID, session.ClientID, session.Signature, session.UserCodeSignature, session.Status, session.Subject,
+		session.RequestedAt, session.CheckedAt, session.ExpiresAt, session.PollingInterval, session.RequestedScopes,
+		session.GrantedScopes, session.RequestedAudience, session.GrantedAudience, session.Active, session.Revoked,
+		session.Form, session.Session); err != nil {
+		return fmt.Errorf("error inserting oauth2 device code session with signature '%s' and request id '%s': %w", session.Signature, session.RequestID, err)
+	}
+
+	return nil
+}
+
+// UpdateOAuth2DeviceCodeSession updates an existing OAuth2.0 device code session in the storage provider.
+func (p *SQLProvider) UpdateOAuth2DeviceCodeSession(ctx context.Context, session model.OAuth2DeviceCodeSession) (err error) {
+	if session.ID == 0 {
+		return fmt.Errorf("error updating oauth2 device code session with signature '%s' and request id '%s': the id was a zero value", session.Signature, session.RequestID)
+	}
+
+	if session.Session, err = p.encrypt(session.Session); err != nil {
+		return fmt.Errorf("error encrypting oauth2 device code session data with id '%d' and signature '%s' and request id '%s': %w", session.ID, session.Signature, session.RequestID, err)
+	}
+
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateOAuth2DeviceCodeSession,
+		session.Status, session.Subject, session.CheckedAt, session.PollingInterval, session.GrantedScopes,
+		session.GrantedAudience, session.Active, session.Revoked, session.Session, session.ID); err != nil {
+		return fmt.Errorf("error updating oauth2 device code session with id '%d' and signature '%s' and request id '%s': %w", session.ID, session.Signature, session.RequestID, err)
+	}
+
+	return nil
+}
+
+// RevokeOAuth2DeviceCodeSession marks an OAuth2.0 device code session as revoked in the storage provider.
+func (p *SQLProvider) RevokeOAuth2DeviceCodeSession(ctx context.Context, signature string) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlRevokeOAuth2DeviceCodeSession, signature); err != nil {
+		return fmt.Errorf("error revoking oauth2 device code session with signature '%s': %w", signature, err)
+	}
+
+	return nil
+}
+
+// LoadOAuth2DeviceCodeSession loads an OAuth2.0 device code session from the storage provider given the device code
+// signature.
+func (p *SQLProvider) LoadOAuth2DeviceCodeSession(ctx context.Context, signature string) (session *model.OAuth2DeviceCodeSession, err error) {
+	session = &model.OAuth2DeviceCodeSession{}
+
+	if err = p.db.GetContext(ctx, session, p.sqlSelectOAuth2DeviceCodeSession, signature); err != nil {
+		return nil, fmt.Errorf("error selecting oauth2 device code session with signature '%s': %w", signature, err)
+	}
+
+	if session.Session, err = p.decrypt(session.Session); err != nil {
+		return nil, fmt.Errorf("error decrypting oauth2 device code session data with signature '%s' and request id '%s': %w", signature, session.RequestID, err)
+	}
+
+	return session, nil
+}
+
+// LoadOAuth2DeviceCodeSessionByUserCode loads an OAuth2.0 device code session from the storage provider given the
+// user code signature.
+func (p *SQLProvider) LoadOAuth2DeviceCodeSessionByUserCode(ctx context.Context, userCodeSignature string) (session *model.OAuth2DeviceCodeSession, err error) {
+	session = &model.OAuth2DeviceCodeSession{}
+
+	if err = p.db.GetContext(ctx, session, p.sqlSelectOAuth2DeviceCodeSessionByUserCode, userCodeSignature); err != nil {
+		return nil, fmt.Errorf("error selecting oauth2 device code session with user code signature '%s': %w", userCodeSignature, err)
+	}
+
+	if session.Session, err = p.decrypt(session.Session); err != nil {
+		return nil, fmt.Errorf("error decrypting oauth2 device code session data with user code signature '%s' and request id '%s': %w", userCodeSignature, session.RequestID, err)
+	}
+
+	return session, nil
+}
+
 // SaveOAuth2PARContext save an OAuth2.0 PAR context to the storage provider.
 func (p *SQLProvider) SaveOAuth2PARContext(ctx context.Context, par model.OAuth2PARContext) (err error) {
 	if par.Session, err = p.encrypt(par.Session); err != nil {
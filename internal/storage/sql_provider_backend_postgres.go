@@ -43,6 +43,17 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 	provider.sqlSelectPreferred2FAMethod = provider.db.Rebind(provider.sqlSelectPreferred2FAMethod)
 	provider.sqlSelectUserInfo = provider.db.Rebind(provider.sqlSelectUserInfo)
 
+	provider.sqlInsertUser = provider.db.Rebind(provider.sqlInsertUser)
+	provider.sqlSelectUser = provider.db.Rebind(provider.sqlSelectUser)
+	provider.sqlSelectUserByEmail = provider.db.Rebind(provider.sqlSelectUserByEmail)
+	provider.sqlSelectUsers = provider.db.Rebind(provider.sqlSelectUsers)
+	provider.sqlUpdateUserPassword = provider.db.Rebind(provider.sqlUpdateUserPassword)
+	provider.sqlUpdateUserDisabled = provider.db.Rebind(provider.sqlUpdateUserDisabled)
+	provider.sqlDeleteUser = provider.db.Rebind(provider.sqlDeleteUser)
+	provider.sqlInsertUserGroup = provider.db.Rebind(provider.sqlInsertUserGroup)
+	provider.sqlSelectUserGroups = provider.db.Rebind(provider.sqlSelectUserGroups)
+	provider.sqlDeleteUserGroupsByUser = provider.db.Rebind(provider.sqlDeleteUserGroupsByUser)
+
 	provider.sqlInsertUserOpaqueIdentifier = provider.db.Rebind(provider.sqlInsertUserOpaqueIdentifier)
 	provider.sqlSelectUserOpaqueIdentifier = provider.db.Rebind(provider.sqlSelectUserOpaqueIdentifier)
 	provider.sqlSelectUserOpaqueIdentifierBySignature = provider.db.Rebind(provider.sqlSelectUserOpaqueIdentifierBySignature)
@@ -124,6 +135,18 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 	provider.sqlDeactivateOAuth2OpenIDConnectSessionByRequestID = provider.db.Rebind(provider.sqlDeactivateOAuth2OpenIDConnectSessionByRequestID)
 	provider.sqlSelectOAuth2OpenIDConnectSession = provider.db.Rebind(provider.sqlSelectOAuth2OpenIDConnectSession)
 
+	provider.sqlInsertOAuth2Client = provider.db.Rebind(provider.sqlInsertOAuth2Client)
+	provider.sqlUpdateOAuth2Client = provider.db.Rebind(provider.sqlUpdateOAuth2Client)
+	provider.sqlDeleteOAuth2Client = provider.db.Rebind(provider.sqlDeleteOAuth2Client)
+	provider.sqlSelectOAuth2Client = provider.db.Rebind(provider.sqlSelectOAuth2Client)
+	provider.sqlSelectOAuth2Clients = provider.db.Rebind(provider.sqlSelectOAuth2Clients)
+
+	provider.sqlInsertOAuth2DeviceCodeSession = provider.db.Rebind(provider.sqlInsertOAuth2DeviceCodeSession)
+	provider.sqlUpdateOAuth2DeviceCodeSession = provider.db.Rebind(provider.sqlUpdateOAuth2DeviceCodeSession)
+	provider.sqlSelectOAuth2DeviceCodeSession = provider.db.Rebind(provider.sqlSelectOAuth2DeviceCodeSession)
+	provider.sqlSelectOAuth2DeviceCodeSessionByUserCode = provider.db.Rebind(provider.sqlSelectOAuth2DeviceCodeSessionByUserCode)
+	provider.sqlRevokeOAuth2DeviceCodeSession = provider.db.Rebind(provider.sqlRevokeOAuth2DeviceCodeSession)
+
 	provider.sqlInsertOAuth2PARContext = provider.db.Rebind(provider.sqlInsertOAuth2PARContext)
 	provider.sqlUpdateOAuth2PARContext = provider.db.Rebind(provider.sqlUpdateOAuth2PARContext)
 	provider.sqlRevokeOAuth2PARContext = provider.db.Rebind(provider.sqlRevokeOAuth2PARContext)
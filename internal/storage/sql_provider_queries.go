@@ -312,6 +312,36 @@ const (
 		SET value = ?`
 )
 
+const (
+	queryFmtInsertOAuth2Client = `
+		INSERT INTO %s (client_id, client_name, client_secret, registration_access_token_signature, created_at,
+		updated_at, metadata)
+		VALUES (?, ?, ?, ?, ?, ?, ?);`
+
+	queryFmtUpdateOAuth2Client = `
+		UPDATE %s
+		SET client_name = ?, updated_at = ?, metadata = ?
+		WHERE client_id = ?;`
+
+	queryFmtDeleteOAuth2Client = `
+		DELETE FROM %s
+		WHERE client_id = ?;`
+
+	queryFmtSelectOAuth2Client = `
+		SELECT id, client_id, client_name, client_secret, registration_access_token_signature, created_at,
+		updated_at, metadata
+		FROM %s
+		WHERE client_id = ?;`
+
+	queryFmtSelectOAuth2Clients = `
+		SELECT id, client_id, client_name, client_secret, registration_access_token_signature, created_at,
+		updated_at, metadata
+		FROM %s
+		ORDER BY id ASC
+		LIMIT ?
+		OFFSET ?;`
+)
+
 const (
 	queryFmtSelectOAuth2ConsentPreConfigurations = `
 		SELECT id, client_id, subject, created_at, expires_at, revoked, scopes, audience
@@ -407,6 +437,32 @@ const (
 	    form_data = ?, session_data = ?
 	WHERE id = ?;`
 
+	queryFmtSelectOAuth2DeviceCodeSession = `
+		SELECT id, request_id, client_id, signature, user_code_signature, status, subject, requested_at,
+		checked_at, expires_at, polling_interval, requested_scopes, granted_scopes, requested_audience,
+		granted_audience, active, revoked, form_data, session_data
+		FROM %s
+		WHERE signature = ?;`
+
+	queryFmtSelectOAuth2DeviceCodeSessionByUserCodeSignature = `
+		SELECT id, request_id, client_id, signature, user_code_signature, status, subject, requested_at,
+		checked_at, expires_at, polling_interval, requested_scopes, granted_scopes, requested_audience,
+		granted_audience, active, revoked, form_data, session_data
+		FROM %s
+		WHERE user_code_signature = ?;`
+
+	queryFmtInsertOAuth2DeviceCodeSession = `
+		INSERT INTO %s (request_id, client_id, signature, user_code_signature, status, subject, requested_at,
+		checked_at, expires_at, polling_interval, requested_scopes, granted_scopes, requested_audience,
+		granted_audience, active, revoked, form_data, session_data)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+
+	queryFmtUpdateOAuth2DeviceCodeSession = `
+		UPDATE %s
+		SET status = ?, subject = ?, checked_at = ?, polling_interval = ?, granted_scopes = ?, granted_audience = ?,
+		    active = ?, revoked = ?, session_data = ?
+		WHERE id = ?;`
+
 	queryFmtSelectOAuth2BlacklistedJTI = `
 		SELECT id, signature, expires_at
 		FROM %s
@@ -451,3 +507,57 @@ const (
 		SELECT id, service, sector_id, username, identifier
 		FROM %s;`
 )
+
+const (
+	queryFmtInsertUser = `
+		INSERT INTO %s (created_at, updated_at, username, display_name, email, password, disabled)
+		VALUES (?, ?, ?, ?, ?, ?, ?);`
+
+	queryFmtSelectUser = `
+		SELECT id, created_at, updated_at, username, display_name, email, password, disabled
+		FROM %s
+		WHERE username = ?;`
+
+	queryFmtSelectUserByEmail = `
+		SELECT id, created_at, updated_at, username, display_name, email, password, disabled
+		FROM %s
+		WHERE email = ?
+		LIMIT 2;`
+
+	queryFmtSelectUsers = `
+		SELECT id, created_at, updated_at, username, display_name, email, password, disabled
+		FROM %s
+		ORDER BY username
+		LIMIT ?
+		OFFSET ?;`
+
+	queryFmtUpdateUserPassword = `
+		UPDATE %s
+		SET password = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE username = ?;`
+
+	queryFmtUpdateUserDisabled = `
+		UPDATE %s
+		SET disabled = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE username = ?;`
+
+	queryFmtDeleteUser = `
+		DELETE FROM %s
+		WHERE username = ?;`
+)
+
+const (
+	queryFmtInsertUserGroup = `
+		INSERT INTO %s (username, group_name)
+		VALUES (?, ?);`
+
+	queryFmtSelectUserGroups = `
+		SELECT group_name
+		FROM %s
+		WHERE username = ?
+		ORDER BY group_name;`
+
+	queryFmtDeleteUserGroups = `
+		DELETE FROM %s
+		WHERE username = ?;`
+)
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+func TestSQLProvider_LoadUserByEmail(t *testing.T) {
+	provider := NewSQLiteProvider(&schema.Configuration{
+		Storage: schema.Storage{
+			EncryptionKey: "a_not_so_secure_encryption_key",
+			Local: &schema.StorageLocal{
+				Path: filepath.Join(t.TempDir(), "db.sqlite3"),
+			},
+		},
+	})
+
+	defer provider.Close()
+
+	ctx := context.Background()
+
+	require.NoError(t, provider.SchemaMigrate(ctx, true, SchemaLatest))
+
+	now := time.Now()
+
+	for _, user := range []model.User{
+		{CreatedAt: now, UpdatedAt: now, Username: "john", DisplayName: "John Doe", Email: "john.doe@authelia.com", Groups: []string{"dev"}},
+		{CreatedAt: now, UpdatedAt: now, Username: "harry", DisplayName: "Harry Potter", Email: "shared@authelia.com"},
+		{CreatedAt: now, UpdatedAt: now, Username: "bob", DisplayName: "Bob Dylan", Email: "shared@authelia.com"},
+	} {
+		require.NoError(t, provider.SaveUser(ctx, user))
+	}
+
+	user, err := provider.LoadUserByEmail(ctx, "john.doe@authelia.com")
+
+	require.NoError(t, err)
+
+	assert.Equal(t, "john", user.Username)
+	assert.Equal(t, []string{"dev"}, user.Groups)
+
+	user, err = provider.LoadUserByEmail(ctx, "shared@authelia.com")
+
+	assert.Nil(t, user)
+	assert.Equal(t, ErrNoUser, err)
+
+	user, err = provider.LoadUserByEmail(ctx, "fred@authelia.com")
+
+	assert.Nil(t, user)
+	assert.Equal(t, ErrNoUser, err)
+}
@@ -70,6 +70,9 @@ func TestOAuth2SessionType(t *testing.T) {
 	assert.Equal(t, "authorization code", OAuth2SessionTypeAuthorizeCode.String())
 	assert.Equal(t, tableOAuth2AuthorizeCodeSession, OAuth2SessionTypeAuthorizeCode.Table())
 
+	assert.Equal(t, "device code", OAuth2SessionTypeDeviceCode.String())
+	assert.Equal(t, tableOAuth2DeviceCodeSession, OAuth2SessionTypeDeviceCode.Table())
+
 	assert.Equal(t, "openid connect", OAuth2SessionTypeOpenIDConnect.String())
 	assert.Equal(t, tableOAuth2OpenIDConnectSession, OAuth2SessionTypeOpenIDConnect.Table())
 
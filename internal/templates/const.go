@@ -11,7 +11,8 @@ const (
 	TemplateNameEmailIdentityVerificationOTC = "IdentityVerificationOTC"
 	TemplateNameEmailEvent                   = "Event"
 
-	TemplateNameOIDCAuthorizeFormPost = "AuthorizeResponseFormPost.html"
+	TemplateNameOIDCAuthorizeFormPost  = "AuthorizeResponseFormPost.html"
+	TemplateNameOIDCFrontChannelLogout = "FrontChannelLogout.html"
 )
 
 // Template Category Names.
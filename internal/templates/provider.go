@@ -104,6 +104,12 @@ func (p *Provider) GetOpenIDConnectAuthorizeResponseFormPostTemplate() (t *th.Te
 	return p.templates.oidc.formpost
 }
 
+// GetOpenIDConnectFrontChannelLogoutTemplate returns a Template used to render the OpenID Connect 1.0 Front-Channel Logout
+// iframes.
+func (p *Provider) GetOpenIDConnectFrontChannelLogoutTemplate() (t *th.Template) {
+	return p.templates.oidc.frontchannelLogout
+}
+
 func (p *Provider) load() (err error) {
 	var errs []error
 
@@ -130,6 +136,15 @@ func (p *Provider) load() (err error) {
 		errs = append(errs, err)
 	}
 
+	if data, err = embedFS.ReadFile(path.Join("src", TemplateCategoryOpenIDConnect, TemplateNameOIDCFrontChannelLogout)); err != nil {
+		errs = append(errs, err)
+	} else if p.templates.oidc.frontchannelLogout, err = th.
+		New("oidc/FrontChannelLogout.html").
+		Funcs(FuncMap()).
+		Parse(string(data)); err != nil {
+		errs = append(errs, err)
+	}
+
 	if len(errs) != 0 {
 		for i, e := range errs {
 			if i == 0 {
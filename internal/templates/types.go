@@ -14,7 +14,15 @@ type Templates struct {
 }
 
 type OpenIDConnectTemplates struct {
-	formpost *th.Template
+	formpost           *th.Template
+	frontchannelLogout *th.Template
+}
+
+// OpenIDConnectFrontChannelLogoutValues are the values used to render the OpenID Connect 1.0 Front-Channel Logout
+// template.
+type OpenIDConnectFrontChannelLogoutValues struct {
+	RedirectURI            string
+	FrontChannelLogoutURIs []string
 }
 
 // AssetTemplates are templates for specific key assets.
@@ -9,7 +9,9 @@ import { Route, BrowserRouter as Router, Routes } from "react-router-dom";
 import NotificationBar from "@components/NotificationBar";
 import {
     ConsentRoute,
+    DeviceCodeRoute,
     IndexRoute,
+    LogoutConfirmationRoute,
     LogoutRoute,
     ResetPasswordStep1Route,
     ResetPasswordStep2Route,
@@ -22,14 +24,23 @@ import ThemeContextProvider from "@contexts/ThemeContext";
 import NotificationsContext from "@hooks/NotificationsContext";
 import { Notification } from "@models/Notifications";
 import { getBasePath } from "@utils/BasePath";
-import { getDuoSelfEnrollment, getRememberMe, getResetPassword, getResetPasswordCustomURL } from "@utils/Configuration";
+import {
+    getClientCertificate,
+    getDuoSelfEnrollment,
+    getRememberMe,
+    getResetPassword,
+    getResetPasswordCustomURL,
+    getSPNEGO,
+} from "@utils/Configuration";
 import LoadingPage from "@views/LoadingPage/LoadingPage";
 import LoginPortal from "@views/LoginPortal/LoginPortal";
 
 import "@fortawesome/fontawesome-svg-core/styles.css";
 
 const ConsentView = lazy(() => import("@views/LoginPortal/ConsentView/ConsentView"));
+const DeviceCodeView = lazy(() => import("@views/LoginPortal/DeviceCode/DeviceCodeView"));
 const SignOut = lazy(() => import("@views/LoginPortal/SignOut/SignOut"));
+const SignOutConfirmation = lazy(() => import("@views/LoginPortal/SignOut/SignOutConfirmation"));
 const ResetPasswordStep1 = lazy(() => import("@views/ResetPassword/ResetPasswordStep1"));
 const ResetPasswordStep2 = lazy(() => import("@views/ResetPassword/ResetPasswordStep2"));
 const SettingsRouter = lazy(() => import("@views/Settings/SettingsRouter"));
@@ -64,7 +75,9 @@ const App: React.FC<Props> = (props: Props) => {
                                     <Route path={ResetPasswordStep1Route} element={<ResetPasswordStep1 />} />
                                     <Route path={ResetPasswordStep2Route} element={<ResetPasswordStep2 />} />
                                     <Route path={LogoutRoute} element={<SignOut />} />
+                                    <Route path={LogoutConfirmationRoute} element={<SignOutConfirmation />} />
                                     <Route path={ConsentRoute} element={<ConsentView />} />
+                                    <Route path={DeviceCodeRoute} element={<DeviceCodeView />} />
                                     <Route path={RevokeOneTimeCodeRoute} element={<RevokeOneTimeCodeView />} />
                                     <Route path={RevokeResetPasswordRoute} element={<RevokeResetPasswordTokenView />} />
                                     <Route path={`${SettingsRoute}/*`} element={<SettingsRouter />} />
@@ -76,6 +89,8 @@ const App: React.FC<Props> = (props: Props) => {
                                                 rememberMe={getRememberMe()}
                                                 resetPassword={getResetPassword()}
                                                 resetPasswordCustomURL={getResetPasswordCustomURL()}
+                                                spnego={getSPNEGO()}
+                                                clientCertificate={getClientCertificate()}
                                             />
                                         }
                                     />
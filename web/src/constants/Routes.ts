@@ -1,6 +1,7 @@
 export const IndexRoute: string = "/";
 export const AuthenticatedRoute: string = "/authenticated";
 export const ConsentRoute: string = "/consent";
+export const DeviceCodeRoute: string = "/device";
 
 export const SecondFactorRoute: string = "/2fa";
 export const SecondFactorWebAuthnSubRoute: string = "/webauthn";
@@ -10,6 +11,7 @@ export const SecondFactorPushSubRoute: string = "/push-notification";
 export const ResetPasswordStep1Route: string = "/reset-password/step1";
 export const ResetPasswordStep2Route: string = "/reset-password/step2";
 export const LogoutRoute: string = "/logout";
+export const LogoutConfirmationRoute: string = "/logout/confirm";
 
 export const SettingsRoute: string = "/settings";
 export const SettingsTwoFactorAuthenticationSubRoute: string = "/two-factor-authentication";
@@ -5,3 +5,13 @@ export const IdentityToken: string = "token";
 export const RedirectionURL: string = "rd";
 
 export const RequestMethod: string = "rm";
+
+export const ClientID: string = "client_id";
+
+export const PostLogoutRedirectURI: string = "post_logout_redirect_uri";
+
+export const State: string = "state";
+
+export const UserCode: string = "user_code";
+
+export const Status: string = "status";
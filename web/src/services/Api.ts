@@ -6,8 +6,12 @@ const basePath = getBasePath();
 
 // Note: If you change this const you must also do so in the backend at internal/handlers/cost.go.
 export const ConsentPath = basePath + "/api/oidc/consent";
+export const OpenIDConnectLogoutPath = basePath + "/api/oidc/logout";
+export const OpenIDConnectDeviceCodeUserVerificationPath = basePath + "/api/oidc/device-code/user-verification";
 
 export const FirstFactorPath = basePath + "/api/firstfactor";
+export const FirstFactorSPNEGOPath = basePath + "/api/firstfactor/spnego";
+export const FirstFactorClientCertificatePath = basePath + "/api/firstfactor/certificate";
 
 export const TOTPRegistrationPath = basePath + "/api/secondfactor/totp/register";
 export const TOTPConfigurationPath = basePath + "/api/secondfactor/totp";
@@ -18,6 +18,7 @@ export interface ConsentGetResponseBody {
     scopes: string[];
     audience: string[];
     pre_configuration: boolean;
+    user_code?: string;
 }
 
 export function getConsentResponse(consentID: string) {
@@ -1,4 +1,4 @@
-import { FirstFactorPath } from "@services/Api";
+import { FirstFactorClientCertificatePath, FirstFactorPath, FirstFactorSPNEGOPath } from "@services/Api";
 import { PostWithOptionalResponse } from "@services/Client";
 import { SignInResponse } from "@services/SignIn";
 
@@ -11,6 +11,12 @@ interface PostFirstFactorBody {
     workflow?: string;
 }
 
+interface PostFirstFactorExternalBody {
+    targetURL?: string;
+    requestMethod?: string;
+    workflow?: string;
+}
+
 export async function postFirstFactor(
     username: string,
     password: string,
@@ -40,3 +46,30 @@ export async function postFirstFactor(
     const res = await PostWithOptionalResponse<SignInResponse>(FirstFactorPath, data);
     return res ? res : ({} as SignInResponse);
 }
+
+export async function postFirstFactorSPNEGO(targetURL?: string, requestMethod?: string, workflow?: string) {
+    return postFirstFactorExternal(FirstFactorSPNEGOPath, targetURL, requestMethod, workflow);
+}
+
+export async function postFirstFactorClientCertificate(targetURL?: string, requestMethod?: string, workflow?: string) {
+    return postFirstFactorExternal(FirstFactorClientCertificatePath, targetURL, requestMethod, workflow);
+}
+
+async function postFirstFactorExternal(path: string, targetURL?: string, requestMethod?: string, workflow?: string) {
+    const data: PostFirstFactorExternalBody = {};
+
+    if (targetURL) {
+        data.targetURL = targetURL;
+    }
+
+    if (requestMethod) {
+        data.requestMethod = requestMethod;
+    }
+
+    if (workflow) {
+        data.workflow = workflow;
+    }
+
+    const res = await PostWithOptionalResponse<SignInResponse>(path, data);
+    return res ? res : ({} as SignInResponse);
+}
@@ -0,0 +1,37 @@
+import { OpenIDConnectLogoutPath } from "@services/Api";
+import { Get, Post } from "@services/Client";
+
+interface LogoutPostRequestBody {
+    client_id: string;
+    post_logout_redirect_uri: string;
+    state: string;
+}
+
+interface LogoutPostResponseBody {
+    redirect_uri: string;
+}
+
+export interface LogoutGetResponseBody {
+    client_id?: string;
+    client_description?: string;
+}
+
+export function getOpenIDConnectLogout(clientID: string | null) {
+    const query = clientID ? "?client_id=" + encodeURIComponent(clientID) : "";
+
+    return Get<LogoutGetResponseBody>(OpenIDConnectLogoutPath + query);
+}
+
+export function confirmOpenIDConnectLogout(
+    clientID: string | null,
+    postLogoutRedirectURI: string | null,
+    state: string | null,
+) {
+    const body: LogoutPostRequestBody = {
+        client_id: clientID ?? "",
+        post_logout_redirect_uri: postLogoutRedirectURI ?? "",
+        state: state ?? "",
+    };
+
+    return Post<LogoutPostResponseBody>(OpenIDConnectLogoutPath, body);
+}
@@ -1,8 +1,11 @@
 import { ChecksSafeRedirectionPath } from "@services/Api";
 import { PostWithOptionalResponse } from "@services/Client";
+import { AuthenticationLevel } from "@services/State";
 
 interface SafeRedirectionResponse {
     ok: boolean;
+    step_up_methods?: string[];
+    reauthentication_level?: AuthenticationLevel;
 }
 
 export async function checkSafeRedirection(uri: string) {
@@ -37,6 +37,8 @@ document.body.setAttribute("data-duoselfenrollment", "true");
 document.body.setAttribute("data-rememberme", "true");
 document.body.setAttribute("data-resetpassword", "true");
 document.body.setAttribute("data-resetpasswordcustomurl", "");
+document.body.setAttribute("data-spnego", "false");
+document.body.setAttribute("data-clientcertificate", "false");
 document.body.setAttribute("data-privacypolicyurl", "");
 document.body.setAttribute("data-privacypolicyaccept", "false");
 document.body.setAttribute("data-theme", "light");
@@ -27,6 +27,14 @@ export function getResetPasswordCustomURL() {
     return getEmbeddedVariable("resetpasswordcustomurl");
 }
 
+export function getSPNEGO() {
+    return getEmbeddedVariable("spnego") === "true";
+}
+
+export function getClientCertificate() {
+    return getEmbeddedVariable("clientcertificate") === "true";
+}
+
 export function getPrivacyPolicyEnabled() {
     return getEmbeddedVariable("privacypolicyurl") !== "";
 }
@@ -1,6 +1,6 @@
 import React, { Fragment, ReactNode, useEffect, useState } from "react";
 
-import { AccountBox, Autorenew, CheckBox, Contacts, Drafts, Group, LockOpen } from "@mui/icons-material";
+import { AccountBox, Autorenew, CheckBox, Contacts, Drafts, Group, Home, LockOpen, Phone } from "@mui/icons-material";
 import {
     Button,
     Checkbox,
@@ -41,6 +41,10 @@ function scopeNameToAvatar(id: string) {
             return <Group />;
         case "email":
             return <Drafts />;
+        case "phone":
+            return <Phone />;
+        case "address":
+            return <Home />;
         case "authelia.bearer.authz":
             return <LockOpen />;
         default:
@@ -110,6 +114,10 @@ const ConsentView = function (props: Props) {
                 return translate("Access your group membership");
             case "email":
                 return translate("Access your email addresses");
+            case "phone":
+                return translate("Access your phone number");
+            case "address":
+                return translate("Access your address");
             case "authelia.bearer.authz":
                 return translate("Access protected resources logged in as you");
             default:
@@ -166,6 +174,14 @@ const ConsentView = function (props: Props) {
                             </Tooltip>
                         </div>
                     </Grid>
+                    {response?.user_code ? (
+                        <Grid item xs={12}>
+                            <div>{translate("Confirm the code matches the one displayed on your device")}:</div>
+                            <Typography id="user-code" className={styles.userCode}>
+                                {response.user_code}
+                            </Typography>
+                        </Grid>
+                    ) : null}
                     <Grid item xs={12}>
                         <div>{translate("The above application is requesting the following permissions")}:</div>
                     </Grid>
@@ -248,6 +264,12 @@ const useStyles = makeStyles((theme: Theme) => ({
     clientDescription: {
         fontWeight: 600,
     },
+    userCode: {
+        fontFamily: "monospace",
+        fontSize: "1.5rem",
+        fontWeight: 600,
+        letterSpacing: "0.1em",
+    },
     scopesListContainer: {
         textAlign: "center",
     },
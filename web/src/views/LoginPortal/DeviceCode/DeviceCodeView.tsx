@@ -0,0 +1,120 @@
+import React, { useState } from "react";
+
+import { Button, FormControl, Grid, Theme, Typography } from "@mui/material";
+import TextField from "@mui/material/TextField";
+import makeStyles from "@mui/styles/makeStyles";
+import { useTranslation } from "react-i18next";
+import { useSearchParams } from "react-router-dom";
+
+import { Status, UserCode } from "@constants/SearchParams";
+import { useRedirector } from "@hooks/Redirector";
+import MinimalLayout from "@layouts/MinimalLayout";
+import { OpenIDConnectDeviceCodeUserVerificationPath } from "@services/Api";
+
+export interface Props {}
+
+const DeviceCodeView = function (props: Props) {
+    const { t: translate } = useTranslation();
+
+    const styles = useStyles();
+    const redirect = useRedirector();
+    const [searchParams] = useSearchParams();
+
+    const status = searchParams.get(Status);
+
+    const [userCode, setUserCode] = useState(searchParams.get(UserCode) ?? "");
+    const [error, setError] = useState(false);
+
+    const handleSubmit = () => {
+        if (userCode.trim() === "") {
+            setError(true);
+            return;
+        }
+
+        redirect(
+            `${OpenIDConnectDeviceCodeUserVerificationPath}?${new URLSearchParams({ [UserCode]: userCode.trim() })}`,
+        );
+    };
+
+    if (status === "authorized" || status === "denied") {
+        return (
+            <MinimalLayout id="device-code-stage" title={translate("Device Authorization")}>
+                <Grid container>
+                    <Grid item xs={12}>
+                        <Typography id="device-code-status" className={styles.typo}>
+                            {status === "authorized"
+                                ? translate("The device has been authorized, you may now return to it")
+                                : translate("The device authorization request was denied")}
+                        </Typography>
+                    </Grid>
+                </Grid>
+            </MinimalLayout>
+        );
+    }
+
+    return (
+        <MinimalLayout id="device-code-stage" title={translate("Device Authorization")}>
+            <FormControl id="form-device-code">
+                <Grid container className={styles.root} spacing={2}>
+                    <Grid item xs={12}>
+                        <Typography className={styles.typo}>
+                            {translate("Enter the code displayed on your device")}
+                        </Typography>
+                    </Grid>
+                    {status === "invalid" ? (
+                        <Grid item xs={12}>
+                            <Typography id="device-code-invalid" color="error" className={styles.typo}>
+                                {translate("The code is invalid or has expired")}
+                            </Typography>
+                        </Grid>
+                    ) : null}
+                    <Grid item xs={12}>
+                        <TextField
+                            id="user-code-textfield"
+                            label={translate("Code")}
+                            variant="outlined"
+                            fullWidth
+                            autoFocus
+                            error={error}
+                            value={userCode}
+                            inputProps={{ autoCapitalize: "characters", autoComplete: "off" }}
+                            onChange={(e) => {
+                                setUserCode(e.target.value.toUpperCase());
+                                setError(false);
+                            }}
+                            onKeyDown={(ev) => {
+                                if (ev.key === "Enter") {
+                                    handleSubmit();
+                                    ev.preventDefault();
+                                }
+                            }}
+                        />
+                    </Grid>
+                    <Grid item xs={12}>
+                        <Button
+                            id="device-code-submit-button"
+                            variant="contained"
+                            color="primary"
+                            fullWidth
+                            onClick={handleSubmit}
+                        >
+                            {translate("Continue")}
+                        </Button>
+                    </Grid>
+                </Grid>
+            </FormControl>
+        </MinimalLayout>
+    );
+};
+
+export default DeviceCodeView;
+
+const useStyles = makeStyles((theme: Theme) => ({
+    root: {
+        marginTop: theme.spacing(2),
+        marginBottom: theme.spacing(2),
+    },
+    typo: {
+        padding: theme.spacing(),
+    },
+}));
@@ -15,7 +15,7 @@ import { useQueryParam } from "@hooks/QueryParam";
 import { useWorkflow } from "@hooks/Workflow";
 import LoginLayout from "@layouts/LoginLayout";
 import { IsCapsLockModified } from "@services/CapsLock";
-import { postFirstFactor } from "@services/FirstFactor";
+import { postFirstFactor, postFirstFactorClientCertificate, postFirstFactorSPNEGO } from "@services/FirstFactor";
 
 export interface Props {
     disabled: boolean;
@@ -24,6 +24,9 @@ export interface Props {
     resetPassword: boolean;
     resetPasswordCustomURL: string;
 
+    spnego: boolean;
+    clientCertificate: boolean;
+
     onAuthenticationStart: () => void;
     onAuthenticationFailure: () => void;
     onAuthenticationSuccess: (redirectURL: string | undefined) => void;
@@ -51,6 +54,7 @@ const FirstFactorForm = function (props: Props) {
 
     const usernameRef = useRef() as MutableRefObject<HTMLInputElement>;
     const passwordRef = useRef() as MutableRefObject<HTMLInputElement>;
+    const externalAttempted = useRef(false);
 
     const styles = useStyles();
 
@@ -67,6 +71,46 @@ const FirstFactorForm = function (props: Props) {
         });
     }, [loginChannel, redirectionURL, props]);
 
+    useEffect(() => {
+        if ((!props.spnego && !props.clientCertificate) || externalAttempted.current) {
+            return;
+        }
+
+        externalAttempted.current = true;
+
+        props.onAuthenticationStart();
+
+        const attempt = async () => {
+            if (props.spnego) {
+                try {
+                    return await postFirstFactorSPNEGO(redirectionURL, requestMethod, workflow);
+                } catch (err) {
+                    console.debug("Kerberos single sign-on is not available", err);
+                }
+            }
+
+            if (props.clientCertificate) {
+                try {
+                    return await postFirstFactorClientCertificate(redirectionURL, requestMethod, workflow);
+                } catch (err) {
+                    console.debug("Client certificate sign-on is not available", err);
+                }
+            }
+
+            return null;
+        };
+
+        attempt().then(async (res) => {
+            if (res === null) {
+                props.onAuthenticationFailure();
+                return;
+            }
+
+            await loginChannel.postMessage(true);
+            props.onAuthenticationSuccess(res.redirect);
+        });
+    }, [loginChannel, props, redirectionURL, requestMethod, workflow]);
+
     const disabled = props.disabled;
 
     const handleRememberMeChange = () => {
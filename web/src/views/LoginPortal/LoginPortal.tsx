@@ -35,6 +35,9 @@ export interface Props {
 
     resetPassword: boolean;
     resetPasswordCustomURL: string;
+
+    spnego: boolean;
+    clientCertificate: boolean;
 }
 
 const RedirectionErrorMessage =
@@ -43,9 +46,10 @@ const RedirectionErrorMessage =
 const LoginPortal = function (props: Props) {
     const location = useLocation();
     const redirectionURL = useQueryParam(RedirectionURL);
-    const { createErrorNotification } = useNotifications();
+    const { createErrorNotification, createInfoNotification } = useNotifications();
     const [firstFactorDisabled, setFirstFactorDisabled] = useState(true);
     const [broadcastRedirect, setBroadcastRedirect] = useState(false);
+    const [reauthenticationLevel, setReauthenticationLevel] = useState<AuthenticationLevel>();
     const redirector = useRedirector();
     const { localStorageMethod } = useLocalStorageMethodContext();
     const { t: translate } = useTranslation();
@@ -100,7 +104,7 @@ const LoginPortal = function (props: Props) {
     // Redirect to the correct stage if not enough authenticated
     useEffect(() => {
         (async function () {
-            if (!state) {
+            if (!state || reauthenticationLevel !== undefined) {
                 return;
             }
 
@@ -114,7 +118,26 @@ const LoginPortal = function (props: Props) {
             ) {
                 try {
                     const res = await checkSafeRedirection(redirectionURL);
-                    if (res && res.ok) {
+                    if (res && res.ok && res.reauthentication_level === AuthenticationLevel.OneFactor) {
+                        setReauthenticationLevel(AuthenticationLevel.OneFactor);
+                        createInfoNotification(translate("The resource requires you to authenticate again"));
+                        setFirstFactorDisabled(false);
+                        navigate(IndexRoute);
+                    } else if (res && res.ok && res.reauthentication_level === AuthenticationLevel.TwoFactor) {
+                        setReauthenticationLevel(AuthenticationLevel.TwoFactor);
+                        createInfoNotification(translate("The resource requires you to authenticate again"));
+                        navigate(getSecondFactorRoute(localStorageMethod || userInfo?.method));
+                    } else if (res && res.ok && res.step_up_methods && res.step_up_methods.length !== 0) {
+                        setReauthenticationLevel(AuthenticationLevel.TwoFactor);
+                        createInfoNotification(
+                            translate("The resource requires you to authenticate with a stronger second factor method"),
+                        );
+                        navigate(
+                            getSecondFactorRoute(
+                                getStepUpMethod(res.step_up_methods, localStorageMethod || userInfo?.method),
+                            ),
+                        );
+                    } else if (res && res.ok) {
                         redirector(redirectionURL);
                     } else {
                         createErrorNotification(translate(RedirectionErrorMessage));
@@ -130,18 +153,19 @@ const LoginPortal = function (props: Props) {
                 setFirstFactorDisabled(false);
                 navigate(IndexRoute);
             } else if (state.authentication_level >= AuthenticationLevel.OneFactor && userInfo && configuration) {
-                if (configuration.available_methods.size === 0) {
+                if (
+                    redirectionURL &&
+                    state.authentication_level === AuthenticationLevel.OneFactor &&
+                    (await isFirstFactorStale(redirectionURL))
+                ) {
+                    setReauthenticationLevel(AuthenticationLevel.OneFactor);
+                    createInfoNotification(translate("The resource requires you to authenticate again"));
+                    setFirstFactorDisabled(false);
+                    navigate(IndexRoute);
+                } else if (configuration.available_methods.size === 0) {
                     navigate(AuthenticatedRoute, false);
                 } else {
-                    const method = localStorageMethod || userInfo.method;
-
-                    if (method === SecondFactorMethod.WebAuthn) {
-                        navigate(`${SecondFactorRoute}${SecondFactorWebAuthnSubRoute}`);
-                    } else if (method === SecondFactorMethod.MobilePush) {
-                        navigate(`${SecondFactorRoute}${SecondFactorPushSubRoute}`);
-                    } else {
-                        navigate(`${SecondFactorRoute}${SecondFactorTOTPSubRoute}`);
-                    }
+                    navigate(getSecondFactorRoute(localStorageMethod || userInfo.method));
                 }
             }
         })();
@@ -153,9 +177,11 @@ const LoginPortal = function (props: Props) {
         setFirstFactorDisabled,
         configuration,
         createErrorNotification,
+        createInfoNotification,
         redirector,
         broadcastRedirect,
         localStorageMethod,
+        reauthenticationLevel,
         translate,
     ]);
 
@@ -170,13 +196,16 @@ const LoginPortal = function (props: Props) {
             redirector(redirectionURL);
         } else {
             // Refresh state
+            setReauthenticationLevel(undefined);
             fetchState();
         }
     };
 
+    const authenticationLevel = getDisplayedAuthenticationLevel(reauthenticationLevel, state?.authentication_level);
+
     const firstFactorReady =
         state !== undefined &&
-        state.authentication_level === AuthenticationLevel.Unauthenticated &&
+        authenticationLevel === AuthenticationLevel.Unauthenticated &&
         location.pathname === IndexRoute;
 
     return (
@@ -190,6 +219,8 @@ const LoginPortal = function (props: Props) {
                             rememberMe={props.rememberMe}
                             resetPassword={props.resetPassword}
                             resetPasswordCustomURL={props.resetPasswordCustomURL}
+                            spnego={props.spnego}
+                            clientCertificate={props.clientCertificate}
                             onAuthenticationStart={() => setFirstFactorDisabled(true)}
                             onAuthenticationFailure={() => setFirstFactorDisabled(false)}
                             onAuthenticationSuccess={handleAuthSuccess}
@@ -203,7 +234,7 @@ const LoginPortal = function (props: Props) {
                 element={
                     state && userInfo && configuration ? (
                         <SecondFactorForm
-                            authenticationLevel={state.authentication_level}
+                            authenticationLevel={authenticationLevel ?? state.authentication_level}
                             userInfo={userInfo}
                             configuration={configuration}
                             duoSelfEnrollment={props.duoSelfEnrollment}
@@ -218,6 +249,65 @@ const LoginPortal = function (props: Props) {
     );
 };
 
+function getSecondFactorRoute(method: SecondFactorMethod | undefined) {
+    switch (method) {
+        case SecondFactorMethod.WebAuthn:
+            return `${SecondFactorRoute}${SecondFactorWebAuthnSubRoute}`;
+        case SecondFactorMethod.MobilePush:
+            return `${SecondFactorRoute}${SecondFactorPushSubRoute}`;
+        default:
+            return `${SecondFactorRoute}${SecondFactorTOTPSubRoute}`;
+    }
+}
+
+// getDisplayedAuthenticationLevel returns the level the forms are displayed for, which is the level prior to the one
+// which must be performed again when the user is re-authenticating.
+function getDisplayedAuthenticationLevel(
+    reauthenticationLevel: AuthenticationLevel | undefined,
+    level: AuthenticationLevel | undefined,
+) {
+    switch (reauthenticationLevel) {
+        case AuthenticationLevel.OneFactor:
+            return AuthenticationLevel.Unauthenticated;
+        case AuthenticationLevel.TwoFactor:
+            return AuthenticationLevel.OneFactor;
+        default:
+            return level;
+    }
+}
+
+// isFirstFactorStale returns true if the first factor must be performed again before redirecting to the uri.
+async function isFirstFactorStale(uri: string) {
+    try {
+        const res = await checkSafeRedirection(uri);
+
+        return res !== undefined && res.ok && res.reauthentication_level === AuthenticationLevel.OneFactor;
+    } catch (err) {
+        return false;
+    }
+}
+
+// getStepUpMethod returns the preferred method if it satisfies the step up methods, otherwise the first method which
+// satisfies them.
+function getStepUpMethod(methods: string[], preferred: SecondFactorMethod | undefined) {
+    const satisfying = methods.map((method) => {
+        switch (method) {
+            case "totp":
+                return SecondFactorMethod.TOTP;
+            case "mobile_push":
+                return SecondFactorMethod.MobilePush;
+            default:
+                return SecondFactorMethod.WebAuthn;
+        }
+    });
+
+    if (preferred !== undefined && satisfying.includes(preferred)) {
+        return preferred;
+    }
+
+    return satisfying[0];
+}
+
 interface ComponentOrLoadingProps {
     ready: boolean;
 
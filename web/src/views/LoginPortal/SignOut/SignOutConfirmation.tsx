@@ -0,0 +1,127 @@
+import React, { useEffect, useState } from "react";
+
+import { Button, Grid, Theme, Typography } from "@mui/material";
+import makeStyles from "@mui/styles/makeStyles";
+import { useTranslation } from "react-i18next";
+import { useNavigate, useSearchParams } from "react-router-dom";
+
+import { IndexRoute } from "@constants/Routes";
+import { ClientID, PostLogoutRedirectURI, State } from "@constants/SearchParams";
+import { useNotifications } from "@hooks/NotificationsContext";
+import { useRedirector } from "@hooks/Redirector";
+import MinimalLayout from "@layouts/MinimalLayout";
+import {
+    LogoutGetResponseBody,
+    confirmOpenIDConnectLogout,
+    getOpenIDConnectLogout,
+} from "@services/OpenIDConnectLogout";
+
+export interface Props {}
+
+const SignOutConfirmation = function (props: Props) {
+    const { t: translate } = useTranslation();
+
+    const styles = useStyles();
+    const navigate = useNavigate();
+    const redirect = useRedirector();
+    const { createErrorNotification } = useNotifications();
+    const [searchParams] = useSearchParams();
+
+    const clientID = searchParams.get(ClientID);
+    const postLogoutRedirectURI = searchParams.get(PostLogoutRedirectURI);
+    const state = searchParams.get(State);
+
+    const [response, setResponse] = useState<LogoutGetResponseBody>();
+
+    useEffect(() => {
+        getOpenIDConnectLogout(clientID)
+            .then((r) => {
+                setResponse(r);
+            })
+            .catch((err) => {
+                console.error(err);
+                createErrorNotification(translate("There was an issue retrieving the logout request"));
+            });
+    }, [clientID, createErrorNotification, translate]);
+
+    const handleConfirm = async () => {
+        try {
+            const res = await confirmOpenIDConnectLogout(clientID, postLogoutRedirectURI, state);
+            if (res.redirect_uri) {
+                redirect(res.redirect_uri);
+            } else {
+                navigate(IndexRoute);
+            }
+        } catch (err) {
+            console.error(err);
+            createErrorNotification(translate("There was an issue signing out"));
+        }
+    };
+
+    const handleCancel = () => {
+        navigate(IndexRoute);
+    };
+
+    const client = response?.client_description || response?.client_id;
+
+    return (
+        <MinimalLayout id="logout-confirmation-stage" title={translate("Logout Request")}>
+            <Grid container>
+                {client ? (
+                    <Grid item xs={12}>
+                        <Typography className={styles.clientDescription}>{client}</Typography>
+                        <Typography className={styles.typo}>
+                            {translate("The above application is requesting that you sign out")}
+                        </Typography>
+                    </Grid>
+                ) : null}
+                <Grid item xs={12}>
+                    <Typography className={styles.typo}>{translate("Do you want to sign out")}?</Typography>
+                </Grid>
+                <Grid item xs={12}>
+                    <Grid container spacing={1}>
+                        <Grid item xs={6}>
+                            <Button
+                                id="logout-confirm-button"
+                                className={styles.button}
+                                disabled={!response}
+                                onClick={handleConfirm}
+                                color="primary"
+                                variant="contained"
+                            >
+                                {translate("Logout")}
+                            </Button>
+                        </Grid>
+                        <Grid item xs={6}>
+                            <Button
+                                id="logout-cancel-button"
+                                className={styles.button}
+                                onClick={handleCancel}
+                                color="secondary"
+                                variant="contained"
+                            >
+                                {translate("Cancel")}
+                            </Button>
+                        </Grid>
+                    </Grid>
+                </Grid>
+            </Grid>
+        </MinimalLayout>
+    );
+};
+
+export default SignOutConfirmation;
+
+const useStyles = makeStyles((theme: Theme) => ({
+    typo: {
+        padding: theme.spacing(),
+    },
+    clientDescription: {
+        fontWeight: 600,
+    },
+    button: {
+        marginLeft: theme.spacing(),
+        marginRight: theme.spacing(),
+        width: "100%",
+    },
+}));